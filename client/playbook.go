@@ -1,6 +1,10 @@
 package client
 
-import "gopkg.in/guregu/null.v4"
+import (
+	"encoding/json"
+
+	"gopkg.in/guregu/null.v4"
+)
 
 // Playbook represents the planning before a playbook run is initiated.
 type Playbook struct {
@@ -28,8 +32,19 @@ type Playbook struct {
 	WebhookOnCreationURLs                   []string               `json:"webhook_on_creation_urls"`
 	WebhookOnCreationEnabled                bool                   `json:"webhook_on_creation_enabled"`
 	Metrics                                 []PlaybookMetricConfig `json:"metrics"`
+	PropertyDefinitions                     []PropertyDefinition   `json:"property_definitions"`
 	CreateChannelMemberOnNewParticipant     bool                   `json:"create_channel_member_on_new_participant"`
 	RemoveChannelMemberOnRemovedParticipant bool                   `json:"remove_channel_member_on_removed_participant"`
+	ArchiveChannelOnFinishEnabled           bool                   `json:"archive_channel_on_finish_enabled"`
+	ArchiveChannelOnFinishDelayMinutes      int64                  `json:"archive_channel_on_finish_delay_minutes"`
+	ArchiveChannelSkipIfPosted              bool                   `json:"archive_channel_skip_if_posted"`
+	RetrospectiveEnabled                    bool                   `json:"retrospective_enabled"`
+	RetrospectiveRequired                   bool                   `json:"retrospective_required"`
+	ChecklistItemDependenciesEnforced       bool                   `json:"checklist_item_dependencies_enforced"`
+	AutoFinishEnabled                       bool                   `json:"auto_finish_enabled"`
+	IsTemplate                              bool                   `json:"is_template"`
+	TemplateSourceID                        string                 `json:"template_source_id"`
+	StatusUpdateTemplates                   []StatusUpdateTemplate `json:"status_update_templates"`
 }
 
 type PlaybookMember struct {
@@ -44,6 +59,17 @@ const (
 	MetricTypeInteger  = "metric_integer"
 )
 
+const (
+	MetricDirectionLowerIsBetter  = "lower"
+	MetricDirectionHigherIsBetter = "higher"
+)
+
+const (
+	PropertyTypeText   = "text"
+	PropertyTypeSelect = "select"
+	PropertyTypeNumber = "number"
+)
+
 // Checklist represents a checklist in a playbook
 type Checklist struct {
 	ID    string          `json:"id"`
@@ -53,17 +79,26 @@ type Checklist struct {
 
 // ChecklistItem represents an item in a checklist
 type ChecklistItem struct {
-	ID               string `json:"id"`
-	Title            string `json:"title"`
-	State            string `json:"state"`
-	StateModified    int64  `json:"state_modified"`
-	AssigneeID       string `json:"assignee_id"`
-	AssigneeModified int64  `json:"assignee_modified"`
-	Command          string `json:"command"`
-	CommandLastRun   int64  `json:"command_last_run"`
-	Description      string `json:"description"`
-	LastSkipped      int64  `json:"delete_at"`
-	DueDate          int64  `json:"due_date"`
+	ID                string `json:"id"`
+	Title             string `json:"title"`
+	State             string `json:"state"`
+	SkipReason        string `json:"skip_reason"`
+	StateModified     int64  `json:"state_modified"`
+	StateModifiedBy   string `json:"state_modified_by"`
+	AssigneeID        string `json:"assignee_id"`
+	AssigneeModified  int64  `json:"assignee_modified"`
+	DefaultAssigneeID string `json:"default_assignee_id"`
+	Command           string `json:"command"`
+	CommandLastRun    int64  `json:"command_last_run"`
+	CommandOutputID   string `json:"command_output_id"`
+	Description       string `json:"description"`
+	LastSkipped       int64  `json:"delete_at"`
+	DueDate           int64  `json:"due_date"`
+	DueOffset         int64  `json:"due_offset"`
+	Condition         string `json:"condition"`
+	Hidden            bool   `json:"hidden"`
+	DependsOnItemID   string `json:"depends_on_item_id"`
+	Blocked           bool   `json:"blocked"`
 }
 
 // PlaybookCreateOptions specifies the parameters for PlaybooksService.Create method.
@@ -86,8 +121,18 @@ type PlaybookCreateOptions struct {
 	BroadcastChannelIDs                     []string               `json:"broadcast_channel_ids"`
 	BroadcastEnabled                        bool                   `json:"broadcast_enabled"`
 	Metrics                                 []PlaybookMetricConfig `json:"metrics"`
+	PropertyDefinitions                     []PropertyDefinition   `json:"property_definitions"`
 	CreateChannelMemberOnNewParticipant     bool                   `json:"create_channel_member_on_new_participant"`
 	RemoveChannelMemberOnRemovedParticipant bool                   `json:"remove_channel_member_on_removed_participant"`
+	ArchiveChannelOnFinishEnabled           bool                   `json:"archive_channel_on_finish_enabled"`
+	ArchiveChannelOnFinishDelayMinutes      int64                  `json:"archive_channel_on_finish_delay_minutes"`
+	ArchiveChannelSkipIfPosted              bool                   `json:"archive_channel_skip_if_posted"`
+	RetrospectiveEnabled                    bool                   `json:"retrospective_enabled"`
+	RetrospectiveRequired                   bool                   `json:"retrospective_required"`
+	ChecklistItemDependenciesEnforced       bool                   `json:"checklist_item_dependencies_enforced"`
+	AutoFinishEnabled                       bool                   `json:"auto_finish_enabled"`
+	IsTemplate                              bool                   `json:"is_template"`
+	StatusUpdateTemplates                   []StatusUpdateTemplate `json:"status_update_templates"`
 }
 
 type PlaybookMetricConfig struct {
@@ -97,6 +142,53 @@ type PlaybookMetricConfig struct {
 	Description string   `json:"description"`
 	Type        string   `json:"type"`
 	Target      null.Int `json:"target"`
+
+	// Direction tells whether higher or lower values are better. Empty means lower is better.
+	Direction string `json:"direction"`
+}
+
+// PropertyDefinition is a custom field defined on a playbook, whose value is set for each run.
+type PropertyDefinition struct {
+	ID         string   `json:"id"`
+	PlaybookID string   `json:"playbook_id"`
+	Name       string   `json:"name"`
+	Type       string   `json:"type"`
+	Options    []string `json:"options"`
+}
+
+// StatusUpdateTemplate is a named template for the message of a status update.
+type StatusUpdateTemplate struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Template string `json:"template"`
+}
+
+// PlaybookVersion is a snapshot of a playbook as it was before one of its updates.
+type PlaybookVersion struct {
+	PlaybookID string `json:"playbook_id"`
+	Version    int64  `json:"version"`
+
+	// UserID is the user whose update replaced this version.
+	UserID string `json:"user_id"`
+
+	// CreateAt is when this version was replaced.
+	CreateAt int64 `json:"create_at"`
+
+	// Playbook is the snapshot. It is nil in the listings of versions.
+	Playbook *Playbook `json:"playbook,omitempty"`
+}
+
+// PlaybookFieldChange is a top-level field of a playbook whose value differs between two versions.
+type PlaybookFieldChange struct {
+	Field   string          `json:"field"`
+	Version json.RawMessage `json:"version"`
+	Current json.RawMessage `json:"current"`
+}
+
+// PlaybookVersionDiff is a past version of a playbook and its changes up to the current playbook.
+type PlaybookVersionDiff struct {
+	Version PlaybookVersion       `json:"version"`
+	Changes []PlaybookFieldChange `json:"changes"`
 }
 
 // PlaybookListOptions specifies the optional parameters to the
@@ -106,6 +198,9 @@ type PlaybookListOptions struct {
 	Direction    SortDirection `url:"direction,omitempty"`
 	SearchTeam   string        `url:"search_term,omitempty"`
 	WithArchived bool          `url:"with_archived,omitempty"`
+
+	// TemplatesOnly lists only templates, from every team unless a team is given.
+	TemplatesOnly bool `url:"templates_only,omitempty"`
 }
 
 type GetPlaybooksResults struct {
@@ -131,5 +226,10 @@ type PlaybookStats struct {
 	MetricRollingAverageChange    []null.Int `json:"metric_rolling_average_change"`
 	MetricValueRange              [][]int64  `json:"metric_value_range"`
 	MetricRollingValues           [][]int64  `json:"metric_rolling_values"`
+	MetricBreachingRuns           []null.Int `json:"metric_breaching_runs"`
+	RunsBreachingMetricTargets    int        `json:"runs_breaching_metric_targets"`
 	LastXRunNames                 []string   `json:"last_x_run_names"`
+	AverageRunDuration            null.Int   `json:"average_run_duration"`
+	RunsStartedPrev30Days         int        `json:"runs_started_prev_30_days"`
+	MeanTimeBetweenRunsPrev30Days null.Int   `json:"mean_time_between_runs_prev_30_days"`
 }
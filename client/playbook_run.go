@@ -12,49 +12,64 @@ const Me = "me"
 
 // PlaybookRun represents a playbook run.
 type PlaybookRun struct {
-	ID                                      string          `json:"id"`
-	Name                                    string          `json:"name"`
-	Summary                                 string          `json:"summary"`
-	OwnerUserID                             string          `json:"owner_user_id"`
-	ReporterUserID                          string          `json:"reporter_user_id"`
-	TeamID                                  string          `json:"team_id"`
-	ChannelID                               string          `json:"channel_id"`
-	CreateAt                                int64           `json:"create_at"`
-	EndAt                                   int64           `json:"end_at"`
-	DeleteAt                                int64           `json:"delete_at"`
-	ActiveStage                             int             `json:"active_stage"`
-	ActiveStageTitle                        string          `json:"active_stage_title"`
-	PostID                                  string          `json:"post_id"`
-	PlaybookID                              string          `json:"playbook_id"`
-	Checklists                              []Checklist     `json:"checklists"`
-	StatusPosts                             []StatusPost    `json:"status_posts"`
-	CurrentStatus                           string          `json:"current_status"`
-	LastStatusUpdateAt                      int64           `json:"last_status_update_at"`
-	ReminderPostID                          string          `json:"reminder_post_id"`
-	PreviousReminder                        time.Duration   `json:"previous_reminder"`
-	ReminderTimerDefaultSeconds             int64           `json:"reminder_timer_default_seconds"`
-	StatusUpdateEnabled                     bool            `json:"status_update_enabled"`
-	BroadcastChannelIDs                     []string        `json:"broadcast_channel_ids"`
-	WebhookOnStatusUpdateURLs               []string        `json:"webhook_on_status_update_urls"`
-	StatusUpdateBroadcastChannelsEnabled    bool            `json:"status_update_broadcast_channels_enabled"`
-	StatusUpdateBroadcastWebhooksEnabled    bool            `json:"status_update_broadcast_webhooks_enabled"`
-	ReminderMessageTemplate                 string          `json:"reminder_message_template"`
-	InvitedUserIDs                          []string        `json:"invited_user_ids"`
-	InvitedGroupIDs                         []string        `json:"invited_group_ids"`
-	TimelineEvents                          []TimelineEvent `json:"timeline_events"`
-	DefaultOwnerID                          string          `json:"default_owner_id"`
-	WebhookOnCreationURLs                   []string        `json:"webhook_on_creation_urls"`
-	Retrospective                           string          `json:"retrospective"`
-	RetrospectivePublishedAt                int64           `json:"retrospective_published_at"`
-	RetrospectiveWasCanceled                bool            `json:"retrospective_was_canceled"`
-	RetrospectiveReminderIntervalSeconds    int64           `json:"retrospective_reminder_interval_seconds"`
-	RetrospectiveEnabled                    bool            `json:"retrospective_enabled"`
-	MessageOnJoin                           string          `json:"message_on_join"`
-	ParticipantIDs                          []string        `json:"participant_ids"`
-	CategoryName                            string          `json:"category_name"`
-	MetricsData                             []RunMetricData `json:"metrics_data"`
-	CreateChannelMemberOnNewParticipant     bool            `json:"create_channel_member_on_new_participant"`
-	RemoveChannelMemberOnRemovedParticipant bool            `json:"remove_channel_member_on_removed_participant"`
+	ID                                      string                 `json:"id"`
+	Name                                    string                 `json:"name"`
+	Summary                                 string                 `json:"summary"`
+	OwnerUserID                             string                 `json:"owner_user_id"`
+	CoOwnerUserIDs                          []string               `json:"co_owner_user_ids"`
+	Tags                                    []string               `json:"tags"`
+	ReporterUserID                          string                 `json:"reporter_user_id"`
+	TeamID                                  string                 `json:"team_id"`
+	ChannelID                               string                 `json:"channel_id"`
+	CreateAt                                int64                  `json:"create_at"`
+	EndAt                                   int64                  `json:"end_at"`
+	PausedAt                                int64                  `json:"paused_at"`
+	PausedDuration                          int64                  `json:"paused_duration"`
+	DeleteAt                                int64                  `json:"delete_at"`
+	ActiveStage                             int                    `json:"active_stage"`
+	ActiveStageTitle                        string                 `json:"active_stage_title"`
+	PostID                                  string                 `json:"post_id"`
+	PlaybookID                              string                 `json:"playbook_id"`
+	Checklists                              []Checklist            `json:"checklists"`
+	StatusPosts                             []StatusPost           `json:"status_posts"`
+	CurrentStatus                           string                 `json:"current_status"`
+	LastStatusUpdateAt                      int64                  `json:"last_status_update_at"`
+	ReminderPostID                          string                 `json:"reminder_post_id"`
+	PreviousReminder                        time.Duration          `json:"previous_reminder"`
+	ReminderTimerDefaultSeconds             int64                  `json:"reminder_timer_default_seconds"`
+	StatusUpdateEnabled                     bool                   `json:"status_update_enabled"`
+	BroadcastChannelIDs                     []string               `json:"broadcast_channel_ids"`
+	WebhookOnStatusUpdateURLs               []string               `json:"webhook_on_status_update_urls"`
+	StatusUpdateBroadcastChannelsEnabled    bool                   `json:"status_update_broadcast_channels_enabled"`
+	StatusUpdateBroadcastWebhooksEnabled    bool                   `json:"status_update_broadcast_webhooks_enabled"`
+	ReminderMessageTemplate                 string                 `json:"reminder_message_template"`
+	InvitedUserIDs                          []string               `json:"invited_user_ids"`
+	InvitedGroupIDs                         []string               `json:"invited_group_ids"`
+	TimelineEvents                          []TimelineEvent        `json:"timeline_events"`
+	DefaultOwnerID                          string                 `json:"default_owner_id"`
+	WebhookOnCreationURLs                   []string               `json:"webhook_on_creation_urls"`
+	Retrospective                           string                 `json:"retrospective"`
+	RetrospectivePublishedAt                int64                  `json:"retrospective_published_at"`
+	RetrospectiveWasCanceled                bool                   `json:"retrospective_was_canceled"`
+	RetrospectiveReminderIntervalSeconds    int64                  `json:"retrospective_reminder_interval_seconds"`
+	RetrospectiveEnabled                    bool                   `json:"retrospective_enabled"`
+	MessageOnJoin                           string                 `json:"message_on_join"`
+	ParticipantIDs                          []string               `json:"participant_ids"`
+	ViewerIDs                               []string               `json:"viewer_ids"`
+	CategoryName                            string                 `json:"category_name"`
+	MetricsData                             []RunMetricData        `json:"metrics_data"`
+	PropertyValues                          []PropertyValue        `json:"property_values"`
+	CreateChannelMemberOnNewParticipant     bool                   `json:"create_channel_member_on_new_participant"`
+	RemoveChannelMemberOnRemovedParticipant bool                   `json:"remove_channel_member_on_removed_participant"`
+	ArchiveChannelOnFinishEnabled           bool                   `json:"archive_channel_on_finish_enabled"`
+	ArchiveChannelOnFinishDelayMinutes      int64                  `json:"archive_channel_on_finish_delay_minutes"`
+	ArchiveChannelSkipIfPosted              bool                   `json:"archive_channel_skip_if_posted"`
+	ChannelAutoArchiveAt                    int64                  `json:"channel_auto_archive_at"`
+	RetrospectiveRequired                   bool                   `json:"retrospective_required"`
+	ChecklistItemDependenciesEnforced       bool                   `json:"checklist_item_dependencies_enforced"`
+	AutoFinishEnabled                       bool                   `json:"auto_finish_enabled"`
+	MergedIntoRunID                         string                 `json:"merged_into_run_id"`
+	StatusUpdateTemplates                   []StatusUpdateTemplate `json:"status_update_templates"`
 }
 
 // StatusPost is information added to the playbook run when selecting from the db and sent to the
@@ -95,6 +110,8 @@ const (
 	StatusUpdated          TimelineEventType = "status_updated"
 	StatusUpdateRequested  TimelineEventType = "status_update_requested"
 	OwnerChanged           TimelineEventType = "owner_changed"
+	CoOwnerAdded           TimelineEventType = "co_owner_added"
+	CoOwnerRemoved         TimelineEventType = "co_owner_removed"
 	AssigneeChanged        TimelineEventType = "assignee_changed"
 	RanSlashCommand        TimelineEventType = "ran_slash_command"
 	EventFromPost          TimelineEventType = "event_from_post"
@@ -103,8 +120,14 @@ const (
 	CanceledRetrospective  TimelineEventType = "canceled_retrospective"
 	RunFinished            TimelineEventType = "run_finished"
 	RunRestored            TimelineEventType = "run_restored"
+	RunPaused              TimelineEventType = "run_paused"
+	RunResumed             TimelineEventType = "run_resumed"
+	RunCloned              TimelineEventType = "run_cloned"
+	RunMerged              TimelineEventType = "run_merged"
+	ParticipantRoleChanged TimelineEventType = "participant_role_changed"
 	StatusUpdatesEnabled   TimelineEventType = "status_updates_enabled"
 	StatusUpdatesDisabled  TimelineEventType = "status_updates_disabled"
+	KeyEvent               TimelineEventType = "key_event"
 )
 
 // TimelineEvent represents an event recorded to a playbook run's timeline.
@@ -120,8 +143,25 @@ type TimelineEvent struct {
 	PostID        string            `json:"post_id"`
 	SubjectUserID string            `json:"subject_user_id"`
 	CreatorUserID string            `json:"creator_user_id"`
+	UserCreated   bool              `json:"user_created"`
 }
 
+// KeyEventOptions specifies the parameters for PlaybookRunService.AddKeyEvent method.
+type KeyEventOptions struct {
+	Summary string `json:"summary"`
+	Details string `json:"details"`
+
+	// PostID, if not empty, links the event to a post of the run's channel.
+	PostID string `json:"post_id"`
+}
+
+// Channel modes of a new run: whether a channel is created for it, or it's attached to an
+// existing channel.
+const (
+	PlaybookRunCreateNewChannel    = "create_new_channel"
+	PlaybookRunLinkExistingChannel = "link_existing_channel"
+)
+
 // PlaybookRunCreateOptions specifies the parameters for PlaybookRunService.Create method.
 type PlaybookRunCreateOptions struct {
 	Name        string `json:"name"`
@@ -131,6 +171,17 @@ type PlaybookRunCreateOptions struct {
 	Description string `json:"description"`
 	PostID      string `json:"post_id"`
 	PlaybookID  string `json:"playbook_id"`
+
+	// ChannelMode, if set, overrides the channel mode of the playbook. With
+	// PlaybookRunLinkExistingChannel the run is attached to ChannelID.
+	ChannelMode string `json:"channel_mode,omitempty"`
+
+	// AllowMultipleRuns allows attaching the run to a channel that already has an unfinished run.
+	AllowMultipleRuns bool `json:"allow_multiple_runs,omitempty"`
+
+	// IdempotencyKey, if set, makes retrying the creation safe: a repeated request with the same
+	// key returns the run created by the first one instead of creating another.
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
 }
 
 // RunAction represents the run action settings. Frontend passes this struct to update settings.
@@ -215,6 +266,9 @@ type PlaybookRunListOptions struct {
 	// ParticipantOrFollowerID filters playbook runs that have this user as member or as follower. Defaults to blank (no filter). Specify "me" for current user.
 	ParticipantOrFollowerID string `url:"participant_or_follower,omitempty"`
 
+	// AssigneeID filters playbook runs that have at least one open checklist item assigned to this user. Defaults to blank (no filter). Specify "me" for current user.
+	AssigneeID string `url:"assignee_id,omitempty"`
+
 	// SearchTerm returns results of the search term and respecting the other header filter options.
 	// The search term acts as a filter and respects the Sort and Direction fields (i.e., results are
 	// not returned in relevance order).
@@ -239,6 +293,14 @@ type PlaybookRunListOptions struct {
 	// StartedLT filters playbook runs that were started before the unix time given (in millis).
 	// A value of 0 means the filter is ignored (which is the default).
 	StartedLT int64 `url:"started_lt,omitempty"`
+
+	// Properties filters playbook runs that have every given property set to the given value,
+	// each formatted as "<property definition id>:<value>".
+	Properties []string `url:"property,omitempty"`
+
+	// Tags filters playbook runs that have any of the given tags, or all of them if TagsMatchAll.
+	Tags         []string `url:"tags,omitempty"`
+	TagsMatchAll bool     `url:"tags_match_all,omitempty"`
 }
 
 // PlaybookRunList contains the paginated result.
@@ -254,7 +316,20 @@ type Status string
 
 const (
 	StatusInProgress Status = "InProgress"
+	StatusPaused     Status = "Paused"
 	StatusFinished   Status = "Finished"
+	StatusMerged     Status = "Merged"
+)
+
+// ParticipantRole is what a participant can do in a playbook run.
+type ParticipantRole string
+
+const (
+	// ParticipantRoleActive participants can change the checklists, status and owners of the run.
+	ParticipantRoleActive ParticipantRole = "active"
+
+	// ParticipantRoleViewer participants can only follow the run along.
+	ParticipantRoleViewer ParticipantRole = "viewer"
 )
 
 type GetPlaybookRunsResults struct {
@@ -264,16 +339,286 @@ type GetPlaybookRunsResults struct {
 	Items      []PlaybookRun `json:"items"`
 }
 
+// PlaybookRunCloneOptions specifies the parameters to the PlaybookRunService.Clone method.
+type PlaybookRunCloneOptions struct {
+	// CopyParticipants, if true, invites the participants of the source run to the new run.
+	CopyParticipants bool `url:"copy_participants,omitempty"`
+
+	// PreserveState, if true, keeps the state of the checklist items of the source run.
+	// Otherwise every item of the new run starts open.
+	PreserveState bool `url:"preserve_state,omitempty"`
+}
+
+// StatusUpdateSearchOptions specifies the parameters to the PlaybookRunService.SearchStatusUpdates method.
+type StatusUpdateSearchOptions struct {
+	// TeamID limits the search to the runs of this team.
+	TeamID string `url:"team_id,omitempty"`
+
+	// Term is the text to search for in the status updates.
+	Term string `url:"term"`
+}
+
+// StatusUpdateSearchResult is a status update matching a search, along with the run it was posted to.
+type StatusUpdateSearchResult struct {
+	PlaybookRunID   string `json:"playbook_run_id"`
+	PlaybookRunName string `json:"playbook_run_name"`
+	PostID          string `json:"post_id"`
+	Snippet         string `json:"snippet"`
+	CreateAt        int64  `json:"create_at"`
+}
+
+type SearchStatusUpdatesResults struct {
+	TotalCount int                        `json:"total_count"`
+	PageCount  int                        `json:"page_count"`
+	HasMore    bool                       `json:"has_more"`
+	Items      []StatusUpdateSearchResult `json:"items"`
+}
+
+// StaleRunsOptions specifies the parameters to the PlaybookRunService.GetStale method.
+type StaleRunsOptions struct {
+	// TeamID limits the runs to this team.
+	TeamID string `url:"team_id,omitempty"`
+
+	// OlderThan is how long a run goes without activity before it is stale, such as 72h. The
+	// server defaults to a day.
+	OlderThan string `url:"olderThan,omitempty"`
+}
+
+// SavedFilter is a named preset of the filters of the run list of a team. It is private to its
+// creator unless it is shared with the team.
+type SavedFilter struct {
+	ID       string             `json:"id"`
+	Name     string             `json:"name"`
+	TeamID   string             `json:"team_id"`
+	UserID   string             `json:"user_id"`
+	Shared   bool               `json:"shared"`
+	Options  SavedFilterOptions `json:"options"`
+	CreateAt int64              `json:"create_at"`
+	UpdateAt int64              `json:"update_at"`
+}
+
+// SavedFilterOptions are the filters of the run list kept by a saved filter. Empty options don't
+// filter.
+type SavedFilterOptions struct {
+	OwnerID      string        `json:"owner_user_id"`
+	Statuses     []Status      `json:"statuses"`
+	PlaybookID   string        `json:"playbook_id"`
+	Tags         []string      `json:"tags"`
+	TagsMatchAll bool          `json:"tags_match_all"`
+	AssigneeID   string        `json:"assignee_id"`
+	Sort         Sort          `json:"sort"`
+	Direction    SortDirection `json:"direction"`
+}
+
+// StaleRun is an in-progress run without activity for longer than a threshold.
+type StaleRun struct {
+	ID                 string `json:"id"`
+	Name               string `json:"name"`
+	TeamID             string `json:"team_id"`
+	ChannelID          string `json:"channel_id"`
+	OwnerUserID        string `json:"owner_user_id"`
+	PlaybookID         string `json:"playbook_id"`
+	CreateAt           int64  `json:"create_at"`
+	LastStatusUpdateAt int64  `json:"last_status_update_at"`
+	LastActivityAt     int64  `json:"last_activity_at"`
+}
+
+type GetStaleRunsResults struct {
+	TotalCount int        `json:"total_count"`
+	PageCount  int        `json:"page_count"`
+	HasMore    bool       `json:"has_more"`
+	Items      []StaleRun `json:"items"`
+}
+
+// MaxPlaybookRunsBatch is the maximum number of runs PlaybookRunService.GetBatch fetches at once.
+const MaxPlaybookRunsBatch = 100
+
+// PlaybookRunsBatchResult holds the runs fetched by PlaybookRunService.GetBatch.
+type PlaybookRunsBatchResult struct {
+	// Items are the requested runs the user can view, in the order they were requested.
+	Items []PlaybookRun `json:"items"`
+
+	// OmittedRunIDs are the requested runs that don't exist or that the user can't view.
+	OmittedRunIDs []string `json:"omitted_run_ids"`
+}
+
+// ReassignTasksOptions specifies the parameters to the PlaybookRunService.ReassignTasks method.
+type ReassignTasksOptions struct {
+	FromUserID string `json:"from_user_id"`
+	ToUserID   string `json:"to_user_id"`
+
+	// TeamID, if not empty, limits the reassignment to the runs of this team.
+	TeamID string `json:"team_id,omitempty"`
+
+	// PlaybookRunIDs, if not empty, limits the reassignment to these runs.
+	PlaybookRunIDs []string `json:"playbook_run_ids,omitempty"`
+}
+
+// ReassignTasksResult summarizes the changes of PlaybookRunService.ReassignTasks.
+type ReassignTasksResult struct {
+	ItemsReassigned int      `json:"items_reassigned"`
+	RunsChanged     int      `json:"runs_changed"`
+	SkippedRunIDs   []string `json:"skipped_run_ids"`
+}
+
+// AddChecklistItemsFromPostsOptions specifies the parameters to the
+// PlaybookRunService.AddChecklistItemsFromPosts method: either the posts of the run channel to
+// add, in order, or the number of most recent posts to add.
+type AddChecklistItemsFromPostsOptions struct {
+	PostIDs     []string `json:"post_ids,omitempty"`
+	RecentPosts int      `json:"recent_posts,omitempty"`
+}
+
+// AddChecklistItemsFromPostsResult summarizes the changes of PlaybookRunService.AddChecklistItemsFromPosts.
+type AddChecklistItemsFromPostsResult struct {
+	ItemsAdded   int `json:"items_added"`
+	PostsSkipped int `json:"posts_skipped"`
+}
+
+// RunActivityType is the kind of the entry of a playbook run's activity feed.
+type RunActivityType string
+
+const (
+	RunActivityTimelineEvent          RunActivityType = "timeline_event"
+	RunActivityStatusUpdate           RunActivityType = "status_update"
+	RunActivityChecklistItemCompleted RunActivityType = "checklist_item_completed"
+)
+
+// RunActivity is an entry of the activity feed of a playbook run. Exactly one of TimelineEvent,
+// StatusUpdate and ChecklistItem is set, depending on Type.
+type RunActivity struct {
+	ID             string          `json:"id"`
+	Type           RunActivityType `json:"type"`
+	At             int64           `json:"at"`
+	UserID         string          `json:"user_id"`
+	TimelineEvent  *TimelineEvent  `json:"timeline_event,omitempty"`
+	StatusUpdate   *StatusPost     `json:"status_update,omitempty"`
+	ChecklistItem  *ChecklistItem  `json:"checklist_item,omitempty"`
+	ChecklistTitle string          `json:"checklist_title,omitempty"`
+}
+
+// RunActivityOptions specifies the parameters to the PlaybookRunService.GetActivity method.
+type RunActivityOptions struct {
+	// Cursor, if not empty, is the NextCursor of the previous page.
+	Cursor string `url:"cursor,omitempty"`
+
+	PerPage int `url:"per_page,omitempty"`
+}
+
+// RunActivityResults is a page of a playbook run's activity feed, oldest first.
+type RunActivityResults struct {
+	Items      []RunActivity `json:"items"`
+	HasMore    bool          `json:"has_more"`
+	NextCursor string        `json:"next_cursor"`
+}
+
 // StatusUpdateOptions are the fields required to update a playbook run's status
 type StatusUpdateOptions struct {
 	Message   string        `json:"message"`
 	Reminder  time.Duration `json:"reminder"`
 	FinishRun bool          `json:"finish_run"`
+
+	// TemplateID is the status update template of the run to use when Message is empty.
+	TemplateID string `json:"template_id"`
+}
+
+// BroadcastResult is the outcome of broadcasting a status update to one of the broadcast channels
+// of a run.
+type BroadcastResult struct {
+	ChannelID string `json:"channel_id"`
+
+	// PostID is the identifier of the post made in the channel. Empty if the broadcast failed.
+	PostID string `json:"post_id"`
+
+	// Error is why the broadcast to the channel failed. Empty if it succeeded.
+	Error string `json:"error,omitempty"`
+}
+
+// PropertyValue is the value of a property, defined on the playbook, for a run.
+type PropertyValue struct {
+	PropertyDefinitionID string `json:"property_definition_id"`
+	Name                 string `json:"name"`
+	Value                string `json:"value"`
+}
+
+// PlaybookRunDryRun is what the creation of a playbook run would do.
+type PlaybookRunDryRun struct {
+	// Valid is false if the creation would fail, as explained by ValidationError.
+	Valid           bool                `json:"valid"`
+	ValidationError *RunValidationError `json:"validation_error"`
+
+	// PlaybookRun is the run that would be created. It has no ID.
+	PlaybookRun *PlaybookRun `json:"playbook_run"`
+
+	CreateChannel bool   `json:"create_channel"`
+	ChannelName   string `json:"channel_name"`
+
+	// ChannelNameTaken is true if random characters would be appended to ChannelName, as a
+	// channel of the team already has it.
+	ChannelNameTaken bool `json:"channel_name_taken"`
+	Public           bool `json:"public"`
+}
+
+// RunValidationError explains why the creation of a playbook run would fail.
+type RunValidationError struct {
+	Code       string `json:"code"`
+	StatusCode int    `json:"status_code"`
+	Message    string `json:"message"`
+}
+
+// CommandOutputStatus tells whether the slash command of a checklist item worked.
+type CommandOutputStatus string
+
+const (
+	CommandOutputSucceeded CommandOutputStatus = "succeeded"
+	CommandOutputFailed    CommandOutputStatus = "failed"
+)
+
+// CommandOutput is what the slash command of a checklist item responded the last time it was run
+// from the run.
+type CommandOutput struct {
+	ID              string              `json:"id"`
+	PlaybookRunID   string              `json:"playbook_run_id"`
+	ChecklistItemID string              `json:"checklist_item_id"`
+	Command         string              `json:"command"`
+	UserID          string              `json:"user_id"`
+	Status          CommandOutputStatus `json:"status"`
+	Output          string              `json:"output"`
+	Truncated       bool                `json:"truncated"`
+	CreateAt        int64               `json:"create_at"`
 }
 
 type RunMetricData struct {
 	MetricConfigID string   `json:"metric_config_id"`
 	Value          null.Int `json:"value"`
+
+	// Breached is true if Value misses the target of the metric.
+	Breached bool `json:"breached"`
+}
+
+// RunUserAutocompleteOptions specifies the parameters to the PlaybookRunService.AutocompleteUsers
+// method.
+type RunUserAutocompleteOptions struct {
+	// Prefix is the beginning of the username, first name, last name or nickname of the users.
+	Prefix string `url:"q,omitempty"`
+
+	// Limit is the maximum number of users returned. The server default is used if 0.
+	Limit int `url:"limit,omitempty"`
+}
+
+// RunUserAutocompleteResult is a user of the team of a run matching an autocomplete search.
+type RunUserAutocompleteResult struct {
+	UserID    string `json:"user_id"`
+	Username  string `json:"username"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Nickname  string `json:"nickname"`
+
+	// DisplayName is the name of the user formatted per the name display setting of the server.
+	DisplayName string `json:"display_name"`
+
+	// InChannel is true if the user is a member of the channel of the run.
+	InChannel bool `json:"in_channel"`
 }
 
 // OwnerInfo holds the summary information of a owner.
@@ -284,3 +629,36 @@ type OwnerInfo struct {
 	LastName  string `json:"last_name"`
 	Nickname  string `json:"nickname"`
 }
+
+// UserTaskLoad is the work a user has across the active runs of a team.
+type UserTaskLoad struct {
+	UserID string `json:"user_id"`
+
+	// IncompleteItems is the number of checklist items assigned to the user that are not done or
+	// skipped yet.
+	IncompleteItems int `json:"incomplete_items"`
+
+	// OwnedRuns is the number of active runs owned by the user.
+	OwnedRuns int `json:"owned_runs"`
+}
+
+// TaskLoadSort is the order of the users in a task load.
+type TaskLoadSort string
+
+const (
+	// TaskLoadSortByLoad sorts the users by decreasing number of incomplete items, then owned runs.
+	TaskLoadSortByLoad TaskLoadSort = "load"
+
+	// TaskLoadSortByOwnedRuns sorts the users by decreasing number of owned runs, then incomplete
+	// items.
+	TaskLoadSortByOwnedRuns TaskLoadSort = "owned_runs"
+)
+
+// TaskLoadOptions selects the users of a task load.
+type TaskLoadOptions struct {
+	// TeamID is the team whose runs are counted. Required.
+	TeamID string `url:"team_id"`
+
+	Sort  TaskLoadSort `url:"sort,omitempty"`
+	Limit int          `url:"limit,omitempty"`
+}
@@ -4,9 +4,12 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"time"
 )
 
@@ -88,6 +91,63 @@ func (s *PlaybookRunService) GetStatusUpdates(ctx context.Context, playbookRunID
 	return statusUpdates, nil
 }
 
+// Export a playbook run in the given format, e.g. "md" for Markdown.
+func (s *PlaybookRunService) Export(ctx context.Context, playbookRunID, format string) ([]byte, error) {
+	exportURL := fmt.Sprintf("runs/%s/export?format=%s", playbookRunID, url.QueryEscape(format))
+	req, err := s.client.newRequest(http.MethodGet, exportURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var export bytes.Buffer
+	resp, err := s.client.do(ctx, req, &export)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+
+	return export.Bytes(), nil
+}
+
+// ExportCSV streams the runs of teamID visible to the user as CSV into w.
+func (s *PlaybookRunService) ExportCSV(ctx context.Context, teamID string, w io.Writer) error {
+	exportURL := fmt.Sprintf("runs/export?team_id=%s", url.QueryEscape(teamID))
+	req, err := s.client.newRequest(http.MethodGet, exportURL, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.client.do(ctx, req, w)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// GetBatch gets the playbook runs with the given IDs in a single request, up to
+// MaxPlaybookRunsBatch at once. The runs that don't exist or that the user can't view are
+// reported in the OmittedRunIDs of the result.
+func (s *PlaybookRunService) GetBatch(ctx context.Context, playbookRunIDs []string) (*PlaybookRunsBatchResult, error) {
+	body := struct {
+		PlaybookRunIDs []string `json:"playbook_run_ids"`
+	}{playbookRunIDs}
+	req, err := s.client.newRequest(http.MethodPost, "runs/batch", body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	result := &PlaybookRunsBatchResult{}
+	resp, err := s.client.do(ctx, req, result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	resp.Body.Close()
+
+	return result, nil
+}
+
 // List the playbook runs.
 func (s *PlaybookRunService) List(ctx context.Context, page, perPage int, opts PlaybookRunListOptions) (*GetPlaybookRunsResults, error) {
 	playbookRunURL := "runs"
@@ -115,6 +175,98 @@ func (s *PlaybookRunService) List(ctx context.Context, page, perPage int, opts P
 	return result, nil
 }
 
+// SearchStatusUpdates returns the status updates matching opts.Term, newest first.
+func (s *PlaybookRunService) SearchStatusUpdates(ctx context.Context, page, perPage int, opts StatusUpdateSearchOptions) (*SearchStatusUpdatesResults, error) {
+	searchURL := "runs/status-updates/search"
+	searchURL, err := addOptions(searchURL, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build options: %w", err)
+	}
+	searchURL, err = addPaginationOptions(searchURL, page, perPage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build pagination options: %w", err)
+	}
+
+	req, err := s.client.newRequest(http.MethodGet, searchURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	result := &SearchStatusUpdatesResults{}
+	resp, err := s.client.do(ctx, req, result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	resp.Body.Close()
+
+	return result, nil
+}
+
+// GetStale returns the in-progress runs without status updates, checklist item changes or
+// participant changes for longer than opts.OlderThan, stalest first.
+func (s *PlaybookRunService) GetStale(ctx context.Context, page, perPage int, opts StaleRunsOptions) (*GetStaleRunsResults, error) {
+	staleURL := "runs/stale"
+	staleURL, err := addOptions(staleURL, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build options: %w", err)
+	}
+	staleURL, err = addPaginationOptions(staleURL, page, perPage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build pagination options: %w", err)
+	}
+
+	req, err := s.client.newRequest(http.MethodGet, staleURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	result := &GetStaleRunsResults{}
+	resp, err := s.client.do(ctx, req, result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	resp.Body.Close()
+
+	return result, nil
+}
+
+// ReassignTasks assigns the checklist items of opts.FromUserID that are not done or skipped to
+// opts.ToUserID, in the unfinished runs the caller can manage. Other runs are reported as skipped.
+func (s *PlaybookRunService) ReassignTasks(ctx context.Context, opts ReassignTasksOptions) (*ReassignTasksResult, error) {
+	req, err := s.client.newRequest(http.MethodPost, "runs/reassign", opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	result := &ReassignTasksResult{}
+	resp, err := s.client.do(ctx, req, result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	resp.Body.Close()
+
+	return result, nil
+}
+
+// AddChecklistItemsFromPosts appends a checklist item for each of the given posts of the run
+// channel to the checklist.
+func (s *PlaybookRunService) AddChecklistItemsFromPosts(ctx context.Context, playbookRunID string, checklistNumber int, opts AddChecklistItemsFromPostsOptions) (*AddChecklistItemsFromPostsResult, error) {
+	addURL := fmt.Sprintf("runs/%s/checklists/%d/add-from-posts", playbookRunID, checklistNumber)
+	req, err := s.client.newRequest(http.MethodPost, addURL, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	result := &AddChecklistItemsFromPostsResult{}
+	resp, err := s.client.do(ctx, req, result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	resp.Body.Close()
+
+	return result, nil
+}
+
 // Create a playbook run.
 func (s *PlaybookRunService) Create(ctx context.Context, opts PlaybookRunCreateOptions) (*PlaybookRun, error) {
 	playbookRunURL := "runs"
@@ -130,13 +282,32 @@ func (s *PlaybookRunService) Create(ctx context.Context, opts PlaybookRunCreateO
 	}
 	resp.Body.Close()
 
-	if resp.StatusCode != http.StatusCreated {
+	// A repeated request with the same idempotency key returns the existing run.
+	if resp.StatusCode != http.StatusCreated && !(opts.IdempotencyKey != "" && resp.StatusCode == http.StatusOK) {
 		return nil, fmt.Errorf("expected status code %d", http.StatusCreated)
 	}
 
 	return playbookRun, nil
 }
 
+// DryRunCreate validates the creation of a playbook run without creating anything, returning
+// the run that would be created or why the creation would fail.
+func (s *PlaybookRunService) DryRunCreate(ctx context.Context, opts PlaybookRunCreateOptions) (*PlaybookRunDryRun, error) {
+	req, err := s.client.newRequest(http.MethodPost, "runs?dryRun=true", opts)
+	if err != nil {
+		return nil, err
+	}
+
+	dryRun := new(PlaybookRunDryRun)
+	resp, err := s.client.do(ctx, req, dryRun)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+
+	return dryRun, nil
+}
+
 func (s *PlaybookRunService) UpdateStatus(ctx context.Context, playbookRunID string, message string, reminderInSeconds int64) error {
 	updateURL := fmt.Sprintf("runs/%s/status", playbookRunID)
 	opts := StatusUpdateOptions{
@@ -160,6 +331,54 @@ func (s *PlaybookRunService) UpdateStatus(ctx context.Context, playbookRunID str
 	return nil
 }
 
+// UpdateStatusFromTemplate posts a status update using the status update template templateID of
+// the run. An empty message posts the text of the template.
+func (s *PlaybookRunService) UpdateStatusFromTemplate(ctx context.Context, playbookRunID, templateID, message string, reminderInSeconds int64) error {
+	updateURL := fmt.Sprintf("runs/%s/status", playbookRunID)
+	opts := StatusUpdateOptions{
+		Message:    message,
+		Reminder:   time.Duration(reminderInSeconds),
+		TemplateID: templateID,
+	}
+	req, err := s.client.newRequest(http.MethodPost, updateURL, opts)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.client.do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("expected status code %d", http.StatusOK)
+	}
+
+	return nil
+}
+
+// RetryStatusUpdateBroadcast broadcasts the latest status update of a run again to channelIDs,
+// which must be broadcast channels of the run.
+func (s *PlaybookRunService) RetryStatusUpdateBroadcast(ctx context.Context, playbookRunID string, channelIDs []string) ([]BroadcastResult, error) {
+	broadcastURL := fmt.Sprintf("runs/%s/status/broadcast", playbookRunID)
+	body := struct {
+		ChannelIDs []string `json:"channel_ids"`
+	}{channelIDs}
+
+	req, err := s.client.newRequest(http.MethodPost, broadcastURL, body)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []BroadcastResult{}
+	_, err = s.client.do(ctx, req, &results)
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
 func (s *PlaybookRunService) RequestUpdate(ctx context.Context, playbookRunID, userID string) error {
 	requestURL := fmt.Sprintf("runs/%s/request-update", playbookRunID)
 	req, err := s.client.newRequest(http.MethodPost, requestURL, nil)
@@ -190,6 +409,459 @@ func (s *PlaybookRunService) Finish(ctx context.Context, playbookRunID string) e
 	return nil
 }
 
+// Restart moves a finished playbook run back to in progress, unarchiving its channel if needed.
+func (s *PlaybookRunService) Restart(ctx context.Context, playbookRunID string) error {
+	restartURL := fmt.Sprintf("runs/%s/restart", playbookRunID)
+	req, err := s.client.newRequest(http.MethodPost, restartURL, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Clone creates a new playbook run, with a new channel, from the checklists, owner and
+// broadcast settings of playbookRunID.
+func (s *PlaybookRunService) Clone(ctx context.Context, playbookRunID string, opts PlaybookRunCloneOptions) (*PlaybookRun, error) {
+	cloneURL := fmt.Sprintf("runs/%s/clone", playbookRunID)
+	cloneURL, err := addOptions(cloneURL, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := s.client.newRequest(http.MethodPost, cloneURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	playbookRun := new(PlaybookRun)
+	resp, err := s.client.do(ctx, req, playbookRun)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("expected status code %d", http.StatusCreated)
+	}
+
+	return playbookRun, nil
+}
+
+// Merge merges the run sourceRunID into playbookRunID, appending its checklists and adding its
+// participants, tags and status updates. The source run ends as merged.
+func (s *PlaybookRunService) Merge(ctx context.Context, playbookRunID, sourceRunID string) (*PlaybookRun, error) {
+	mergeURL := fmt.Sprintf("runs/%s/merge", playbookRunID)
+	body := struct {
+		SourceRunID string `json:"source_run_id"`
+	}{sourceRunID}
+
+	req, err := s.client.newRequest(http.MethodPost, mergeURL, body)
+	if err != nil {
+		return nil, err
+	}
+
+	playbookRun := new(PlaybookRun)
+	_, err = s.client.do(ctx, req, playbookRun)
+	if err != nil {
+		return nil, err
+	}
+
+	return playbookRun, nil
+}
+
+// Pause pauses a playbook run. Paused time does not count towards the run's duration.
+func (s *PlaybookRunService) Pause(ctx context.Context, playbookRunID string) error {
+	pauseURL := fmt.Sprintf("runs/%s/pause", playbookRunID)
+	req, err := s.client.newRequest(http.MethodPut, pauseURL, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Resume resumes a paused playbook run.
+func (s *PlaybookRunService) Resume(ctx context.Context, playbookRunID string) error {
+	resumeURL := fmt.Sprintf("runs/%s/resume", playbookRunID)
+	req, err := s.client.newRequest(http.MethodPut, resumeURL, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// AddCoOwner makes userID a co-owner of a playbook run.
+func (s *PlaybookRunService) AddCoOwner(ctx context.Context, playbookRunID string, userID string) error {
+	addURL := fmt.Sprintf("runs/%s/co-owners", playbookRunID)
+	body := struct {
+		UserID string `json:"user_id"`
+	}{userID}
+	req, err := s.client.newRequest(http.MethodPost, addURL, body)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RemoveCoOwner removes userID from the co-owners of a playbook run.
+func (s *PlaybookRunService) RemoveCoOwner(ctx context.Context, playbookRunID string, userID string) error {
+	removeURL := fmt.Sprintf("runs/%s/co-owners/%s", playbookRunID, userID)
+	req, err := s.client.newRequest(http.MethodDelete, removeURL, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SetParticipantRole makes a participant of a playbook run an active participant or a viewer.
+func (s *PlaybookRunService) SetParticipantRole(ctx context.Context, playbookRunID, userID string, role ParticipantRole) error {
+	roleURL := fmt.Sprintf("runs/%s/participants/%s/role", playbookRunID, userID)
+	body := struct {
+		Role ParticipantRole `json:"role"`
+	}{role}
+	req, err := s.client.newRequest(http.MethodPut, roleURL, body)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// AddTags tags a playbook run. Tags are trimmed and lowercased.
+func (s *PlaybookRunService) AddTags(ctx context.Context, playbookRunID string, tags []string) error {
+	addURL := fmt.Sprintf("runs/%s/tags", playbookRunID)
+	body := struct {
+		Tags []string `json:"tags"`
+	}{tags}
+	req, err := s.client.newRequest(http.MethodPost, addURL, body)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RemoveTag removes a tag from a playbook run.
+func (s *PlaybookRunService) RemoveTag(ctx context.Context, playbookRunID string, tag string) error {
+	removeURL := fmt.Sprintf("runs/%s/tags/%s", playbookRunID, url.PathEscape(tag))
+	req, err := s.client.newRequest(http.MethodDelete, removeURL, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetTags suggests the tags used by the runs of a team that start with prefix, most used first.
+func (s *PlaybookRunService) GetTags(ctx context.Context, teamID, prefix string) ([]string, error) {
+	tagsURL := fmt.Sprintf("runs/tags?team_id=%s&prefix=%s", url.QueryEscape(teamID), url.QueryEscape(prefix))
+	req, err := s.client.newRequest(http.MethodGet, tagsURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := []string{}
+	_, err = s.client.do(ctx, req, &tags)
+	if err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// ListSavedFilters returns the saved filters of the run list of a team that the user can see:
+// their own and those shared with the team.
+func (s *PlaybookRunService) ListSavedFilters(ctx context.Context, teamID string) ([]SavedFilter, error) {
+	filtersURL := fmt.Sprintf("runs/saved-filters?team_id=%s", url.QueryEscape(teamID))
+	req, err := s.client.newRequest(http.MethodGet, filtersURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	filters := []SavedFilter{}
+	resp, err := s.client.do(ctx, req, &filters)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+
+	return filters, nil
+}
+
+// CreateSavedFilter saves a new filter of the run list of filter.TeamID.
+func (s *PlaybookRunService) CreateSavedFilter(ctx context.Context, filter SavedFilter) (*SavedFilter, error) {
+	req, err := s.client.newRequest(http.MethodPost, "runs/saved-filters", filter)
+	if err != nil {
+		return nil, err
+	}
+
+	created := new(SavedFilter)
+	resp, err := s.client.do(ctx, req, created)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("expected status code %d", http.StatusCreated)
+	}
+
+	return created, nil
+}
+
+// UpdateSavedFilter updates the name, sharing and options of a saved filter.
+func (s *PlaybookRunService) UpdateSavedFilter(ctx context.Context, filter SavedFilter) (*SavedFilter, error) {
+	filterURL := fmt.Sprintf("runs/saved-filters/%s", filter.ID)
+	req, err := s.client.newRequest(http.MethodPut, filterURL, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := new(SavedFilter)
+	resp, err := s.client.do(ctx, req, updated)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+
+	return updated, nil
+}
+
+// DeleteSavedFilter deletes a saved filter.
+func (s *PlaybookRunService) DeleteSavedFilter(ctx context.Context, savedFilterID string) error {
+	filterURL := fmt.Sprintf("runs/saved-filters/%s", savedFilterID)
+	req, err := s.client.newRequest(http.MethodDelete, filterURL, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ListWithSavedFilter lists the playbook runs matching a saved filter. The playbook and users
+// the filter refers to that no longer exist are ignored.
+func (s *PlaybookRunService) ListWithSavedFilter(ctx context.Context, savedFilterID string, page, perPage int) (*GetPlaybookRunsResults, error) {
+	runsURL, err := addPaginationOptions(fmt.Sprintf("runs/saved-filters/%s/runs", savedFilterID), page, perPage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build pagination options: %w", err)
+	}
+
+	req, err := s.client.newRequest(http.MethodGet, runsURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	result := &GetPlaybookRunsResults{}
+	resp, err := s.client.do(ctx, req, result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	resp.Body.Close()
+
+	return result, nil
+}
+
+// GetPropertyValues gets the values of the properties set for a playbook run.
+func (s *PlaybookRunService) GetPropertyValues(ctx context.Context, playbookRunID string) ([]PropertyValue, error) {
+	propertiesURL := fmt.Sprintf("runs/%s/properties", playbookRunID)
+	req, err := s.client.newRequest(http.MethodGet, propertiesURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	propertyValues := []PropertyValue{}
+	resp, err := s.client.do(ctx, req, &propertyValues)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+
+	return propertyValues, nil
+}
+
+// RunItemCommand runs the slash command of a checklist item of a playbook run.
+func (s *PlaybookRunService) RunItemCommand(ctx context.Context, playbookRunID string, checklistIdx int, itemIdx int) error {
+	runURL := fmt.Sprintf("runs/%s/checklists/%d/item/%d/run", playbookRunID, checklistIdx, itemIdx)
+	req, err := s.client.newRequest(http.MethodPost, runURL, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.do(ctx, req, nil)
+	return err
+}
+
+// GetCommandOutput gets the output of the slash command of a checklist item of a playbook run.
+func (s *PlaybookRunService) GetCommandOutput(ctx context.Context, playbookRunID, outputID string) (*CommandOutput, error) {
+	outputURL := fmt.Sprintf("runs/%s/command-outputs/%s", playbookRunID, outputID)
+	req, err := s.client.newRequest(http.MethodGet, outputURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &CommandOutput{}
+	resp, err := s.client.do(ctx, req, output)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+
+	return output, nil
+}
+
+// GetActivity gets a page of the activity feed of a playbook run: its timeline events, status
+// updates and checklist item completions, oldest first.
+func (s *PlaybookRunService) GetActivity(ctx context.Context, playbookRunID string, opts RunActivityOptions) (*RunActivityResults, error) {
+	activityURL, err := addOptions(fmt.Sprintf("runs/%s/activity", playbookRunID), opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build options: %w", err)
+	}
+
+	req, err := s.client.newRequest(http.MethodGet, activityURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	result := &RunActivityResults{}
+	resp, err := s.client.do(ctx, req, result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	resp.Body.Close()
+
+	return result, nil
+}
+
+// AddKeyEvent adds a key event to the timeline of a playbook run.
+func (s *PlaybookRunService) AddKeyEvent(ctx context.Context, playbookRunID string, opts KeyEventOptions) (*TimelineEvent, error) {
+	keyEventURL := fmt.Sprintf("runs/%s/timeline", playbookRunID)
+	req, err := s.client.newRequest(http.MethodPost, keyEventURL, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	event := new(TimelineEvent)
+	resp, err := s.client.do(ctx, req, event)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("expected status code %d", http.StatusCreated)
+	}
+
+	return event, nil
+}
+
+// RemoveKeyEvent removes a key event from the timeline of a playbook run. Only the events added by
+// users can be removed this way.
+func (s *PlaybookRunService) RemoveKeyEvent(ctx context.Context, playbookRunID, eventID string) error {
+	removeURL := fmt.Sprintf("runs/%s/timeline/key-events/%s", playbookRunID, eventID)
+	req, err := s.client.newRequest(http.MethodDelete, removeURL, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// AutocompleteUsers returns the users of the team of a run whose names start with the prefix,
+// members of the channel of the run first.
+func (s *PlaybookRunService) AutocompleteUsers(ctx context.Context, playbookRunID string, opts RunUserAutocompleteOptions) ([]RunUserAutocompleteResult, error) {
+	autocompleteURL, err := addOptions(fmt.Sprintf("runs/%s/users/autocomplete", playbookRunID), opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build options: %w", err)
+	}
+
+	req, err := s.client.newRequest(http.MethodGet, autocompleteURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	users := []RunUserAutocompleteResult{}
+	resp, err := s.client.do(ctx, req, &users)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	resp.Body.Close()
+
+	return users, nil
+}
+
+// SetPropertyValue sets the value of a property of a playbook run. The empty value unsets it.
+func (s *PlaybookRunService) SetPropertyValue(ctx context.Context, playbookRunID, propertyDefinitionID, value string) error {
+	setURL := fmt.Sprintf("runs/%s/properties/%s", playbookRunID, propertyDefinitionID)
+	body := struct {
+		Value string `json:"value"`
+	}{value}
+	req, err := s.client.newRequest(http.MethodPut, setURL, body)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (s *PlaybookRunService) CreateChecklist(ctx context.Context, playbookRunID string, checklist Checklist) error {
 	createURL := fmt.Sprintf("runs/%s/checklists", playbookRunID)
 	req, err := s.client.newRequest(http.MethodPost, createURL, checklist)
@@ -268,6 +940,23 @@ func (s *PlaybookRunService) MoveChecklistItem(ctx context.Context, playbookRunI
 	return err
 }
 
+// ReorderChecklistItems sets the order of all the items in a checklist; itemIDs must contain the
+// ID of every item in the checklist exactly once.
+func (s *PlaybookRunService) ReorderChecklistItems(ctx context.Context, playbookRunID string, checklistNum int, itemIDs []string) error {
+	reorderURL := fmt.Sprintf("runs/%s/checklists/%d/reorder", playbookRunID, checklistNum)
+	body := struct {
+		ItemIDs []string `json:"item_ids"`
+	}{itemIDs}
+
+	req, err := s.client.newRequest(http.MethodPut, reorderURL, body)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.do(ctx, req, nil)
+	return err
+}
+
 // UpdateRetrospective updates the run's retrospective info
 func (s *PlaybookRunService) UpdateRetrospective(ctx context.Context, playbookRunID, userID string, retroUpdate RetrospectiveUpdate) error {
 	createURL := fmt.Sprintf("runs/%s/retrospective", playbookRunID)
@@ -315,6 +1004,23 @@ func (s *PlaybookRunService) SetItemAssignee(ctx context.Context, playbookRunID
 	return err
 }
 
+// SetItemState sets the state of a checklist item. skipReason is only kept when skipping it.
+func (s *PlaybookRunService) SetItemState(ctx context.Context, playbookRunID string, checklistIdx int, itemIdx int, newState, skipReason string) error {
+	createURL := fmt.Sprintf("runs/%s/checklists/%d/item/%d/state", playbookRunID, checklistIdx, itemIdx)
+	body := struct {
+		NewState   string `json:"new_state"`
+		SkipReason string `json:"skip_reason"`
+	}{newState, skipReason}
+
+	req, err := s.client.newRequest(http.MethodPut, createURL, body)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.do(ctx, req, nil)
+	return err
+}
+
 func (s *PlaybookRunService) SetItemDueDate(ctx context.Context, playbookRunID string, checklistIdx int, itemIdx int, duedate int64) error {
 	createURL := fmt.Sprintf("runs/%s/checklists/%d/item/%d/duedate", playbookRunID, checklistIdx, itemIdx)
 	body := struct {
@@ -346,3 +1052,26 @@ func (s *PlaybookRunService) GetOwners(ctx context.Context) ([]OwnerInfo, error)
 
 	return owners, nil
 }
+
+// GetTaskLoad returns the incomplete assigned checklist items and owned runs of the users with
+// work in the active runs of a team.
+func (s *PlaybookRunService) GetTaskLoad(ctx context.Context, opts TaskLoadOptions) ([]UserTaskLoad, error) {
+	taskLoadURL, err := addOptions("runs/task-load", opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build options: %w", err)
+	}
+
+	req, err := s.client.newRequest(http.MethodGet, taskLoadURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	taskLoad := make([]UserTaskLoad, 0)
+	resp, err := s.client.do(ctx, req, &taskLoad)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+
+	return taskLoad, nil
+}
@@ -153,6 +153,36 @@ func (s *PlaybooksService) Export(ctx context.Context, playbookID string) ([]byt
 	return result, nil
 }
 
+// CopyFromTemplate creates a new playbook in teamID as a copy of the template with templateID,
+// returning the ID of the new playbook. An empty title keeps the title of the template.
+func (s *PlaybooksService) CopyFromTemplate(ctx context.Context, templateID, teamID, title string) (string, error) {
+	url := fmt.Sprintf("playbooks/%s/copy", templateID)
+	body := struct {
+		TeamID string `json:"team_id"`
+		Title  string `json:"title"`
+	}{teamID, title}
+
+	req, err := s.client.newRequest(http.MethodPost, url, body)
+	if err != nil {
+		return "", err
+	}
+
+	var result struct {
+		ID string `json:"id"`
+	}
+	resp, err := s.client.do(ctx, req, &result)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("expected status code %d", http.StatusCreated)
+	}
+
+	return result.ID, nil
+}
+
 // Duplicate a playbook. Returns the id of the newly created playbook
 func (s *PlaybooksService) Duplicate(ctx context.Context, playbookID string) (string, error) {
 	url := fmt.Sprintf("playbooks/%s/duplicate", playbookID)
@@ -267,3 +297,39 @@ func (s *PlaybooksService) GetAutoFollows(ctx context.Context, playbookID string
 
 	return followers, nil
 }
+
+// GetVersions returns the past versions of a playbook, most recent first, without their snapshot.
+func (s *PlaybooksService) GetVersions(ctx context.Context, playbookID string) ([]PlaybookVersion, error) {
+	versionsURL := fmt.Sprintf("playbooks/%s/versions", playbookID)
+	req, err := s.client.newRequest(http.MethodGet, versionsURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []PlaybookVersion
+	resp, err := s.client.do(ctx, req, &versions)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+
+	return versions, nil
+}
+
+// GetVersion returns a past version of a playbook and its changes up to the current playbook.
+func (s *PlaybooksService) GetVersion(ctx context.Context, playbookID string, version int64) (*PlaybookVersionDiff, error) {
+	versionURL := fmt.Sprintf("playbooks/%s/versions/%d", playbookID, version)
+	req, err := s.client.newRequest(http.MethodGet, versionURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	versionDiff := new(PlaybookVersionDiff)
+	resp, err := s.client.do(ctx, req, versionDiff)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+
+	return versionDiff, nil
+}
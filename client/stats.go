@@ -2,7 +2,9 @@ package client
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"time"
 )
 
 // StatsService handles communication with the stats related methods.
@@ -33,3 +35,34 @@ func (s *StatsService) GetSiteStats(ctx context.Context) (*PlaybookSiteStats, er
 
 	return stats, nil
 }
+
+// CompletionVelocityPoint is a point of the completion velocity series of a run.
+type CompletionVelocityPoint struct {
+	// At is the end of the interval, in milliseconds since epoch.
+	At int64 `json:"at"`
+
+	// Completed is the number of checklist items completed from the start of the run up to At.
+	Completed int `json:"completed"`
+}
+
+// GetRunCompletionVelocity returns the cumulative number of checklist items of a run completed
+// by the end of each interval from the start of the run. If interval is 0, the server picks one.
+func (s *StatsService) GetRunCompletionVelocity(ctx context.Context, playbookRunID string, interval time.Duration) ([]CompletionVelocityPoint, error) {
+	statsURL := fmt.Sprintf("stats/completion-velocity?run_id=%s", playbookRunID)
+	if interval > 0 {
+		statsURL += fmt.Sprintf("&interval=%d", int64(interval.Seconds()))
+	}
+	req, err := s.client.newRequest(http.MethodGet, statsURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	points := []CompletionVelocityPoint{}
+	resp, err := s.client.do(ctx, req, &points)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+
+	return points, nil
+}
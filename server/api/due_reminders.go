@@ -0,0 +1,94 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/mattermost-plugin-playbooks/server/app"
+	"github.com/mattermost/mattermost-plugin-playbooks/server/timeutils"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/pkg/errors"
+
+	pluginapi "github.com/mattermost/mattermost-plugin-api"
+)
+
+// DueReminderHandler is the API handler for the reminders of checklist items due soon.
+type DueReminderHandler struct {
+	*ErrorHandler
+	pluginAPI    *pluginapi.Client
+	dueReminders *app.DueReminderScheduler
+}
+
+// NewDueReminderHandler returns a new due reminder api handler
+func NewDueReminderHandler(router *mux.Router, api *pluginapi.Client, dueReminders *app.DueReminderScheduler) *DueReminderHandler {
+	handler := &DueReminderHandler{
+		ErrorHandler: &ErrorHandler{},
+		pluginAPI:    api,
+		dueReminders: dueReminders,
+	}
+
+	dueRemindersRouter := router.PathPrefix("/due-reminders").Subrouter()
+	dueRemindersRouter.HandleFunc("/{id:[A-Za-z0-9]+}/{itemID:[A-Za-z0-9]+}/snooze", withContext(handler.snooze)).Methods(http.MethodPost)
+
+	return handler
+}
+
+// snooze handles the POST /due-reminders/{id}/{itemID}/snooze endpoint, called when the assignee
+// clicks on a snooze button of the reminder of a checklist item. The duration is given in minutes
+// by the snooze_minutes context of the button.
+func (h *DueReminderHandler) snooze(c *Context, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	playbookRunID := vars["id"]
+	checklistItemID := vars["itemID"]
+	userID := r.Header.Get("Mattermost-User-ID")
+
+	var requestData *model.PostActionIntegrationRequest
+	err := json.NewDecoder(r.Body).Decode(&requestData)
+	if err != nil || requestData == nil {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "missing request data", err)
+		return
+	}
+
+	minutes, ok := requestData.Context["snooze_minutes"].(float64)
+	if !ok {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "malformed context: snooze_minutes is not a number", nil)
+		return
+	}
+	duration := time.Duration(minutes) * time.Minute
+
+	_, err = h.dueReminders.Snooze(playbookRunID, checklistItemID, userID, duration)
+	if errors.Is(err, app.ErrInvalidSnooze) {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "invalid snooze", err)
+		return
+	} else if errors.Is(err, app.ErrNotFound) {
+		h.HandleErrorWithCode(w, c.logger, http.StatusNotFound, "the checklist item has no reminder", err)
+		return
+	} else if errors.Is(err, app.ErrNoPermissions) {
+		h.HandleErrorWithCode(w, c.logger, http.StatusForbidden, "not authorized", err)
+		return
+	} else if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	response := &model.PostActionIntegrationResponse{}
+	if requestData.PostId != "" {
+		originalPost, err := h.pluginAPI.Post.GetPost(requestData.PostId)
+		if err != nil {
+			h.HandleError(w, c.logger, err)
+			return
+		}
+
+		// Replace the buttons, so that the reminder isn't snoozed twice from the same post.
+		response.Update = &model.Post{
+			Id: originalPost.Id,
+			Message: fmt.Sprintf("%s\n\nSnoozed for %s.", originalPost.Message,
+				timeutils.DurationString(time.Time{}, time.Time{}.Add(duration))),
+		}
+	}
+
+	ReturnJSON(w, response, http.StatusOK)
+}
@@ -5,12 +5,14 @@ import (
 	_ "embed"
 	"encoding/json"
 	"net/http"
+	"sync"
 
 	"github.com/gorilla/mux"
 	graphql "github.com/graph-gophers/graphql-go"
 	pluginapi "github.com/mattermost/mattermost-plugin-api"
 	"github.com/mattermost/mattermost-plugin-playbooks/server/app"
 	"github.com/mattermost/mattermost-plugin-playbooks/server/config"
+	"github.com/mattermost/mattermost-server/v6/model"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
@@ -62,7 +64,8 @@ func NewGraphQLHandler(
 
 	if !configService.IsConfiguredForDevelopmentAndTesting() {
 		opts = append(opts,
-			graphql.MaxDepth(4),
+			// Deep enough for the users assigned to the checklist items of a run
+			graphql.MaxDepth(5),
 			graphql.DisableIntrospection(),
 		)
 	}
@@ -94,6 +97,45 @@ type GraphQLContext struct {
 	config             config.Service
 	permissions        *app.PermissionsService
 	licenceChecker     app.LicenseChecker
+
+	// users caches the users resolved during the request, by ID, since the resolvers of every
+	// entry of a list may ask for the same users.
+	usersLock sync.Mutex
+	users     map[string]*model.User
+
+	showFullNameOnce sync.Once
+	showFullName     bool
+}
+
+// getUser returns the user userID, fetched once per request.
+func (c *GraphQLContext) getUser(userID string) (*model.User, error) {
+	c.usersLock.Lock()
+	defer c.usersLock.Unlock()
+
+	if user, ok := c.users[userID]; ok {
+		return user, nil
+	}
+
+	user, err := c.pluginAPI.User.Get(userID)
+	if err != nil {
+		return nil, err
+	}
+	if c.users == nil {
+		c.users = make(map[string]*model.User)
+	}
+	c.users[userID] = user
+
+	return user, nil
+}
+
+// canSeeFullNames is true if the user making the request can see the first and last names of
+// other users, as in the REST API.
+func (c *GraphQLContext) canSeeFullNames() bool {
+	c.showFullNameOnce.Do(func() {
+		c.showFullName = app.CanSeeFullNames(c.r.Header.Get("Mattermost-User-ID"), c.pluginAPI)
+	})
+
+	return c.showFullName
 }
 
 // When moving over to the multi-product architecture this should be handled by the server.
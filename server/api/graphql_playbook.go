@@ -50,6 +50,10 @@ func (r *PlaybookResolver) ReminderTimerDefaultSeconds() float64 {
 	return float64(r.Playbook.ReminderTimerDefaultSeconds)
 }
 
+func (r *PlaybookResolver) ArchiveChannelOnFinishDelayMinutes() float64 {
+	return float64(r.Playbook.ArchiveChannelOnFinishDelayMinutes)
+}
+
 func (r *PlaybookResolver) Metrics() []*MetricConfigResolver {
 	metricConfigResolvers := make([]*MetricConfigResolver, 0, len(r.Playbook.Metrics))
 	for _, metricConfig := range r.Playbook.Metrics {
@@ -74,7 +78,7 @@ func (r *MetricConfigResolver) Target() *int32 {
 func (r *PlaybookResolver) Checklists() []*ChecklistResolver {
 	checklistResolvers := make([]*ChecklistResolver, 0, len(r.Playbook.Checklists))
 	for _, checklist := range r.Playbook.Checklists {
-		checklistResolvers = append(checklistResolvers, &ChecklistResolver{checklist})
+		checklistResolvers = append(checklistResolvers, &ChecklistResolver{checklist, r.TeamID})
 	}
 
 	return checklistResolvers
@@ -82,12 +86,13 @@ func (r *PlaybookResolver) Checklists() []*ChecklistResolver {
 
 type ChecklistResolver struct {
 	app.Checklist
+	teamID string
 }
 
 func (r *ChecklistResolver) Items() []*ChecklistItemResolver {
 	checklistItemResolvers := make([]*ChecklistItemResolver, 0, len(r.Checklist.Items))
 	for _, items := range r.Checklist.Items {
-		checklistItemResolvers = append(checklistItemResolvers, &ChecklistItemResolver{items})
+		checklistItemResolvers = append(checklistItemResolvers, &ChecklistItemResolver{items, r.teamID})
 	}
 
 	return checklistItemResolvers
@@ -95,6 +100,7 @@ func (r *ChecklistResolver) Items() []*ChecklistItemResolver {
 
 type ChecklistItemResolver struct {
 	app.ChecklistItem
+	teamID string
 }
 
 func (r *ChecklistItemResolver) StateModified() float64 {
@@ -113,20 +119,65 @@ func (r *ChecklistItemResolver) DueDate() float64 {
 	return float64(r.ChecklistItem.DueDate)
 }
 
+func (r *ChecklistItemResolver) Assignee(ctx context.Context) (*UserResolver, error) {
+	if r.AssigneeID == "" {
+		return nil, nil
+	}
+
+	c, err := getContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userID := c.r.Header.Get("Mattermost-User-ID")
+
+	if err := c.permissions.TeamMembersView(userID, r.teamID); err != nil {
+		return nil, err
+	}
+
+	user, err := c.getUser(r.AssigneeID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "can't get assignee %s", r.AssigneeID)
+	}
+
+	assignee := app.OwnerInfo{
+		UserID:   user.Id,
+		Username: user.Username,
+		Nickname: user.Nickname,
+	}
+	if c.canSeeFullNames() {
+		assignee.FirstName = user.FirstName
+		assignee.LastName = user.LastName
+	}
+
+	return &UserResolver{assignee}, nil
+}
+
+type UserResolver struct {
+	app.OwnerInfo
+}
+
+func (r *UserResolver) ID() string {
+	return r.UserID
+}
+
 type UpdateChecklist struct {
 	Title string                `json:"title"`
 	Items []UpdateChecklistItem `json:"items"`
 }
 
 type UpdateChecklistItem struct {
-	Title            string  `json:"title"`
-	State            string  `json:"state"`
-	StateModified    float64 `json:"state_modified"`
-	AssigneeID       string  `json:"assignee_id"`
-	AssigneeModified float64 `json:"assignee_modified"`
-	Command          string  `json:"command"`
-	CommandLastRun   float64 `json:"command_last_run"`
-	Description      string  `json:"description"`
-	LastSkipped      float64 `json:"delete_at"`
-	DueDate          float64 `json:"due_date"`
+	ID                *string `json:"id,omitempty"`
+	Title             string  `json:"title"`
+	State             string  `json:"state"`
+	StateModified     float64 `json:"state_modified"`
+	AssigneeID        string  `json:"assignee_id"`
+	AssigneeModified  float64 `json:"assignee_modified"`
+	Command           string  `json:"command"`
+	CommandLastRun    float64 `json:"command_last_run"`
+	Description       string  `json:"description"`
+	LastSkipped       float64 `json:"delete_at"`
+	DueDate           float64 `json:"due_date"`
+	Condition         *string `json:"condition,omitempty"`
+	DefaultAssigneeID *string `json:"default_assignee_id,omitempty"`
+	DependsOnItemID   *string `json:"depends_on_item_id,omitempty"`
 }
@@ -109,6 +109,9 @@ func (r *PlaybookRootResolver) UpdatePlaybook(ctx context.Context, args struct {
 		RetrospectiveReminderIntervalSeconds    *float64
 		RetrospectiveTemplate                   *string
 		RetrospectiveEnabled                    *bool
+		RetrospectiveRequired                   *bool
+		ChecklistItemDependenciesEnforced       *bool
+		AutoFinishEnabled                       *bool
 		WebhookOnStatusUpdateURLs               *[]string
 		WebhookOnStatusUpdateEnabled            *bool
 		SignalAnyKeywords                       *[]string
@@ -122,6 +125,9 @@ func (r *PlaybookRootResolver) UpdatePlaybook(ctx context.Context, args struct {
 		IsFavorite                              *bool
 		CreateChannelMemberOnNewParticipant     *bool
 		RemoveChannelMemberOnRemovedParticipant *bool
+		ArchiveChannelOnFinishEnabled           *bool
+		ArchiveChannelOnFinishDelayMinutes      *float64
+		ArchiveChannelSkipIfPosted              *bool
 		ChannelID                               *string
 		ChannelMode                             *string
 	}
@@ -169,6 +175,14 @@ func (r *PlaybookRootResolver) UpdatePlaybook(ctx context.Context, args struct {
 	addToSetmap(setmap, "StatusUpdateEnabled", args.Updates.StatusUpdateEnabled)
 	addToSetmap(setmap, "CreateChannelMemberOnNewParticipant", args.Updates.CreateChannelMemberOnNewParticipant)
 	addToSetmap(setmap, "RemoveChannelMemberOnRemovedParticipant", args.Updates.RemoveChannelMemberOnRemovedParticipant)
+	addToSetmap(setmap, "ArchiveChannelOnFinishEnabled", args.Updates.ArchiveChannelOnFinishEnabled)
+	if args.Updates.ArchiveChannelOnFinishDelayMinutes != nil {
+		if err := app.ValidateArchiveChannelOnFinishDelay(int64(*args.Updates.ArchiveChannelOnFinishDelayMinutes)); err != nil {
+			return "", err
+		}
+		addToSetmap(setmap, "ArchiveChannelOnFinishDelayMinutes", args.Updates.ArchiveChannelOnFinishDelayMinutes)
+	}
+	addToSetmap(setmap, "ArchiveChannelSkipIfPosted", args.Updates.ArchiveChannelSkipIfPosted)
 
 	if args.Updates.InvitedUserIDs != nil {
 		filteredInvitedUserIDs := c.permissions.FilterInvitedUserIDs(*args.Updates.InvitedUserIDs, currentPlaybook.TeamID)
@@ -209,6 +223,9 @@ func (r *PlaybookRootResolver) UpdatePlaybook(ctx context.Context, args struct {
 	addToSetmap(setmap, "RetrospectiveReminderIntervalSeconds", args.Updates.RetrospectiveReminderIntervalSeconds)
 	addToSetmap(setmap, "RetrospectiveTemplate", args.Updates.RetrospectiveTemplate)
 	addToSetmap(setmap, "RetrospectiveEnabled", args.Updates.RetrospectiveEnabled)
+	addToSetmap(setmap, "RetrospectiveRequired", args.Updates.RetrospectiveRequired)
+	addToSetmap(setmap, "ChecklistItemDependenciesEnforced", args.Updates.ChecklistItemDependenciesEnforced)
+	addToSetmap(setmap, "AutoFinishEnabled", args.Updates.AutoFinishEnabled)
 	if args.Updates.WebhookOnStatusUpdateURLs != nil {
 		if err := app.ValidateWebhookURLs(*args.Updates.WebhookOnStatusUpdateURLs); err != nil {
 			return "", err
@@ -236,16 +253,42 @@ func (r *PlaybookRootResolver) UpdatePlaybook(ctx context.Context, args struct {
 
 	// Not optimal graphql. Stopgap measure. Should be updated seperately.
 	if args.Updates.Checklists != nil {
+		for _, checklist := range *args.Updates.Checklists {
+			for _, item := range checklist.Items {
+				if item.DefaultAssigneeID != nil && *item.DefaultAssigneeID != "" {
+					if !model.IsValidId(*item.DefaultAssigneeID) {
+						return "", errors.Wrapf(app.ErrInvalidDefaultAssignee, "checklist item %q", item.Title)
+					}
+					if !app.IsMemberOfTeam(*item.DefaultAssigneeID, currentPlaybook.TeamID, c.pluginAPI) {
+						return "", errors.Wrapf(app.ErrInvalidDefaultAssignee, "default assignee of checklist item %q is not a member of the team", item.Title)
+					}
+				}
+				if item.Condition == nil {
+					continue
+				}
+				if err := app.ValidateChecklistItemCondition(*item.Condition); err != nil {
+					return "", err
+				}
+			}
+		}
 		cleanUpUpdateChecklist(*args.Updates.Checklists)
 		checklistsJSON, err := json.Marshal(args.Updates.Checklists)
 		if err != nil {
 			return "", errors.Wrapf(err, "failed to marshal checklist in graphql json for playbook id: '%s'", args.ID)
 		}
+
+		var checklists []app.Checklist
+		if err = json.Unmarshal(checklistsJSON, &checklists); err != nil {
+			return "", errors.Wrapf(err, "failed to unmarshal checklist in graphql json for playbook id: '%s'", args.ID)
+		}
+		if err = app.ValidateChecklistItemDependencies(checklists); err != nil {
+			return "", err
+		}
 		setmap["ChecklistsJSON"] = checklistsJSON
 	}
 
 	if len(setmap) > 0 {
-		if err := c.playbookStore.GraphqlUpdate(args.ID, setmap); err != nil {
+		if err := c.playbookService.GraphqlUpdate(args.ID, setmap, userID); err != nil {
 			return "", err
 		}
 	}
@@ -348,6 +391,7 @@ func (r *PlaybookRootResolver) AddMetric(ctx context.Context, args struct {
 	Description string
 	Type        string
 	Target      *float64
+	Direction   *string
 }) (string, error) {
 	c, err := getContext(ctx)
 	if err != nil {
@@ -368,6 +412,14 @@ func (r *PlaybookRootResolver) AddMetric(ctx context.Context, args struct {
 		return "", err
 	}
 
+	direction := ""
+	if args.Direction != nil {
+		direction = *args.Direction
+	}
+	if err := app.ValidateMetricDirection(direction); err != nil {
+		return "", err
+	}
+
 	var target null.Int
 	if args.Target == nil {
 		target = null.NewInt(0, false)
@@ -380,6 +432,7 @@ func (r *PlaybookRootResolver) AddMetric(ctx context.Context, args struct {
 		Description: args.Description,
 		Type:        args.Type,
 		Target:      target,
+		Direction:   direction,
 	}); err != nil {
 		return "", err
 	}
@@ -392,6 +445,7 @@ func (r *PlaybookRootResolver) UpdateMetric(ctx context.Context, args struct {
 	Title       *string
 	Description *string
 	Target      *float64
+	Direction   *string
 }) (string, error) {
 	c, err := getContext(ctx)
 	if err != nil {
@@ -423,6 +477,12 @@ func (r *PlaybookRootResolver) UpdateMetric(ctx context.Context, args struct {
 	if args.Target != nil {
 		setmap["Target"] = null.IntFrom(int64(*args.Target))
 	}
+	if args.Direction != nil {
+		if err := app.ValidateMetricDirection(*args.Direction); err != nil {
+			return "", err
+		}
+		setmap["Direction"] = *args.Direction
+	}
 	if len(setmap) > 0 {
 		if err := c.playbookStore.UpdateMetric(args.ID, setmap); err != nil {
 			return "", err
@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"sort"
 
 	"github.com/mattermost/mattermost-plugin-playbooks/server/app"
 	"github.com/pkg/errors"
@@ -45,12 +46,60 @@ func (r *RunResolver) RetrospectiveReminderIntervalSeconds() float64 {
 func (r *RunResolver) Checklists() []*ChecklistResolver {
 	checklistResolvers := make([]*ChecklistResolver, 0, len(r.PlaybookRun.Checklists))
 	for _, checklist := range r.PlaybookRun.Checklists {
-		checklistResolvers = append(checklistResolvers, &ChecklistResolver{checklist})
+		checklistResolvers = append(checklistResolvers, &ChecklistResolver{checklist, r.TeamID})
 	}
 
 	return checklistResolvers
 }
 
+func (r *RunResolver) CompletionPercentage() float64 {
+	return r.PlaybookRun.CompletionPercentage()
+}
+
+func (r *RunResolver) SkippedItemsCount() int32 {
+	return int32(r.PlaybookRun.SkippedItemsCount())
+}
+
+// LastStatusUpdate resolves the newest status update that was not deleted, checking the same
+// permissions as the status updates endpoint.
+func (r *RunResolver) LastStatusUpdate(ctx context.Context) (*StatusUpdateResolver, error) {
+	c, err := getContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	userID := c.r.Header.Get("Mattermost-User-ID")
+
+	if err := c.permissions.RunView(userID, r.ID); err != nil {
+		return nil, err
+	}
+
+	statusPosts := make([]app.StatusPost, len(r.PlaybookRun.StatusPosts))
+	copy(statusPosts, r.PlaybookRun.StatusPosts)
+	sort.Slice(statusPosts, func(i, j int) bool {
+		return statusPosts[i].CreateAt > statusPosts[j].CreateAt
+	})
+
+	for _, statusPost := range statusPosts {
+		if statusPost.DeleteAt != 0 {
+			continue
+		}
+
+		post, err := c.pluginAPI.Post.GetPost(statusPost.ID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "can't get status update %s", statusPost.ID)
+		}
+
+		// Same check as the status updates endpoint, as we are bypassing the channel permissions
+		if post.Type != "custom_run_update" {
+			continue
+		}
+
+		return &StatusUpdateResolver{*app.NewStatusPostComplete(post)}, nil
+	}
+
+	return nil, nil
+}
+
 func (r *RunResolver) StatusPosts() []*StatusPostResolver {
 	statusPostResolvers := make([]*StatusPostResolver, 0, len(r.PlaybookRun.StatusPosts))
 	for _, statusPost := range r.PlaybookRun.StatusPosts {
@@ -103,6 +152,14 @@ func (r *StatusPostResolver) DeleteAt() float64 {
 	return float64(r.StatusPost.DeleteAt)
 }
 
+type StatusUpdateResolver struct {
+	app.StatusPostComplete
+}
+
+func (r *StatusUpdateResolver) CreateAt() float64 {
+	return float64(r.StatusPostComplete.CreateAt)
+}
+
 type TimelineEventResolver struct {
 	app.TimelineEvent
 }
@@ -23,6 +23,9 @@ import (
 	"github.com/mattermost/mattermost-plugin-playbooks/server/config"
 )
 
+// idempotencyKeyHeader is the header holding the idempotency key of a run creation request.
+const idempotencyKeyHeader = "Idempotency-Key"
+
 // PlaybookRunHandler is the API handler.
 type PlaybookRunHandler struct {
 	*ErrorHandler
@@ -31,8 +34,11 @@ type PlaybookRunHandler struct {
 	playbookService    app.PlaybookService
 	permissions        *app.PermissionsService
 	licenseChecker     app.LicenseChecker
+	runIdempotency     *app.RunIdempotencyService
 	pluginAPI          *pluginapi.Client
 	poster             bot.Poster
+	runEvents          *app.RunEventBroker
+	savedFilters       *app.SavedFilterService
 }
 
 // NewPlaybookRunHandler Creates a new Plugin API handler.
@@ -42,9 +48,12 @@ func NewPlaybookRunHandler(
 	playbookService app.PlaybookService,
 	permissions *app.PermissionsService,
 	licenseChecker app.LicenseChecker,
+	runIdempotency *app.RunIdempotencyService,
 	api *pluginapi.Client,
 	poster bot.Poster,
 	configService config.Service,
+	runEvents *app.RunEventBroker,
+	savedFilters *app.SavedFilterService,
 ) *PlaybookRunHandler {
 	handler := &PlaybookRunHandler{
 		ErrorHandler:       &ErrorHandler{},
@@ -55,6 +64,9 @@ func NewPlaybookRunHandler(
 		config:             configService,
 		permissions:        permissions,
 		licenseChecker:     licenseChecker,
+		runIdempotency:     runIdempotency,
+		runEvents:          runEvents,
+		savedFilters:       savedFilters,
 	}
 
 	playbookRunsRouter := router.PathPrefix("/runs").Subrouter()
@@ -64,9 +76,24 @@ func NewPlaybookRunHandler(
 	playbookRunsRouter.HandleFunc("/dialog", withContext(handler.createPlaybookRunFromDialog)).Methods(http.MethodPost)
 	playbookRunsRouter.HandleFunc("/add-to-timeline-dialog", withContext(handler.addToTimelineDialog)).Methods(http.MethodPost)
 	playbookRunsRouter.HandleFunc("/owners", withContext(handler.getOwners)).Methods(http.MethodGet)
+	playbookRunsRouter.HandleFunc("/task-load", withContext(handler.getTaskLoad)).Methods(http.MethodGet)
 	playbookRunsRouter.HandleFunc("/channels", withContext(handler.getChannels)).Methods(http.MethodGet)
 	playbookRunsRouter.HandleFunc("/checklist-autocomplete", withContext(handler.getChecklistAutocomplete)).Methods(http.MethodGet)
 	playbookRunsRouter.HandleFunc("/checklist-autocomplete-item", withContext(handler.getChecklistAutocompleteItem)).Methods(http.MethodGet)
+	playbookRunsRouter.HandleFunc("/status-updates/search", withContext(handler.searchStatusUpdates)).Methods(http.MethodGet)
+	playbookRunsRouter.HandleFunc("/reassign", withContext(handler.reassignTasks)).Methods(http.MethodPost)
+	playbookRunsRouter.HandleFunc("/tags", withContext(handler.getTeamTags)).Methods(http.MethodGet)
+	playbookRunsRouter.HandleFunc("/export", withContext(handler.exportPlaybookRuns)).Methods(http.MethodGet)
+	playbookRunsRouter.HandleFunc("/stale", withContext(handler.getStalePlaybookRuns)).Methods(http.MethodGet)
+	playbookRunsRouter.HandleFunc("/batch", withContext(handler.getPlaybookRunsBatch)).Methods(http.MethodPost)
+
+	savedFiltersRouter := playbookRunsRouter.PathPrefix("/saved-filters").Subrouter()
+	savedFiltersRouter.HandleFunc("", withContext(handler.getSavedFilters)).Methods(http.MethodGet)
+	savedFiltersRouter.HandleFunc("", withContext(handler.createSavedFilter)).Methods(http.MethodPost)
+	savedFilterRouter := savedFiltersRouter.PathPrefix("/{savedFilterID:[A-Za-z0-9]+}").Subrouter()
+	savedFilterRouter.HandleFunc("", withContext(handler.updateSavedFilter)).Methods(http.MethodPut)
+	savedFilterRouter.HandleFunc("", withContext(handler.deleteSavedFilter)).Methods(http.MethodDelete)
+	savedFilterRouter.HandleFunc("/runs", withContext(handler.getSavedFilterRuns)).Methods(http.MethodGet)
 
 	playbookRunRouter := playbookRunsRouter.PathPrefix("/{id:[A-Za-z0-9]+}").Subrouter()
 	playbookRunRouter.HandleFunc("", withContext(handler.getPlaybookRun)).Methods(http.MethodGet)
@@ -74,21 +101,41 @@ func NewPlaybookRunHandler(
 	playbookRunRouter.HandleFunc("/status-updates", withContext(handler.getStatusUpdates)).Methods(http.MethodGet)
 	playbookRunRouter.HandleFunc("/request-update", withContext(handler.requestUpdate)).Methods(http.MethodPost)
 	playbookRunRouter.HandleFunc("/request-join-channel", withContext(handler.requestJoinChannel)).Methods(http.MethodPost)
+	playbookRunRouter.HandleFunc("/export", withContext(handler.exportPlaybookRun)).Methods(http.MethodGet)
+	playbookRunRouter.HandleFunc("/properties", withContext(handler.getPropertyValues)).Methods(http.MethodGet)
+	playbookRunRouter.HandleFunc("/activity", withContext(handler.getRunActivity)).Methods(http.MethodGet)
+	playbookRunRouter.HandleFunc("/events", withContext(handler.streamEvents)).Methods(http.MethodGet)
+	playbookRunRouter.HandleFunc("/restart", withContext(handler.restart)).Methods(http.MethodPost)
+	playbookRunRouter.HandleFunc("/users/autocomplete", withContext(handler.autocompleteRunUsers)).Methods(http.MethodGet)
+	playbookRunRouter.HandleFunc("/command-outputs/{outputID:[A-Za-z0-9]+}", withContext(handler.getCommandOutput)).Methods(http.MethodGet)
 
 	playbookRunRouterAuthorized := playbookRunRouter.PathPrefix("").Subrouter()
 	playbookRunRouterAuthorized.Use(handler.checkEditPermissions)
 	playbookRunRouterAuthorized.HandleFunc("", withContext(handler.updatePlaybookRun)).Methods(http.MethodPatch)
 	playbookRunRouterAuthorized.HandleFunc("/owner", withContext(handler.changeOwner)).Methods(http.MethodPost)
+	playbookRunRouterAuthorized.HandleFunc("/co-owners", withContext(handler.addCoOwner)).Methods(http.MethodPost)
+	playbookRunRouterAuthorized.HandleFunc("/co-owners/{userID:[A-Za-z0-9]+}", withContext(handler.removeCoOwner)).Methods(http.MethodDelete)
+	playbookRunRouterAuthorized.HandleFunc("/participants/{userID:[A-Za-z0-9]+}/role", withContext(handler.setParticipantRole)).Methods(http.MethodPut)
+	playbookRunRouterAuthorized.HandleFunc("/properties/{definitionID:[A-Za-z0-9]+}", withContext(handler.setPropertyValue)).Methods(http.MethodPut)
+	playbookRunRouterAuthorized.HandleFunc("/tags", withContext(handler.addTags)).Methods(http.MethodPost)
+	playbookRunRouterAuthorized.HandleFunc("/tags/{tag}", withContext(handler.removeTag)).Methods(http.MethodDelete)
 	playbookRunRouterAuthorized.HandleFunc("/status", withContext(handler.status)).Methods(http.MethodPost)
+	playbookRunRouterAuthorized.HandleFunc("/status/broadcast", withContext(handler.retryStatusUpdateBroadcast)).Methods(http.MethodPost)
 	playbookRunRouterAuthorized.HandleFunc("/finish", withContext(handler.finish)).Methods(http.MethodPut)
 	playbookRunRouterAuthorized.HandleFunc("/finish-dialog", withContext(handler.finishDialog)).Methods(http.MethodPost)
 	playbookRunRouterAuthorized.HandleFunc("/update-status-dialog", withContext(handler.updateStatusDialog)).Methods(http.MethodPost)
 	playbookRunRouterAuthorized.HandleFunc("/reminder/button-update", withContext(handler.reminderButtonUpdate)).Methods(http.MethodPost)
 	playbookRunRouterAuthorized.HandleFunc("/reminder", withContext(handler.reminderReset)).Methods(http.MethodPost)
 	playbookRunRouterAuthorized.HandleFunc("/no-retrospective-button", withContext(handler.noRetrospectiveButton)).Methods(http.MethodPost)
+	playbookRunRouterAuthorized.HandleFunc("/timeline", withContext(handler.addKeyEvent)).Methods(http.MethodPost)
 	playbookRunRouterAuthorized.HandleFunc("/timeline/{eventID:[A-Za-z0-9]+}", withContext(handler.removeTimelineEvent)).Methods(http.MethodDelete)
+	playbookRunRouterAuthorized.HandleFunc("/timeline/key-events/{eventID:[A-Za-z0-9]+}", withContext(handler.removeKeyEvent)).Methods(http.MethodDelete)
 	playbookRunRouterAuthorized.HandleFunc("/update-description", withContext(handler.updateDescription)).Methods(http.MethodPut)
 	playbookRunRouterAuthorized.HandleFunc("/restore", withContext(handler.restore)).Methods(http.MethodPut)
+	playbookRunRouterAuthorized.HandleFunc("/pause", withContext(handler.pause)).Methods(http.MethodPut)
+	playbookRunRouterAuthorized.HandleFunc("/clone", withContext(handler.clone)).Methods(http.MethodPost)
+	playbookRunRouterAuthorized.HandleFunc("/merge", withContext(handler.merge)).Methods(http.MethodPost)
+	playbookRunRouterAuthorized.HandleFunc("/resume", withContext(handler.resume)).Methods(http.MethodPut)
 	playbookRunRouterAuthorized.HandleFunc("/status-update-enabled", withContext(handler.toggleStatusUpdates)).Methods(http.MethodPut)
 
 	channelRouter := playbookRunsRouter.PathPrefix("/channel").Subrouter()
@@ -102,11 +149,13 @@ func NewPlaybookRunHandler(
 	checklistRouter := checklistsRouter.PathPrefix("/{checklist:[0-9]+}").Subrouter()
 	checklistRouter.HandleFunc("", withContext(handler.removeChecklist)).Methods(http.MethodDelete)
 	checklistRouter.HandleFunc("/add", withContext(handler.addChecklistItem)).Methods(http.MethodPost)
+	checklistRouter.HandleFunc("/add-from-posts", withContext(handler.addChecklistItemsFromPosts)).Methods(http.MethodPost)
 	checklistRouter.HandleFunc("/rename", withContext(handler.renameChecklist)).Methods(http.MethodPut)
 	checklistRouter.HandleFunc("/add-dialog", withContext(handler.addChecklistItemDialog)).Methods(http.MethodPost)
 	checklistRouter.HandleFunc("/skip", withContext(handler.checklistSkip)).Methods(http.MethodPut)
 	checklistRouter.HandleFunc("/restore", withContext(handler.checklistRestore)).Methods(http.MethodPut)
 	checklistRouter.HandleFunc("/duplicate", withContext(handler.duplicateChecklist)).Methods(http.MethodPost)
+	checklistRouter.HandleFunc("/reorder", withContext(handler.reorderChecklistItems)).Methods(http.MethodPut)
 
 	checklistItem := checklistRouter.PathPrefix("/item/{item:[0-9]+}").Subrouter()
 	checklistItem.HandleFunc("", withContext(handler.itemDelete)).Methods(http.MethodDelete)
@@ -162,26 +211,76 @@ func (h *PlaybookRunHandler) createPlaybookRunFromPost(c *Context, w http.Respon
 		return
 	}
 
-	playbookRun, err := h.createPlaybookRun(
-		app.PlaybookRun{
-			OwnerUserID: playbookRunCreateOptions.OwnerUserID,
-			TeamID:      playbookRunCreateOptions.TeamID,
-			ChannelID:   playbookRunCreateOptions.ChannelID,
-			Name:        playbookRunCreateOptions.Name,
-			Summary:     playbookRunCreateOptions.Description,
-			PostID:      playbookRunCreateOptions.PostID,
-			PlaybookID:  playbookRunCreateOptions.PlaybookID,
-		},
-		userID,
-	)
+	var channelOptions *runChannelOptions
+	if playbookRunCreateOptions.ChannelMode != "" {
+		channelOptions = &runChannelOptions{allowMultipleRuns: playbookRunCreateOptions.AllowMultipleRuns}
+		if err := channelOptions.mode.UnmarshalText([]byte(playbookRunCreateOptions.ChannelMode)); err != nil {
+			h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "Bad parameter 'channel_mode'", err)
+			return
+		}
+	}
 
-	if errors.Is(err, app.ErrNoPermissions) {
-		h.HandleErrorWithCode(w, c.logger, http.StatusForbidden, "unable to create playbook run", err)
-		return
+	playbookRunToCreate := app.PlaybookRun{
+		OwnerUserID: playbookRunCreateOptions.OwnerUserID,
+		TeamID:      playbookRunCreateOptions.TeamID,
+		ChannelID:   playbookRunCreateOptions.ChannelID,
+		Name:        playbookRunCreateOptions.Name,
+		Summary:     playbookRunCreateOptions.Description,
+		PostID:      playbookRunCreateOptions.PostID,
+		PlaybookID:  playbookRunCreateOptions.PlaybookID,
+	}
+
+	if dryRunParam := r.URL.Query().Get("dryRun"); dryRunParam != "" {
+		dryRun, err := strconv.ParseBool(dryRunParam)
+		if err != nil {
+			h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "Bad parameter 'dryRun'", err)
+			return
+		}
+
+		if dryRun {
+			h.returnPlaybookRunDryRun(c, w, playbookRunToCreate, userID, channelOptions)
+			return
+		}
+	}
+
+	// A client retrying a request with the same idempotency key gets the run created by the first
+	// request instead of a new one.
+	idempotencyKey := r.Header.Get(idempotencyKeyHeader)
+	if idempotencyKey == "" {
+		idempotencyKey = playbookRunCreateOptions.IdempotencyKey
+	}
+
+	var reservation *app.RunIdempotencyKey
+	if idempotencyKey != "" {
+		reserved, err := h.runIdempotency.Reserve(userID, idempotencyKey)
+		if errors.Is(err, app.ErrMalformedPlaybookRun) {
+			h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "invalid idempotency key", err)
+			return
+		}
+		if errors.Is(err, app.ErrIdempotencyKeyInUse) {
+			h.HandleErrorWithCode(w, c.logger, http.StatusConflict, "a run is already being created with this idempotency key", err)
+			return
+		}
+		if err != nil {
+			h.HandleError(w, c.logger, err)
+			return
+		}
+
+		if reserved.PlaybookRunID != "" {
+			h.returnIdempotentPlaybookRun(c, w, reserved.PlaybookRunID, userID)
+			return
+		}
+		reservation = &reserved
+	}
+
+	playbookRun, err := h.createPlaybookRun(playbookRunToCreate, userID, channelOptions)
+
+	if reservation != nil {
+		h.finishRunIdempotencyKey(c.logger, *reservation, playbookRun, err)
 	}
 
-	if errors.Is(err, app.ErrMalformedPlaybookRun) {
-		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "unable to create playbook run", err)
+	if validationErr := runCreationValidationError(err); validationErr != nil {
+		h.HandleErrorWithCode(w, c.logger, validationErr.statusCode, validationErr.publicMessage, err)
 		return
 	}
 
@@ -198,6 +297,89 @@ func (h *PlaybookRunHandler) createPlaybookRunFromPost(c *Context, w http.Respon
 	ReturnJSON(w, &playbookRun, http.StatusCreated)
 }
 
+// returnPlaybookRunDryRun responds to a dry run of the creation of playbookRun with the run that
+// would be created or, if the creation would fail, why.
+func (h *PlaybookRunHandler) returnPlaybookRunDryRun(c *Context, w http.ResponseWriter, playbookRun app.PlaybookRun, userID string, channelOptions *runChannelOptions) {
+	dryRun, err := h.dryRunPlaybookRun(playbookRun, userID, channelOptions)
+	if validationErr := runCreationValidationError(err); validationErr != nil {
+		ReturnJSON(w, &app.PlaybookRunDryRun{
+			Valid: false,
+			ValidationError: &app.RunValidationError{
+				Code:       validationErr.code,
+				StatusCode: validationErr.statusCode,
+				Message:    err.Error(),
+			},
+		}, http.StatusOK)
+		return
+	}
+	if err != nil {
+		h.HandleError(w, c.logger, errors.Wrapf(err, "unable to validate playbook run"))
+		return
+	}
+
+	ReturnJSON(w, dryRun, http.StatusOK)
+}
+
+// runCreationError describes an error the creation of a run fails with because of the request.
+type runCreationError struct {
+	statusCode    int
+	code          string
+	publicMessage string
+}
+
+// runCreationValidationError returns how to report err, returned by the creation of a run, or nil
+// if err is not a validation error.
+func runCreationValidationError(err error) *runCreationError {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, app.ErrNoPermissions):
+		return &runCreationError{http.StatusForbidden, "no_permissions", "unable to create playbook run"}
+	case errors.Is(err, app.ErrMalformedPlaybookRun):
+		return &runCreationError{http.StatusBadRequest, "malformed_run", "unable to create playbook run"}
+	case errors.Is(err, app.ErrChannelHasActiveRun):
+		return &runCreationError{http.StatusConflict, "channel_has_active_run", "unable to create playbook run"}
+	case errors.Is(err, app.ErrPlaybookArchived):
+		return &runCreationError{http.StatusBadRequest, "playbook_archived", "playbook is archived, cannot create a new run using an archived playbook"}
+	case errors.Is(err, app.ErrChannelDisplayNameInvalid):
+		return &runCreationError{http.StatusBadRequest, "channel_name_invalid", "the name of the playbook run cannot be used for its channel"}
+	default:
+		return nil
+	}
+}
+
+// returnIdempotentPlaybookRun responds to a repeated creation request with the run created by
+// the first one.
+func (h *PlaybookRunHandler) returnIdempotentPlaybookRun(c *Context, w http.ResponseWriter, playbookRunID, userID string) {
+	if !h.PermissionsCheck(w, c.logger, h.permissions.RunView(userID, playbookRunID)) {
+		return
+	}
+
+	playbookRun, err := h.playbookRunService.GetPlaybookRun(playbookRunID)
+	if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	w.Header().Add("Location", fmt.Sprintf("/api/v0/runs/%s", playbookRun.ID))
+	ReturnJSON(w, &playbookRun, http.StatusOK)
+}
+
+// finishRunIdempotencyKey records the run created for reservation, or frees its key if the
+// creation failed so that the request can be retried.
+func (h *PlaybookRunHandler) finishRunIdempotencyKey(logger logrus.FieldLogger, reservation app.RunIdempotencyKey, playbookRun *app.PlaybookRun, createErr error) {
+	if createErr != nil {
+		if err := h.runIdempotency.Release(reservation); err != nil {
+			logger.WithError(err).Warn("failed to release run idempotency key")
+		}
+		return
+	}
+
+	if err := h.runIdempotency.Complete(reservation, playbookRun.ID); err != nil {
+		logger.WithError(err).WithField("playbook_run_id", playbookRun.ID).Warn("failed to record the run of an idempotency key")
+	}
+}
+
 // Note that this currently does nothing. This is temporary given the removal of stages. Will be used by status.
 func (h *PlaybookRunHandler) updatePlaybookRun(c *Context, w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
@@ -261,6 +443,7 @@ func (h *PlaybookRunHandler) createPlaybookRunFromDialog(c *Context, w http.Resp
 			PlaybookID:  playbookID,
 		},
 		request.UserId,
+		nil,
 	)
 	if err != nil {
 		if errors.Is(err, app.ErrMalformedPlaybookRun) {
@@ -279,6 +462,10 @@ func (h *PlaybookRunHandler) createPlaybookRunFromDialog(c *Context, w http.Resp
 			msg = "The name is invalid or too long. Please use a valid name with fewer than 64 characters."
 		}
 
+		if errors.Is(err, app.ErrPlaybookArchived) {
+			msg = "The playbook is archived. Restore it to start new runs from it."
+		}
+
 		if msg != "" {
 			resp := &model.SubmitDialogResponse{
 				Errors: map[string]string{
@@ -412,26 +599,71 @@ func (h *PlaybookRunHandler) addToTimelineDialog(c *Context, w http.ResponseWrit
 	w.WriteHeader(http.StatusOK)
 }
 
-func (h *PlaybookRunHandler) createPlaybookRun(playbookRun app.PlaybookRun, userID string) (*app.PlaybookRun, error) {
+// runChannelOptions choose the channel of a new run, instead of the channel mode of its playbook.
+type runChannelOptions struct {
+	mode app.ChannelPlaybookMode
+
+	// allowMultipleRuns allows linking the run to a channel that already has an unfinished run.
+	allowMultipleRuns bool
+}
+
+func (h *PlaybookRunHandler) createPlaybookRun(playbookRun app.PlaybookRun, userID string, channelOptions *runChannelOptions) (*app.PlaybookRun, error) {
+	playbook, public, err := h.validatePlaybookRunCreation(&playbookRun, userID, channelOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	playbookRunReturned, err := h.playbookRunService.CreatePlaybookRun(&playbookRun, playbook, userID, public)
+	if err != nil {
+		return nil, err
+	}
+
+	// force database retrieval to ensure all data is processed correctly (i.e participantIds)
+	return h.playbookRunService.GetPlaybookRun(playbookRunReturned.ID)
+}
+
+// dryRunPlaybookRun validates the creation of playbookRun like createPlaybookRun, without creating
+// anything.
+func (h *PlaybookRunHandler) dryRunPlaybookRun(playbookRun app.PlaybookRun, userID string, channelOptions *runChannelOptions) (*app.PlaybookRunDryRun, error) {
+	playbook, public, err := h.validatePlaybookRunCreation(&playbookRun, userID, channelOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.playbookRunService.DryRunPlaybookRun(&playbookRun, playbook, userID, public)
+}
+
+// validatePlaybookRunCreation checks that userID can create playbookRun, copying into it the
+// settings of its playbook. Returns the playbook, and whether the channel of the run is public.
+func (h *PlaybookRunHandler) validatePlaybookRunCreation(playbookRun *app.PlaybookRun, userID string, channelOptions *runChannelOptions) (*app.Playbook, bool, error) {
 	// Validate initial data
 	if playbookRun.ID != "" {
-		return nil, errors.Wrap(app.ErrMalformedPlaybookRun, "playbook run already has an id")
+		return nil, false, errors.Wrap(app.ErrMalformedPlaybookRun, "playbook run already has an id")
 	}
 
 	if playbookRun.CreateAt != 0 {
-		return nil, errors.Wrap(app.ErrMalformedPlaybookRun, "playbook run channel already has created at date")
+		return nil, false, errors.Wrap(app.ErrMalformedPlaybookRun, "playbook run channel already has created at date")
 	}
 
 	if playbookRun.TeamID == "" && playbookRun.ChannelID == "" {
-		return nil, errors.Wrap(app.ErrMalformedPlaybookRun, "must provide team or channel to create playbook run")
+		return nil, false, errors.Wrap(app.ErrMalformedPlaybookRun, "must provide team or channel to create playbook run")
 	}
 
 	if playbookRun.OwnerUserID == "" {
-		return nil, errors.Wrap(app.ErrMalformedPlaybookRun, "missing owner user id of playbook run")
+		return nil, false, errors.Wrap(app.ErrMalformedPlaybookRun, "missing owner user id of playbook run")
 	}
 
 	if strings.TrimSpace(playbookRun.Name) == "" && playbookRun.ChannelID == "" {
-		return nil, errors.Wrap(app.ErrMalformedPlaybookRun, "missing name of playbook run")
+		return nil, false, errors.Wrap(app.ErrMalformedPlaybookRun, "missing name of playbook run")
+	}
+
+	if channelOptions != nil {
+		if channelOptions.mode == app.PlaybookRunLinkExistingChannel && playbookRun.ChannelID == "" {
+			return nil, false, errors.Wrap(app.ErrMalformedPlaybookRun, "must provide the channel to link the playbook run to")
+		}
+		if channelOptions.mode == app.PlaybookRunCreateNewChannel && playbookRun.ChannelID != "" {
+			return nil, false, errors.Wrap(app.ErrMalformedPlaybookRun, "cannot provide a channel when creating a new one for the playbook run")
+		}
 	}
 
 	// Retrieve channel if needed and validate it
@@ -442,13 +674,13 @@ func (h *PlaybookRunHandler) createPlaybookRun(playbookRun app.PlaybookRun, user
 	if playbookRun.ChannelID != "" {
 		channel, err = h.pluginAPI.Channel.Get(playbookRun.ChannelID)
 		if err != nil {
-			return nil, errors.Wrapf(err, "failed to get channel")
+			return nil, false, errors.Wrapf(err, "failed to get channel")
 		}
 
 		if playbookRun.TeamID == "" {
 			playbookRun.TeamID = channel.TeamId
 		} else if channel.TeamId != playbookRun.TeamID {
-			return nil, errors.Wrap(app.ErrMalformedPlaybookRun, "channel not in given team")
+			return nil, false, errors.Wrap(app.ErrMalformedPlaybookRun, "channel not in given team")
 		}
 	}
 
@@ -458,22 +690,29 @@ func (h *PlaybookRunHandler) createPlaybookRun(playbookRun app.PlaybookRun, user
 	if playbookRun.PlaybookID != "" {
 		pb, err := h.playbookService.Get(playbookRun.PlaybookID)
 		if err != nil {
-			return nil, errors.Wrapf(err, "failed to get playbook")
+			return nil, false, errors.Wrapf(err, "failed to get playbook")
 		}
 		playbook = &pb
 
 		if playbook.DeleteAt != 0 {
-			return nil, errors.New("playbook is archived, cannot create a new run using an archived playbook")
+			return nil, false, errors.Wrap(app.ErrPlaybookArchived, "cannot create a new run using an archived playbook")
 		}
 
 		if err := h.permissions.RunCreate(userID, *playbook); err != nil {
-			return nil, err
+			return nil, false, err
 		}
 
 		public = pb.CreatePublicPlaybookRun
 
 		playbookRun.SetChecklistFromPlaybook(*playbook)
 		playbookRun.SetConfigurationFromPlaybook(*playbook)
+
+		// CreatePlaybookRun follows the channel mode of the playbook, so the chosen mode replaces it
+		// in this copy of the playbook.
+		if channelOptions != nil {
+			playbook.ChannelMode = channelOptions.mode
+			playbook.ChannelID = playbookRun.ChannelID
+		}
 	}
 
 	// Check the permissions on the channel: the user must be able to create it or,
@@ -486,7 +725,7 @@ func (h *PlaybookRunHandler) createPlaybookRun(playbookRun app.PlaybookRun, user
 			permissionMessage = "You are not able to create a public channel"
 		}
 		if !h.pluginAPI.User.HasPermissionToTeam(userID, playbookRun.TeamID, permission) {
-			return nil, errors.Wrap(app.ErrNoPermissions, permissionMessage)
+			return nil, false, errors.Wrap(app.ErrNoPermissions, permissionMessage)
 		}
 	} else {
 		permission := model.PermissionManagePublicChannelProperties
@@ -500,7 +739,23 @@ func (h *PlaybookRunHandler) createPlaybookRun(playbookRun app.PlaybookRun, user
 		}
 
 		if !h.pluginAPI.User.HasPermissionToChannel(userID, channel.Id, permission) {
-			return nil, errors.Wrap(app.ErrNoPermissions, permissionMessage)
+			return nil, false, errors.Wrap(app.ErrNoPermissions, permissionMessage)
+		}
+
+		if channelOptions != nil {
+			if !h.pluginAPI.User.HasPermissionToChannel(userID, channel.Id, model.PermissionCreatePost) {
+				return nil, false, errors.Wrap(app.ErrNoPermissions, "You are not able to post in this channel")
+			}
+
+			if !channelOptions.allowMultipleRuns {
+				activeRunIDs, err := h.playbookRunService.GetActivePlaybookRunIDsForChannel(channel.Id)
+				if err != nil {
+					return nil, false, errors.Wrap(err, "failed to get the active runs of the channel")
+				}
+				if len(activeRunIDs) > 0 {
+					return nil, false, errors.Wrapf(app.ErrChannelHasActiveRun, "channel %s already has the active run %s", channel.Id, activeRunIDs[0])
+				}
+			}
 		}
 	}
 
@@ -508,21 +763,14 @@ func (h *PlaybookRunHandler) createPlaybookRun(playbookRun app.PlaybookRun, user
 	if playbookRun.PostID != "" {
 		post, err := h.pluginAPI.Post.GetPost(playbookRun.PostID)
 		if err != nil {
-			return nil, errors.Wrapf(err, "failed to get playbook run original post")
+			return nil, false, errors.Wrapf(err, "failed to get playbook run original post")
 		}
 		if !h.pluginAPI.User.HasPermissionToChannel(userID, post.ChannelId, model.PermissionReadChannel) {
-			return nil, errors.New("user does not have access to the channel containing the playbook run's original post")
+			return nil, false, errors.New("user does not have access to the channel containing the playbook run's original post")
 		}
 	}
 
-	playbookRunReturned, err := h.playbookRunService.CreatePlaybookRun(&playbookRun, playbook, userID, public)
-	if err != nil {
-		return nil, err
-	}
-
-	// force database retrieval to ensure all data is processed correctly (i.e participantIds)
-	return h.playbookRunService.GetPlaybookRun(playbookRunReturned.ID)
-
+	return playbook, public, nil
 }
 
 func (h *PlaybookRunHandler) getRequesterInfo(userID string) (app.RequesterInfo, error) {
@@ -554,6 +802,152 @@ func (h *PlaybookRunHandler) getPlaybookRuns(c *Context, w http.ResponseWriter,
 	ReturnJSON(w, results, http.StatusOK)
 }
 
+// searchStatusUpdates handles the GET /runs/status-updates/search endpoint, returning the status
+// updates of the runs visible to the user that match the term query parameter.
+func (h *PlaybookRunHandler) searchStatusUpdates(c *Context, w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get("Mattermost-User-ID")
+	query := r.URL.Query()
+
+	term := strings.TrimSpace(query.Get("term"))
+	if term == "" {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "Bad parameter 'term': must not be empty", nil)
+		return
+	}
+
+	// Runs of teams the user is not a member of are filtered out by the store.
+	teamID := query.Get("team_id")
+
+	var options app.StatusUpdateSearchOptions
+	var err error
+	if page := query.Get("page"); page != "" {
+		if options.Page, err = strconv.Atoi(page); err != nil {
+			h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "Bad parameter 'page'", err)
+			return
+		}
+	}
+	options.PerPage = app.PerPageDefault
+	if perPage := query.Get("per_page"); perPage != "" {
+		if options.PerPage, err = strconv.Atoi(perPage); err != nil {
+			h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "Bad parameter 'per_page'", err)
+			return
+		}
+	}
+
+	requesterInfo, err := h.getRequesterInfo(userID)
+	if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	results, err := h.playbookRunService.SearchStatusUpdates(requesterInfo, teamID, term, options)
+	if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	ReturnJSON(w, results, http.StatusOK)
+}
+
+// getStalePlaybookRuns handles the GET /runs/stale endpoint, returning the in-progress runs visible
+// to the user without activity for longer than the olderThan query parameter, a duration such as
+// 72h, stalest first.
+func (h *PlaybookRunHandler) getStalePlaybookRuns(c *Context, w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get("Mattermost-User-ID")
+	query := r.URL.Query()
+
+	olderThan := app.DefaultStaleRunThreshold
+	if param := query.Get("olderThan"); param != "" {
+		var err error
+		if olderThan, err = time.ParseDuration(param); err != nil || olderThan <= 0 {
+			h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "Bad parameter 'olderThan': must be a positive duration, such as 72h", err)
+			return
+		}
+	}
+
+	// Runs of teams the user is not a member of are filtered out by the store.
+	teamID := query.Get("team_id")
+
+	var options app.StaleRunsOptions
+	var err error
+	if page := query.Get("page"); page != "" {
+		if options.Page, err = strconv.Atoi(page); err != nil {
+			h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "Bad parameter 'page'", err)
+			return
+		}
+	}
+	options.PerPage = app.PerPageDefault
+	if perPage := query.Get("per_page"); perPage != "" {
+		if options.PerPage, err = strconv.Atoi(perPage); err != nil {
+			h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "Bad parameter 'per_page'", err)
+			return
+		}
+	}
+
+	requesterInfo, err := h.getRequesterInfo(userID)
+	if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	results, err := h.playbookRunService.GetStalePlaybookRuns(requesterInfo, teamID, olderThan, options)
+	if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	ReturnJSON(w, results, http.StatusOK)
+}
+
+// reassignTasks handles the POST /runs/reassign endpoint, assigning the incomplete checklist
+// items of a user to another one in the runs the user making the request can manage.
+func (h *PlaybookRunHandler) reassignTasks(c *Context, w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get("Mattermost-User-ID")
+
+	var options app.ReassignTasksOptions
+	if err := json.NewDecoder(r.Body).Decode(&options); err != nil {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "unable to decode reassign options", err)
+		return
+	}
+
+	if err := options.Validate(); err != nil {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	result, err := h.playbookRunService.ReassignTasks(userID, options)
+	if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	ReturnJSON(w, result, http.StatusOK)
+}
+
+// getPlaybookRunsBatch handles the POST /runs/batch endpoint, returning the runs of the given IDs
+// that the user can view. The others are reported as omitted instead of failing the request.
+func (h *PlaybookRunHandler) getPlaybookRunsBatch(c *Context, w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get("Mattermost-User-ID")
+
+	var options app.PlaybookRunsBatchOptions
+	if err := json.NewDecoder(r.Body).Decode(&options); err != nil {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "unable to decode batch options", err)
+		return
+	}
+
+	if err := options.Validate(); err != nil {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	result, err := h.playbookRunService.GetPlaybookRunsBatch(userID, options)
+	if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	ReturnJSON(w, result, http.StatusOK)
+}
+
 // getPlaybookRun handles the /runs/{id} endpoint.
 func (h *PlaybookRunHandler) getPlaybookRun(c *Context, w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
@@ -658,6 +1052,52 @@ func (h *PlaybookRunHandler) getOwners(c *Context, w http.ResponseWriter, r *htt
 	ReturnJSON(w, owners, http.StatusOK)
 }
 
+// getTaskLoad handles the GET /runs/task-load endpoint, returning the incomplete assigned
+// checklist items and owned runs of the users with work in the active runs of a team, counting
+// only the runs visible to the requester.
+func (h *PlaybookRunHandler) getTaskLoad(c *Context, w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get("Mattermost-User-ID")
+	query := r.URL.Query()
+
+	limit := 0
+	if param := query.Get("limit"); param != "" {
+		var err error
+		limit, err = strconv.Atoi(param)
+		if err != nil {
+			h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "bad parameter 'limit'", err)
+			return
+		}
+	}
+
+	options, err := app.TaskLoadOptions{
+		TeamID: query.Get("team_id"),
+		Sort:   app.TaskLoadSort(query.Get("sort")),
+		Limit:  limit,
+	}.Validate()
+	if err != nil {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "Bad parameter", err)
+		return
+	}
+
+	if !h.PermissionsCheck(w, c.logger, h.permissions.TeamMembersView(userID, options.TeamID)) {
+		return
+	}
+
+	requesterInfo, err := h.getRequesterInfo(userID)
+	if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	taskLoad, err := h.playbookRunService.GetTaskLoad(requesterInfo, options)
+	if err != nil {
+		h.HandleError(w, c.logger, errors.Wrapf(err, "failed to get task load"))
+		return
+	}
+
+	ReturnJSON(w, taskLoad, http.StatusOK)
+}
+
 func (h *PlaybookRunHandler) getChannels(c *Context, w http.ResponseWriter, r *http.Request) {
 	userID := r.Header.Get("Mattermost-User-ID")
 
@@ -700,31 +1140,782 @@ func (h *PlaybookRunHandler) changeOwner(c *Context, w http.ResponseWriter, r *h
 		return
 	}
 
-	if err := h.playbookRunService.ChangeOwner(vars["id"], userID, params.OwnerID); err != nil {
+	if err := h.playbookRunService.ChangeOwner(vars["id"], userID, params.OwnerID); err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	ReturnJSON(w, map[string]interface{}{}, http.StatusOK)
+}
+
+// addCoOwner handles the POST /runs/{id}/co-owners endpoint, user has edit permissions
+func (h *PlaybookRunHandler) addCoOwner(c *Context, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID := r.Header.Get("Mattermost-User-ID")
+
+	var params struct {
+		UserID string `json:"user_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "could not decode request body", err)
+		return
+	}
+
+	if params.UserID == "" {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "missing user id of co-owner", nil)
+		return
+	}
+
+	err := h.playbookRunService.AddCoOwner(vars["id"], userID, params.UserID)
+	if errors.Is(err, app.ErrInvalidCoOwner) {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "the owner of the run can't be a co-owner", err)
+		return
+	} else if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	ReturnJSON(w, map[string]interface{}{}, http.StatusOK)
+}
+
+// removeCoOwner handles the DELETE /runs/{id}/co-owners/{userID} endpoint, user has edit permissions
+func (h *PlaybookRunHandler) removeCoOwner(c *Context, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID := r.Header.Get("Mattermost-User-ID")
+
+	if err := h.playbookRunService.RemoveCoOwner(vars["id"], userID, vars["userID"]); err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	ReturnJSON(w, map[string]interface{}{}, http.StatusOK)
+}
+
+// setParticipantRole handles the PUT /runs/{id}/participants/{userID}/role endpoint, making a
+// participant an active participant or a viewer. User has edit permissions.
+func (h *PlaybookRunHandler) setParticipantRole(c *Context, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID := r.Header.Get("Mattermost-User-ID")
+
+	var params struct {
+		Role app.ParticipantRole `json:"role"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "could not decode request body", err)
+		return
+	}
+
+	err := h.playbookRunService.SetParticipantRole(vars["id"], vars["userID"], params.Role, userID)
+	if errors.Is(err, app.ErrInvalidParticipantRole) {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "invalid participant role", err)
+		return
+	} else if errors.Is(err, app.ErrNoPermissions) {
+		h.HandleErrorWithCode(w, c.logger, http.StatusForbidden, "not authorized", err)
+		return
+	} else if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	ReturnJSON(w, map[string]interface{}{}, http.StatusOK)
+}
+
+// addTags handles the POST /runs/{id}/tags endpoint, user has edit permissions
+func (h *PlaybookRunHandler) addTags(c *Context, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	var params struct {
+		Tags []string `json:"tags"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "could not decode request body", err)
+		return
+	}
+
+	err := h.playbookRunService.AddTags(vars["id"], params.Tags)
+	if errors.Is(err, app.ErrInvalidRunTag) {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "invalid tag", err)
+		return
+	} else if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	ReturnJSON(w, map[string]interface{}{}, http.StatusOK)
+}
+
+// removeTag handles the DELETE /runs/{id}/tags/{tag} endpoint, user has edit permissions
+func (h *PlaybookRunHandler) removeTag(c *Context, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	err := h.playbookRunService.RemoveTag(vars["id"], vars["tag"])
+	if errors.Is(err, app.ErrInvalidRunTag) {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "invalid tag", err)
+		return
+	} else if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	ReturnJSON(w, map[string]interface{}{}, http.StatusOK)
+}
+
+// getTeamTags handles the GET /runs/tags endpoint, suggesting the tags already used by the runs
+// of the team_id query parameter that start with its prefix query parameter.
+func (h *PlaybookRunHandler) getTeamTags(c *Context, w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get("Mattermost-User-ID")
+	query := r.URL.Query()
+
+	teamID := query.Get("team_id")
+	if !model.IsValidId(teamID) {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "Bad parameter 'team_id': must be 26 characters", nil)
+		return
+	}
+
+	if !h.PermissionsCheck(w, c.logger, h.permissions.RunTagsView(userID, teamID)) {
+		return
+	}
+
+	tags, err := h.playbookRunService.GetTeamTags(teamID, query.Get("prefix"))
+	if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	if tags == nil {
+		tags = []string{}
+	}
+
+	ReturnJSON(w, tags, http.StatusOK)
+}
+
+// getSavedFilters handles the GET /runs/saved-filters endpoint, returning the saved filters of the
+// team given by the team_id query parameter that the user can see.
+func (h *PlaybookRunHandler) getSavedFilters(c *Context, w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get("Mattermost-User-ID")
+
+	teamID := r.URL.Query().Get("team_id")
+	if !model.IsValidId(teamID) {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "Bad parameter 'team_id': must be 26 characters", nil)
+		return
+	}
+
+	if !h.PermissionsCheck(w, c.logger, h.permissions.SavedFiltersList(userID, teamID)) {
+		return
+	}
+
+	filters, err := h.savedFilters.GetSavedFiltersForUser(userID, teamID)
+	if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	ReturnJSON(w, filters, http.StatusOK)
+}
+
+func (h *PlaybookRunHandler) createSavedFilter(c *Context, w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get("Mattermost-User-ID")
+
+	var filter app.SavedFilter
+	if err := json.NewDecoder(r.Body).Decode(&filter); err != nil {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "unable to decode saved filter", err)
+		return
+	}
+	filter.ID = ""
+
+	if !model.IsValidId(filter.TeamID) {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "Bad parameter 'team_id': must be 26 characters", nil)
+		return
+	}
+
+	if !h.PermissionsCheck(w, c.logger, h.permissions.SavedFiltersList(userID, filter.TeamID)) {
+		return
+	}
+
+	filter, err := h.savedFilters.CreateSavedFilter(filter, userID)
+	if errors.Is(err, app.ErrMalformedSavedFilter) {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, err.Error(), err)
+		return
+	} else if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	w.Header().Add("Location", makeAPIURL(h.pluginAPI, "runs/saved-filters/%s", filter.ID))
+	ReturnJSON(w, &filter, http.StatusCreated)
+}
+
+func (h *PlaybookRunHandler) updateSavedFilter(c *Context, w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get("Mattermost-User-ID")
+
+	filter, ok := h.getSavedFilter(c, w, mux.Vars(r)["savedFilterID"], userID)
+	if !ok {
+		return
+	}
+
+	if !h.PermissionsCheck(w, c.logger, h.permissions.SavedFilterManage(userID, filter)) {
+		return
+	}
+
+	var update app.SavedFilter
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "unable to decode saved filter", err)
+		return
+	}
+	update.ID = filter.ID
+
+	updated, err := h.savedFilters.UpdateSavedFilter(update)
+	if errors.Is(err, app.ErrMalformedSavedFilter) {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, err.Error(), err)
+		return
+	} else if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	ReturnJSON(w, &updated, http.StatusOK)
+}
+
+func (h *PlaybookRunHandler) deleteSavedFilter(c *Context, w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get("Mattermost-User-ID")
+
+	filter, ok := h.getSavedFilter(c, w, mux.Vars(r)["savedFilterID"], userID)
+	if !ok {
+		return
+	}
+
+	if !h.PermissionsCheck(w, c.logger, h.permissions.SavedFilterManage(userID, filter)) {
+		return
+	}
+
+	if err := h.savedFilters.DeleteSavedFilter(filter.ID); err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getSavedFilterRuns handles the GET /runs/saved-filters/{savedFilterID}/runs endpoint, returning
+// the page of runs matching the saved filter given by the page and per_page query parameters.
+func (h *PlaybookRunHandler) getSavedFilterRuns(c *Context, w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get("Mattermost-User-ID")
+	query := r.URL.Query()
+
+	filter, ok := h.getSavedFilter(c, w, mux.Vars(r)["savedFilterID"], userID)
+	if !ok {
+		return
+	}
+
+	filterOptions, err := h.savedFilters.RunFilterOptions(filter)
+	if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	if page := query.Get("page"); page != "" {
+		if filterOptions.Page, err = strconv.Atoi(page); err != nil || filterOptions.Page < 0 {
+			h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "Bad parameter 'page'", err)
+			return
+		}
+	}
+	if perPage := query.Get("per_page"); perPage != "" {
+		parsed, err := strconv.Atoi(perPage)
+		if err != nil || parsed < 0 {
+			h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "Bad parameter 'per_page'", err)
+			return
+		}
+		// Zero keeps the default page size.
+		if parsed > 0 {
+			filterOptions.PerPage = parsed
+		}
+	}
+
+	requesterInfo, err := h.getRequesterInfo(userID)
+	if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	results, err := h.playbookRunService.GetPlaybookRuns(requesterInfo, filterOptions)
+	if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	ReturnJSON(w, results, http.StatusOK)
+}
+
+// getSavedFilter fetches a saved filter, checking that the user can view it. It writes the error
+// response and returns false on failure.
+func (h *PlaybookRunHandler) getSavedFilter(c *Context, w http.ResponseWriter, savedFilterID, userID string) (app.SavedFilter, bool) {
+	filter, err := h.savedFilters.GetSavedFilter(savedFilterID)
+	if errors.Is(err, app.ErrNotFound) {
+		h.HandleErrorWithCode(w, c.logger, http.StatusNotFound, "saved filter not found", err)
+		return app.SavedFilter{}, false
+	} else if err != nil {
+		h.HandleError(w, c.logger, err)
+		return app.SavedFilter{}, false
+	}
+
+	if !h.PermissionsCheck(w, c.logger, h.permissions.SavedFilterView(userID, filter)) {
+		return app.SavedFilter{}, false
+	}
+
+	return filter, true
+}
+
+// getPropertyValues handles the GET /runs/{id}/properties endpoint.
+func (h *PlaybookRunHandler) getPropertyValues(c *Context, w http.ResponseWriter, r *http.Request) {
+	playbookRunID := mux.Vars(r)["id"]
+	userID := r.Header.Get("Mattermost-User-ID")
+
+	if !h.PermissionsCheck(w, c.logger, h.permissions.RunView(userID, playbookRunID)) {
+		return
+	}
+
+	playbookRun, err := h.playbookRunService.GetPlaybookRun(playbookRunID)
+	if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	propertyValues := playbookRun.PropertyValues
+	if propertyValues == nil {
+		propertyValues = []app.PropertyValue{}
+	}
+
+	ReturnJSON(w, propertyValues, http.StatusOK)
+}
+
+// getCommandOutput handles the GET /runs/{id}/command-outputs/{outputID} endpoint, returning the
+// output of the slash command of a checklist item of the run.
+func (h *PlaybookRunHandler) getCommandOutput(c *Context, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	playbookRunID := vars["id"]
+	userID := r.Header.Get("Mattermost-User-ID")
+
+	if !h.PermissionsCheck(w, c.logger, h.permissions.RunView(userID, playbookRunID)) {
+		return
+	}
+
+	output, err := h.playbookRunService.GetCommandOutput(playbookRunID, vars["outputID"])
+	if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	ReturnJSON(w, output, http.StatusOK)
+}
+
+// getRunActivity handles the GET /runs/{id}/activity endpoint, returning a page of the run's
+// activity feed after the cursor query parameter.
+func (h *PlaybookRunHandler) getRunActivity(c *Context, w http.ResponseWriter, r *http.Request) {
+	playbookRunID := mux.Vars(r)["id"]
+	userID := r.Header.Get("Mattermost-User-ID")
+	query := r.URL.Query()
+
+	if !h.PermissionsCheck(w, c.logger, h.permissions.RunView(userID, playbookRunID)) {
+		return
+	}
+
+	options := app.RunActivityOptions{Cursor: query.Get("cursor")}
+	if perPage := query.Get("per_page"); perPage != "" {
+		var err error
+		if options.PerPage, err = strconv.Atoi(perPage); err != nil {
+			h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "Bad parameter 'per_page'", err)
+			return
+		}
+	}
+
+	results, err := h.playbookRunService.GetRunActivity(playbookRunID, options)
+	if errors.Is(err, app.ErrInvalidActivityCursor) {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "Bad parameter 'cursor'", err)
+		return
+	} else if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	ReturnJSON(w, results, http.StatusOK)
+}
+
+// autocompleteRunUsers handles the GET /runs/{id}/users/autocomplete endpoint, returning the users
+// of the team of the run starting with the q parameter, members of the channel of the run first.
+func (h *PlaybookRunHandler) autocompleteRunUsers(c *Context, w http.ResponseWriter, r *http.Request) {
+	playbookRunID := mux.Vars(r)["id"]
+	userID := r.Header.Get("Mattermost-User-ID")
+	query := r.URL.Query()
+
+	if !h.PermissionsCheck(w, c.logger, h.permissions.RunView(userID, playbookRunID)) {
+		return
+	}
+
+	limit := 0
+	if limitParam := query.Get("limit"); limitParam != "" {
+		var err error
+		if limit, err = strconv.Atoi(limitParam); err != nil || limit < 0 {
+			h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "Bad parameter 'limit'", err)
+			return
+		}
+	}
+
+	users, err := h.playbookRunService.AutocompleteRunUsers(userID, playbookRunID, query.Get("q"), limit)
+	if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	if users == nil {
+		users = []app.RunUserAutocompleteResult{}
+	}
+
+	ReturnJSON(w, users, http.StatusOK)
+}
+
+// setPropertyValue handles the PUT /runs/{id}/properties/{definitionID} endpoint, user has edit permissions
+func (h *PlaybookRunHandler) setPropertyValue(c *Context, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID := r.Header.Get("Mattermost-User-ID")
+
+	var params struct {
+		Value string `json:"value"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "could not decode request body", err)
+		return
+	}
+
+	err := h.playbookRunService.SetPropertyValue(vars["id"], userID, vars["definitionID"], params.Value)
+	if errors.Is(err, app.ErrNotFound) {
+		h.HandleErrorWithCode(w, c.logger, http.StatusNotFound, "property not found in the run's playbook", err)
+		return
+	} else if errors.Is(err, app.ErrInvalidPropertyValue) {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, err.Error(), err)
+		return
+	} else if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	ReturnJSON(w, map[string]interface{}{}, http.StatusOK)
+}
+
+// updateStatusD handles the POST /runs/{id}/status endpoint, user has edit permissions
+func (h *PlaybookRunHandler) status(c *Context, w http.ResponseWriter, r *http.Request) {
+	playbookRunID := mux.Vars(r)["id"]
+	userID := r.Header.Get("Mattermost-User-ID")
+
+	var options app.StatusUpdateOptions
+	if err := json.NewDecoder(r.Body).Decode(&options); err != nil {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "unable to decode body into StatusUpdateOptions", err)
+		return
+	}
+
+	broadcastResults, publicMsg, internalErr := h.updateStatus(playbookRunID, userID, options)
+	if internalErr != nil {
+		if errors.Is(internalErr, app.ErrNoPermissions) {
+			h.HandleErrorWithCode(w, c.logger, http.StatusForbidden, publicMsg, internalErr)
+		} else {
+			h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, publicMsg, internalErr)
+		}
+		return
+	}
+
+	ReturnJSON(w, statusUpdateResponse{Status: "OK", BroadcastResults: broadcastResults}, http.StatusOK)
+}
+
+// statusUpdateResponse is the response to a status update, with the result of its broadcast to
+// each of the broadcast channels of the run.
+type statusUpdateResponse struct {
+	Status           string                `json:"status"`
+	BroadcastResults []app.BroadcastResult `json:"broadcast_results"`
+}
+
+// updateStatus returns the broadcast results, a publicMessage and an internal error
+func (h *PlaybookRunHandler) updateStatus(playbookRunID, userID string, options app.StatusUpdateOptions) ([]app.BroadcastResult, string, error) {
+	playbookRunToModify, err := h.playbookRunService.GetPlaybookRun(playbookRunID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := h.permissions.RunUpdateStatus(userID, playbookRunToModify); err != nil {
+		return nil, "Not authorized", err
+	}
+
+	if options.FinishRun && playbookRunToModify.MissingRequiredRetrospective() {
+		return nil, app.ErrRetrospectiveRequired.Error(), app.ErrRetrospectiveRequired
+	}
+
+	if options.TemplateID != "" {
+		template, err := playbookRunToModify.GetStatusUpdateTemplate(options.TemplateID)
+		if err != nil {
+			return nil, "unknown status update template", err
+		}
+		if strings.TrimSpace(options.Message) == "" {
+			options.Message = template.Template
+		}
+	}
+
+	options.Message = strings.TrimSpace(options.Message)
+	if options.Message == "" {
+		return nil, "message must not be empty", errors.New("message field empty")
+	}
+
+	if options.Reminder <= 0 && !options.FinishRun {
+		return nil, "the reminder must be set and not 0", errors.New("reminder was 0")
+	}
+	if options.Reminder < 0 || options.FinishRun {
+		options.Reminder = 0
+	}
+	options.Reminder = options.Reminder * time.Second
+
+	broadcastResults, err := h.playbookRunService.UpdateStatus(playbookRunID, userID, options)
+	if err != nil {
+		return nil, "An internal error has occurred. Check app server logs for details.", err
+	}
+
+	if options.FinishRun {
+		if err := h.playbookRunService.FinishPlaybookRun(playbookRunID, userID); err != nil {
+			return nil, "An internal error has occurred. Check app server logs for details.", err
+		}
+	}
+
+	return broadcastResults, "", nil
+}
+
+// retryStatusUpdateBroadcast handles the POST /runs/{id}/status/broadcast endpoint, broadcasting
+// the latest status update again to some broadcast channels of the run.
+func (h *PlaybookRunHandler) retryStatusUpdateBroadcast(c *Context, w http.ResponseWriter, r *http.Request) {
+	playbookRunID := mux.Vars(r)["id"]
+	userID := r.Header.Get("Mattermost-User-ID")
+
+	var params struct {
+		ChannelIDs []string `json:"channel_ids"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "unable to decode body", err)
+		return
+	}
+	if len(params.ChannelIDs) == 0 {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "channel_ids must not be empty", nil)
+		return
+	}
+
+	playbookRun, err := h.playbookRunService.GetPlaybookRun(playbookRunID)
+	if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	if !h.PermissionsCheck(w, c.logger, h.permissions.RunUpdateStatus(userID, playbookRun)) {
+		return
+	}
+
+	broadcastResults, err := h.playbookRunService.RetryStatusUpdateBroadcast(playbookRunID, userID, params.ChannelIDs)
+	if errors.Is(err, app.ErrInvalidBroadcastChannel) || errors.Is(err, app.ErrNoStatusUpdate) {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, err.Error(), err)
+		return
+	} else if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	ReturnJSON(w, broadcastResults, http.StatusOK)
+}
+
+// updateStatusD handles the POST /runs/{id}/finish endpoint, user has edit permissions
+func (h *PlaybookRunHandler) finish(c *Context, w http.ResponseWriter, r *http.Request) {
+	playbookRunID := mux.Vars(r)["id"]
+	userID := r.Header.Get("Mattermost-User-ID")
+
+	err := h.playbookRunService.FinishPlaybookRun(playbookRunID, userID)
+	if errors.Is(err, app.ErrRetrospectiveRequired) {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, err.Error(), err)
+		return
+	} else if errors.Is(err, app.ErrPlaybookRunNotActive) {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "unable to finish run", err)
+		return
+	} else if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(`{"status":"OK"}`))
+}
+
+// getStatusUpdates handles the GET /runs/{id}/status endpoint
+//
+// Our goal is to deliver status updates to any user (when playbook is public) or
+// any playbook member (when playbook is private). To do that we need to bypass the
+// permissions system and avoid checking channel membership.
+//
+// This approach will be deprecated as a step towards channel-playbook decoupling.
+func (h *PlaybookRunHandler) getStatusUpdates(c *Context, w http.ResponseWriter, r *http.Request) {
+	playbookRunID := mux.Vars(r)["id"]
+	userID := r.Header.Get("Mattermost-User-ID")
+
+	if !h.PermissionsCheck(w, c.logger, h.permissions.RunView(userID, playbookRunID)) {
+		h.HandleErrorWithCode(w, c.logger, http.StatusForbidden, "not authorized to get status updates", nil)
+		return
+	}
+
+	playbookRun, err := h.playbookRunService.GetPlaybookRun(playbookRunID)
+	if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	posts := make([]*app.StatusPostComplete, 0)
+	for _, p := range playbookRun.StatusPosts {
+		post, err := h.pluginAPI.Post.GetPost(p.ID)
+		if err != nil {
+			c.logger.WithError(err).WithField("post_id", p.ID).Error("statusUpdates: can not retrieve post")
+			continue
+		}
+
+		// Given the fact that we are bypassing some permissions,
+		// an additional check is added to limit the risk
+		if post.Type == "custom_run_update" {
+			posts = append(posts, app.NewStatusPostComplete(post))
+		}
+	}
+
+	// sort by creation date, so that the first element is the newest post
+	sort.Slice(posts, func(i, j int) bool {
+		return posts[i].CreateAt > posts[j].CreateAt
+	})
+
+	ReturnJSON(w, posts, http.StatusOK)
+}
+
+// exportPlaybookRun handles the GET /runs/{id}/export endpoint, rendering the run in the format
+// requested through the format query parameter (Markdown by default).
+func (h *PlaybookRunHandler) exportPlaybookRun(c *Context, w http.ResponseWriter, r *http.Request) {
+	playbookRunID := mux.Vars(r)["id"]
+	userID := r.Header.Get("Mattermost-User-ID")
+
+	if !h.PermissionsCheck(w, c.logger, h.permissions.RunView(userID, playbookRunID)) {
+		return
+	}
+
+	format := app.RunExportFormat(r.URL.Query().Get("format"))
+	if format == "" {
+		format = app.RunExportFormatMarkdown
+	}
+
+	export, err := h.playbookRunService.ExportPlaybookRun(playbookRunID, userID, format)
+	if errors.Is(err, app.ErrUnsupportedExportFormat) {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "unsupported export format", err)
+		return
+	} else if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(export)
+}
+
+// streamEvents handles the GET /runs/{id}/events endpoint, streaming the events sent to the
+// websocket for the run as server-sent events, until the client disconnects or can no longer view
+// the run.
+func (h *PlaybookRunHandler) streamEvents(c *Context, w http.ResponseWriter, r *http.Request) {
+	playbookRunID := mux.Vars(r)["id"]
+	userID := r.Header.Get("Mattermost-User-ID")
+
+	if !h.PermissionsCheck(w, c.logger, h.permissions.RunView(userID, playbookRunID)) {
+		return
+	}
+
+	events, unsubscribe := h.runEvents.Subscribe(playbookRunID)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	heartbeat := time.NewTicker(app.RunEventsHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	err := app.StreamRunEvents(r.Context(), w, events, heartbeat.C, func() error {
+		return h.permissions.RunView(userID, playbookRunID)
+	})
+	if err != nil {
+		c.logger.WithError(err).Debug("stream of run events ended")
+	}
+}
+
+// exportPlaybookRuns handles the GET /runs/export endpoint, streaming the runs of the team given
+// by the team_id query parameter as CSV. Runs the user cannot view are left out.
+func (h *PlaybookRunHandler) exportPlaybookRuns(c *Context, w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get("Mattermost-User-ID")
+
+	teamID := r.URL.Query().Get("team_id")
+	if !model.IsValidId(teamID) {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "Bad parameter 'team_id': must be 26 characters", nil)
+		return
+	}
+
+	if !h.PermissionsCheck(w, c.logger, h.permissions.RunExport(userID, teamID)) {
+		return
+	}
+
+	requesterInfo, err := h.getRequesterInfo(userID)
+	if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"runs-%s.csv\"", teamID))
+	w.WriteHeader(http.StatusOK)
+
+	// The status is already sent once rows are streamed, so a failure can only cut the export short.
+	if err := h.playbookRunService.ExportPlaybookRunsCSV(requesterInfo, teamID, w); err != nil {
+		c.logger.WithError(err).Error("failed to export runs")
+	}
+}
+
+// restore "un-finishes" a playbook run
+func (h *PlaybookRunHandler) restore(c *Context, w http.ResponseWriter, r *http.Request) {
+	playbookRunID := mux.Vars(r)["id"]
+	userID := r.Header.Get("Mattermost-User-ID")
+
+	if err := h.playbookRunService.RestorePlaybookRun(playbookRunID, userID); err != nil {
 		h.HandleError(w, c.logger, err)
 		return
 	}
 
-	ReturnJSON(w, map[string]interface{}{}, http.StatusOK)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(`{"status":"OK"}`))
 }
 
-// updateStatusD handles the POST /runs/{id}/status endpoint, user has edit permissions
-func (h *PlaybookRunHandler) status(c *Context, w http.ResponseWriter, r *http.Request) {
+// restart handles the POST /runs/{id}/restart endpoint, moving a finished run back to In Progress.
+// Only the owners of the run and those who can manage its playbook can restart it.
+func (h *PlaybookRunHandler) restart(c *Context, w http.ResponseWriter, r *http.Request) {
 	playbookRunID := mux.Vars(r)["id"]
 	userID := r.Header.Get("Mattermost-User-ID")
 
-	var options app.StatusUpdateOptions
-	if err := json.NewDecoder(r.Body).Decode(&options); err != nil {
-		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "unable to decode body into StatusUpdateOptions", err)
+	playbookRun, err := h.playbookRunService.GetPlaybookRun(playbookRunID)
+	if err != nil {
+		h.HandleError(w, c.logger, err)
 		return
 	}
 
-	if publicMsg, internalErr := h.updateStatus(playbookRunID, userID, options); internalErr != nil {
-		if errors.Is(internalErr, app.ErrNoPermissions) {
-			h.HandleErrorWithCode(w, c.logger, http.StatusForbidden, publicMsg, internalErr)
-		} else {
-			h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, publicMsg, internalErr)
-		}
+	if !h.PermissionsCheck(w, c.logger, h.permissions.RunRestart(userID, playbookRun)) {
+		return
+	}
+
+	err = h.playbookRunService.RestartPlaybookRun(playbookRunID, userID)
+	if errors.Is(err, app.ErrPlaybookRunNotActive) || errors.Is(err, app.ErrPlaybookRunNotFinished) {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "unable to restart run", err)
+		return
+	} else if err != nil {
+		h.HandleError(w, c.logger, err)
 		return
 	}
 
@@ -732,108 +1923,143 @@ func (h *PlaybookRunHandler) status(c *Context, w http.ResponseWriter, r *http.R
 	_, _ = w.Write([]byte(`{"status":"OK"}`))
 }
 
-// updateStatus returns a publicMessage and an internal error
-func (h *PlaybookRunHandler) updateStatus(playbookRunID, userID string, options app.StatusUpdateOptions) (string, error) {
-	playbookRunToModify, err := h.playbookRunService.GetPlaybookRun(playbookRunID)
+// clone handles the POST /runs/{id}/clone endpoint, user has edit permissions
+func (h *PlaybookRunHandler) clone(c *Context, w http.ResponseWriter, r *http.Request) {
+	playbookRunID := mux.Vars(r)["id"]
+	userID := r.Header.Get("Mattermost-User-ID")
+
+	copyParticipants, _ := strconv.ParseBool(r.URL.Query().Get("copy_participants"))
+	preserveState, _ := strconv.ParseBool(r.URL.Query().Get("preserve_state"))
+
+	playbookRun, err := h.playbookRunService.GetPlaybookRun(playbookRunID)
 	if err != nil {
-		return "", err
+		h.HandleError(w, c.logger, err)
+		return
 	}
 
-	if err := h.permissions.RunUpdateStatus(userID, playbookRunToModify); err != nil {
-		return "Not authorized", err
+	if playbookRun.PlaybookID != "" {
+		playbook, err := h.playbookService.Get(playbookRun.PlaybookID)
+		if err != nil {
+			h.HandleError(w, c.logger, err)
+			return
+		}
+
+		if !h.PermissionsCheck(w, c.logger, h.permissions.RunCreate(userID, playbook)) {
+			return
+		}
 	}
 
-	options.Message = strings.TrimSpace(options.Message)
-	if options.Message == "" {
-		return "message must not be empty", errors.New("message field empty")
+	channel, err := h.pluginAPI.Channel.Get(playbookRun.ChannelID)
+	if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
 	}
 
-	if options.Reminder <= 0 && !options.FinishRun {
-		return "the reminder must be set and not 0", errors.New("reminder was 0")
+	permission := model.PermissionCreatePrivateChannel
+	if channel.Type == model.ChannelTypeOpen {
+		permission = model.PermissionCreatePublicChannel
 	}
-	if options.Reminder < 0 || options.FinishRun {
-		options.Reminder = 0
+	if !h.pluginAPI.User.HasPermissionToTeam(userID, playbookRun.TeamID, permission) {
+		h.HandleErrorWithCode(w, c.logger, http.StatusForbidden, "You are not able to create the channel for the cloned run", nil)
+		return
 	}
-	options.Reminder = options.Reminder * time.Second
 
-	if err := h.playbookRunService.UpdateStatus(playbookRunID, userID, options); err != nil {
-		return "An internal error has occurred. Check app server logs for details.", err
+	clonedRun, err := h.playbookRunService.ClonePlaybookRun(playbookRunID, userID, app.ClonePlaybookRunOptions{
+		CopyParticipants: copyParticipants,
+		ResetItemStates:  !preserveState,
+	})
+	if errors.Is(err, app.ErrPlaybookArchived) {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "playbook is archived, cannot create a new run using an archived playbook", err)
+		return
+	} else if errors.Is(err, app.ErrPlaybookRunNotActive) {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "unable to clone run", err)
+		return
+	} else if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
 	}
 
-	if options.FinishRun {
-		if err := h.playbookRunService.FinishPlaybookRun(playbookRunID, userID); err != nil {
-			return "An internal error has occurred. Check app server logs for details.", err
-		}
+	// force database retrieval to ensure all data is processed correctly (i.e participantIds)
+	clonedRun, err = h.playbookRunService.GetPlaybookRun(clonedRun.ID)
+	if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
 	}
 
-	return "", nil
+	h.poster.PublishWebsocketEventToUser(app.PlaybookRunCreatedWSEvent, map[string]interface{}{
+		"playbook_run": clonedRun,
+	}, userID)
+
+	w.Header().Add("Location", fmt.Sprintf("/api/v0/runs/%s", clonedRun.ID))
+	ReturnJSON(w, &clonedRun, http.StatusCreated)
 }
 
-// updateStatusD handles the POST /runs/{id}/finish endpoint, user has edit permissions
-func (h *PlaybookRunHandler) finish(c *Context, w http.ResponseWriter, r *http.Request) {
+// merge handles the POST /runs/{id}/merge endpoint, user has edit permissions on both runs
+func (h *PlaybookRunHandler) merge(c *Context, w http.ResponseWriter, r *http.Request) {
 	playbookRunID := mux.Vars(r)["id"]
 	userID := r.Header.Get("Mattermost-User-ID")
 
-	if err := h.playbookRunService.FinishPlaybookRun(playbookRunID, userID); err != nil {
-		h.HandleError(w, c.logger, err)
+	var params struct {
+		SourceRunID string `json:"source_run_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "unable to decode body", err)
+		return
+	}
+	if params.SourceRunID == "" {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "source_run_id must not be empty", nil)
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
-	_, _ = w.Write([]byte(`{"status":"OK"}`))
-}
-
-// getStatusUpdates handles the GET /runs/{id}/status endpoint
-//
-// Our goal is to deliver status updates to any user (when playbook is public) or
-// any playbook member (when playbook is private). To do that we need to bypass the
-// permissions system and avoid checking channel membership.
-//
-// This approach will be deprecated as a step towards channel-playbook decoupling.
-func (h *PlaybookRunHandler) getStatusUpdates(c *Context, w http.ResponseWriter, r *http.Request) {
-	playbookRunID := mux.Vars(r)["id"]
-	userID := r.Header.Get("Mattermost-User-ID")
+	sourceRun, err := h.playbookRunService.GetPlaybookRun(params.SourceRunID)
+	if errors.Is(err, app.ErrNotFound) {
+		h.HandleErrorWithCode(w, c.logger, http.StatusNotFound, "source run not found", err)
+		return
+	} else if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
 
-	if !h.PermissionsCheck(w, c.logger, h.permissions.RunView(userID, playbookRunID)) {
-		h.HandleErrorWithCode(w, c.logger, http.StatusForbidden, "not authorized to get status updates", nil)
+	if !h.PermissionsCheck(w, c.logger, h.permissions.RunManageProperties(userID, sourceRun.ID)) {
 		return
 	}
 
-	playbookRun, err := h.playbookRunService.GetPlaybookRun(playbookRunID)
-	if err != nil {
+	mergedRun, err := h.playbookRunService.MergePlaybookRuns(playbookRunID, sourceRun.ID, userID)
+	if errors.Is(err, app.ErrInvalidRunMerge) || errors.Is(err, app.ErrPlaybookRunNotActive) {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "unable to merge runs", err)
+		return
+	} else if err != nil {
 		h.HandleError(w, c.logger, err)
 		return
 	}
 
-	posts := make([]*app.StatusPostComplete, 0)
-	for _, p := range playbookRun.StatusPosts {
-		post, err := h.pluginAPI.Post.GetPost(p.ID)
-		if err != nil {
-			c.logger.WithError(err).WithField("post_id", p.ID).Error("statusUpdates: can not retrieve post")
-			continue
-		}
+	ReturnJSON(w, mergedRun, http.StatusOK)
+}
 
-		// Given the fact that we are bypassing some permissions,
-		// an additional check is added to limit the risk
-		if post.Type == "custom_run_update" {
-			posts = append(posts, app.NewStatusPostComplete(post))
-		}
-	}
+// pause handles the PUT /runs/{id}/pause endpoint, user has edit permissions
+func (h *PlaybookRunHandler) pause(c *Context, w http.ResponseWriter, r *http.Request) {
+	playbookRunID := mux.Vars(r)["id"]
+	userID := r.Header.Get("Mattermost-User-ID")
 
-	// sort by creation date, so that the first element is the newest post
-	sort.Slice(posts, func(i, j int) bool {
-		return posts[i].CreateAt > posts[j].CreateAt
-	})
+	err := h.playbookRunService.PausePlaybookRun(playbookRunID, userID)
+	if errors.Is(err, app.ErrPlaybookRunNotActive) {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "unable to pause run", err)
+		return
+	} else if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
 
-	ReturnJSON(w, posts, http.StatusOK)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(`{"status":"OK"}`))
 }
 
-// restore "un-finishes" a playbook run
-func (h *PlaybookRunHandler) restore(c *Context, w http.ResponseWriter, r *http.Request) {
+// resume handles the PUT /runs/{id}/resume endpoint, user has edit permissions
+func (h *PlaybookRunHandler) resume(c *Context, w http.ResponseWriter, r *http.Request) {
 	playbookRunID := mux.Vars(r)["id"]
 	userID := r.Header.Get("Mattermost-User-ID")
 
-	if err := h.playbookRunService.RestorePlaybookRun(playbookRunID, userID); err != nil {
+	if err := h.playbookRunService.ResumePlaybookRun(playbookRunID, userID); err != nil {
 		h.HandleError(w, c.logger, err)
 		return
 	}
@@ -891,7 +2117,14 @@ func (h *PlaybookRunHandler) finishDialog(c *Context, w http.ResponseWriter, r *
 		return
 	}
 
-	if err := h.playbookRunService.FinishPlaybookRun(playbookRunID, userID); err != nil {
+	err := h.playbookRunService.FinishPlaybookRun(playbookRunID, userID)
+	if errors.Is(err, app.ErrRetrospectiveRequired) {
+		respBytes, _ := json.Marshal(&model.SubmitDialogResponse{
+			Error: "Publish the retrospective of this run before finishing it.",
+		})
+		_, _ = w.Write(respBytes)
+		return
+	} else if err != nil {
 		h.HandleError(w, c.logger, err)
 		return
 	}
@@ -954,7 +2187,16 @@ func (h *PlaybookRunHandler) updateStatusDialog(c *Context, w http.ResponseWrite
 		}
 	}
 
-	if publicMsg, internalErr := h.updateStatus(playbookRunID, userID, options); internalErr != nil {
+	if _, publicMsg, internalErr := h.updateStatus(playbookRunID, userID, options); internalErr != nil {
+		if errors.Is(internalErr, app.ErrRetrospectiveRequired) {
+			respBytes, _ := json.Marshal(&model.SubmitDialogResponse{
+				Errors: map[string]string{
+					app.DialogFieldFinishRun: "Publish the retrospective of this run before finishing it.",
+				},
+			})
+			_, _ = w.Write(respBytes)
+			return
+		}
 		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, publicMsg, internalErr)
 		return
 	}
@@ -1067,6 +2309,68 @@ func (h *PlaybookRunHandler) removeTimelineEvent(c *Context, w http.ResponseWrit
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// addKeyEvent handles the POST /runs/{id}/timeline endpoint, adding a key event to the timeline.
+// User has been authenticated to edit the playbook run.
+func (h *PlaybookRunHandler) addKeyEvent(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !h.licenseChecker.TimelineAllowed() {
+		h.HandleErrorWithCode(w, c.logger, http.StatusForbidden, "timeline feature is not covered by current server license", nil)
+		return
+	}
+
+	playbookRunID := mux.Vars(r)["id"]
+	userID := r.Header.Get("Mattermost-User-ID")
+
+	var options app.KeyEventOptions
+	if err := json.NewDecoder(r.Body).Decode(&options); err != nil {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "unable to decode key event", err)
+		return
+	}
+
+	options, err := options.Validate()
+	if err != nil {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	event, err := h.playbookRunService.AddKeyEvent(playbookRunID, userID, options)
+	if errors.Is(err, app.ErrNotFound) {
+		h.HandleErrorWithCode(w, c.logger, http.StatusNotFound, "post not found", err)
+		return
+	} else if errors.Is(err, app.ErrInvalidKeyEvent) {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, err.Error(), err)
+		return
+	} else if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	ReturnJSON(w, event, http.StatusCreated)
+}
+
+// removeKeyEvent handles the DELETE /runs/{id}/timeline/key-events/{eventID} endpoint, which only
+// removes the events that users added to the timeline.
+// User has been authenticated to edit the playbook run.
+func (h *PlaybookRunHandler) removeKeyEvent(c *Context, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	playbookRunID := vars["id"]
+	eventID := vars["eventID"]
+	userID := r.Header.Get("Mattermost-User-ID")
+
+	err := h.playbookRunService.RemoveKeyEvent(playbookRunID, userID, eventID)
+	if errors.Is(err, app.ErrNotFound) {
+		h.HandleErrorWithCode(w, c.logger, http.StatusNotFound, "timeline event not found", err)
+		return
+	} else if errors.Is(err, app.ErrTimelineEventNotUserCreated) {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, err.Error(), err)
+		return
+	} else if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func (h *PlaybookRunHandler) updateDescription(c *Context, w http.ResponseWriter, r *http.Request) {
 	playbookRunID := mux.Vars(r)["id"]
 	userID := r.Header.Get("Mattermost-User-ID")
@@ -1162,7 +2466,8 @@ func (h *PlaybookRunHandler) itemSetState(c *Context, w http.ResponseWriter, r *
 	userID := r.Header.Get("Mattermost-User-ID")
 
 	var params struct {
-		NewState string `json:"new_state"`
+		NewState   string `json:"new_state"`
+		SkipReason string `json:"skip_reason"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
 		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "failed to unmarshal", err)
@@ -1174,7 +2479,17 @@ func (h *PlaybookRunHandler) itemSetState(c *Context, w http.ResponseWriter, r *
 		return
 	}
 
-	if err := h.playbookRunService.ModifyCheckedState(id, userID, params.NewState, checklistNum, itemNum); err != nil {
+	skipReason := strings.TrimSpace(params.SkipReason)
+	if err := app.ValidateChecklistItemSkipReason(skipReason); err != nil {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	err = h.playbookRunService.ModifyCheckedState(id, userID, params.NewState, skipReason, checklistNum, itemNum)
+	if errors.Is(err, app.ErrChecklistItemBlocked) {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, err.Error(), err)
+		return
+	} else if err != nil {
 		h.HandleError(w, c.logger, err)
 		return
 	}
@@ -1420,6 +2735,51 @@ func (h *PlaybookRunHandler) addChecklistItem(c *Context, w http.ResponseWriter,
 	w.WriteHeader(http.StatusCreated)
 }
 
+// addChecklistItemsFromPosts handles the POST /runs/{id}/checklists/{checklist}/add-from-posts
+// endpoint, appending the given posts of the run channel, or its most recent ones, as checklist items.
+func (h *PlaybookRunHandler) addChecklistItemsFromPosts(c *Context, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+	checklistNum, err := strconv.Atoi(vars["checklist"])
+	if err != nil {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "failed to parse checklist", err)
+		return
+	}
+	userID := r.Header.Get("Mattermost-User-ID")
+
+	var options app.AddChecklistItemsFromPostsOptions
+	if err = json.NewDecoder(r.Body).Decode(&options); err != nil {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "unable to decode options", err)
+		return
+	}
+
+	if err = options.Validate(); err != nil {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	playbookRun, err := h.playbookRunService.GetPlaybookRun(id)
+	if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	// The participants of a run are not necessarily members of its channel.
+	if !h.pluginAPI.User.HasPermissionToChannel(userID, playbookRun.ChannelID, model.PermissionReadChannel) {
+		h.HandleErrorWithCode(w, c.logger, http.StatusForbidden, "Not authorized",
+			errors.Errorf("user %s cannot read the posts of channel %s", userID, playbookRun.ChannelID))
+		return
+	}
+
+	result, err := h.playbookRunService.AddChecklistItemsFromPosts(id, userID, checklistNum, options)
+	if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	ReturnJSON(w, result, http.StatusOK)
+}
+
 // addChecklistItemDialog handles the interactive dialog submission when a user clicks add new task
 func (h *PlaybookRunHandler) addChecklistItemDialog(c *Context, w http.ResponseWriter, r *http.Request) {
 	userID := r.Header.Get("Mattermost-User-ID")
@@ -1596,17 +2956,37 @@ func (h *PlaybookRunHandler) itemEdit(c *Context, w http.ResponseWriter, r *http
 		Title       string `json:"title"`
 		Command     string `json:"command"`
 		Description string `json:"description"`
+		DueDate     *int64 `json:"due_date"`
+		DueOffset   *int64 `json:"due_offset"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
 		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "failed to unmarshal edit params state", err)
 		return
 	}
 
+	if (params.DueDate != nil || params.DueOffset != nil) && !h.licenseChecker.ChecklistItemDueDateAllowed() {
+		h.HandleErrorWithCode(w, c.logger, http.StatusForbidden, "checklist item due date feature is not covered by current server license", nil)
+		return
+	}
+
 	if err := h.playbookRunService.EditChecklistItem(id, userID, checklistNum, itemNum, params.Title, params.Command, params.Description); err != nil {
 		h.HandleError(w, c.logger, err)
 		return
 	}
 
+	// A relative due date takes precedence over an absolute one, since the latter is derived from it.
+	if params.DueOffset != nil {
+		if err := h.playbookRunService.SetDueOffset(id, userID, *params.DueOffset, checklistNum, itemNum); err != nil {
+			h.HandleError(w, c.logger, err)
+			return
+		}
+	} else if params.DueDate != nil {
+		if err := h.playbookRunService.SetDueDate(id, userID, *params.DueDate, checklistNum, itemNum); err != nil {
+			h.HandleError(w, c.logger, err)
+			return
+		}
+	}
+
 	w.WriteHeader(http.StatusOK)
 }
 
@@ -1688,6 +3068,38 @@ func (h *PlaybookRunHandler) moveChecklistItem(c *Context, w http.ResponseWriter
 	w.WriteHeader(http.StatusOK)
 }
 
+// reorderChecklistItems handles the PUT /runs/{id}/checklists/{checklist}/reorder endpoint, setting
+// the order of all the items in the checklist at once.
+func (h *PlaybookRunHandler) reorderChecklistItems(c *Context, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id := vars["id"]
+	checklistNum, err := strconv.Atoi(vars["checklist"])
+	if err != nil {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "failed to parse checklist", err)
+		return
+	}
+	userID := r.Header.Get("Mattermost-User-ID")
+
+	var params struct {
+		ItemIDs []string `json:"item_ids"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "failed to unmarshal reorder params", err)
+		return
+	}
+
+	if err := h.playbookRunService.ReorderChecklistItems(id, userID, checklistNum, params.ItemIDs); err != nil {
+		if errors.Is(err, app.ErrInvalidChecklistItemOrder) {
+			h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "invalid item order", err)
+			return
+		}
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
 func (h *PlaybookRunHandler) postPlaybookRunCreatedMessage(playbookRun *app.PlaybookRun, channelID string) error {
 	channel, err := h.pluginAPI.Channel.Get(playbookRun.ChannelID)
 	if err != nil {
@@ -1833,6 +3245,11 @@ func parsePlaybookRunsFilterOptions(u *url.URL, currentUserID string) (*app.Play
 		participantOrFollowerID = currentUserID
 	}
 
+	assigneeID := u.Query().Get("assignee_id")
+	if assigneeID == client.Me {
+		assigneeID = currentUserID
+	}
+
 	playbookID := u.Query().Get("playbook_id")
 
 	activeGTEParam := u.Query().Get("active_gte")
@@ -1859,6 +3276,20 @@ func parsePlaybookRunsFilterOptions(u *url.URL, currentUserID string) (*app.Play
 	}
 	startedLT, _ := strconv.ParseInt(startedLTParam, 10, 64)
 
+	// Parse property=<definition id>:<value> query string parameters as an array.
+	var propertyValues []app.PropertyValue
+	for _, property := range u.Query()["property"] {
+		definitionID, value, found := strings.Cut(property, ":")
+		if !found {
+			return nil, errors.Errorf("bad parameter 'property': expected <property definition id>:<value>, got %q", property)
+		}
+		propertyValues = append(propertyValues, app.PropertyValue{PropertyDefinitionID: definitionID, Value: value})
+	}
+
+	// Parse tags= query string parameters as an array.
+	tags := u.Query()["tags"]
+	tagsMatchAll, _ := strconv.ParseBool(u.Query().Get("tags_match_all"))
+
 	options := app.PlaybookRunFilterOptions{
 		TeamID:                  teamID,
 		Page:                    page,
@@ -1870,11 +3301,15 @@ func parsePlaybookRunsFilterOptions(u *url.URL, currentUserID string) (*app.Play
 		SearchTerm:              searchTerm,
 		ParticipantID:           participantID,
 		ParticipantOrFollowerID: participantOrFollowerID,
+		AssigneeID:              assigneeID,
 		PlaybookID:              playbookID,
 		ActiveGTE:               activeGTE,
 		ActiveLT:                activeLT,
 		StartedGTE:              startedGTE,
 		StartedLT:               startedLT,
+		PropertyValues:          propertyValues,
+		Tags:                    tags,
+		TagsMatchAll:            tagsMatchAll,
 	}
 
 	options, err = options.Validate()
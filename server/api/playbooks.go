@@ -3,6 +3,7 @@ package api
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -27,19 +28,21 @@ type PlaybookHandler struct {
 	pluginAPI       *pluginapi.Client
 	config          config.Service
 	permissions     *app.PermissionsService
+	runScheduler    *app.RunScheduler
 }
 
 const SettingsKey = "global_settings"
 const maxPlaybooksToAutocomplete = 15
 
 // NewPlaybookHandler returns a new playbook api handler
-func NewPlaybookHandler(router *mux.Router, playbookService app.PlaybookService, api *pluginapi.Client, configService config.Service, permissions *app.PermissionsService) *PlaybookHandler {
+func NewPlaybookHandler(router *mux.Router, playbookService app.PlaybookService, api *pluginapi.Client, configService config.Service, permissions *app.PermissionsService, runScheduler *app.RunScheduler) *PlaybookHandler {
 	handler := &PlaybookHandler{
 		ErrorHandler:    &ErrorHandler{},
 		playbookService: playbookService,
 		pluginAPI:       api,
 		config:          configService,
 		permissions:     permissions,
+		runScheduler:    runScheduler,
 	}
 
 	playbooksRouter := router.PathPrefix("/playbooks").Subrouter()
@@ -57,6 +60,9 @@ func NewPlaybookHandler(router *mux.Router, playbookService app.PlaybookService,
 	playbookRouter.HandleFunc("/restore", withContext(handler.restorePlaybook)).Methods(http.MethodPut)
 	playbookRouter.HandleFunc("/export", withContext(handler.exportPlaybook)).Methods(http.MethodGet)
 	playbookRouter.HandleFunc("/duplicate", withContext(handler.duplicatePlaybook)).Methods(http.MethodPost)
+	playbookRouter.HandleFunc("/copy", withContext(handler.copyPlaybookTemplate)).Methods(http.MethodPost)
+	playbookRouter.HandleFunc("/versions", withContext(handler.getPlaybookVersions)).Methods(http.MethodGet)
+	playbookRouter.HandleFunc("/versions/{version:[0-9]+}", withContext(handler.getPlaybookVersion)).Methods(http.MethodGet)
 
 	autoFollowsRouter := playbookRouter.PathPrefix("/autofollows").Subrouter()
 	autoFollowsRouter.HandleFunc("", withContext(handler.getAutoFollows)).Methods(http.MethodGet)
@@ -64,6 +70,13 @@ func NewPlaybookHandler(router *mux.Router, playbookService app.PlaybookService,
 	autoFollowRouter.HandleFunc("", withContext(handler.autoFollow)).Methods(http.MethodPut)
 	autoFollowRouter.HandleFunc("", withContext(handler.autoUnfollow)).Methods(http.MethodDelete)
 
+	scheduledRunsRouter := playbookRouter.PathPrefix("/scheduled-runs").Subrouter()
+	scheduledRunsRouter.HandleFunc("", withContext(handler.getScheduledRuns)).Methods(http.MethodGet)
+	scheduledRunsRouter.HandleFunc("", withContext(handler.createScheduledRun)).Methods(http.MethodPost)
+	scheduledRunRouter := scheduledRunsRouter.PathPrefix("/{scheduledRunID:[A-Za-z0-9]+}").Subrouter()
+	scheduledRunRouter.HandleFunc("", withContext(handler.updateScheduledRun)).Methods(http.MethodPut)
+	scheduledRunRouter.HandleFunc("", withContext(handler.deleteScheduledRun)).Methods(http.MethodDelete)
+
 	insightsRouter := playbooksRouter.PathPrefix("/insights").Subrouter()
 	insightsRouter.HandleFunc("/user/me", withContext(handler.getTopPlaybooksForUser)).Methods(http.MethodGet)
 	insightsRouter.HandleFunc("/teams/{teamID}", withContext(handler.getTopPlaybooksForTeam)).Methods(http.MethodGet)
@@ -86,6 +99,16 @@ func (h *PlaybookHandler) validPlaybook(w http.ResponseWriter, logger logrus.Fie
 		}
 	}
 
+	if err := app.ValidateArchiveChannelOnFinishDelay(playbook.ArchiveChannelOnFinishDelayMinutes); err != nil {
+		h.HandleErrorWithCode(w, logger, http.StatusBadRequest, err.Error(), err)
+		return false
+	}
+
+	if playbook.RetrospectiveRequired && !playbook.RetrospectiveEnabled {
+		h.HandleErrorWithCode(w, logger, http.StatusBadRequest, "a retrospective can only be required if retrospectives are enabled", nil)
+		return false
+	}
+
 	if playbook.CategorizeChannelEnabled {
 		if err := app.ValidateCategoryName(playbook.CategoryName); err != nil {
 			h.HandleErrorWithCode(w, logger, http.StatusBadRequest, "invalid category name", err)
@@ -93,6 +116,34 @@ func (h *PlaybookHandler) validPlaybook(w http.ResponseWriter, logger logrus.Fie
 		}
 	}
 
+	if err := app.ValidateChecklistItemConditions(playbook.Checklists); err != nil {
+		h.HandleErrorWithCode(w, logger, http.StatusBadRequest, err.Error(), err)
+		return false
+	}
+
+	isTeamMember := func(userID string) bool {
+		return app.IsMemberOfTeam(userID, playbook.TeamID, h.pluginAPI)
+	}
+	if err := app.ValidateChecklistItemDefaultAssignees(playbook.Checklists, isTeamMember); err != nil {
+		h.HandleErrorWithCode(w, logger, http.StatusBadRequest, err.Error(), err)
+		return false
+	}
+
+	if err := app.ValidateChecklistItemDependencies(playbook.Checklists); err != nil {
+		h.HandleErrorWithCode(w, logger, http.StatusBadRequest, err.Error(), err)
+		return false
+	}
+
+	if err := app.ValidatePropertyDefinitions(playbook.PropertyDefinitions); err != nil {
+		h.HandleErrorWithCode(w, logger, http.StatusBadRequest, err.Error(), err)
+		return false
+	}
+
+	if err := app.PrepareStatusUpdateTemplates(playbook.StatusUpdateTemplates); err != nil {
+		h.HandleErrorWithCode(w, logger, http.StatusBadRequest, err.Error(), err)
+		return false
+	}
+
 	if len(playbook.SignalAnyKeywords) != 0 {
 		playbook.SignalAnyKeywords = app.ProcessSignalAnyKeywords(playbook.SignalAnyKeywords)
 	}
@@ -158,6 +209,9 @@ func (h *PlaybookHandler) createPlaybook(c *Context, w http.ResponseWriter, r *h
 
 	cleanUpChecklist(playbook.Checklists)
 
+	// Only playbooks copied from a template have a template source
+	playbook.TemplateSourceID = ""
+
 	id, err := h.playbookService.Create(playbook, userID)
 	if err != nil {
 		h.HandleError(w, c.logger, err)
@@ -246,8 +300,12 @@ func cleanUpChecklist(checklists []app.Checklist) {
 			checklists[listIndex].Items[itemIndex].AssigneeID = ""
 			checklists[listIndex].Items[itemIndex].AssigneeModified = 0
 			checklists[listIndex].Items[itemIndex].State = ""
+			checklists[listIndex].Items[itemIndex].SkipReason = ""
 			checklists[listIndex].Items[itemIndex].StateModified = 0
+			checklists[listIndex].Items[itemIndex].StateModifiedBy = ""
+			checklists[listIndex].Items[itemIndex].DueOffset = 0
 			checklists[listIndex].Items[itemIndex].CommandLastRun = 0
+			checklists[listIndex].Items[itemIndex].CommandOutputID = ""
 		}
 	}
 }
@@ -310,7 +368,8 @@ func (h *PlaybookHandler) getPlaybooks(c *Context, w http.ResponseWriter, r *htt
 		return
 	}
 
-	if teamID != "" && !h.PermissionsCheck(w, c.logger, h.permissions.PlaybookList(userID, teamID)) {
+	// Templates can be listed from every team
+	if teamID != "" && !opts.TemplatesOnly && !h.PermissionsCheck(w, c.logger, h.permissions.PlaybookList(userID, teamID)) {
 		return
 	}
 
@@ -320,6 +379,14 @@ func (h *PlaybookHandler) getPlaybooks(c *Context, w http.ResponseWriter, r *htt
 		IsAdmin: app.IsSystemAdmin(userID, h.pluginAPI),
 	}
 
+	if opts.TemplatesOnly {
+		requesterInfo.IsGuest, err = app.IsGuest(userID, h.pluginAPI)
+		if err != nil {
+			h.HandleError(w, c.logger, err)
+			return
+		}
+	}
+
 	playbookResults, err := h.playbookService.GetPlaybooksForTeam(requesterInfo, teamID, opts)
 	if err != nil {
 		h.HandleError(w, c.logger, err)
@@ -427,13 +494,16 @@ func parseGetPlaybooksOptions(u *url.URL) (app.PlaybookFilterOptions, error) {
 
 	withArchived, _ := strconv.ParseBool(u.Query().Get("with_archived"))
 
+	templatesOnly, _ := strconv.ParseBool(u.Query().Get("templates_only"))
+
 	return app.PlaybookFilterOptions{
-		Sort:         sortField,
-		Direction:    sortDirection,
-		Page:         page,
-		PerPage:      perPage,
-		SearchTerm:   searchTerm,
-		WithArchived: withArchived,
+		Sort:          sortField,
+		Direction:     sortDirection,
+		Page:          page,
+		PerPage:       perPage,
+		SearchTerm:    searchTerm,
+		WithArchived:  withArchived,
+		TemplatesOnly: templatesOnly,
 	}, nil
 }
 
@@ -498,6 +568,143 @@ func (h *PlaybookHandler) getAutoFollows(c *Context, w http.ResponseWriter, r *h
 	ReturnJSON(w, autoFollowers, http.StatusOK)
 }
 
+// getScheduledRuns returns the recurring run schedules attached to the playbook
+func (h *PlaybookHandler) getScheduledRuns(c *Context, w http.ResponseWriter, r *http.Request) {
+	playbookID := mux.Vars(r)["id"]
+	userID := r.Header.Get("Mattermost-User-ID")
+
+	if !h.PermissionsCheck(w, c.logger, h.permissions.PlaybookView(userID, playbookID)) {
+		return
+	}
+
+	scheduledRuns, err := h.runScheduler.GetScheduledRunsForPlaybook(playbookID)
+	if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	ReturnJSON(w, scheduledRuns, http.StatusOK)
+}
+
+func (h *PlaybookHandler) createScheduledRun(c *Context, w http.ResponseWriter, r *http.Request) {
+	playbookID := mux.Vars(r)["id"]
+	userID := r.Header.Get("Mattermost-User-ID")
+
+	playbook, err := h.playbookService.Get(playbookID)
+	if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	if !h.PermissionsCheck(w, c.logger, h.permissions.PlaybookManageProperties(userID, playbook)) {
+		return
+	}
+
+	if !h.PermissionsCheck(w, c.logger, h.permissions.RunCreate(userID, playbook)) {
+		return
+	}
+
+	if playbook.DeleteAt != 0 {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "Playbook cannot be modified", fmt.Errorf("playbook with id '%s' cannot be scheduled because it is archived", playbook.ID))
+		return
+	}
+
+	var scheduledRun app.ScheduledRun
+	if err = json.NewDecoder(r.Body).Decode(&scheduledRun); err != nil {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "unable to decode scheduled run", err)
+		return
+	}
+	scheduledRun.ID = ""
+	scheduledRun.PlaybookID = playbook.ID
+
+	scheduledRun, err = h.runScheduler.CreateScheduledRun(scheduledRun, userID)
+	if errors.Is(err, app.ErrMalformedScheduledRun) {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, err.Error(), err)
+		return
+	} else if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	w.Header().Add("Location", makeAPIURL(h.pluginAPI, "playbooks/%s/scheduled-runs/%s", playbook.ID, scheduledRun.ID))
+	ReturnJSON(w, &scheduledRun, http.StatusCreated)
+}
+
+func (h *PlaybookHandler) updateScheduledRun(c *Context, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID := r.Header.Get("Mattermost-User-ID")
+
+	playbook, scheduledRun, ok := h.getScheduledRunToModify(c, w, vars["id"], vars["scheduledRunID"], userID)
+	if !ok {
+		return
+	}
+
+	var update app.ScheduledRun
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "unable to decode scheduled run", err)
+		return
+	}
+	update.ID = scheduledRun.ID
+
+	if update.Enabled && playbook.DeleteAt != 0 {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "Playbook cannot be modified", fmt.Errorf("playbook with id '%s' cannot be scheduled because it is archived", playbook.ID))
+		return
+	}
+
+	updated, err := h.runScheduler.UpdateScheduledRun(update)
+	if errors.Is(err, app.ErrMalformedScheduledRun) {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, err.Error(), err)
+		return
+	} else if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	ReturnJSON(w, &updated, http.StatusOK)
+}
+
+func (h *PlaybookHandler) deleteScheduledRun(c *Context, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID := r.Header.Get("Mattermost-User-ID")
+
+	_, scheduledRun, ok := h.getScheduledRunToModify(c, w, vars["id"], vars["scheduledRunID"], userID)
+	if !ok {
+		return
+	}
+
+	if err := h.runScheduler.DeleteScheduledRun(scheduledRun.ID); err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getScheduledRunToModify fetches the playbook and one of its schedules, checking that the user
+// can manage the playbook. It writes the error response and returns false on failure.
+func (h *PlaybookHandler) getScheduledRunToModify(c *Context, w http.ResponseWriter, playbookID, scheduledRunID, userID string) (app.Playbook, app.ScheduledRun, bool) {
+	playbook, err := h.playbookService.Get(playbookID)
+	if err != nil {
+		h.HandleError(w, c.logger, err)
+		return app.Playbook{}, app.ScheduledRun{}, false
+	}
+
+	if !h.PermissionsCheck(w, c.logger, h.permissions.PlaybookManageProperties(userID, playbook)) {
+		return app.Playbook{}, app.ScheduledRun{}, false
+	}
+
+	scheduledRun, err := h.runScheduler.GetScheduledRun(scheduledRunID)
+	if errors.Is(err, app.ErrNotFound) || (err == nil && scheduledRun.PlaybookID != playbook.ID) {
+		h.HandleErrorWithCode(w, c.logger, http.StatusNotFound, "scheduled run not found", err)
+		return app.Playbook{}, app.ScheduledRun{}, false
+	} else if err != nil {
+		h.HandleError(w, c.logger, err)
+		return app.Playbook{}, app.ScheduledRun{}, false
+	}
+
+	return playbook, scheduledRun, true
+}
+
 func (h *PlaybookHandler) exportPlaybook(c *Context, w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	playbookID := vars["id"]
@@ -524,6 +731,50 @@ func (h *PlaybookHandler) exportPlaybook(c *Context, w http.ResponseWriter, r *h
 	_, _ = w.Write(export)
 }
 
+func (h *PlaybookHandler) getPlaybookVersions(c *Context, w http.ResponseWriter, r *http.Request) {
+	playbookID := mux.Vars(r)["id"]
+	userID := r.Header.Get("Mattermost-User-ID")
+
+	if !h.PermissionsCheck(w, c.logger, h.permissions.PlaybookView(userID, playbookID)) {
+		return
+	}
+
+	versions, err := h.playbookService.GetVersions(playbookID)
+	if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	ReturnJSON(w, versions, http.StatusOK)
+}
+
+func (h *PlaybookHandler) getPlaybookVersion(c *Context, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	playbookID := vars["id"]
+	userID := r.Header.Get("Mattermost-User-ID")
+
+	version, err := strconv.ParseInt(vars["version"], 10, 64)
+	if err != nil {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "invalid version", err)
+		return
+	}
+
+	if !h.PermissionsCheck(w, c.logger, h.permissions.PlaybookView(userID, playbookID)) {
+		return
+	}
+
+	versionDiff, err := h.playbookService.GetVersionDiff(playbookID, version)
+	if errors.Is(err, app.ErrNotFound) {
+		h.HandleErrorWithCode(w, c.logger, http.StatusNotFound, "Not found", err)
+		return
+	} else if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	ReturnJSON(w, versionDiff, http.StatusOK)
+}
+
 func (h *PlaybookHandler) duplicatePlaybook(c *Context, w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	playbookID := vars["id"]
@@ -557,27 +808,80 @@ func (h *PlaybookHandler) duplicatePlaybook(c *Context, w http.ResponseWriter, r
 	ReturnJSON(w, &result, http.StatusCreated)
 }
 
-func (h *PlaybookHandler) importPlaybook(c *Context, w http.ResponseWriter, r *http.Request) {
-	params := r.URL.Query()
-	teamID := params.Get("team_id")
+// copyPlaybookTemplate handles the POST /playbooks/{id}/copy endpoint, creating a new playbook in
+// the given team from the template with the given ID.
+func (h *PlaybookHandler) copyPlaybookTemplate(c *Context, w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	templateID := vars["id"]
 	userID := r.Header.Get("Mattermost-User-ID")
-	var importBlock struct {
-		app.Playbook
-		Version int `json:"version"`
+
+	var params struct {
+		TeamID string `json:"team_id"`
+		Title  string `json:"title"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&importBlock); err != nil {
-		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "unable to decode playbook import", err)
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "unable to decode copy params", err)
 		return
 	}
-	playbook := importBlock.Playbook
 
-	if playbook.ID != "" {
-		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "playbook import should not have ID field", nil)
+	if params.TeamID == "" {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "missing team_id", nil)
+		return
+	}
+
+	template, err := h.playbookService.Get(templateID)
+	if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	if !h.PermissionsCheck(w, c.logger, h.permissions.PlaybookViewTemplate(userID, template)) {
+		return
+	}
+
+	if !h.PermissionsCheck(w, c.logger, h.permissions.PlaybookCreate(userID, template.CopyFromTemplate(params.TeamID, params.Title))) {
+		return
+	}
+
+	newPlaybookID, err := h.playbookService.CreateFromTemplate(templateID, params.TeamID, params.Title, userID)
+	if err != nil {
+		h.HandleError(w, c.logger, err)
 		return
 	}
 
-	if importBlock.Version != app.CurrentPlaybookExportVersion {
-		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "Unsupported import version", nil)
+	result := struct {
+		ID string `json:"id"`
+	}{
+		ID: newPlaybookID,
+	}
+	ReturnJSON(w, &result, http.StatusCreated)
+}
+
+// MaxPlaybookImportSize is the size limit of the document of a playbook import.
+const MaxPlaybookImportSize = 1024 * 1024 // 1MB
+
+// importPlaybook handles the POST /playbooks/import endpoint, creating a playbook from a
+// document in the export format. The document is validated against the format of its version.
+func (h *PlaybookHandler) importPlaybook(c *Context, w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	teamID := params.Get("team_id")
+	userID := r.Header.Get("Mattermost-User-ID")
+
+	r.Body = http.MaxBytesReader(w, r.Body, MaxPlaybookImportSize)
+	data, err := io.ReadAll(r.Body)
+
+	// The reader fails once it has read up to the limit: the body is larger.
+	if err != nil && len(data) == MaxPlaybookImportSize {
+		h.HandleErrorWithCode(w, c.logger, http.StatusRequestEntityTooLarge, fmt.Sprintf("playbook import is larger than %d bytes", MaxPlaybookImportSize), err)
+		return
+	} else if err != nil {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "unable to read playbook import", err)
+		return
+	}
+
+	playbook, err := app.ParsePlaybookImport(data)
+	if err != nil {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, err.Error(), err)
 		return
 	}
 
@@ -632,6 +936,10 @@ func (h *PlaybookHandler) validateMetrics(pb app.Playbook) error {
 			return errors.Errorf("metrics names must be unique")
 		}
 		titles[m.Title] = true
+
+		if err := app.ValidateMetricDirection(m.Direction); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -4,6 +4,8 @@ import (
 	"math"
 	"net/http"
 	"net/url"
+	"strconv"
+	"time"
 
 	"github.com/mattermost/mattermost-plugin-playbooks/server/app"
 	"github.com/mattermost/mattermost-server/v6/model"
@@ -38,6 +40,7 @@ func NewStatsHandler(router *mux.Router, api *pluginapi.Client, statsStore *sqls
 	statsRouter := router.PathPrefix("/stats").Subrouter()
 	statsRouter.HandleFunc("/site", withContext(handler.playbookSiteStats)).Methods(http.MethodGet)
 	statsRouter.HandleFunc("/playbook", withContext(handler.playbookStats)).Methods(http.MethodGet)
+	statsRouter.HandleFunc("/completion-velocity", withContext(handler.runCompletionVelocity)).Methods(http.MethodGet)
 
 	return handler
 }
@@ -58,7 +61,12 @@ type PlaybookStats struct {
 	MetricRollingAverageChange    []null.Int `json:"metric_rolling_average_change"`
 	MetricValueRange              [][]int64  `json:"metric_value_range"`
 	MetricRollingValues           [][]int64  `json:"metric_rolling_values"`
+	MetricBreachingRuns           []null.Int `json:"metric_breaching_runs"`
+	RunsBreachingMetricTargets    int        `json:"runs_breaching_metric_targets"`
 	LastXRunNames                 []string   `json:"last_x_run_names"`
+	AverageRunDuration            null.Int   `json:"average_run_duration"`
+	RunsStartedPrev30Days         int        `json:"runs_started_prev_30_days"`
+	MeanTimeBetweenRunsPrev30Days null.Int   `json:"mean_time_between_runs_prev_30_days"`
 }
 
 const (
@@ -113,6 +121,9 @@ func (h *StatsHandler) playbookStats(c *Context, w http.ResponseWriter, r *http.
 	metricRollingAverage, metricRollingAverageChange := h.statsStore.MetricRollingAverageAndChange(MetricRollingAveragePeriod, *filters)
 	metricValueRange := h.statsStore.MetricValueRange(*filters)
 
+	now := model.GetMillis()
+	runFrequency := h.statsStore.RunFrequencyBetween(filters, now-30*24*time.Hour.Milliseconds(), now+1)
+
 	ReturnJSON(w, &PlaybookStats{
 		RunsInProgress:                h.statsStore.TotalInProgressPlaybookRuns(filters),
 		ParticipantsActive:            h.statsStore.TotalActiveParticipants(filters),
@@ -129,10 +140,62 @@ func (h *StatsHandler) playbookStats(c *Context, w http.ResponseWriter, r *http.
 		MetricValueRange:              metricValueRange,
 		MetricRollingAverage:          metricRollingAverage,
 		MetricRollingAverageChange:    metricRollingAverageChange,
+		MetricBreachingRuns:           h.statsStore.MetricBreachingRuns(*filters),
+		RunsBreachingMetricTargets:    h.statsStore.RunsBreachingMetricTargets(*filters),
 		LastXRunNames:                 lastXRunNames,
+		AverageRunDuration:            h.statsStore.AverageRunDuration(filters),
+		RunsStartedPrev30Days:         runFrequency.RunsStarted,
+		MeanTimeBetweenRunsPrev30Days: runFrequency.MeanTimeBetweenRuns,
 	}, http.StatusOK)
 }
 
+// runCompletionVelocity handles the GET /stats/completion-velocity endpoint, returning the
+// cumulative number of checklist items completed in the run_id run by the end of each interval,
+// given in seconds by the optional interval parameter, from the start of the run.
+func (h *StatsHandler) runCompletionVelocity(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !h.licenseChecker.StatsAllowed() {
+		h.HandleErrorWithCode(w, c.logger, http.StatusForbidden, "stats feature is not covered by current server license", nil)
+		return
+	}
+
+	userID := r.Header.Get("Mattermost-User-ID")
+	query := r.URL.Query()
+
+	playbookRunID := query.Get("run_id")
+	if playbookRunID == "" {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "bad parameter 'run_id'; 'run_id' is required", nil)
+		return
+	}
+
+	var interval time.Duration
+	if param := query.Get("interval"); param != "" {
+		seconds, err := strconv.ParseInt(param, 10, 64)
+		if err != nil || seconds < int64(sqlstore.MinCompletionVelocityInterval/time.Second) || seconds > int64(sqlstore.MaxCompletionVelocityInterval/time.Second) {
+			h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "bad parameter 'interval'; must be a number of seconds from one hour to 365 days", err)
+			return
+		}
+		interval = time.Duration(seconds) * time.Second
+	}
+
+	if !h.PermissionsCheck(w, c.logger, h.permissions.RunView(userID, playbookRunID)) {
+		return
+	}
+
+	points, err := h.statsStore.RunCompletionVelocity(playbookRunID, interval, model.GetMillis())
+	if errors.Is(err, app.ErrNotFound) {
+		h.HandleErrorWithCode(w, c.logger, http.StatusNotFound, "run not found", err)
+		return
+	} else if errors.Is(err, app.ErrInvalidStatsInterval) {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "bad parameter 'interval'", err)
+		return
+	} else if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	ReturnJSON(w, points, http.StatusOK)
+}
+
 type PlaybookSiteStats struct {
 	TotalPlaybooks    int `json:"total_playbooks"`
 	TotalPlaybookRuns int `json:"total_playbook_runs"`
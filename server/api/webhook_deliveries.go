@@ -0,0 +1,87 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/mattermost/mattermost-plugin-playbooks/server/app"
+	"github.com/pkg/errors"
+
+	pluginapi "github.com/mattermost/mattermost-plugin-api"
+)
+
+// WebhookDeliveryHandler is the API handler for the outgoing webhook deliveries.
+type WebhookDeliveryHandler struct {
+	*ErrorHandler
+	pluginAPI         *pluginapi.Client
+	webhookDispatcher *app.WebhookDispatcher
+}
+
+// NewWebhookDeliveryHandler returns a new webhook delivery api handler
+func NewWebhookDeliveryHandler(router *mux.Router, api *pluginapi.Client, webhookDispatcher *app.WebhookDispatcher) *WebhookDeliveryHandler {
+	handler := &WebhookDeliveryHandler{
+		ErrorHandler:      &ErrorHandler{},
+		pluginAPI:         api,
+		webhookDispatcher: webhookDispatcher,
+	}
+
+	webhookDeliveriesRouter := router.PathPrefix("/webhook-deliveries").Subrouter()
+	webhookDeliveriesRouter.HandleFunc("", withContext(handler.getWebhookDeliveries)).Methods(http.MethodGet)
+
+	return handler
+}
+
+// getWebhookDeliveries handles the GET /webhook-deliveries endpoint, listing the webhooks that
+// failed at least once. Only available to system admins.
+func (h *WebhookDeliveryHandler) getWebhookDeliveries(c *Context, w http.ResponseWriter, r *http.Request) {
+	userID := r.Header.Get("Mattermost-User-ID")
+
+	if !app.IsSystemAdmin(userID, h.pluginAPI) {
+		h.HandleErrorWithCode(w, c.logger, http.StatusForbidden, "not authorized", errors.New("only system admins can list webhook deliveries"))
+		return
+	}
+
+	options, err := parseWebhookDeliveryFilterOptions(r)
+	if err != nil {
+		h.HandleErrorWithCode(w, c.logger, http.StatusBadRequest, "bad parameter", err)
+		return
+	}
+
+	deliveries, err := h.webhookDispatcher.GetWebhookDeliveries(options)
+	if err != nil {
+		h.HandleError(w, c.logger, err)
+		return
+	}
+
+	ReturnJSON(w, deliveries, http.StatusOK)
+}
+
+func parseWebhookDeliveryFilterOptions(r *http.Request) (app.WebhookDeliveryFilterOptions, error) {
+	query := r.URL.Query()
+	options := app.WebhookDeliveryFilterOptions{}
+
+	for _, status := range query["status"] {
+		switch app.WebhookDeliveryStatus(status) {
+		case app.WebhookDeliveryPending, app.WebhookDeliveryDelivered, app.WebhookDeliveryFailed:
+			options.Statuses = append(options.Statuses, app.WebhookDeliveryStatus(status))
+		default:
+			return app.WebhookDeliveryFilterOptions{}, errors.Errorf("bad parameter 'status': unknown status %q", status)
+		}
+	}
+
+	var err error
+	if page := query.Get("page"); page != "" {
+		if options.Page, err = strconv.Atoi(page); err != nil {
+			return app.WebhookDeliveryFilterOptions{}, errors.Wrap(err, "bad parameter 'page'")
+		}
+	}
+
+	if perPage := query.Get("per_page"); perPage != "" {
+		if options.PerPage, err = strconv.Atoi(perPage); err != nil {
+			return app.WebhookDeliveryFilterOptions{}, errors.Wrap(err, "bad parameter 'per_page'")
+		}
+	}
+
+	return options, nil
+}
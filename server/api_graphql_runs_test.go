@@ -7,6 +7,7 @@ import (
 
 	"github.com/graph-gophers/graphql-go"
 	"github.com/mattermost/mattermost-plugin-playbooks/client"
+	"github.com/mattermost/mattermost-plugin-playbooks/server/app"
 	"github.com/mattermost/mattermost-server/v6/app/request"
 	"github.com/mattermost/mattermost-server/v6/model"
 	"github.com/stretchr/testify/assert"
@@ -90,6 +91,239 @@ func TestGraphQLRunList(t *testing.T) {
 	})
 }
 
+func TestGraphQLRunWithChecklists(t *testing.T) {
+	e := Setup(t)
+	e.CreateBasic()
+
+	playbookID, err := e.PlaybooksAdminClient.Playbooks.Create(context.Background(), client.PlaybookCreateOptions{
+		Title:  "TestPlaybook with checklists",
+		TeamID: e.BasicTeam.Id,
+		Public: true,
+		Checklists: []client.Checklist{
+			{
+				Title: "A",
+				Items: []client.ChecklistItem{
+					{Title: "Open item"},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	run, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+		Name:        "Run with checklists",
+		OwnerUserID: e.RegularUser.Id,
+		TeamID:      e.BasicTeam.Id,
+		PlaybookID:  playbookID,
+	})
+	require.NoError(t, err)
+
+	err = e.PlaybooksClient.PlaybookRuns.AddChecklistItem(context.Background(), run.ID, 0, client.ChecklistItem{
+		Title: "Done item",
+		State: app.ChecklistItemStateClosed,
+	})
+	require.NoError(t, err)
+	err = e.PlaybooksClient.PlaybookRuns.SetItemAssignee(context.Background(), run.ID, 0, 0, e.RegularUser.Id)
+	require.NoError(t, err)
+	err = e.PlaybooksClient.PlaybookRuns.UpdateStatus(context.Background(), run.ID, "update 1", 600)
+	require.NoError(t, err)
+	err = e.PlaybooksClient.PlaybookRuns.UpdateStatus(context.Background(), run.ID, "update 2", 600)
+	require.NoError(t, err)
+
+	testRunQuery := `
+	query Run($id: String!) {
+		run(id: $id) {
+			id
+			completionPercentage
+			lastStatusUpdate {
+				message
+				authorUserName
+			}
+			checklists {
+				title
+				items {
+					title
+					assignee {
+						id
+						username
+					}
+				}
+			}
+		}
+	}
+	`
+
+	type runResult struct {
+		Data struct {
+			Run *struct {
+				ID                   string
+				CompletionPercentage float64
+				LastStatusUpdate     *struct {
+					Message        string
+					AuthorUserName string
+				}
+				Checklists []struct {
+					Title string
+					Items []struct {
+						Title    string
+						Assignee *struct {
+							ID       string
+							Username string
+						}
+					}
+				}
+			}
+		}
+		Errors []struct {
+			Message string
+			Path    []interface{}
+		}
+	}
+
+	t.Run("run with nested checklists in a single query", func(t *testing.T) {
+		var result runResult
+		err := e.PlaybooksClient.DoGraphql(context.Background(), &client.GraphQLInput{
+			Query:         testRunQuery,
+			OperationName: "Run",
+			Variables:     map[string]interface{}{"id": run.ID},
+		}, &result)
+		require.NoError(t, err)
+		require.Empty(t, result.Errors)
+
+		actual := result.Data.Run
+		require.NotNil(t, actual)
+		assert.Equal(t, run.ID, actual.ID)
+		assert.Equal(t, 50.0, actual.CompletionPercentage)
+		require.NotNil(t, actual.LastStatusUpdate)
+		assert.Equal(t, "update 2", actual.LastStatusUpdate.Message)
+		assert.Equal(t, e.RegularUser.Username, actual.LastStatusUpdate.AuthorUserName)
+
+		require.Len(t, actual.Checklists, 1)
+		items := actual.Checklists[0].Items
+		require.Len(t, items, 2)
+		require.NotNil(t, items[0].Assignee)
+		assert.Equal(t, e.RegularUser.Id, items[0].Assignee.ID)
+		assert.Equal(t, e.RegularUser.Username, items[0].Assignee.Username)
+		assert.Nil(t, items[1].Assignee)
+	})
+
+	t.Run("only the selected fields are returned", func(t *testing.T) {
+		var result struct {
+			Data struct {
+				Run map[string]interface{}
+			}
+		}
+		err := e.PlaybooksClient.DoGraphql(context.Background(), &client.GraphQLInput{
+			Query:         `query Run($id: String!) { run(id: $id) { name } }`,
+			OperationName: "Run",
+			Variables:     map[string]interface{}{"id": run.ID},
+		}, &result)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"name": run.Name}, result.Data.Run)
+	})
+
+	t.Run("viewer of the playbook", func(t *testing.T) {
+		var result runResult
+		err := e.PlaybooksClient2.DoGraphql(context.Background(), &client.GraphQLInput{
+			Query:         testRunQuery,
+			OperationName: "Run",
+			Variables:     map[string]interface{}{"id": run.ID},
+		}, &result)
+		require.NoError(t, err)
+		require.Empty(t, result.Errors)
+		require.NotNil(t, result.Data.Run)
+		require.NotNil(t, result.Data.Run.LastStatusUpdate)
+	})
+
+	t.Run("full names of assignees are hidden as in the REST API", func(t *testing.T) {
+		query := `
+		query Run($id: String!) {
+			run(id: $id) {
+				checklists {
+					items {
+						assignee {
+							id
+							firstName
+							lastName
+						}
+					}
+				}
+			}
+		}
+		`
+		type assigneesResult struct {
+			Data struct {
+				Run struct {
+					Checklists []struct {
+						Items []struct {
+							Assignee *struct {
+								ID        string
+								FirstName string
+								LastName  string
+							}
+						}
+					}
+				}
+			}
+			Errors []struct {
+				Message string
+			}
+		}
+
+		cfg := e.Srv.Config()
+		cfg.PrivacySettings.ShowFullName = model.NewBool(false)
+		_, _, err := e.ServerAdminClient.UpdateConfig(cfg)
+		require.NoError(t, err)
+		defer func() {
+			cfg.PrivacySettings.ShowFullName = model.NewBool(true)
+			_, _, err := e.ServerAdminClient.UpdateConfig(cfg)
+			require.NoError(t, err)
+		}()
+
+		for name, tc := range map[string]struct {
+			client       *client.Client
+			showFullName bool
+		}{
+			"regular user": {client: e.PlaybooksClient, showFullName: false},
+			"system admin": {client: e.PlaybooksAdminClient, showFullName: true},
+		} {
+			t.Run(name, func(t *testing.T) {
+				var result assigneesResult
+				err := tc.client.DoGraphql(context.Background(), &client.GraphQLInput{
+					Query:         query,
+					OperationName: "Run",
+					Variables:     map[string]interface{}{"id": run.ID},
+				}, &result)
+				require.NoError(t, err)
+				require.Empty(t, result.Errors)
+
+				assignee := result.Data.Run.Checklists[0].Items[0].Assignee
+				require.NotNil(t, assignee)
+				assert.Equal(t, e.RegularUser.Id, assignee.ID)
+				if tc.showFullName {
+					assert.Equal(t, e.RegularUser.FirstName, assignee.FirstName)
+					assert.Equal(t, e.RegularUser.LastName, assignee.LastName)
+				} else {
+					assert.Empty(t, assignee.FirstName)
+					assert.Empty(t, assignee.LastName)
+				}
+			})
+		}
+	})
+
+	t.Run("not in team", func(t *testing.T) {
+		var result runResult
+		err := e.PlaybooksClientNotInTeam.DoGraphql(context.Background(), &client.GraphQLInput{
+			Query:         testRunQuery,
+			OperationName: "Run",
+			Variables:     map[string]interface{}{"id": run.ID},
+		}, &result)
+		require.NoError(t, err)
+		require.NotEmpty(t, result.Errors)
+		require.Nil(t, result.Data.Run)
+	})
+}
+
 func TestGraphQLChangeRunParticipants(t *testing.T) {
 	e := Setup(t)
 	e.CreateBasic()
@@ -10,11 +10,13 @@ import (
 	"testing"
 
 	"github.com/mattermost/mattermost-plugin-playbooks/client"
+	"github.com/mattermost/mattermost-plugin-playbooks/server/api"
 	"github.com/mattermost/mattermost-plugin-playbooks/server/app"
 	"github.com/mattermost/mattermost-server/v6/model"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
 )
 
 func TestPlaybooks(t *testing.T) {
@@ -107,9 +109,25 @@ func TestPlaybooks(t *testing.T) {
 		err = e.PlaybooksClient.Playbooks.Update(context.Background(), *playbook)
 		assert.Nil(t, err)
 
+		run, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+			Name:        "run before archiving",
+			OwnerUserID: e.RegularUser.Id,
+			TeamID:      e.BasicTeam.Id,
+			PlaybookID:  id,
+		})
+		require.NoError(t, err)
+
 		err = e.PlaybooksClient.Playbooks.Archive(context.Background(), id)
 		assert.Nil(t, err)
 
+		// Test that existing runs still resolve the archived playbook
+		run, err = e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+		require.NoError(t, err)
+		archivedPlaybook, err := e.PlaybooksClient.Playbooks.Get(context.Background(), run.PlaybookID)
+		require.NoError(t, err)
+		assert.Equal(t, "New Title!", archivedPlaybook.Title)
+		assert.NotZero(t, archivedPlaybook.DeleteAt)
+
 		// Test that we cannot update an archived playbook
 		playbook.Title = "Another title"
 		err = e.PlaybooksClient.Playbooks.Update(context.Background(), *playbook)
@@ -122,7 +140,11 @@ func TestPlaybooks(t *testing.T) {
 			TeamID:      e.BasicTeam.Id,
 			PlaybookID:  id,
 		})
-		requireErrorWithStatusCode(t, err, http.StatusInternalServerError)
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+
+		// Nor to clone a run of it
+		_, err = e.PlaybooksClient.PlaybookRuns.Clone(context.Background(), run.ID, client.PlaybookRunCloneOptions{})
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
 	})
 
 	t.Run("playbooks can be searched by title", func(t *testing.T) {
@@ -1025,6 +1047,33 @@ func TestPlaybooksImportExport(t *testing.T) {
 
 		assert.Equal(t, e.BasicPlaybook.Title, newPlaybook.Title)
 		assert.NotEqual(t, e.BasicPlaybook.ID, newPlaybook.ID)
+
+		reexported, err := e.PlaybooksClient.Playbooks.Export(context.Background(), newPlaybookID)
+		require.NoError(t, err)
+		assert.JSONEq(t, string(result), string(reexported))
+	})
+
+	t.Run("Import rejects unknown fields", func(t *testing.T) {
+		_, err := e.PlaybooksClient.Playbooks.Import(context.Background(), []byte(`{"version": 1, "title": "Imported", "owner": "someone"}`), e.BasicTeam.Id)
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+		assert.Contains(t, err.Error(), "owner: unknown field")
+	})
+
+	t.Run("Import rejects type mismatches", func(t *testing.T) {
+		_, err := e.PlaybooksClient.Playbooks.Import(context.Background(), []byte(`{"version": 1, "title": "Imported", "checklists": [{"title": 12}]}`), e.BasicTeam.Id)
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+		assert.Contains(t, err.Error(), "checklists[0].title: expected string, got number")
+	})
+
+	t.Run("Import rejects unsupported versions", func(t *testing.T) {
+		_, err := e.PlaybooksClient.Playbooks.Import(context.Background(), []byte(`{"version": 99, "title": "Imported"}`), e.BasicTeam.Id)
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+	})
+
+	t.Run("Import rejects documents that are too large", func(t *testing.T) {
+		data := []byte(`{"version": 1, "title": "` + strings.Repeat("A", api.MaxPlaybookImportSize) + `"}`)
+		_, err := e.PlaybooksClient.Playbooks.Import(context.Background(), data, e.BasicTeam.Id)
+		requireErrorWithStatusCode(t, err, http.StatusRequestEntityTooLarge)
 	})
 }
 
@@ -1049,6 +1098,105 @@ func TestPlaybooksDuplicate(t *testing.T) {
 	})
 }
 
+func TestPlaybooksTemplates(t *testing.T) {
+	e := Setup(t)
+	e.CreateBasic()
+
+	templateID, err := e.PlaybooksAdminClient.Playbooks.Create(context.Background(), client.PlaybookCreateOptions{
+		Title:      "Golden playbook",
+		TeamID:     e.BasicTeam.Id,
+		Public:     true,
+		IsTemplate: true,
+		Checklists: []client.Checklist{
+			{
+				Title: "Triage",
+				Items: []client.ChecklistItem{{Title: "Page the on-call"}, {Title: "Open a ticket"}},
+			},
+		},
+		Metrics: []client.PlaybookMetricConfig{
+			{Title: "time to acknowledge", Type: app.MetricTypeDuration, Target: null.IntFrom(60000)},
+		},
+		BroadcastChannelIDs:                     []string{e.BasicPublicChannel.Id},
+		BroadcastEnabled:                        true,
+		CreateChannelMemberOnNewParticipant:     true,
+		RemoveChannelMemberOnRemovedParticipant: true,
+	})
+	require.NoError(t, err)
+
+	t.Run("templates are listed from every team", func(t *testing.T) {
+		results, err := e.PlaybooksClientNotInTeam.Playbooks.List(context.Background(), "", 0, 100, client.PlaybookListOptions{TemplatesOnly: true})
+		require.NoError(t, err)
+		require.Len(t, results.Items, 1)
+		require.Equal(t, templateID, results.Items[0].ID)
+		require.True(t, results.Items[0].IsTemplate)
+
+		results, err = e.PlaybooksClientNotInTeam.Playbooks.List(context.Background(), "", 0, 100, client.PlaybookListOptions{})
+		require.NoError(t, err)
+		require.Empty(t, results.Items)
+	})
+
+	t.Run("templates only filter excludes regular playbooks", func(t *testing.T) {
+		results, err := e.PlaybooksClient.Playbooks.List(context.Background(), e.BasicTeam.Id, 0, 100, client.PlaybookListOptions{TemplatesOnly: true})
+		require.NoError(t, err)
+		require.Len(t, results.Items, 1)
+		require.Equal(t, templateID, results.Items[0].ID)
+	})
+
+	t.Run("copy a template to another team", func(t *testing.T) {
+		copyID, err := e.PlaybooksClient.Playbooks.CopyFromTemplate(context.Background(), templateID, e.BasicTeam2.Id, "My copy")
+		require.NoError(t, err)
+		require.NotEqual(t, templateID, copyID)
+
+		template, err := e.PlaybooksAdminClient.Playbooks.Get(context.Background(), templateID)
+		require.NoError(t, err)
+
+		playbookCopy, err := e.PlaybooksClient.Playbooks.Get(context.Background(), copyID)
+		require.NoError(t, err)
+		assert.Equal(t, "My copy", playbookCopy.Title)
+		assert.Equal(t, e.BasicTeam2.Id, playbookCopy.TeamID)
+		assert.False(t, playbookCopy.IsTemplate)
+		assert.Equal(t, templateID, playbookCopy.TemplateSourceID)
+		assert.Equal(t, template.Checklists, playbookCopy.Checklists)
+		require.Len(t, playbookCopy.Metrics, 1)
+		assert.NotEqual(t, template.Metrics[0].ID, playbookCopy.Metrics[0].ID)
+		assert.Equal(t, template.Metrics[0].Title, playbookCopy.Metrics[0].Title)
+		assert.True(t, playbookCopy.CreateChannelMemberOnNewParticipant)
+		// the broadcast channel belongs to the template's team
+		assert.Empty(t, playbookCopy.BroadcastChannelIDs)
+
+		// editing the copy does not touch the template
+		playbookCopy.Description = "Edited copy"
+		playbookCopy.Checklists[0].Items = playbookCopy.Checklists[0].Items[:1]
+		err = e.PlaybooksClient.Playbooks.Update(context.Background(), *playbookCopy)
+		require.NoError(t, err)
+
+		unchanged, err := e.PlaybooksAdminClient.Playbooks.Get(context.Background(), templateID)
+		require.NoError(t, err)
+		assert.Equal(t, template.Description, unchanged.Description)
+		assert.Equal(t, template.Checklists, unchanged.Checklists)
+	})
+
+	t.Run("copy keeps the title when none is given", func(t *testing.T) {
+		copyID, err := e.PlaybooksClient.Playbooks.CopyFromTemplate(context.Background(), templateID, e.BasicTeam.Id, "")
+		require.NoError(t, err)
+
+		playbookCopy, err := e.PlaybooksClient.Playbooks.Get(context.Background(), copyID)
+		require.NoError(t, err)
+		assert.Equal(t, "Golden playbook", playbookCopy.Title)
+		assert.Equal(t, []string{e.BasicPublicChannel.Id}, playbookCopy.BroadcastChannelIDs)
+	})
+
+	t.Run("can't copy a playbook that is not a template", func(t *testing.T) {
+		_, err := e.PlaybooksClient.Playbooks.CopyFromTemplate(context.Background(), e.BasicPlaybook.ID, e.BasicTeam2.Id, "")
+		requireErrorWithStatusCode(t, err, http.StatusForbidden)
+	})
+
+	t.Run("can't copy a template to a team without access", func(t *testing.T) {
+		_, err := e.PlaybooksClientNotInTeam.Playbooks.CopyFromTemplate(context.Background(), templateID, e.BasicTeam.Id, "")
+		requireErrorWithStatusCode(t, err, http.StatusForbidden)
+	})
+}
+
 func TestAddPostToTimeline(t *testing.T) {
 	e := Setup(t)
 	e.CreateBasic()
@@ -1317,3 +1465,302 @@ func TestPlaybookChecklistCleanup(t *testing.T) {
 		require.Equal(t, pb.Checklists, actual)
 	})
 }
+
+func TestPlaybookDefaultAssignees(t *testing.T) {
+	e := Setup(t)
+	e.CreateBasic()
+
+	playbookID, err := e.PlaybooksClient.Playbooks.Create(context.Background(), client.PlaybookCreateOptions{
+		Title:  "Deploys",
+		TeamID: e.BasicTeam.Id,
+		Public: true,
+		Checklists: []client.Checklist{
+			{
+				Title: "A",
+				Items: []client.ChecklistItem{
+					{Title: "Deploy", DefaultAssigneeID: e.RegularUser2.Id},
+					{Title: "Announce"},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	run, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+		Name:        "Deploy run",
+		OwnerUserID: e.RegularUser.Id,
+		TeamID:      e.BasicTeam.Id,
+		PlaybookID:  playbookID,
+	})
+	require.NoError(t, err)
+
+	t.Run("runs are assigned to the default assignees", func(t *testing.T) {
+		require.Len(t, run.Checklists[0].Items, 2)
+		assert.Equal(t, e.RegularUser2.Id, run.Checklists[0].Items[0].AssigneeID)
+		assert.Empty(t, run.Checklists[0].Items[1].AssigneeID)
+	})
+
+	t.Run("clearing the default leaves new runs unassigned", func(t *testing.T) {
+		playbook, err := e.PlaybooksClient.Playbooks.Get(context.Background(), playbookID)
+		require.NoError(t, err)
+		assert.Equal(t, e.RegularUser2.Id, playbook.Checklists[0].Items[0].DefaultAssigneeID)
+
+		playbook.Checklists[0].Items[0].DefaultAssigneeID = ""
+		err = e.PlaybooksClient.Playbooks.Update(context.Background(), *playbook)
+		require.NoError(t, err)
+
+		newRun, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+			Name:        "Another deploy run",
+			OwnerUserID: e.RegularUser.Id,
+			TeamID:      e.BasicTeam.Id,
+			PlaybookID:  playbookID,
+		})
+		require.NoError(t, err)
+		assert.Empty(t, newRun.Checklists[0].Items[0].AssigneeID)
+
+		// runs in flight keep the assignees they started with
+		oldRun, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+		require.NoError(t, err)
+		assert.Equal(t, e.RegularUser2.Id, oldRun.Checklists[0].Items[0].AssigneeID)
+	})
+
+	t.Run("invalid default assignee", func(t *testing.T) {
+		playbook, err := e.PlaybooksClient.Playbooks.Get(context.Background(), playbookID)
+		require.NoError(t, err)
+
+		playbook.Checklists[0].Items[0].DefaultAssigneeID = "on-call"
+		err = e.PlaybooksClient.Playbooks.Update(context.Background(), *playbook)
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+	})
+
+	t.Run("default assignee not in the team", func(t *testing.T) {
+		playbook, err := e.PlaybooksClient.Playbooks.Get(context.Background(), playbookID)
+		require.NoError(t, err)
+
+		playbook.Checklists[0].Items[0].DefaultAssigneeID = e.RegularUserNotInTeam.Id
+		err = e.PlaybooksClient.Playbooks.Update(context.Background(), *playbook)
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+
+		playbook.Checklists[0].Items[0].DefaultAssigneeID = model.NewId()
+		err = e.PlaybooksClient.Playbooks.Update(context.Background(), *playbook)
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+	})
+}
+
+func TestPlaybookChecklistItemDependencies(t *testing.T) {
+	e := Setup(t)
+	e.CreateBasic()
+
+	createPlaybook := func(enforced bool) string {
+		playbookID, err := e.PlaybooksClient.Playbooks.Create(context.Background(), client.PlaybookCreateOptions{
+			Title:                             "Deploys",
+			TeamID:                            e.BasicTeam.Id,
+			Public:                            true,
+			ChecklistItemDependenciesEnforced: enforced,
+			Checklists: []client.Checklist{
+				{
+					Title: "A",
+					Items: []client.ChecklistItem{
+						{ID: model.NewId(), Title: "Build"},
+						{Title: "Deploy"},
+					},
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		playbook, err := e.PlaybooksClient.Playbooks.Get(context.Background(), playbookID)
+		require.NoError(t, err)
+		playbook.Checklists[0].Items[1].DependsOnItemID = playbook.Checklists[0].Items[0].ID
+		err = e.PlaybooksClient.Playbooks.Update(context.Background(), *playbook)
+		require.NoError(t, err)
+
+		return playbookID
+	}
+
+	createRun := func(playbookID string) *client.PlaybookRun {
+		run, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+			Name:        "Deploy run",
+			OwnerUserID: e.RegularUser.Id,
+			TeamID:      e.BasicTeam.Id,
+			PlaybookID:  playbookID,
+		})
+		require.NoError(t, err)
+
+		return run
+	}
+
+	t.Run("enforced dependencies block items", func(t *testing.T) {
+		playbookID := createPlaybook(true)
+		playbook, err := e.PlaybooksClient.Playbooks.Get(context.Background(), playbookID)
+		require.NoError(t, err)
+
+		run := createRun(playbookID)
+		items := run.Checklists[0].Items
+		assert.NotEqual(t, playbook.Checklists[0].Items[0].ID, items[0].ID)
+		assert.Equal(t, items[0].ID, items[1].DependsOnItemID)
+		assert.False(t, items[0].Blocked)
+		assert.True(t, items[1].Blocked)
+
+		err = e.PlaybooksClient.PlaybookRuns.SetItemState(context.Background(), run.ID, 0, 1, app.ChecklistItemStateClosed, "")
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+
+		err = e.PlaybooksClient.PlaybookRuns.SetItemState(context.Background(), run.ID, 0, 0, app.ChecklistItemStateClosed, "")
+		require.NoError(t, err)
+
+		run, err = e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+		require.NoError(t, err)
+		assert.False(t, run.Checklists[0].Items[1].Blocked)
+
+		err = e.PlaybooksClient.PlaybookRuns.SetItemState(context.Background(), run.ID, 0, 1, app.ChecklistItemStateClosed, "")
+		require.NoError(t, err)
+	})
+
+	t.Run("dependencies that are not enforced only warn", func(t *testing.T) {
+		run := createRun(createPlaybook(false))
+		assert.True(t, run.Checklists[0].Items[1].Blocked)
+
+		err := e.PlaybooksClient.PlaybookRuns.SetItemState(context.Background(), run.ID, 0, 1, app.ChecklistItemStateClosed, "")
+		require.NoError(t, err)
+	})
+
+	t.Run("dependency cycles are rejected", func(t *testing.T) {
+		playbook, err := e.PlaybooksClient.Playbooks.Get(context.Background(), createPlaybook(true))
+		require.NoError(t, err)
+
+		playbook.Checklists[0].Items[1].ID = model.NewId()
+		playbook.Checklists[0].Items[0].DependsOnItemID = playbook.Checklists[0].Items[1].ID
+		err = e.PlaybooksClient.Playbooks.Update(context.Background(), *playbook)
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+	})
+}
+
+func TestPlaybookAutoFinish(t *testing.T) {
+	e := Setup(t)
+	e.CreateBasic()
+
+	createRun := func(opts client.PlaybookCreateOptions) *client.PlaybookRun {
+		opts.Title = "Deploys"
+		opts.TeamID = e.BasicTeam.Id
+		opts.Public = true
+		opts.Checklists = []client.Checklist{
+			{
+				Title: "A",
+				Items: []client.ChecklistItem{{Title: "Build"}, {Title: "Deploy"}},
+			},
+		}
+		playbookID, err := e.PlaybooksClient.Playbooks.Create(context.Background(), opts)
+		require.NoError(t, err)
+
+		run, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+			Name:        "Deploy run",
+			OwnerUserID: e.RegularUser.Id,
+			TeamID:      e.BasicTeam.Id,
+			PlaybookID:  playbookID,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, opts.AutoFinishEnabled, run.AutoFinishEnabled)
+
+		return run
+	}
+
+	completeChecklists := func(run *client.PlaybookRun) *client.PlaybookRun {
+		err := e.PlaybooksClient.PlaybookRuns.SetItemState(context.Background(), run.ID, 0, 0, app.ChecklistItemStateClosed, "")
+		require.NoError(t, err)
+
+		run, err = e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+		require.NoError(t, err)
+		assert.Equal(t, string(client.StatusInProgress), run.CurrentStatus)
+
+		err = e.PlaybooksClient.PlaybookRuns.SetItemState(context.Background(), run.ID, 0, 1, app.ChecklistItemStateSkipped, "Not needed")
+		require.NoError(t, err)
+
+		run, err = e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+		require.NoError(t, err)
+
+		return run
+	}
+
+	t.Run("runs finish once all their items are done or skipped", func(t *testing.T) {
+		run := completeChecklists(createRun(client.PlaybookCreateOptions{AutoFinishEnabled: true}))
+		assert.Equal(t, string(client.StatusFinished), run.CurrentStatus)
+		assert.Len(t, run.StatusPosts, 1)
+	})
+
+	t.Run("runs don't finish without the setting", func(t *testing.T) {
+		run := completeChecklists(createRun(client.PlaybookCreateOptions{}))
+		assert.Equal(t, string(client.StatusInProgress), run.CurrentStatus)
+		assert.Empty(t, run.StatusPosts)
+	})
+
+	t.Run("runs missing their required retrospective don't finish", func(t *testing.T) {
+		run := completeChecklists(createRun(client.PlaybookCreateOptions{
+			AutoFinishEnabled:     true,
+			RetrospectiveEnabled:  true,
+			RetrospectiveRequired: true,
+		}))
+		assert.Equal(t, string(client.StatusInProgress), run.CurrentStatus)
+		assert.Empty(t, run.StatusPosts)
+	})
+}
+
+func TestPlaybookVersions(t *testing.T) {
+	e := Setup(t)
+	e.CreateBasic()
+
+	playbookID, err := e.PlaybooksClient.Playbooks.Create(context.Background(), client.PlaybookCreateOptions{
+		Title:  "versioned",
+		TeamID: e.BasicTeam.Id,
+		Public: true,
+	})
+	require.NoError(t, err)
+
+	t.Run("a new playbook has no versions", func(t *testing.T) {
+		versions, err := e.PlaybooksClient.Playbooks.GetVersions(context.Background(), playbookID)
+		require.NoError(t, err)
+		require.Empty(t, versions)
+	})
+
+	t.Run("updates record the previous version", func(t *testing.T) {
+		playbook, err := e.PlaybooksClient.Playbooks.Get(context.Background(), playbookID)
+		require.NoError(t, err)
+
+		playbook.Title = "versioned v2"
+		require.NoError(t, e.PlaybooksClient.Playbooks.Update(context.Background(), *playbook))
+
+		// An update that changes nothing is not a version.
+		require.NoError(t, e.PlaybooksClient.Playbooks.Update(context.Background(), *playbook))
+
+		require.NoError(t, gqlTestPlaybookUpdate(e, t, playbookID, map[string]interface{}{"description": "graphql update"}))
+
+		versions, err := e.PlaybooksClient.Playbooks.GetVersions(context.Background(), playbookID)
+		require.NoError(t, err)
+		require.Len(t, versions, 2)
+		require.EqualValues(t, 2, versions[0].Version)
+		require.EqualValues(t, 1, versions[1].Version)
+		require.Equal(t, e.RegularUser.Id, versions[1].UserID)
+
+		versionDiff, err := e.PlaybooksClient.Playbooks.GetVersion(context.Background(), playbookID, 1)
+		require.NoError(t, err)
+		require.Equal(t, "versioned", versionDiff.Version.Playbook.Title)
+
+		changedFields := []string{}
+		for _, change := range versionDiff.Changes {
+			changedFields = append(changedFields, change.Field)
+		}
+		require.Equal(t, []string{"description", "title"}, changedFields)
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		_, err := e.PlaybooksClient.Playbooks.GetVersion(context.Background(), playbookID, 100)
+		requireErrorWithStatusCode(t, err, http.StatusNotFound)
+	})
+
+	t.Run("users who can't view the playbook can't see its versions", func(t *testing.T) {
+		_, err := e.PlaybooksClientNotInTeam.Playbooks.GetVersions(context.Background(), playbookID)
+		requireErrorWithStatusCode(t, err, http.StatusForbidden)
+
+		_, err = e.PlaybooksClientNotInTeam.Playbooks.GetVersion(context.Background(), playbookID, 1)
+		requireErrorWithStatusCode(t, err, http.StatusForbidden)
+	})
+}
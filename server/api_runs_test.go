@@ -1,10 +1,13 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"net/http"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -219,7 +222,7 @@ func TestRunCreation(t *testing.T) {
 			TeamID:      e.BasicTeam.Id,
 			PlaybookID:  e.ArchivedPlaybook.ID,
 		})
-		assert.Error(t, err)
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
 	})
 
 	t.Run("create valid run using playbook with due dates", func(t *testing.T) {
@@ -278,6 +281,11 @@ func TestRunCreation(t *testing.T) {
 		assert.Equal(t, (now+durations[1])/10000, run.Checklists[0].Items[1].DueDate/10000)
 		assert.Equal(t, (now+durations[2])/10000, run.Checklists[1].Items[0].DueDate/10000)
 		assert.Zero(t, run.Checklists[1].Items[1].DueDate)
+
+		// the relative due dates are kept as offsets from the start of the run
+		assert.Equal(t, durations[0], run.Checklists[0].Items[0].DueOffset)
+		assert.Equal(t, durations[2], run.Checklists[1].Items[0].DueOffset)
+		assert.Zero(t, run.Checklists[1].Items[1].DueOffset)
 	})
 }
 
@@ -298,6 +306,123 @@ func TestCreateInvalidRuns(t *testing.T) {
 	})
 }
 
+func TestRunCreationInExistingChannel(t *testing.T) {
+	e := Setup(t)
+	e.CreateBasic()
+
+	createChannel := func(t *testing.T, name string) *model.Channel {
+		t.Helper()
+
+		channel, _, err := e.ServerAdminClient.CreateChannel(&model.Channel{
+			DisplayName: name,
+			Name:        name,
+			Type:        model.ChannelTypeOpen,
+			TeamId:      e.BasicTeam.Id,
+		})
+		require.NoError(t, err)
+		_, _, err = e.ServerAdminClient.AddChannelMember(channel.Id, e.RegularUser.Id)
+		require.NoError(t, err)
+
+		return channel
+	}
+
+	t.Run("link the run to an existing channel", func(t *testing.T) {
+		channel := createChannel(t, "service-a")
+
+		run, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+			Name:        "In service channel",
+			OwnerUserID: e.RegularUser.Id,
+			TeamID:      e.BasicTeam.Id,
+			PlaybookID:  e.BasicPlaybook.ID,
+			ChannelID:   channel.Id,
+			ChannelMode: client.PlaybookRunLinkExistingChannel,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, channel.Id, run.ChannelID)
+
+		// The header of the channel is kept.
+		channel, _, err = e.ServerAdminClient.GetChannel(channel.Id, "")
+		require.NoError(t, err)
+		assert.Empty(t, channel.Header)
+
+		t.Run("a second active run is refused", func(t *testing.T) {
+			_, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+				Name:        "Second run",
+				OwnerUserID: e.RegularUser.Id,
+				TeamID:      e.BasicTeam.Id,
+				PlaybookID:  e.BasicPlaybook.ID,
+				ChannelID:   channel.Id,
+				ChannelMode: client.PlaybookRunLinkExistingChannel,
+			})
+			requireErrorWithStatusCode(t, err, http.StatusConflict)
+		})
+
+		t.Run("unless multiple runs are allowed", func(t *testing.T) {
+			second, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+				Name:              "Second run",
+				OwnerUserID:       e.RegularUser.Id,
+				TeamID:            e.BasicTeam.Id,
+				PlaybookID:        e.BasicPlaybook.ID,
+				ChannelID:         channel.Id,
+				ChannelMode:       client.PlaybookRunLinkExistingChannel,
+				AllowMultipleRuns: true,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, channel.Id, second.ChannelID)
+		})
+	})
+
+	t.Run("a finished run does not own the channel", func(t *testing.T) {
+		channel := createChannel(t, "service-b")
+
+		options := client.PlaybookRunCreateOptions{
+			Name:        "In service channel",
+			OwnerUserID: e.RegularUser.Id,
+			TeamID:      e.BasicTeam.Id,
+			ChannelID:   channel.Id,
+			ChannelMode: client.PlaybookRunLinkExistingChannel,
+		}
+		run, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), options)
+		require.NoError(t, err)
+		require.NoError(t, e.PlaybooksClient.PlaybookRuns.Finish(context.Background(), run.ID))
+
+		_, err = e.PlaybooksClient.PlaybookRuns.Create(context.Background(), options)
+		require.NoError(t, err)
+	})
+
+	t.Run("missing channel", func(t *testing.T) {
+		_, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+			Name:        "No channel",
+			OwnerUserID: e.RegularUser.Id,
+			TeamID:      e.BasicTeam.Id,
+			ChannelMode: client.PlaybookRunLinkExistingChannel,
+		})
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+	})
+
+	t.Run("invalid channel mode", func(t *testing.T) {
+		_, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+			Name:        "Invalid mode",
+			OwnerUserID: e.RegularUser.Id,
+			TeamID:      e.BasicTeam.Id,
+			ChannelID:   e.BasicPublicChannel.Id,
+			ChannelMode: "reuse_channel",
+		})
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+	})
+
+	t.Run("channel the user cannot access", func(t *testing.T) {
+		_, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+			Name:        "Private channel",
+			OwnerUserID: e.RegularUser.Id,
+			TeamID:      e.BasicTeam.Id,
+			ChannelID:   e.BasicPrivateChannel.Id,
+			ChannelMode: client.PlaybookRunLinkExistingChannel,
+		})
+		requireErrorWithStatusCode(t, err, http.StatusForbidden)
+	})
+}
+
 func TestRunRetrieval(t *testing.T) {
 	e := Setup(t)
 	e.CreateBasic()
@@ -420,1079 +545,3253 @@ func TestRunStatus(t *testing.T) {
 	})
 }
 
-func TestChecklistManagement(t *testing.T) {
+func TestRunPauseAndResume(t *testing.T) {
 	e := Setup(t)
 	e.CreateBasic()
 
-	createNewRunWithNoChecklists := func(t *testing.T) *client.PlaybookRun {
-		t.Helper()
+	run, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+		Name:        "Run to pause",
+		OwnerUserID: e.RegularUser.Id,
+		TeamID:      e.BasicTeam.Id,
+		PlaybookID:  e.BasicPlaybook.ID,
+	})
+	require.NoError(t, err)
 
-		run, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
-			Name:        "Run name",
-			OwnerUserID: e.RegularUser.Id,
-			TeamID:      e.BasicTeam.Id,
-			PlaybookID:  e.BasicPlaybook.ID,
-		})
+	t.Run("pause", func(t *testing.T) {
+		err := e.PlaybooksClient.PlaybookRuns.Pause(context.Background(), run.ID)
 		require.NoError(t, err)
-		require.Len(t, run.Checklists, 0)
 
-		return run
-	}
+		pausedRun, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+		require.NoError(t, err)
+		assert.EqualValues(t, client.StatusPaused, pausedRun.CurrentStatus)
+		assert.NotZero(t, pausedRun.PausedAt)
+		require.NotEmpty(t, pausedRun.TimelineEvents)
+		assert.Equal(t, client.RunPaused, pausedRun.TimelineEvents[len(pausedRun.TimelineEvents)-1].EventType)
+	})
 
-	t.Run("checklist creation - success: empty checklist", func(t *testing.T) {
-		run := createNewRunWithNoChecklists(t)
-		title := "A new checklist"
+	t.Run("pause without permissions", func(t *testing.T) {
+		err := e.PlaybooksClient2.PlaybookRuns.Pause(context.Background(), run.ID)
+		requireErrorWithStatusCode(t, err, http.StatusForbidden)
+	})
 
-		// Create a valid, empty checklist
-		err := e.PlaybooksClient.PlaybookRuns.CreateChecklist(context.Background(), run.ID, client.Checklist{
-			Title: title,
-			Items: []client.ChecklistItem{},
-		})
+	t.Run("resume", func(t *testing.T) {
+		err := e.PlaybooksClient.PlaybookRuns.Resume(context.Background(), run.ID)
 		require.NoError(t, err)
 
-		// Make sure the new checklist is there
-		editedRun, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+		resumedRun, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
 		require.NoError(t, err)
-		require.Len(t, editedRun.Checklists, 1)
-		require.Equal(t, title, editedRun.Checklists[0].Title)
+		assert.EqualValues(t, client.StatusInProgress, resumedRun.CurrentStatus)
+		assert.Zero(t, resumedRun.PausedAt)
+		require.NotEmpty(t, resumedRun.TimelineEvents)
+		assert.Equal(t, client.RunResumed, resumedRun.TimelineEvents[len(resumedRun.TimelineEvents)-1].EventType)
 	})
 
-	t.Run("checklist creation - failure: no permissions", func(t *testing.T) {
-		run := createNewRunWithNoChecklists(t)
-		title := "A new checklist"
+	t.Run("pause a finished run", func(t *testing.T) {
+		err := e.PlaybooksClient.PlaybookRuns.Finish(context.Background(), run.ID)
+		require.NoError(t, err)
 
-		// Create a valid, empty checklist
-		err := e.PlaybooksClient2.PlaybookRuns.CreateChecklist(context.Background(), run.ID, client.Checklist{
-			Title: title,
-			Items: []client.ChecklistItem{},
-		})
-		require.Error(t, err)
+		err = e.PlaybooksClient.PlaybookRuns.Pause(context.Background(), run.ID)
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
 	})
+}
 
-	t.Run("checklist creation - success: checklist with items", func(t *testing.T) {
-		run := createNewRunWithNoChecklists(t)
-		title := "A new checklist"
-
-		// Create a valid checklist with some items
-		items := []client.ChecklistItem{
-			{
-				Title:       "First",
-				Description: "",
-			},
-			{
-				Title:       "Second",
-				Description: "Description",
-			},
-		}
-		err := e.PlaybooksClient.PlaybookRuns.CreateChecklist(context.Background(), run.ID, client.Checklist{
-			Title: title,
-			Items: items,
-		})
-		require.NoError(t, err)
+func TestRunRestart(t *testing.T) {
+	e := Setup(t)
+	e.CreateBasic()
 
-		// Make sure the new checklist is there
-		editedRun, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
-		require.NoError(t, err)
-		require.Len(t, editedRun.Checklists, 1)
-		require.Equal(t, title, editedRun.Checklists[0].Title)
-		require.Equal(t, "First", editedRun.Checklists[0].Items[0].Title)
-		require.Equal(t, "Second", editedRun.Checklists[0].Items[1].Title)
-		require.Equal(t, "Description", editedRun.Checklists[0].Items[1].Description)
+	run, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+		Name:        "Run to restart",
+		OwnerUserID: e.RegularUser.Id,
+		TeamID:      e.BasicTeam.Id,
+		PlaybookID:  e.BasicPlaybook.ID,
 	})
+	require.NoError(t, err)
 
-	t.Run("checklist creation - failure: no title", func(t *testing.T) {
-		run := createNewRunWithNoChecklists(t)
+	t.Run("restart a run in progress", func(t *testing.T) {
+		err := e.PlaybooksClient.PlaybookRuns.Restart(context.Background(), run.ID)
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+	})
 
-		// Try to create a new checklist with no title
-		err := e.PlaybooksClient.PlaybookRuns.CreateChecklist(context.Background(), run.ID, client.Checklist{
-			Title: "",
-			Items: []client.ChecklistItem{},
-		})
-		require.Error(t, err)
+	err = e.PlaybooksClient.PlaybookRuns.Finish(context.Background(), run.ID)
+	require.NoError(t, err)
+	_, err = e.ServerAdminClient.DeleteChannel(run.ChannelID)
+	require.NoError(t, err)
 
-		// Make sure that the checklist was not added
-		editedRun, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
-		require.NoError(t, err)
-		require.Len(t, editedRun.Checklists, 0)
+	t.Run("restart without permissions", func(t *testing.T) {
+		err := e.PlaybooksClient2.PlaybookRuns.Restart(context.Background(), run.ID)
+		requireErrorWithStatusCode(t, err, http.StatusForbidden)
 	})
 
-	t.Run("checklist renaming - success", func(t *testing.T) {
-		run := createNewRunWithNoChecklists(t)
-		oldTitle := "Old Title"
-		newTitle := "New Title"
-
-		// Create a new checklist with a known title
-		err := e.PlaybooksClient.PlaybookRuns.CreateChecklist(context.Background(), run.ID, client.Checklist{
-			Title: oldTitle,
-			Items: []client.ChecklistItem{},
-		})
+	t.Run("restart", func(t *testing.T) {
+		err := e.PlaybooksClient.PlaybookRuns.Restart(context.Background(), run.ID)
 		require.NoError(t, err)
 
-		// Rename the checklist to a new title
-		err = e.PlaybooksClient.PlaybookRuns.RenameChecklist(context.Background(), run.ID, 0, newTitle)
+		restartedRun, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
 		require.NoError(t, err)
+		assert.EqualValues(t, client.StatusInProgress, restartedRun.CurrentStatus)
+		assert.Zero(t, restartedRun.EndAt)
+		require.NotEmpty(t, restartedRun.TimelineEvents)
+		assert.Equal(t, client.RunRestored, restartedRun.TimelineEvents[len(restartedRun.TimelineEvents)-1].EventType)
 
-		// Retrieve the run again and make sure that the checklist's title has changed
-		editedRun, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+		channel, _, err := e.ServerAdminClient.GetChannel(run.ChannelID, "")
 		require.NoError(t, err)
-		require.Len(t, editedRun.Checklists, 1)
-		require.Equal(t, newTitle, editedRun.Checklists[0].Title)
+		assert.Zero(t, channel.DeleteAt)
 	})
+}
 
-	t.Run("checklist renaming - failure: no title", func(t *testing.T) {
-		run := createNewRunWithNoChecklists(t)
-		oldTitle := "Old Title"
-		newTitle := ""
+func TestRunChecklistItemConditions(t *testing.T) {
+	e := Setup(t)
+	e.CreateBasic()
 
-		// Create a valid checklist
-		err := e.PlaybooksClient.PlaybookRuns.CreateChecklist(context.Background(), run.ID, client.Checklist{
-			Title: oldTitle,
-			Items: []client.ChecklistItem{},
+	t.Run("invalid condition", func(t *testing.T) {
+		_, err := e.PlaybooksClient.Playbooks.Create(context.Background(), client.PlaybookCreateOptions{
+			Title:  "PB",
+			TeamID: e.BasicTeam.Id,
+			Checklists: []client.Checklist{
+				{
+					Title: "A",
+					Items: []client.ChecklistItem{
+						{Title: "Page the CTO", Condition: "$SEVERITY = critical"},
+					},
+				},
+			},
+		})
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+	})
+
+	t.Run("items are hidden based on the run summary", func(t *testing.T) {
+		playbookID, err := e.PlaybooksClient.Playbooks.Create(context.Background(), client.PlaybookCreateOptions{
+			Title:  "PB",
+			TeamID: e.BasicTeam.Id,
+			Checklists: []client.Checklist{
+				{
+					Title: "A",
+					Items: []client.ChecklistItem{
+						{Title: "Investigate"},
+						{Title: "Page the CTO", Condition: "$SEVERITY in (critical, high)"},
+					},
+				},
+			},
 		})
 		require.NoError(t, err)
 
-		// Try to rename the checklist to an empty title
-		err = e.PlaybooksClient.PlaybookRuns.RenameChecklist(context.Background(), run.ID, 0, newTitle)
-		require.Error(t, err)
+		run, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+			Name:        "Run with conditions",
+			OwnerUserID: e.RegularUser.Id,
+			TeamID:      e.BasicTeam.Id,
+			PlaybookID:  playbookID,
+			Description: "$SEVERITY=low",
+		})
+		require.NoError(t, err)
+		require.Len(t, run.Checklists[0].Items, 2)
+		assert.False(t, run.Checklists[0].Items[0].Hidden)
+		assert.True(t, run.Checklists[0].Items[1].Hidden)
+
+		run, err = e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+			Name:        "Critical run with conditions",
+			OwnerUserID: e.RegularUser.Id,
+			TeamID:      e.BasicTeam.Id,
+			PlaybookID:  playbookID,
+			Description: "$SEVERITY=critical",
+		})
+		require.NoError(t, err)
+		assert.False(t, run.Checklists[0].Items[1].Hidden)
 	})
+}
 
-	t.Run("checklist renaming - failure: wrong checklist number", func(t *testing.T) {
-		run := createNewRunWithNoChecklists(t)
-		newTitle := "New Title"
+func TestRunCoOwners(t *testing.T) {
+	e := Setup(t)
+	e.CreateBasic()
 
-		// Try to rename a checklist that does not exist (negative number)
-		err := e.PlaybooksClient.PlaybookRuns.RenameChecklist(context.Background(), run.ID, -1, newTitle)
-		require.Error(t, err)
+	run, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+		Name:        "Run with co-owners",
+		OwnerUserID: e.RegularUser.Id,
+		TeamID:      e.BasicTeam.Id,
+		PlaybookID:  e.BasicPlaybook.ID,
+	})
+	require.NoError(t, err)
 
-		// Try to rename a checklist that does not exist (number greater than the index of the last checklist)
-		err = e.PlaybooksClient.PlaybookRuns.RenameChecklist(context.Background(), run.ID, len(run.Checklists), newTitle)
-		require.Error(t, err)
+	t.Run("add co-owner without permissions", func(t *testing.T) {
+		err := e.PlaybooksClient2.PlaybookRuns.AddCoOwner(context.Background(), run.ID, e.RegularUser2.Id)
+		requireErrorWithStatusCode(t, err, http.StatusForbidden)
 	})
 
-	t.Run("checklist removal - success: result in no checklists", func(t *testing.T) {
-		run := createNewRunWithNoChecklists(t)
-		require.Len(t, run.Checklists, 0)
+	t.Run("owner can't be a co-owner", func(t *testing.T) {
+		err := e.PlaybooksClient.PlaybookRuns.AddCoOwner(context.Background(), run.ID, e.RegularUser.Id)
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+	})
 
-		// Create a valid checklist
-		err := e.PlaybooksClient.PlaybookRuns.CreateChecklist(context.Background(), run.ID, client.Checklist{
-			Title: "title",
-			Items: []client.ChecklistItem{},
-		})
+	t.Run("add co-owner", func(t *testing.T) {
+		err := e.PlaybooksClient.PlaybookRuns.AddCoOwner(context.Background(), run.ID, e.RegularUser2.Id)
 		require.NoError(t, err)
 
-		// Retrieve the run again and make sure that the checklist was created
-		editedRun, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+		updatedRun, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
 		require.NoError(t, err)
-		require.Len(t, editedRun.Checklists, 1)
+		assert.Equal(t, []string{e.RegularUser2.Id}, updatedRun.CoOwnerUserIDs)
+		assert.Contains(t, updatedRun.ParticipantIDs, e.RegularUser2.Id)
+		require.NotEmpty(t, updatedRun.TimelineEvents)
+		assert.Equal(t, client.CoOwnerAdded, updatedRun.TimelineEvents[len(updatedRun.TimelineEvents)-1].EventType)
+	})
 
-		// Remove the recently created checklist
-		err = e.PlaybooksClient.PlaybookRuns.RemoveChecklist(context.Background(), run.ID, 0)
+	t.Run("co-owner can edit the run", func(t *testing.T) {
+		err := e.PlaybooksClient2.PlaybookRuns.Pause(context.Background(), run.ID)
 		require.NoError(t, err)
 
-		// Retrieve the run again and make sure that the checklist was removed
-		editedRun, err = e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+		err = e.PlaybooksClient2.PlaybookRuns.Resume(context.Background(), run.ID)
 		require.NoError(t, err)
-		require.Len(t, editedRun.Checklists, 0)
 	})
 
-	t.Run("checklist removal - success: still some checklists", func(t *testing.T) {
-		run := createNewRunWithNoChecklists(t)
+	t.Run("remove co-owner", func(t *testing.T) {
+		err := e.PlaybooksClient.PlaybookRuns.RemoveCoOwner(context.Background(), run.ID, e.RegularUser2.Id)
+		require.NoError(t, err)
 
-		// Create two valid checklists
-		err := e.PlaybooksClient.PlaybookRuns.CreateChecklist(context.Background(), run.ID, client.Checklist{
-			Title: "First checklist",
-			Items: []client.ChecklistItem{},
-		})
+		updatedRun, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
 		require.NoError(t, err)
+		assert.Empty(t, updatedRun.CoOwnerUserIDs)
+		require.NotEmpty(t, updatedRun.TimelineEvents)
+		assert.Equal(t, client.CoOwnerRemoved, updatedRun.TimelineEvents[len(updatedRun.TimelineEvents)-1].EventType)
+	})
 
-		err = e.PlaybooksClient.PlaybookRuns.CreateChecklist(context.Background(), run.ID, client.Checklist{
-			Title: "Second checklist",
-			Items: []client.ChecklistItem{},
-		})
+	t.Run("co-owner promoted to owner", func(t *testing.T) {
+		err := e.PlaybooksClient.PlaybookRuns.AddCoOwner(context.Background(), run.ID, e.RegularUser2.Id)
 		require.NoError(t, err)
 
-		// Retrieve the run again and make sure that the checklists were created
-		editedRun, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+		body, err := json.Marshal(map[string]string{"owner_id": e.RegularUser2.Id})
 		require.NoError(t, err)
-		require.Len(t, editedRun.Checklists, 2)
+		resp, err := e.ServerClient.DoAPIRequestBytes("POST", e.ServerClient.URL+"/plugins/"+manifest.Id+"/api/v0/runs/"+run.ID+"/owner", body, "")
+		require.NoError(t, err)
+		defer resp.Body.Close()
 
-		// Remove the last checklist
-		err = e.PlaybooksClient.PlaybookRuns.RemoveChecklist(context.Background(), run.ID, 1)
+		updatedRun, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
 		require.NoError(t, err)
+		assert.Equal(t, e.RegularUser2.Id, updatedRun.OwnerUserID)
+		assert.Empty(t, updatedRun.CoOwnerUserIDs)
+		require.NotEmpty(t, updatedRun.TimelineEvents)
+		assert.Equal(t, client.OwnerChanged, updatedRun.TimelineEvents[len(updatedRun.TimelineEvents)-1].EventType)
+	})
+}
 
-		// Retrieve the run again and make sure that the checklist was removed
-		editedRun, err = e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+func TestRunParticipantRoles(t *testing.T) {
+	e := Setup(t)
+	e.CreateBasic()
+
+	playbookID, err := e.PlaybooksAdminClient.Playbooks.Create(context.Background(), client.PlaybookCreateOptions{
+		Title:  "PB",
+		TeamID: e.BasicTeam.Id,
+		Public: true,
+		Members: []client.PlaybookMember{
+			{UserID: e.RegularUser.Id, Roles: []string{app.PlaybookRoleMember}},
+		},
+		Checklists: []client.Checklist{
+			{
+				Title: "A",
+				Items: []client.ChecklistItem{
+					{Title: "Do this"},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	run, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+		Name:        "Run with viewers",
+		OwnerUserID: e.RegularUser.Id,
+		TeamID:      e.BasicTeam.Id,
+		PlaybookID:  playbookID,
+	})
+	require.NoError(t, err)
+
+	response, err := addParticipants(e.PlaybooksClient, run.ID, []string{e.RegularUser2.Id})
+	require.NoError(t, err)
+	require.Empty(t, response.Errors)
+
+	t.Run("unknown role", func(t *testing.T) {
+		err := e.PlaybooksClient.PlaybookRuns.SetParticipantRole(context.Background(), run.ID, e.RegularUser2.Id, "admin")
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+	})
+
+	t.Run("owner can't be a viewer", func(t *testing.T) {
+		err := e.PlaybooksClient.PlaybookRuns.SetParticipantRole(context.Background(), run.ID, e.RegularUser.Id, client.ParticipantRoleViewer)
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+	})
+
+	t.Run("non-participants can't be viewers", func(t *testing.T) {
+		err := e.PlaybooksClient.PlaybookRuns.SetParticipantRole(context.Background(), run.ID, e.AdminUser.Id, client.ParticipantRoleViewer)
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+	})
+
+	t.Run("make a participant a viewer", func(t *testing.T) {
+		err := e.PlaybooksClient.PlaybookRuns.SetParticipantRole(context.Background(), run.ID, e.RegularUser2.Id, client.ParticipantRoleViewer)
 		require.NoError(t, err)
-		require.Len(t, editedRun.Checklists, 1)
-		require.Equal(t, "First checklist", editedRun.Checklists[0].Title)
+
+		updatedRun, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+		require.NoError(t, err)
+		assert.Contains(t, updatedRun.ParticipantIDs, e.RegularUser2.Id)
+		assert.Equal(t, []string{e.RegularUser2.Id}, updatedRun.ViewerIDs)
+		require.NotEmpty(t, updatedRun.TimelineEvents)
+		assert.Equal(t, client.ParticipantRoleChanged, updatedRun.TimelineEvents[len(updatedRun.TimelineEvents)-1].EventType)
 	})
 
-	t.Run("checklist removal - failure: wrong checklist number", func(t *testing.T) {
-		run := createNewRunWithNoChecklists(t)
+	t.Run("viewers can read but not change the run", func(t *testing.T) {
+		_, err := e.PlaybooksClient2.PlaybookRuns.Get(context.Background(), run.ID)
+		require.NoError(t, err)
 
-		// Try to remove a checklist that does not exist (negative number)
-		err := e.PlaybooksClient.PlaybookRuns.RemoveChecklist(context.Background(), run.ID, -1)
-		require.Error(t, err)
+		err = e.PlaybooksClient2.PlaybookRuns.SetItemState(context.Background(), run.ID, 0, 0, app.ChecklistItemStateClosed, "")
+		requireErrorWithStatusCode(t, err, http.StatusForbidden)
 
-		// Try to rename a checklist that does not exist (number greater than the index of the last checklist)
-		err = e.PlaybooksClient.PlaybookRuns.RemoveChecklist(context.Background(), run.ID, 0)
-		require.Error(t, err)
+		err = e.PlaybooksClient2.PlaybookRuns.UpdateStatus(context.Background(), run.ID, "Looking", 600)
+		requireErrorWithStatusCode(t, err, http.StatusForbidden)
 
-		// Create a checklist so that there is at least one
-		err = e.PlaybooksClient.PlaybookRuns.CreateChecklist(context.Background(), run.ID, client.Checklist{
-			Title: "Second checklist",
-			Items: []client.ChecklistItem{},
-		})
+		err = e.PlaybooksClient2.PlaybookRuns.SetParticipantRole(context.Background(), run.ID, e.RegularUser2.Id, client.ParticipantRoleActive)
+		requireErrorWithStatusCode(t, err, http.StatusForbidden)
+	})
+
+	t.Run("make a viewer an active participant", func(t *testing.T) {
+		err := e.PlaybooksClient.PlaybookRuns.SetParticipantRole(context.Background(), run.ID, e.RegularUser2.Id, client.ParticipantRoleActive)
 		require.NoError(t, err)
 
-		// Retrieve the run again and make sure that there is one checklist
-		editedRun, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+		updatedRun, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
 		require.NoError(t, err)
-		require.Len(t, editedRun.Checklists, 1)
+		assert.Empty(t, updatedRun.ViewerIDs)
 
-		// Try to remove a checklist that does not exist (number greater than the index of the last checklist)
-		err = e.PlaybooksClient.PlaybookRuns.RemoveChecklist(context.Background(), run.ID, 1)
-		require.Error(t, err)
+		err = e.PlaybooksClient2.PlaybookRuns.SetItemState(context.Background(), run.ID, 0, 0, app.ChecklistItemStateClosed, "")
+		require.NoError(t, err)
 	})
+}
 
-	t.Run("checklist adding - success", func(t *testing.T) {
-		run := createNewRunWithNoChecklists(t)
+func TestRunTaskLoad(t *testing.T) {
+	e := Setup(t)
+	e.CreateBasic()
 
-		// Create a new checklist with a known title
-		err := e.PlaybooksClient.PlaybookRuns.CreateChecklist(context.Background(), run.ID, client.Checklist{
-			Title: "Checklist Title",
-			Items: []client.ChecklistItem{},
-		})
+	checklists := []client.Checklist{
+		{
+			Title: "A",
+			Items: []client.ChecklistItem{
+				{Title: "Do this"},
+				{Title: "Do that"},
+			},
+		},
+	}
+
+	publicPlaybookID, err := e.PlaybooksAdminClient.Playbooks.Create(context.Background(), client.PlaybookCreateOptions{
+		Title:  "Public",
+		TeamID: e.BasicTeam.Id,
+		Public: true,
+		Members: []client.PlaybookMember{
+			{UserID: e.RegularUser.Id, Roles: []string{app.PlaybookRoleMember}},
+		},
+		Checklists: checklists,
+	})
+	require.NoError(t, err)
+
+	privatePlaybookID, err := e.PlaybooksAdminClient.Playbooks.Create(context.Background(), client.PlaybookCreateOptions{
+		Title:  "Private",
+		TeamID: e.BasicTeam.Id,
+		Members: []client.PlaybookMember{
+			{UserID: e.AdminUser.Id, Roles: []string{app.PlaybookRoleAdmin, app.PlaybookRoleMember}},
+		},
+		Checklists: checklists,
+	})
+	require.NoError(t, err)
+
+	publicRun, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+		Name:        "Public run",
+		OwnerUserID: e.RegularUser.Id,
+		TeamID:      e.BasicTeam.Id,
+		PlaybookID:  publicPlaybookID,
+	})
+	require.NoError(t, err)
+	require.NoError(t, e.PlaybooksClient.PlaybookRuns.SetItemAssignee(context.Background(), publicRun.ID, 0, 0, e.RegularUser2.Id))
+	require.NoError(t, e.PlaybooksClient.PlaybookRuns.SetItemAssignee(context.Background(), publicRun.ID, 0, 1, e.RegularUser2.Id))
+
+	privateRun, err := e.PlaybooksAdminClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+		Name:        "Private run",
+		OwnerUserID: e.AdminUser.Id,
+		TeamID:      e.BasicTeam.Id,
+		PlaybookID:  privatePlaybookID,
+	})
+	require.NoError(t, err)
+	require.NoError(t, e.PlaybooksAdminClient.PlaybookRuns.SetItemAssignee(context.Background(), privateRun.ID, 0, 0, e.AdminUser.Id))
+
+	t.Run("only visible runs are counted", func(t *testing.T) {
+		taskLoad, err := e.PlaybooksClient.PlaybookRuns.GetTaskLoad(context.Background(), client.TaskLoadOptions{TeamID: e.BasicTeam.Id})
 		require.NoError(t, err)
+		assert.Equal(t, []client.UserTaskLoad{
+			{UserID: e.RegularUser2.Id, IncompleteItems: 2},
+			{UserID: e.RegularUser.Id, OwnedRuns: 1},
+		}, taskLoad)
+	})
 
-		// Add the new checklistItem
-		itemTitle := "New echo item"
-		command := "/echo hi!"
-		description := "A very complicated checklist item."
-		err = e.PlaybooksClient.PlaybookRuns.AddChecklistItem(context.Background(), run.ID, 0, client.ChecklistItem{
-			Title:       itemTitle,
-			Command:     command,
-			Description: description,
+	t.Run("sort and limit", func(t *testing.T) {
+		taskLoad, err := e.PlaybooksAdminClient.PlaybookRuns.GetTaskLoad(context.Background(), client.TaskLoadOptions{
+			TeamID: e.BasicTeam.Id,
+			Sort:   client.TaskLoadSortByOwnedRuns,
+			Limit:  2,
 		})
 		require.NoError(t, err)
+		assert.Equal(t, []client.UserTaskLoad{
+			{UserID: e.AdminUser.Id, IncompleteItems: 1, OwnedRuns: 1},
+			{UserID: e.RegularUser.Id, OwnedRuns: 1},
+		}, taskLoad)
+	})
 
-		// Retrieve the run again and make sure that the checklistItem is there
-		editedRun, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+	t.Run("finished runs are not counted", func(t *testing.T) {
+		require.NoError(t, e.PlaybooksClient.PlaybookRuns.Finish(context.Background(), publicRun.ID))
+
+		taskLoad, err := e.PlaybooksClient.PlaybookRuns.GetTaskLoad(context.Background(), client.TaskLoadOptions{TeamID: e.BasicTeam.Id})
 		require.NoError(t, err)
-		require.Len(t, editedRun.Checklists, 1)
-		require.Len(t, editedRun.Checklists[0].Items, 1)
-		require.Equal(t, itemTitle, editedRun.Checklists[0].Items[0].Title)
-		require.Equal(t, command, editedRun.Checklists[0].Items[0].Command)
-		require.Equal(t, description, editedRun.Checklists[0].Items[0].Description)
+		assert.Empty(t, taskLoad)
 	})
 
-	t.Run("checklist adding - failure: no title", func(t *testing.T) {
-		run := createNewRunWithNoChecklists(t)
+	t.Run("invalid options", func(t *testing.T) {
+		_, err := e.PlaybooksClient.PlaybookRuns.GetTaskLoad(context.Background(), client.TaskLoadOptions{TeamID: e.BasicTeam.Id, Sort: "name"})
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
 
-		// Create a new checklist with a known title
-		err := e.PlaybooksClient.PlaybookRuns.CreateChecklist(context.Background(), run.ID, client.Checklist{
-			Title: "Checklist Title",
-			Items: []client.ChecklistItem{},
+		_, err = e.PlaybooksClient.PlaybookRuns.GetTaskLoad(context.Background(), client.TaskLoadOptions{})
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+	})
+
+	t.Run("not a member of the team", func(t *testing.T) {
+		_, err := e.PlaybooksClientNotInTeam.PlaybookRuns.GetTaskLoad(context.Background(), client.TaskLoadOptions{TeamID: e.BasicTeam.Id})
+		requireErrorWithStatusCode(t, err, http.StatusForbidden)
+	})
+}
+
+func TestRunKeyEvents(t *testing.T) {
+	e := Setup(t)
+	e.CreateBasic()
+
+	run := e.BasicRun
+
+	var keyEvent *client.TimelineEvent
+	t.Run("add a key event", func(t *testing.T) {
+		var err error
+		keyEvent, err = e.PlaybooksClient.PlaybookRuns.AddKeyEvent(context.Background(), run.ID, client.KeyEventOptions{
+			Summary: " Root cause found ",
+			Details: "An expired certificate",
 		})
 		require.NoError(t, err)
+		assert.Equal(t, client.KeyEvent, keyEvent.EventType)
+		assert.Equal(t, "Root cause found", keyEvent.Summary)
+		assert.Equal(t, e.RegularUser.Id, keyEvent.CreatorUserID)
+		assert.True(t, keyEvent.UserCreated)
 
-		// Add the new checklistItem with an invalid title
-		err = e.PlaybooksClient.PlaybookRuns.AddChecklistItem(context.Background(), run.ID, 0, client.ChecklistItem{
-			Title: "",
-		})
-		require.Error(t, err)
+		updatedRun, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+		require.NoError(t, err)
+		assert.Equal(t, keyEvent.ID, updatedRun.TimelineEvents[len(updatedRun.TimelineEvents)-1].ID)
 	})
 
-	t.Run("checklist adding - failure: wrong checklist number", func(t *testing.T) {
-		run := createNewRunWithNoChecklists(t)
+	t.Run("add a key event linked to a post", func(t *testing.T) {
+		post, _, err := e.ServerClient.CreatePost(&model.Post{ChannelId: run.ChannelID, Message: "the certificate expired"})
+		require.NoError(t, err)
 
-		// Create a new checklist with a known title
-		err := e.PlaybooksClient.PlaybookRuns.CreateChecklist(context.Background(), run.ID, client.Checklist{
-			Title: "Checklist Title",
-			Items: []client.ChecklistItem{},
+		event, err := e.PlaybooksClient.PlaybookRuns.AddKeyEvent(context.Background(), run.ID, client.KeyEventOptions{
+			Summary: "Certificate expired",
+			PostID:  post.Id,
 		})
 		require.NoError(t, err)
+		assert.Equal(t, post.Id, event.PostID)
+		assert.Equal(t, post.CreateAt, event.EventAt)
+	})
 
-		// Add the new checklistItem -- to an invalid checklist number (negative)
-		err = e.PlaybooksClient.PlaybookRuns.AddChecklistItem(context.Background(), run.ID, -1, client.ChecklistItem{
-			Title: "New echo item",
+	t.Run("invalid key events", func(t *testing.T) {
+		_, err := e.PlaybooksClient.PlaybookRuns.AddKeyEvent(context.Background(), run.ID, client.KeyEventOptions{Summary: "  "})
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+
+		_, err = e.PlaybooksClient.PlaybookRuns.AddKeyEvent(context.Background(), run.ID, client.KeyEventOptions{
+			Summary: "Elsewhere",
+			PostID:  e.BasicPublicChannelPost.Id,
 		})
-		require.Error(t, err)
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
 
-		// Add the new checklistItem -- to an invalid checklist number (non-existent)
-		err = e.PlaybooksClient.PlaybookRuns.AddChecklistItem(context.Background(), run.ID, len(run.Checklists)+1, client.ChecklistItem{
-			Title: "New echo item",
+		_, err = e.PlaybooksClient.PlaybookRuns.AddKeyEvent(context.Background(), run.ID, client.KeyEventOptions{
+			Summary: "Missing",
+			PostID:  model.NewId(),
 		})
-		require.Error(t, err)
+		requireErrorWithStatusCode(t, err, http.StatusNotFound)
 	})
 
-	type ExpectedError struct{ StatusCode int }
+	t.Run("key events are in the activity feed and the export", func(t *testing.T) {
+		activity, err := e.PlaybooksClient.PlaybookRuns.GetActivity(context.Background(), run.ID, client.RunActivityOptions{})
+		require.NoError(t, err)
+		found := false
+		for _, item := range activity.Items {
+			found = found || item.ID == keyEvent.ID
+		}
+		assert.True(t, found)
 
-	moveItemTests := []struct {
-		Title              string
-		Checklists         [][]string
-		SourceChecklistIdx int
-		SourceItemIdx      int
-		DestChecklistIdx   int
-		DestItemIdx        int
-		ExpectedItemTitles [][]string
-		ExpectedError      *ExpectedError
-	}{
-		{
-			"One checklist with two items - move the first item",
-			[][]string{{"00", "01"}},
-			0, 0, 0, 1,
-			[][]string{{"01", "00"}},
-			nil,
-		},
-		{
-			"One checklist with two items - move the second item",
-			[][]string{{"00", "01"}},
-			0, 1, 0, 0,
-			[][]string{{"01", "00"}},
-			nil,
-		},
-		{
-			"One checklist with three items - move the first item to the second position",
-			[][]string{{"00", "01", "02"}},
-			0, 0, 0, 1,
-			[][]string{{"01", "00", "02"}},
-			nil,
-		},
-		{
-			"One checklist with three items - move the second item to the first position",
-			[][]string{{"00", "01", "02"}},
-			0, 1, 0, 0,
-			[][]string{{"01", "00", "02"}},
-			nil,
-		},
-		{
-			"One checklist with three items - move the first item to the last position",
-			[][]string{{"00", "01", "02"}},
-			0, 0, 0, 2,
-			[][]string{{"01", "02", "00"}},
-			nil,
+		export, err := e.PlaybooksClient.PlaybookRuns.Export(context.Background(), run.ID, "md")
+		require.NoError(t, err)
+		assert.Contains(t, string(export), "Root cause found")
+	})
+
+	t.Run("without permissions", func(t *testing.T) {
+		_, err := e.PlaybooksClient2.PlaybookRuns.AddKeyEvent(context.Background(), run.ID, client.KeyEventOptions{Summary: "Not mine"})
+		requireErrorWithStatusCode(t, err, http.StatusForbidden)
+
+		err = e.PlaybooksClient2.PlaybookRuns.RemoveKeyEvent(context.Background(), run.ID, keyEvent.ID)
+		requireErrorWithStatusCode(t, err, http.StatusForbidden)
+	})
+
+	t.Run("only user-created events can be removed", func(t *testing.T) {
+		currentRun, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+		require.NoError(t, err)
+		require.Equal(t, client.PlaybookRunCreated, currentRun.TimelineEvents[0].EventType)
+
+		err = e.PlaybooksClient.PlaybookRuns.RemoveKeyEvent(context.Background(), run.ID, currentRun.TimelineEvents[0].ID)
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+	})
+
+	t.Run("remove a key event", func(t *testing.T) {
+		err := e.PlaybooksClient.PlaybookRuns.RemoveKeyEvent(context.Background(), run.ID, keyEvent.ID)
+		require.NoError(t, err)
+
+		err = e.PlaybooksClient.PlaybookRuns.RemoveKeyEvent(context.Background(), run.ID, keyEvent.ID)
+		requireErrorWithStatusCode(t, err, http.StatusNotFound)
+
+		export, err := e.PlaybooksClient.PlaybookRuns.Export(context.Background(), run.ID, "md")
+		require.NoError(t, err)
+		assert.NotContains(t, string(export), "Root cause found")
+	})
+}
+
+func TestRunEvents(t *testing.T) {
+	e := Setup(t)
+	e.CreateBasic()
+
+	privatePlaybookID, err := e.PlaybooksAdminClient.Playbooks.Create(context.Background(), client.PlaybookCreateOptions{
+		Title:  "Private",
+		TeamID: e.BasicTeam.Id,
+		Members: []client.PlaybookMember{
+			{UserID: e.AdminUser.Id, Roles: []string{app.PlaybookRoleAdmin, app.PlaybookRoleMember}},
 		},
-		{
-			"Multiple checklists - move from one to another",
-			[][]string{{"10", "11", "12"}, {"00", "01", "02"}},
-			0, 1, 1, 0,
-			[][]string{{"00", "02"}, {"01", "10", "11", "12"}},
-			nil,
+	})
+	require.NoError(t, err)
+
+	run, err := e.PlaybooksAdminClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+		Name:        "Private run",
+		OwnerUserID: e.AdminUser.Id,
+		TeamID:      e.BasicTeam.Id,
+		PlaybookID:  privatePlaybookID,
+	})
+	require.NoError(t, err)
+
+	t.Run("subscribe without permissions", func(t *testing.T) {
+		resp, err := e.ServerClient.DoAPIRequestBytes("GET", e.ServerClient.URL+"/plugins/"+manifest.Id+"/api/v0/runs/"+run.ID+"/events", nil, "")
+		assert.Error(t, err)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+}
+
+func TestRunProperties(t *testing.T) {
+	e := Setup(t)
+	e.CreateBasic()
+
+	playbookID, err := e.PlaybooksClient.Playbooks.Create(context.Background(), client.PlaybookCreateOptions{
+		Title:  "PB with properties",
+		TeamID: e.BasicTeam.Id,
+		PropertyDefinitions: []client.PropertyDefinition{
+			{Name: "Severity", Type: client.PropertyTypeSelect, Options: []string{"low", "critical"}},
+			{Name: "Cost", Type: client.PropertyTypeNumber},
 		},
-		{
-			"Multiple checklists - move to an empty checklist",
-			[][]string{{}, {"00", "01"}},
-			0, 0, 1, 0,
-			[][]string{{"01"}, {"00"}},
-			nil,
-		},
-		{
-			"Multiple checklists - leave the original checklist empty",
-			[][]string{{"10"}, {"00"}},
-			0, 0, 1, 1,
-			[][]string{{}, {"10", "00"}},
-			nil,
-		},
-		{
-			"One checklist - invalid source checklist: greater than lenght of checklists",
-			[][]string{{"00"}},
-			1, 0, 0, 0,
-			[][]string{},
-			&ExpectedError{StatusCode: 500},
-		},
-		{
-			"One checklist - invalid source checklist: negative number",
-			[][]string{{"00"}},
-			-1, 0, 0, 0,
-			[][]string{},
-			&ExpectedError{StatusCode: 500},
-		},
-		{
-			"One checklist - invalid dest checklist: greater than length of items",
-			[][]string{{"00"}},
-			0, 0, 1, 0,
-			[][]string{},
-			&ExpectedError{StatusCode: 500},
+	})
+	require.NoError(t, err)
+
+	playbook, err := e.PlaybooksClient.Playbooks.Get(context.Background(), playbookID)
+	require.NoError(t, err)
+	require.Len(t, playbook.PropertyDefinitions, 2)
+	severity, cost := playbook.PropertyDefinitions[0], playbook.PropertyDefinitions[1]
+
+	run, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+		Name:        "Run with properties",
+		OwnerUserID: e.RegularUser.Id,
+		TeamID:      e.BasicTeam.Id,
+		PlaybookID:  playbookID,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, run.PropertyValues)
+
+	t.Run("invalid definitions are rejected", func(t *testing.T) {
+		_, err := e.PlaybooksClient.Playbooks.Create(context.Background(), client.PlaybookCreateOptions{
+			Title:  "PB with invalid properties",
+			TeamID: e.BasicTeam.Id,
+			PropertyDefinitions: []client.PropertyDefinition{
+				{Name: "Severity", Type: client.PropertyTypeSelect},
+			},
+		})
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+	})
+
+	t.Run("set values", func(t *testing.T) {
+		err := e.PlaybooksClient.PlaybookRuns.SetPropertyValue(context.Background(), run.ID, severity.ID, "critical")
+		require.NoError(t, err)
+		err = e.PlaybooksClient.PlaybookRuns.SetPropertyValue(context.Background(), run.ID, cost.ID, "1200.50")
+		require.NoError(t, err)
+
+		values, err := e.PlaybooksClient.PlaybookRuns.GetPropertyValues(context.Background(), run.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []client.PropertyValue{
+			{PropertyDefinitionID: severity.ID, Name: "Severity", Value: "critical"},
+			{PropertyDefinitionID: cost.ID, Name: "Cost", Value: "1200.50"},
+		}, values)
+	})
+
+	t.Run("values must match the definition", func(t *testing.T) {
+		err := e.PlaybooksClient.PlaybookRuns.SetPropertyValue(context.Background(), run.ID, severity.ID, "unknown")
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+
+		err = e.PlaybooksClient.PlaybookRuns.SetPropertyValue(context.Background(), run.ID, cost.ID, "a lot")
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+	})
+
+	t.Run("unknown property", func(t *testing.T) {
+		err := e.PlaybooksClient.PlaybookRuns.SetPropertyValue(context.Background(), run.ID, model.NewId(), "critical")
+		requireErrorWithStatusCode(t, err, http.StatusNotFound)
+	})
+
+	t.Run("set value without permissions", func(t *testing.T) {
+		err := e.PlaybooksClient2.PlaybookRuns.SetPropertyValue(context.Background(), run.ID, severity.ID, "low")
+		requireErrorWithStatusCode(t, err, http.StatusForbidden)
+	})
+
+	t.Run("get values when not in team", func(t *testing.T) {
+		_, err := e.PlaybooksClientNotInTeam.PlaybookRuns.GetPropertyValues(context.Background(), run.ID)
+		requireErrorWithStatusCode(t, err, http.StatusForbidden)
+	})
+
+	t.Run("filter runs by property", func(t *testing.T) {
+		runs, err := e.PlaybooksClient.PlaybookRuns.List(context.Background(), 0, 100, client.PlaybookRunListOptions{
+			TeamID:     e.BasicTeam.Id,
+			Properties: []string{severity.ID + ":critical"},
+		})
+		require.NoError(t, err)
+		require.Len(t, runs.Items, 1)
+		assert.Equal(t, run.ID, runs.Items[0].ID)
+
+		runs, err = e.PlaybooksClient.PlaybookRuns.List(context.Background(), 0, 100, client.PlaybookRunListOptions{
+			TeamID:     e.BasicTeam.Id,
+			Properties: []string{severity.ID + ":low"},
+		})
+		require.NoError(t, err)
+		assert.Empty(t, runs.Items)
+
+		_, err = e.PlaybooksClient.PlaybookRuns.List(context.Background(), 0, 100, client.PlaybookRunListOptions{
+			TeamID:     e.BasicTeam.Id,
+			Properties: []string{severity.ID},
+		})
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+	})
+
+	t.Run("values are exported", func(t *testing.T) {
+		export, err := e.PlaybooksClient.PlaybookRuns.Export(context.Background(), run.ID, "md")
+		require.NoError(t, err)
+		assert.Contains(t, string(export), "- **Severity:** critical")
+	})
+
+	t.Run("unset value", func(t *testing.T) {
+		err := e.PlaybooksClient.PlaybookRuns.SetPropertyValue(context.Background(), run.ID, cost.ID, "")
+		require.NoError(t, err)
+
+		updatedRun, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []client.PropertyValue{
+			{PropertyDefinitionID: severity.ID, Name: "Severity", Value: "critical"},
+		}, updatedRun.PropertyValues)
+	})
+}
+
+func TestRunActivity(t *testing.T) {
+	e := Setup(t)
+	e.CreateBasic()
+
+	err := e.PlaybooksClient.PlaybookRuns.UpdateStatus(context.Background(), e.BasicRun.ID, "all good", 600)
+	require.NoError(t, err)
+
+	t.Run("feed merges timeline events and status updates", func(t *testing.T) {
+		activity, err := e.PlaybooksClient.PlaybookRuns.GetActivity(context.Background(), e.BasicRun.ID, client.RunActivityOptions{})
+		require.NoError(t, err)
+		assert.False(t, activity.HasMore)
+
+		statusUpdates := 0
+		for i, entry := range activity.Items {
+			if i > 0 {
+				assert.LessOrEqual(t, activity.Items[i-1].At, entry.At)
+			}
+			if entry.Type == client.RunActivityStatusUpdate {
+				statusUpdates++
+				assert.Equal(t, e.RegularUser.Id, entry.UserID)
+				require.NotNil(t, entry.StatusUpdate)
+			} else {
+				require.NotNil(t, entry.TimelineEvent)
+				assert.NotEqual(t, client.StatusUpdated, entry.TimelineEvent.EventType)
+			}
+		}
+		assert.Equal(t, 1, statusUpdates)
+	})
+
+	t.Run("paginate with the cursor", func(t *testing.T) {
+		all, err := e.PlaybooksClient.PlaybookRuns.GetActivity(context.Background(), e.BasicRun.ID, client.RunActivityOptions{})
+		require.NoError(t, err)
+
+		paginated := []client.RunActivity{}
+		options := client.RunActivityOptions{PerPage: 1}
+		for {
+			page, err := e.PlaybooksClient.PlaybookRuns.GetActivity(context.Background(), e.BasicRun.ID, options)
+			require.NoError(t, err)
+			paginated = append(paginated, page.Items...)
+			if !page.HasMore {
+				break
+			}
+			options.Cursor = page.NextCursor
+		}
+		assert.Equal(t, all.Items, paginated)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		_, err := e.PlaybooksClient.PlaybookRuns.GetActivity(context.Background(), e.BasicRun.ID, client.RunActivityOptions{Cursor: "not a cursor"})
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+	})
+
+	t.Run("get activity when not in team", func(t *testing.T) {
+		_, err := e.PlaybooksClientNotInTeam.PlaybookRuns.GetActivity(context.Background(), e.BasicRun.ID, client.RunActivityOptions{})
+		requireErrorWithStatusCode(t, err, http.StatusForbidden)
+	})
+}
+
+func TestRunClone(t *testing.T) {
+	e := Setup(t)
+	e.CreateBasic()
+
+	playbookID, err := e.PlaybooksAdminClient.Playbooks.Create(context.Background(), client.PlaybookCreateOptions{
+		Title:  "PB",
+		TeamID: e.BasicTeam.Id,
+		Public: true,
+		Members: []client.PlaybookMember{
+			{UserID: e.RegularUser.Id, Roles: []string{app.PlaybookRoleMember}},
 		},
-		{
-			"One checklist - invalid dest checklist: negative number",
-			[][]string{{"00"}},
-			0, 0, -1, 0,
-			[][]string{},
-			&ExpectedError{StatusCode: 500},
+		Checklists: []client.Checklist{
+			{
+				Title: "A",
+				Items: []client.ChecklistItem{
+					{Title: "Do this"},
+					{Title: "Then this"},
+				},
+			},
 		},
+	})
+	require.NoError(t, err)
+
+	run, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+		Name:        "Run to clone",
+		OwnerUserID: e.RegularUser.Id,
+		TeamID:      e.BasicTeam.Id,
+		PlaybookID:  playbookID,
+	})
+	require.NoError(t, err)
+
+	body, err := json.Marshal(map[string]string{"new_state": app.ChecklistItemStateClosed})
+	require.NoError(t, err)
+	resp, err := e.ServerClient.DoAPIRequestBytes("PUT", e.ServerClient.URL+"/plugins/"+manifest.Id+"/api/v0/runs/"+run.ID+"/checklists/0/item/0/state", body, "")
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	t.Run("clone without permissions", func(t *testing.T) {
+		_, err := e.PlaybooksClient2.PlaybookRuns.Clone(context.Background(), run.ID, client.PlaybookRunCloneOptions{})
+		requireErrorWithStatusCode(t, err, http.StatusForbidden)
+	})
+
+	t.Run("clone resetting item states", func(t *testing.T) {
+		clonedRun, err := e.PlaybooksClient.PlaybookRuns.Clone(context.Background(), run.ID, client.PlaybookRunCloneOptions{})
+		require.NoError(t, err)
+
+		assert.NotEqual(t, run.ID, clonedRun.ID)
+		assert.NotEqual(t, run.ChannelID, clonedRun.ChannelID)
+		assert.Equal(t, run.OwnerUserID, clonedRun.OwnerUserID)
+		assert.Equal(t, playbookID, clonedRun.PlaybookID)
+		require.Len(t, clonedRun.Checklists, 1)
+		require.Len(t, clonedRun.Checklists[0].Items, 2)
+		assert.Equal(t, "Do this", clonedRun.Checklists[0].Items[0].Title)
+		assert.Equal(t, app.ChecklistItemStateOpen, clonedRun.Checklists[0].Items[0].State)
+
+		var clonedEvent *client.TimelineEvent
+		for i, event := range clonedRun.TimelineEvents {
+			if event.EventType == client.RunCloned {
+				clonedEvent = &clonedRun.TimelineEvents[i]
+			}
+		}
+		require.NotNil(t, clonedEvent)
+		assert.Equal(t, run.ID, clonedEvent.Details)
+	})
+
+	t.Run("clone preserving item states", func(t *testing.T) {
+		clonedRun, err := e.PlaybooksClient.PlaybookRuns.Clone(context.Background(), run.ID, client.PlaybookRunCloneOptions{PreserveState: true})
+		require.NoError(t, err)
+
+		require.Len(t, clonedRun.Checklists[0].Items, 2)
+		assert.Equal(t, app.ChecklistItemStateClosed, clonedRun.Checklists[0].Items[0].State)
+		assert.Equal(t, app.ChecklistItemStateOpen, clonedRun.Checklists[0].Items[1].State)
+	})
+
+	t.Run("finished runs can't be cloned", func(t *testing.T) {
+		err := e.PlaybooksClient.PlaybookRuns.Finish(context.Background(), run.ID)
+		require.NoError(t, err)
+
+		_, err = e.PlaybooksClient.PlaybookRuns.Clone(context.Background(), run.ID, client.PlaybookRunCloneOptions{})
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+	})
+}
+
+func TestRunSearchStatusUpdates(t *testing.T) {
+	e := Setup(t)
+	e.CreateBasic()
+
+	err := e.PlaybooksClient.PlaybookRuns.UpdateStatus(context.Background(), e.BasicRun.ID, "Started the database failover", 600)
+	require.NoError(t, err)
+	err = e.PlaybooksClient.PlaybookRuns.UpdateStatus(context.Background(), e.BasicRun.ID, "All systems nominal", 600)
+	require.NoError(t, err)
+
+	t.Run("search", func(t *testing.T) {
+		results, err := e.PlaybooksClient.PlaybookRuns.SearchStatusUpdates(context.Background(), 0, 10, client.StatusUpdateSearchOptions{
+			TeamID: e.BasicTeam.Id,
+			Term:   "database failover",
+		})
+		require.NoError(t, err)
+		require.Equal(t, 1, results.TotalCount)
+		assert.Equal(t, e.BasicRun.ID, results.Items[0].PlaybookRunID)
+		assert.Contains(t, results.Items[0].Snippet, "database failover")
+	})
+
+	t.Run("not in team", func(t *testing.T) {
+		results, err := e.PlaybooksClientNotInTeam.PlaybookRuns.SearchStatusUpdates(context.Background(), 0, 10, client.StatusUpdateSearchOptions{
+			Term: "database failover",
+		})
+		require.NoError(t, err)
+		assert.Zero(t, results.TotalCount)
+	})
+
+	t.Run("empty term", func(t *testing.T) {
+		_, err := e.PlaybooksClient.PlaybookRuns.SearchStatusUpdates(context.Background(), 0, 10, client.StatusUpdateSearchOptions{
+			TeamID: e.BasicTeam.Id,
+		})
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+	})
+}
+
+func TestRunExport(t *testing.T) {
+	e := Setup(t)
+	e.CreateBasic()
+
+	t.Run("export to markdown", func(t *testing.T) {
+		err := e.PlaybooksClient.PlaybookRuns.UpdateStatus(context.Background(), e.BasicRun.ID, "all systems nominal", 600)
+		require.NoError(t, err)
+
+		export, err := e.PlaybooksClient.PlaybookRuns.Export(context.Background(), e.BasicRun.ID, "md")
+		require.NoError(t, err)
+
+		markdown := string(export)
+		assert.True(t, strings.HasPrefix(markdown, "# "+e.BasicRun.Name+"\n"))
+		assert.Contains(t, markdown, "- **Owner:** @"+e.RegularUser.Username)
+		assert.Contains(t, markdown, "## Status updates")
+		assert.Contains(t, markdown, "all systems nominal")
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		_, err := e.PlaybooksClient.PlaybookRuns.Export(context.Background(), e.BasicRun.ID, "pdf")
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+	})
+
+	t.Run("fails because not in team", func(t *testing.T) {
+		_, err := e.PlaybooksClientNotInTeam.PlaybookRuns.Export(context.Background(), e.BasicRun.ID, "md")
+		requireErrorWithStatusCode(t, err, http.StatusForbidden)
+	})
+}
+
+func TestChecklistManagement(t *testing.T) {
+	e := Setup(t)
+	e.CreateBasic()
+
+	createNewRunWithNoChecklists := func(t *testing.T) *client.PlaybookRun {
+		t.Helper()
+
+		run, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+			Name:        "Run name",
+			OwnerUserID: e.RegularUser.Id,
+			TeamID:      e.BasicTeam.Id,
+			PlaybookID:  e.BasicPlaybook.ID,
+		})
+		require.NoError(t, err)
+		require.Len(t, run.Checklists, 0)
+
+		return run
+	}
+
+	t.Run("checklist creation - success: empty checklist", func(t *testing.T) {
+		run := createNewRunWithNoChecklists(t)
+		title := "A new checklist"
+
+		// Create a valid, empty checklist
+		err := e.PlaybooksClient.PlaybookRuns.CreateChecklist(context.Background(), run.ID, client.Checklist{
+			Title: title,
+			Items: []client.ChecklistItem{},
+		})
+		require.NoError(t, err)
+
+		// Make sure the new checklist is there
+		editedRun, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+		require.NoError(t, err)
+		require.Len(t, editedRun.Checklists, 1)
+		require.Equal(t, title, editedRun.Checklists[0].Title)
+	})
+
+	t.Run("checklist creation - failure: no permissions", func(t *testing.T) {
+		run := createNewRunWithNoChecklists(t)
+		title := "A new checklist"
+
+		// Create a valid, empty checklist
+		err := e.PlaybooksClient2.PlaybookRuns.CreateChecklist(context.Background(), run.ID, client.Checklist{
+			Title: title,
+			Items: []client.ChecklistItem{},
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("checklist creation - success: checklist with items", func(t *testing.T) {
+		run := createNewRunWithNoChecklists(t)
+		title := "A new checklist"
+
+		// Create a valid checklist with some items
+		items := []client.ChecklistItem{
+			{
+				Title:       "First",
+				Description: "",
+			},
+			{
+				Title:       "Second",
+				Description: "Description",
+			},
+		}
+		err := e.PlaybooksClient.PlaybookRuns.CreateChecklist(context.Background(), run.ID, client.Checklist{
+			Title: title,
+			Items: items,
+		})
+		require.NoError(t, err)
+
+		// Make sure the new checklist is there
+		editedRun, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+		require.NoError(t, err)
+		require.Len(t, editedRun.Checklists, 1)
+		require.Equal(t, title, editedRun.Checklists[0].Title)
+		require.Equal(t, "First", editedRun.Checklists[0].Items[0].Title)
+		require.Equal(t, "Second", editedRun.Checklists[0].Items[1].Title)
+		require.Equal(t, "Description", editedRun.Checklists[0].Items[1].Description)
+	})
+
+	t.Run("checklist creation - failure: no title", func(t *testing.T) {
+		run := createNewRunWithNoChecklists(t)
+
+		// Try to create a new checklist with no title
+		err := e.PlaybooksClient.PlaybookRuns.CreateChecklist(context.Background(), run.ID, client.Checklist{
+			Title: "",
+			Items: []client.ChecklistItem{},
+		})
+		require.Error(t, err)
+
+		// Make sure that the checklist was not added
+		editedRun, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+		require.NoError(t, err)
+		require.Len(t, editedRun.Checklists, 0)
+	})
+
+	t.Run("checklist renaming - success", func(t *testing.T) {
+		run := createNewRunWithNoChecklists(t)
+		oldTitle := "Old Title"
+		newTitle := "New Title"
+
+		// Create a new checklist with a known title
+		err := e.PlaybooksClient.PlaybookRuns.CreateChecklist(context.Background(), run.ID, client.Checklist{
+			Title: oldTitle,
+			Items: []client.ChecklistItem{},
+		})
+		require.NoError(t, err)
+
+		// Rename the checklist to a new title
+		err = e.PlaybooksClient.PlaybookRuns.RenameChecklist(context.Background(), run.ID, 0, newTitle)
+		require.NoError(t, err)
+
+		// Retrieve the run again and make sure that the checklist's title has changed
+		editedRun, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+		require.NoError(t, err)
+		require.Len(t, editedRun.Checklists, 1)
+		require.Equal(t, newTitle, editedRun.Checklists[0].Title)
+	})
+
+	t.Run("checklist renaming - failure: no title", func(t *testing.T) {
+		run := createNewRunWithNoChecklists(t)
+		oldTitle := "Old Title"
+		newTitle := ""
+
+		// Create a valid checklist
+		err := e.PlaybooksClient.PlaybookRuns.CreateChecklist(context.Background(), run.ID, client.Checklist{
+			Title: oldTitle,
+			Items: []client.ChecklistItem{},
+		})
+		require.NoError(t, err)
+
+		// Try to rename the checklist to an empty title
+		err = e.PlaybooksClient.PlaybookRuns.RenameChecklist(context.Background(), run.ID, 0, newTitle)
+		require.Error(t, err)
+	})
+
+	t.Run("checklist renaming - failure: wrong checklist number", func(t *testing.T) {
+		run := createNewRunWithNoChecklists(t)
+		newTitle := "New Title"
+
+		// Try to rename a checklist that does not exist (negative number)
+		err := e.PlaybooksClient.PlaybookRuns.RenameChecklist(context.Background(), run.ID, -1, newTitle)
+		require.Error(t, err)
+
+		// Try to rename a checklist that does not exist (number greater than the index of the last checklist)
+		err = e.PlaybooksClient.PlaybookRuns.RenameChecklist(context.Background(), run.ID, len(run.Checklists), newTitle)
+		require.Error(t, err)
+	})
+
+	t.Run("checklist removal - success: result in no checklists", func(t *testing.T) {
+		run := createNewRunWithNoChecklists(t)
+		require.Len(t, run.Checklists, 0)
+
+		// Create a valid checklist
+		err := e.PlaybooksClient.PlaybookRuns.CreateChecklist(context.Background(), run.ID, client.Checklist{
+			Title: "title",
+			Items: []client.ChecklistItem{},
+		})
+		require.NoError(t, err)
+
+		// Retrieve the run again and make sure that the checklist was created
+		editedRun, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+		require.NoError(t, err)
+		require.Len(t, editedRun.Checklists, 1)
+
+		// Remove the recently created checklist
+		err = e.PlaybooksClient.PlaybookRuns.RemoveChecklist(context.Background(), run.ID, 0)
+		require.NoError(t, err)
+
+		// Retrieve the run again and make sure that the checklist was removed
+		editedRun, err = e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+		require.NoError(t, err)
+		require.Len(t, editedRun.Checklists, 0)
+	})
+
+	t.Run("checklist removal - success: still some checklists", func(t *testing.T) {
+		run := createNewRunWithNoChecklists(t)
+
+		// Create two valid checklists
+		err := e.PlaybooksClient.PlaybookRuns.CreateChecklist(context.Background(), run.ID, client.Checklist{
+			Title: "First checklist",
+			Items: []client.ChecklistItem{},
+		})
+		require.NoError(t, err)
+
+		err = e.PlaybooksClient.PlaybookRuns.CreateChecklist(context.Background(), run.ID, client.Checklist{
+			Title: "Second checklist",
+			Items: []client.ChecklistItem{},
+		})
+		require.NoError(t, err)
+
+		// Retrieve the run again and make sure that the checklists were created
+		editedRun, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+		require.NoError(t, err)
+		require.Len(t, editedRun.Checklists, 2)
+
+		// Remove the last checklist
+		err = e.PlaybooksClient.PlaybookRuns.RemoveChecklist(context.Background(), run.ID, 1)
+		require.NoError(t, err)
+
+		// Retrieve the run again and make sure that the checklist was removed
+		editedRun, err = e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+		require.NoError(t, err)
+		require.Len(t, editedRun.Checklists, 1)
+		require.Equal(t, "First checklist", editedRun.Checklists[0].Title)
+	})
+
+	t.Run("checklist removal - failure: wrong checklist number", func(t *testing.T) {
+		run := createNewRunWithNoChecklists(t)
+
+		// Try to remove a checklist that does not exist (negative number)
+		err := e.PlaybooksClient.PlaybookRuns.RemoveChecklist(context.Background(), run.ID, -1)
+		require.Error(t, err)
+
+		// Try to rename a checklist that does not exist (number greater than the index of the last checklist)
+		err = e.PlaybooksClient.PlaybookRuns.RemoveChecklist(context.Background(), run.ID, 0)
+		require.Error(t, err)
+
+		// Create a checklist so that there is at least one
+		err = e.PlaybooksClient.PlaybookRuns.CreateChecklist(context.Background(), run.ID, client.Checklist{
+			Title: "Second checklist",
+			Items: []client.ChecklistItem{},
+		})
+		require.NoError(t, err)
+
+		// Retrieve the run again and make sure that there is one checklist
+		editedRun, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+		require.NoError(t, err)
+		require.Len(t, editedRun.Checklists, 1)
+
+		// Try to remove a checklist that does not exist (number greater than the index of the last checklist)
+		err = e.PlaybooksClient.PlaybookRuns.RemoveChecklist(context.Background(), run.ID, 1)
+		require.Error(t, err)
+	})
+
+	t.Run("checklist adding - success", func(t *testing.T) {
+		run := createNewRunWithNoChecklists(t)
+
+		// Create a new checklist with a known title
+		err := e.PlaybooksClient.PlaybookRuns.CreateChecklist(context.Background(), run.ID, client.Checklist{
+			Title: "Checklist Title",
+			Items: []client.ChecklistItem{},
+		})
+		require.NoError(t, err)
+
+		// Add the new checklistItem
+		itemTitle := "New echo item"
+		command := "/echo hi!"
+		description := "A very complicated checklist item."
+		err = e.PlaybooksClient.PlaybookRuns.AddChecklistItem(context.Background(), run.ID, 0, client.ChecklistItem{
+			Title:       itemTitle,
+			Command:     command,
+			Description: description,
+		})
+		require.NoError(t, err)
+
+		// Retrieve the run again and make sure that the checklistItem is there
+		editedRun, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+		require.NoError(t, err)
+		require.Len(t, editedRun.Checklists, 1)
+		require.Len(t, editedRun.Checklists[0].Items, 1)
+		require.Equal(t, itemTitle, editedRun.Checklists[0].Items[0].Title)
+		require.Equal(t, command, editedRun.Checklists[0].Items[0].Command)
+		require.Equal(t, description, editedRun.Checklists[0].Items[0].Description)
+	})
+
+	t.Run("checklist adding - failure: no title", func(t *testing.T) {
+		run := createNewRunWithNoChecklists(t)
+
+		// Create a new checklist with a known title
+		err := e.PlaybooksClient.PlaybookRuns.CreateChecklist(context.Background(), run.ID, client.Checklist{
+			Title: "Checklist Title",
+			Items: []client.ChecklistItem{},
+		})
+		require.NoError(t, err)
+
+		// Add the new checklistItem with an invalid title
+		err = e.PlaybooksClient.PlaybookRuns.AddChecklistItem(context.Background(), run.ID, 0, client.ChecklistItem{
+			Title: "",
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("checklist adding - failure: wrong checklist number", func(t *testing.T) {
+		run := createNewRunWithNoChecklists(t)
+
+		// Create a new checklist with a known title
+		err := e.PlaybooksClient.PlaybookRuns.CreateChecklist(context.Background(), run.ID, client.Checklist{
+			Title: "Checklist Title",
+			Items: []client.ChecklistItem{},
+		})
+		require.NoError(t, err)
+
+		// Add the new checklistItem -- to an invalid checklist number (negative)
+		err = e.PlaybooksClient.PlaybookRuns.AddChecklistItem(context.Background(), run.ID, -1, client.ChecklistItem{
+			Title: "New echo item",
+		})
+		require.Error(t, err)
+
+		// Add the new checklistItem -- to an invalid checklist number (non-existent)
+		err = e.PlaybooksClient.PlaybookRuns.AddChecklistItem(context.Background(), run.ID, len(run.Checklists)+1, client.ChecklistItem{
+			Title: "New echo item",
+		})
+		require.Error(t, err)
+	})
+
+	type ExpectedError struct{ StatusCode int }
+
+	moveItemTests := []struct {
+		Title              string
+		Checklists         [][]string
+		SourceChecklistIdx int
+		SourceItemIdx      int
+		DestChecklistIdx   int
+		DestItemIdx        int
+		ExpectedItemTitles [][]string
+		ExpectedError      *ExpectedError
+	}{
+		{
+			"One checklist with two items - move the first item",
+			[][]string{{"00", "01"}},
+			0, 0, 0, 1,
+			[][]string{{"01", "00"}},
+			nil,
+		},
+		{
+			"One checklist with two items - move the second item",
+			[][]string{{"00", "01"}},
+			0, 1, 0, 0,
+			[][]string{{"01", "00"}},
+			nil,
+		},
+		{
+			"One checklist with three items - move the first item to the second position",
+			[][]string{{"00", "01", "02"}},
+			0, 0, 0, 1,
+			[][]string{{"01", "00", "02"}},
+			nil,
+		},
+		{
+			"One checklist with three items - move the second item to the first position",
+			[][]string{{"00", "01", "02"}},
+			0, 1, 0, 0,
+			[][]string{{"01", "00", "02"}},
+			nil,
+		},
+		{
+			"One checklist with three items - move the first item to the last position",
+			[][]string{{"00", "01", "02"}},
+			0, 0, 0, 2,
+			[][]string{{"01", "02", "00"}},
+			nil,
+		},
+		{
+			"Multiple checklists - move from one to another",
+			[][]string{{"10", "11", "12"}, {"00", "01", "02"}},
+			0, 1, 1, 0,
+			[][]string{{"00", "02"}, {"01", "10", "11", "12"}},
+			nil,
+		},
+		{
+			"Multiple checklists - move to an empty checklist",
+			[][]string{{}, {"00", "01"}},
+			0, 0, 1, 0,
+			[][]string{{"01"}, {"00"}},
+			nil,
+		},
+		{
+			"Multiple checklists - leave the original checklist empty",
+			[][]string{{"10"}, {"00"}},
+			0, 0, 1, 1,
+			[][]string{{}, {"10", "00"}},
+			nil,
+		},
+		{
+			"One checklist - invalid source checklist: greater than lenght of checklists",
+			[][]string{{"00"}},
+			1, 0, 0, 0,
+			[][]string{},
+			&ExpectedError{StatusCode: 500},
+		},
+		{
+			"One checklist - invalid source checklist: negative number",
+			[][]string{{"00"}},
+			-1, 0, 0, 0,
+			[][]string{},
+			&ExpectedError{StatusCode: 500},
+		},
+		{
+			"One checklist - invalid dest checklist: greater than length of items",
+			[][]string{{"00"}},
+			0, 0, 1, 0,
+			[][]string{},
+			&ExpectedError{StatusCode: 500},
+		},
+		{
+			"One checklist - invalid dest checklist: negative number",
+			[][]string{{"00"}},
+			0, 0, -1, 0,
+			[][]string{},
+			&ExpectedError{StatusCode: 500},
+		},
+		{
+			"One checklist - invalid source item: greater than lenght of items",
+			[][]string{{"00"}},
+			0, 1, 0, 0,
+			[][]string{},
+			&ExpectedError{StatusCode: 500},
+		},
+		{
+			"One checklist - invalid source item: negative number",
+			[][]string{{"00"}},
+			0, -1, 0, 0,
+			[][]string{},
+			&ExpectedError{StatusCode: 500},
+		},
+		{
+			"One checklist - invalid dest item: greater than length of items",
+			[][]string{{"00"}},
+			0, 0, 0, 1,
+			[][]string{},
+			&ExpectedError{StatusCode: 500},
+		},
+		{
+			"One checklist - invalid dest item: negative number",
+			[][]string{{"00"}},
+			0, 0, 0, -1,
+			[][]string{},
+			&ExpectedError{StatusCode: 500},
+		},
+	}
+
+	for _, test := range moveItemTests {
+		t.Run(test.Title, func(t *testing.T) {
+			// Create a new empty run
+			run := createNewRunWithNoChecklists(t)
+
+			// Add the specified checklists: note that we need to iterate backwards because CreateChecklist prepends new checklists
+			for i := len(test.Checklists) - 1; i >= 0; i-- {
+				// Generate the items for this checklist
+				checklist := test.Checklists[i]
+				items := make([]client.ChecklistItem, 0, len(checklist))
+				for _, title := range checklist {
+					items = append(items, client.ChecklistItem{Title: title})
+				}
+
+				// Create the checklist with the defined items
+				err := e.PlaybooksClient.PlaybookRuns.CreateChecklist(context.Background(), run.ID, client.Checklist{
+					Title: "Checklist",
+					Items: items,
+				})
+				require.NoError(t, err)
+			}
+
+			// Move the item from its source to its destination
+			err := e.PlaybooksClient.PlaybookRuns.MoveChecklistItem(context.Background(), run.ID, test.SourceChecklistIdx, test.SourceItemIdx, test.DestChecklistIdx, test.DestItemIdx)
+
+			// If an error is expected, check that it's the one we expect
+			if test.ExpectedError != nil {
+				requireErrorWithStatusCode(t, err, test.ExpectedError.StatusCode)
+				return
+			}
+
+			// If no error is expected, retrieve the run again
+			require.NoError(t, err)
+			run, err = e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+			require.NoError(t, err)
+
+			// And check that the new checklists are ordered as specified by the test data
+			for checklistIdx, actualChecklist := range run.Checklists {
+				expectedItemTitles := test.ExpectedItemTitles[checklistIdx]
+				require.Len(t, actualChecklist.Items, len(expectedItemTitles))
+
+				for itemIdx, actualItem := range actualChecklist.Items {
+					require.Equal(t, expectedItemTitles[itemIdx], actualItem.Title)
+				}
+			}
+		})
+	}
+
+	moveChecklistTests := []struct {
+		Title              string
+		Checklists         []string
+		SourceChecklistIdx int
+		DestChecklistIdx   int
+		ExpectedChecklists []string
+		ExpectedError      *ExpectedError
+	}{
+		{
+			"Move checklist to the same position",
+			[]string{"0"},
+			0, 0,
+			[]string{"0"},
+			nil,
+		},
+		{
+			"Swap two checklists, moving the first one",
+			[]string{"1", "0"},
+			0, 1,
+			[]string{"1", "0"},
+			nil,
+		},
+		{
+			"Swap two checklists, moving the second one",
+			[]string{"1", "0"},
+			1, 0,
+			[]string{"1", "0"},
+			nil,
+		},
+		{
+			"Move a checklist in a list of three checklists - first to second ",
+			[]string{"2", "1", "0"},
+			0, 1,
+			[]string{"1", "0", "2"},
+			nil,
+		},
+		{
+			"Move a checklist in a list of three checklists - first to third",
+			[]string{"2", "1", "0"},
+			0, 2,
+			[]string{"1", "2", "0"},
+			nil,
+		},
+		{
+			"Move a checklist in a list of three checklists - second to first",
+			[]string{"2", "1", "0"},
+			1, 0,
+			[]string{"1", "0", "2"},
+			nil,
+		},
+		{
+			"Move a checklist in a list of three checklists - second to third",
+			[]string{"2", "1", "0"},
+			1, 2,
+			[]string{"0", "2", "1"},
+			nil,
+		},
+		{
+			"Move a checklist in a list of three checklists - third to first",
+			[]string{"2", "1", "0"},
+			2, 0,
+			[]string{"2", "0", "1"},
+			nil,
+		},
+		{
+			"Move a checklist in a list of three checklists - third to second",
+			[]string{"2", "1", "0"},
+			2, 1,
+			[]string{"0", "2", "1"},
+			nil,
+		},
+		{
+			"Wrong destination index - greater than length of list",
+			[]string{"2", "1", "0"},
+			0, 5,
+			[]string{"0", "1", "2"},
+			&ExpectedError{500},
+		},
+		{
+			"Wrong destination index - negative",
+			[]string{"2", "1", "0"},
+			0, -5,
+			[]string{"0", "1", "2"},
+			&ExpectedError{500},
+		},
+		{
+			"Wrong source index - greater than length of list",
+			[]string{"2", "1", "0"},
+			5, 0,
+			[]string{"0", "1", "2"},
+			&ExpectedError{500},
+		},
+		{
+			"Wrong source index - negative",
+			[]string{"2", "1", "0"},
+			-5, 0,
+			[]string{"0", "1", "2"},
+			&ExpectedError{500},
+		},
+	}
+
+	for _, test := range moveChecklistTests {
+		t.Run(test.Title, func(t *testing.T) {
+			// Create a new empty run
+			run := createNewRunWithNoChecklists(t)
+
+			// Add the specified checklists: note that we need to iterate backwards because CreateChecklist prepends new checklists
+			for i := len(test.Checklists) - 1; i >= 0; i-- {
+				err := e.PlaybooksClient.PlaybookRuns.CreateChecklist(context.Background(), run.ID, client.Checklist{
+					Title: test.Checklists[i],
+				})
+				require.NoError(t, err)
+			}
+
+			// Move the checklist from its source to its destination
+			err := e.PlaybooksClient.PlaybookRuns.MoveChecklist(context.Background(), run.ID, test.SourceChecklistIdx, test.DestChecklistIdx)
+
+			// If an error is expected, check that it's the one we expect
+			if test.ExpectedError != nil {
+				requireErrorWithStatusCode(t, err, test.ExpectedError.StatusCode)
+				return
+			}
+
+			// If no error is expected, retrieve the run again
+			require.NoError(t, err)
+			run, err = e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+			require.NoError(t, err)
+
+			// And check that the new checklists are ordered as specified by the test data
+			for checklistIdx, actualChecklist := range run.Checklists {
+				require.Equal(t, test.ExpectedChecklists[checklistIdx], actualChecklist.Title)
+			}
+		})
+	}
+
+	createRunWithItems := func(t *testing.T, titles ...string) (*client.PlaybookRun, []string) {
+		t.Helper()
+
+		run := createNewRunWithNoChecklists(t)
+		items := make([]client.ChecklistItem, 0, len(titles))
+		for _, title := range titles {
+			items = append(items, client.ChecklistItem{Title: title})
+		}
+		err := e.PlaybooksClient.PlaybookRuns.CreateChecklist(context.Background(), run.ID, client.Checklist{
+			Title: "Checklist",
+			Items: items,
+		})
+		require.NoError(t, err)
+
+		run, err = e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+		require.NoError(t, err)
+
+		itemIDs := make([]string, 0, len(titles))
+		for _, item := range run.Checklists[0].Items {
+			itemIDs = append(itemIDs, item.ID)
+		}
+
+		return run, itemIDs
+	}
+
+	t.Run("checklist items reorder - success", func(t *testing.T) {
+		run, itemIDs := createRunWithItems(t, "0", "1", "2")
+
+		err := e.PlaybooksClient.PlaybookRuns.ReorderChecklistItems(context.Background(), run.ID, 0, []string{itemIDs[2], itemIDs[0], itemIDs[1]})
+		require.NoError(t, err)
+
+		run, err = e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+		require.NoError(t, err)
+		require.Len(t, run.Checklists[0].Items, 3)
+		require.Equal(t, "2", run.Checklists[0].Items[0].Title)
+		require.Equal(t, "0", run.Checklists[0].Items[1].Title)
+		require.Equal(t, "1", run.Checklists[0].Items[2].Title)
+	})
+
+	t.Run("checklist items reorder - failure: wrong set of ids", func(t *testing.T) {
+		run, itemIDs := createRunWithItems(t, "0", "1", "2")
+
+		for _, ids := range [][]string{
+			{itemIDs[0], itemIDs[1]},
+			{itemIDs[0], itemIDs[1], itemIDs[1]},
+			{itemIDs[0], itemIDs[1], model.NewId()},
+			{itemIDs[0], itemIDs[1], itemIDs[2], model.NewId()},
+		} {
+			err := e.PlaybooksClient.PlaybookRuns.ReorderChecklistItems(context.Background(), run.ID, 0, ids)
+			requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+		}
+
+		run, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+		require.NoError(t, err)
+		for i, item := range run.Checklists[0].Items {
+			require.Equal(t, itemIDs[i], item.ID)
+		}
+	})
+
+	t.Run("checklist items reorder - failure: wrong checklist number", func(t *testing.T) {
+		run, itemIDs := createRunWithItems(t, "0", "1")
+
+		err := e.PlaybooksClient.PlaybookRuns.ReorderChecklistItems(context.Background(), run.ID, 1, itemIDs)
+		require.Error(t, err)
+	})
+
+	t.Run("checklist items reorder - failure: no permissions", func(t *testing.T) {
+		run, itemIDs := createRunWithItems(t, "0", "1")
+
+		err := e.PlaybooksClient2.PlaybookRuns.ReorderChecklistItems(context.Background(), run.ID, 0, []string{itemIDs[1], itemIDs[0]})
+		require.Error(t, err)
+	})
+}
+
+func TestChecklisFailTooLarge(t *testing.T) {
+	e := Setup(t)
+	e.CreateBasic()
+
+	t.Run("checklist creation - failure: too large checklist", func(t *testing.T) {
+		run, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+			Name:        "Run name",
+			OwnerUserID: e.RegularUser.Id,
+			TeamID:      e.BasicTeam.Id,
+			PlaybookID:  e.BasicPlaybook.ID,
+		})
+		require.NoError(t, err)
+		require.Len(t, run.Checklists, 0)
+
+		err = e.PlaybooksClient.PlaybookRuns.CreateChecklist(context.Background(), run.ID, client.Checklist{
+			Title: "My regular title",
+			Items: []client.ChecklistItem{
+				{Title: "Item title", Description: strings.Repeat("A", (256*1024)+1)},
+			},
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestRunGetStatusUpdates(t *testing.T) {
+	e := Setup(t)
+	e.CreateBasic()
+
+	t.Run("public - get no updates", func(t *testing.T) {
+		statusUpdates, err := e.PlaybooksClient.PlaybookRuns.GetStatusUpdates(context.Background(), e.BasicRun.ID)
+		assert.NoError(t, err)
+		assert.Len(t, statusUpdates, 0)
+	})
+
+	t.Run("public - get 2 updates as participant", func(t *testing.T) {
+		err := e.PlaybooksClient.PlaybookRuns.UpdateStatus(context.Background(), e.BasicRun.ID, "update 1", 5000)
+		require.NoError(t, err)
+		err = e.PlaybooksClient.PlaybookRuns.UpdateStatus(context.Background(), e.BasicRun.ID, "update 2", 10000)
+		require.NoError(t, err)
+
+		statusUpdates, err := e.PlaybooksClient.PlaybookRuns.GetStatusUpdates(context.Background(), e.BasicRun.ID)
+		require.NoError(t, err)
+		assert.Len(t, statusUpdates, 2)
+		assert.Equal(t, "update 2", statusUpdates[0].Message)
+		assert.Equal(t, "update 1", statusUpdates[1].Message)
+		assert.Equal(t, e.RegularUser.Username, statusUpdates[0].AuthorUserName)
+	})
+
+	t.Run("public - get 2 updates as viewer", func(t *testing.T) {
+		statusUpdates, err := e.PlaybooksClient2.PlaybookRuns.GetStatusUpdates(context.Background(), e.BasicRun.ID)
+		require.NoError(t, err)
+		assert.Len(t, statusUpdates, 2)
+		assert.Equal(t, "update 2", statusUpdates[0].Message)
+		assert.Equal(t, "update 1", statusUpdates[1].Message)
+		assert.Equal(t, e.RegularUser.Username, statusUpdates[0].AuthorUserName)
+		assert.Equal(t, e.RegularUser.Username, statusUpdates[1].AuthorUserName)
+	})
+
+	t.Run("public - fails because not in team", func(t *testing.T) {
+		statusUpdates, err := e.PlaybooksClientNotInTeam.PlaybookRuns.GetStatusUpdates(context.Background(), e.BasicRun.ID)
+		require.Error(t, err)
+		assert.Len(t, statusUpdates, 0)
+	})
+
+	t.Run("private - get no updates", func(t *testing.T) {
+		privateRun, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+			Name:        "Basic create",
+			OwnerUserID: e.RegularUser.Id,
+			TeamID:      e.BasicTeam.Id,
+			PlaybookID:  e.BasicPrivatePlaybook.ID,
+		})
+		assert.NoError(t, err)
+
+		statusUpdates, err := e.PlaybooksClient.PlaybookRuns.GetStatusUpdates(context.Background(), privateRun.ID)
+		assert.NoError(t, err)
+		assert.Len(t, statusUpdates, 0)
+	})
+
+	t.Run("private - get 2 updates as participant", func(t *testing.T) {
+		privateRun, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+			Name:        "Basic create",
+			OwnerUserID: e.RegularUser.Id,
+			TeamID:      e.BasicTeam.Id,
+			PlaybookID:  e.BasicPrivatePlaybook.ID,
+		})
+		assert.NoError(t, err)
+
+		err = e.PlaybooksClient.PlaybookRuns.UpdateStatus(context.Background(), privateRun.ID, "update 1", 5000)
+		require.NoError(t, err)
+		err = e.PlaybooksClient.PlaybookRuns.UpdateStatus(context.Background(), privateRun.ID, "update 2", 10000)
+		require.NoError(t, err)
+
+		statusUpdates, err := e.PlaybooksClient.PlaybookRuns.GetStatusUpdates(context.Background(), privateRun.ID)
+		require.NoError(t, err)
+		assert.Len(t, statusUpdates, 2)
+		assert.Equal(t, "update 2", statusUpdates[0].Message)
+		assert.Equal(t, "update 1", statusUpdates[1].Message)
+		assert.Equal(t, e.RegularUser.Username, statusUpdates[0].AuthorUserName)
+		assert.Equal(t, e.RegularUser.Username, statusUpdates[1].AuthorUserName)
+	})
+
+	t.Run("private - get 2 updates as viewer", func(t *testing.T) {
+		privatePlaybookID, err := e.PlaybooksAdminClient.Playbooks.Create(context.Background(), client.PlaybookCreateOptions{
+			Title:  "TestPrivatePlaybook custom",
+			TeamID: e.BasicTeam.Id,
+			Public: false,
+			Members: []client.PlaybookMember{
+				{UserID: e.RegularUser2.Id, Roles: []string{app.PlaybookRoleMember}},
+				{UserID: e.RegularUser.Id, Roles: []string{app.PlaybookRoleMember}},
+				{UserID: e.AdminUser.Id, Roles: []string{app.PlaybookRoleAdmin, app.PlaybookRoleMember}},
+			},
+		})
+		require.NoError(t, err)
+
+		privatePlaybook, err := e.PlaybooksClient.Playbooks.Get(context.Background(), privatePlaybookID)
+		require.NoError(e.T, err)
+
+		privateRun, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+			Name:        "Basic create",
+			OwnerUserID: e.RegularUser.Id,
+			TeamID:      e.BasicTeam.Id,
+			PlaybookID:  privatePlaybook.ID,
+		})
+		require.NoError(t, err)
+
+		err = e.PlaybooksClient.PlaybookRuns.UpdateStatus(context.Background(), privateRun.ID, "update 1", 5000)
+		require.NoError(t, err)
+		err = e.PlaybooksClient.PlaybookRuns.UpdateStatus(context.Background(), privateRun.ID, "update 2", 10000)
+		require.NoError(t, err)
+
+		statusUpdates, err := e.PlaybooksClient2.PlaybookRuns.GetStatusUpdates(context.Background(), privateRun.ID)
+		require.NoError(t, err)
+		assert.Len(t, statusUpdates, 2)
+		assert.Equal(t, "update 2", statusUpdates[0].Message)
+		assert.Equal(t, "update 1", statusUpdates[1].Message)
+		assert.Equal(t, e.RegularUser.Username, statusUpdates[0].AuthorUserName)
+	})
+
+	t.Run("private - fails because not in playbook members", func(t *testing.T) {
+		privateRun, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+			Name:        "Basic create",
+			OwnerUserID: e.RegularUser.Id,
+			TeamID:      e.BasicTeam.Id,
+			PlaybookID:  e.BasicPrivatePlaybook.ID,
+		})
+		require.NoError(t, err)
+
+		statusUpdates, err := e.PlaybooksClient2.PlaybookRuns.GetStatusUpdates(context.Background(), privateRun.ID)
+		require.Error(t, err)
+		assert.Len(t, statusUpdates, 0)
+	})
+}
+
+func TestRequestUpdate(t *testing.T) {
+	e := Setup(t)
+	e.CreateBasic()
+
+	t.Run("private - no viewer access ", func(t *testing.T) {
+		privateRun, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+			Name:        "Basic create",
+			OwnerUserID: e.RegularUser.Id,
+			TeamID:      e.BasicTeam.Id,
+			PlaybookID:  e.BasicPrivatePlaybook.ID,
+		})
+		assert.NoError(t, err)
+
+		err = e.PlaybooksClient2.PlaybookRuns.RequestUpdate(context.Background(), privateRun.ID, e.RegularUser2.Id)
+		assert.Error(t, err)
+
+		err = e.PlaybooksClientNotInTeam.PlaybookRuns.RequestUpdate(context.Background(), privateRun.ID, e.RegularUserNotInTeam.Id)
+		assert.Error(t, err)
+	})
+
+	t.Run("private - viewer access ", func(t *testing.T) {
+		privatePlaybookID, err := e.PlaybooksClient.Playbooks.Create(context.Background(), client.PlaybookCreateOptions{
+			Title:  "TestPrivatePlaybook custom",
+			TeamID: e.BasicTeam.Id,
+			Public: false,
+			Members: []client.PlaybookMember{
+				{UserID: e.RegularUser.Id, Roles: []string{app.PlaybookRoleMember}},
+			},
+		})
+		require.NoError(t, err)
+
+		privatePlaybook, err := e.PlaybooksClient.Playbooks.Get(context.Background(), privatePlaybookID)
+		require.NoError(e.T, err)
+
+		privateRun, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+			Name:        "Basic create",
+			OwnerUserID: e.RegularUser.Id,
+			TeamID:      e.BasicTeam.Id,
+			PlaybookID:  privatePlaybookID,
+		})
+		assert.NoError(t, err)
+
+		// No access, RegularUser2 is not a Viewer
+		err = e.PlaybooksClient2.PlaybookRuns.RequestUpdate(context.Background(), privateRun.ID, e.RegularUser2.Id)
+		assert.Error(t, err)
+
+		// Add RegularUser2 as a Viewer
+		privatePlaybook.Members = append(privatePlaybook.Members, client.PlaybookMember{UserID: e.RegularUser2.Id, Roles: []string{app.PlaybookRoleMember}})
+		err = e.PlaybooksClient.Playbooks.Update(context.Background(), *privatePlaybook)
+		assert.NoError(t, err)
+
+		// Gained Viewer access
+		err = e.PlaybooksClient2.PlaybookRuns.RequestUpdate(context.Background(), privateRun.ID, e.RegularUser2.Id)
+		assert.NoError(t, err)
+
+		// Assert that timeline event is created
+		privateRun, err = e.PlaybooksClient.PlaybookRuns.Get(context.Background(), privateRun.ID)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, privateRun.TimelineEvents)
+		lastEvent := privateRun.TimelineEvents[len(privateRun.TimelineEvents)-1]
+		assert.Equal(t, client.StatusUpdateRequested, lastEvent.EventType)
+		assert.Equal(t, e.RegularUser2.Id, lastEvent.SubjectUserID)
+		assert.Equal(t, e.RegularUser2.Id, lastEvent.CreatorUserID)
+		assert.NotZero(t, lastEvent.PostID)
+		assert.Equal(t, "@playbooksuser2 requested a status update", lastEvent.Summary)
+	})
+
+	t.Run("public - viewer access ", func(t *testing.T) {
+		publicRun, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+			Name:        "Basic create",
+			OwnerUserID: e.RegularUser.Id,
+			TeamID:      e.BasicTeam.Id,
+			PlaybookID:  e.BasicPlaybook.ID,
+		})
+		assert.NoError(t, err)
+
+		err = e.PlaybooksClient2.PlaybookRuns.RequestUpdate(context.Background(), publicRun.ID, e.RegularUser2.Id)
+		assert.NoError(t, err)
+
+		// Assert that timeline event is created
+		publicRun, err = e.PlaybooksClient.PlaybookRuns.Get(context.Background(), publicRun.ID)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, publicRun.TimelineEvents)
+		lastEvent := publicRun.TimelineEvents[len(publicRun.TimelineEvents)-1]
+		assert.Equal(t, client.StatusUpdateRequested, lastEvent.EventType)
+		assert.Equal(t, e.RegularUser2.Id, lastEvent.SubjectUserID)
+		assert.Equal(t, "@playbooksuser2 requested a status update", lastEvent.Summary)
+
+		err = e.PlaybooksClientNotInTeam.PlaybookRuns.RequestUpdate(context.Background(), publicRun.ID, e.RegularUserNotInTeam.Id)
+		assert.Error(t, err)
+	})
+}
+
+func TestReminderReset(t *testing.T) {
+	e := Setup(t)
+	e.CreateBasic()
+
+	t.Run("reminder reset - event created", func(t *testing.T) {
+		payload := client.ReminderResetPayload{
+			NewReminderSeconds: 100,
+		}
+		err := e.PlaybooksClient.Reminders.Reset(context.Background(), e.BasicRun.ID, payload)
+		assert.NoError(t, err)
+
+		pb, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), e.BasicRun.ID)
+		assert.NoError(t, err)
+
+		statusSnoozed := make([]client.TimelineEvent, 0)
+		for _, te := range pb.TimelineEvents {
+			if te.EventType == "status_update_snoozed" {
+				statusSnoozed = append(statusSnoozed, te)
+			}
+		}
+
+		require.Len(t, statusSnoozed, 1)
+	})
+}
+
+func TestChecklisItem_SetAssignee(t *testing.T) {
+	e := Setup(t)
+	e.CreateBasic()
+
+	addSimpleChecklistToTun := func(t *testing.T, runID string) *client.PlaybookRun {
+		checklist := client.Checklist{
+			Title: "Test Checklist",
+			Items: []client.ChecklistItem{
+				{
+					Title: "Test Item",
+				},
+			},
+		}
+
+		err := e.PlaybooksClient.PlaybookRuns.CreateChecklist(context.Background(), runID, checklist)
+		require.NoError(t, err)
+
+		run, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), runID)
+		require.NoError(t, err)
+		require.Len(t, run.Checklists, 1)
+		require.Len(t, run.Checklists[0].Items, 1)
+		return run
+	}
+
+	t.Run("set assignee and participant", func(t *testing.T) {
+		run, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+			Name:        "Run name",
+			OwnerUserID: e.RegularUser.Id,
+			TeamID:      e.BasicTeam.Id,
+			PlaybookID:  e.BasicPlaybook.ID,
+		})
+		require.NoError(t, err)
+		require.Len(t, run.Checklists, 0)
+
+		run = addSimpleChecklistToTun(t, run.ID)
+
+		// assignee is not set and user is not participant (before)
+		require.Empty(t, run.Checklists[0].Items[0].AssigneeID)
+		require.Len(t, run.ParticipantIDs, 1)
+		require.NotContains(t, run.ParticipantIDs, e.RegularUser2.Id)
+
+		// set assignee
+		err = e.PlaybooksClient.PlaybookRuns.SetItemAssignee(context.Background(), run.ID, 0, 0, e.RegularUser2.Id)
+		require.NoError(t, err)
+
+		// assignee is not set and user is not participant (after)
+		run, err = e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+		require.NoError(t, err)
+		require.Equal(t, e.RegularUser2.Id, run.Checklists[0].Items[0].AssigneeID)
+		require.Contains(t, run.ParticipantIDs, e.RegularUser2.Id)
+	})
+
+	t.Run("set and unset", func(t *testing.T) {
+		run, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+			Name:        "Run name",
+			OwnerUserID: e.RegularUser.Id,
+			TeamID:      e.BasicTeam.Id,
+			PlaybookID:  e.BasicPlaybook.ID,
+		})
+		require.NoError(t, err)
+		require.Len(t, run.Checklists, 0)
+
+		run = addSimpleChecklistToTun(t, run.ID)
+
+		// set assignee
+		err = e.PlaybooksClient.PlaybookRuns.SetItemAssignee(context.Background(), run.ID, 0, 0, e.RegularUser.Id)
+		require.NoError(t, err)
+
+		run, err = e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+		require.NoError(t, err)
+		require.Equal(t, e.RegularUser.Id, run.Checklists[0].Items[0].AssigneeID)
+
+		// unset assignee
+		err = e.PlaybooksClient.PlaybookRuns.SetItemAssignee(context.Background(), run.ID, 0, 0, "")
+		require.NoError(t, err)
+
+		run, err = e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+		require.NoError(t, err)
+		require.Equal(t, "", run.Checklists[0].Items[0].AssigneeID)
+	})
+
+	t.Run("idempotent action", func(t *testing.T) {
+		run, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+			Name:        "Run name",
+			OwnerUserID: e.RegularUser.Id,
+			TeamID:      e.BasicTeam.Id,
+			PlaybookID:  e.BasicPlaybook.ID,
+		})
+		require.NoError(t, err)
+		require.Len(t, run.Checklists, 0)
+
+		run = addSimpleChecklistToTun(t, run.ID)
+
+		// set assignee
+		err = e.PlaybooksClient.PlaybookRuns.SetItemAssignee(context.Background(), run.ID, 0, 0, e.RegularUser.Id)
+		require.NoError(t, err)
+
+		run, err = e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+		require.NoError(t, err)
+		require.Equal(t, e.RegularUser.Id, run.Checklists[0].Items[0].AssigneeID)
+
+		// unset assignee
+		err = e.PlaybooksClient.PlaybookRuns.SetItemAssignee(context.Background(), run.ID, 0, 0, e.RegularUser.Id)
+		require.NoError(t, err)
+
+		run, err = e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+		require.NoError(t, err)
+		require.Equal(t, e.RegularUser.Id, run.Checklists[0].Items[0].AssigneeID)
+	})
+}
+
+func TestReassignTasks(t *testing.T) {
+	e := Setup(t)
+	e.CreateBasic()
+
+	createRunWithTasks := func(t *testing.T, c *client.Client, ownerID, assigneeID string, numTasks int) *client.PlaybookRun {
+		t.Helper()
+
+		run, err := c.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+			Name:        "Run name",
+			OwnerUserID: ownerID,
+			TeamID:      e.BasicTeam.Id,
+			PlaybookID:  e.BasicPlaybook.ID,
+		})
+		require.NoError(t, err)
+
+		items := make([]client.ChecklistItem, numTasks)
+		for i := range items {
+			items[i] = client.ChecklistItem{Title: "Task"}
+		}
+		err = c.PlaybookRuns.CreateChecklist(context.Background(), run.ID, client.Checklist{Title: "Checklist", Items: items})
+		require.NoError(t, err)
+
+		for i := range items {
+			err = c.PlaybookRuns.SetItemAssignee(context.Background(), run.ID, 0, i, assigneeID)
+			require.NoError(t, err)
+		}
+
+		return run
+	}
+
+	t.Run("reassign in the runs the user can manage", func(t *testing.T) {
+		managed := createRunWithTasks(t, e.PlaybooksClient, e.RegularUser.Id, e.RegularUser2.Id, 2)
+		notManaged := createRunWithTasks(t, e.PlaybooksClient2, e.RegularUser2.Id, e.RegularUser2.Id, 1)
+
+		result, err := e.PlaybooksClient.PlaybookRuns.ReassignTasks(context.Background(), client.ReassignTasksOptions{
+			FromUserID:     e.RegularUser2.Id,
+			ToUserID:       e.RegularUser.Id,
+			PlaybookRunIDs: []string{managed.ID, notManaged.ID},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.ItemsReassigned)
+		assert.Equal(t, 1, result.RunsChanged)
+		assert.Equal(t, []string{notManaged.ID}, result.SkippedRunIDs)
+
+		run, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), managed.ID)
+		require.NoError(t, err)
+		for _, item := range run.Checklists[0].Items {
+			assert.Equal(t, e.RegularUser.Id, item.AssigneeID)
+		}
+
+		run, err = e.PlaybooksClient2.PlaybookRuns.Get(context.Background(), notManaged.ID)
+		require.NoError(t, err)
+		assert.Equal(t, e.RegularUser2.Id, run.Checklists[0].Items[0].AssigneeID)
+
+		// Nothing is left to reassign.
+		result, err = e.PlaybooksClient.PlaybookRuns.ReassignTasks(context.Background(), client.ReassignTasksOptions{
+			FromUserID:     e.RegularUser2.Id,
+			ToUserID:       e.RegularUser.Id,
+			PlaybookRunIDs: []string{managed.ID},
+		})
+		require.NoError(t, err)
+		assert.Zero(t, result.ItemsReassigned)
+		assert.Zero(t, result.RunsChanged)
+	})
+
+	t.Run("finished runs are left untouched", func(t *testing.T) {
+		run := createRunWithTasks(t, e.PlaybooksClient, e.RegularUser.Id, e.RegularUser2.Id, 1)
+		err := e.PlaybooksClient.PlaybookRuns.Finish(context.Background(), run.ID)
+		require.NoError(t, err)
+
+		result, err := e.PlaybooksClient.PlaybookRuns.ReassignTasks(context.Background(), client.ReassignTasksOptions{
+			FromUserID:     e.RegularUser2.Id,
+			ToUserID:       e.RegularUser.Id,
+			PlaybookRunIDs: []string{run.ID},
+		})
+		require.NoError(t, err)
+		assert.Zero(t, result.ItemsReassigned)
+		assert.Empty(t, result.SkippedRunIDs)
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		for name, options := range map[string]client.ReassignTasksOptions{
+			"same user":       {FromUserID: e.RegularUser.Id, ToUserID: e.RegularUser.Id},
+			"missing user":    {FromUserID: e.RegularUser.Id},
+			"invalid run ids": {FromUserID: e.RegularUser.Id, ToUserID: e.RegularUser2.Id, PlaybookRunIDs: []string{"invalid"}},
+		} {
+			t.Run(name, func(t *testing.T) {
+				_, err := e.PlaybooksClient.PlaybookRuns.ReassignTasks(context.Background(), options)
+				requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+			})
+		}
+	})
+}
+
+func TestRunTags(t *testing.T) {
+	e := Setup(t)
+	e.CreateBasic()
+
+	createRun := func(t *testing.T) *client.PlaybookRun {
+		t.Helper()
+
+		run, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+			Name:        "Run name",
+			OwnerUserID: e.RegularUser.Id,
+			TeamID:      e.BasicTeam.Id,
+			PlaybookID:  e.BasicPlaybook.ID,
+		})
+		require.NoError(t, err)
+
+		return run
+	}
+
+	listRunIDs := func(t *testing.T, tags []string, matchAll bool) []string {
+		t.Helper()
+
+		list, err := e.PlaybooksClient.PlaybookRuns.List(context.Background(), 0, 100, client.PlaybookRunListOptions{
+			TeamID:       e.BasicTeam.Id,
+			Tags:         tags,
+			TagsMatchAll: matchAll,
+		})
+		require.NoError(t, err)
+
+		ids := []string{}
+		for _, run := range list.Items {
+			ids = append(ids, run.ID)
+		}
+		return ids
+	}
+
+	securityRun := createRun(t)
+	postmortemRun := createRun(t)
+
+	t.Run("add tags", func(t *testing.T) {
+		err := e.PlaybooksClient.PlaybookRuns.AddTags(context.Background(), securityRun.ID, []string{" Security ", "postmortem-needed"})
+		require.NoError(t, err)
+		err = e.PlaybooksClient.PlaybookRuns.AddTags(context.Background(), postmortemRun.ID, []string{"postmortem-needed"})
+		require.NoError(t, err)
+
+		run, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), securityRun.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"postmortem-needed", "security"}, run.Tags)
+	})
+
+	t.Run("filter by tags", func(t *testing.T) {
+		assert.ElementsMatch(t, []string{securityRun.ID, postmortemRun.ID}, listRunIDs(t, []string{"security", "postmortem-needed"}, false))
+		assert.ElementsMatch(t, []string{securityRun.ID}, listRunIDs(t, []string{"security", "postmortem-needed"}, true))
+		assert.ElementsMatch(t, []string{securityRun.ID}, listRunIDs(t, []string{"SECURITY"}, false))
+	})
+
+	t.Run("autocomplete tags", func(t *testing.T) {
+		tags, err := e.PlaybooksClient.PlaybookRuns.GetTags(context.Background(), e.BasicTeam.Id, "")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"postmortem-needed", "security"}, tags)
+
+		tags, err = e.PlaybooksClient.PlaybookRuns.GetTags(context.Background(), e.BasicTeam.Id, "Sec")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"security"}, tags)
+	})
+
+	t.Run("autocomplete tags of another team", func(t *testing.T) {
+		_, err := e.PlaybooksClientNotInTeam.PlaybookRuns.GetTags(context.Background(), e.BasicTeam.Id, "")
+		requireErrorWithStatusCode(t, err, http.StatusForbidden)
+	})
+
+	t.Run("invalid tags", func(t *testing.T) {
+		for name, tag := range map[string]string{
+			"empty":    " ",
+			"comma":    "a,b",
+			"slash":    "a/b",
+			"too long": strings.Repeat("a", app.MaxRunTagLength+1),
+		} {
+			t.Run(name, func(t *testing.T) {
+				err := e.PlaybooksClient.PlaybookRuns.AddTags(context.Background(), securityRun.ID, []string{tag})
+				requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+			})
+		}
+	})
+
+	t.Run("tag a run without permissions", func(t *testing.T) {
+		err := e.PlaybooksClient2.PlaybookRuns.AddTags(context.Background(), securityRun.ID, []string{"sev-1"})
+		requireErrorWithStatusCode(t, err, http.StatusForbidden)
+	})
+
+	t.Run("remove tag", func(t *testing.T) {
+		err := e.PlaybooksClient.PlaybookRuns.RemoveTag(context.Background(), securityRun.ID, "security")
+		require.NoError(t, err)
+
+		run, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), securityRun.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"postmortem-needed"}, run.Tags)
+
+		assert.Empty(t, listRunIDs(t, []string{"security"}, false))
+	})
+}
+
+func TestRunsCSVExport(t *testing.T) {
+	e := Setup(t)
+	e.CreateBasic()
+
+	run, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+		Name:        "Run, with a comma",
+		OwnerUserID: e.RegularUser.Id,
+		TeamID:      e.BasicTeam.Id,
+		PlaybookID:  e.BasicPlaybook.ID,
+	})
+	require.NoError(t, err)
+
+	t.Run("export the team's runs", func(t *testing.T) {
+		var export bytes.Buffer
+		err := e.PlaybooksClient.PlaybookRuns.ExportCSV(context.Background(), e.BasicTeam.Id, &export)
+		require.NoError(t, err)
+
+		records, err := csv.NewReader(&export).ReadAll()
+		require.NoError(t, err)
+		require.Equal(t, "ID", records[0][0])
+
+		found := false
+		for _, record := range records[1:] {
+			if record[0] == run.ID {
+				found = true
+				assert.Equal(t, run.Name, record[1])
+				assert.Equal(t, e.RegularUser.Id, record[3])
+				assert.Equal(t, client.StatusInProgress, client.Status(record[5]))
+			}
+		}
+		assert.True(t, found)
+	})
+
+	t.Run("export the runs of another team", func(t *testing.T) {
+		err := e.PlaybooksClientNotInTeam.PlaybookRuns.ExportCSV(context.Background(), e.BasicTeam.Id, &bytes.Buffer{})
+		requireErrorWithStatusCode(t, err, http.StatusForbidden)
+	})
+
+	t.Run("invalid team", func(t *testing.T) {
+		err := e.PlaybooksClient.PlaybookRuns.ExportCSV(context.Background(), "invalid", &bytes.Buffer{})
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+	})
+}
+
+func TestChecklistItemsFromPosts(t *testing.T) {
+	e := Setup(t)
+	e.CreateBasic()
+
+	run, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+		Name:        "Run name",
+		OwnerUserID: e.RegularUser.Id,
+		TeamID:      e.BasicTeam.Id,
+		PlaybookID:  e.BasicPlaybook.ID,
+	})
+	require.NoError(t, err)
+	err = e.PlaybooksClient.PlaybookRuns.CreateChecklist(context.Background(), run.ID, client.Checklist{Title: "Next steps"})
+	require.NoError(t, err)
+	checklistNum := len(run.Checklists)
+
+	createPost := func(t *testing.T, message string) *model.Post {
+		t.Helper()
+
+		post, _, err := e.ServerClient.CreatePost(&model.Post{ChannelId: run.ChannelID, Message: message})
+		require.NoError(t, err)
+		return post
+	}
+
+	getItems := func(t *testing.T) []client.ChecklistItem {
+		t.Helper()
+
+		run, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+		require.NoError(t, err)
+		return run.Checklists[checklistNum].Items
+	}
+
+	t.Run("add the given posts", func(t *testing.T) {
+		first := createPost(t, "**Restart** the `api` servers")
+		second := createPost(t, "- [ ] Check the [dashboard](https://example.com)")
+		deleted := createPost(t, "Never mind")
+		_, err := e.ServerClient.DeletePost(deleted.Id)
+		require.NoError(t, err)
+
+		result, err := e.PlaybooksClient.PlaybookRuns.AddChecklistItemsFromPosts(context.Background(), run.ID, checklistNum, client.AddChecklistItemsFromPostsOptions{
+			PostIDs: []string{second.Id, deleted.Id, e.BasicPublicChannelPost.Id, first.Id},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.ItemsAdded)
+		assert.Equal(t, 2, result.PostsSkipped)
+
+		items := getItems(t)
+		require.Len(t, items, 2)
+		assert.Equal(t, "Check the dashboard", items[0].Title)
+		assert.Equal(t, "Restart the api servers", items[1].Title)
+		assert.Contains(t, items[1].Description, "@"+e.RegularUser.Username)
+		assert.Empty(t, items[1].State)
+	})
+
+	t.Run("add the recent posts", func(t *testing.T) {
+		createPost(t, "Page the database team")
+		createPost(t, "Update the status page")
+
+		result, err := e.PlaybooksClient.PlaybookRuns.AddChecklistItemsFromPosts(context.Background(), run.ID, checklistNum, client.AddChecklistItemsFromPostsOptions{
+			RecentPosts: 2,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.ItemsAdded)
+
+		items := getItems(t)
+		require.Len(t, items, 4)
+		assert.Equal(t, "Page the database team", items[2].Title)
+		assert.Equal(t, "Update the status page", items[3].Title)
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		for name, options := range map[string]client.AddChecklistItemsFromPostsOptions{
+			"no posts":        {},
+			"both":            {PostIDs: []string{e.BasicPublicChannelPost.Id}, RecentPosts: 1},
+			"too many posts":  {RecentPosts: app.MaxChecklistItemsFromPosts + 1},
+			"invalid post id": {PostIDs: []string{"invalid"}},
+		} {
+			t.Run(name, func(t *testing.T) {
+				_, err := e.PlaybooksClient.PlaybookRuns.AddChecklistItemsFromPosts(context.Background(), run.ID, checklistNum, options)
+				requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+			})
+		}
+	})
+
+	t.Run("without permissions", func(t *testing.T) {
+		_, err := e.PlaybooksClient2.PlaybookRuns.AddChecklistItemsFromPosts(context.Background(), run.ID, checklistNum, client.AddChecklistItemsFromPostsOptions{
+			RecentPosts: 1,
+		})
+		requireErrorWithStatusCode(t, err, http.StatusForbidden)
+	})
+}
+
+func TestGetOwners(t *testing.T) {
+	e := Setup(t)
+	e.CreateBasic()
+
+	ownerFromUser := func(u *model.User) client.OwnerInfo {
+		return client.OwnerInfo{
+			UserID:    u.Id,
+			Username:  u.Username,
+			FirstName: u.FirstName,
+			LastName:  u.LastName,
+			Nickname:  u.Nickname,
+		}
+	}
+
+	_, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+		Name:        "Run name",
+		OwnerUserID: e.RegularUser.Id,
+		TeamID:      e.BasicTeam.Id,
+		PlaybookID:  e.BasicPlaybook.ID,
+	})
+	require.NoError(t, err)
+
+	_, err = e.PlaybooksClient2.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+		Name:        "Run name",
+		OwnerUserID: e.RegularUser2.Id,
+		TeamID:      e.BasicTeam.Id,
+		PlaybookID:  e.BasicPlaybook.ID,
+	})
+	require.NoError(t, err)
+
+	fullOwner1 := ownerFromUser(e.RegularUser)
+	fullOwner2 := ownerFromUser(e.RegularUser2)
+	partialOwner1 := fullOwner1
+	partialOwner1.FirstName = ""
+	partialOwner1.LastName = ""
+	partialOwner2 := fullOwner2
+	partialOwner2.FirstName = ""
+	partialOwner2.LastName = ""
+	for _, tc := range []struct {
+		Name         string
+		ShowFullName bool
+		Client       *client.Client
+		MustContain  []client.OwnerInfo
+	}{
 		{
-			"One checklist - invalid source item: greater than lenght of items",
-			[][]string{{"00"}},
-			0, 1, 0, 0,
-			[][]string{},
-			&ExpectedError{StatusCode: 500},
+			Name:         "showfullname set to true",
+			ShowFullName: true,
+			Client:       e.PlaybooksClient,
+			MustContain:  []client.OwnerInfo{fullOwner1, fullOwner2},
 		},
 		{
-			"One checklist - invalid source item: negative number",
-			[][]string{{"00"}},
-			0, -1, 0, 0,
-			[][]string{},
-			&ExpectedError{StatusCode: 500},
+			Name:         "showfullname set to false",
+			ShowFullName: false,
+			Client:       e.PlaybooksClient,
+			MustContain:  []client.OwnerInfo{partialOwner1, partialOwner2},
 		},
 		{
-			"One checklist - invalid dest item: greater than length of items",
-			[][]string{{"00"}},
-			0, 0, 0, 1,
-			[][]string{},
-			&ExpectedError{StatusCode: 500},
+			Name:         "showfullname set to false and sysadmin",
+			ShowFullName: false,
+			Client:       e.PlaybooksAdminClient,
+			MustContain:  []client.OwnerInfo{fullOwner1, fullOwner2},
 		},
-		{
-			"One checklist - invalid dest item: negative number",
-			[][]string{{"00"}},
-			0, 0, 0, -1,
-			[][]string{},
-			&ExpectedError{StatusCode: 500},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			cfg := e.Srv.Config()
+			cfg.PrivacySettings.ShowFullName = model.NewBool(tc.ShowFullName)
+			_, _, err = e.ServerAdminClient.UpdateConfig(cfg)
+			require.NoError(t, err)
+
+			owners, err := tc.Client.PlaybookRuns.GetOwners(context.Background())
+			require.NoError(t, err)
+			require.Len(t, owners, len(tc.MustContain))
+			for _, mc := range tc.MustContain {
+				require.Contains(t, owners, mc)
+			}
+		})
+	}
+}
+
+func TestRunStatusUpdateTemplates(t *testing.T) {
+	e := Setup(t)
+	e.CreateBasic()
+
+	playbookID, err := e.PlaybooksClient.Playbooks.Create(context.Background(), client.PlaybookCreateOptions{
+		Title:  "PB",
+		TeamID: e.BasicTeam.Id,
+		StatusUpdateTemplates: []client.StatusUpdateTemplate{
+			{Title: "Customer impact", Template: "Customers affected: "},
 		},
+	})
+	require.NoError(t, err)
+
+	playbook, err := e.PlaybooksClient.Playbooks.Get(context.Background(), playbookID)
+	require.NoError(t, err)
+	require.Len(t, playbook.StatusUpdateTemplates, 1)
+	template := playbook.StatusUpdateTemplates[0]
+	require.NotEmpty(t, template.ID)
+
+	run, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+		Name:        "Run name",
+		OwnerUserID: e.RegularUser.Id,
+		TeamID:      e.BasicTeam.Id,
+		PlaybookID:  playbookID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, playbook.StatusUpdateTemplates, run.StatusUpdateTemplates)
+
+	t.Run("duplicate titles are rejected", func(t *testing.T) {
+		updated := *playbook
+		updated.StatusUpdateTemplates = []client.StatusUpdateTemplate{template, {Title: "customer impact", Template: "Other"}}
+		err := e.PlaybooksClient.Playbooks.Update(context.Background(), updated)
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+	})
+
+	t.Run("the run keeps the templates removed from its playbook", func(t *testing.T) {
+		updated := *playbook
+		updated.StatusUpdateTemplates = nil
+		err := e.PlaybooksClient.Playbooks.Update(context.Background(), updated)
+		require.NoError(t, err)
+
+		err = e.PlaybooksClient.PlaybookRuns.UpdateStatusFromTemplate(context.Background(), run.ID, template.ID, "", 600)
+		require.NoError(t, err)
+
+		run, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+		require.NoError(t, err)
+		require.Len(t, run.StatusPosts, 1)
+
+		post, _, err := e.ServerClient.GetPost(run.StatusPosts[0].ID, "")
+		require.NoError(t, err)
+		assert.Equal(t, "Customers affected:", post.Message)
+	})
+
+	t.Run("message overrides the template", func(t *testing.T) {
+		err := e.PlaybooksClient.PlaybookRuns.UpdateStatusFromTemplate(context.Background(), run.ID, template.ID, "Customers affected: none", 600)
+		require.NoError(t, err)
+	})
+
+	t.Run("unknown template", func(t *testing.T) {
+		err := e.PlaybooksClient.PlaybookRuns.UpdateStatusFromTemplate(context.Background(), run.ID, "unknown", "", 600)
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+	})
+}
+
+func TestRunCreateIdempotencyKey(t *testing.T) {
+	e := Setup(t)
+	e.CreateBasic()
+
+	createOptions := func(idempotencyKey string) client.PlaybookRunCreateOptions {
+		return client.PlaybookRunCreateOptions{
+			Name:           "Run name",
+			OwnerUserID:    e.RegularUser.Id,
+			TeamID:         e.BasicTeam.Id,
+			PlaybookID:     e.BasicPlaybook.ID,
+			IdempotencyKey: idempotencyKey,
+		}
 	}
 
-	for _, test := range moveItemTests {
-		t.Run(test.Title, func(t *testing.T) {
-			// Create a new empty run
-			run := createNewRunWithNoChecklists(t)
+	t.Run("a retried request returns the original run", func(t *testing.T) {
+		run, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), createOptions("retry-key"))
+		require.NoError(t, err)
 
-			// Add the specified checklists: note that we need to iterate backwards because CreateChecklist prepends new checklists
-			for i := len(test.Checklists) - 1; i >= 0; i-- {
-				// Generate the items for this checklist
-				checklist := test.Checklists[i]
-				items := make([]client.ChecklistItem, 0, len(checklist))
-				for _, title := range checklist {
-					items = append(items, client.ChecklistItem{Title: title})
-				}
+		retried, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), createOptions("retry-key"))
+		require.NoError(t, err)
+		assert.Equal(t, run.ID, retried.ID)
+		assert.Equal(t, run.ChannelID, retried.ChannelID)
+	})
 
-				// Create the checklist with the defined items
-				err := e.PlaybooksClient.PlaybookRuns.CreateChecklist(context.Background(), run.ID, client.Checklist{
-					Title: "Checklist",
-					Items: items,
-				})
-				require.NoError(t, err)
-			}
+	t.Run("keys are per user", func(t *testing.T) {
+		run, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), createOptions("shared-key"))
+		require.NoError(t, err)
 
-			// Move the item from its source to its destination
-			err := e.PlaybooksClient.PlaybookRuns.MoveChecklistItem(context.Background(), run.ID, test.SourceChecklistIdx, test.SourceItemIdx, test.DestChecklistIdx, test.DestItemIdx)
+		otherRun, err := e.PlaybooksClient2.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+			Name:           "Run name",
+			OwnerUserID:    e.RegularUser2.Id,
+			TeamID:         e.BasicTeam.Id,
+			PlaybookID:     e.BasicPlaybook.ID,
+			IdempotencyKey: "shared-key",
+		})
+		require.NoError(t, err)
+		assert.NotEqual(t, run.ID, otherRun.ID)
+	})
 
-			// If an error is expected, check that it's the one we expect
-			if test.ExpectedError != nil {
-				requireErrorWithStatusCode(t, err, test.ExpectedError.StatusCode)
-				return
-			}
+	t.Run("the key can be sent as a header", func(t *testing.T) {
+		body, err := json.Marshal(createOptions(""))
+		require.NoError(t, err)
 
-			// If no error is expected, retrieve the run again
-			require.NoError(t, err)
-			run, err = e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
-			require.NoError(t, err)
+		url := e.ServerClient.URL + "/plugins/" + manifest.Id + "/api/v0/runs"
+		headers := map[string]string{"Idempotency-Key": "header-key"}
 
-			// And check that the new checklists are ordered as specified by the test data
-			for checklistIdx, actualChecklist := range run.Checklists {
-				expectedItemTitles := test.ExpectedItemTitles[checklistIdx]
-				require.Len(t, actualChecklist.Items, len(expectedItemTitles))
+		resp, err := e.ServerClient.DoAPIRequestWithHeaders(http.MethodPost, url, string(body), headers)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+		var run client.PlaybookRun
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&run))
+		resp.Body.Close()
 
-				for itemIdx, actualItem := range actualChecklist.Items {
-					require.Equal(t, expectedItemTitles[itemIdx], actualItem.Title)
+		resp, err = e.ServerClient.DoAPIRequestWithHeaders(http.MethodPost, url, string(body), headers)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var retried client.PlaybookRun
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&retried))
+		resp.Body.Close()
+
+		assert.Equal(t, run.ID, retried.ID)
+	})
+
+	t.Run("concurrent requests with the same key create a single run", func(t *testing.T) {
+		const numRequests = 5
+
+		var wg sync.WaitGroup
+		runIDs := make(chan string, numRequests)
+		errs := make(chan error, numRequests)
+		for i := 0; i < numRequests; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				run, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), createOptions("concurrent-key"))
+				if err != nil {
+					errs <- err
+					return
 				}
-			}
+				runIDs <- run.ID
+			}()
+		}
+		wg.Wait()
+		close(runIDs)
+		close(errs)
+
+		// The requests that lost the race while the run was being created are told to retry.
+		for err := range errs {
+			requireErrorWithStatusCode(t, err, http.StatusConflict)
+		}
+
+		ids := map[string]bool{}
+		for id := range runIDs {
+			ids[id] = true
+		}
+		require.Len(t, ids, 1)
+	})
+
+	t.Run("a failed creation frees the key", func(t *testing.T) {
+		options := createOptions("failure-key")
+		options.PlaybookID = model.NewId()
+		_, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), options)
+		require.Error(t, err)
+
+		_, err = e.PlaybooksClient.PlaybookRuns.Create(context.Background(), createOptions("failure-key"))
+		require.NoError(t, err)
+	})
+}
+
+func TestStaleRuns(t *testing.T) {
+	e := Setup(t)
+	e.CreateBasic()
+
+	run, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+		Name:        "Quiet run",
+		OwnerUserID: e.RegularUser.Id,
+		TeamID:      e.BasicTeam.Id,
+		PlaybookID:  e.BasicPlaybook.ID,
+	})
+	require.NoError(t, err)
+	time.Sleep(100 * time.Millisecond)
+
+	staleIDs := func(t *testing.T, playbooksClient *client.Client, olderThan string) []string {
+		t.Helper()
+
+		results, err := playbooksClient.PlaybookRuns.GetStale(context.Background(), 0, 100, client.StaleRunsOptions{
+			TeamID:    e.BasicTeam.Id,
+			OlderThan: olderThan,
 		})
+		require.NoError(t, err)
+
+		ids := []string{}
+		for _, staleRun := range results.Items {
+			ids = append(ids, staleRun.ID)
+		}
+		return ids
 	}
 
-	moveChecklistTests := []struct {
-		Title              string
-		Checklists         []string
-		SourceChecklistIdx int
-		DestChecklistIdx   int
-		ExpectedChecklists []string
-		ExpectedError      *ExpectedError
-	}{
-		{
-			"Move checklist to the same position",
-			[]string{"0"},
-			0, 0,
-			[]string{"0"},
-			nil,
-		},
-		{
-			"Swap two checklists, moving the first one",
-			[]string{"1", "0"},
-			0, 1,
-			[]string{"1", "0"},
-			nil,
-		},
-		{
-			"Swap two checklists, moving the second one",
-			[]string{"1", "0"},
-			1, 0,
-			[]string{"1", "0"},
-			nil,
-		},
-		{
-			"Move a checklist in a list of three checklists - first to second ",
-			[]string{"2", "1", "0"},
-			0, 1,
-			[]string{"1", "0", "2"},
-			nil,
-		},
-		{
-			"Move a checklist in a list of three checklists - first to third",
-			[]string{"2", "1", "0"},
-			0, 2,
-			[]string{"1", "2", "0"},
-			nil,
-		},
-		{
-			"Move a checklist in a list of three checklists - second to first",
-			[]string{"2", "1", "0"},
-			1, 0,
-			[]string{"1", "0", "2"},
-			nil,
-		},
-		{
-			"Move a checklist in a list of three checklists - second to third",
-			[]string{"2", "1", "0"},
-			1, 2,
-			[]string{"0", "2", "1"},
-			nil,
-		},
-		{
-			"Move a checklist in a list of three checklists - third to first",
-			[]string{"2", "1", "0"},
-			2, 0,
-			[]string{"2", "0", "1"},
-			nil,
-		},
-		{
-			"Move a checklist in a list of three checklists - third to second",
-			[]string{"2", "1", "0"},
-			2, 1,
-			[]string{"0", "2", "1"},
-			nil,
-		},
-		{
-			"Wrong destination index - greater than length of list",
-			[]string{"2", "1", "0"},
-			0, 5,
-			[]string{"0", "1", "2"},
-			&ExpectedError{500},
-		},
-		{
-			"Wrong destination index - negative",
-			[]string{"2", "1", "0"},
-			0, -5,
-			[]string{"0", "1", "2"},
-			&ExpectedError{500},
-		},
-		{
-			"Wrong source index - greater than length of list",
-			[]string{"2", "1", "0"},
-			5, 0,
-			[]string{"0", "1", "2"},
-			&ExpectedError{500},
-		},
-		{
-			"Wrong source index - negative",
-			[]string{"2", "1", "0"},
-			-5, 0,
-			[]string{"0", "1", "2"},
-			&ExpectedError{500},
+	t.Run("a run without activity is stale", func(t *testing.T) {
+		assert.Contains(t, staleIDs(t, e.PlaybooksClient, "50ms"), run.ID)
+		assert.NotContains(t, staleIDs(t, e.PlaybooksClient, "1h"), run.ID)
+	})
+
+	t.Run("a status update is activity", func(t *testing.T) {
+		err := e.PlaybooksClient.PlaybookRuns.UpdateStatus(context.Background(), run.ID, "Still on it", 600)
+		require.NoError(t, err)
+
+		assert.NotContains(t, staleIDs(t, e.PlaybooksClient, "50ms"), run.ID)
+	})
+
+	t.Run("runs of other teams are not visible", func(t *testing.T) {
+		time.Sleep(100 * time.Millisecond)
+		assert.NotContains(t, staleIDs(t, e.PlaybooksClientNotInTeam, "50ms"), run.ID)
+	})
+
+	t.Run("invalid threshold", func(t *testing.T) {
+		_, err := e.PlaybooksClient.PlaybookRuns.GetStale(context.Background(), 0, 100, client.StaleRunsOptions{OlderThan: "soon"})
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+
+		_, err = e.PlaybooksClient.PlaybookRuns.GetStale(context.Background(), 0, 100, client.StaleRunsOptions{OlderThan: "-1h"})
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+	})
+}
+
+func TestRunItemCommandOutput(t *testing.T) {
+	e := Setup(t)
+	e.CreateBasic()
+
+	run, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+		Name:        "Diagnostics run",
+		OwnerUserID: e.RegularUser.Id,
+		TeamID:      e.BasicTeam.Id,
+		PlaybookID:  e.BasicPlaybook.ID,
+	})
+	require.NoError(t, err)
+
+	err = e.PlaybooksClient.PlaybookRuns.CreateChecklist(context.Background(), run.ID, client.Checklist{
+		Title: "Diagnostics",
+		Items: []client.ChecklistItem{
+			{Title: "Echo", Command: "/echo hi!"},
+			{Title: "Missing", Command: "/nonexistentdiagnostic"},
 		},
+	})
+	require.NoError(t, err)
+	checklistIdx := len(e.BasicPlaybook.Checklists)
+
+	getOutput := func(t *testing.T, itemIdx int) *client.CommandOutput {
+		t.Helper()
+
+		updatedRun, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+		require.NoError(t, err)
+		item := updatedRun.Checklists[checklistIdx].Items[itemIdx]
+		require.NotEmpty(t, item.CommandOutputID)
+
+		output, err := e.PlaybooksClient.PlaybookRuns.GetCommandOutput(context.Background(), run.ID, item.CommandOutputID)
+		require.NoError(t, err)
+		require.Equal(t, item.ID, output.ChecklistItemID)
+		return output
+	}
+
+	t.Run("the output of a command is recorded", func(t *testing.T) {
+		err := e.PlaybooksClient.PlaybookRuns.RunItemCommand(context.Background(), run.ID, checklistIdx, 0)
+		require.NoError(t, err)
+
+		output := getOutput(t, 0)
+		assert.Equal(t, client.CommandOutputSucceeded, output.Status)
+		assert.Equal(t, "/echo hi!", output.Command)
+		assert.Equal(t, e.RegularUser.Id, output.UserID)
+	})
+
+	t.Run("a failed command is recorded as failed", func(t *testing.T) {
+		err := e.PlaybooksClient.PlaybookRuns.RunItemCommand(context.Background(), run.ID, checklistIdx, 1)
+		require.Error(t, err)
+
+		output := getOutput(t, 1)
+		assert.Equal(t, client.CommandOutputFailed, output.Status)
+		assert.NotEmpty(t, output.Output)
+	})
+
+	t.Run("outputs are not visible without access to the run", func(t *testing.T) {
+		updatedRun, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+		require.NoError(t, err)
+
+		_, err = e.PlaybooksClientNotInTeam.PlaybookRuns.GetCommandOutput(context.Background(), run.ID, updatedRun.Checklists[checklistIdx].Items[0].CommandOutputID)
+		requireErrorWithStatusCode(t, err, http.StatusForbidden)
+	})
+
+	t.Run("unknown output", func(t *testing.T) {
+		_, err := e.PlaybooksClient.PlaybookRuns.GetCommandOutput(context.Background(), run.ID, model.NewId())
+		requireErrorWithStatusCode(t, err, http.StatusNotFound)
+	})
+}
+
+func TestRunCreateDryRun(t *testing.T) {
+	e := Setup(t)
+	e.CreateBasic()
+
+	countRuns := func(t *testing.T) int {
+		t.Helper()
+
+		list, err := e.PlaybooksAdminClient.PlaybookRuns.List(context.Background(), 0, 100, client.PlaybookRunListOptions{
+			TeamID: e.BasicTeam.Id,
+		})
+		require.NoError(t, err)
+		return list.TotalCount
 	}
 
-	for _, test := range moveChecklistTests {
-		t.Run(test.Title, func(t *testing.T) {
-			// Create a new empty run
-			run := createNewRunWithNoChecklists(t)
+	createOptions := client.PlaybookRunCreateOptions{
+		Name:        "Dry Run",
+		OwnerUserID: e.RegularUser.Id,
+		TeamID:      e.BasicTeam.Id,
+		PlaybookID:  e.BasicPlaybook.ID,
+	}
 
-			// Add the specified checklists: note that we need to iterate backwards because CreateChecklist prepends new checklists
-			for i := len(test.Checklists) - 1; i >= 0; i-- {
-				err := e.PlaybooksClient.PlaybookRuns.CreateChecklist(context.Background(), run.ID, client.Checklist{
-					Title: test.Checklists[i],
-				})
-				require.NoError(t, err)
-			}
+	t.Run("returns the run that would be created without creating it", func(t *testing.T) {
+		before := countRuns(t)
 
-			// Move the checklist from its source to its destination
-			err := e.PlaybooksClient.PlaybookRuns.MoveChecklist(context.Background(), run.ID, test.SourceChecklistIdx, test.DestChecklistIdx)
+		dryRun, err := e.PlaybooksClient.PlaybookRuns.DryRunCreate(context.Background(), createOptions)
+		require.NoError(t, err)
+		require.True(t, dryRun.Valid)
+		require.Nil(t, dryRun.ValidationError)
+		require.True(t, dryRun.CreateChannel)
+		assert.Equal(t, "dry-run", dryRun.ChannelName)
+		assert.False(t, dryRun.ChannelNameTaken)
+		require.NotNil(t, dryRun.PlaybookRun)
+		assert.Empty(t, dryRun.PlaybookRun.ID)
+		assert.Equal(t, e.RegularUser.Id, dryRun.PlaybookRun.OwnerUserID)
+		assert.Equal(t, e.BasicPlaybook.ID, dryRun.PlaybookRun.PlaybookID)
+
+		assert.Equal(t, before, countRuns(t))
+		_, _, err = e.ServerClient.GetChannelByName("dry-run", e.BasicTeam.Id, "")
+		assert.Error(t, err)
+	})
 
-			// If an error is expected, check that it's the one we expect
-			if test.ExpectedError != nil {
-				requireErrorWithStatusCode(t, err, test.ExpectedError.StatusCode)
-				return
-			}
+	t.Run("reports a channel name collision", func(t *testing.T) {
+		_, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), createOptions)
+		require.NoError(t, err)
 
-			// If no error is expected, retrieve the run again
-			require.NoError(t, err)
-			run, err = e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
-			require.NoError(t, err)
+		dryRun, err := e.PlaybooksClient.PlaybookRuns.DryRunCreate(context.Background(), createOptions)
+		require.NoError(t, err)
+		require.True(t, dryRun.Valid)
+		assert.True(t, dryRun.ChannelNameTaken)
+	})
 
-			// And check that the new checklists are ordered as specified by the test data
-			for checklistIdx, actualChecklist := range run.Checklists {
-				require.Equal(t, test.ExpectedChecklists[checklistIdx], actualChecklist.Title)
-			}
-		})
-	}
+	t.Run("reports validation errors", func(t *testing.T) {
+		missingName := createOptions
+		missingName.Name = ""
+		dryRun, err := e.PlaybooksClient.PlaybookRuns.DryRunCreate(context.Background(), missingName)
+		require.NoError(t, err)
+		require.False(t, dryRun.Valid)
+		require.NotNil(t, dryRun.ValidationError)
+		assert.Equal(t, "malformed_run", dryRun.ValidationError.Code)
+		assert.Equal(t, http.StatusBadRequest, dryRun.ValidationError.StatusCode)
+		assert.Nil(t, dryRun.PlaybookRun)
+
+		dryRun, err = e.PlaybooksClientNotInTeam.PlaybookRuns.DryRunCreate(context.Background(), createOptions)
+		require.NoError(t, err)
+		require.False(t, dryRun.Valid)
+		assert.Equal(t, "no_permissions", dryRun.ValidationError.Code)
+		assert.Equal(t, http.StatusForbidden, dryRun.ValidationError.StatusCode)
+	})
 }
 
-func TestChecklisFailTooLarge(t *testing.T) {
+func TestRunUsersAutocomplete(t *testing.T) {
 	e := Setup(t)
 	e.CreateBasic()
 
-	t.Run("checklist creation - failure: too large checklist", func(t *testing.T) {
-		run, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
-			Name:        "Run name",
-			OwnerUserID: e.RegularUser.Id,
-			TeamID:      e.BasicTeam.Id,
-			PlaybookID:  e.BasicPlaybook.ID,
+	t.Run("channel members come first", func(t *testing.T) {
+		users, err := e.PlaybooksClient.PlaybookRuns.AutocompleteUsers(context.Background(), e.BasicRun.ID, client.RunUserAutocompleteOptions{
+			Prefix: "playbooksuser",
 		})
 		require.NoError(t, err)
-		require.Len(t, run.Checklists, 0)
+		require.Len(t, users, 2)
 
-		err = e.PlaybooksClient.PlaybookRuns.CreateChecklist(context.Background(), run.ID, client.Checklist{
-			Title: "My regular title",
-			Items: []client.ChecklistItem{
-				{Title: "Item title", Description: strings.Repeat("A", (256*1024)+1)},
-			},
+		assert.Equal(t, e.RegularUser.Id, users[0].UserID)
+		assert.True(t, users[0].InChannel)
+		assert.NotEmpty(t, users[0].DisplayName)
+		assert.Equal(t, e.RegularUser2.Id, users[1].UserID)
+		assert.False(t, users[1].InChannel)
+	})
+
+	t.Run("the result count is bounded", func(t *testing.T) {
+		users, err := e.PlaybooksClient.PlaybookRuns.AutocompleteUsers(context.Background(), e.BasicRun.ID, client.RunUserAutocompleteOptions{
+			Prefix: "playbooksuser",
+			Limit:  1,
 		})
-		require.Error(t, err)
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+		assert.Equal(t, e.RegularUser.Id, users[0].UserID)
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		users, err := e.PlaybooksClient.PlaybookRuns.AutocompleteUsers(context.Background(), e.BasicRun.ID, client.RunUserAutocompleteOptions{
+			Prefix: "nobodyhasthisname",
+		})
+		require.NoError(t, err)
+		assert.Empty(t, users)
+	})
+
+	t.Run("users who can't view the run can't search its users", func(t *testing.T) {
+		_, err := e.PlaybooksClientNotInTeam.PlaybookRuns.AutocompleteUsers(context.Background(), e.BasicRun.ID, client.RunUserAutocompleteOptions{
+			Prefix: "playbooksuser",
+		})
+		requireErrorWithStatusCode(t, err, http.StatusForbidden)
 	})
 }
 
-func TestRunGetStatusUpdates(t *testing.T) {
+func TestRunSkipChecklistItem(t *testing.T) {
 	e := Setup(t)
 	e.CreateBasic()
 
-	t.Run("public - get no updates", func(t *testing.T) {
-		statusUpdates, err := e.PlaybooksClient.PlaybookRuns.GetStatusUpdates(context.Background(), e.BasicRun.ID)
-		assert.NoError(t, err)
-		assert.Len(t, statusUpdates, 0)
+	playbookID, err := e.PlaybooksAdminClient.Playbooks.Create(context.Background(), client.PlaybookCreateOptions{
+		Title:  "PB",
+		TeamID: e.BasicTeam.Id,
+		Public: true,
+		Members: []client.PlaybookMember{
+			{UserID: e.RegularUser.Id, Roles: []string{app.PlaybookRoleMember}},
+		},
+		Checklists: []client.Checklist{
+			{
+				Title: "A",
+				Items: []client.ChecklistItem{
+					{Title: "Does not apply"},
+					{Title: "Do this"},
+				},
+			},
+		},
 	})
+	require.NoError(t, err)
 
-	t.Run("public - get 2 updates as participant", func(t *testing.T) {
-		err := e.PlaybooksClient.PlaybookRuns.UpdateStatus(context.Background(), e.BasicRun.ID, "update 1", 5000)
-		require.NoError(t, err)
-		err = e.PlaybooksClient.PlaybookRuns.UpdateStatus(context.Background(), e.BasicRun.ID, "update 2", 10000)
+	run, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+		Name:        "Run with skipped items",
+		OwnerUserID: e.RegularUser.Id,
+		TeamID:      e.BasicTeam.Id,
+		PlaybookID:  playbookID,
+	})
+	require.NoError(t, err)
+
+	t.Run("skip with a reason", func(t *testing.T) {
+		err := e.PlaybooksClient.PlaybookRuns.SetItemState(context.Background(), run.ID, 0, 0, app.ChecklistItemStateSkipped, "Not a production incident")
 		require.NoError(t, err)
 
-		statusUpdates, err := e.PlaybooksClient.PlaybookRuns.GetStatusUpdates(context.Background(), e.BasicRun.ID)
+		run, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
 		require.NoError(t, err)
-		assert.Len(t, statusUpdates, 2)
-		assert.Equal(t, "update 2", statusUpdates[0].Message)
-		assert.Equal(t, "update 1", statusUpdates[1].Message)
-		assert.Equal(t, e.RegularUser.Username, statusUpdates[0].AuthorUserName)
+		item := run.Checklists[0].Items[0]
+		assert.Equal(t, app.ChecklistItemStateSkipped, item.State)
+		assert.Equal(t, "Not a production incident", item.SkipReason)
+
+		var skippedEvent *client.TimelineEvent
+		for i, event := range run.TimelineEvents {
+			if event.EventType == client.TaskStateModified {
+				skippedEvent = &run.TimelineEvents[i]
+			}
+		}
+		require.NotNil(t, skippedEvent)
+		assert.Contains(t, skippedEvent.Summary, "skipped checklist item")
+		assert.Equal(t, "Not a production incident", skippedEvent.Details)
 	})
 
-	t.Run("public - get 2 updates as viewer", func(t *testing.T) {
-		statusUpdates, err := e.PlaybooksClient2.PlaybookRuns.GetStatusUpdates(context.Background(), e.BasicRun.ID)
-		require.NoError(t, err)
-		assert.Len(t, statusUpdates, 2)
-		assert.Equal(t, "update 2", statusUpdates[0].Message)
-		assert.Equal(t, "update 1", statusUpdates[1].Message)
-		assert.Equal(t, e.RegularUser.Username, statusUpdates[0].AuthorUserName)
-		assert.Equal(t, e.RegularUser.Username, statusUpdates[1].AuthorUserName)
+	t.Run("too long reasons are rejected", func(t *testing.T) {
+		err := e.PlaybooksClient.PlaybookRuns.SetItemState(context.Background(), run.ID, 0, 1, app.ChecklistItemStateSkipped, strings.Repeat("a", app.MaxChecklistItemSkipReasonLength+1))
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
 	})
 
-	t.Run("public - fails because not in team", func(t *testing.T) {
-		statusUpdates, err := e.PlaybooksClientNotInTeam.PlaybookRuns.GetStatusUpdates(context.Background(), e.BasicRun.ID)
-		require.Error(t, err)
-		assert.Len(t, statusUpdates, 0)
+	t.Run("reopening clears the reason", func(t *testing.T) {
+		err := e.PlaybooksClient.PlaybookRuns.SetItemState(context.Background(), run.ID, 0, 0, app.ChecklistItemStateOpen, "ignored")
+		require.NoError(t, err)
+
+		run, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+		require.NoError(t, err)
+		item := run.Checklists[0].Items[0]
+		assert.Equal(t, app.ChecklistItemStateOpen, item.State)
+		assert.Empty(t, item.SkipReason)
 	})
+}
 
-	t.Run("private - get no updates", func(t *testing.T) {
-		privateRun, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
-			Name:        "Basic create",
-			OwnerUserID: e.RegularUser.Id,
-			TeamID:      e.BasicTeam.Id,
-			PlaybookID:  e.BasicPrivatePlaybook.ID,
+func TestRunFinishRetrospectiveRequired(t *testing.T) {
+	e := Setup(t)
+	e.CreateBasic()
+
+	t.Run("a retrospective can't be required if retrospectives are disabled", func(t *testing.T) {
+		_, err := e.PlaybooksAdminClient.Playbooks.Create(context.Background(), client.PlaybookCreateOptions{
+			Title:                 "PB",
+			TeamID:                e.BasicTeam.Id,
+			RetrospectiveEnabled:  false,
+			RetrospectiveRequired: true,
 		})
-		assert.NoError(t, err)
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+	})
 
-		statusUpdates, err := e.PlaybooksClient.PlaybookRuns.GetStatusUpdates(context.Background(), privateRun.ID)
-		assert.NoError(t, err)
-		assert.Len(t, statusUpdates, 0)
+	playbookID, err := e.PlaybooksAdminClient.Playbooks.Create(context.Background(), client.PlaybookCreateOptions{
+		Title:                 "PB",
+		TeamID:                e.BasicTeam.Id,
+		Public:                true,
+		RetrospectiveEnabled:  true,
+		RetrospectiveRequired: true,
+		Members: []client.PlaybookMember{
+			{UserID: e.RegularUser.Id, Roles: []string{app.PlaybookRoleMember}},
+		},
 	})
+	require.NoError(t, err)
 
-	t.Run("private - get 2 updates as participant", func(t *testing.T) {
-		privateRun, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
-			Name:        "Basic create",
-			OwnerUserID: e.RegularUser.Id,
-			TeamID:      e.BasicTeam.Id,
-			PlaybookID:  e.BasicPrivatePlaybook.ID,
-		})
-		assert.NoError(t, err)
+	run, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+		Name:        "Run requiring a retrospective",
+		OwnerUserID: e.RegularUser.Id,
+		TeamID:      e.BasicTeam.Id,
+		PlaybookID:  playbookID,
+	})
+	require.NoError(t, err)
+	assert.True(t, run.RetrospectiveRequired)
 
-		err = e.PlaybooksClient.PlaybookRuns.UpdateStatus(context.Background(), privateRun.ID, "update 1", 5000)
-		require.NoError(t, err)
-		err = e.PlaybooksClient.PlaybookRuns.UpdateStatus(context.Background(), privateRun.ID, "update 2", 10000)
+	t.Run("finishing without a retrospective is rejected", func(t *testing.T) {
+		err := e.PlaybooksClient.PlaybookRuns.Finish(context.Background(), run.ID)
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+
+		err = e.PlaybooksClient.PlaybookRuns.UpdateRetrospective(context.Background(), run.ID, e.RegularUser.Id, client.RetrospectiveUpdate{Text: "Draft"})
 		require.NoError(t, err)
+		err = e.PlaybooksClient.PlaybookRuns.Finish(context.Background(), run.ID)
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
 
-		statusUpdates, err := e.PlaybooksClient.PlaybookRuns.GetStatusUpdates(context.Background(), privateRun.ID)
+		run, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
 		require.NoError(t, err)
-		assert.Len(t, statusUpdates, 2)
-		assert.Equal(t, "update 2", statusUpdates[0].Message)
-		assert.Equal(t, "update 1", statusUpdates[1].Message)
-		assert.Equal(t, e.RegularUser.Username, statusUpdates[0].AuthorUserName)
-		assert.Equal(t, e.RegularUser.Username, statusUpdates[1].AuthorUserName)
+		assert.Equal(t, app.StatusInProgress, run.CurrentStatus)
 	})
 
-	t.Run("private - get 2 updates as viewer", func(t *testing.T) {
-		privatePlaybookID, err := e.PlaybooksAdminClient.Playbooks.Create(context.Background(), client.PlaybookCreateOptions{
-			Title:  "TestPrivatePlaybook custom",
-			TeamID: e.BasicTeam.Id,
-			Public: false,
-			Members: []client.PlaybookMember{
-				{UserID: e.RegularUser2.Id, Roles: []string{app.PlaybookRoleMember}},
-				{UserID: e.RegularUser.Id, Roles: []string{app.PlaybookRoleMember}},
-				{UserID: e.AdminUser.Id, Roles: []string{app.PlaybookRoleAdmin, app.PlaybookRoleMember}},
-			},
-		})
+	t.Run("finishing with an empty published retrospective is rejected", func(t *testing.T) {
+		err := e.PlaybooksClient.PlaybookRuns.PublishRetrospective(context.Background(), run.ID, e.RegularUser.Id, client.RetrospectiveUpdate{Text: "  "})
 		require.NoError(t, err)
 
-		privatePlaybook, err := e.PlaybooksClient.Playbooks.Get(context.Background(), privatePlaybookID)
-		require.NoError(e.T, err)
-
-		privateRun, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
-			Name:        "Basic create",
-			OwnerUserID: e.RegularUser.Id,
-			TeamID:      e.BasicTeam.Id,
-			PlaybookID:  privatePlaybook.ID,
-		})
-		require.NoError(t, err)
+		err = e.PlaybooksClient.PlaybookRuns.Finish(context.Background(), run.ID)
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+	})
 
-		err = e.PlaybooksClient.PlaybookRuns.UpdateStatus(context.Background(), privateRun.ID, "update 1", 5000)
-		require.NoError(t, err)
-		err = e.PlaybooksClient.PlaybookRuns.UpdateStatus(context.Background(), privateRun.ID, "update 2", 10000)
+	t.Run("finishing with a published retrospective is allowed", func(t *testing.T) {
+		err := e.PlaybooksClient.PlaybookRuns.PublishRetrospective(context.Background(), run.ID, e.RegularUser.Id, client.RetrospectiveUpdate{Text: "What went well"})
 		require.NoError(t, err)
 
-		statusUpdates, err := e.PlaybooksClient2.PlaybookRuns.GetStatusUpdates(context.Background(), privateRun.ID)
+		err = e.PlaybooksClient.PlaybookRuns.Finish(context.Background(), run.ID)
 		require.NoError(t, err)
-		assert.Len(t, statusUpdates, 2)
-		assert.Equal(t, "update 2", statusUpdates[0].Message)
-		assert.Equal(t, "update 1", statusUpdates[1].Message)
-		assert.Equal(t, e.RegularUser.Username, statusUpdates[0].AuthorUserName)
-	})
 
-	t.Run("private - fails because not in playbook members", func(t *testing.T) {
-		privateRun, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
-			Name:        "Basic create",
-			OwnerUserID: e.RegularUser.Id,
-			TeamID:      e.BasicTeam.Id,
-			PlaybookID:  e.BasicPrivatePlaybook.ID,
-		})
+		run, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
 		require.NoError(t, err)
-
-		statusUpdates, err := e.PlaybooksClient2.PlaybookRuns.GetStatusUpdates(context.Background(), privateRun.ID)
-		require.Error(t, err)
-		assert.Len(t, statusUpdates, 0)
+		assert.Equal(t, app.StatusFinished, run.CurrentStatus)
 	})
 }
 
-func TestRequestUpdate(t *testing.T) {
+func TestRunStatusUpdateBroadcastResults(t *testing.T) {
 	e := Setup(t)
 	e.CreateBasic()
 
-	t.Run("private - no viewer access ", func(t *testing.T) {
-		privateRun, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
-			Name:        "Basic create",
-			OwnerUserID: e.RegularUser.Id,
-			TeamID:      e.BasicTeam.Id,
-			PlaybookID:  e.BasicPrivatePlaybook.ID,
-		})
-		assert.NoError(t, err)
-
-		err = e.PlaybooksClient2.PlaybookRuns.RequestUpdate(context.Background(), privateRun.ID, e.RegularUser2.Id)
-		assert.Error(t, err)
-
-		err = e.PlaybooksClientNotInTeam.PlaybookRuns.RequestUpdate(context.Background(), privateRun.ID, e.RegularUserNotInTeam.Id)
-		assert.Error(t, err)
+	archivedChannel, _, err := e.ServerAdminClient.CreateChannel(&model.Channel{
+		DisplayName: "To be archived",
+		Name:        "to-be-archived",
+		Type:        model.ChannelTypeOpen,
+		TeamId:      e.BasicTeam.Id,
 	})
+	require.NoError(t, err)
 
-	t.Run("private - viewer access ", func(t *testing.T) {
-		privatePlaybookID, err := e.PlaybooksClient.Playbooks.Create(context.Background(), client.PlaybookCreateOptions{
-			Title:  "TestPrivatePlaybook custom",
-			TeamID: e.BasicTeam.Id,
-			Public: false,
-			Members: []client.PlaybookMember{
-				{UserID: e.RegularUser.Id, Roles: []string{app.PlaybookRoleMember}},
-			},
-		})
-		require.NoError(t, err)
+	playbookID, err := e.PlaybooksAdminClient.Playbooks.Create(context.Background(), client.PlaybookCreateOptions{
+		Title:               "PB",
+		TeamID:              e.BasicTeam.Id,
+		Public:              true,
+		BroadcastEnabled:    true,
+		BroadcastChannelIDs: []string{e.BasicPublicChannel.Id, archivedChannel.Id},
+		Members: []client.PlaybookMember{
+			{UserID: e.RegularUser.Id, Roles: []string{app.PlaybookRoleMember}},
+		},
+	})
+	require.NoError(t, err)
 
-		privatePlaybook, err := e.PlaybooksClient.Playbooks.Get(context.Background(), privatePlaybookID)
-		require.NoError(e.T, err)
+	run, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+		Name:        "Run with broadcasts",
+		OwnerUserID: e.RegularUser.Id,
+		TeamID:      e.BasicTeam.Id,
+		PlaybookID:  playbookID,
+	})
+	require.NoError(t, err)
 
-		privateRun, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
-			Name:        "Basic create",
-			OwnerUserID: e.RegularUser.Id,
-			TeamID:      e.BasicTeam.Id,
-			PlaybookID:  privatePlaybookID,
-		})
-		assert.NoError(t, err)
+	_, err = e.ServerAdminClient.DeleteChannel(archivedChannel.Id)
+	require.NoError(t, err)
 
-		// No access, RegularUser2 is not a Viewer
-		err = e.PlaybooksClient2.PlaybookRuns.RequestUpdate(context.Background(), privateRun.ID, e.RegularUser2.Id)
-		assert.Error(t, err)
+	t.Run("each channel has its result", func(t *testing.T) {
+		body, err := json.Marshal(client.StatusUpdateOptions{Message: "Mitigated", Reminder: 600})
+		require.NoError(t, err)
+		resp, err := e.ServerClient.DoAPIRequestBytes("POST", e.ServerClient.URL+"/plugins/"+manifest.Id+"/api/v0/runs/"+run.ID+"/status", body, "")
+		require.NoError(t, err)
+		defer resp.Body.Close()
 
-		// Add RegularUser2 as a Viewer
-		privatePlaybook.Members = append(privatePlaybook.Members, client.PlaybookMember{UserID: e.RegularUser2.Id, Roles: []string{app.PlaybookRoleMember}})
-		err = e.PlaybooksClient.Playbooks.Update(context.Background(), *privatePlaybook)
-		assert.NoError(t, err)
+		var response struct {
+			BroadcastResults []client.BroadcastResult `json:"broadcast_results"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+		require.Len(t, response.BroadcastResults, 2)
 
-		// Gained Viewer access
-		err = e.PlaybooksClient2.PlaybookRuns.RequestUpdate(context.Background(), privateRun.ID, e.RegularUser2.Id)
-		assert.NoError(t, err)
+		assert.Equal(t, e.BasicPublicChannel.Id, response.BroadcastResults[0].ChannelID)
+		assert.NotEmpty(t, response.BroadcastResults[0].PostID)
+		assert.Empty(t, response.BroadcastResults[0].Error)
 
-		// Assert that timeline event is created
-		privateRun, err = e.PlaybooksClient.PlaybookRuns.Get(context.Background(), privateRun.ID)
-		assert.NoError(t, err)
-		assert.NotEmpty(t, privateRun.TimelineEvents)
-		lastEvent := privateRun.TimelineEvents[len(privateRun.TimelineEvents)-1]
-		assert.Equal(t, client.StatusUpdateRequested, lastEvent.EventType)
-		assert.Equal(t, e.RegularUser2.Id, lastEvent.SubjectUserID)
-		assert.Equal(t, e.RegularUser2.Id, lastEvent.CreatorUserID)
-		assert.NotZero(t, lastEvent.PostID)
-		assert.Equal(t, "@playbooksuser2 requested a status update", lastEvent.Summary)
+		assert.Equal(t, archivedChannel.Id, response.BroadcastResults[1].ChannelID)
+		assert.Empty(t, response.BroadcastResults[1].PostID)
+		assert.NotEmpty(t, response.BroadcastResults[1].Error)
+
+		bot, _, err := e.ServerAdminClient.GetUserByUsername("playbooks", "")
+		require.NoError(t, err)
+		_, _, err = e.ServerAdminClient.GetChannelMember(e.BasicPublicChannel.Id, bot.Id, "")
+		require.NoError(t, err)
 	})
 
-	t.Run("public - viewer access ", func(t *testing.T) {
-		publicRun, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
-			Name:        "Basic create",
-			OwnerUserID: e.RegularUser.Id,
-			TeamID:      e.BasicTeam.Id,
-			PlaybookID:  e.BasicPlaybook.ID,
-		})
-		assert.NoError(t, err)
+	t.Run("retry the broadcast to a channel", func(t *testing.T) {
+		results, err := e.PlaybooksClient.PlaybookRuns.RetryStatusUpdateBroadcast(context.Background(), run.ID, []string{e.BasicPublicChannel.Id})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.NotEmpty(t, results[0].PostID)
 
-		err = e.PlaybooksClient2.PlaybookRuns.RequestUpdate(context.Background(), publicRun.ID, e.RegularUser2.Id)
-		assert.NoError(t, err)
+		post, _, err := e.ServerAdminClient.GetPost(results[0].PostID, "")
+		require.NoError(t, err)
+		assert.Equal(t, "Mitigated", post.Message)
+		assert.NotEmpty(t, post.RootId)
+	})
 
-		// Assert that timeline event is created
-		publicRun, err = e.PlaybooksClient.PlaybookRuns.Get(context.Background(), publicRun.ID)
-		assert.NoError(t, err)
-		assert.NotEmpty(t, publicRun.TimelineEvents)
-		lastEvent := publicRun.TimelineEvents[len(publicRun.TimelineEvents)-1]
-		assert.Equal(t, client.StatusUpdateRequested, lastEvent.EventType)
-		assert.Equal(t, e.RegularUser2.Id, lastEvent.SubjectUserID)
-		assert.Equal(t, "@playbooksuser2 requested a status update", lastEvent.Summary)
+	t.Run("only broadcast channels can be retried", func(t *testing.T) {
+		_, err := e.PlaybooksClient.PlaybookRuns.RetryStatusUpdateBroadcast(context.Background(), run.ID, []string{e.BasicPrivateChannel.Id})
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+	})
 
-		err = e.PlaybooksClientNotInTeam.PlaybookRuns.RequestUpdate(context.Background(), publicRun.ID, e.RegularUserNotInTeam.Id)
-		assert.Error(t, err)
+	t.Run("users who can't update the status can't retry", func(t *testing.T) {
+		_, err := e.PlaybooksClientNotInTeam.PlaybookRuns.RetryStatusUpdateBroadcast(context.Background(), run.ID, []string{e.BasicPublicChannel.Id})
+		requireErrorWithStatusCode(t, err, http.StatusForbidden)
 	})
 }
 
-func TestReminderReset(t *testing.T) {
+func TestRunMerge(t *testing.T) {
 	e := Setup(t)
 	e.CreateBasic()
 
-	t.Run("reminder reset - event created", func(t *testing.T) {
-		payload := client.ReminderResetPayload{
-			NewReminderSeconds: 100,
-		}
-		err := e.PlaybooksClient.Reminders.Reset(context.Background(), e.BasicRun.ID, payload)
-		assert.NoError(t, err)
+	playbookID, err := e.PlaybooksAdminClient.Playbooks.Create(context.Background(), client.PlaybookCreateOptions{
+		Title:  "PB",
+		TeamID: e.BasicTeam.Id,
+		Public: true,
+		Members: []client.PlaybookMember{
+			{UserID: e.RegularUser.Id, Roles: []string{app.PlaybookRoleMember}},
+		},
+		Checklists: []client.Checklist{
+			{
+				Title: "A",
+				Items: []client.ChecklistItem{
+					{Title: "Do this"},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
 
-		pb, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), e.BasicRun.ID)
-		assert.NoError(t, err)
+	target, err := e.PlaybooksAdminClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+		Name:        "Target run",
+		OwnerUserID: e.AdminUser.Id,
+		TeamID:      e.BasicTeam.Id,
+		PlaybookID:  playbookID,
+	})
+	require.NoError(t, err)
 
-		statusSnoozed := make([]client.TimelineEvent, 0)
-		for _, te := range pb.TimelineEvents {
-			if te.EventType == "status_update_snoozed" {
-				statusSnoozed = append(statusSnoozed, te)
+	source, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+		Name:        "Duplicate run",
+		OwnerUserID: e.RegularUser.Id,
+		TeamID:      e.BasicTeam.Id,
+		PlaybookID:  playbookID,
+	})
+	require.NoError(t, err)
+
+	err = e.PlaybooksClient.PlaybookRuns.AddTags(context.Background(), source.ID, []string{"database"})
+	require.NoError(t, err)
+	err = e.PlaybooksClient.PlaybookRuns.UpdateStatus(context.Background(), source.ID, "Found the culprit", 600)
+	require.NoError(t, err)
+
+	t.Run("merge without permissions on the source", func(t *testing.T) {
+		_, err := e.PlaybooksClient2.PlaybookRuns.Merge(context.Background(), target.ID, source.ID)
+		requireErrorWithStatusCode(t, err, http.StatusForbidden)
+	})
+
+	t.Run("merge a run into itself", func(t *testing.T) {
+		_, err := e.PlaybooksAdminClient.PlaybookRuns.Merge(context.Background(), target.ID, target.ID)
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+	})
+
+	t.Run("merge an unknown run", func(t *testing.T) {
+		_, err := e.PlaybooksAdminClient.PlaybookRuns.Merge(context.Background(), target.ID, model.NewId())
+		requireErrorWithStatusCode(t, err, http.StatusNotFound)
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		mergedRun, err := e.PlaybooksAdminClient.PlaybookRuns.Merge(context.Background(), target.ID, source.ID)
+		require.NoError(t, err)
+
+		require.Len(t, mergedRun.Checklists, 2)
+		assert.Equal(t, "A", mergedRun.Checklists[1].Title)
+		assert.Contains(t, mergedRun.ParticipantIDs, e.RegularUser.Id)
+		assert.Contains(t, mergedRun.Tags, "database")
+		require.Len(t, mergedRun.StatusPosts, 1)
+
+		var mergedEvent *client.TimelineEvent
+		for i, event := range mergedRun.TimelineEvents {
+			if event.EventType == client.RunMerged {
+				mergedEvent = &mergedRun.TimelineEvents[i]
 			}
 		}
+		require.NotNil(t, mergedEvent)
+		assert.Equal(t, source.ID, mergedEvent.Details)
 
-		require.Len(t, statusSnoozed, 1)
+		mergedSource, err := e.PlaybooksAdminClient.PlaybookRuns.Get(context.Background(), source.ID)
+		require.NoError(t, err)
+		assert.Equal(t, app.StatusMerged, mergedSource.CurrentStatus)
+		assert.Equal(t, target.ID, mergedSource.MergedIntoRunID)
+		assert.NotZero(t, mergedSource.EndAt)
+
+		sourceChannel, _, err := e.ServerAdminClient.GetChannel(source.ChannelID, "")
+		require.NoError(t, err)
+		assert.NotZero(t, sourceChannel.DeleteAt)
+	})
+
+	t.Run("merged runs can't be merged again", func(t *testing.T) {
+		_, err := e.PlaybooksAdminClient.PlaybookRuns.Merge(context.Background(), target.ID, source.ID)
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+
+		err = e.PlaybooksAdminClient.PlaybookRuns.Finish(context.Background(), source.ID)
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+
+		err = e.PlaybooksAdminClient.PlaybookRuns.Restart(context.Background(), source.ID)
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
 	})
 }
 
-func TestChecklisItem_SetAssignee(t *testing.T) {
+func TestRunSavedFilters(t *testing.T) {
 	e := Setup(t)
 	e.CreateBasic()
 
-	addSimpleChecklistToTun := func(t *testing.T, runID string) *client.PlaybookRun {
-		checklist := client.Checklist{
-			Title: "Test Checklist",
-			Items: []client.ChecklistItem{
-				{
-					Title: "Test Item",
-				},
-			},
-		}
-
-		err := e.PlaybooksClient.PlaybookRuns.CreateChecklist(context.Background(), runID, checklist)
-		require.NoError(t, err)
+	otherRun, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+		Name:        "Run owned by another user",
+		OwnerUserID: e.RegularUser2.Id,
+		TeamID:      e.BasicTeam.Id,
+		PlaybookID:  e.BasicPlaybook.ID,
+	})
+	require.NoError(t, err)
 
-		run, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), runID)
-		require.NoError(t, err)
-		require.Len(t, run.Checklists, 1)
-		require.Len(t, run.Checklists[0].Items, 1)
-		return run
-	}
+	filter, err := e.PlaybooksClient.PlaybookRuns.CreateSavedFilter(context.Background(), client.SavedFilter{
+		Name:   "  My runs  ",
+		TeamID: e.BasicTeam.Id,
+		Options: client.SavedFilterOptions{
+			OwnerID:    e.RegularUser.Id,
+			Statuses:   []client.Status{client.StatusInProgress},
+			PlaybookID: e.BasicPlaybook.ID,
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "My runs", filter.Name)
+	assert.Equal(t, e.RegularUser.Id, filter.UserID)
+	assert.False(t, filter.Shared)
 
-	t.Run("set assignee and participant", func(t *testing.T) {
-		run, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
-			Name:        "Run name",
-			OwnerUserID: e.RegularUser.Id,
-			TeamID:      e.BasicTeam.Id,
-			PlaybookID:  e.BasicPlaybook.ID,
+	t.Run("create an invalid filter", func(t *testing.T) {
+		_, err := e.PlaybooksClient.PlaybookRuns.CreateSavedFilter(context.Background(), client.SavedFilter{
+			Name:   " ",
+			TeamID: e.BasicTeam.Id,
 		})
-		require.NoError(t, err)
-		require.Len(t, run.Checklists, 0)
-
-		run = addSimpleChecklistToTun(t, run.ID)
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
 
-		// assignee is not set and user is not participant (before)
-		require.Empty(t, run.Checklists[0].Items[0].AssigneeID)
-		require.Len(t, run.ParticipantIDs, 1)
-		require.NotContains(t, run.ParticipantIDs, e.RegularUser2.Id)
+		_, err = e.PlaybooksClient.PlaybookRuns.CreateSavedFilter(context.Background(), client.SavedFilter{
+			Name:    "Bad status",
+			TeamID:  e.BasicTeam.Id,
+			Options: client.SavedFilterOptions{Statuses: []client.Status{"Unknown"}},
+		})
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+	})
 
-		// set assignee
-		err = e.PlaybooksClient.PlaybookRuns.SetItemAssignee(context.Background(), run.ID, 0, 0, e.RegularUser2.Id)
-		require.NoError(t, err)
+	t.Run("create a filter in a team the user is not in", func(t *testing.T) {
+		_, err := e.PlaybooksClientNotInTeam.PlaybookRuns.CreateSavedFilter(context.Background(), client.SavedFilter{
+			Name:   "Runs",
+			TeamID: e.BasicTeam.Id,
+		})
+		requireErrorWithStatusCode(t, err, http.StatusForbidden)
+	})
 
-		// assignee is not set and user is not participant (after)
-		run, err = e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+	t.Run("apply a filter", func(t *testing.T) {
+		results, err := e.PlaybooksClient.PlaybookRuns.ListWithSavedFilter(context.Background(), filter.ID, 0, 100)
 		require.NoError(t, err)
-		require.Equal(t, e.RegularUser2.Id, run.Checklists[0].Items[0].AssigneeID)
-		require.Contains(t, run.ParticipantIDs, e.RegularUser2.Id)
+		require.Len(t, results.Items, 1)
+		assert.Equal(t, e.BasicRun.ID, results.Items[0].ID)
 	})
 
-	t.Run("set and unset", func(t *testing.T) {
-		run, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
-			Name:        "Run name",
-			OwnerUserID: e.RegularUser.Id,
-			TeamID:      e.BasicTeam.Id,
-			PlaybookID:  e.BasicPlaybook.ID,
-		})
+	t.Run("private filters are only visible to their creator", func(t *testing.T) {
+		filters, err := e.PlaybooksClient2.PlaybookRuns.ListSavedFilters(context.Background(), e.BasicTeam.Id)
 		require.NoError(t, err)
-		require.Len(t, run.Checklists, 0)
+		assert.Empty(t, filters)
 
-		run = addSimpleChecklistToTun(t, run.ID)
+		_, err = e.PlaybooksClient2.PlaybookRuns.ListWithSavedFilter(context.Background(), filter.ID, 0, 100)
+		requireErrorWithStatusCode(t, err, http.StatusForbidden)
+	})
 
-		// set assignee
-		err = e.PlaybooksClient.PlaybookRuns.SetItemAssignee(context.Background(), run.ID, 0, 0, e.RegularUser.Id)
+	t.Run("share a filter", func(t *testing.T) {
+		filter.Shared = true
+		updated, err := e.PlaybooksClient.PlaybookRuns.UpdateSavedFilter(context.Background(), *filter)
 		require.NoError(t, err)
+		assert.True(t, updated.Shared)
 
-		run, err = e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+		filters, err := e.PlaybooksClient2.PlaybookRuns.ListSavedFilters(context.Background(), e.BasicTeam.Id)
 		require.NoError(t, err)
-		require.Equal(t, e.RegularUser.Id, run.Checklists[0].Items[0].AssigneeID)
+		require.Len(t, filters, 1)
+		assert.Equal(t, filter.ID, filters[0].ID)
 
-		// unset assignee
-		err = e.PlaybooksClient.PlaybookRuns.SetItemAssignee(context.Background(), run.ID, 0, 0, "")
+		_, err = e.PlaybooksClient2.PlaybookRuns.ListWithSavedFilter(context.Background(), filter.ID, 0, 100)
 		require.NoError(t, err)
 
-		run, err = e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
-		require.NoError(t, err)
-		require.Equal(t, "", run.Checklists[0].Items[0].AssigneeID)
+		filters, err = e.PlaybooksClientNotInTeam.PlaybookRuns.ListSavedFilters(context.Background(), e.BasicTeam.Id)
+		requireErrorWithStatusCode(t, err, http.StatusForbidden)
+		assert.Nil(t, filters)
 	})
 
-	t.Run("idempotent action", func(t *testing.T) {
-		run, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
-			Name:        "Run name",
-			OwnerUserID: e.RegularUser.Id,
-			TeamID:      e.BasicTeam.Id,
-			PlaybookID:  e.BasicPlaybook.ID,
-		})
-		require.NoError(t, err)
-		require.Len(t, run.Checklists, 0)
+	t.Run("only the creator can modify a shared filter", func(t *testing.T) {
+		_, err := e.PlaybooksClient2.PlaybookRuns.UpdateSavedFilter(context.Background(), *filter)
+		requireErrorWithStatusCode(t, err, http.StatusForbidden)
 
-		run = addSimpleChecklistToTun(t, run.ID)
+		err = e.PlaybooksClient2.PlaybookRuns.DeleteSavedFilter(context.Background(), filter.ID)
+		requireErrorWithStatusCode(t, err, http.StatusForbidden)
+	})
 
-		// set assignee
-		err = e.PlaybooksClient.PlaybookRuns.SetItemAssignee(context.Background(), run.ID, 0, 0, e.RegularUser.Id)
+	t.Run("stale references are ignored", func(t *testing.T) {
+		stale, err := e.PlaybooksClient.PlaybookRuns.CreateSavedFilter(context.Background(), client.SavedFilter{
+			Name:   "Deleted playbook and owner",
+			TeamID: e.BasicTeam.Id,
+			Options: client.SavedFilterOptions{
+				OwnerID:    model.NewId(),
+				PlaybookID: model.NewId(),
+				Sort:       client.SortByCreateAt,
+				Direction:  client.SortAsc,
+			},
+		})
 		require.NoError(t, err)
 
-		run, err = e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
+		results, err := e.PlaybooksClient.PlaybookRuns.ListWithSavedFilter(context.Background(), stale.ID, 0, 100)
 		require.NoError(t, err)
-		require.Equal(t, e.RegularUser.Id, run.Checklists[0].Items[0].AssigneeID)
+		require.Len(t, results.Items, 2)
+		assert.Equal(t, e.BasicRun.ID, results.Items[0].ID)
+		assert.Equal(t, otherRun.ID, results.Items[1].ID)
+	})
 
-		// unset assignee
-		err = e.PlaybooksClient.PlaybookRuns.SetItemAssignee(context.Background(), run.ID, 0, 0, e.RegularUser.Id)
+	t.Run("delete a filter", func(t *testing.T) {
+		err := e.PlaybooksClient.PlaybookRuns.DeleteSavedFilter(context.Background(), filter.ID)
 		require.NoError(t, err)
 
-		run, err = e.PlaybooksClient.PlaybookRuns.Get(context.Background(), run.ID)
-		require.NoError(t, err)
-		require.Equal(t, e.RegularUser.Id, run.Checklists[0].Items[0].AssigneeID)
+		_, err = e.PlaybooksClient.PlaybookRuns.ListWithSavedFilter(context.Background(), filter.ID, 0, 100)
+		requireErrorWithStatusCode(t, err, http.StatusNotFound)
 	})
 }
 
-func TestGetOwners(t *testing.T) {
+func TestRunsBatch(t *testing.T) {
 	e := Setup(t)
 	e.CreateBasic()
 
-	ownerFromUser := func(u *model.User) client.OwnerInfo {
-		return client.OwnerInfo{
-			UserID:    u.Id,
-			Username:  u.Username,
-			FirstName: u.FirstName,
-			LastName:  u.LastName,
-			Nickname:  u.Nickname,
-		}
-	}
-
-	_, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
-		Name:        "Run name",
+	privateRun, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+		Name:        "Private run",
 		OwnerUserID: e.RegularUser.Id,
 		TeamID:      e.BasicTeam.Id,
-		PlaybookID:  e.BasicPlaybook.ID,
+		PlaybookID:  e.BasicPrivatePlaybook.ID,
 	})
 	require.NoError(t, err)
 
-	_, err = e.PlaybooksClient2.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
-		Name:        "Run name",
-		OwnerUserID: e.RegularUser2.Id,
-		TeamID:      e.BasicTeam.Id,
-		PlaybookID:  e.BasicPlaybook.ID,
+	missingID := model.NewId()
+
+	t.Run("get runs", func(t *testing.T) {
+		result, err := e.PlaybooksClient.PlaybookRuns.GetBatch(context.Background(), []string{privateRun.ID, missingID, e.BasicRun.ID, privateRun.ID})
+		require.NoError(t, err)
+		require.Len(t, result.Items, 2)
+		assert.Equal(t, privateRun.ID, result.Items[0].ID)
+		assert.Equal(t, e.BasicRun.ID, result.Items[1].ID)
+		assert.Equal(t, e.BasicRun.Name, result.Items[1].Name)
+		assert.NotEmpty(t, result.Items[1].TimelineEvents)
+		assert.Equal(t, []string{missingID}, result.OmittedRunIDs)
 	})
-	require.NoError(t, err)
 
-	fullOwner1 := ownerFromUser(e.RegularUser)
-	fullOwner2 := ownerFromUser(e.RegularUser2)
-	partialOwner1 := fullOwner1
-	partialOwner1.FirstName = ""
-	partialOwner1.LastName = ""
-	partialOwner2 := fullOwner2
-	partialOwner2.FirstName = ""
-	partialOwner2.LastName = ""
-	for _, tc := range []struct {
-		Name         string
-		ShowFullName bool
-		Client       *client.Client
-		MustContain  []client.OwnerInfo
-	}{
-		{
-			Name:         "showfullname set to true",
-			ShowFullName: true,
-			Client:       e.PlaybooksClient,
-			MustContain:  []client.OwnerInfo{fullOwner1, fullOwner2},
-		},
-		{
-			Name:         "showfullname set to false",
-			ShowFullName: false,
-			Client:       e.PlaybooksClient,
-			MustContain:  []client.OwnerInfo{partialOwner1, partialOwner2},
-		},
-		{
-			Name:         "showfullname set to false and sysadmin",
-			ShowFullName: false,
-			Client:       e.PlaybooksAdminClient,
-			MustContain:  []client.OwnerInfo{fullOwner1, fullOwner2},
-		},
-	} {
-		t.Run(tc.Name, func(t *testing.T) {
-			cfg := e.Srv.Config()
-			cfg.PrivacySettings.ShowFullName = model.NewBool(tc.ShowFullName)
-			_, _, err = e.ServerAdminClient.UpdateConfig(cfg)
-			require.NoError(t, err)
+	t.Run("runs the user can't view are omitted", func(t *testing.T) {
+		result, err := e.PlaybooksClient2.PlaybookRuns.GetBatch(context.Background(), []string{e.BasicRun.ID, privateRun.ID})
+		require.NoError(t, err)
+		require.Len(t, result.Items, 1)
+		assert.Equal(t, e.BasicRun.ID, result.Items[0].ID)
+		assert.Equal(t, []string{privateRun.ID}, result.OmittedRunIDs)
 
-			owners, err := tc.Client.PlaybookRuns.GetOwners(context.Background())
-			require.NoError(t, err)
-			require.Len(t, owners, len(tc.MustContain))
-			for _, mc := range tc.MustContain {
-				require.Contains(t, owners, mc)
-			}
-		})
-	}
+		result, err = e.PlaybooksClientNotInTeam.PlaybookRuns.GetBatch(context.Background(), []string{e.BasicRun.ID})
+		require.NoError(t, err)
+		assert.Empty(t, result.Items)
+		assert.Equal(t, []string{e.BasicRun.ID}, result.OmittedRunIDs)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		_, err := e.PlaybooksClient.PlaybookRuns.GetBatch(context.Background(), nil)
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+
+		_, err = e.PlaybooksClient.PlaybookRuns.GetBatch(context.Background(), []string{"run"})
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+
+		ids := make([]string, client.MaxPlaybookRunsBatch+1)
+		for i := range ids {
+			ids[i] = model.NewId()
+		}
+		_, err = e.PlaybooksClient.PlaybookRuns.GetBatch(context.Background(), ids)
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+	})
 }
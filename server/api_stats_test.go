@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/mattermost/mattermost-plugin-playbooks/client"
+	"github.com/mattermost/mattermost-plugin-playbooks/server/app"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/guregu/null.v4"
@@ -74,6 +76,8 @@ func TestPlaybookKeyMetricsStats(t *testing.T) {
 		require.Equal(t, stats.MetricRollingAverageChange, []null.Int{null.NewInt(0, false), null.NewInt(0, false)})
 		require.Equal(t, stats.MetricRollingValues, [][]int64{{322, 653, 12312}, {76575, 7262, 9123}})
 		require.Equal(t, stats.MetricValueRange, [][]int64{{322, 12312}, {7262, 76575}})
+		require.Equal(t, 5, stats.RunsStartedPrev30Days)
+		require.True(t, stats.MeanTimeBetweenRunsPrev30Days.Valid)
 	})
 
 	t.Run("13 runs with published metrics, 7 runs without publishing", func(t *testing.T) {
@@ -171,6 +175,60 @@ func TestPlaybookKeyMetricsStats(t *testing.T) {
 		require.Equal(t, stats.MetricRollingValues, [][]int64{nil, {1, 7, 6, 5, 3, 2, 7, 1, 5, 3}})
 		require.Equal(t, stats.MetricValueRange, [][]int64{nil, {1, 7}})
 	})
+
+	t.Run("runs breaching metric targets", func(t *testing.T) {
+		playbookID, err := e.PlaybooksAdminClient.Playbooks.Create(context.Background(), client.PlaybookCreateOptions{
+			Title:  "pb5",
+			TeamID: e.BasicTeam.Id,
+			Public: true,
+			Metrics: []client.PlaybookMetricConfig{
+				{Title: "time to resolve", Type: client.MetricTypeDuration, Target: null.IntFrom(60)},
+				{Title: "customers reached", Type: client.MetricTypeInteger, Target: null.IntFrom(10), Direction: client.MetricDirectionHigherIsBetter},
+				{Title: "cost", Type: client.MetricTypeCurrency},
+			},
+		})
+		require.NoError(e.T, err)
+
+		pb, err := e.PlaybooksClient.Playbooks.Get(context.Background(), playbookID)
+		require.NoError(e.T, err)
+		require.Equal(t, client.MetricDirectionHigherIsBetter, pb.Metrics[1].Direction)
+
+		metricsData := createMetricsData(pb.Metrics, [][]int64{{30, 20, 100}, {90, 20, 100}, {90, 5, 100}, {60, 10, 100}})
+		createRunsWithMetrics(t, e, playbookID, metricsData, true)
+
+		stats, err := e.PlaybooksClient.Playbooks.Stats(context.Background(), playbookID)
+		require.NoError(t, err)
+		require.Equal(t, []null.Int{null.IntFrom(2), null.IntFrom(1), null.NewInt(0, false)}, stats.MetricBreachingRuns)
+		require.Equal(t, 2, stats.RunsBreachingMetricTargets)
+
+		runs, err := e.PlaybooksClient.PlaybookRuns.List(context.Background(), 0, 100, client.PlaybookRunListOptions{
+			TeamID:     e.BasicTeam.Id,
+			PlaybookID: playbookID,
+			SearchTerm: "run2",
+		})
+		require.NoError(t, err)
+		require.Len(t, runs.Items, 1)
+
+		// the third run breaches both targets
+		run, err := e.PlaybooksClient.PlaybookRuns.Get(context.Background(), runs.Items[0].ID)
+		require.NoError(t, err)
+		require.Len(t, run.MetricsData, 3)
+		for _, md := range run.MetricsData {
+			require.Equal(t, md.MetricConfigID != pb.Metrics[2].ID, md.Breached)
+		}
+	})
+
+	t.Run("invalid metric direction", func(t *testing.T) {
+		_, err := e.PlaybooksAdminClient.Playbooks.Create(context.Background(), client.PlaybookCreateOptions{
+			Title:  "pb6",
+			TeamID: e.BasicTeam.Id,
+			Public: true,
+			Metrics: []client.PlaybookMetricConfig{
+				{Title: "time to resolve", Type: client.MetricTypeDuration, Target: null.IntFrom(60), Direction: "sideways"},
+			},
+		})
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+	})
 }
 
 func createRunsWithMetrics(t *testing.T, e *TestEnvironment, playbookID string, metricsData [][]client.RunMetricData, publish bool) {
@@ -229,3 +287,75 @@ func intsToNullInts(nums []int64) []null.Int {
 	}
 	return res
 }
+
+func TestRunCompletionVelocity(t *testing.T) {
+	e := Setup(t)
+	e.CreateBasic()
+
+	playbookID, err := e.PlaybooksAdminClient.Playbooks.Create(context.Background(), client.PlaybookCreateOptions{
+		Title:  "PB",
+		TeamID: e.BasicTeam.Id,
+		Public: false,
+		Members: []client.PlaybookMember{
+			{UserID: e.RegularUser.Id, Roles: []string{app.PlaybookRoleMember}},
+		},
+		Checklists: []client.Checklist{
+			{
+				Title: "A",
+				Items: []client.ChecklistItem{
+					{Title: "First"},
+					{Title: "Second"},
+					{Title: "Third"},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	run, err := e.PlaybooksClient.PlaybookRuns.Create(context.Background(), client.PlaybookRunCreateOptions{
+		Name:        "Run with completed items",
+		OwnerUserID: e.RegularUser.Id,
+		TeamID:      e.BasicTeam.Id,
+		PlaybookID:  playbookID,
+	})
+	require.NoError(t, err)
+
+	t.Run("not licensed", func(t *testing.T) {
+		e.RemoveLicence()
+		_, err := e.PlaybooksClient.Stats.GetRunCompletionVelocity(context.Background(), run.ID, 0)
+		requireErrorWithStatusCode(t, err, http.StatusForbidden)
+	})
+
+	e.SetE20Licence()
+
+	t.Run("reopened items count once", func(t *testing.T) {
+		for _, state := range []string{app.ChecklistItemStateClosed, app.ChecklistItemStateOpen, app.ChecklistItemStateClosed} {
+			err := e.PlaybooksClient.PlaybookRuns.SetItemState(context.Background(), run.ID, 0, 0, state, "")
+			require.NoError(t, err)
+		}
+		err := e.PlaybooksClient.PlaybookRuns.SetItemState(context.Background(), run.ID, 0, 1, app.ChecklistItemStateClosed, "")
+		require.NoError(t, err)
+
+		points, err := e.PlaybooksClient.Stats.GetRunCompletionVelocity(context.Background(), run.ID, time.Hour)
+		require.NoError(t, err)
+		require.NotEmpty(t, points)
+		assert.Equal(t, run.CreateAt, points[0].At)
+		assert.Equal(t, 2, points[len(points)-1].Completed)
+	})
+
+	t.Run("bad interval", func(t *testing.T) {
+		_, err := e.PlaybooksClient.Stats.GetRunCompletionVelocity(context.Background(), run.ID, time.Millisecond)
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+
+		_, err = e.PlaybooksClient.Stats.GetRunCompletionVelocity(context.Background(), run.ID, time.Minute)
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+
+		_, err = e.PlaybooksClient.Stats.GetRunCompletionVelocity(context.Background(), run.ID, 366*24*time.Hour)
+		requireErrorWithStatusCode(t, err, http.StatusBadRequest)
+	})
+
+	t.Run("not a participant", func(t *testing.T) {
+		_, err := e.PlaybooksClient2.Stats.GetRunCompletionVelocity(context.Background(), run.ID, 0)
+		requireErrorWithStatusCode(t, err, http.StatusForbidden)
+	})
+}
@@ -0,0 +1,156 @@
+package app
+
+import (
+	"time"
+
+	pluginapi "github.com/mattermost/mattermost-plugin-api"
+	"github.com/mattermost/mattermost-plugin-api/cluster"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/mattermost/mattermost-plugin-playbooks/server/config"
+)
+
+const (
+	// ChannelAutoArchivePollInterval is how often the ChannelAutoArchiver looks for channels due to
+	// be archived.
+	ChannelAutoArchivePollInterval = 1 * time.Minute
+
+	channelAutoArchiveJobKey = "IR_ChannelAutoArchive"
+)
+
+// ChannelAutoArchiver archives the channels of finished runs whose playbook asked for it, once the
+// configured delay after the run finished has passed.
+//
+// A channel is kept if another run was attached to it since, or, if so configured, if someone
+// posted in it after the run finished. Either way the run is only considered once.
+//
+// Polling is done through a cluster job, so only one node in the cluster archives channels at a
+// time.
+type ChannelAutoArchiver struct {
+	store         PlaybookRunStore
+	configService config.Service
+	pluginAPI     *pluginapi.Client
+	job           *cluster.Job
+}
+
+// NewChannelAutoArchiver creates a new ChannelAutoArchiver. Call Start to begin polling.
+func NewChannelAutoArchiver(store PlaybookRunStore, configService config.Service, pluginAPI *pluginapi.Client) *ChannelAutoArchiver {
+	return &ChannelAutoArchiver{
+		store:         store,
+		configService: configService,
+		pluginAPI:     pluginAPI,
+	}
+}
+
+// Start schedules the polling job. Channels that came due while the plugin was stopped are
+// archived on the first poll after it starts again.
+func (a *ChannelAutoArchiver) Start(api cluster.JobPluginAPI) error {
+	job, err := cluster.Schedule(api, channelAutoArchiveJobKey, cluster.MakeWaitForInterval(ChannelAutoArchivePollInterval), a.poll)
+	if err != nil {
+		return errors.Wrap(err, "failed to schedule the channel auto-archive job")
+	}
+	a.job = job
+
+	return nil
+}
+
+// Stop stops polling.
+func (a *ChannelAutoArchiver) Stop() error {
+	if a.job == nil {
+		return nil
+	}
+
+	return a.job.Close()
+}
+
+func (a *ChannelAutoArchiver) poll() {
+	playbookRuns, err := a.store.GetPlaybookRunsDueForChannelArchive(model.GetMillis())
+	if err != nil {
+		logrus.WithError(err).Error("failed to get the runs with a channel due to be archived")
+		return
+	}
+
+	for i := range playbookRuns {
+		playbookRun := &playbookRuns[i]
+		logger := logrus.WithFields(logrus.Fields{
+			"playbook_run_id": playbookRun.ID,
+			"channel_id":      playbookRun.ChannelID,
+		})
+
+		if err := a.archiveChannel(playbookRun); err != nil {
+			logger.WithError(err).Warn("failed to archive the channel of the finished run")
+		}
+
+		// Failures aren't retried, so that a channel that cannot be archived isn't tried forever.
+		if err := a.store.ClearChannelAutoArchive(playbookRun.ID); err != nil {
+			logger.WithError(err).Error("failed to mark the channel archive of the run as handled")
+		}
+	}
+}
+
+// archiveChannel archives the channel of playbookRun, unless it must be kept.
+func (a *ChannelAutoArchiver) archiveChannel(playbookRun *PlaybookRun) error {
+	channel, err := a.pluginAPI.Channel.Get(playbookRun.ChannelID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get channel")
+	}
+	if channel.DeleteAt != 0 {
+		return nil
+	}
+
+	activeRunIDs, err := a.store.GetActivePlaybookRunIDsForChannel(playbookRun.ChannelID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get the active runs of the channel")
+	}
+	if len(activeRunIDs) > 0 {
+		return nil
+	}
+
+	botUserID := a.configService.GetConfiguration().BotUserID
+	if playbookRun.ArchiveChannelSkipIfPosted {
+		posts, err := a.pluginAPI.Post.GetPostsSince(playbookRun.ChannelID, playbookRun.EndAt)
+		if err != nil {
+			return errors.Wrap(err, "failed to get the posts since the run finished")
+		}
+		if hasUserPostsAfter(posts, playbookRun.EndAt, botUserID) {
+			return nil
+		}
+	}
+
+	if err := a.pluginAPI.Channel.Delete(playbookRun.ChannelID); err != nil {
+		return errors.Wrap(err, "failed to archive channel")
+	}
+
+	eventTime := model.GetMillis()
+	event := &TimelineEvent{
+		PlaybookRunID: playbookRun.ID,
+		CreateAt:      eventTime,
+		EventAt:       eventTime,
+		EventType:     ChannelArchived,
+		Summary:       "Channel archived after the run finished",
+		SubjectUserID: botUserID,
+	}
+	if _, err := a.store.CreateTimelineEvent(event); err != nil {
+		return errors.Wrap(err, "failed to create timeline event")
+	}
+
+	return nil
+}
+
+// hasUserPostsAfter is true if posts has a post created after the given time, in millis, other
+// than system messages and the posts of the bot.
+func hasUserPostsAfter(posts *model.PostList, after int64, botUserID string) bool {
+	if posts == nil {
+		return false
+	}
+
+	for _, post := range posts.Posts {
+		if post.CreateAt > after && post.DeleteAt == 0 && post.UserId != botUserID && !post.IsSystemMessage() {
+			return true
+		}
+	}
+
+	return false
+}
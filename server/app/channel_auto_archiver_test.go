@@ -0,0 +1,49 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHasUserPostsAfter(t *testing.T) {
+	const botUserID = "bot"
+
+	postList := func(posts ...*model.Post) *model.PostList {
+		list := model.NewPostList()
+		for _, post := range posts {
+			list.AddPost(post)
+		}
+		return list
+	}
+
+	t.Run("no posts", func(t *testing.T) {
+		require.False(t, hasUserPostsAfter(nil, 1000, botUserID))
+		require.False(t, hasUserPostsAfter(postList(), 1000, botUserID))
+	})
+
+	t.Run("posts that don't count", func(t *testing.T) {
+		posts := postList(
+			&model.Post{Id: "before", UserId: "user", CreateAt: 1000},
+			&model.Post{Id: "bot", UserId: botUserID, CreateAt: 2000},
+			&model.Post{Id: "system", UserId: "user", CreateAt: 2000, Type: model.PostTypeJoinChannel},
+			&model.Post{Id: "deleted", UserId: "user", CreateAt: 2000, DeleteAt: 3000},
+		)
+		require.False(t, hasUserPostsAfter(posts, 1000, botUserID))
+	})
+
+	t.Run("a user posted after", func(t *testing.T) {
+		posts := postList(
+			&model.Post{Id: "bot", UserId: botUserID, CreateAt: 2000},
+			&model.Post{Id: "user", UserId: "user", CreateAt: 2000},
+		)
+		require.True(t, hasUserPostsAfter(posts, 1000, botUserID))
+	})
+}
+
+func TestValidateArchiveChannelOnFinishDelay(t *testing.T) {
+	require.NoError(t, ValidateArchiveChannelOnFinishDelay(0))
+	require.NoError(t, ValidateArchiveChannelOnFinishDelay(60))
+	require.Error(t, ValidateArchiveChannelOnFinishDelay(-1))
+}
@@ -0,0 +1,127 @@
+package app
+
+import (
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/pkg/errors"
+)
+
+// A checklist item can depend on another item of the same playbook or run, identified by its ID.
+// The item is blocked until the item it depends on is done or skipped. Dependencies on items that
+// were removed from the run, or that are hidden by their condition, don't block.
+
+// ValidateChecklistItemDependencies checks that every item of checklists with a dependency depends
+// on another item of checklists whose ID is unique, and that the dependencies don't form a cycle.
+func ValidateChecklistItemDependencies(checklists []Checklist) error {
+	items := make(map[string]ChecklistItem)
+	duplicateIDs := make(map[string]bool)
+	for _, checklist := range checklists {
+		for _, item := range checklist.Items {
+			if item.ID == "" {
+				continue
+			}
+			if _, ok := items[item.ID]; ok {
+				duplicateIDs[item.ID] = true
+			}
+			items[item.ID] = item
+		}
+	}
+
+	for _, checklist := range checklists {
+		for _, item := range checklist.Items {
+			if item.DependsOnItemID == "" {
+				continue
+			}
+			if item.DependsOnItemID == item.ID {
+				return errors.Wrapf(ErrInvalidChecklistItemDependency, "checklist item %q depends on itself", item.Title)
+			}
+			if _, ok := items[item.DependsOnItemID]; !ok {
+				return errors.Wrapf(ErrInvalidChecklistItemDependency, "checklist item %q depends on an item that doesn't exist", item.Title)
+			}
+			if duplicateIDs[item.DependsOnItemID] {
+				return errors.Wrapf(ErrInvalidChecklistItemDependency, "checklist item %q depends on an item whose id is not unique", item.Title)
+			}
+		}
+	}
+
+	// Every item depends on at most one item, so a cycle is found by following the dependencies
+	// of each item until reaching an item without any, or an item already seen.
+	for _, checklist := range checklists {
+		for _, item := range checklist.Items {
+			seen := map[string]bool{item.ID: true}
+			for current := item; current.DependsOnItemID != ""; current = items[current.DependsOnItemID] {
+				if seen[current.DependsOnItemID] {
+					return errors.Wrapf(ErrChecklistItemDependencyCycle, "checklist item %q depends on itself through %q", items[current.DependsOnItemID].Title, current.Title)
+				}
+				seen[current.DependsOnItemID] = true
+			}
+		}
+	}
+
+	return nil
+}
+
+// ApplyChecklistItemDependencies flags as blocked the run's checklist items whose dependency is
+// not done or skipped yet.
+func (r *PlaybookRun) ApplyChecklistItemDependencies() {
+	items := make(map[string]ChecklistItem)
+	for _, checklist := range r.Checklists {
+		for _, item := range checklist.Items {
+			items[item.ID] = item
+		}
+	}
+
+	for i := range r.Checklists {
+		for j := range r.Checklists[i].Items {
+			item := &r.Checklists[i].Items[j]
+
+			dependency, ok := items[item.DependsOnItemID]
+			item.Blocked = item.DependsOnItemID != "" && ok && !dependency.Hidden &&
+				dependency.State != ChecklistItemStateClosed && dependency.State != ChecklistItemStateSkipped
+		}
+	}
+}
+
+// ChecklistItemDependency returns the item of the run the given item depends on. Returns false if
+// the item doesn't depend on any item, or if the item it depends on was removed from the run.
+func (r *PlaybookRun) ChecklistItemDependency(item ChecklistItem) (ChecklistItem, bool) {
+	if item.DependsOnItemID == "" {
+		return ChecklistItem{}, false
+	}
+
+	for _, checklist := range r.Checklists {
+		for _, other := range checklist.Items {
+			if other.ID == item.DependsOnItemID {
+				return other, true
+			}
+		}
+	}
+
+	return ChecklistItem{}, false
+}
+
+// renewChecklistItemIDs gives new IDs to the items of checklists, keeping the dependencies between
+// them. The IDs of the items of a run must be unique across runs, so the items of a run copied
+// from a playbook or from another run can't keep the IDs of the items they were copied from.
+// Dependencies on items that are not in checklists are dropped.
+func renewChecklistItemIDs(checklists []Checklist) {
+	newIDs := make(map[string]string)
+	for i := range checklists {
+		for j := range checklists[i].Items {
+			item := &checklists[i].Items[j]
+			newID := model.NewId()
+			if item.ID != "" {
+				newIDs[item.ID] = newID
+			}
+			item.ID = newID
+		}
+	}
+
+	for i := range checklists {
+		for j := range checklists[i].Items {
+			item := &checklists[i].Items[j]
+			if item.DependsOnItemID != "" {
+				item.DependsOnItemID = newIDs[item.DependsOnItemID]
+			}
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateChecklistItemDependencies(t *testing.T) {
+	testCases := []struct {
+		name       string
+		checklists []Checklist
+		expected   error
+	}{
+		{
+			name: "no dependencies",
+			checklists: []Checklist{
+				{Items: []ChecklistItem{{Title: "a"}, {Title: "b"}}},
+			},
+		},
+		{
+			name: "chain across checklists",
+			checklists: []Checklist{
+				{Items: []ChecklistItem{{ID: "a", Title: "a"}, {ID: "b", Title: "b", DependsOnItemID: "a"}}},
+				{Items: []ChecklistItem{{ID: "c", Title: "c", DependsOnItemID: "b"}, {ID: "d", Title: "d", DependsOnItemID: "b"}}},
+			},
+		},
+		{
+			name: "depends on itself",
+			checklists: []Checklist{
+				{Items: []ChecklistItem{{ID: "a", Title: "a", DependsOnItemID: "a"}}},
+			},
+			expected: ErrInvalidChecklistItemDependency,
+		},
+		{
+			name: "depends on an item that doesn't exist",
+			checklists: []Checklist{
+				{Items: []ChecklistItem{{ID: "a", Title: "a", DependsOnItemID: "b"}}},
+			},
+			expected: ErrInvalidChecklistItemDependency,
+		},
+		{
+			name: "depends on an item whose id is not unique",
+			checklists: []Checklist{
+				{Items: []ChecklistItem{{ID: "a", Title: "a"}, {ID: "b", Title: "b", DependsOnItemID: "a"}}},
+				{Items: []ChecklistItem{{ID: "a", Title: "other a"}}},
+			},
+			expected: ErrInvalidChecklistItemDependency,
+		},
+		{
+			name: "cycle",
+			checklists: []Checklist{
+				{Items: []ChecklistItem{{ID: "a", Title: "a", DependsOnItemID: "c"}, {ID: "b", Title: "b", DependsOnItemID: "a"}}},
+				{Items: []ChecklistItem{{ID: "c", Title: "c", DependsOnItemID: "b"}}},
+			},
+			expected: ErrChecklistItemDependencyCycle,
+		},
+		{
+			name: "chain leading to a cycle",
+			checklists: []Checklist{
+				{Items: []ChecklistItem{
+					{ID: "a", Title: "a", DependsOnItemID: "b"},
+					{ID: "b", Title: "b", DependsOnItemID: "c"},
+					{ID: "c", Title: "c", DependsOnItemID: "b"},
+				}},
+			},
+			expected: ErrChecklistItemDependencyCycle,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateChecklistItemDependencies(tc.checklists)
+			if tc.expected == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.expected)
+			}
+		})
+	}
+}
+
+func TestPlaybookRun_ApplyChecklistItemDependencies(t *testing.T) {
+	run := PlaybookRun{
+		Checklists: []Checklist{
+			{Items: []ChecklistItem{
+				{ID: "open", State: ChecklistItemStateOpen},
+				{ID: "closed", State: ChecklistItemStateClosed},
+				{ID: "skipped", State: ChecklistItemStateSkipped},
+				{ID: "hidden", State: ChecklistItemStateOpen, Hidden: true},
+			}},
+			{Items: []ChecklistItem{
+				{ID: "on-open", DependsOnItemID: "open", Blocked: false},
+				{ID: "on-closed", DependsOnItemID: "closed", Blocked: true},
+				{ID: "on-skipped", DependsOnItemID: "skipped"},
+				{ID: "on-hidden", DependsOnItemID: "hidden"},
+				{ID: "on-removed", DependsOnItemID: "removed"},
+				{ID: "none"},
+			}},
+		},
+	}
+
+	run.ApplyChecklistItemDependencies()
+
+	blocked := map[string]bool{}
+	for _, item := range run.Checklists[1].Items {
+		blocked[item.ID] = item.Blocked
+	}
+	require.Equal(t, map[string]bool{
+		"on-open":    true,
+		"on-closed":  false,
+		"on-skipped": false,
+		"on-hidden":  false,
+		"on-removed": false,
+		"none":       false,
+	}, blocked)
+
+	dependency, ok := run.ChecklistItemDependency(run.Checklists[1].Items[0])
+	require.True(t, ok)
+	require.Equal(t, "open", dependency.ID)
+
+	_, ok = run.ChecklistItemDependency(run.Checklists[1].Items[4])
+	require.False(t, ok)
+}
+
+func TestRenewChecklistItemIDs(t *testing.T) {
+	checklists := []Checklist{
+		{Items: []ChecklistItem{{ID: "a"}, {}}},
+		{Items: []ChecklistItem{{ID: "b", DependsOnItemID: "a"}, {ID: "c", DependsOnItemID: "removed"}}},
+	}
+
+	renewChecklistItemIDs(checklists)
+
+	ids := map[string]bool{}
+	for _, checklist := range checklists {
+		for _, item := range checklist.Items {
+			require.Len(t, item.ID, 26)
+			ids[item.ID] = true
+		}
+	}
+	require.Len(t, ids, 4)
+
+	require.Equal(t, checklists[0].Items[0].ID, checklists[1].Items[0].DependsOnItemID)
+	require.Empty(t, checklists[1].Items[1].DependsOnItemID)
+}
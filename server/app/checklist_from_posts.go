@@ -0,0 +1,171 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// MaxChecklistItemsFromPosts is the maximum number of posts that can be turned into checklist
+// items at once.
+const MaxChecklistItemsFromPosts = 50
+
+// maxChecklistItemTitleFromPostLength is the length, in characters, past which the text of a post
+// is shortened to make the title of a checklist item. The whole message is kept in the description.
+const maxChecklistItemTitleFromPostLength = 200
+
+var (
+	reMarkdownImage      = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	reMarkdownLink       = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	reMarkdownLinePrefix = regexp.MustCompile(`^\s{0,3}(#{1,6}\s+|>\s?|[-*+]\s+\[[ xX]\]\s+|[-*+]\s+|\d+[.)]\s+)`)
+	reMarkdownEmphasis   = regexp.MustCompile(`\*(\S|\S[^*]*?\S)\*`)
+)
+
+// AddChecklistItemsFromPosts appends a checklist item for each of the given posts of the run
+// channel to the specified checklist, in order. The title of each item is the text of its post
+// without the Markdown formatting, and its description names the author of the post. Posts that
+// were deleted, cannot be read, are in another channel, are system messages or have no text are
+// skipped and counted in the result.
+func (s *PlaybookRunServiceImpl) AddChecklistItemsFromPosts(playbookRunID, userID string, checklistNumber int, options AddChecklistItemsFromPostsOptions) (*AddChecklistItemsFromPostsResult, error) {
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+
+	playbookRunToModify, err := s.checklistParamsVerify(playbookRunID, userID, checklistNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	posts, skipped := s.getPostsForChecklistItems(playbookRunToModify.ChannelID, options)
+
+	usernames := map[string]string{}
+	items := make([]ChecklistItem, 0, len(posts))
+	for _, post := range posts {
+		item, ok := s.checklistItemFromPost(post, usernames)
+		if !ok {
+			skipped++
+			continue
+		}
+		items = append(items, item)
+	}
+
+	result := &AddChecklistItemsFromPostsResult{ItemsAdded: len(items), PostsSkipped: skipped}
+	if len(items) == 0 {
+		return result, nil
+	}
+
+	playbookRunToModify.Checklists[checklistNumber].Items = append(playbookRunToModify.Checklists[checklistNumber].Items, items...)
+	playbookRunToModify.ApplyChecklistItemConditions()
+
+	playbookRunToModify, err = s.store.UpdatePlaybookRun(playbookRunToModify)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to update playbook run")
+	}
+
+	s.sendPlaybookRunUpdatedWS(playbookRunID, WithPlaybookRun(playbookRunToModify))
+	for _, item := range items {
+		s.telemetry.AddTask(playbookRunID, userID, item)
+	}
+
+	return result, nil
+}
+
+// getPostsForChecklistItems returns the posts named by options, oldest first for the recent posts
+// of channelID, along with the number of posts that could not be read or are not in channelID.
+func (s *PlaybookRunServiceImpl) getPostsForChecklistItems(channelID string, options AddChecklistItemsFromPostsOptions) ([]*model.Post, int) {
+	if options.RecentPosts > 0 {
+		list, err := s.pluginAPI.Post.GetPostsForChannel(channelID, 0, options.RecentPosts)
+		if err != nil {
+			logrus.WithError(err).WithField("channel_id", channelID).Warn("failed to get recent posts for checklist items")
+			return nil, options.RecentPosts
+		}
+
+		// The posts are ordered newest first.
+		posts := make([]*model.Post, 0, len(list.Order))
+		for i := len(list.Order) - 1; i >= 0; i-- {
+			posts = append(posts, list.Posts[list.Order[i]])
+		}
+		return posts, 0
+	}
+
+	posts := make([]*model.Post, 0, len(options.PostIDs))
+	skipped := 0
+	for _, postID := range options.PostIDs {
+		post, err := s.pluginAPI.Post.GetPost(postID)
+		if err != nil || post.ChannelId != channelID {
+			skipped++
+			continue
+		}
+		posts = append(posts, post)
+	}
+
+	return posts, skipped
+}
+
+// checklistItemFromPost returns an unchecked checklist item with the text of post, or false if the
+// post can't be turned into one.
+func (s *PlaybookRunServiceImpl) checklistItemFromPost(post *model.Post, usernames map[string]string) (ChecklistItem, bool) {
+	if post == nil || post.DeleteAt != 0 || post.Type != "" {
+		return ChecklistItem{}, false
+	}
+
+	title, shortened := checklistItemTitleFromMessage(post.Message)
+	if title == "" {
+		return ChecklistItem{}, false
+	}
+
+	username, ok := usernames[post.UserId]
+	if !ok {
+		username = post.UserId
+		if user, err := s.pluginAPI.User.Get(post.UserId); err == nil {
+			username = user.Username
+		}
+		usernames[post.UserId] = username
+	}
+
+	description := fmt.Sprintf("_Added from a message by @%s._", username)
+	if shortened {
+		description = strings.TrimSpace(post.Message) + "\n\n" + description
+	}
+
+	return ChecklistItem{
+		ID:          model.NewId(),
+		Title:       title,
+		Description: description,
+		State:       ChecklistItemStateOpen,
+	}, true
+}
+
+// checklistItemTitleFromMessage strips the Markdown formatting of message and joins its lines,
+// shortening the result if it is too long to be a title. Returns true if it was shortened.
+func checklistItemTitleFromMessage(message string) (string, bool) {
+	lines := []string{}
+	for _, line := range strings.Split(message, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			continue
+		}
+
+		line = reMarkdownLinePrefix.ReplaceAllString(line, "")
+		line = reMarkdownImage.ReplaceAllString(line, "$1")
+		line = reMarkdownLink.ReplaceAllString(line, "$1")
+		line = strings.NewReplacer("**", "", "__", "", "~~", "", "`", "").Replace(line)
+		line = reMarkdownEmphasis.ReplaceAllString(line, "$1")
+
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	title := strings.Join(strings.Fields(strings.Join(lines, " ")), " ")
+	if utf8.RuneCountInString(title) <= maxChecklistItemTitleFromPostLength {
+		return title, false
+	}
+
+	runes := []rune(title)
+	return strings.TrimSpace(string(runes[:maxChecklistItemTitleFromPostLength-1])) + "…", true
+}
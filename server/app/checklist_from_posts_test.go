@@ -0,0 +1,58 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecklistItemTitleFromMessage(t *testing.T) {
+	testCases := []struct {
+		message  string
+		expected string
+	}{
+		{"Restart the api servers", "Restart the api servers"},
+		{"**Restart** the `api` servers", "Restart the api servers"},
+		{"_Restart_ the *api* servers", "_Restart_ the api servers"},
+		{"restart the api_server and db_server", "restart the api_server and db_server"},
+		{"~~Roll back~~ roll forward", "Roll back roll forward"},
+		{"### Next steps", "Next steps"},
+		{"> quoted idea", "quoted idea"},
+		{"- [ ] check the logs", "check the logs"},
+		{"1. page the DBA\n2. check replication", "page the DBA check replication"},
+		{"Check the [dashboard](https://example.com/d)", "Check the dashboard"},
+		{"See ![graph](https://example.com/g.png)", "See graph"},
+		{"Run this:\n```\nkubectl rollout restart\n```", "Run this: kubectl rollout restart"},
+		{"   \n```\n```", ""},
+		{"2 * 3 * 4", "2 * 3 * 4"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.message, func(t *testing.T) {
+			title, shortened := checklistItemTitleFromMessage(tc.message)
+			require.Equal(t, tc.expected, title)
+			require.False(t, shortened)
+		})
+	}
+
+	t.Run("long message", func(t *testing.T) {
+		title, shortened := checklistItemTitleFromMessage(strings.Repeat("ñ", maxChecklistItemTitleFromPostLength+1))
+		require.True(t, shortened)
+		require.Equal(t, maxChecklistItemTitleFromPostLength, len([]rune(title)))
+		require.True(t, strings.HasSuffix(title, "…"))
+	})
+}
+
+func TestAddChecklistItemsFromPostsOptions_Validate(t *testing.T) {
+	postID := "abcdefghijklmnopqrstuvwxyz"
+
+	require.NoError(t, AddChecklistItemsFromPostsOptions{PostIDs: []string{postID}}.Validate())
+	require.NoError(t, AddChecklistItemsFromPostsOptions{RecentPosts: MaxChecklistItemsFromPosts}.Validate())
+
+	require.Error(t, AddChecklistItemsFromPostsOptions{}.Validate())
+	require.Error(t, AddChecklistItemsFromPostsOptions{RecentPosts: -1}.Validate())
+	require.Error(t, AddChecklistItemsFromPostsOptions{RecentPosts: MaxChecklistItemsFromPosts + 1}.Validate())
+	require.Error(t, AddChecklistItemsFromPostsOptions{PostIDs: []string{postID}, RecentPosts: 1}.Validate())
+	require.Error(t, AddChecklistItemsFromPostsOptions{PostIDs: []string{"invalid"}}.Validate())
+}
@@ -0,0 +1,112 @@
+package app
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+)
+
+// CommandOutputStatus tells whether the slash command of a checklist item worked.
+type CommandOutputStatus string
+
+const (
+	// CommandOutputSucceeded outputs are the response of a command that was executed.
+	CommandOutputSucceeded CommandOutputStatus = "succeeded"
+
+	// CommandOutputFailed outputs are the error of a command that could not be executed, so the
+	// step it automates didn't actually run.
+	CommandOutputFailed CommandOutputStatus = "failed"
+)
+
+// MaxCommandOutputLength is the maximum length, in characters, of a stored command output. Longer
+// outputs are truncated.
+const MaxCommandOutputLength = 16000
+
+// commandOutputTruncatedIndicator ends the outputs that were truncated.
+const commandOutputTruncatedIndicator = "\n… (output truncated)"
+
+// CommandOutput is what the slash command of a checklist item responded the last time it was run
+// from the run.
+type CommandOutput struct {
+	ID              string `json:"id"`
+	PlaybookRunID   string `json:"playbook_run_id"`
+	ChecklistItemID string `json:"checklist_item_id"`
+
+	// Command is the command as executed, with the variables of the run replaced.
+	Command string `json:"command"`
+
+	// UserID is the user who ran the command.
+	UserID string `json:"user_id"`
+
+	Status CommandOutputStatus `json:"status"`
+
+	// Output is the text of the response of the command, or the error for failed commands.
+	Output string `json:"output"`
+
+	// Truncated is true if Output was shortened to MaxCommandOutputLength.
+	Truncated bool `json:"truncated"`
+
+	CreateAt int64 `json:"create_at"`
+}
+
+// newCommandOutput returns the output of command, truncating output if it is too long.
+func newCommandOutput(playbookRunID, checklistItemID, command, userID string, status CommandOutputStatus, output string) CommandOutput {
+	output, truncated := truncateCommandOutput(output)
+
+	return CommandOutput{
+		ID:              model.NewId(),
+		PlaybookRunID:   playbookRunID,
+		ChecklistItemID: checklistItemID,
+		Command:         command,
+		UserID:          userID,
+		Status:          status,
+		Output:          output,
+		Truncated:       truncated,
+		CreateAt:        model.GetMillis(),
+	}
+}
+
+// commandResponseText returns the text shown by the response of a command: its message, the
+// text of its attachments and that of its extra responses.
+func commandResponseText(response *model.CommandResponse) string {
+	if response == nil {
+		return ""
+	}
+
+	parts := []string{}
+	if text := strings.TrimSpace(response.Text); text != "" {
+		parts = append(parts, text)
+	}
+	for _, attachment := range response.Attachments {
+		if attachment == nil {
+			continue
+		}
+
+		text := strings.TrimSpace(attachment.Text)
+		if text == "" {
+			text = strings.TrimSpace(attachment.Fallback)
+		}
+		if text != "" {
+			parts = append(parts, text)
+		}
+	}
+	for _, extraResponse := range response.ExtraResponses {
+		if text := commandResponseText(extraResponse); text != "" {
+			parts = append(parts, text)
+		}
+	}
+
+	return strings.Join(parts, "\n\n")
+}
+
+// truncateCommandOutput shortens output to MaxCommandOutputLength characters, ending it with an
+// indicator. Returns true if it was shortened.
+func truncateCommandOutput(output string) (string, bool) {
+	if utf8.RuneCountInString(output) <= MaxCommandOutputLength {
+		return output, false
+	}
+
+	runes := []rune(output)
+	return string(runes[:MaxCommandOutputLength-utf8.RuneCountInString(commandOutputTruncatedIndicator)]) + commandOutputTruncatedIndicator, true
+}
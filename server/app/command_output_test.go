@@ -0,0 +1,38 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandResponseText(t *testing.T) {
+	require.Empty(t, commandResponseText(nil))
+
+	response := &model.CommandResponse{
+		Text: "disk usage: 42%",
+		Attachments: []*model.SlackAttachment{
+			{Text: "sda1 ok"},
+			{Fallback: "sdb1 degraded"},
+			nil,
+		},
+		ExtraResponses: []*model.CommandResponse{
+			{Text: "  checked 2 disks  "},
+		},
+	}
+	require.Equal(t, "disk usage: 42%\n\nsda1 ok\n\nsdb1 degraded\n\nchecked 2 disks", commandResponseText(response))
+}
+
+func TestTruncateCommandOutput(t *testing.T) {
+	output, truncated := truncateCommandOutput("short")
+	require.False(t, truncated)
+	require.Equal(t, "short", output)
+
+	output, truncated = truncateCommandOutput(strings.Repeat("é", MaxCommandOutputLength+1))
+	require.True(t, truncated)
+	require.Equal(t, MaxCommandOutputLength, utf8.RuneCountInString(output))
+	require.True(t, strings.HasSuffix(output, commandOutputTruncatedIndicator))
+}
@@ -0,0 +1,181 @@
+package app
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// A checklist item condition compares a run variable with a value or a set of values, e.g.
+// `$SEVERITY == critical`, `$SEVERITY != low` or `$SEVERITY in (critical, "very high")`.
+// Variables are the ones defined in the run summary, and undefined variables are empty.
+// Values are compared ignoring case, and need to be quoted if they contain spaces, commas or
+// parentheses.
+
+type conditionOperator string
+
+const (
+	conditionEquals    conditionOperator = "=="
+	conditionNotEquals conditionOperator = "!="
+	conditionIn        conditionOperator = "in"
+	conditionNotIn     conditionOperator = "not in"
+)
+
+var reCondition = regexp.MustCompile(`^\s*` + varsReStr + `\s*(==|!=|\bnot\s+in\b|\bin\b)\s*(.*?)\s*$`)
+
+type checklistItemCondition struct {
+	variable string
+	operator conditionOperator
+	values   []string
+}
+
+// parseChecklistItemCondition parses the condition expression of a checklist item.
+func parseChecklistItemCondition(expression string) (checklistItemCondition, error) {
+	match := reCondition.FindStringSubmatch(expression)
+	if match == nil {
+		return checklistItemCondition{}, errors.Errorf("invalid condition %q: expected a variable, an operator (==, !=, in, not in) and a value", expression)
+	}
+
+	condition := checklistItemCondition{
+		variable: match[1],
+		operator: conditionOperator(strings.Join(strings.Fields(match[2]), " ")),
+	}
+
+	var err error
+	switch condition.operator {
+	case conditionEquals, conditionNotEquals:
+		var value string
+		value, err = parseConditionValue(match[3])
+		condition.values = []string{value}
+	default:
+		condition.values, err = parseConditionSet(match[3])
+	}
+	if err != nil {
+		return checklistItemCondition{}, errors.Wrapf(err, "invalid condition %q", expression)
+	}
+
+	return condition, nil
+}
+
+func parseConditionValue(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if strings.HasPrefix(raw, `"`) {
+		value, err := strconv.Unquote(raw)
+		if err != nil {
+			return "", errors.Errorf("invalid quoted value %s", raw)
+		}
+		return value, nil
+	}
+
+	if raw == "" || strings.ContainsAny(raw, "\"(), \t") {
+		return "", errors.Errorf("invalid value %q", raw)
+	}
+
+	return raw, nil
+}
+
+func parseConditionSet(raw string) ([]string, error) {
+	if !strings.HasPrefix(raw, "(") || !strings.HasSuffix(raw, ")") {
+		return nil, errors.Errorf("expected a parenthesized list of values, got %q", raw)
+	}
+	inner := raw[1 : len(raw)-1]
+
+	var values []string
+	start, inQuotes, escaped := 0, false, false
+	for i, c := range inner {
+		switch {
+		case escaped:
+			escaped = false
+		case inQuotes && c == '\\':
+			escaped = true
+		case c == '"':
+			inQuotes = !inQuotes
+		case c == ',' && !inQuotes:
+			value, err := parseConditionValue(inner[start:i])
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+			start = i + 1
+		}
+	}
+
+	value, err := parseConditionValue(inner[start:])
+	if err != nil {
+		return nil, err
+	}
+
+	return append(values, value), nil
+}
+
+// evaluate returns whether the condition holds for the given run variables.
+func (c checklistItemCondition) evaluate(vars map[string]string) bool {
+	value := strings.TrimSpace(vars[c.variable])
+
+	matches := false
+	for _, candidate := range c.values {
+		if strings.EqualFold(value, candidate) {
+			matches = true
+			break
+		}
+	}
+
+	if c.operator == conditionNotEquals || c.operator == conditionNotIn {
+		return !matches
+	}
+
+	return matches
+}
+
+// ValidateChecklistItemCondition checks that expression is a valid checklist item condition.
+// The empty expression is valid, and means that the item is always shown.
+func ValidateChecklistItemCondition(expression string) error {
+	if expression == "" {
+		return nil
+	}
+
+	_, err := parseChecklistItemCondition(expression)
+	return err
+}
+
+// ValidateChecklistItemConditions checks that the conditions of every item in checklists are valid.
+func ValidateChecklistItemConditions(checklists []Checklist) error {
+	for _, checklist := range checklists {
+		for _, item := range checklist.Items {
+			if err := ValidateChecklistItemCondition(item.Condition); err != nil {
+				return errors.Wrapf(err, "checklist item %q", item.Title)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ApplyChecklistItemConditions evaluates the conditions of the run's checklist items against the
+// variables defined in its summary, hiding the items whose condition does not hold. Items with an
+// invalid condition are shown. Returns true if any item was hidden or shown.
+func (r *PlaybookRun) ApplyChecklistItemConditions() bool {
+	vars := parseVariablesAndValues(r.Summary)
+
+	changed := false
+	for i := range r.Checklists {
+		for j := range r.Checklists[i].Items {
+			item := &r.Checklists[i].Items[j]
+
+			hidden := false
+			if item.Condition != "" {
+				condition, err := parseChecklistItemCondition(item.Condition)
+				hidden = err == nil && !condition.evaluate(vars)
+			}
+
+			if item.Hidden != hidden {
+				item.Hidden = hidden
+				changed = true
+			}
+		}
+	}
+
+	return changed
+}
@@ -0,0 +1,92 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecklistItemCondition(t *testing.T) {
+	vars := map[string]string{
+		"$SEVERITY": "Critical ",
+		"$REGION":   "us east",
+	}
+
+	testCases := []struct {
+		expression string
+		expected   bool
+	}{
+		{`$SEVERITY == critical`, true},
+		{`$SEVERITY==CRITICAL`, true},
+		{`$SEVERITY == low`, false},
+		{`$SEVERITY != low`, true},
+		{`$SEVERITY != critical`, false},
+		{`$SEVERITY in (high, critical)`, true},
+		{`$SEVERITY in(low)`, false},
+		{`$SEVERITY not in (low, medium)`, true},
+		{`$SEVERITY not  in (critical)`, false},
+		{`$REGION == "us east"`, true},
+		{`$REGION in ("us west", "us east")`, true},
+		{`$REGION in ("us, east", other)`, false},
+		{`$UNDEFINED == ""`, true},
+		{`$UNDEFINED in (a, b)`, false},
+		{`$UNDEFINED != a`, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expression, func(t *testing.T) {
+			condition, err := parseChecklistItemCondition(tc.expression)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, condition.evaluate(vars))
+		})
+	}
+
+	for _, expression := range []string{
+		`SEVERITY == critical`,
+		`$SEVERITY = critical`,
+		`$SEVERITY ==`,
+		`$SEVERITY == two words`,
+		`$SEVERITY == "unterminated`,
+		`$SEVERITY in critical`,
+		`$SEVERITY in ()`,
+		`$SEVERITY in (a,, b)`,
+		`$SEVERITYin (a)`,
+	} {
+		t.Run("invalid "+expression, func(t *testing.T) {
+			require.Error(t, ValidateChecklistItemCondition(expression))
+		})
+	}
+}
+
+func TestPlaybookRun_ApplyChecklistItemConditions(t *testing.T) {
+	run := PlaybookRun{
+		Summary: "Database outage\n$SEVERITY=low",
+		Checklists: []Checklist{
+			{
+				Items: []ChecklistItem{
+					{Title: "always"},
+					{Title: "critical only", Condition: "$SEVERITY == critical"},
+					{Title: "not critical", Condition: "$SEVERITY != critical"},
+					{Title: "invalid", Condition: "$SEVERITY ~ critical"},
+				},
+			},
+		},
+	}
+
+	hidden := func() []bool {
+		h := []bool{}
+		for _, item := range run.Checklists[0].Items {
+			h = append(h, item.Hidden)
+		}
+		return h
+	}
+
+	require.True(t, run.ApplyChecklistItemConditions())
+	require.Equal(t, []bool{false, true, false, false}, hidden())
+
+	require.False(t, run.ApplyChecklistItemConditions())
+
+	run.Summary = "Database outage\n$SEVERITY=critical"
+	require.True(t, run.ApplyChecklistItemConditions())
+	require.Equal(t, []bool{false, false, true, false}, hidden())
+}
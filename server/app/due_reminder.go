@@ -0,0 +1,349 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	pluginapi "github.com/mattermost/mattermost-plugin-api"
+	"github.com/mattermost/mattermost-plugin-api/cluster"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/i18n"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	stripmd "github.com/writeas/go-strip-markdown"
+
+	"github.com/mattermost/mattermost-plugin-playbooks/server/bot"
+	"github.com/mattermost/mattermost-plugin-playbooks/server/config"
+	"github.com/mattermost/mattermost-plugin-playbooks/server/timeutils"
+)
+
+const (
+	// DueReminderPollInterval is how often the DueReminderScheduler looks for reminders to send.
+	DueReminderPollInterval = 1 * time.Minute
+
+	// DefaultDueReminderBefore is how long before an item is due its assignee is reminded, when
+	// not configured.
+	DefaultDueReminderBefore = 1 * time.Hour
+
+	// MaxDueReminderSnooze is the longest a due reminder can be snoozed for.
+	MaxDueReminderSnooze = 7 * 24 * time.Hour
+
+	dueReminderJobKey    = "IR_DueReminder"
+	dueReminderBatchSize = 100
+)
+
+// dueReminderSnoozeOptions are the durations offered to snooze a due reminder from its DM, with
+// the translation ID of their button.
+var dueReminderSnoozeOptions = []struct {
+	nameID   string
+	duration time.Duration
+}{
+	{nameID: "app.user.due_reminder.snooze_hour", duration: 1 * time.Hour},
+	{nameID: "app.user.due_reminder.snooze_day", duration: 24 * time.Hour},
+}
+
+// DueReminder is the DM reminding the assignee of a checklist item that the item is due soon.
+//
+// There is at most one reminder per item. It follows the item: it moves to the new assignee when
+// the item is reassigned, it is rescheduled when the due date changes and it is removed when the
+// item is done, skipped or unassigned.
+type DueReminder struct {
+	PlaybookRunID   string `json:"playbook_run_id"`
+	ChecklistItemID string `json:"checklist_item_id"`
+
+	// UserID is the assignee to remind.
+	UserID string `json:"user_id"`
+
+	// DueDate is the due date, in milliseconds since epoch, of the item when the reminder was
+	// scheduled.
+	DueDate int64 `json:"due_date"`
+
+	// RemindAt is the time, in milliseconds since epoch, at which the reminder is sent. Snoozing
+	// the reminder moves it later.
+	RemindAt int64 `json:"remind_at"`
+
+	// SentAt is the time, in milliseconds since epoch, the reminder was sent. 0 while pending.
+	SentAt int64 `json:"sent_at"`
+
+	CreateAt int64 `json:"create_at"`
+	UpdateAt int64 `json:"update_at"`
+}
+
+// DueReminderStore defines the methods the DueReminderScheduler needs from the interface layer.
+type DueReminderStore interface {
+	// GetDueReminder returns the reminder of a checklist item. Returns ErrNotFound if not found.
+	GetDueReminder(playbookRunID, checklistItemID string) (DueReminder, error)
+
+	// UpsertDueReminder stores the reminder, replacing the one of the same item if any.
+	UpsertDueReminder(reminder DueReminder) error
+
+	// DeleteDueReminder removes the reminder of a checklist item, if any.
+	DeleteDueReminder(playbookRunID, checklistItemID string) error
+
+	// GetPendingDueReminders returns up to limit reminders not sent yet whose time is at or
+	// before now, in millis, the earliest first.
+	GetPendingDueReminders(now int64, limit int) ([]DueReminder, error)
+
+	// ClaimDueReminder marks the reminder as sent at sentAt, unless it was sent, snoozed or
+	// moved since it was read. Returns whether this call claimed it.
+	ClaimDueReminder(reminder DueReminder, sentAt int64) (bool, error)
+}
+
+// DueReminderScheduler DMs the assignees of checklist items shortly before the items are due, as
+// configured by DueReminderMinutes.
+//
+// Reminders are stored in the database and sent from a cluster job, so only one node in the
+// cluster sends them and they survive restarts of the plugin. Every reminder is claimed before it
+// is sent, so that it is sent once even if it is snoozed meanwhile.
+type DueReminderScheduler struct {
+	store         DueReminderStore
+	runStore      PlaybookRunStore
+	configService config.Service
+	poster        bot.Poster
+	pluginAPI     *pluginapi.Client
+	job           *cluster.Job
+}
+
+// NewDueReminderScheduler creates a new DueReminderScheduler. Call Start to begin sending reminders.
+func NewDueReminderScheduler(store DueReminderStore, runStore PlaybookRunStore, configService config.Service, poster bot.Poster, pluginAPI *pluginapi.Client) *DueReminderScheduler {
+	return &DueReminderScheduler{
+		store:         store,
+		runStore:      runStore,
+		configService: configService,
+		poster:        poster,
+		pluginAPI:     pluginAPI,
+	}
+}
+
+// Start schedules the polling job. Reminders that came due while the plugin was stopped are sent
+// on the first poll after it starts again.
+func (s *DueReminderScheduler) Start(api cluster.JobPluginAPI) error {
+	job, err := cluster.Schedule(api, dueReminderJobKey, cluster.MakeWaitForInterval(DueReminderPollInterval), s.poll)
+	if err != nil {
+		return errors.Wrap(err, "failed to schedule the due reminder job")
+	}
+	s.job = job
+
+	return nil
+}
+
+// Stop stops sending reminders.
+func (s *DueReminderScheduler) Stop() error {
+	if s.job == nil {
+		return nil
+	}
+
+	return s.job.Close()
+}
+
+// Sync schedules, moves or removes the reminder of item after a change to it.
+func (s *DueReminderScheduler) Sync(playbookRunID string, item ChecklistItem) error {
+	if item.ID == "" {
+		return nil
+	}
+
+	existing, err := s.store.GetDueReminder(playbookRunID, item.ID)
+	var current *DueReminder
+	if err == nil {
+		current = &existing
+	} else if !errors.Is(err, ErrNotFound) {
+		return errors.Wrap(err, "failed to get due reminder")
+	}
+
+	now := model.GetMillis()
+	reminder, changed := nextDueReminder(current, playbookRunID, item, s.remindBefore(), now)
+	if reminder == nil {
+		if current == nil {
+			return nil
+		}
+		return s.store.DeleteDueReminder(playbookRunID, item.ID)
+	}
+	if !changed {
+		return nil
+	}
+
+	return s.store.UpsertDueReminder(*reminder)
+}
+
+// Snooze sends the reminder of a checklist item again after duration. Only the user reminded can
+// snooze it. Returns ErrNotFound if the item has no reminder, ErrNoPermissions if userID isn't
+// the user reminded and ErrInvalidSnooze for invalid durations.
+func (s *DueReminderScheduler) Snooze(playbookRunID, checklistItemID, userID string, duration time.Duration) (DueReminder, error) {
+	if duration <= 0 || duration > MaxDueReminderSnooze {
+		return DueReminder{}, errors.Wrapf(ErrInvalidSnooze, "cannot snooze for %s", duration)
+	}
+
+	reminder, err := s.store.GetDueReminder(playbookRunID, checklistItemID)
+	if err != nil {
+		return DueReminder{}, err
+	}
+
+	if reminder.UserID != userID {
+		return DueReminder{}, errors.Wrap(ErrNoPermissions, "only the assignee can snooze the reminder")
+	}
+
+	now := model.GetMillis()
+	reminder.RemindAt = now + duration.Milliseconds()
+	reminder.SentAt = 0
+	reminder.UpdateAt = now
+	if err = s.store.UpsertDueReminder(reminder); err != nil {
+		return DueReminder{}, errors.Wrap(err, "failed to snooze due reminder")
+	}
+
+	return reminder, nil
+}
+
+func (s *DueReminderScheduler) remindBefore() time.Duration {
+	minutes := s.configService.GetConfiguration().DueReminderMinutes
+	if minutes <= 0 {
+		return DefaultDueReminderBefore
+	}
+
+	return time.Duration(minutes) * time.Minute
+}
+
+func (s *DueReminderScheduler) poll() {
+	now := model.GetMillis()
+	pending, err := s.store.GetPendingDueReminders(now, dueReminderBatchSize)
+	if err != nil {
+		logrus.WithError(err).Error("failed to get the due reminders to send")
+		return
+	}
+
+	for _, reminder := range pending {
+		if err := s.send(reminder, now); err != nil {
+			logrus.WithError(err).WithFields(logrus.Fields{
+				"playbook_run_id":   reminder.PlaybookRunID,
+				"checklist_item_id": reminder.ChecklistItemID,
+			}).Warn("failed to send due reminder")
+		}
+	}
+}
+
+// send DMs the reminder, unless it went stale: reminders of items that are not due later anymore,
+// or that the user reminded isn't assigned to anymore, are removed instead.
+func (s *DueReminderScheduler) send(reminder DueReminder, now int64) error {
+	playbookRun, err := s.runStore.GetPlaybookRun(reminder.PlaybookRunID)
+	if errors.Is(err, ErrNotFound) {
+		return s.store.DeleteDueReminder(reminder.PlaybookRunID, reminder.ChecklistItemID)
+	} else if err != nil {
+		return errors.Wrap(err, "failed to get playbook run")
+	}
+
+	var item *ChecklistItem
+	for i := range playbookRun.Checklists {
+		for j := range playbookRun.Checklists[i].Items {
+			if playbookRun.Checklists[i].Items[j].ID == reminder.ChecklistItemID {
+				item = &playbookRun.Checklists[i].Items[j]
+			}
+		}
+	}
+
+	active := playbookRun.CurrentStatus == StatusInProgress || playbookRun.CurrentStatus == StatusPaused
+	if !active || item == nil || item.AssigneeID != reminder.UserID || item.DueDate != reminder.DueDate || !dueReminderApplies(*item, now) {
+		return s.store.DeleteDueReminder(reminder.PlaybookRunID, reminder.ChecklistItemID)
+	}
+
+	claimed, err := s.store.ClaimDueReminder(reminder, now)
+	if err != nil {
+		return errors.Wrap(err, "failed to claim due reminder")
+	}
+	if !claimed {
+		return nil
+	}
+
+	siteURL := s.pluginAPI.Configuration.GetConfig().ServiceSettings.SiteURL
+	if siteURL == nil {
+		return errors.New("cannot send due reminder, please set siteURL")
+	}
+
+	T := userTranslations(s.pluginAPI, reminder.UserID)
+	post := &model.Post{
+		Message: T("app.user.due_reminder", map[string]interface{}{
+			"ItemTitle": stripmd.Strip(item.Title),
+			"RunName":   playbookRun.Name,
+			"RunURL":    getRunDetailsURL(*siteURL, playbookRun.ID),
+			"DueIn":     timeutils.DurationString(timeutils.GetTimeForMillis(now), timeutils.GetTimeForMillis(item.DueDate)),
+		}),
+	}
+	model.ParseSlackAttachment(post, []*model.SlackAttachment{{Actions: s.snoozeActions(reminder, T)}})
+
+	if err = s.poster.DM(reminder.UserID, post); err != nil {
+		return errors.Wrap(err, "failed to DM due reminder")
+	}
+
+	return nil
+}
+
+func (s *DueReminderScheduler) snoozeActions(reminder DueReminder, T i18n.TranslateFunc) []*model.PostAction {
+	actions := make([]*model.PostAction, 0, len(dueReminderSnoozeOptions))
+	for _, option := range dueReminderSnoozeOptions {
+		actions = append(actions, &model.PostAction{
+			Type: model.PostActionTypeButton,
+			Name: T(option.nameID),
+			Integration: &model.PostActionIntegration{
+				URL: fmt.Sprintf("/plugins/%s/api/v0/due-reminders/%s/%s/snooze",
+					s.configService.GetManifest().Id,
+					reminder.PlaybookRunID,
+					reminder.ChecklistItemID),
+				Context: map[string]interface{}{
+					"snooze_minutes": int(option.duration.Minutes()),
+				},
+			},
+		})
+	}
+
+	return actions
+}
+
+// dueReminderApplies is true if item is open, assigned and due after now, in millis.
+func dueReminderApplies(item ChecklistItem, now int64) bool {
+	return !item.Hidden && item.State == ChecklistItemStateOpen && item.AssigneeID != "" && item.DueDate > now
+}
+
+// nextDueReminder returns the reminder item should have at now, in millis, given its current
+// reminder, if any, and whether it differs from the current one. Returns nil if item should
+// not have a reminder.
+//
+// The reminder follows reassignments of the item: a pending reminder is moved as is, snoozed or
+// not, while a reminder already sent is sent again to the new assignee.
+func nextDueReminder(current *DueReminder, playbookRunID string, item ChecklistItem, before time.Duration, now int64) (*DueReminder, bool) {
+	if !dueReminderApplies(item, now) {
+		return nil, current != nil
+	}
+
+	remindAt := item.DueDate - before.Milliseconds()
+	if remindAt < now {
+		remindAt = now
+	}
+
+	if current != nil && current.DueDate == item.DueDate {
+		if current.UserID == item.AssigneeID {
+			return current, false
+		}
+
+		moved := *current
+		moved.UserID = item.AssigneeID
+		moved.UpdateAt = now
+		if moved.SentAt != 0 {
+			moved.SentAt = 0
+			moved.RemindAt = remindAt
+		}
+		return &moved, true
+	}
+
+	reminder := &DueReminder{
+		PlaybookRunID:   playbookRunID,
+		ChecklistItemID: item.ID,
+		UserID:          item.AssigneeID,
+		DueDate:         item.DueDate,
+		RemindAt:        remindAt,
+		CreateAt:        now,
+		UpdateAt:        now,
+	}
+	if current != nil {
+		reminder.CreateAt = current.CreateAt
+	}
+
+	return reminder, true
+}
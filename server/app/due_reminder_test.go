@@ -0,0 +1,104 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextDueReminder(t *testing.T) {
+	const (
+		runID = "run"
+		now   = int64(10 * 3600000)
+		hour  = int64(3600000)
+	)
+
+	openItem := func(assigneeID string, dueDate int64) ChecklistItem {
+		return ChecklistItem{
+			ID:         "item",
+			State:      ChecklistItemStateOpen,
+			AssigneeID: assigneeID,
+			DueDate:    dueDate,
+		}
+	}
+
+	t.Run("new reminder, the configured time before the due date", func(t *testing.T) {
+		reminder, changed := nextDueReminder(nil, runID, openItem("user", now+3*hour), time.Hour, now)
+		require.True(t, changed)
+		require.Equal(t, &DueReminder{
+			PlaybookRunID:   runID,
+			ChecklistItemID: "item",
+			UserID:          "user",
+			DueDate:         now + 3*hour,
+			RemindAt:        now + 2*hour,
+			CreateAt:        now,
+			UpdateAt:        now,
+		}, reminder)
+	})
+
+	t.Run("item due sooner than the configured time is reminded right away", func(t *testing.T) {
+		reminder, changed := nextDueReminder(nil, runID, openItem("user", now+hour/2), time.Hour, now)
+		require.True(t, changed)
+		require.Equal(t, now, reminder.RemindAt)
+	})
+
+	t.Run("items without a reminder", func(t *testing.T) {
+		for name, item := range map[string]ChecklistItem{
+			"unassigned":  openItem("", now+3*hour),
+			"no due date": openItem("user", 0),
+			"overdue":     openItem("user", now-hour),
+			"done":        {ID: "item", State: ChecklistItemStateClosed, AssigneeID: "user", DueDate: now + 3*hour},
+			"skipped":     {ID: "item", State: ChecklistItemStateSkipped, AssigneeID: "user", DueDate: now + 3*hour},
+			"hidden":      {ID: "item", State: ChecklistItemStateOpen, AssigneeID: "user", DueDate: now + 3*hour, Hidden: true},
+		} {
+			t.Run(name, func(t *testing.T) {
+				reminder, changed := nextDueReminder(nil, runID, item, time.Hour, now)
+				require.Nil(t, reminder)
+				require.False(t, changed)
+
+				existing := &DueReminder{PlaybookRunID: runID, ChecklistItemID: "item", UserID: "user", DueDate: now + 3*hour, RemindAt: now + 2*hour}
+				reminder, changed = nextDueReminder(existing, runID, item, time.Hour, now)
+				require.Nil(t, reminder)
+				require.True(t, changed)
+			})
+		}
+	})
+
+	t.Run("unchanged item keeps its snoozed reminder", func(t *testing.T) {
+		existing := &DueReminder{PlaybookRunID: runID, ChecklistItemID: "item", UserID: "user", DueDate: now + 3*hour, RemindAt: now + 150*hour/100, CreateAt: 1}
+		reminder, changed := nextDueReminder(existing, runID, openItem("user", now+3*hour), time.Hour, now)
+		require.False(t, changed)
+		require.Equal(t, existing, reminder)
+	})
+
+	t.Run("reassigning moves a pending reminder to the new assignee", func(t *testing.T) {
+		existing := &DueReminder{PlaybookRunID: runID, ChecklistItemID: "item", UserID: "user", DueDate: now + 3*hour, RemindAt: now + 150*hour/100, CreateAt: 1}
+		reminder, changed := nextDueReminder(existing, runID, openItem("other", now+3*hour), time.Hour, now)
+		require.True(t, changed)
+		require.Equal(t, "other", reminder.UserID)
+		require.Equal(t, existing.RemindAt, reminder.RemindAt)
+		require.Equal(t, int64(1), reminder.CreateAt)
+		require.Equal(t, now, reminder.UpdateAt)
+		require.Equal(t, "user", existing.UserID)
+	})
+
+	t.Run("reassigning after the reminder was sent reminds the new assignee", func(t *testing.T) {
+		existing := &DueReminder{PlaybookRunID: runID, ChecklistItemID: "item", UserID: "user", DueDate: now + hour/2, RemindAt: now - hour/2, SentAt: now - hour/2}
+		reminder, changed := nextDueReminder(existing, runID, openItem("other", now+hour/2), time.Hour, now)
+		require.True(t, changed)
+		require.Equal(t, "other", reminder.UserID)
+		require.Equal(t, now, reminder.RemindAt)
+		require.Zero(t, reminder.SentAt)
+	})
+
+	t.Run("changing the due date reschedules the reminder", func(t *testing.T) {
+		existing := &DueReminder{PlaybookRunID: runID, ChecklistItemID: "item", UserID: "user", DueDate: now + hour/2, RemindAt: now - hour/2, SentAt: now - hour/2, CreateAt: 1}
+		reminder, changed := nextDueReminder(existing, runID, openItem("user", now+5*hour), time.Hour, now)
+		require.True(t, changed)
+		require.Equal(t, now+5*hour, reminder.DueDate)
+		require.Equal(t, now+4*hour, reminder.RemindAt)
+		require.Zero(t, reminder.SentAt)
+		require.Equal(t, int64(1), reminder.CreateAt)
+	})
+}
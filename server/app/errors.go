@@ -11,6 +11,9 @@ var ErrChannelDisplayNameInvalid = errors.New("channel name is invalid or too lo
 // ErrPlaybookRunNotActive occurs when trying to run a command on a playbook run that has ended.
 var ErrPlaybookRunNotActive = errors.New("already ended")
 
+// ErrPlaybookRunNotFinished occurs when restarting a playbook run that is not finished.
+var ErrPlaybookRunNotFinished = errors.New("not finished")
+
 // ErrPlaybookRunActive occurs when trying to run a command on a playbook run that is active.
 var ErrPlaybookRunActive = errors.New("already active")
 
@@ -19,3 +22,101 @@ var ErrMalformedPlaybookRun = errors.New("malformed")
 
 // ErrDuplicateEntry occurs when failing to insert because the entry already existed.
 var ErrDuplicateEntry = errors.New("duplicate entry")
+
+// ErrMalformedScheduledRun occurs when a scheduled run is not valid.
+var ErrMalformedScheduledRun = errors.New("malformed scheduled run")
+
+// ErrUnsupportedExportFormat occurs when exporting a playbook run to an unknown format.
+var ErrUnsupportedExportFormat = errors.New("unsupported export format")
+
+// ErrInvalidChecklistItemOrder occurs when reordering a checklist with a list of item IDs that
+// does not match its items.
+var ErrInvalidChecklistItemOrder = errors.New("invalid checklist item order")
+
+// ErrPlaybookArchived occurs when trying to start a run from an archived playbook.
+var ErrPlaybookArchived = errors.New("playbook is archived")
+
+// ErrPlaybookNotTemplate occurs when copying from a playbook that is not a template.
+var ErrPlaybookNotTemplate = errors.New("playbook is not a template")
+
+// ErrInvalidCoOwner occurs when adding the owner of a playbook run as one of its co-owners.
+var ErrInvalidCoOwner = errors.New("invalid co-owner")
+
+// ErrInvalidPropertyValue occurs when setting a run property to a value that does not match its
+// definition.
+var ErrInvalidPropertyValue = errors.New("invalid property value")
+
+// ErrInvalidActivityCursor occurs when paginating the activity of a run from a cursor that was
+// not returned by a previous page.
+var ErrInvalidActivityCursor = errors.New("invalid activity cursor")
+
+// ErrChannelHasActiveRun occurs when linking a new run to a channel that already has an unfinished
+// run, without allowing multiple runs in the channel.
+var ErrChannelHasActiveRun = errors.New("channel already has an active run")
+
+// ErrInvalidRunTag occurs when tagging a run with an empty tag, a tag that is too long or a tag
+// with a comma or a slash.
+var ErrInvalidRunTag = errors.New("invalid run tag")
+
+// ErrStatusUpdateTemplateNotFound occurs when updating the status of a run from a template the
+// run does not have.
+var ErrStatusUpdateTemplateNotFound = errors.New("status update template not found")
+
+// ErrRetrospectiveRequired occurs when finishing a run whose playbook requires a retrospective
+// before the retrospective of the run is published.
+var ErrRetrospectiveRequired = errors.New("the retrospective must be published before finishing this run")
+
+// ErrInvalidBroadcastChannel occurs when retrying the broadcast of a status update to a channel
+// that is not a broadcast channel of the run.
+var ErrInvalidBroadcastChannel = errors.New("invalid broadcast channel")
+
+// ErrNoStatusUpdate occurs when retrying the broadcast of the latest status update of a run that
+// has none.
+var ErrNoStatusUpdate = errors.New("run has no status update")
+
+// ErrIdempotencyKeyInUse occurs when creating a run with an idempotency key that another request
+// is still creating a run with.
+var ErrIdempotencyKeyInUse = errors.New("idempotency key in use")
+
+// ErrInvalidRunMerge occurs when merging a run into itself or into a run of another team.
+var ErrInvalidRunMerge = errors.New("invalid run merge")
+
+// ErrInvalidSnooze occurs when snoozing a due reminder for no time, or for longer than
+// MaxDueReminderSnooze.
+var ErrInvalidSnooze = errors.New("invalid snooze duration")
+
+// ErrInvalidStatsInterval occurs when a time series is requested with an interval that is not
+// positive, or too short for the period it covers.
+var ErrInvalidStatsInterval = errors.New("invalid stats interval")
+
+// ErrInvalidParticipantRole occurs when giving a role that doesn't exist, giving a role to a user
+// who isn't a participant of the run, or making its owner or a co-owner a viewer.
+var ErrInvalidParticipantRole = errors.New("invalid participant role")
+
+// ErrInvalidDefaultAssignee occurs when the default assignee of a checklist item of a playbook is
+// not a valid user ID, or not a member of the playbook's team.
+var ErrInvalidDefaultAssignee = errors.New("invalid default assignee")
+
+// ErrInvalidChecklistItemDependency occurs when a checklist item of a playbook depends on itself,
+// on an item that doesn't exist, or on an item whose ID is not unique.
+var ErrInvalidChecklistItemDependency = errors.New("invalid checklist item dependency")
+
+// ErrChecklistItemDependencyCycle occurs when the dependencies of the checklist items of a
+// playbook form a cycle, so that none of the items in it could ever be done.
+var ErrChecklistItemDependencyCycle = errors.New("checklist item dependency cycle")
+
+// ErrChecklistItemBlocked occurs when checking off a checklist item before the item it depends on
+// is done, in a run enforcing the dependencies of its items.
+var ErrChecklistItemBlocked = errors.New("checklist item is blocked by an incomplete dependency")
+
+// ErrInvalidKeyEvent occurs when adding a key event to the timeline of a run without a summary,
+// with a summary that is too long, or linked to a post outside of the run's channel.
+var ErrInvalidKeyEvent = errors.New("invalid key event")
+
+// ErrTimelineEventNotUserCreated occurs when removing, as a key event, a timeline event that was
+// recorded by the run instead of being added by a user.
+var ErrTimelineEventNotUserCreated = errors.New("timeline event was not created by a user")
+
+// ErrMalformedSavedFilter occurs when a saved filter of the run list has no name, a name that is
+// too long, or options that are not valid.
+var ErrMalformedSavedFilter = errors.New("malformed saved filter")
@@ -55,6 +55,24 @@ func generateMetricsExport(metrics []PlaybookMetricConfig) []interface{} {
 	return exported
 }
 
+func generatePropertyDefinitionsExport(definitions []PropertyDefinition) []interface{} {
+	exported := make([]interface{}, 0, len(definitions))
+	for _, definition := range definitions {
+		exported = append(exported, getFieldsForExport(definition))
+	}
+
+	return exported
+}
+
+func generateStatusUpdateTemplatesExport(templates []StatusUpdateTemplate) []interface{} {
+	exported := make([]interface{}, 0, len(templates))
+	for _, template := range templates {
+		exported = append(exported, getFieldsForExport(template))
+	}
+
+	return exported
+}
+
 // GeneratePlaybookExport returns a playbook in export format.
 // Fields marked with the stuct tag "export" are included using the given string.
 func GeneratePlaybookExport(playbook Playbook) ([]byte, error) {
@@ -62,6 +80,8 @@ func GeneratePlaybookExport(playbook Playbook) ([]byte, error) {
 	export["version"] = CurrentPlaybookExportVersion
 	export["checklists"] = generateChecklistExport(playbook.Checklists)
 	export["metrics"] = generateMetricsExport(playbook.Metrics)
+	export["property_definitions"] = generatePropertyDefinitionsExport(playbook.PropertyDefinitions)
+	export["status_update_templates"] = generateStatusUpdateTemplatesExport(playbook.StatusUpdateTemplates)
 
 	result, err := json.MarshalIndent(export, "", "    ")
 	if err != nil {
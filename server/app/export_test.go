@@ -76,4 +76,5 @@ func TestPlaybookDefinesExports(t *testing.T) {
 	definesExports(t, Playbook{})
 	definesExports(t, Checklist{})
 	definesExports(t, ChecklistItem{})
+	definesExports(t, StatusUpdateTemplate{})
 }
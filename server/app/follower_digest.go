@@ -0,0 +1,248 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	pluginapi "github.com/mattermost/mattermost-plugin-api"
+	"github.com/mattermost/mattermost-plugin-api/cluster"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/i18n"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/mattermost/mattermost-plugin-playbooks/server/bot"
+)
+
+const (
+	// FollowerDigestInterval is how often the followers of runs get their digest. Each digest
+	// covers the period since the previous one.
+	FollowerDigestInterval = 24 * time.Hour
+
+	followerDigestJobKey = "IR_FollowerDigest"
+)
+
+// FollowerDigest DMs the followers of runs a daily summary of the status changes and newly
+// overdue checklist items of the runs they follow.
+//
+// The digest is sent from a cluster job, so only one node in the cluster sends it, and the time
+// of the last digest survives restarts of the plugin. Followers are read when the digest is sent,
+// so users that unfollowed a run don't get news of it anymore.
+type FollowerDigest struct {
+	store         PlaybookRunStore
+	userInfoStore UserInfoStore
+	permissions   *PermissionsService
+	poster        bot.Poster
+	pluginAPI     *pluginapi.Client
+	job           *cluster.Job
+}
+
+// NewFollowerDigest creates a new FollowerDigest. Call Start to begin sending digests.
+func NewFollowerDigest(store PlaybookRunStore, userInfoStore UserInfoStore, permissions *PermissionsService, poster bot.Poster, pluginAPI *pluginapi.Client) *FollowerDigest {
+	return &FollowerDigest{
+		store:         store,
+		userInfoStore: userInfoStore,
+		permissions:   permissions,
+		poster:        poster,
+		pluginAPI:     pluginAPI,
+	}
+}
+
+// Start schedules the digest job.
+func (d *FollowerDigest) Start(api cluster.JobPluginAPI) error {
+	job, err := cluster.Schedule(api, followerDigestJobKey, cluster.MakeWaitForInterval(FollowerDigestInterval), d.send)
+	if err != nil {
+		return errors.Wrap(err, "failed to schedule the follower digest job")
+	}
+	d.job = job
+
+	return nil
+}
+
+// Stop stops sending digests.
+func (d *FollowerDigest) Stop() error {
+	if d.job == nil {
+		return nil
+	}
+
+	return d.job.Close()
+}
+
+// send sends the digests of the changes since the last digest, so that no change is missed when
+// the job runs late, or is skipped while the plugin is stopped. The first digest covers the last
+// FollowerDigestInterval.
+func (d *FollowerDigest) send() {
+	now := model.GetMillis()
+
+	since, err := d.store.GetFollowerDigestSentAt()
+	if err != nil {
+		logrus.WithError(err).Error("failed to get the time the last follower digest was sent at")
+		return
+	}
+	if since == 0 {
+		since = now - FollowerDigestInterval.Milliseconds()
+	}
+
+	if err = d.SendDigests(since, now); err != nil {
+		logrus.WithError(err).Error("failed to send the follower digests")
+		return
+	}
+
+	if err = d.store.SetFollowerDigestSentAt(now); err != nil {
+		logrus.WithError(err).Error("failed to store the time the follower digest was sent at")
+	}
+}
+
+// SendDigests DMs every follower the changes, between since and until (in millis), of the runs
+// they follow and are still allowed to view. Users that disabled the daily digest and users
+// without changes in the runs they follow get nothing.
+func (d *FollowerDigest) SendDigests(since, until int64) error {
+	followers, err := d.store.GetRunFollowersSince(since)
+	if err != nil {
+		return err
+	}
+
+	siteURL := d.pluginAPI.Configuration.GetConfig().ServiceSettings.SiteURL
+	if siteURL == nil {
+		return errors.New("cannot send the follower digests, please set siteURL")
+	}
+
+	runs := make(map[string]*PlaybookRun)
+	getRun := func(playbookRunID string) *PlaybookRun {
+		if run, ok := runs[playbookRunID]; ok {
+			return run
+		}
+
+		run, err := d.store.GetPlaybookRun(playbookRunID)
+		if err != nil {
+			logrus.WithError(err).WithField("playbook_run_id", playbookRunID).Warn("failed to get followed run for the digest")
+		}
+		runs[playbookRunID] = run
+		return run
+	}
+
+	// Followers are ordered by user, so each user's digest is complete once the next user shows up.
+	var changes []followedRunChanges
+	for i, follower := range followers {
+		run := getRun(follower.PlaybookRunID)
+		if run != nil && d.permissions.RunView(follower.UserID, run.ID) == nil {
+			if runChanges, changed := getFollowedRunChanges(run, since, until); changed {
+				changes = append(changes, runChanges)
+			}
+		}
+
+		if i == len(followers)-1 || followers[i+1].UserID != follower.UserID {
+			if len(changes) > 0 {
+				d.dmDigest(follower.UserID, changes, *siteURL)
+			}
+			changes = nil
+		}
+	}
+
+	return nil
+}
+
+func (d *FollowerDigest) dmDigest(userID string, changes []followedRunChanges, siteURL string) {
+	logger := logrus.WithField("user_id", userID)
+
+	info, err := d.userInfoStore.Get(userID)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		logger.WithError(err).Warn("failed to get user info for the follower digest")
+		return
+	}
+	if info.DisableDailyDigest {
+		return
+	}
+
+	user, err := d.pluginAPI.User.Get(userID)
+	if err != nil {
+		logger.WithError(err).Warn("failed to get user for the follower digest")
+		return
+	}
+
+	if err := d.poster.DM(userID, &model.Post{Message: buildFollowedRunsDigestMessage(changes, user.Locale, siteURL)}); err != nil {
+		logger.WithError(err).Warn("failed to DM the follower digest")
+	}
+}
+
+// followedRunChanges are the changes of a run, over the period covered by a digest.
+type followedRunChanges struct {
+	run           *PlaybookRun
+	statusUpdates int
+	statusChanges []timelineEventType
+	overdueItems  []ChecklistItem
+	checklists    []string
+}
+
+// getFollowedRunChanges returns the status updates, the status changes and the checklist items
+// that became overdue between since and until (in millis). Returns false if there were none.
+func getFollowedRunChanges(run *PlaybookRun, since, until int64) (followedRunChanges, bool) {
+	changes := followedRunChanges{run: run}
+
+	for _, event := range run.TimelineEvents {
+		if event.EventAt <= since || event.EventAt > until {
+			continue
+		}
+
+		switch event.EventType {
+		case StatusUpdated:
+			changes.statusUpdates++
+		case RunFinished, RunRestored, RunPaused, RunResumed:
+			changes.statusChanges = append(changes.statusChanges, event.EventType)
+		}
+	}
+
+	for _, checklist := range run.Checklists {
+		for _, item := range checklist.Items {
+			if item.Hidden || item.State == ChecklistItemStateClosed || item.State == ChecklistItemStateSkipped {
+				continue
+			}
+			if item.DueDate > since && item.DueDate <= until {
+				changes.overdueItems = append(changes.overdueItems, item)
+				changes.checklists = append(changes.checklists, checklist.Title)
+			}
+		}
+	}
+
+	changed := changes.statusUpdates > 0 || len(changes.statusChanges) > 0 || len(changes.overdueItems) > 0
+	return changes, changed
+}
+
+func buildFollowedRunsDigestMessage(changes []followedRunChanges, locale, siteURL string) string {
+	T := i18n.GetUserTranslations(locale)
+
+	var msg strings.Builder
+	msg.WriteString("##### ")
+	msg.WriteString(T("app.user.digest.followed_runs.heading"))
+	msg.WriteString("\n")
+	msg.WriteString(T("app.user.digest.followed_runs.num_changed", len(changes)))
+	msg.WriteString("\n")
+
+	for _, runChanges := range changes {
+		msg.WriteString(fmt.Sprintf("- [%s](%s)\n", runChanges.run.Name, getRunDetailsURL(siteURL, runChanges.run.ID)))
+
+		for _, statusChange := range runChanges.statusChanges {
+			var statusChangeMsg string
+			switch statusChange {
+			case RunFinished:
+				statusChangeMsg = T("app.user.digest.followed_runs.run_finished")
+			case RunRestored:
+				statusChangeMsg = T("app.user.digest.followed_runs.run_restored")
+			case RunPaused:
+				statusChangeMsg = T("app.user.digest.followed_runs.run_paused")
+			case RunResumed:
+				statusChangeMsg = T("app.user.digest.followed_runs.run_resumed")
+			}
+			msg.WriteString(fmt.Sprintf("  - %s\n", statusChangeMsg))
+		}
+		if runChanges.statusUpdates > 0 {
+			msg.WriteString(fmt.Sprintf("  - %s\n", T("app.user.digest.followed_runs.num_status_updates", runChanges.statusUpdates)))
+		}
+		for i, item := range runChanges.overdueItems {
+			msg.WriteString(fmt.Sprintf("  - %s: %s **`%s`**\n", runChanges.checklists[i], item.Title, T("app.user.digest.followed_runs.overdue")))
+		}
+	}
+
+	return msg.String()
+}
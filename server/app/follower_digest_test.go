@@ -0,0 +1,55 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetFollowedRunChanges(t *testing.T) {
+	since, until := int64(100000), int64(200000)
+
+	run := &PlaybookRun{
+		ID: "run_id",
+		TimelineEvents: []TimelineEvent{
+			{EventType: StatusUpdated, EventAt: since},
+			{EventType: StatusUpdated, EventAt: since + 1},
+			{EventType: StatusUpdated, EventAt: until},
+			{EventType: RunPaused, EventAt: since + 2},
+			{EventType: OwnerChanged, EventAt: since + 3},
+			{EventType: RunFinished, EventAt: until + 1},
+		},
+		Checklists: []Checklist{
+			{
+				Title: "Triage",
+				Items: []ChecklistItem{
+					{Title: "newly overdue", DueDate: since + 10},
+					{Title: "already overdue", DueDate: since - 10},
+					{Title: "not due yet", DueDate: until + 10},
+					{Title: "no due date"},
+					{Title: "done", DueDate: since + 10, State: ChecklistItemStateClosed},
+					{Title: "skipped", DueDate: since + 10, State: ChecklistItemStateSkipped},
+					{Title: "hidden", DueDate: since + 10, Hidden: true},
+					{Title: "in progress", DueDate: until, State: ChecklistItemStateInProgress},
+				},
+			},
+		},
+	}
+
+	changes, changed := getFollowedRunChanges(run, since, until)
+	require.True(t, changed)
+	require.Equal(t, 2, changes.statusUpdates)
+	require.Equal(t, []timelineEventType{RunPaused}, changes.statusChanges)
+
+	titles := []string{}
+	for _, item := range changes.overdueItems {
+		titles = append(titles, item.Title)
+	}
+	require.Equal(t, []string{"newly overdue", "in progress"}, titles)
+	require.Equal(t, []string{"Triage", "Triage"}, changes.checklists)
+
+	t.Run("no changes", func(t *testing.T) {
+		_, changed := getFollowedRunChanges(run, until+100, until+200)
+		require.False(t, changed)
+	})
+}
@@ -0,0 +1,252 @@
+package app
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+)
+
+// PlaybookImportFieldError describes a field of a playbook import that does not match the export format.
+type PlaybookImportFieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// PlaybookImportError is returned when a playbook import does not match the export format.
+type PlaybookImportError struct {
+	Errors []PlaybookImportFieldError
+}
+
+func (e *PlaybookImportError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, fieldErr := range e.Errors {
+		messages = append(messages, fmt.Sprintf("%s: %s", fieldErr.Field, fieldErr.Message))
+	}
+
+	return "invalid playbook import: " + strings.Join(messages, "; ")
+}
+
+// playbookImportMigrations upgrade an import document from the version used as key to the next
+// one, so that documents exported by older versions of the plugin can still be imported.
+var playbookImportMigrations = map[int]func(document map[string]interface{}){}
+
+var (
+	nullIntType         = reflect.TypeOf(null.Int{})
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// ParsePlaybookImport validates data against the export format and returns the playbook it
+// describes. The format of each version is the one given by the "export" struct tags: unknown
+// fields and values of the wrong type are rejected, reporting every offending field.
+func ParsePlaybookImport(data []byte) (Playbook, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var document map[string]interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return Playbook{}, errors.Wrap(err, "unable to decode playbook import")
+	}
+
+	rawVersion, ok := document["version"]
+	if !ok {
+		return Playbook{}, &PlaybookImportError{Errors: []PlaybookImportFieldError{{Field: "version", Message: "required field is missing"}}}
+	}
+	delete(document, "version")
+
+	version, err := parseImportVersion(rawVersion)
+	if err != nil {
+		return Playbook{}, &PlaybookImportError{Errors: []PlaybookImportFieldError{{Field: "version", Message: err.Error()}}}
+	}
+
+	for ; version < CurrentPlaybookExportVersion; version++ {
+		if migrate, ok := playbookImportMigrations[version]; ok {
+			migrate(document)
+		}
+	}
+
+	var playbook Playbook
+	importDecoder := &playbookImportDecoder{}
+	importDecoder.decode("", document, reflect.ValueOf(&playbook).Elem())
+	if len(importDecoder.errors) > 0 {
+		return Playbook{}, &PlaybookImportError{Errors: importDecoder.errors}
+	}
+
+	return playbook, nil
+}
+
+func parseImportVersion(rawVersion interface{}) (int, error) {
+	number, ok := rawVersion.(json.Number)
+	if !ok {
+		return 0, errors.Errorf("expected integer, got %s", importTypeName(rawVersion))
+	}
+
+	version, err := number.Int64()
+	if err != nil {
+		return 0, errors.Errorf("expected integer, got %s", number)
+	}
+
+	if version < 1 || version > CurrentPlaybookExportVersion {
+		return 0, errors.Errorf("unsupported version %d, expected a version between 1 and %d", version, CurrentPlaybookExportVersion)
+	}
+
+	return int(version), nil
+}
+
+// playbookImportDecoder decodes an import document into a playbook, collecting the errors of
+// every field instead of stopping at the first one.
+type playbookImportDecoder struct {
+	errors []PlaybookImportFieldError
+}
+
+func (d *playbookImportDecoder) fail(path, format string, args ...interface{}) {
+	d.errors = append(d.errors, PlaybookImportFieldError{Field: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (d *playbookImportDecoder) mismatch(path, expected string, value interface{}) {
+	d.fail(path, "expected %s, got %s", expected, importTypeName(value))
+}
+
+func (d *playbookImportDecoder) decode(path string, value interface{}, target reflect.Value) {
+	if target.Type() == nullIntType {
+		if value == nil {
+			return
+		}
+		number, ok := value.(json.Number)
+		if !ok {
+			d.mismatch(path, "integer or null", value)
+			return
+		}
+		i, err := number.Int64()
+		if err != nil {
+			d.fail(path, "expected integer or null, got %s", number)
+			return
+		}
+		target.Set(reflect.ValueOf(null.IntFrom(i)))
+		return
+	}
+
+	if reflect.PtrTo(target.Type()).Implements(textUnmarshalerType) {
+		text, ok := value.(string)
+		if !ok {
+			d.mismatch(path, "string", value)
+			return
+		}
+		if err := target.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(text)); err != nil {
+			d.fail(path, err.Error())
+		}
+		return
+	}
+
+	switch target.Kind() {
+	case reflect.String:
+		text, ok := value.(string)
+		if !ok {
+			d.mismatch(path, "string", value)
+			return
+		}
+		target.SetString(text)
+
+	case reflect.Bool:
+		b, ok := value.(bool)
+		if !ok {
+			d.mismatch(path, "boolean", value)
+			return
+		}
+		target.SetBool(b)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number, ok := value.(json.Number)
+		if !ok {
+			d.mismatch(path, "integer", value)
+			return
+		}
+		i, err := number.Int64()
+		if err != nil || target.OverflowInt(i) {
+			d.fail(path, "expected integer, got %s", number)
+			return
+		}
+		target.SetInt(i)
+
+	case reflect.Slice:
+		array, ok := value.([]interface{})
+		if !ok {
+			d.mismatch(path, "array", value)
+			return
+		}
+		slice := reflect.MakeSlice(target.Type(), len(array), len(array))
+		for i := range array {
+			d.decode(fmt.Sprintf("%s[%d]", path, i), array[i], slice.Index(i))
+		}
+		target.Set(slice)
+
+	case reflect.Struct:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			d.mismatch(path, "object", value)
+			return
+		}
+
+		fields := exportedFieldIndexes(target.Type())
+		keys := make([]string, 0, len(object))
+		for key := range object {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			fieldPath := key
+			if path != "" {
+				fieldPath = path + "." + key
+			}
+
+			index, ok := fields[key]
+			if !ok {
+				d.fail(fieldPath, "unknown field")
+				continue
+			}
+			d.decode(fieldPath, object[key], target.Field(index))
+		}
+
+	default:
+		d.fail(path, "unsupported field type %s", target.Type())
+	}
+}
+
+// exportedFieldIndexes maps the export names of the fields of structType to their indexes.
+func exportedFieldIndexes(structType reflect.Type) map[string]int {
+	fields := map[string]int{}
+	for i := 0; i < structType.NumField(); i++ {
+		tag := structType.Field(i).Tag.Get("export")
+		if tag != "" && tag != "-" {
+			fields[tag] = i
+		}
+	}
+
+	return fields
+}
+
+func importTypeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
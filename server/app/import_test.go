@@ -0,0 +1,173 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+)
+
+func TestParsePlaybookImport(t *testing.T) {
+	t.Run("round trips the export", func(t *testing.T) {
+		pb := Playbook{
+			ID:                                      "playbook_id",
+			Title:                                   "Testing",
+			Description:                             "A description",
+			TeamID:                                  "team_id",
+			CreateAt:                                23423234,
+			ReminderTimerDefaultSeconds:             3600,
+			StatusUpdateEnabled:                     true,
+			SignalAnyKeywords:                       []string{"outage", "down"},
+			SignalAnyKeywordsEnabled:                true,
+			ChannelMode:                             PlaybookRunLinkExistingChannel,
+			RemoveChannelMemberOnRemovedParticipant: true,
+			Checklists: []Checklist{
+				{
+					ID:    "checklist_id",
+					Title: "checklist 1",
+					Items: []ChecklistItem{
+						{
+							ID:          "item_id",
+							Title:       "This is an item",
+							Description: "It's an item",
+							Command:     "/echo hi",
+							DueDate:     60000,
+							State:       ChecklistItemStateClosed,
+						},
+						{
+							ID:              "dependent_item_id",
+							Title:           "This item waits for the first one",
+							DependsOnItemID: "item_id",
+						},
+					},
+				},
+			},
+			Metrics: []PlaybookMetricConfig{
+				{
+					ID:     "metric_id",
+					Title:  "Title 1",
+					Type:   MetricTypeCurrency,
+					Target: null.IntFrom(147),
+				},
+				{
+					Title: "Title 2",
+					Type:  MetricTypeDuration,
+				},
+			},
+			PropertyDefinitions: []PropertyDefinition{
+				{
+					ID:      "property_definition_id",
+					Name:    "Severity",
+					Type:    PropertyTypeSelect,
+					Options: []string{"low", "critical"},
+				},
+				{
+					Name: "Customer",
+					Type: PropertyTypeText,
+				},
+			},
+			StatusUpdateTemplates: []StatusUpdateTemplate{
+				{
+					ID:       "template_id",
+					Title:    "Mitigated",
+					Template: "The impact is mitigated.",
+				},
+			},
+		}
+
+		output, err := GeneratePlaybookExport(pb)
+		require.NoError(t, err)
+
+		result, err := ParsePlaybookImport(output)
+		require.NoError(t, err)
+
+		// Only the exported fields survive the round trip
+		pb.ID = ""
+		pb.TeamID = ""
+		pb.CreateAt = 0
+		pb.Checklists[0].ID = ""
+		pb.Checklists[0].Items[0].State = ""
+		pb.Metrics[0].ID = ""
+		pb.PropertyDefinitions[0].ID = ""
+		pb.StatusUpdateTemplates[0].ID = ""
+		assert.Equal(t, pb, result)
+
+		// Dependencies between items are kept, and checked again
+		assert.NoError(t, ValidateChecklistItemDependencies(result.Checklists))
+
+		// Imported templates get new IDs
+		require.NoError(t, PrepareStatusUpdateTemplates(result.StatusUpdateTemplates))
+		assert.NotEmpty(t, result.StatusUpdateTemplates[0].ID)
+		assert.Equal(t, "Mitigated", result.StatusUpdateTemplates[0].Title)
+	})
+
+	t.Run("dependencies on missing items are rejected", func(t *testing.T) {
+		result, err := ParsePlaybookImport([]byte(`{"version": 1, "title": "Testing", "checklists": [{"title": "one", "items": [{"id": "item_id", "title": "item", "depends_on_item_id": "missing_id"}]}]}`))
+		require.NoError(t, err)
+		assert.ErrorIs(t, ValidateChecklistItemDependencies(result.Checklists), ErrInvalidChecklistItemDependency)
+	})
+
+	testCases := []struct {
+		name           string
+		input          string
+		expectedErrors []PlaybookImportFieldError
+	}{
+		{
+			name:  "missing version",
+			input: `{"title": "Testing"}`,
+			expectedErrors: []PlaybookImportFieldError{
+				{Field: "version", Message: "required field is missing"},
+			},
+		},
+		{
+			name:  "unsupported version",
+			input: `{"version": 2, "title": "Testing"}`,
+			expectedErrors: []PlaybookImportFieldError{
+				{Field: "version", Message: "unsupported version 2, expected a version between 1 and 1"},
+			},
+		},
+		{
+			name:  "unknown fields",
+			input: `{"version": 1, "id": "playbook_id", "checklists": [{"title": "one", "items": [{"title": "item", "state": "closed"}]}]}`,
+			expectedErrors: []PlaybookImportFieldError{
+				{Field: "checklists[0].items[0].state", Message: "unknown field"},
+				{Field: "id", Message: "unknown field"},
+			},
+		},
+		{
+			name:  "type mismatches",
+			input: `{"version": 1, "title": 3, "status_update_enabled": "yes", "reminder_timer_default_seconds": 1.5, "signal_any_keywords": "outage", "metrics": [{"title": "metric", "target": "high"}]}`,
+			expectedErrors: []PlaybookImportFieldError{
+				{Field: "metrics[0].target", Message: "expected integer or null, got string"},
+				{Field: "reminder_timer_default_seconds", Message: "expected integer, got 1.5"},
+				{Field: "signal_any_keywords", Message: "expected array, got string"},
+				{Field: "status_update_enabled", Message: "expected boolean, got string"},
+				{Field: "title", Message: "expected string, got number"},
+			},
+		},
+		{
+			name:  "invalid channel mode",
+			input: `{"version": 1, "title": "Testing", "channel_mode": "no_channel"}`,
+			expectedErrors: []PlaybookImportFieldError{
+				{Field: "channel_mode", Message: "unknown ChannelPlaybookMode: no_channel"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParsePlaybookImport([]byte(tc.input))
+
+			var importErr *PlaybookImportError
+			require.True(t, errors.As(err, &importErr), "expected a PlaybookImportError, got %v", err)
+			assert.Equal(t, tc.expectedErrors, importErr.Errors)
+		})
+	}
+
+	t.Run("not a JSON object", func(t *testing.T) {
+		_, err := ParsePlaybookImport([]byte(`["title"]`))
+		require.Error(t, err)
+	})
+}
@@ -347,6 +347,68 @@ func (p *PermissionsService) PlaybookList(userID, teamID string) error {
 	return errors.Wrapf(ErrNoPermissions, "user `%s` does not have permission to list playbooks for team `%s`", userID, teamID)
 }
 
+// TeamMembersView checks that userID can view the members of teamID, such as the users assigned
+// to the checklist items of the team's runs and playbooks.
+func (p *PermissionsService) TeamMembersView(userID, teamID string) error {
+	if p.canViewTeam(userID, teamID) {
+		return nil
+	}
+
+	return errors.Wrapf(ErrNoPermissions, "user `%s` does not have permission to view the members of team `%s`", userID, teamID)
+}
+
+// RunTagsView checks that userID can view the tags used by the runs of teamID.
+func (p *PermissionsService) RunTagsView(userID, teamID string) error {
+	if p.canViewTeam(userID, teamID) {
+		return nil
+	}
+
+	return errors.Wrapf(ErrNoPermissions, "user `%s` does not have permission to view the run tags of team `%s`", userID, teamID)
+}
+
+// RunExport checks that userID can export the runs of teamID. Only the runs the user can view are
+// exported.
+func (p *PermissionsService) RunExport(userID, teamID string) error {
+	if p.canViewTeam(userID, teamID) {
+		return nil
+	}
+
+	return errors.Wrapf(ErrNoPermissions, "user `%s` does not have permission to export the runs of team `%s`", userID, teamID)
+}
+
+// SavedFiltersList checks that userID can list and create the saved filters of the run list of
+// teamID.
+func (p *PermissionsService) SavedFiltersList(userID, teamID string) error {
+	if p.canViewTeam(userID, teamID) {
+		return nil
+	}
+
+	return errors.Wrapf(ErrNoPermissions, "user `%s` does not have permission to the saved filters of team `%s`", userID, teamID)
+}
+
+// SavedFilterView checks that userID can view and apply a saved filter: it must be theirs, or be
+// shared with a team they can view.
+func (p *PermissionsService) SavedFilterView(userID string, filter SavedFilter) error {
+	if !p.canViewTeam(userID, filter.TeamID) {
+		return errors.Wrapf(ErrNoPermissions, "user `%s` does not have permission to the saved filters of team `%s`", userID, filter.TeamID)
+	}
+
+	if filter.UserID == userID || filter.Shared {
+		return nil
+	}
+
+	return errors.Wrapf(ErrNoPermissions, "user `%s` does not have permission to view saved filter `%s`", userID, filter.ID)
+}
+
+// SavedFilterManage checks that userID can update and delete a saved filter. Only its creator can.
+func (p *PermissionsService) SavedFilterManage(userID string, filter SavedFilter) error {
+	if filter.UserID == userID {
+		return nil
+	}
+
+	return errors.Wrapf(ErrNoPermissions, "user `%s` does not have permission to modify saved filter `%s`", userID, filter.ID)
+}
+
 func (p *PermissionsService) PlaybookViewWithPlaybook(userID string, playbook Playbook) error {
 	noAccessErr := errors.Wrapf(
 		ErrNoPermissions,
@@ -372,6 +434,25 @@ func (p *PermissionsService) PlaybookViewWithPlaybook(userID string, playbook Pl
 	return noAccessErr
 }
 
+// PlaybookViewTemplate checks that the user can see, and so copy, the given template. Templates
+// are visible from every team, but not to guests outside of the template's team.
+func (p *PermissionsService) PlaybookViewTemplate(userID string, playbook Playbook) error {
+	if !playbook.IsTemplate {
+		return errors.Wrapf(ErrPlaybookNotTemplate, "playbook `%s`", playbook.ID)
+	}
+
+	isGuest, err := IsGuest(userID, p.pluginAPI)
+	if err != nil {
+		return err
+	}
+
+	if !isGuest {
+		return nil
+	}
+
+	return p.PlaybookViewWithPlaybook(userID, playbook)
+}
+
 func (p *PermissionsService) PlaybookMakePrivate(userID string, playbook Playbook) error {
 	if p.hasPermissionsToPlaybook(userID, playbook, model.PermissionPublicPlaybookMakePrivate) {
 		return nil
@@ -406,10 +487,15 @@ func (p *PermissionsService) RunManageProperties(userID, runID string) error {
 }
 
 func (p *PermissionsService) runManagePropertiesWithPlaybookRun(userID string, run *PlaybookRun) error {
-	if run.OwnerUserID == userID {
+	if run.IsOwnerOrCoOwner(userID) {
 		return nil
 	}
 
+	// Viewers only follow the run along
+	if run.IsViewer(userID) {
+		return errors.Wrapf(ErrNoPermissions, "user `%s` is a viewer of run `%s`", userID, run.ID)
+	}
+
 	for _, participantID := range run.ParticipantIDs {
 		if participantID == userID {
 			return nil
@@ -423,6 +509,27 @@ func (p *PermissionsService) runManagePropertiesWithPlaybookRun(userID string, r
 	return errors.Wrapf(ErrNoPermissions, "user `%s` does not have permission to manage run `%s`", userID, run.ID)
 }
 
+// RunRestart checks that userID can restart the finished run: its owner and co-owners, system
+// admins, and those who can manage the playbook it was run from.
+func (p *PermissionsService) RunRestart(userID string, run *PlaybookRun) error {
+	if run.IsOwnerOrCoOwner(userID) || IsSystemAdmin(userID, p.pluginAPI) {
+		return nil
+	}
+
+	if run.PlaybookID != "" {
+		playbook, err := p.playbookService.Get(run.PlaybookID)
+		if err != nil {
+			return errors.Wrapf(err, "Unable to get playbook to determine permissions, playbook id `%s`", run.PlaybookID)
+		}
+
+		if p.PlaybookManageProperties(userID, playbook) == nil {
+			return nil
+		}
+	}
+
+	return errors.Wrapf(ErrNoPermissions, "user `%s` does not have permission to restart run `%s`", userID, run.ID)
+}
+
 func (p *PermissionsService) RunManagePropertiesByChannel(userID, channelID string) error {
 	runID, err := p.runService.GetPlaybookRunIDForChannel(channelID)
 	if err != nil {
@@ -438,8 +545,13 @@ func (p *PermissionsService) RunView(userID, runID string) error {
 		return errors.Wrapf(err, "Unable to get run to determine permissions, run id `%s`", runID)
 	}
 
-	// Has permission if is the owner of the run
-	if run.OwnerUserID == userID {
+	return p.RunViewWithRun(userID, run)
+}
+
+// RunViewWithRun checks that userID can view the given run, for callers that already fetched it.
+func (p *PermissionsService) RunViewWithRun(userID string, run *PlaybookRun) error {
+	// Has permission if is the owner or a co-owner of the run
+	if run.IsOwnerOrCoOwner(userID) {
 		return nil
 	}
 
@@ -525,6 +637,23 @@ func CanPostToChannel(userID, channelID string, pluginAPI *pluginapi.Client) boo
 	return pluginAPI.User.HasPermissionToChannel(userID, channelID, model.PermissionCreatePost)
 }
 
+// CanSeeFullNames is true if userID can see the first and last names of other users, as system
+// admins always can.
+func CanSeeFullNames(userID string, pluginAPI *pluginapi.Client) bool {
+	if IsSystemAdmin(userID, pluginAPI) {
+		return true
+	}
+
+	// ShowFullName is coming as nil when setting is set to false
+	// TODO: further investigation https://mattermost.atlassian.net/browse/MM-48464
+	cfg := pluginAPI.Configuration.GetConfig()
+	if cfg.PrivacySettings.ShowFullName != nil {
+		return *cfg.PrivacySettings.ShowFullName
+	}
+
+	return false
+}
+
 func IsMemberOfTeam(userID, teamID string, pluginAPI *pluginapi.Client) bool {
 	teamMember, err := pluginAPI.Team.GetMember(teamID, userID)
 	if err != nil {
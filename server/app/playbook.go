@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/mattermost/mattermost-server/v6/model"
 	"gopkg.in/guregu/null.v4"
@@ -33,7 +34,7 @@ type Playbook struct {
 	NumRuns                                 int64                  `json:"num_runs" export:"-"`
 	NumActions                              int64                  `json:"num_actions" export:"-"`
 	LastRunAt                               int64                  `json:"last_run_at" export:"-"`
-	Checklists                              []Checklist            `json:"checklists" export:"-"`
+	Checklists                              []Checklist            `json:"checklists" export:"checklists"`
 	Members                                 []PlaybookMember       `json:"members" export:"-"`
 	ReminderMessageTemplate                 string                 `json:"reminder_message_template" export:"reminder_message_template"`
 	ReminderTimerDefaultSeconds             int64                  `json:"reminder_timer_default_seconds" export:"reminder_timer_default_seconds"`
@@ -64,10 +65,35 @@ type Playbook struct {
 	DefaultRunAdminRole                     string                 `json:"default_run_admin_role" export:"-"`
 	DefaultRunMemberRole                    string                 `json:"default_run_member_role" export:"-"`
 	Metrics                                 []PlaybookMetricConfig `json:"metrics" export:"metrics"`
+	PropertyDefinitions                     []PropertyDefinition   `json:"property_definitions" export:"property_definitions"`
 	ActiveRuns                              int64                  `json:"active_runs" export:"-"`
 	CreateChannelMemberOnNewParticipant     bool                   `json:"create_channel_member_on_new_participant" export:"create_channel_member_on_new_participant"`
 	RemoveChannelMemberOnRemovedParticipant bool                   `json:"remove_channel_member_on_removed_participant" export:"create_channel_member_on_removed_participant"`
 
+	// ArchiveChannelOnFinishEnabled archives the channel of the runs of this playbook
+	// ArchiveChannelOnFinishDelayMinutes after they finish.
+	ArchiveChannelOnFinishEnabled bool `json:"archive_channel_on_finish_enabled" export:"archive_channel_on_finish_enabled"`
+
+	// ArchiveChannelOnFinishDelayMinutes is how long after a run finishes its channel is archived.
+	// 0 archives it as soon as possible. Never negative.
+	ArchiveChannelOnFinishDelayMinutes int64 `json:"archive_channel_on_finish_delay_minutes" export:"archive_channel_on_finish_delay_minutes"`
+
+	// ArchiveChannelSkipIfPosted keeps the channel of a finished run if someone posted in it after
+	// the run finished.
+	ArchiveChannelSkipIfPosted bool `json:"archive_channel_skip_if_posted" export:"archive_channel_skip_if_posted"`
+
+	// RetrospectiveRequired prevents the runs of this playbook from finishing until their
+	// retrospective is published. Only applies if RetrospectiveEnabled is set.
+	RetrospectiveRequired bool `json:"retrospective_required" export:"retrospective_required"`
+
+	// ChecklistItemDependenciesEnforced prevents the checklist items of the runs of this playbook
+	// from being checked off before the items they depend on. Otherwise, doing so only warns.
+	ChecklistItemDependenciesEnforced bool `json:"checklist_item_dependencies_enforced" export:"checklist_item_dependencies_enforced"`
+
+	// AutoFinishEnabled finishes the runs of this playbook as soon as all their checklist items
+	// are done or skipped.
+	AutoFinishEnabled bool `json:"auto_finish_enabled" export:"auto_finish_enabled"`
+
 	// ChannelID is the identifier of the channel that would be -potentially- linked
 	// to any new run of this playbook
 	ChannelID string `json:"channel_id" export:"channel_id"`
@@ -75,6 +101,18 @@ type Playbook struct {
 	// ChannelMode is the playbook>run>channel flow used
 	ChannelMode ChannelPlaybookMode `json:"channel_mode" export:"channel_mode"`
 
+	// IsTemplate marks the playbook as a template: it can be listed from every team and copied
+	// into any of them, but it can still only be edited from its own team.
+	IsTemplate bool `json:"is_template" export:"-"`
+
+	// TemplateSourceID is the identifier of the template this playbook was copied from, if any.
+	// It is informational only: the copy is never updated when the template changes.
+	TemplateSourceID string `json:"template_source_id" export:"-"`
+
+	// StatusUpdateTemplates are the named templates a status update can be started from, e.g.
+	// one per phase of an incident. Runs keep a copy of the templates they were started with.
+	StatusUpdateTemplates []StatusUpdateTemplate `json:"status_update_templates" export:"status_update_templates"`
+
 	// Deprecated: preserved for backwards compatibility with v1.27
 	BroadcastEnabled             bool `json:"broadcast_enabled" export:"-"`
 	WebhookOnStatusUpdateEnabled bool `json:"webhook_on_status_update_enabled" export:"-"`
@@ -91,8 +129,24 @@ const (
 	MetricTypeInteger  = "metric_integer"
 )
 
+const (
+	// MetricDirectionLowerIsBetter means a run breaches the target of a metric when its value is
+	// above the target. It's the direction of metrics that don't set one.
+	MetricDirectionLowerIsBetter = "lower"
+
+	// MetricDirectionHigherIsBetter means a run breaches the target of a metric when its value is
+	// below the target.
+	MetricDirectionHigherIsBetter = "higher"
+)
+
 const MaxMetricsPerPlaybook = 4
 
+const (
+	PropertyTypeText   = "text"
+	PropertyTypeSelect = "select"
+	PropertyTypeNumber = "number"
+)
+
 type PlaybookMember struct {
 	UserID      string   `json:"user_id"`
 	Roles       []string `json:"roles"`
@@ -106,6 +160,39 @@ type PlaybookMetricConfig struct {
 	Description string   `json:"description" export:"description"`
 	Type        string   `json:"type" export:"type"`
 	Target      null.Int `json:"target" export:"target"`
+
+	// Direction tells whether higher or lower values of the metric are better, to detect the runs
+	// breaching its Target. Empty means lower is better.
+	Direction string `json:"direction" export:"direction"`
+}
+
+// ValidateMetricDirection checks that direction is empty or one of the known metric directions.
+func ValidateMetricDirection(direction string) error {
+	switch direction {
+	case "", MetricDirectionLowerIsBetter, MetricDirectionHigherIsBetter:
+		return nil
+	}
+
+	return errors.Errorf("invalid metric direction %q: must be %q or %q", direction, MetricDirectionLowerIsBetter, MetricDirectionHigherIsBetter)
+}
+
+// PropertyDefinition is a custom field defined on a playbook, whose value is set for each run.
+type PropertyDefinition struct {
+	ID         string `json:"id" export:"-"`
+	PlaybookID string `json:"playbook_id" export:"-"`
+	Name       string `json:"name" export:"name"`
+	Type       string `json:"type" export:"type"`
+
+	// Options are the allowed values of a select property.
+	Options []string `json:"options" export:"options"`
+}
+
+func (d PropertyDefinition) Clone() PropertyDefinition {
+	newDefinition := d
+	if len(d.Options) != 0 {
+		newDefinition.Options = append([]string(nil), d.Options...)
+	}
+	return newDefinition
 }
 
 func (pm PlaybookMember) Clone() PlaybookMember {
@@ -127,6 +214,12 @@ func (p Playbook) Clone() Playbook {
 	}
 	newPlaybook.Checklists = newChecklists
 	newPlaybook.Metrics = append([]PlaybookMetricConfig(nil), p.Metrics...)
+	var newPropertyDefinitions []PropertyDefinition
+	for _, d := range p.PropertyDefinitions {
+		newPropertyDefinitions = append(newPropertyDefinitions, d.Clone())
+	}
+	newPlaybook.PropertyDefinitions = newPropertyDefinitions
+	newPlaybook.StatusUpdateTemplates = append([]StatusUpdateTemplate(nil), p.StatusUpdateTemplates...)
 	var newMembers []PlaybookMember
 	for _, m := range p.Members {
 		newMembers = append(newMembers, m.Clone())
@@ -153,6 +246,56 @@ func (p Playbook) Clone() Playbook {
 	return newPlaybook
 }
 
+// CopyFromTemplate returns a new, unsaved playbook for targetTeamID with a deep copy of the
+// checklists, metrics, actions and settings of the template p. References to channels, users and
+// groups are dropped when copying to a different team, since they belong to the template's team.
+func (p Playbook) CopyFromTemplate(targetTeamID, title string) Playbook {
+	newPlaybook := p.Clone()
+	newPlaybook.ID = ""
+	newPlaybook.TeamID = targetTeamID
+	newPlaybook.IsTemplate = false
+	newPlaybook.TemplateSourceID = p.ID
+	newPlaybook.Members = nil
+	newPlaybook.CreateAt = 0
+	newPlaybook.UpdateAt = 0
+	newPlaybook.DeleteAt = 0
+	newPlaybook.NumRuns = 0
+	newPlaybook.ActiveRuns = 0
+	newPlaybook.LastRunAt = 0
+
+	if title != "" {
+		newPlaybook.Title = title
+	}
+
+	// Empty metric and property definition IDs, so that new ones are stored for the copy.
+	for i := range newPlaybook.Metrics {
+		newPlaybook.Metrics[i].ID = ""
+		newPlaybook.Metrics[i].PlaybookID = ""
+	}
+	for i := range newPlaybook.PropertyDefinitions {
+		newPlaybook.PropertyDefinitions[i].ID = ""
+		newPlaybook.PropertyDefinitions[i].PlaybookID = ""
+	}
+
+	if targetTeamID != p.TeamID {
+		newPlaybook.ChannelID = ""
+		newPlaybook.ChannelMode = PlaybookRunCreateNewChannel
+		newPlaybook.BroadcastChannelIDs = nil
+		newPlaybook.BroadcastEnabled = false
+		newPlaybook.InvitedUserIDs = nil
+		newPlaybook.InvitedGroupIDs = nil
+		newPlaybook.DefaultOwnerID = ""
+		newPlaybook.DefaultOwnerEnabled = false
+		for i := range newPlaybook.Checklists {
+			for j := range newPlaybook.Checklists[i].Items {
+				newPlaybook.Checklists[i].Items[j].DefaultAssigneeID = ""
+			}
+		}
+	}
+
+	return newPlaybook
+}
+
 func (p Playbook) MarshalJSON() ([]byte, error) {
 	type Alias Playbook
 
@@ -172,6 +315,17 @@ func (p Playbook) MarshalJSON() ([]byte, error) {
 	if old.Metrics == nil {
 		old.Metrics = []PlaybookMetricConfig{}
 	}
+	if old.PropertyDefinitions == nil {
+		old.PropertyDefinitions = []PropertyDefinition{}
+	}
+	for j, d := range old.PropertyDefinitions {
+		if d.Options == nil {
+			old.PropertyDefinitions[j].Options = []string{}
+		}
+	}
+	if old.StatusUpdateTemplates == nil {
+		old.StatusUpdateTemplates = []StatusUpdateTemplate{}
+	}
 	if old.InvitedUserIDs == nil {
 		old.InvitedUserIDs = []string{}
 	}
@@ -203,7 +357,7 @@ type Checklist struct {
 	Title string `json:"title" export:"title"`
 
 	// Items is an array of all the items in the checklist.
-	Items []ChecklistItem `json:"items" export:"-"`
+	Items []ChecklistItem `json:"items" export:"items"`
 }
 
 func (c Checklist) Clone() Checklist {
@@ -212,10 +366,54 @@ func (c Checklist) Clone() Checklist {
 	return newChecklist
 }
 
+// ReorderItems returns the items of the checklist in the order given by itemIDs, which must
+// contain the ID of every item in the checklist exactly once.
+func (c Checklist) ReorderItems(itemIDs []string) ([]ChecklistItem, error) {
+	if len(itemIDs) != len(c.Items) {
+		return nil, errors.Wrapf(ErrInvalidChecklistItemOrder, "expected %d item ids, got %d", len(c.Items), len(itemIDs))
+	}
+
+	itemsByID := make(map[string]ChecklistItem, len(c.Items))
+	for _, item := range c.Items {
+		itemsByID[item.ID] = item
+	}
+
+	reordered := make([]ChecklistItem, 0, len(itemIDs))
+	for _, itemID := range itemIDs {
+		item, ok := itemsByID[itemID]
+		if !ok {
+			return nil, errors.Wrapf(ErrInvalidChecklistItemOrder, "unknown or repeated item id %q", itemID)
+		}
+		delete(itemsByID, itemID)
+		reordered = append(reordered, item)
+	}
+
+	return reordered, nil
+}
+
+// ReassignItems assigns to toUserID every item of the checklist assigned to fromUserID that is
+// not done or skipped yet, at modifiedAt (in millis). Returns the number of reassigned items.
+func (c *Checklist) ReassignItems(fromUserID, toUserID string, modifiedAt int64) int {
+	reassigned := 0
+	for i := range c.Items {
+		item := &c.Items[i]
+		if item.AssigneeID != fromUserID || item.State == ChecklistItemStateClosed || item.State == ChecklistItemStateSkipped {
+			continue
+		}
+
+		item.AssigneeID = toUserID
+		item.AssigneeModified = modifiedAt
+		reassigned++
+	}
+
+	return reassigned
+}
+
 // ChecklistItem represents an item in a checklist.
 type ChecklistItem struct {
-	// ID is the identifier of the checklist item.
-	ID string `json:"id" export:"-"`
+	// ID is the identifier of the checklist item. It is exported so that the dependencies between
+	// the items of a playbook survive its export.
+	ID string `json:"id" export:"id"`
 
 	// Title is the content of the checklist item.
 	Title string `json:"title" export:"title"`
@@ -224,10 +422,17 @@ type ChecklistItem struct {
 	// been skipped, the empty string otherwise.
 	State string `json:"state" export:"-"`
 
+	// SkipReason is why the item was skipped, if given. Empty unless the item is skipped.
+	SkipReason string `json:"skip_reason" export:"-"`
+
 	// StateModified is the timestamp, in milliseconds since epoch, of the last time the item's
 	// state was modified. 0 if it was never modified.
 	StateModified int64 `json:"state_modified" export:"-"`
 
+	// StateModifiedBy is the identifier of the user who last modified the item's state.
+	// Empty if the state was never modified.
+	StateModifiedBy string `json:"state_modified_by" export:"-"`
+
 	// AssigneeID is the identifier of the user to whom this item is assigned.
 	AssigneeID string `json:"assignee_id" export:"-"`
 
@@ -235,6 +440,10 @@ type ChecklistItem struct {
 	// assignee was modified. 0 if it was never modified.
 	AssigneeModified int64 `json:"assignee_modified" export:"-"`
 
+	// DefaultAssigneeID is the identifier of the user to whom the item is assigned when a run is
+	// started from the playbook. Empty to leave the item unassigned. Only used by playbooks.
+	DefaultAssigneeID string `json:"default_assignee_id" export:"-"`
+
 	// Command, if not empty, is the slash command that can be run as part of this item.
 	Command string `json:"command" export:"command"`
 
@@ -242,6 +451,10 @@ type ChecklistItem struct {
 	// slash command was run. 0 if it was never run.
 	CommandLastRun int64 `json:"command_last_run" export:"-"`
 
+	// CommandOutputID is the identifier of the output of the last run of the item's slash command,
+	// empty if it was never run from the run.
+	CommandOutputID string `json:"command_output_id" export:"-"`
+
 	// Description is a string with the markdown content of the long description of the item.
 	Description string `json:"description" export:"description"`
 
@@ -253,6 +466,54 @@ type ChecklistItem struct {
 	// of the checklist item. 0 if not set.
 	// Playbook can have only relative timstamp, run can have only absolute timestamp.
 	DueDate int64 `json:"due_date" export:"due_date"`
+
+	// DueOffset is the duration, in milliseconds, relative to the start of the run within which
+	// the item must be done. Only used by runs, where DueDate is computed from it. 0 if the due
+	// date is not relative.
+	DueOffset int64 `json:"due_offset" export:"-"`
+
+	// Condition, if not empty, is an expression over the run's variables deciding whether the
+	// item applies to the run, e.g. `$SEVERITY == critical` or `$SEVERITY in (critical, high)`.
+	Condition string `json:"condition" export:"condition"`
+
+	// Hidden is true when the item's condition does not hold for the run. Hidden items are not
+	// shown and do not count towards the run's progress. Only used by runs.
+	Hidden bool `json:"hidden" export:"-"`
+
+	// DependsOnItemID, if not empty, is the identifier of the item of the same playbook or run
+	// that must be done or skipped before this item can be checked off.
+	DependsOnItemID string `json:"depends_on_item_id" export:"depends_on_item_id"`
+
+	// Blocked is true when the item this item depends on is not done or skipped yet. It is
+	// computed whenever the run is read from the store. Only used by runs.
+	Blocked bool `json:"blocked" export:"-"`
+}
+
+// ValidateChecklistItemDefaultAssignees checks that the default assignees of every item in
+// checklists are valid user IDs for which isTeamMember, telling whether they are members of the
+// playbook's team, returns true.
+func ValidateChecklistItemDefaultAssignees(checklists []Checklist, isTeamMember func(userID string) bool) error {
+	for _, checklist := range checklists {
+		for _, item := range checklist.Items {
+			if item.DefaultAssigneeID == "" {
+				continue
+			}
+			if !model.IsValidId(item.DefaultAssigneeID) {
+				return errors.Wrapf(ErrInvalidDefaultAssignee, "checklist item %q", item.Title)
+			}
+			if !isTeamMember(item.DefaultAssigneeID) {
+				return errors.Wrapf(ErrInvalidDefaultAssignee, "default assignee of checklist item %q is not a member of the team", item.Title)
+			}
+		}
+	}
+
+	return nil
+}
+
+// IsOverdue returns true if the item is shown, still open and its due date is at or before now,
+// in milliseconds since epoch.
+func (ci ChecklistItem) IsOverdue(now int64) bool {
+	return !ci.Hidden && ci.State == ChecklistItemStateOpen && ci.DueDate > 0 && ci.DueDate <= now
 }
 
 type GetPlaybooksResults struct {
@@ -301,6 +562,17 @@ type PlaybookService interface {
 	// Update updates a playbook
 	Update(playbook Playbook, userID string) error
 
+	// GraphqlUpdate updates the playbook with id taking a setmap for graphql
+	GraphqlUpdate(id string, setmap map[string]interface{}, userID string) error
+
+	// GetVersions returns the past versions of a playbook, most recent first, without their
+	// snapshot.
+	GetVersions(playbookID string) ([]PlaybookVersion, error)
+
+	// GetVersionDiff returns a past version of a playbook and its changes up to the current
+	// playbook. Returns ErrNotFound if the version doesn't exist.
+	GetVersionDiff(playbookID string, version int64) (PlaybookVersionDiff, error)
+
 	// Archive archives a playbook
 	Archive(playbook Playbook, userID string) error
 
@@ -319,6 +591,10 @@ type PlaybookService interface {
 	// Duplicate duplicates a playbook
 	Duplicate(playbook Playbook, userID string) (string, error)
 
+	// CreateFromTemplate creates a new playbook in targetTeamID as a copy of the template with
+	// templateID. The copy is not linked to the template in any way.
+	CreateFromTemplate(templateID, targetTeamID, newName, userID string) (string, error)
+
 	// Get top playbooks for teams
 	GetTopPlaybooksForTeam(teamID, userID string, opts *model.InsightsOpts) (*PlaybooksInsightsList, error)
 
@@ -445,6 +721,18 @@ func IsValidChecklistItemState(state string) bool {
 		state == ChecklistItemStateSkipped
 }
 
+// MaxChecklistItemSkipReasonLength is the maximum length, in characters, of the reason for
+// skipping a checklist item.
+const MaxChecklistItemSkipReasonLength = 1024
+
+// ValidateChecklistItemSkipReason returns an error if reason is too long.
+func ValidateChecklistItemSkipReason(reason string) error {
+	if utf8.RuneCountInString(reason) > MaxChecklistItemSkipReasonLength {
+		return errors.Errorf("invalid skip reason (maximum length is %d characters)", MaxChecklistItemSkipReasonLength)
+	}
+	return nil
+}
+
 func IsValidChecklistItemIndex(checklists []Checklist, checklistNum, itemNum int) bool {
 	return checklists != nil && checklistNum >= 0 && itemNum >= 0 && checklistNum < len(checklists) && itemNum < len(checklists[checklistNum].Items)
 }
@@ -456,6 +744,7 @@ type PlaybookFilterOptions struct {
 	SearchTerm         string
 	WithArchived       bool
 	WithMembershipOnly bool //if true will return only playbooks you are a member of
+	TemplatesOnly      bool //if true will return only templates, from every team
 
 	// Pagination options.
 	Page    int
@@ -519,6 +808,16 @@ func ValidateWebhookURLs(urls []string) error {
 	return nil
 }
 
+// ValidateArchiveChannelOnFinishDelay checks the delay, in minutes, after which the channel of a
+// finished run is archived.
+func ValidateArchiveChannelOnFinishDelay(minutes int64) error {
+	if minutes < 0 {
+		return errors.New("the delay to archive the channel of finished runs cannot be negative")
+	}
+
+	return nil
+}
+
 func ValidateCategoryName(categoryName string) error {
 	categoryNameLength := len(categoryName)
 	if categoryNameLength > 22 {
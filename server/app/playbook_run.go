@@ -2,8 +2,10 @@ package app
 
 import (
 	"encoding/json"
+	"io"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"gopkg.in/guregu/null.v4"
 
@@ -15,7 +17,11 @@ import (
 
 const (
 	StatusInProgress = "InProgress"
+	StatusPaused     = "Paused"
 	StatusFinished   = "Finished"
+
+	// StatusMerged runs were merged into another run, and are as final as finished runs.
+	StatusMerged = "Merged"
 )
 
 const (
@@ -23,6 +29,18 @@ const (
 	RunRoleAdmin  = "run_admin"
 )
 
+// ParticipantRole is what a participant can do in a run.
+type ParticipantRole string
+
+const (
+	// ParticipantRoleActive participants take part in the run: they can change its checklists,
+	// status and owners.
+	ParticipantRoleActive ParticipantRole = "active"
+
+	// ParticipantRoleViewer participants only follow the run along.
+	ParticipantRoleViewer ParticipantRole = "viewer"
+)
+
 // PlaybookRun holds the detailed information of a playbook run.
 //
 // NOTE: When adding a column to the db, search for "When adding a PlaybookRun column" to see where
@@ -43,6 +61,13 @@ type PlaybookRun struct {
 	// OwnerUserID is the user identifier of the playbook run's owner.
 	OwnerUserID string `json:"owner_user_id"`
 
+	// CoOwnerUserIDs are the identifiers of the users that lead the playbook run along with the
+	// owner, e.g. a secondary commander. Co-owners can edit the run as the owner does.
+	CoOwnerUserIDs []string `json:"co_owner_user_ids"`
+
+	// Tags are the free-form labels categorizing the playbook run, e.g. "security".
+	Tags []string `json:"tags"`
+
 	// ReporterUserID is the user identifier of the playbook run's reporter; i.e., the user that created the run.
 	ReporterUserID string `json:"reporter_user_id"`
 
@@ -59,6 +84,14 @@ type PlaybookRun struct {
 	// If 0, the run is still ongoing.
 	EndAt int64 `json:"end_at"`
 
+	// PausedAt is the timestamp, in milliseconds since epoch, of when the playbook run was paused.
+	// If 0, the run is not paused.
+	PausedAt int64 `json:"paused_at"`
+
+	// PausedDuration is the total time, in milliseconds, the playbook run spent paused, not counting
+	// the current pause if the run is paused.
+	PausedDuration int64 `json:"paused_duration"`
+
 	// Deprecated: preserved for backwards compatibility with v1.2.
 	DeleteAt int64 `json:"delete_at"`
 
@@ -81,7 +114,8 @@ type PlaybookRun struct {
 	StatusPosts []StatusPost `json:"status_posts"`
 
 	// CurrentStatus is the current status of the playbook run.
-	// It can be StatusInProgress ("InProgress") or StatusFinished ("Finished")
+	// It can be StatusInProgress ("InProgress"), StatusPaused ("Paused"), StatusFinished ("Finished")
+	// or StatusMerged ("Merged")
 	CurrentStatus string `json:"current_status"`
 
 	// LastStatusUpdateAt is the timestamp, in milliseconds since epoch, of the time the last
@@ -100,6 +134,10 @@ type PlaybookRun struct {
 	// playbook run for the first time.
 	ReminderMessageTemplate string `json:"reminder_message_template"`
 
+	// StatusUpdateTemplates are the status update templates of the playbook, as they were when
+	// the run started, so that editing the playbook doesn't break the updates of ongoing runs.
+	StatusUpdateTemplates []StatusUpdateTemplate `json:"status_update_templates"`
+
 	// ReminderTimerDefaultSeconds is the expected default interval, in seconds,
 	// between every status update
 	ReminderTimerDefaultSeconds int64 `json:"reminder_timer_default_seconds"`
@@ -168,12 +206,20 @@ type PlaybookRun struct {
 	// A participant is any member of the playbook run channel that isn't a bot.
 	ParticipantIDs []string `json:"participant_ids"`
 
+	// ViewerIDs are the participants that follow the run without taking part in it: they can see
+	// the run but not change its checklists, status or owners. All other participants are active.
+	ViewerIDs []string `json:"viewer_ids"`
+
 	// CategoryName, if not empty, is the name of the category where the run channel will live.
 	CategoryName string `json:"category_name"`
 
 	// Playbook run metric values
 	MetricsData []RunMetricData `json:"metrics_data"`
 
+	// PropertyValues are the values set for the properties defined on the run's playbook.
+	// Unset properties are omitted.
+	PropertyValues []PropertyValue `json:"property_values"`
+
 	// CreateChannelMemberOnNewParticipant is the Run action flag that defines if a new channel member will be added
 	// to the run's channel when a new participant is added to the run (by themselve or by other members).
 	CreateChannelMemberOnNewParticipant bool `json:"create_channel_member_on_new_participant" export:"create_channel_member_on_new_participant"`
@@ -181,6 +227,164 @@ type PlaybookRun struct {
 	// RemoveChannelMemberOnRemovedParticipant is the Run action flag that defines if an existent channel member will be removed
 	// from the run's channel when a new participant is added to the run (by themselve or by other members).
 	RemoveChannelMemberOnRemovedParticipant bool `json:"remove_channel_member_on_removed_participant" export:"create_channel_member_on_removed_participant"`
+
+	// ArchiveChannelOnFinishEnabled archives the channel of the run ArchiveChannelOnFinishDelayMinutes
+	// after it finishes, unless another run was attached to the channel since.
+	ArchiveChannelOnFinishEnabled bool `json:"archive_channel_on_finish_enabled" export:"-"`
+
+	// ArchiveChannelOnFinishDelayMinutes is how long after the run finishes its channel is archived.
+	ArchiveChannelOnFinishDelayMinutes int64 `json:"archive_channel_on_finish_delay_minutes" export:"-"`
+
+	// ArchiveChannelSkipIfPosted keeps the channel if someone posted in it after the run finished.
+	ArchiveChannelSkipIfPosted bool `json:"archive_channel_skip_if_posted" export:"-"`
+
+	// ChannelAutoArchiveAt is the timestamp, in milliseconds since epoch, at which the channel of
+	// the finished run is due to be archived. 0 if it isn't, or was already handled.
+	ChannelAutoArchiveAt int64 `json:"channel_auto_archive_at" export:"-"`
+
+	// RetrospectiveRequired prevents the run from finishing until its retrospective is published
+	// with some text. A canceled retrospective doesn't count.
+	RetrospectiveRequired bool `json:"retrospective_required" export:"-"`
+
+	// ChecklistItemDependenciesEnforced prevents checklist items from being checked off before the
+	// items they depend on are done or skipped.
+	ChecklistItemDependenciesEnforced bool `json:"checklist_item_dependencies_enforced" export:"-"`
+
+	// AutoFinishEnabled finishes the run as soon as all its checklist items are done or skipped.
+	AutoFinishEnabled bool `json:"auto_finish_enabled" export:"-"`
+
+	// MergedIntoRunID is the identifier of the run this run was merged into, if its status is
+	// StatusMerged.
+	MergedIntoRunID string `json:"merged_into_run_id" export:"-"`
+}
+
+// ActiveDuration returns the time, in milliseconds, the run has been in progress, excluding the
+// time it spent paused. Ongoing runs and pauses are measured up to now.
+func (r *PlaybookRun) ActiveDuration(now int64) int64 {
+	end := now
+	if r.EndAt != 0 {
+		end = r.EndAt
+	}
+
+	paused := r.PausedDuration
+	if r.PausedAt != 0 && r.PausedAt < end {
+		paused += end - r.PausedAt
+	}
+
+	duration := end - r.CreateAt - paused
+	if duration < 0 {
+		return 0
+	}
+
+	return duration
+}
+
+// CompletionPercentage returns the percentage, between 0 and 100, of the run's checklist items
+// that are done. Skipped and hidden items are not counted, and a run without any other items is
+// at 0.
+func (r *PlaybookRun) CompletionPercentage() float64 {
+	total, closed := 0, 0
+	for _, checklist := range r.Checklists {
+		for _, item := range checklist.Items {
+			if item.Hidden || item.State == ChecklistItemStateSkipped {
+				continue
+			}
+			total++
+			if item.State == ChecklistItemStateClosed {
+				closed++
+			}
+		}
+	}
+
+	if total == 0 {
+		return 0
+	}
+
+	return 100 * float64(closed) / float64(total)
+}
+
+// ChecklistsComplete is true if all the run's checklist items are done or skipped, and there is at
+// least one. Hidden items are not counted.
+func (r *PlaybookRun) ChecklistsComplete() bool {
+	total := 0
+	for _, checklist := range r.Checklists {
+		for _, item := range checklist.Items {
+			if item.Hidden {
+				continue
+			}
+			if item.State != ChecklistItemStateClosed && item.State != ChecklistItemStateSkipped {
+				return false
+			}
+			total++
+		}
+	}
+
+	return total > 0
+}
+
+// MissingRequiredRetrospective is true if the run requires a retrospective and none with some text
+// was published.
+func (r *PlaybookRun) MissingRequiredRetrospective() bool {
+	if !r.RetrospectiveRequired {
+		return false
+	}
+
+	return r.RetrospectivePublishedAt == 0 || r.RetrospectiveWasCanceled || strings.TrimSpace(r.Retrospective) == ""
+}
+
+// SkippedItemsCount returns the number of the run's checklist items that were skipped. Hidden
+// items are not counted.
+func (r *PlaybookRun) SkippedItemsCount() int {
+	skipped := 0
+	for _, checklist := range r.Checklists {
+		for _, item := range checklist.Items {
+			if !item.Hidden && item.State == ChecklistItemStateSkipped {
+				skipped++
+			}
+		}
+	}
+
+	return skipped
+}
+
+// IsViewer returns true if userID is a viewer of the run. The owner and co-owners are never
+// viewers.
+func (r *PlaybookRun) IsViewer(userID string) bool {
+	if r.IsOwnerOrCoOwner(userID) {
+		return false
+	}
+
+	for _, viewerID := range r.ViewerIDs {
+		if viewerID == userID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParticipantRole returns the role of userID in the run, assuming they are a participant.
+func (r *PlaybookRun) ParticipantRole(userID string) ParticipantRole {
+	if r.IsViewer(userID) {
+		return ParticipantRoleViewer
+	}
+
+	return ParticipantRoleActive
+}
+
+// IsOwnerOrCoOwner returns true if userID is the owner or one of the co-owners of the run.
+func (r *PlaybookRun) IsOwnerOrCoOwner(userID string) bool {
+	if r.OwnerUserID == userID {
+		return true
+	}
+
+	for _, coOwnerID := range r.CoOwnerUserIDs {
+		if coOwnerID == userID {
+			return true
+		}
+	}
+
+	return false
 }
 
 func (r *PlaybookRun) Clone() *PlaybookRun {
@@ -196,9 +400,14 @@ func (r *PlaybookRun) Clone() *PlaybookRun {
 	newPlaybookRun.InvitedUserIDs = append([]string(nil), r.InvitedUserIDs...)
 	newPlaybookRun.InvitedGroupIDs = append([]string(nil), r.InvitedGroupIDs...)
 	newPlaybookRun.ParticipantIDs = append([]string(nil), r.ParticipantIDs...)
+	newPlaybookRun.ViewerIDs = append([]string(nil), r.ViewerIDs...)
+	newPlaybookRun.CoOwnerUserIDs = append([]string(nil), r.CoOwnerUserIDs...)
+	newPlaybookRun.Tags = append([]string(nil), r.Tags...)
 	newPlaybookRun.WebhookOnCreationURLs = append([]string(nil), r.WebhookOnCreationURLs...)
 	newPlaybookRun.WebhookOnStatusUpdateURLs = append([]string(nil), r.WebhookOnStatusUpdateURLs...)
 	newPlaybookRun.MetricsData = append([]RunMetricData(nil), r.MetricsData...)
+	newPlaybookRun.PropertyValues = append([]PropertyValue(nil), r.PropertyValues...)
+	newPlaybookRun.StatusUpdateTemplates = append([]StatusUpdateTemplate(nil), r.StatusUpdateTemplates...)
 
 	return &newPlaybookRun
 }
@@ -231,6 +440,18 @@ func (r *PlaybookRun) MarshalJSON() ([]byte, error) {
 	if old.ParticipantIDs == nil {
 		old.ParticipantIDs = []string{}
 	}
+	if old.ViewerIDs == nil {
+		old.ViewerIDs = []string{}
+	}
+	if old.CoOwnerUserIDs == nil {
+		old.CoOwnerUserIDs = []string{}
+	}
+	if old.Tags == nil {
+		old.Tags = []string{}
+	}
+	if old.StatusUpdateTemplates == nil {
+		old.StatusUpdateTemplates = []StatusUpdateTemplate{}
+	}
 	if old.BroadcastChannelIDs == nil {
 		old.BroadcastChannelIDs = []string{}
 	}
@@ -243,21 +464,37 @@ func (r *PlaybookRun) MarshalJSON() ([]byte, error) {
 	if old.MetricsData == nil {
 		old.MetricsData = []RunMetricData{}
 	}
+	if old.PropertyValues == nil {
+		old.PropertyValues = []PropertyValue{}
+	}
 
 	return json.Marshal(old)
 }
 
-// SetChecklistFromPlaybook overwrites this run's checklists with the ones in the provided playbook.
+// SetChecklistFromPlaybook overwrites this run's checklists with a copy of the ones in the provided
+// playbook, assigning the items to their default assignees.
 func (r *PlaybookRun) SetChecklistFromPlaybook(playbook Playbook) {
-	r.Checklists = playbook.Checklists
+	r.Checklists = make([]Checklist, 0, len(playbook.Checklists))
+	for _, checklist := range playbook.Checklists {
+		r.Checklists = append(r.Checklists, checklist.Clone())
+	}
+	renewChecklistItemIDs(r.Checklists)
 
 	// Playbooks can only have due dates relative to when a run starts,
 	// so we should convert them to absolute timestamp.
 	now := model.GetMillis()
 	for i := range r.Checklists {
 		for j := range r.Checklists[i].Items {
-			if r.Checklists[i].Items[j].DueDate > 0 {
-				r.Checklists[i].Items[j].DueDate += now
+			item := &r.Checklists[i].Items[j]
+			if item.DueDate > 0 {
+				item.DueOffset = item.DueDate
+				item.DueDate += now
+			}
+
+			if item.DefaultAssigneeID != "" {
+				item.AssigneeID = item.DefaultAssigneeID
+				item.AssigneeModified = now
+				item.DefaultAssigneeID = ""
 			}
 		}
 	}
@@ -270,6 +507,7 @@ func (r *PlaybookRun) SetConfigurationFromPlaybook(playbook Playbook) {
 		r.Summary = playbook.RunSummaryTemplate
 	}
 	r.ReminderMessageTemplate = playbook.ReminderMessageTemplate
+	r.StatusUpdateTemplates = append([]StatusUpdateTemplate(nil), playbook.StatusUpdateTemplates...)
 	r.StatusUpdateEnabled = playbook.StatusUpdateEnabled
 	r.PreviousReminder = time.Duration(playbook.ReminderTimerDefaultSeconds) * time.Second
 	r.ReminderTimerDefaultSeconds = playbook.ReminderTimerDefaultSeconds
@@ -306,6 +544,14 @@ func (r *PlaybookRun) SetConfigurationFromPlaybook(playbook Playbook) {
 
 	r.CreateChannelMemberOnNewParticipant = playbook.CreateChannelMemberOnNewParticipant
 	r.RemoveChannelMemberOnRemovedParticipant = playbook.RemoveChannelMemberOnRemovedParticipant
+
+	r.ArchiveChannelOnFinishEnabled = playbook.ArchiveChannelOnFinishEnabled
+	r.ArchiveChannelOnFinishDelayMinutes = playbook.ArchiveChannelOnFinishDelayMinutes
+	r.ArchiveChannelSkipIfPosted = playbook.ArchiveChannelSkipIfPosted
+
+	r.RetrospectiveRequired = playbook.RetrospectiveEnabled && playbook.RetrospectiveRequired
+	r.ChecklistItemDependenciesEnforced = playbook.ChecklistItemDependenciesEnforced
+	r.AutoFinishEnabled = playbook.AutoFinishEnabled
 }
 
 type StatusPost struct {
@@ -359,12 +605,50 @@ func NewStatusPostComplete(post *model.Post) *StatusPostComplete {
 type UpdateOptions struct {
 }
 
+// BroadcastResult is the outcome of broadcasting a message of a run to one of its broadcast
+// channels. Each channel is attempted independently, so some may fail while others succeed.
+type BroadcastResult struct {
+	ChannelID string `json:"channel_id"`
+
+	// PostID is the identifier of the post made in the channel. Empty if the broadcast failed.
+	PostID string `json:"post_id"`
+
+	// Error is why the broadcast to the channel failed. Empty if it succeeded.
+	Error string `json:"error,omitempty"`
+}
+
+// RunMerge is what merging a source run into a target run changes, all stored at once: the
+// checklists of the target with those of the source appended, the participants, tags and status
+// updates of the source to add to the target, and the timeline events recorded in both runs.
+type RunMerge struct {
+	TargetRunID string
+	SourceRunID string
+
+	// SourceChecklists are the checklists of the source run, appended to the checklists of the
+	// target run as they are when the merge is stored.
+	SourceChecklists []Checklist
+
+	ParticipantIDs []string
+	Tags           []string
+	StatusPostIDs  []string
+
+	// MergedAt is the timestamp, in milliseconds since epoch, at which the source run ended.
+	MergedAt int64
+
+	TargetEvent *TimelineEvent
+	SourceEvent *TimelineEvent
+}
+
 // StatusUpdateOptions encapsulates the fields that can be set when updating a playbook run's status
 // NOTE: changes made to this should be reflected in the client package.
 type StatusUpdateOptions struct {
 	Message   string        `json:"message"`
 	Reminder  time.Duration `json:"reminder"`
 	FinishRun bool          `json:"finish_run"`
+
+	// TemplateID, if set, is the status update template of the run the update is written from.
+	// The message of the template is used if Message is empty.
+	TemplateID string `json:"template_id"`
 }
 
 // Metadata tracks ancillary metadata about a playbook run.
@@ -385,6 +669,8 @@ const (
 	StatusUpdated          timelineEventType = "status_updated"
 	StatusUpdateRequested  timelineEventType = "status_update_requested"
 	OwnerChanged           timelineEventType = "owner_changed"
+	CoOwnerAdded           timelineEventType = "co_owner_added"
+	CoOwnerRemoved         timelineEventType = "co_owner_removed"
 	AssigneeChanged        timelineEventType = "assignee_changed"
 	RanSlashCommand        timelineEventType = "ran_slash_command"
 	EventFromPost          timelineEventType = "event_from_post"
@@ -394,9 +680,17 @@ const (
 	CanceledRetrospective  timelineEventType = "canceled_retrospective"
 	RunFinished            timelineEventType = "run_finished"
 	RunRestored            timelineEventType = "run_restored"
+	RunPaused              timelineEventType = "run_paused"
+	RunResumed             timelineEventType = "run_resumed"
+	RunCloned              timelineEventType = "run_cloned"
 	StatusUpdateSnoozed    timelineEventType = "status_update_snoozed"
 	StatusUpdatesEnabled   timelineEventType = "status_updates_enabled"
 	StatusUpdatesDisabled  timelineEventType = "status_updates_disabled"
+	ChannelArchived        timelineEventType = "channel_archived"
+	ChannelUnarchived      timelineEventType = "channel_unarchived"
+	RunMerged              timelineEventType = "run_merged"
+	ParticipantRoleChanged timelineEventType = "participant_role_changed"
+	KeyEvent               timelineEventType = "key_event"
 )
 
 type TimelineEvent struct {
@@ -419,7 +713,8 @@ type TimelineEvent struct {
 
 	// EventType is the type of this event. It can be "incident_created", "task_state_modified",
 	// "status_updated", "owner_changed", "assignee_changed", "ran_slash_command",
-	// "event_from_post", "user_joined_left", "published_retrospective", "canceled_retrospective" or "status_update_snoozed".
+	// "event_from_post", "user_joined_left", "published_retrospective", "canceled_retrospective",
+	// "status_update_snoozed" or "key_event".
 	EventType timelineEventType `json:"event_type"`
 
 	// Summary is a short description of the event.
@@ -438,6 +733,46 @@ type TimelineEvent struct {
 
 	// CreatorUserID is the identifier of the user that created the event.
 	CreatorUserID string `json:"creator_user_id"`
+
+	// UserCreated is true if a user added the event to the timeline, as a key event or from a
+	// post, instead of it being recorded when something happened in the run.
+	UserCreated bool `json:"user_created"`
+}
+
+// MaxKeyEventSummaryLength is the maximum length, in characters, of the summary of a key event.
+const MaxKeyEventSummaryLength = 1024
+
+// KeyEventOptions are the parameters of a key event added by a user to the timeline of a run.
+type KeyEventOptions struct {
+	// Summary is the short description of the event. Required.
+	Summary string `json:"summary"`
+
+	// Details is the longer description of the event, if any.
+	Details string `json:"details"`
+
+	// PostID, if not empty, is the identifier of a post of the run's channel the event is about.
+	// The event then happened when the post was created, instead of when the event is added.
+	PostID string `json:"post_id"`
+}
+
+// Validate returns a copy of the options with the text trimmed, or an error wrapping
+// ErrInvalidKeyEvent if they are invalid.
+func (o KeyEventOptions) Validate() (KeyEventOptions, error) {
+	options := o
+	options.Summary = strings.TrimSpace(options.Summary)
+	options.Details = strings.TrimSpace(options.Details)
+
+	if options.Summary == "" {
+		return KeyEventOptions{}, errors.Wrap(ErrInvalidKeyEvent, "summary is required")
+	}
+	if utf8.RuneCountInString(options.Summary) > MaxKeyEventSummaryLength {
+		return KeyEventOptions{}, errors.Wrapf(ErrInvalidKeyEvent, "summary is longer than %d characters", MaxKeyEventSummaryLength)
+	}
+	if options.PostID != "" && !model.IsValidId(options.PostID) {
+		return KeyEventOptions{}, errors.Wrap(ErrInvalidKeyEvent, "post_id must be 26 characters")
+	}
+
+	return options, nil
 }
 
 // GetPlaybookRunsResults collects the results of the GetPlaybookRuns call: the list of PlaybookRuns matching
@@ -449,6 +784,135 @@ type GetPlaybookRunsResults struct {
 	Items      []PlaybookRun `json:"items"`
 }
 
+// RunExportRow is a playbook run as exported to CSV, without the checklists, status posts and
+// timeline events that make loading full runs expensive.
+type RunExportRow struct {
+	ID                 string
+	Name               string
+	PlaybookID         string
+	OwnerUserID        string
+	ChannelID          string
+	CurrentStatus      string
+	CreateAt           int64
+	EndAt              int64
+	LastStatusUpdateAt int64
+}
+
+// RunExportCursor is the position of a run in a CSV export, which orders runs by creation time
+// and then by ID. The zero cursor is before the first run.
+type RunExportCursor struct {
+	CreateAt int64
+	ID       string
+}
+
+// StatusUpdateSearchResult is a status update matching a search, along with the run it was posted to.
+type StatusUpdateSearchResult struct {
+	PlaybookRunID   string `json:"playbook_run_id"`
+	PlaybookRunName string `json:"playbook_run_name"`
+	PostID          string `json:"post_id"`
+
+	// Snippet is the part of the status update around the first match of the search term.
+	Snippet  string `json:"snippet"`
+	CreateAt int64  `json:"create_at"`
+}
+
+// SearchStatusUpdatesResults collects the results of the SearchStatusUpdates call: the matching
+// status updates, newest first, and the TotalCount of matches before paging was applied.
+type SearchStatusUpdatesResults struct {
+	TotalCount int                        `json:"total_count"`
+	PageCount  int                        `json:"page_count"`
+	HasMore    bool                       `json:"has_more"`
+	Items      []StatusUpdateSearchResult `json:"items"`
+}
+
+// RunActivityEventTypes are the timeline events that count as activity on a run: status updates,
+// changes of the state of checklist items, and participants joining or leaving. A run without
+// any of them since it started had its last activity when it was created.
+var RunActivityEventTypes = []timelineEventType{StatusUpdated, TaskStateModified, ParticipantsChanged, UserJoinedLeft}
+
+// IsRunActivity returns true if the event counts as activity on its run.
+func (t timelineEventType) IsRunActivity() bool {
+	for _, eventType := range RunActivityEventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultStaleRunThreshold is how long a run goes without activity before it is stale, unless
+// another threshold is given.
+const DefaultStaleRunThreshold = 24 * time.Hour
+
+// StaleRun is an in-progress run without activity for longer than a threshold.
+type StaleRun struct {
+	ID                 string `json:"id"`
+	Name               string `json:"name"`
+	TeamID             string `json:"team_id"`
+	ChannelID          string `json:"channel_id"`
+	OwnerUserID        string `json:"owner_user_id"`
+	PlaybookID         string `json:"playbook_id"`
+	CreateAt           int64  `json:"create_at"`
+	LastStatusUpdateAt int64  `json:"last_status_update_at"`
+
+	// LastActivityAt is the time, in millis, of the most recent activity on the run.
+	LastActivityAt int64 `json:"last_activity_at"`
+}
+
+// StaleRunsOptions specifies the pagination of the stale runs.
+type StaleRunsOptions struct {
+	Page    int `url:"page,omitempty"`
+	PerPage int `url:"per_page,omitempty"`
+}
+
+// GetStaleRunsResults collects the results of the GetStalePlaybookRuns call: the stale runs,
+// stalest first, and the TotalCount of stale runs before paging was applied.
+type GetStaleRunsResults struct {
+	TotalCount int        `json:"total_count"`
+	PageCount  int        `json:"page_count"`
+	HasMore    bool       `json:"has_more"`
+	Items      []StaleRun `json:"items"`
+}
+
+// PlaybookRunDryRun is what the creation of a playbook run would do, as validated by a dry run
+// that creates nothing.
+type PlaybookRunDryRun struct {
+	// Valid is false if the creation would fail, as explained by ValidationError.
+	Valid bool `json:"valid"`
+
+	// ValidationError is why the creation would fail, nil if it would succeed.
+	ValidationError *RunValidationError `json:"validation_error,omitempty"`
+
+	// PlaybookRun is the run that would be created, with its owner resolved and the settings of
+	// its playbook applied. It has no ID, as it isn't created. Nil if the creation would fail.
+	PlaybookRun *PlaybookRun `json:"playbook_run,omitempty"`
+
+	// CreateChannel is true if a new channel would be created for the run, false if the run
+	// would be linked to an existing one.
+	CreateChannel bool `json:"create_channel"`
+
+	// ChannelName is the name of the channel that would be created, or that of the linked one.
+	ChannelName string `json:"channel_name"`
+
+	// ChannelNameTaken is true if a channel of the team already has ChannelName, in which case
+	// random characters would be appended to the name of the new channel.
+	ChannelNameTaken bool `json:"channel_name_taken"`
+
+	// Public is true if the new channel would be public.
+	Public bool `json:"public"`
+}
+
+// RunValidationError explains why the creation of a playbook run would fail.
+type RunValidationError struct {
+	// Code identifies the kind of error, such as no_permissions or playbook_archived.
+	Code string `json:"code"`
+
+	// StatusCode is the HTTP status code the creation would fail with.
+	StatusCode int `json:"status_code"`
+
+	Message string `json:"message"`
+}
+
 type SQLStatusPost struct {
 	PlaybookRunID string
 	PostID        string
@@ -458,6 +922,25 @@ type SQLStatusPost struct {
 type RunMetricData struct {
 	MetricConfigID string   `json:"metric_config_id"`
 	Value          null.Int `json:"value"`
+
+	// Breached is true if Value misses the target of the metric. It's filled in when reading the
+	// run, and is always false for metrics without a target.
+	Breached bool `json:"breached"`
+}
+
+// RunFollower is a user following a run.
+type RunFollower struct {
+	PlaybookRunID string
+	UserID        string
+}
+
+// PropertyValue is the value of a property, defined on the playbook, for a run.
+type PropertyValue struct {
+	PropertyDefinitionID string `json:"property_definition_id"`
+
+	// Name is the name of the property definition, filled in when reading the run.
+	Name  string `json:"name"`
+	Value string `json:"value"`
 }
 
 type RetrospectiveUpdate struct {
@@ -498,6 +981,103 @@ type OwnerInfo struct {
 	Nickname  string `json:"nickname"`
 }
 
+// UserTaskLoad is the work a user has across the active runs of a team.
+type UserTaskLoad struct {
+	UserID string `json:"user_id"`
+
+	// IncompleteItems is the number of checklist items assigned to the user that are not done or
+	// skipped yet. Hidden items are not counted.
+	IncompleteItems int `json:"incomplete_items"`
+
+	// OwnedRuns is the number of active runs owned by the user.
+	OwnedRuns int `json:"owned_runs"`
+}
+
+// TaskLoadSort is the order of the users in a task load.
+type TaskLoadSort string
+
+const (
+	// TaskLoadSortByLoad sorts the users by decreasing number of incomplete items, then owned runs.
+	TaskLoadSortByLoad TaskLoadSort = "load"
+
+	// TaskLoadSortByOwnedRuns sorts the users by decreasing number of owned runs, then incomplete
+	// items.
+	TaskLoadSortByOwnedRuns TaskLoadSort = "owned_runs"
+)
+
+const (
+	// DefaultTaskLoadLimit is the number of users returned by a task load when no limit is given.
+	DefaultTaskLoadLimit = 20
+
+	// MaxTaskLoadLimit is the largest number of users returned by a task load.
+	MaxTaskLoadLimit = 200
+)
+
+// TaskLoadOptions selects the users of a task load.
+type TaskLoadOptions struct {
+	// TeamID is the team whose runs are counted. Required.
+	TeamID string
+
+	Sort  TaskLoadSort
+	Limit int
+}
+
+// Validate returns a copy of the options with the defaults set, or an error if they are invalid.
+func (o TaskLoadOptions) Validate() (TaskLoadOptions, error) {
+	options := o
+
+	if !model.IsValidId(options.TeamID) {
+		return TaskLoadOptions{}, errors.New("bad parameter 'team_id': must be 26 characters")
+	}
+
+	options.Sort = TaskLoadSort(strings.ToLower(string(options.Sort)))
+	switch options.Sort {
+	case TaskLoadSortByLoad:
+	case TaskLoadSortByOwnedRuns:
+	case "": // default
+		options.Sort = TaskLoadSortByLoad
+	default:
+		return TaskLoadOptions{}, errors.Errorf("unsupported sort '%s'", options.Sort)
+	}
+
+	if options.Limit < 0 {
+		return TaskLoadOptions{}, errors.New("bad parameter 'limit': must be positive")
+	}
+	if options.Limit == 0 {
+		options.Limit = DefaultTaskLoadLimit
+	}
+	if options.Limit > MaxTaskLoadLimit {
+		options.Limit = MaxTaskLoadLimit
+	}
+
+	return options, nil
+}
+
+const (
+	// DefaultRunUserAutocompleteLimit is the number of users returned by a run user autocomplete
+	// when no limit is given.
+	DefaultRunUserAutocompleteLimit = 20
+
+	// MaxRunUserAutocompleteLimit is the maximum number of users returned by a run user
+	// autocomplete.
+	MaxRunUserAutocompleteLimit = 100
+)
+
+// RunUserAutocompleteResult is a user of the team of a run matching an autocomplete search.
+type RunUserAutocompleteResult struct {
+	UserID    string `json:"user_id"`
+	Username  string `json:"username"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Nickname  string `json:"nickname"`
+
+	// DisplayName is the name of the user formatted per the name display setting of the server.
+	DisplayName string `json:"display_name"`
+
+	// InChannel is true if the user is a member of the channel of the run.
+	InChannel bool `json:"in_channel"`
+}
+
 // DialogState holds the start playbook run interactive dialog's state as it appears in the client
 // and is submitted back to the server.
 type DialogState struct {
@@ -518,6 +1098,18 @@ type RunLink struct {
 	ChannelDisplayName string
 }
 
+// StatusUpdateReminder represents the persisted state of the status update reminder of a run
+type StatusUpdateReminder struct {
+	PlaybookRunID      string
+	LastStatusUpdateAt int64
+	PreviousReminder   time.Duration
+}
+
+// DueAt returns the time at which the reminder is due.
+func (r StatusUpdateReminder) DueAt() time.Time {
+	return time.UnixMilli(r.LastStatusUpdateAt).Add(r.PreviousReminder)
+}
+
 // AssignedRun represents all the info needed to display a Run & ChecklistItem to a user
 type AssignedRun struct {
 	RunLink
@@ -571,9 +1163,26 @@ type PlaybookRunService interface {
 	// GetPlaybookRuns returns filtered playbook runs and the total count before paging.
 	GetPlaybookRuns(requesterInfo RequesterInfo, options PlaybookRunFilterOptions) (*GetPlaybookRunsResults, error)
 
+	// SearchStatusUpdates returns the status updates of the runs in teamID matching term,
+	// along with the total count before paging.
+	SearchStatusUpdates(requesterInfo RequesterInfo, teamID, term string, options StatusUpdateSearchOptions) (*SearchStatusUpdatesResults, error)
+
+	// GetStalePlaybookRuns returns the in-progress runs visible to the requester without activity
+	// for longer than olderThan, stalest first. All the teams of the requester are searched if
+	// teamID is empty.
+	GetStalePlaybookRuns(requesterInfo RequesterInfo, teamID string, olderThan time.Duration, options StaleRunsOptions) (*GetStaleRunsResults, error)
+
 	// CreatePlaybookRun creates a new playbook run. userID is the user who initiated the CreatePlaybookRun.
 	CreatePlaybookRun(playbookRun *PlaybookRun, playbook *Playbook, userID string, public bool) (*PlaybookRun, error)
 
+	// DryRunPlaybookRun validates the creation of playbookRun like CreatePlaybookRun, returning
+	// the run that would be created without creating anything.
+	DryRunPlaybookRun(playbookRun *PlaybookRun, playbook *Playbook, userID string, public bool) (*PlaybookRunDryRun, error)
+
+	// ClonePlaybookRun creates a new run, with a new channel, from the checklists, owner and
+	// broadcast settings of playbookRunID. userID is the user who initiated the clone.
+	ClonePlaybookRun(playbookRunID, userID string, options ClonePlaybookRunOptions) (*PlaybookRun, error)
+
 	// OpenCreatePlaybookRunDialog opens an interactive dialog to start a new playbook run.
 	OpenCreatePlaybookRunDialog(teamID, ownerID, triggerID, postID, clientID string, playbooks []Playbook, isMobileApp bool, promptPostID string) error
 
@@ -592,21 +1201,53 @@ type PlaybookRunService interface {
 	// RemoveTimelineEvent removes the timeline event (sets the DeleteAt to the current time).
 	RemoveTimelineEvent(playbookRunID, userID, eventID string) error
 
-	// UpdateStatus updates a playbook run's status.
-	UpdateStatus(playbookRunID, userID string, options StatusUpdateOptions) error
+	// AddKeyEvent adds a user-created event to the timeline of playbookRunID. The options must be
+	// validated. Returns ErrNotFound if the linked post doesn't exist, and ErrInvalidKeyEvent if it
+	// is not in the run's channel.
+	AddKeyEvent(playbookRunID, userID string, options KeyEventOptions) (*TimelineEvent, error)
+
+	// RemoveKeyEvent removes a user-created event from the timeline of playbookRunID. Returns
+	// ErrNotFound if the run has no such event, and ErrTimelineEventNotUserCreated for the events
+	// that users didn't create.
+	RemoveKeyEvent(playbookRunID, userID, eventID string) error
+
+	// UpdateStatus updates a playbook run's status. Returns the results of broadcasting the update
+	// to the broadcast channels of the run, if enabled.
+	UpdateStatus(playbookRunID, userID string, options StatusUpdateOptions) ([]BroadcastResult, error)
+
+	// RetryStatusUpdateBroadcast broadcasts the latest status update of a run again to channelIDs,
+	// which must be broadcast channels of the run, e.g. to those a previous broadcast failed for.
+	RetryStatusUpdateBroadcast(playbookRunID, userID string, channelIDs []string) ([]BroadcastResult, error)
 
 	// OpenFinishPlaybookRunDialog opens the dialog to confirm the run should be finished.
 	OpenFinishPlaybookRunDialog(playbookRunID, triggerID string) error
 
 	// FinishPlaybookRun changes a run's state to Finished. If run is already in Finished state, the call is a noop.
+	// Returns ErrRetrospectiveRequired if the run cannot finish before its retrospective is published.
 	FinishPlaybookRun(playbookRunID, userID string) error
 
+	// MergePlaybookRuns merges the run sourceRunID into targetRunID, appending its checklists and
+	// adding its participants, tags and status updates, then ends the source run as merged and
+	// archives its channel unless another run still uses it. Returns the updated target run.
+	// Returns ErrInvalidRunMerge for a run merged into itself or into a run of another team, and
+	// ErrPlaybookRunNotActive if either run has ended.
+	MergePlaybookRuns(targetRunID, sourceRunID, userID string) (*PlaybookRun, error)
+
 	// ToggleStatusUpdates  enables or disables status update for the run
 	ToggleStatusUpdates(playbookRunID, userID string, enable bool) error
 
 	// GetPlaybookRun gets a playbook run by ID. Returns error if it could not be found.
 	GetPlaybookRun(playbookRunID string) (*PlaybookRun, error)
 
+	// GetPlaybookRunsBatch gets the runs of options.PlaybookRunIDs that userID can view, reporting
+	// the others as omitted.
+	GetPlaybookRunsBatch(userID string, options PlaybookRunsBatchOptions) (*PlaybookRunsBatchResult, error)
+
+	// GetRunActivity gets a page of the activity feed of a playbook run: its timeline events,
+	// status updates and checklist item completions, oldest first. Pages have up to
+	// MaxRunActivityPerPage entries.
+	GetRunActivity(playbookRunID string, options RunActivityOptions) (*RunActivityResults, error)
+
 	// GetPlaybookRunMetadata gets ancillary metadata about a playbook run.
 	GetPlaybookRunMetadata(playbookRunID string) (*Metadata, error)
 
@@ -614,9 +1255,23 @@ type PlaybookRunService interface {
 	// if there is no playbook run associated with this channel.
 	GetPlaybookRunIDForChannel(channelID string) (string, error)
 
+	// GetActivePlaybookRunIDsForChannel gets the IDs of the runs associated with this channel that
+	// are not finished yet.
+	GetActivePlaybookRunIDsForChannel(channelID string) ([]string, error)
+
 	// GetOwners returns all the owners of playbook runs selected
 	GetOwners(requesterInfo RequesterInfo, options PlaybookRunFilterOptions) ([]OwnerInfo, error)
 
+	// GetTaskLoad returns, for the users with work in the active runs of a team visible to the
+	// requester, their incomplete assigned checklist items and owned runs. Options must be
+	// validated.
+	GetTaskLoad(requesterInfo RequesterInfo, options TaskLoadOptions) ([]UserTaskLoad, error)
+
+	// AutocompleteRunUsers returns up to limit active users of the team of the run whose
+	// username, first name, last name or nickname starts with prefix, members of the channel of
+	// the run first. A limit of 0 uses DefaultRunUserAutocompleteLimit.
+	AutocompleteRunUsers(requesterUserID, playbookRunID, prefix string, limit int) ([]RunUserAutocompleteResult, error)
+
 	// IsOwner returns true if the userID is the owner for playbookRunID.
 	IsOwner(playbookRunID string, userID string) bool
 
@@ -624,9 +1279,31 @@ type PlaybookRunService interface {
 	// to ownerID. Changing to the same ownerID is a no-op.
 	ChangeOwner(playbookRunID string, userID string, ownerID string) error
 
+	// AddCoOwner processes a request from userID to make coOwnerID a co-owner of playbookRunID.
+	// Adding an existing co-owner is a no-op.
+	AddCoOwner(playbookRunID string, userID string, coOwnerID string) error
+
+	// AddTags tags playbookRunID with every one of tags. Returns ErrInvalidRunTag if any tag is invalid.
+	AddTags(playbookRunID string, tags []string) error
+
+	// RemoveTag removes tag from the tags of playbookRunID.
+	RemoveTag(playbookRunID string, tag string) error
+
+	// GetTeamTags returns the tags used by runs of teamID starting with prefix, most used first.
+	GetTeamTags(teamID, prefix string) ([]string, error)
+
+	// RemoveCoOwner processes a request from userID to remove coOwnerID from the co-owners of
+	// playbookRunID. Removing a user that is not a co-owner is a no-op.
+	RemoveCoOwner(playbookRunID string, userID string, coOwnerID string) error
+
+	// SetPropertyValue processes a request from userID to set the value of the property
+	// propertyDefinitionID of playbookRunID. The empty value unsets the property.
+	SetPropertyValue(playbookRunID, userID, propertyDefinitionID, value string) error
+
 	// ModifyCheckedState modifies the state of the specified checklist item
 	// Idempotent, will not perform any actions if the checklist item is already in the specified state
-	ModifyCheckedState(playbookRunID, userID, newState string, checklistNumber int, itemNumber int) error
+	// Returns ErrChecklistItemBlocked when checking off a blocked item of a run enforcing dependencies.
+	ModifyCheckedState(playbookRunID, userID, newState, skipReason string, checklistNumber int, itemNumber int) error
 
 	// ToggleCheckedState checks or unchecks the specified checklist item
 	ToggleCheckedState(playbookRunID, userID string, checklistNumber, itemNumber int) error
@@ -641,15 +1318,25 @@ type PlaybookRunService interface {
 	// SetDueDate sets absolute due date timestamp for the specified checklist item
 	SetDueDate(playbookRunID, userID string, duedate int64, checklistNumber, itemNumber int) error
 
+	// SetDueOffset sets the due date of the specified checklist item relative to the start of the run
+	SetDueOffset(playbookRunID, userID string, dueOffset int64, checklistNumber, itemNumber int) error
+
 	// RunChecklistItemSlashCommand executes the slash command associated with the specified checklist item.
 	RunChecklistItemSlashCommand(playbookRunID, userID string, checklistNumber, itemNumber int) (string, error)
 
+	// GetCommandOutput returns the command output outputID of the run playbookRunID.
+	GetCommandOutput(playbookRunID, outputID string) (*CommandOutput, error)
+
 	// DuplicateChecklistItem duplicates the checklist item.
 	DuplicateChecklistItem(playbookRunID, userID string, checklistNumber, itemNumber int) error
 
 	// AddChecklistItem adds an item to the specified checklist
 	AddChecklistItem(playbookRunID, userID string, checklistNumber int, checklistItem ChecklistItem) error
 
+	// AddChecklistItemsFromPosts appends a checklist item for each of the given posts of the run
+	// channel to the specified checklist, skipping the posts that cannot be added.
+	AddChecklistItemsFromPosts(playbookRunID, userID string, checklistNumber int, options AddChecklistItemsFromPostsOptions) (*AddChecklistItemsFromPostsResult, error)
+
 	// RemoveChecklistItem removes an item from the specified checklist
 	RemoveChecklistItem(playbookRunID, userID string, checklistNumber int, itemNumber int) error
 
@@ -677,6 +1364,13 @@ type PlaybookRunService interface {
 	// MoveChecklistItem moves a checklist item from one position to another.
 	MoveChecklistItem(playbookRunID, userID string, sourceChecklistIdx, sourceItemIdx, destChecklistIdx, destItemIdx int) error
 
+	// ReorderChecklistItems sets the order of all the items in a checklist at once.
+	ReorderChecklistItems(playbookRunID, userID string, checklistNumber int, itemIDs []string) error
+
+	// ReassignTasks processes a request from userID to assign the incomplete checklist items of
+	// options.FromUserID to options.ToUserID, in the runs userID can manage.
+	ReassignTasks(userID string, options ReassignTasksOptions) (*ReassignTasksResult, error)
+
 	// GetChecklistItemAutocomplete returns the list of checklist items for playbookRunID to be used in autocomplete
 	GetChecklistItemAutocomplete(playbookRunID string) ([]model.AutocompleteListItem, error)
 
@@ -695,13 +1389,17 @@ type PlaybookRunService interface {
 	// NukeDB removes all playbook run related data.
 	NukeDB() error
 
-	// SetReminder sets a reminder. After time.Now().Add(fromNow) in the future,
-	// the owner will be reminded to update the playbook run's status.
+	// SetReminder sets a reminder, replacing any pending one. After time.Now().Add(fromNow) in
+	// the future, the owner will be reminded to update the playbook run's status.
 	SetReminder(playbookRunID string, fromNow time.Duration) error
 
 	// RemoveReminder removes the pending reminder for playbookRunID (if any).
 	RemoveReminder(playbookRunID string)
 
+	// RestoreReminders schedules the status update reminders of the active runs that are
+	// missing from the scheduler, so that no reminder is lost across plugin restarts.
+	RestoreReminders() error
+
 	// HandleReminder is the handler for all reminder events.
 	HandleReminder(key string)
 
@@ -746,6 +1444,10 @@ type PlaybookRunService interface {
 	// GetOverdueUpdateRuns returns the list of userID's runs that have overdue updates
 	GetOverdueUpdateRuns(userID string) ([]RunLink, error)
 
+	// GetOverdueChecklistItems returns the list of active runs that have open checklist items
+	// past their due date, along with those items
+	GetOverdueChecklistItems() ([]AssignedRun, error)
+
 	// Follow method lets user follow a specific playbook run
 	Follow(playbookRunID, userID string) error
 
@@ -758,6 +1460,18 @@ type PlaybookRunService interface {
 	// RestorePlaybookRun reverts a run from the Finished state. If run was not in Finished state, the call is a noop.
 	RestorePlaybookRun(playbookRunID, userID string) error
 
+	// RestartPlaybookRun reverts a run from the Finished state to continue it, unarchiving its
+	// channel if needed. Returns ErrPlaybookRunNotActive if the run was merged, and
+	// ErrPlaybookRunNotFinished if it is not finished.
+	RestartPlaybookRun(playbookRunID, userID string) error
+
+	// PausePlaybookRun changes a run's state to Paused, stopping its status update reminders. If run is
+	// already in Paused state, the call is a noop.
+	PausePlaybookRun(playbookRunID, userID string) error
+
+	// ResumePlaybookRun reverts a run from the Paused state. If run was not in Paused state, the call is a noop.
+	ResumePlaybookRun(playbookRunID, userID string) error
+
 	// RequestUpdate posts a status update request message in the run's channel
 	RequestUpdate(playbookRunID, requesterID string) error
 
@@ -770,6 +1484,10 @@ type PlaybookRunService interface {
 	// AddParticipants adds users to the participants list
 	AddParticipants(playbookRunID string, userIDs []string, requesterUserID string, forceAddToChannel bool) error
 
+	// SetParticipantRole makes a participant of the run an active participant or a viewer,
+	// on behalf of requesterUserID. The owner and co-owners cannot be made viewers.
+	SetParticipantRole(playbookRunID, userID string, role ParticipantRole, requesterUserID string) error
+
 	// GetPlaybookRunIDsForUser returns run ids where user is a participant or is following
 	GetPlaybookRunIDsForUser(userID string) ([]string, error)
 
@@ -785,13 +1503,32 @@ type PlaybookRunService interface {
 
 	// GraphqlUpdate taking a setmap for graphql
 	GraphqlUpdate(id string, setmap map[string]interface{}) error
+
+	// ExportPlaybookRun renders the playbook run in the given format, using the timezone of
+	// requesterID for all the timestamps.
+	ExportPlaybookRun(playbookRunID, requesterID string, format RunExportFormat) ([]byte, error)
+
+	// ExportPlaybookRunsCSV writes the runs of teamID visible to the requester to w as CSV, oldest
+	// first, reading and writing them one page at a time.
+	ExportPlaybookRunsCSV(requesterInfo RequesterInfo, teamID string, w io.Writer) error
 }
 
+// RunExportFormat is a format a playbook run can be exported to.
+type RunExportFormat string
+
+const (
+	RunExportFormatMarkdown RunExportFormat = "md"
+)
+
 // PlaybookRunStore defines the methods the PlaybookRunServiceImpl needs from the interfaceStore.
 type PlaybookRunStore interface {
 	// GetPlaybookRuns returns filtered playbook runs and the total count before paging.
 	GetPlaybookRuns(requesterInfo RequesterInfo, options PlaybookRunFilterOptions) (*GetPlaybookRunsResults, error)
 
+	// SearchStatusUpdates returns the status updates of the runs in teamID matching term,
+	// along with the total count before paging.
+	SearchStatusUpdates(requesterInfo RequesterInfo, teamID, term string, options StatusUpdateSearchOptions) (*SearchStatusUpdatesResults, error)
+
 	// CreatePlaybookRun creates a new playbook run. If playbook run has an ID, that ID will be used.
 	CreatePlaybookRun(playbookRun *PlaybookRun) (*PlaybookRun, error)
 
@@ -804,12 +1541,27 @@ type PlaybookRunStore interface {
 	// UpdateStatus updates the status of a playbook run.
 	UpdateStatus(statusPost *SQLStatusPost) error
 
-	// FinishPlaybookRun finishes a run at endAt (in millis)
-	FinishPlaybookRun(playbookRunID string, endAt int64) error
+	// FinishPlaybookRun finishes a run at endAt (in millis), scheduling the archive of its channel
+	// if enabled. Returns false if the run had already ended, in which case it is left unchanged.
+	FinishPlaybookRun(playbookRunID string, endAt int64) (bool, error)
+
+	// MergePlaybookRuns stores a merge of runs in a single transaction, ending the source run as
+	// merged into the target run. Returns ErrNotFound if either run does not exist, and
+	// ErrPlaybookRunNotActive if either run has ended.
+	MergePlaybookRuns(merge RunMerge) error
 
 	// RestorePlaybookRun restores a run at restoreAt (in millis)
 	RestorePlaybookRun(playbookRunID string, restoreAt int64) error
 
+	// PausePlaybookRun pauses a run at pausedAt (in millis). Returns false if the run was not in
+	// progress, in which case it is left unchanged.
+	PausePlaybookRun(playbookRunID string, pausedAt int64) (bool, error)
+
+	// ResumePlaybookRun resumes a run at resumedAt (in millis), adding the time since it was paused
+	// to its paused duration. Returns false if the run was not paused, in which case it is left
+	// unchanged.
+	ResumePlaybookRun(playbookRunID string, resumedAt int64) (bool, error)
+
 	// GetTimelineEvent returns the timeline event for playbookRunID by the timeline event ID.
 	GetTimelineEvent(playbookRunID, eventID string) (*TimelineEvent, error)
 
@@ -822,9 +1574,32 @@ type PlaybookRunStore interface {
 	// GetPlaybookRun gets a playbook run by ID.
 	GetPlaybookRun(playbookRunID string) (*PlaybookRun, error)
 
+	// GetPlaybookRunActivity gets a playbook run by ID with what its activity feed after the
+	// position (afterAt, afterID) is built from: its checklists, the first limit of its status
+	// posts and timeline events after the position, ordered by time and then by ID, and the
+	// status update events of those posts. Deleted status posts and timeline events are left out.
+	// Timeline events recording the completion of a checklist item don't count toward the limit,
+	// since the feed shows the item instead.
+	GetPlaybookRunActivity(playbookRunID string, afterAt int64, afterID string, limit int) (*PlaybookRun, error)
+
+	// GetPlaybookRunsByIDs gets the playbook runs with the given IDs, in no particular order.
+	// IDs without a run are ignored.
+	GetPlaybookRunsByIDs(playbookRunIDs []string) ([]PlaybookRun, error)
+
 	// GetPlaybookRunByChannel gets a playbook run associated with the given channel id.
 	GetPlaybookRunIDForChannel(channelID string) (string, error)
 
+	// GetActivePlaybookRunIDsForChannel gets the IDs of the unfinished runs associated with the
+	// given channel id.
+	GetActivePlaybookRunIDsForChannel(channelID string) ([]string, error)
+
+	// GetPlaybookRunsDueForChannelArchive returns the finished runs whose channel was due to be
+	// archived at or before now, in millis.
+	GetPlaybookRunsDueForChannelArchive(now int64) ([]PlaybookRun, error)
+
+	// ClearChannelAutoArchive marks the automatic archive of the channel of the run as handled.
+	ClearChannelAutoArchive(playbookRunID string) error
+
 	// GetHistoricalPlaybookRunParticipantsCount returns the count of all participants of the
 	// playbook run associated with the given channel id since the beginning of the
 	// playbook run, excluding bots.
@@ -833,6 +1608,16 @@ type PlaybookRunStore interface {
 	// GetOwners returns the owners of the playbook runs selected by options
 	GetOwners(requesterInfo RequesterInfo, options PlaybookRunFilterOptions) ([]OwnerInfo, error)
 
+	// GetTaskLoad returns the incomplete assigned checklist items and owned runs of the users with
+	// work in the unfinished runs of options.TeamID visible to the requester, sorted and limited by
+	// options. Options must be validated.
+	GetTaskLoad(requesterInfo RequesterInfo, options TaskLoadOptions) ([]UserTaskLoad, error)
+
+	// GetUsersForAutocomplete returns up to limit active members of teamID whose username or
+	// nickname, or first or last name if showFullName, starts with prefix, ignoring case. Members
+	// of channelID come first, then users are sorted by username. DisplayName is left empty.
+	GetUsersForAutocomplete(teamID, channelID, prefix string, showFullName bool, limit int) ([]RunUserAutocompleteResult, error)
+
 	// NukeDB removes all playbook run related data.
 	NukeDB() error
 
@@ -856,6 +1641,27 @@ type PlaybookRunStore interface {
 	// GetOverdueUpdateRuns returns the list of runs that userID is participating in that have overdue updates
 	GetOverdueUpdateRuns(userID string) ([]RunLink, error)
 
+	// ReorderChecklistItems atomically sets the order of the items in the given checklist to
+	// the one in itemIDs. Returns ErrInvalidChecklistItemOrder if itemIDs does not match the items.
+	ReorderChecklistItems(playbookRunID string, checklistNumber int, itemIDs []string) error
+
+	// GetRunIDsWithIncompleteTasks returns the unfinished runs with checklist items assigned to
+	// assigneeID that are not done or skipped yet. The runs are limited to teamID and to
+	// playbookRunIDs, unless they are empty.
+	GetRunIDsWithIncompleteTasks(assigneeID, teamID string, playbookRunIDs []string) ([]string, error)
+
+	// ReassignChecklistItems assigns to toUserID the incomplete checklist items of fromUserID in
+	// every given run, in a single transaction. Returns the number of reassigned items by run.
+	ReassignChecklistItems(playbookRunIDs []string, fromUserID, toUserID string, modifiedAt int64) (map[string]int, error)
+
+	// GetRunsWithOverdueTasks returns the list of active runs that have open tasks whose due date
+	// is at or before now
+	GetRunsWithOverdueTasks(now int64) ([]AssignedRun, error)
+
+	// GetStatusUpdateReminders returns the status update reminders of the active runs that are
+	// waiting for a reminder to be posted
+	GetStatusUpdateReminders() ([]StatusUpdateReminder, error)
+
 	// Follow method lets user follow a specific playbook run
 	Follow(playbookRunID, userID string) error
 
@@ -865,6 +1671,17 @@ type PlaybookRunStore interface {
 	// GetFollowers returns list of followers for a specific playbook run
 	GetFollowers(playbookRunID string) ([]string, error)
 
+	// GetRunFollowersSince returns the followers of the runs that were in progress at any time
+	// since the given time, in millis, ordered by follower.
+	GetRunFollowersSince(since int64) ([]RunFollower, error)
+
+	// GetFollowerDigestSentAt returns the time, in millis, the last follower digest was sent at,
+	// or 0 if none was sent yet.
+	GetFollowerDigestSentAt() (int64, error)
+
+	// SetFollowerDigestSentAt stores the time, in millis, the last follower digest was sent at.
+	SetFollowerDigestSentAt(sentAt int64) error
+
 	// GetRunsActiveTotal returns number of active runs
 	GetRunsActiveTotal() (int64, error)
 
@@ -886,9 +1703,48 @@ type PlaybookRunStore interface {
 	// AddParticipants adds particpants to the run
 	AddParticipants(playbookRunID string, userIDs []string) error
 
-	// RemoveParticipants removes participants from the run
+	// RemoveParticipants removes participants from the run. They lose their role, if any.
 	RemoveParticipants(playbookRunID string, userIDs []string) error
 
+	// SetParticipantViewer makes a participant of the run a viewer, or an active participant.
+	SetParticipantViewer(playbookRunID, userID string, isViewer bool) error
+
+	// AddCoOwner makes userID a co-owner of the run
+	AddCoOwner(playbookRunID string, userID string) error
+
+	// RemoveCoOwners removes userIDs from the co-owners of the run
+	RemoveCoOwners(playbookRunID string, userIDs []string) error
+
+	// AddRunTags adds tags to the run, ignoring the ones it already has
+	AddRunTags(playbookRunID string, tags []string) error
+
+	// RemoveRunTag removes tag from the run
+	RemoveRunTag(playbookRunID string, tag string) error
+
+	// GetTeamRunTags returns up to limit distinct tags of the runs of teamID starting with prefix,
+	// the most used first
+	GetTeamRunTags(teamID, prefix string, limit int) ([]string, error)
+
+	// GetPlaybookRunsForExport returns up to limit runs of teamID visible to the requester that
+	// were created after the cursor, ordered by creation.
+	GetPlaybookRunsForExport(requesterInfo RequesterInfo, teamID string, after RunExportCursor, limit int) ([]RunExportRow, error)
+
+	// GetStalePlaybookRuns returns the in-progress runs visible to the requester whose last
+	// activity was before lastActivityBefore (in millis), stalest first. All the teams of the
+	// requester are searched if teamID is empty.
+	GetStalePlaybookRuns(requesterInfo RequesterInfo, teamID string, lastActivityBefore int64, options StaleRunsOptions) (*GetStaleRunsResults, error)
+
+	// SetPropertyValue sets the value of the property propertyDefinitionID of the run,
+	// removing it if value is empty.
+	SetPropertyValue(playbookRunID, propertyDefinitionID, value string) error
+
+	// CreateCommandOutput stores the output of the slash command of a checklist item.
+	CreateCommandOutput(output CommandOutput) error
+
+	// GetCommandOutput returns the command output outputID of the run playbookRunID. Returns
+	// ErrNotFound if not found.
+	GetCommandOutput(playbookRunID, outputID string) (*CommandOutput, error)
+
 	// GetSchemeRolesForChannel scheme role ids for the channel
 	GetSchemeRolesForChannel(channelID string) (string, string, string, error)
 
@@ -1011,6 +1867,139 @@ type JobOnceScheduler interface {
 
 const PerPageDefault = 1000
 
+// ClonePlaybookRunOptions specifies what ClonePlaybookRun copies from the source run.
+type ClonePlaybookRunOptions struct {
+	// CopyParticipants, if true, invites the participants of the source run to the new run.
+	CopyParticipants bool
+
+	// ResetItemStates, if true, reopens every checklist item of the new run. Otherwise the
+	// items keep the state they have in the source run.
+	ResetItemStates bool
+}
+
+// ReassignTasksOptions specifies whose tasks ReassignTasks reassigns, to whom, and in which runs.
+type ReassignTasksOptions struct {
+	FromUserID string `json:"from_user_id"`
+	ToUserID   string `json:"to_user_id"`
+
+	// TeamID, if not empty, limits the reassignment to the runs of this team.
+	TeamID string `json:"team_id"`
+
+	// PlaybookRunIDs, if not empty, limits the reassignment to these runs.
+	PlaybookRunIDs []string `json:"playbook_run_ids"`
+}
+
+// Validate checks that the options name two different users, and valid team and run IDs.
+func (o ReassignTasksOptions) Validate() error {
+	if !model.IsValidId(o.FromUserID) {
+		return errors.New("bad parameter 'from_user_id': must be 26 characters")
+	}
+	if !model.IsValidId(o.ToUserID) {
+		return errors.New("bad parameter 'to_user_id': must be 26 characters")
+	}
+	if o.FromUserID == o.ToUserID {
+		return errors.New("bad parameter 'to_user_id': must be different from 'from_user_id'")
+	}
+	if o.TeamID != "" && !model.IsValidId(o.TeamID) {
+		return errors.New("bad parameter 'team_id': must be 26 characters or blank")
+	}
+	for _, playbookRunID := range o.PlaybookRunIDs {
+		if !model.IsValidId(playbookRunID) {
+			return errors.New("bad parameter 'playbook_run_ids': must be 26 characters each")
+		}
+	}
+
+	return nil
+}
+
+// AddChecklistItemsFromPostsOptions specifies the posts of the run channel that
+// AddChecklistItemsFromPosts turns into checklist items: either the given posts, in the given
+// order, or the most recent ones, oldest first.
+type AddChecklistItemsFromPostsOptions struct {
+	PostIDs     []string `json:"post_ids"`
+	RecentPosts int      `json:"recent_posts"`
+}
+
+// Validate checks that the options name either valid post IDs or a number of recent posts, and
+// no more than MaxChecklistItemsFromPosts posts.
+func (o AddChecklistItemsFromPostsOptions) Validate() error {
+	if len(o.PostIDs) > 0 && o.RecentPosts != 0 {
+		return errors.New("bad parameter: only one of 'post_ids' and 'recent_posts' can be set")
+	}
+	if len(o.PostIDs) == 0 && o.RecentPosts <= 0 {
+		return errors.New("bad parameter: either 'post_ids' or a positive 'recent_posts' must be set")
+	}
+	if len(o.PostIDs) > MaxChecklistItemsFromPosts || o.RecentPosts > MaxChecklistItemsFromPosts {
+		return errors.Errorf("bad parameter: at most %d posts can be added at once", MaxChecklistItemsFromPosts)
+	}
+	for _, postID := range o.PostIDs {
+		if !model.IsValidId(postID) {
+			return errors.New("bad parameter 'post_ids': must be 26 characters each")
+		}
+	}
+
+	return nil
+}
+
+// AddChecklistItemsFromPostsResult summarizes the changes of AddChecklistItemsFromPosts.
+type AddChecklistItemsFromPostsResult struct {
+	ItemsAdded int `json:"items_added"`
+
+	// PostsSkipped is the number of posts that were not added because they were deleted, could
+	// not be read, were not in the run channel, were system messages or had no text.
+	PostsSkipped int `json:"posts_skipped"`
+}
+
+// ReassignTasksResult summarizes the changes of ReassignTasks.
+type ReassignTasksResult struct {
+	ItemsReassigned int `json:"items_reassigned"`
+	RunsChanged     int `json:"runs_changed"`
+
+	// SkippedRunIDs are the runs with tasks to reassign that the user is not allowed to manage.
+	SkippedRunIDs []string `json:"skipped_run_ids"`
+}
+
+// MaxPlaybookRunsBatch is the maximum number of runs that can be fetched at once by ID.
+const MaxPlaybookRunsBatch = 100
+
+// PlaybookRunsBatchOptions specifies the runs to fetch at once.
+type PlaybookRunsBatchOptions struct {
+	PlaybookRunIDs []string `json:"playbook_run_ids"`
+}
+
+// Validate checks that the options name between one and MaxPlaybookRunsBatch valid run IDs.
+func (o PlaybookRunsBatchOptions) Validate() error {
+	if len(o.PlaybookRunIDs) == 0 {
+		return errors.New("bad parameter 'playbook_run_ids': must not be empty")
+	}
+	if len(o.PlaybookRunIDs) > MaxPlaybookRunsBatch {
+		return errors.Errorf("bad parameter 'playbook_run_ids': at most %d runs can be fetched at once", MaxPlaybookRunsBatch)
+	}
+	for _, playbookRunID := range o.PlaybookRunIDs {
+		if !model.IsValidId(playbookRunID) {
+			return errors.New("bad parameter 'playbook_run_ids': must be 26 characters each")
+		}
+	}
+
+	return nil
+}
+
+// PlaybookRunsBatchResult holds the runs fetched by GetPlaybookRunsBatch.
+type PlaybookRunsBatchResult struct {
+	// Items are the requested runs the user can view, in the order they were requested.
+	Items []PlaybookRun `json:"items"`
+
+	// OmittedRunIDs are the requested runs that don't exist or that the user is not allowed to
+	// view. Both are reported the same way, so that the response doesn't reveal which runs exist.
+	OmittedRunIDs []string `json:"omitted_run_ids"`
+}
+
+// StatusUpdateSearchOptions specifies the pagination of a status update search.
+type StatusUpdateSearchOptions struct {
+	Page    int `url:"page,omitempty"`
+	PerPage int `url:"per_page,omitempty"`
+}
+
 // PlaybookRunFilterOptions specifies the optional parameters when getting playbook runs.
 type PlaybookRunFilterOptions struct {
 	// Gets all the headers with this TeamID.
@@ -1039,6 +2028,10 @@ type PlaybookRunFilterOptions struct {
 	// ParticipantOrFollowerID filters playbook runs that have this user as member or as follower. Defaults to blank (no filter).
 	ParticipantOrFollowerID string `url:"participant_or_follower,omitempty"`
 
+	// AssigneeID filters playbook runs that have at least one open checklist item assigned to
+	// this user. Defaults to blank (no filter).
+	AssigneeID string `url:"assignee_id,omitempty"`
+
 	// IncludeFavorites filters playbook runs that ParticipantOrFollowerID has marked as favorite.
 	// There's no impact if ParticipantOrFollowerID is empty.
 	IncludeFavorites bool `url:"include_favorites,omitempty"`
@@ -1070,6 +2063,14 @@ type PlaybookRunFilterOptions struct {
 
 	// ChannelID filters to playbook runs that are associated with the given channel ID
 	ChannelID string `url:"channel_id,omitempty"`
+
+	// PropertyValues filters playbook runs that have every given property set to the given value.
+	// The names of the values are ignored.
+	PropertyValues []PropertyValue
+
+	// Tags filters playbook runs that have any of the given tags, or all of them if TagsMatchAll.
+	Tags         []string `url:"tags,omitempty"`
+	TagsMatchAll bool     `url:"tags_match_all,omitempty"`
 }
 
 // Clone duplicates the given options.
@@ -1078,6 +2079,12 @@ func (o *PlaybookRunFilterOptions) Clone() PlaybookRunFilterOptions {
 	if len(o.Statuses) > 0 {
 		newPlaybookRunFilterOptions.Statuses = append([]string{}, o.Statuses...)
 	}
+	if len(o.PropertyValues) > 0 {
+		newPlaybookRunFilterOptions.PropertyValues = append([]PropertyValue{}, o.PropertyValues...)
+	}
+	if len(o.Tags) > 0 {
+		newPlaybookRunFilterOptions.Tags = append([]string{}, o.Tags...)
+	}
 
 	return newPlaybookRunFilterOptions
 }
@@ -1129,6 +2136,10 @@ func (o PlaybookRunFilterOptions) Validate() (PlaybookRunFilterOptions, error) {
 		return PlaybookRunFilterOptions{}, errors.New("bad parameter 'participant_id': must be 26 characters or blank")
 	}
 
+	if options.AssigneeID != "" && !model.IsValidId(options.AssigneeID) {
+		return PlaybookRunFilterOptions{}, errors.New("bad parameter 'assignee_id': must be 26 characters or blank")
+	}
+
 	if options.ParticipantOrFollowerID != "" && !model.IsValidId(options.ParticipantOrFollowerID) {
 		return PlaybookRunFilterOptions{}, errors.New("bad parameter 'participant_or_follower_id': must be 26 characters or blank")
 	}
@@ -1156,13 +2167,48 @@ func (o PlaybookRunFilterOptions) Validate() (PlaybookRunFilterOptions, error) {
 
 	for _, s := range options.Statuses {
 		if !validStatus(s) {
-			return PlaybookRunFilterOptions{}, errors.New("bad parameter in 'statuses': must be InProgress or Finished")
+			return PlaybookRunFilterOptions{}, errors.New("bad parameter in 'statuses': must be InProgress, Paused, Finished or Merged")
+		}
+	}
+
+	for _, v := range options.PropertyValues {
+		if !model.IsValidId(v.PropertyDefinitionID) {
+			return PlaybookRunFilterOptions{}, errors.New("bad parameter 'property': must be a property definition ID of 26 characters followed by a colon and a value")
 		}
 	}
 
+	for i, tag := range options.Tags {
+		normalized, err := NormalizeRunTag(tag)
+		if err != nil {
+			return PlaybookRunFilterOptions{}, errors.Wrap(err, "bad parameter 'tags'")
+		}
+		options.Tags[i] = normalized
+	}
+
 	return options, nil
 }
 
+// MaxRunTagLength is the maximum length, in characters, of a run tag.
+const MaxRunTagLength = 64
+
+// NormalizeRunTag returns tag trimmed and lowercased, so that tags differing only in case or
+// surrounding spaces are the same tag. Returns ErrInvalidRunTag if the tag is empty, too long or
+// contains a comma or a slash.
+func NormalizeRunTag(tag string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(tag))
+	if normalized == "" {
+		return "", errors.Wrap(ErrInvalidRunTag, "tag must not be empty")
+	}
+	if utf8.RuneCountInString(normalized) > MaxRunTagLength {
+		return "", errors.Wrapf(ErrInvalidRunTag, "tag %q is longer than %d characters", tag, MaxRunTagLength)
+	}
+	if strings.ContainsAny(normalized, ",/") {
+		return "", errors.Wrapf(ErrInvalidRunTag, "tag %q contains a comma or a slash", tag)
+	}
+
+	return normalized, nil
+}
+
 func validStatus(status string) bool {
-	return status == "" || status == StatusInProgress || status == StatusFinished
+	return status == "" || status == StatusInProgress || status == StatusPaused || status == StatusFinished || status == StatusMerged
 }
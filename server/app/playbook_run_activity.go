@@ -0,0 +1,221 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// MaxRunActivityPerPage is the maximum, and default, number of entries of a page of a run's
+// activity feed.
+const MaxRunActivityPerPage = 200
+
+// RunActivityType is the kind of the entry of a run's activity feed.
+type RunActivityType string
+
+const (
+	// RunActivityTimelineEvent is an event of the run's timeline, other than a status update.
+	RunActivityTimelineEvent RunActivityType = "timeline_event"
+
+	// RunActivityStatusUpdate is a status update posted in the run.
+	RunActivityStatusUpdate RunActivityType = "status_update"
+
+	// RunActivityChecklistItemCompleted is a checklist item that was checked off.
+	RunActivityChecklistItemCompleted RunActivityType = "checklist_item_completed"
+)
+
+// RunActivity is an entry of the activity feed of a run. Exactly one of TimelineEvent,
+// StatusUpdate and ChecklistItem is set, depending on Type.
+type RunActivity struct {
+	// ID is the identifier of the timeline event, status update post or checklist item.
+	ID string `json:"id"`
+
+	// Type discriminates the kind of the entry.
+	Type RunActivityType `json:"type"`
+
+	// At is the timestamp, in milliseconds since epoch, of the time the activity happened.
+	At int64 `json:"at"`
+
+	// UserID is the identifier of the user that acted. Empty if unknown, e.g. for status updates
+	// posted before they were recorded in the timeline.
+	UserID string `json:"user_id"`
+
+	TimelineEvent *TimelineEvent `json:"timeline_event,omitempty"`
+	StatusUpdate  *StatusPost    `json:"status_update,omitempty"`
+	ChecklistItem *ChecklistItem `json:"checklist_item,omitempty"`
+
+	// ChecklistTitle is the title of the checklist of ChecklistItem.
+	ChecklistTitle string `json:"checklist_title,omitempty"`
+}
+
+// cursor returns the position of the entry in the feed, after which the next page starts.
+func (a RunActivity) cursor() string {
+	return fmt.Sprintf("%d:%s", a.At, a.ID)
+}
+
+// before returns whether a comes before b in the feed. Entries are ordered by time, and then by ID
+// so that entries at the same time keep their order across pages.
+func (a RunActivity) before(b RunActivity) bool {
+	if a.At != b.At {
+		return a.At < b.At
+	}
+	return a.ID < b.ID
+}
+
+// RunActivityOptions specifies the pagination of a run's activity feed.
+type RunActivityOptions struct {
+	// Cursor, if not empty, is the NextCursor of the previous page.
+	Cursor string `url:"cursor,omitempty"`
+
+	// PerPage is the number of entries of the page, up to MaxRunActivityPerPage.
+	PerPage int `url:"per_page,omitempty"`
+}
+
+// perPage returns the number of entries of the page, defaulting to and capped at
+// MaxRunActivityPerPage.
+func (o RunActivityOptions) perPage() int {
+	if o.PerPage <= 0 || o.PerPage > MaxRunActivityPerPage {
+		return MaxRunActivityPerPage
+	}
+	return o.PerPage
+}
+
+// RunActivityResults is a page of a run's activity feed, oldest first.
+type RunActivityResults struct {
+	Items   []RunActivity `json:"items"`
+	HasMore bool          `json:"has_more"`
+
+	// NextCursor is the cursor of the next page. Since the feed is paginated by position rather
+	// than by offset, activity added while paginating shows up at the end without duplicating
+	// entries of the following pages.
+	NextCursor string `json:"next_cursor"`
+}
+
+// getRunActivity merges the timeline events, status updates and completed checklist items of
+// the run into a single feed, ordered chronologically.
+func getRunActivity(run *PlaybookRun) []RunActivity {
+	activity := []RunActivity{}
+
+	// Status updates are recorded in the timeline too, along with who posted them.
+	statusUpdateUsers := make(map[string]string)
+	for _, event := range run.TimelineEvents {
+		if event.EventType == StatusUpdated && event.PostID != "" {
+			statusUpdateUsers[event.PostID] = event.SubjectUserID
+		}
+	}
+
+	completedItems := make(map[string]bool)
+	for _, checklist := range run.Checklists {
+		for i := range checklist.Items {
+			item := checklist.Items[i]
+			if item.State != ChecklistItemStateClosed || item.StateModified == 0 {
+				continue
+			}
+			completedItems[fmt.Sprintf("%d:%s", item.StateModified, item.StateModifiedBy)] = true
+			activity = append(activity, RunActivity{
+				ID:             item.ID,
+				Type:           RunActivityChecklistItemCompleted,
+				At:             item.StateModified,
+				UserID:         item.StateModifiedBy,
+				ChecklistItem:  &item,
+				ChecklistTitle: checklist.Title,
+			})
+		}
+	}
+
+	for i := range run.StatusPosts {
+		statusPost := run.StatusPosts[i]
+		if statusPost.DeleteAt != 0 {
+			continue
+		}
+		activity = append(activity, RunActivity{
+			ID:           statusPost.ID,
+			Type:         RunActivityStatusUpdate,
+			At:           statusPost.CreateAt,
+			UserID:       statusUpdateUsers[statusPost.ID],
+			StatusUpdate: &statusPost,
+		})
+	}
+
+	for i := range run.TimelineEvents {
+		event := run.TimelineEvents[i]
+		if event.DeleteAt != 0 || event.EventType == StatusUpdated {
+			continue
+		}
+
+		// Checking off an item records a timeline event at the same time, by the same user, as
+		// the item's last state change: that completion is already in the feed.
+		if event.EventType == TaskStateModified && completedItems[fmt.Sprintf("%d:%s", event.EventAt, event.SubjectUserID)] {
+			continue
+		}
+
+		userID := event.CreatorUserID
+		if userID == "" {
+			userID = event.SubjectUserID
+		}
+		activity = append(activity, RunActivity{
+			ID:            event.ID,
+			Type:          RunActivityTimelineEvent,
+			At:            event.EventAt,
+			UserID:        userID,
+			TimelineEvent: &event,
+		})
+	}
+
+	sort.Slice(activity, func(i, j int) bool {
+		return activity[i].before(activity[j])
+	})
+
+	return activity
+}
+
+// parseRunActivityCursor returns the position in the feed of the cursor: the zero RunActivity,
+// before any entry, if the cursor is empty.
+func parseRunActivityCursor(cursor string) (RunActivity, error) {
+	if cursor == "" {
+		return RunActivity{}, nil
+	}
+
+	rawAt, id, found := strings.Cut(cursor, ":")
+	at, err := strconv.ParseInt(rawAt, 10, 64)
+	if !found || id == "" || err != nil {
+		return RunActivity{}, errors.Wrapf(ErrInvalidActivityCursor, "cursor %q", cursor)
+	}
+
+	return RunActivity{At: at, ID: id}, nil
+}
+
+// paginateRunActivity returns the page of activity that starts after options.Cursor.
+func paginateRunActivity(activity []RunActivity, options RunActivityOptions) (*RunActivityResults, error) {
+	after, err := parseRunActivityCursor(options.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	start := 0
+	if options.Cursor != "" {
+		start = sort.Search(len(activity), func(i int) bool {
+			return after.before(activity[i])
+		})
+	}
+
+	end := start + options.perPage()
+	if end > len(activity) {
+		end = len(activity)
+	}
+
+	results := &RunActivityResults{
+		Items:   activity[start:end],
+		HasMore: end < len(activity),
+	}
+	if end > start {
+		results.NextCursor = activity[end-1].cursor()
+	} else {
+		results.NextCursor = options.Cursor
+	}
+
+	return results, nil
+}
@@ -0,0 +1,112 @@
+package app
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRunActivity(t *testing.T) {
+	run := &PlaybookRun{
+		TimelineEvents: []TimelineEvent{
+			{ID: "created", EventType: PlaybookRunCreated, EventAt: 100, SubjectUserID: "owner"},
+			{ID: "status_event", EventType: StatusUpdated, EventAt: 200, PostID: "status_post", SubjectUserID: "updater"},
+			{ID: "checked", EventType: TaskStateModified, EventAt: 300, SubjectUserID: "checker"},
+			{ID: "unchecked", EventType: TaskStateModified, EventAt: 250, SubjectUserID: "checker"},
+			{ID: "from_post", EventType: EventFromPost, EventAt: 300, SubjectUserID: "author", CreatorUserID: "adder"},
+			{ID: "key_event", EventType: KeyEvent, EventAt: 350, SubjectUserID: "pinner", CreatorUserID: "pinner", UserCreated: true},
+			{ID: "deleted", EventType: OwnerChanged, EventAt: 400, DeleteAt: 500},
+		},
+		StatusPosts: []StatusPost{
+			{ID: "status_post", CreateAt: 200},
+			{ID: "old_status_post", CreateAt: 150},
+			{ID: "deleted_status_post", CreateAt: 160, DeleteAt: 170},
+		},
+		Checklists: []Checklist{
+			{
+				Title: "Triage",
+				Items: []ChecklistItem{
+					{ID: "item", State: ChecklistItemStateClosed, StateModified: 300, StateModifiedBy: "checker"},
+					{ID: "open_item", State: ChecklistItemStateOpen, StateModified: 250, StateModifiedBy: "checker"},
+				},
+			},
+		},
+	}
+
+	activity := getRunActivity(run)
+
+	type entry struct {
+		ID     string
+		Type   RunActivityType
+		UserID string
+	}
+	actual := []entry{}
+	for _, a := range activity {
+		actual = append(actual, entry{a.ID, a.Type, a.UserID})
+	}
+
+	require.Equal(t, []entry{
+		{"created", RunActivityTimelineEvent, "owner"},
+		{"old_status_post", RunActivityStatusUpdate, ""},
+		{"status_post", RunActivityStatusUpdate, "updater"},
+		{"unchecked", RunActivityTimelineEvent, "checker"},
+		{"from_post", RunActivityTimelineEvent, "adder"},
+		{"item", RunActivityChecklistItemCompleted, "checker"},
+		{"key_event", RunActivityTimelineEvent, "pinner"},
+	}, actual)
+	require.Equal(t, "Triage", activity[5].ChecklistTitle)
+}
+
+func TestPaginateRunActivity(t *testing.T) {
+	activity := []RunActivity{
+		{ID: "a", At: 100},
+		{ID: "b", At: 200},
+		{ID: "c", At: 200},
+		{ID: "d", At: 300},
+	}
+
+	page, err := paginateRunActivity(activity, RunActivityOptions{PerPage: 2})
+	require.NoError(t, err)
+	require.Equal(t, activity[:2], page.Items)
+	require.True(t, page.HasMore)
+	require.Equal(t, "200:b", page.NextCursor)
+
+	t.Run("activity added while paginating is not duplicated", func(t *testing.T) {
+		activity := append(activity, RunActivity{ID: "e", At: 400})
+
+		next, err := paginateRunActivity(activity, RunActivityOptions{Cursor: page.NextCursor, PerPage: 2})
+		require.NoError(t, err)
+		require.Equal(t, activity[2:4], next.Items)
+		require.True(t, next.HasMore)
+
+		last, err := paginateRunActivity(activity, RunActivityOptions{Cursor: next.NextCursor, PerPage: 2})
+		require.NoError(t, err)
+		require.Equal(t, activity[4:], last.Items)
+		require.False(t, last.HasMore)
+
+		empty, err := paginateRunActivity(activity, RunActivityOptions{Cursor: last.NextCursor, PerPage: 2})
+		require.NoError(t, err)
+		require.Empty(t, empty.Items)
+		require.Equal(t, last.NextCursor, empty.NextCursor)
+	})
+
+	t.Run("pages are capped", func(t *testing.T) {
+		activity := make([]RunActivity, MaxRunActivityPerPage+1)
+		for i := range activity {
+			activity[i] = RunActivity{ID: fmt.Sprintf("%03d", i), At: 100}
+		}
+
+		page, err := paginateRunActivity(activity, RunActivityOptions{PerPage: MaxRunActivityPerPage + 1})
+		require.NoError(t, err)
+		require.Len(t, page.Items, MaxRunActivityPerPage)
+		require.True(t, page.HasMore)
+	})
+
+	for _, cursor := range []string{"200", "abc:b", "200:"} {
+		t.Run("invalid cursor "+cursor, func(t *testing.T) {
+			_, err := paginateRunActivity(activity, RunActivityOptions{Cursor: cursor})
+			require.ErrorIs(t, err, ErrInvalidActivityCursor)
+		})
+	}
+}
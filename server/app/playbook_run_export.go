@@ -0,0 +1,333 @@
+package app
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/mattermost/mattermost-plugin-playbooks/server/timeutils"
+)
+
+const exportTimeLayout = "Jan 2, 2006 15:04 MST"
+
+// ExportPlaybookRun renders the playbook run in the given format, using the timezone of
+// requesterID for all the timestamps.
+func (s *PlaybookRunServiceImpl) ExportPlaybookRun(playbookRunID, requesterID string, format RunExportFormat) ([]byte, error) {
+	if format != RunExportFormatMarkdown {
+		return nil, errors.Wrapf(ErrUnsupportedExportFormat, "format %q", format)
+	}
+
+	playbookRun, err := s.GetPlaybookRun(playbookRunID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get playbook run")
+	}
+
+	requester, err := s.pluginAPI.User.Get(requesterID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get requester")
+	}
+
+	timezone, err := timeutils.GetUserTimezone(requester)
+	if err != nil || timezone == nil {
+		timezone = time.UTC
+	}
+
+	exporter := &runMarkdownExporter{
+		service:   s,
+		timezone:  timezone,
+		usernames: map[string]string{},
+	}
+
+	return []byte(exporter.export(playbookRun)), nil
+}
+
+// runMarkdownExporter renders a playbook run as a Markdown document.
+type runMarkdownExporter struct {
+	service   *PlaybookRunServiceImpl
+	timezone  *time.Location
+	usernames map[string]string
+	b         strings.Builder
+}
+
+func (e *runMarkdownExporter) export(playbookRun *PlaybookRun) string {
+	e.writeMetadata(playbookRun)
+	e.writeChecklists(playbookRun.Checklists)
+	e.writeStatusUpdates(playbookRun.StatusPosts)
+	e.writeTimeline(playbookRun.TimelineEvents)
+	if playbookRun.RetrospectiveEnabled {
+		e.writeRetrospective(playbookRun)
+	}
+
+	return e.b.String()
+}
+
+func (e *runMarkdownExporter) writeMetadata(playbookRun *PlaybookRun) {
+	fmt.Fprintf(&e.b, "# %s\n\n", playbookRun.Name)
+
+	fmt.Fprintf(&e.b, "- **Owner:** %s\n", e.mention(playbookRun.OwnerUserID))
+	if len(playbookRun.CoOwnerUserIDs) > 0 {
+		coOwners := make([]string, 0, len(playbookRun.CoOwnerUserIDs))
+		for _, coOwnerID := range playbookRun.CoOwnerUserIDs {
+			coOwners = append(coOwners, e.mention(coOwnerID))
+		}
+		fmt.Fprintf(&e.b, "- **Co-owners:** %s\n", strings.Join(coOwners, ", "))
+	}
+	fmt.Fprintf(&e.b, "- **Status:** %s\n", playbookRun.CurrentStatus)
+	fmt.Fprintf(&e.b, "- **Started:** %s\n", e.formatTime(playbookRun.CreateAt))
+	if playbookRun.EndAt != 0 {
+		fmt.Fprintf(&e.b, "- **Ended:** %s\n", e.formatTime(playbookRun.EndAt))
+		fmt.Fprintf(&e.b, "- **Duration:** %s\n", timeutils.DurationString(timeutils.GetTimeForMillis(playbookRun.CreateAt), timeutils.GetTimeForMillis(playbookRun.CreateAt+playbookRun.ActiveDuration(playbookRun.EndAt))))
+	}
+	if len(playbookRun.ParticipantIDs) > 0 {
+		participants := make([]string, 0, len(playbookRun.ParticipantIDs))
+		for _, participantID := range playbookRun.ParticipantIDs {
+			participants = append(participants, e.mention(participantID))
+		}
+		fmt.Fprintf(&e.b, "- **Participants:** %s\n", strings.Join(participants, ", "))
+	}
+	for _, propertyValue := range playbookRun.PropertyValues {
+		fmt.Fprintf(&e.b, "- **%s:** %s\n", propertyValue.Name, propertyValue.Value)
+	}
+
+	if playbookRun.Summary != "" {
+		fmt.Fprintf(&e.b, "\n## Summary\n\n%s\n", playbookRun.Summary)
+	}
+}
+
+func (e *runMarkdownExporter) writeChecklists(checklists []Checklist) {
+	if len(checklists) == 0 {
+		return
+	}
+
+	e.b.WriteString("\n## Checklists\n")
+	for _, checklist := range checklists {
+		fmt.Fprintf(&e.b, "\n### %s\n\n", checklist.Title)
+		for _, item := range checklist.Items {
+			if item.Hidden {
+				continue
+			}
+			e.writeChecklistItem(item)
+		}
+	}
+}
+
+func (e *runMarkdownExporter) writeChecklistItem(item ChecklistItem) {
+	details := []string{}
+
+	switch item.State {
+	case ChecklistItemStateClosed:
+		fmt.Fprintf(&e.b, "- [x] %s", item.Title)
+		checked := "checked"
+		if item.StateModifiedBy != "" {
+			checked += " by " + e.mention(item.StateModifiedBy)
+		}
+		if item.StateModified != 0 {
+			checked += " on " + e.formatTime(item.StateModified)
+		}
+		details = append(details, checked)
+	case ChecklistItemStateSkipped:
+		fmt.Fprintf(&e.b, "- [ ] ~~%s~~", item.Title)
+		details = append(details, "skipped")
+	default:
+		fmt.Fprintf(&e.b, "- [ ] %s", item.Title)
+	}
+
+	if item.AssigneeID != "" {
+		details = append(details, "assigned to "+e.mention(item.AssigneeID))
+	}
+	if item.DueDate != 0 {
+		details = append(details, "due "+e.formatTime(item.DueDate))
+	}
+
+	if len(details) > 0 {
+		fmt.Fprintf(&e.b, " (%s)", strings.Join(details, ", "))
+	}
+	e.b.WriteString("\n")
+}
+
+func (e *runMarkdownExporter) writeStatusUpdates(statusPosts []StatusPost) {
+	updates := make([]*StatusPostComplete, 0, len(statusPosts))
+	for _, statusPost := range statusPosts {
+		if statusPost.DeleteAt != 0 {
+			continue
+		}
+
+		post, err := e.service.pluginAPI.Post.GetPost(statusPost.ID)
+		if err != nil {
+			logrus.WithError(err).WithField("post_id", statusPost.ID).Warn("failed to get status update post for export")
+			continue
+		}
+
+		if post.Type == "custom_run_update" {
+			updates = append(updates, NewStatusPostComplete(post))
+		}
+	}
+
+	if len(updates) == 0 {
+		return
+	}
+
+	sort.Slice(updates, func(i, j int) bool {
+		return updates[i].CreateAt < updates[j].CreateAt
+	})
+
+	e.b.WriteString("\n## Status updates\n")
+	for _, update := range updates {
+		fmt.Fprintf(&e.b, "\n### %s", e.formatTime(update.CreateAt))
+		if update.AuthorUserName != "" {
+			fmt.Fprintf(&e.b, " by @%s", update.AuthorUserName)
+		}
+		fmt.Fprintf(&e.b, "\n\n%s\n", update.Message)
+	}
+}
+
+func (e *runMarkdownExporter) writeTimeline(events []TimelineEvent) {
+	visible := make([]TimelineEvent, 0, len(events))
+	for _, event := range events {
+		if event.DeleteAt == 0 {
+			visible = append(visible, event)
+		}
+	}
+
+	if len(visible) == 0 {
+		return
+	}
+
+	sort.Slice(visible, func(i, j int) bool {
+		return visible[i].EventAt < visible[j].EventAt
+	})
+
+	e.b.WriteString("\n## Timeline\n\n")
+	for _, event := range visible {
+		fmt.Fprintf(&e.b, "- **%s**", e.formatTime(event.EventAt))
+		if event.SubjectUserID != "" {
+			fmt.Fprintf(&e.b, " %s", e.mention(event.SubjectUserID))
+		}
+		fmt.Fprintf(&e.b, ": %s\n", event.Summary)
+	}
+}
+
+func (e *runMarkdownExporter) writeRetrospective(playbookRun *PlaybookRun) {
+	e.b.WriteString("\n## Retrospective\n\n")
+	if playbookRun.RetrospectiveWasCanceled {
+		e.b.WriteString("_The retrospective was canceled._\n")
+		return
+	}
+
+	if playbookRun.Retrospective == "" {
+		e.b.WriteString("_No retrospective was written._\n")
+		return
+	}
+
+	fmt.Fprintf(&e.b, "%s\n", playbookRun.Retrospective)
+	if playbookRun.RetrospectivePublishedAt != 0 {
+		fmt.Fprintf(&e.b, "\n_Published on %s._\n", e.formatTime(playbookRun.RetrospectivePublishedAt))
+	}
+}
+
+// mention returns the @username of the given user, falling back to the user ID if the user
+// cannot be retrieved.
+func (e *runMarkdownExporter) mention(userID string) string {
+	if username, ok := e.usernames[userID]; ok {
+		return "@" + username
+	}
+
+	username := userID
+	user, err := e.service.pluginAPI.User.Get(userID)
+	if err != nil {
+		logrus.WithError(err).WithField("user_id", userID).Warn("failed to get user for export")
+	} else {
+		username = user.Username
+	}
+	e.usernames[userID] = username
+
+	return "@" + username
+}
+
+func (e *runMarkdownExporter) formatTime(millis int64) string {
+	return timeutils.GetTimeForMillis(millis).In(e.timezone).Format(exportTimeLayout)
+}
+
+// runsCSVExportPageSize is the number of runs read from the store at once when exporting the runs
+// of a team to CSV.
+const runsCSVExportPageSize = 500
+
+var runsCSVExportHeader = []string{"ID", "Name", "Playbook ID", "Owner ID", "Channel ID", "Status", "Created At", "Ended At", "Last Status Update At"}
+
+// ExportPlaybookRunsCSV writes the runs of teamID visible to the requester to w as CSV, oldest
+// first. Runs are read from the store and written to w one page at a time, flushing w after each
+// page if it is an http.Flusher, so that the export never holds more than a page in memory.
+func (s *PlaybookRunServiceImpl) ExportPlaybookRunsCSV(requesterInfo RequesterInfo, teamID string, w io.Writer) error {
+	return exportPlaybookRunsCSV(s.store, requesterInfo, teamID, w, runsCSVExportPageSize)
+}
+
+func exportPlaybookRunsCSV(store PlaybookRunStore, requesterInfo RequesterInfo, teamID string, w io.Writer, pageSize int) error {
+	csvWriter := csv.NewWriter(w)
+	flush := func() error {
+		csvWriter.Flush()
+		if err := csvWriter.Error(); err != nil {
+			return errors.Wrap(err, "failed to write runs CSV")
+		}
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+		return nil
+	}
+
+	if err := csvWriter.Write(runsCSVExportHeader); err != nil {
+		return errors.Wrap(err, "failed to write runs CSV header")
+	}
+
+	var cursor RunExportCursor
+	for {
+		rows, err := store.GetPlaybookRunsForExport(requesterInfo, teamID, cursor, pageSize)
+		if err != nil {
+			return errors.Wrap(err, "failed to get runs for export")
+		}
+
+		for _, row := range rows {
+			if err = csvWriter.Write(runExportRecord(row)); err != nil {
+				return errors.Wrapf(err, "failed to write run %s to CSV", row.ID)
+			}
+		}
+		if err = flush(); err != nil {
+			return err
+		}
+
+		if len(rows) < pageSize {
+			return nil
+		}
+		last := rows[len(rows)-1]
+		cursor = RunExportCursor{CreateAt: last.CreateAt, ID: last.ID}
+	}
+}
+
+func runExportRecord(row RunExportRow) []string {
+	return []string{
+		row.ID,
+		row.Name,
+		row.PlaybookID,
+		row.OwnerUserID,
+		row.ChannelID,
+		row.CurrentStatus,
+		formatCSVExportTime(row.CreateAt),
+		formatCSVExportTime(row.EndAt),
+		formatCSVExportTime(row.LastStatusUpdateAt),
+	}
+}
+
+// formatCSVExportTime formats millis as RFC 3339 in UTC, leaving unset times empty.
+func formatCSVExportTime(millis int64) string {
+	if millis == 0 {
+		return ""
+	}
+
+	return timeutils.GetTimeForMillis(millis).UTC().Format(time.RFC3339)
+}
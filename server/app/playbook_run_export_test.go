@@ -0,0 +1,100 @@
+package app
+
+import (
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRunExportStore pages through its rows like the sql store, remembering the largest page it
+// returned.
+type fakeRunExportStore struct {
+	PlaybookRunStore
+	rows        []RunExportRow
+	pages       int
+	largestPage int
+}
+
+func (s *fakeRunExportStore) GetPlaybookRunsForExport(requesterInfo RequesterInfo, teamID string, after RunExportCursor, limit int) ([]RunExportRow, error) {
+	start := sort.Search(len(s.rows), func(i int) bool {
+		row := s.rows[i]
+		return row.CreateAt > after.CreateAt || (row.CreateAt == after.CreateAt && row.ID > after.ID)
+	})
+	end := start + limit
+	if end > len(s.rows) {
+		end = len(s.rows)
+	}
+
+	s.pages++
+	if end-start > s.largestPage {
+		s.largestPage = end - start
+	}
+
+	return s.rows[start:end], nil
+}
+
+// flushRecorder is an http.Flusher remembering the largest amount of data written between two
+// flushes.
+type flushRecorder struct {
+	strings.Builder
+	unflushed        int
+	largestUnflushed int
+	flushes          int
+}
+
+func (r *flushRecorder) Write(p []byte) (int, error) {
+	r.unflushed += len(p)
+	return r.Builder.Write(p)
+}
+
+func (r *flushRecorder) Flush() {
+	if r.unflushed > r.largestUnflushed {
+		r.largestUnflushed = r.unflushed
+	}
+	r.unflushed = 0
+	r.flushes++
+}
+
+func TestExportPlaybookRunsCSV(t *testing.T) {
+	const numRuns = 25000
+	const pageSize = 100
+
+	store := &fakeRunExportStore{}
+	for i := 0; i < numRuns; i++ {
+		// Several runs share each creation time, so that pages break ties by ID.
+		store.rows = append(store.rows, RunExportRow{
+			ID:            fmt.Sprintf("run%06d", i),
+			Name:          fmt.Sprintf("Run %d, with a comma", i),
+			CurrentStatus: StatusInProgress,
+			CreateAt:      int64(1000 + i/7),
+		})
+	}
+
+	w := &flushRecorder{}
+	require.NoError(t, exportPlaybookRunsCSV(store, RequesterInfo{}, "team_id", w, pageSize))
+
+	records, err := csv.NewReader(strings.NewReader(w.String())).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, numRuns+1)
+	require.Equal(t, runsCSVExportHeader, records[0])
+	for i, record := range records[1:] {
+		require.Equal(t, store.rows[i].ID, record[0])
+		require.Equal(t, store.rows[i].Name, record[1])
+	}
+
+	// Only a page is held at a time: the store never returns more than a page, and each page is
+	// flushed to the writer before the next one is read.
+	require.Equal(t, numRuns/pageSize+1, store.pages)
+	require.Equal(t, pageSize, store.largestPage)
+	require.Equal(t, store.pages, w.flushes)
+	require.Less(t, w.largestUnflushed, 2*w.Len()/(numRuns/pageSize))
+}
+
+func TestFormatCSVExportTime(t *testing.T) {
+	require.Equal(t, "", formatCSVExportTime(0))
+	require.Equal(t, "2021-03-04T05:06:07Z", formatCSVExportTime(1614834367000))
+}
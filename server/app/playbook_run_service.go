@@ -1,10 +1,8 @@
 package app
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
-	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
@@ -16,7 +14,6 @@ import (
 
 	"github.com/mattermost/mattermost-plugin-playbooks/server/bot"
 	"github.com/mattermost/mattermost-plugin-playbooks/server/config"
-	"github.com/mattermost/mattermost-plugin-playbooks/server/httptools"
 	"github.com/mattermost/mattermost-plugin-playbooks/server/metrics"
 	"github.com/mattermost/mattermost-plugin-playbooks/server/timeutils"
 	"github.com/mattermost/mattermost-server/v6/model"
@@ -37,20 +34,22 @@ const (
 
 // PlaybookRunServiceImpl holds the information needed by the PlaybookRunService's methods to complete their functions.
 type PlaybookRunServiceImpl struct {
-	pluginAPI        *pluginapi.Client
-	httpClient       *http.Client
-	configService    config.Service
-	store            PlaybookRunStore
-	poster           bot.Poster
-	scheduler        JobOnceScheduler
-	telemetry        PlaybookRunTelemetry
-	genericTelemetry GenericTelemetry
-	api              plugin.API
-	playbookService  PlaybookService
-	actionService    ChannelActionService
-	permissions      *PermissionsService
-	licenseChecker   LicenseChecker
-	metricsService   *metrics.Metrics
+	pluginAPI         *pluginapi.Client
+	configService     config.Service
+	store             PlaybookRunStore
+	poster            bot.Poster
+	scheduler         JobOnceScheduler
+	telemetry         PlaybookRunTelemetry
+	genericTelemetry  GenericTelemetry
+	api               plugin.API
+	playbookService   PlaybookService
+	actionService     ChannelActionService
+	permissions       *PermissionsService
+	licenseChecker    LicenseChecker
+	metricsService    *metrics.Metrics
+	webhookDispatcher *WebhookDispatcher
+	dueReminders      *DueReminderScheduler
+	runEvents         *RunEventBroker
 }
 
 var allNonSpaceNonWordRegex = regexp.MustCompile(`[^\w\s]`)
@@ -102,21 +101,26 @@ func NewPlaybookRunService(
 	channelActionService ChannelActionService,
 	licenseChecker LicenseChecker,
 	metricsService *metrics.Metrics,
+	webhookDispatcher *WebhookDispatcher,
+	dueReminders *DueReminderScheduler,
+	runEvents *RunEventBroker,
 ) *PlaybookRunServiceImpl {
 	service := &PlaybookRunServiceImpl{
-		pluginAPI:        pluginAPI,
-		store:            store,
-		poster:           poster,
-		configService:    configService,
-		scheduler:        scheduler,
-		telemetry:        telemetry,
-		genericTelemetry: genericTelemetry,
-		httpClient:       httptools.MakeClient(pluginAPI),
-		api:              api,
-		playbookService:  playbookService,
-		actionService:    channelActionService,
-		licenseChecker:   licenseChecker,
-		metricsService:   metricsService,
+		pluginAPI:         pluginAPI,
+		store:             store,
+		poster:            poster,
+		configService:     configService,
+		scheduler:         scheduler,
+		telemetry:         telemetry,
+		genericTelemetry:  genericTelemetry,
+		api:               api,
+		playbookService:   playbookService,
+		actionService:     channelActionService,
+		licenseChecker:    licenseChecker,
+		metricsService:    metricsService,
+		webhookDispatcher: webhookDispatcher,
+		dueReminders:      dueReminders,
+		runEvents:         runEvents,
 	}
 
 	service.permissions = NewPermissionsService(service.playbookService, service, service.pluginAPI, service.configService, service.licenseChecker)
@@ -138,6 +142,30 @@ func (s *PlaybookRunServiceImpl) GetPlaybookRuns(requesterInfo RequesterInfo, op
 	}, nil
 }
 
+// SearchStatusUpdates returns the status updates of the runs in teamID matching term.
+func (s *PlaybookRunServiceImpl) SearchStatusUpdates(requesterInfo RequesterInfo, teamID, term string, options StatusUpdateSearchOptions) (*SearchStatusUpdatesResults, error) {
+	results, err := s.store.SearchStatusUpdates(requesterInfo, teamID, term, options)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't search status updates in the store")
+	}
+
+	return results, nil
+}
+
+// GetStalePlaybookRuns returns the in-progress runs without activity for longer than olderThan.
+func (s *PlaybookRunServiceImpl) GetStalePlaybookRuns(requesterInfo RequesterInfo, teamID string, olderThan time.Duration, options StaleRunsOptions) (*GetStaleRunsResults, error) {
+	if olderThan <= 0 {
+		return nil, errors.New("the threshold of stale runs must be positive")
+	}
+
+	results, err := s.store.GetStalePlaybookRuns(requesterInfo, teamID, model.GetMillis()-olderThan.Milliseconds(), options)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't get stale runs from the store")
+	}
+
+	return results, nil
+}
+
 func (s *PlaybookRunServiceImpl) buildPlaybookRunCreationMessageTemplate(playbookTitle, playbookID string, playbookRun *PlaybookRun, reporter *model.User) (string, error) {
 	return fmt.Sprintf(
 		"##### [%s](%s%s)\n@%s ran the [%s](%s) playbook.",
@@ -222,11 +250,28 @@ func (s *PlaybookRunServiceImpl) sendWebhooksOnCreation(playbookRun PlaybookRun)
 		return
 	}
 
-	triggerWebhooks(s, playbookRun.WebhookOnCreationURLs, body)
+	s.webhookDispatcher.Dispatch(playbookRun.ID, playbookRun.WebhookOnCreationURLs, body)
 }
 
-// CreatePlaybookRun creates a new playbook run. userID is the user who initiated the CreatePlaybookRun.
-func (s *PlaybookRunServiceImpl) CreatePlaybookRun(playbookRun *PlaybookRun, pb *Playbook, userID string, public bool) (*PlaybookRun, error) {
+// prepareNewPlaybookRun validates the creation of playbookRun from pb, resolving its owner and
+// channel. It is shared by CreatePlaybookRun and DryRunPlaybookRun so that a dry run validates
+// exactly what the creation does.
+func (s *PlaybookRunServiceImpl) prepareNewPlaybookRun(playbookRun *PlaybookRun, pb *Playbook, userID string) error {
+	if pb != nil && pb.DeleteAt != 0 {
+		return errors.Wrapf(ErrPlaybookArchived, "cannot create a new run using archived playbook %s", pb.ID)
+	}
+
+	// The webhooks are validated like those of the playbooks, so that a run isn't created to then
+	// fail calling them.
+	if err := ValidateWebhookURLs(playbookRun.WebhookOnCreationURLs); err != nil {
+		return errors.Wrap(ErrMalformedPlaybookRun, err.Error())
+	}
+
+	if playbookRun.StatusUpdateBroadcastWebhooksEnabled {
+		if err := ValidateWebhookURLs(playbookRun.WebhookOnStatusUpdateURLs); err != nil {
+			return errors.Wrap(ErrMalformedPlaybookRun, err.Error())
+		}
+	}
 
 	// TODO: forced until start-a-run modal can overwrite it
 	if pb != nil && pb.ChannelMode == PlaybookRunLinkExistingChannel {
@@ -248,21 +293,71 @@ func (s *PlaybookRunServiceImpl) CreatePlaybookRun(playbookRun *PlaybookRun, pb
 	playbookRun.ReporterUserID = userID
 	playbookRun.ID = model.NewId()
 
+	return nil
+}
+
+// newPlaybookRunChannelHeader returns the header of the channel created for playbookRun.
+func newPlaybookRunChannelHeader(playbookRun *PlaybookRun, pb *Playbook) string {
+	if pb == nil {
+		return "This channel was created as part of a playbook run. To view more information, select the shield icon then select *Tasks* or *Overview*."
+	}
+
+	overviewURL := GetRunDetailsRelativeURL(playbookRun.ID)
+	playbookURL := GetPlaybookDetailsRelativeURL(pb.ID)
+	return fmt.Sprintf("This channel was created as part of the [%s](%s) playbook. Visit [the overview page](%s) for more information.",
+		pb.Title, playbookURL, overviewURL)
+}
+
+// removeRunChannelFromBroadcast stops broadcasting the status updates of playbookRun to its own
+// channel, as they are posted there already and would be posted twice.
+func removeRunChannelFromBroadcast(playbookRun *PlaybookRun) {
+	broadcastChannelIDs := make([]string, 0, len(playbookRun.BroadcastChannelIDs))
+	for _, channelID := range playbookRun.BroadcastChannelIDs {
+		if channelID != playbookRun.ChannelID {
+			broadcastChannelIDs = append(broadcastChannelIDs, channelID)
+		}
+	}
+	playbookRun.BroadcastChannelIDs = broadcastChannelIDs
+	playbookRun.StatusUpdateBroadcastChannelsEnabled = playbookRun.StatusUpdateBroadcastChannelsEnabled && len(broadcastChannelIDs) > 0
+}
+
+// setNewPlaybookRunDefaults sets the initial state of playbookRun, created at now.
+func setNewPlaybookRunDefaults(playbookRun *PlaybookRun, pb *Playbook, now int64) {
+	if pb != nil && pb.ChannelMode == PlaybookRunCreateNewChannel && playbookRun.Name == "" {
+		playbookRun.Name = pb.ChannelNameTemplate
+	}
+
+	playbookRun.CreateAt = now
+	playbookRun.LastStatusUpdateAt = now
+	playbookRun.CurrentStatus = StatusInProgress
+
+	// Start with a blank playbook with one empty checklist if one isn't provided
+	if playbookRun.PlaybookID == "" && len(playbookRun.Checklists) == 0 {
+		playbookRun.Checklists = []Checklist{
+			{
+				Title: "Checklist",
+				Items: []ChecklistItem{},
+			},
+		}
+	}
+
+	playbookRun.ApplyChecklistItemConditions()
+}
+
+// CreatePlaybookRun creates a new playbook run. userID is the user who initiated the CreatePlaybookRun.
+func (s *PlaybookRunServiceImpl) CreatePlaybookRun(playbookRun *PlaybookRun, pb *Playbook, userID string, public bool) (*PlaybookRun, error) {
+	if err := s.prepareNewPlaybookRun(playbookRun, pb, userID); err != nil {
+		return nil, err
+	}
+
 	logger := logrus.WithField("playbook_run_id", playbookRun.ID)
 
 	var err error
 	var channel *model.Channel
 
-	if playbookRun.ChannelID == "" {
-		header := "This channel was created as part of a playbook run. To view more information, select the shield icon then select *Tasks* or *Overview*."
-		if pb != nil {
-			overviewURL := GetRunDetailsRelativeURL(playbookRun.ID)
-			playbookURL := GetPlaybookDetailsRelativeURL(pb.ID)
-			header = fmt.Sprintf("This channel was created as part of the [%s](%s) playbook. Visit [the overview page](%s) for more information.",
-				pb.Title, playbookURL, overviewURL)
-		}
-
-		channel, err = s.createPlaybookRunChannel(playbookRun, header, public)
+	linkedChannel := playbookRun.ChannelID != ""
+	if !linkedChannel {
+		channel, err = s.createPlaybookRunChannel(playbookRun, newPlaybookRunChannelHeader(playbookRun, pb), public)
 		if err != nil {
 			return nil, err
 		}
@@ -274,10 +369,7 @@ func (s *PlaybookRunServiceImpl) CreatePlaybookRun(playbookRun *PlaybookRun, pb
 			return nil, err
 		}
 
-	}
-
-	if pb != nil && pb.ChannelMode == PlaybookRunCreateNewChannel && playbookRun.Name == "" {
-		playbookRun.Name = pb.ChannelNameTemplate
+		removeRunChannelFromBroadcast(playbookRun)
 	}
 
 	if pb != nil && pb.MessageOnJoinEnabled && pb.MessageOnJoin != "" {
@@ -316,20 +408,7 @@ func (s *PlaybookRunServiceImpl) CreatePlaybookRun(playbookRun *PlaybookRun, pb
 		}
 	}
 
-	now := model.GetMillis()
-	playbookRun.CreateAt = now
-	playbookRun.LastStatusUpdateAt = now
-	playbookRun.CurrentStatus = StatusInProgress
-
-	// Start with a blank playbook with one empty checklist if one isn't provided
-	if playbookRun.PlaybookID == "" {
-		playbookRun.Checklists = []Checklist{
-			{
-				Title: "Checklist",
-				Items: []ChecklistItem{},
-			},
-		}
-	}
+	setNewPlaybookRunDefaults(playbookRun, pb, model.GetMillis())
 
 	playbookRun, err = s.store.CreatePlaybookRun(playbookRun)
 	if err != nil {
@@ -339,11 +418,26 @@ func (s *PlaybookRunServiceImpl) CreatePlaybookRun(playbookRun *PlaybookRun, pb
 	s.telemetry.CreatePlaybookRun(playbookRun, userID, public)
 	s.metricsService.IncrementRunsCreatedCount(1)
 
+	for _, checklist := range playbookRun.Checklists {
+		for _, item := range checklist.Items {
+			s.setChecklistItemDueReminder(playbookRun.ID, item)
+		}
+	}
+
 	err = s.addPlaybookRunInitialMemberships(playbookRun, channel)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to setup core memberships at run/channel")
 	}
 
+	// The header of an existing channel belongs to its members, so instead of replacing it the run
+	// is announced in the channel.
+	if linkedChannel {
+		message := s.serverTranslations()("app.user.run.channel_linked", map[string]interface{}{"RunURL": GetRunDetailsRelativeURL(playbookRun.ID)})
+		if _, err = s.poster.PostMessage(channel.Id, "%s", message); err != nil {
+			logger.WithError(err).WithField("channel_id", channel.Id).Warn("failed to announce the run in its channel")
+		}
+	}
+
 	invitedUserIDs := playbookRun.InvitedUserIDs
 
 	for _, groupID := range playbookRun.InvitedGroupIDs {
@@ -510,7 +604,7 @@ func (s *PlaybookRunServiceImpl) CreatePlaybookRun(playbookRun *PlaybookRun, pb
 	}
 
 	postURL := fmt.Sprintf("/_redirect/pl/%s", playbookRun.PostID)
-	postMessage := fmt.Sprintf("[Original Post](%s)\n > %s", postURL, post.Message)
+	postMessage := fmt.Sprintf("[%s](%s)\n > %s", s.serverTranslations()("app.user.run.original_post"), postURL, post.Message)
 
 	_, err = s.poster.PostMessage(channel.Id, postMessage)
 	if err != nil {
@@ -520,6 +614,325 @@ func (s *PlaybookRunServiceImpl) CreatePlaybookRun(playbookRun *PlaybookRun, pb
 	return playbookRun, nil
 }
 
+// DryRunPlaybookRun validates the creation of playbookRun like CreatePlaybookRun, returning the run
+// that would be created without creating anything: no run, channel, actions or webhook calls.
+func (s *PlaybookRunServiceImpl) DryRunPlaybookRun(playbookRun *PlaybookRun, pb *Playbook, userID string, public bool) (*PlaybookRunDryRun, error) {
+	if err := s.prepareNewPlaybookRun(playbookRun, pb, userID); err != nil {
+		return nil, err
+	}
+
+	dryRun := &PlaybookRunDryRun{
+		Valid:  true,
+		Public: public,
+	}
+
+	if playbookRun.ChannelID == "" {
+		channel, err := newPlaybookRunChannel(playbookRun, newPlaybookRunChannelHeader(playbookRun, pb), public)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = s.pluginAPI.Channel.GetByName(playbookRun.TeamID, channel.Name, true)
+		if err != nil && !errors.Is(err, pluginapi.ErrNotFound) {
+			return nil, errors.Wrap(err, "failed to look up the channel name")
+		}
+
+		dryRun.CreateChannel = true
+		dryRun.ChannelName = channel.Name
+		dryRun.ChannelNameTaken = err == nil
+	} else {
+		channel, err := s.pluginAPI.Channel.Get(playbookRun.ChannelID)
+		if err != nil {
+			return nil, err
+		}
+
+		removeRunChannelFromBroadcast(playbookRun)
+		dryRun.ChannelName = channel.Name
+	}
+
+	setNewPlaybookRunDefaults(playbookRun, pb, model.GetMillis())
+
+	playbookRun.ID = ""
+	dryRun.PlaybookRun = playbookRun
+
+	return dryRun, nil
+}
+
+// ClonePlaybookRun creates a new run, with a new channel, from the checklists, owner and
+// broadcast settings of playbookRunID. userID is the user who initiated the clone.
+func (s *PlaybookRunServiceImpl) ClonePlaybookRun(playbookRunID, userID string, options ClonePlaybookRunOptions) (*PlaybookRun, error) {
+	source, err := s.store.GetPlaybookRun(playbookRunID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve playbook run")
+	}
+
+	if source.CurrentStatus == StatusFinished || source.CurrentStatus == StatusMerged {
+		return nil, errors.Wrap(ErrPlaybookRunNotActive, "only runs that have not finished can be cloned")
+	}
+
+	if source.PlaybookID != "" {
+		playbook, err := s.playbookService.Get(source.PlaybookID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get playbook of playbook run")
+		}
+
+		if playbook.DeleteAt != 0 {
+			return nil, errors.Wrapf(ErrPlaybookArchived, "cannot clone a run of archived playbook %s", playbook.ID)
+		}
+	}
+
+	sourceChannel, err := s.pluginAPI.Channel.Get(source.ChannelID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get channel of playbook run")
+	}
+
+	checklists := make([]Checklist, 0, len(source.Checklists))
+	for _, sourceChecklist := range source.Checklists {
+		checklist := sourceChecklist.Clone()
+		checklist.ID = ""
+		for i := range checklist.Items {
+			item := &checklist.Items[i]
+			if options.ResetItemStates {
+				item.State = ChecklistItemStateOpen
+				item.SkipReason = ""
+				item.StateModified = 0
+				item.StateModifiedBy = ""
+				item.CommandLastRun = 0
+			}
+			// The output of the command belongs to the source run.
+			item.CommandOutputID = ""
+		}
+		checklists = append(checklists, checklist)
+	}
+	renewChecklistItemIDs(checklists)
+
+	invitedUserIDs := []string{}
+	if options.CopyParticipants {
+		invitedUserIDs = append(invitedUserIDs, source.ParticipantIDs...)
+	}
+
+	playbookRun := &PlaybookRun{
+		Name:                                    source.Name,
+		Summary:                                 source.Summary,
+		OwnerUserID:                             source.OwnerUserID,
+		TeamID:                                  source.TeamID,
+		PlaybookID:                              source.PlaybookID,
+		Checklists:                              checklists,
+		InvitedUserIDs:                          invitedUserIDs,
+		StatusUpdateEnabled:                     source.StatusUpdateEnabled,
+		ReminderMessageTemplate:                 source.ReminderMessageTemplate,
+		StatusUpdateTemplates:                   append([]StatusUpdateTemplate(nil), source.StatusUpdateTemplates...),
+		ReminderTimerDefaultSeconds:             source.ReminderTimerDefaultSeconds,
+		PreviousReminder:                        source.PreviousReminder,
+		BroadcastChannelIDs:                     source.BroadcastChannelIDs,
+		StatusUpdateBroadcastChannelsEnabled:    source.StatusUpdateBroadcastChannelsEnabled,
+		WebhookOnStatusUpdateURLs:               source.WebhookOnStatusUpdateURLs,
+		StatusUpdateBroadcastWebhooksEnabled:    source.StatusUpdateBroadcastWebhooksEnabled,
+		CreateChannelMemberOnNewParticipant:     source.CreateChannelMemberOnNewParticipant,
+		RemoveChannelMemberOnRemovedParticipant: source.RemoveChannelMemberOnRemovedParticipant,
+		ArchiveChannelOnFinishEnabled:           source.ArchiveChannelOnFinishEnabled,
+		ArchiveChannelOnFinishDelayMinutes:      source.ArchiveChannelOnFinishDelayMinutes,
+		ArchiveChannelSkipIfPosted:              source.ArchiveChannelSkipIfPosted,
+		ChecklistItemDependenciesEnforced:       source.ChecklistItemDependenciesEnforced,
+		AutoFinishEnabled:                       source.AutoFinishEnabled,
+	}
+
+	playbookRun, err = s.CreatePlaybookRun(playbookRun, nil, userID, sourceChannel.Type == model.ChannelTypeOpen)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create cloned playbook run")
+	}
+
+	eventTime := model.GetMillis()
+	event := &TimelineEvent{
+		PlaybookRunID: playbookRun.ID,
+		CreateAt:      eventTime,
+		EventAt:       eventTime,
+		EventType:     RunCloned,
+		Summary:       fmt.Sprintf("Cloned from run %s", source.ID),
+		Details:       source.ID,
+		SubjectUserID: userID,
+	}
+
+	if _, err = s.store.CreateTimelineEvent(event); err != nil {
+		return nil, errors.Wrap(err, "failed to create timeline event")
+	}
+	playbookRun.TimelineEvents = append(playbookRun.TimelineEvents, *event)
+
+	return playbookRun, nil
+}
+
+// MergePlaybookRuns merges the run sourceRunID into targetRunID: the checklists of the source are
+// appended to those of the target, and its participants, tags and status updates are added to
+// the target. The source run ends as merged, pointing to the target.
+//
+// The channel of the source run is archived, after a message pointing to the target run, unless
+// it is also the channel of the target run, or of another active run.
+func (s *PlaybookRunServiceImpl) MergePlaybookRuns(targetRunID, sourceRunID, userID string) (*PlaybookRun, error) {
+	logger := logrus.WithFields(logrus.Fields{"playbook_run_id": targetRunID, "source_run_id": sourceRunID})
+
+	if targetRunID == sourceRunID {
+		return nil, errors.Wrap(ErrInvalidRunMerge, "cannot merge a run into itself")
+	}
+
+	target, err := s.store.GetPlaybookRun(targetRunID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve target playbook run")
+	}
+	source, err := s.store.GetPlaybookRun(sourceRunID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve source playbook run")
+	}
+
+	if target.TeamID != source.TeamID {
+		return nil, errors.Wrap(ErrInvalidRunMerge, "cannot merge runs of different teams")
+	}
+	for _, run := range []*PlaybookRun{target, source} {
+		if run.CurrentStatus == StatusFinished || run.CurrentStatus == StatusMerged {
+			return nil, errors.Wrapf(ErrPlaybookRunNotActive, "cannot merge run %s, which has ended", run.ID)
+		}
+	}
+
+	user, err := s.pluginAPI.User.Get(userID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to to resolve user %s", userID)
+	}
+
+	checklists := make([]Checklist, 0, len(source.Checklists))
+	for _, sourceChecklist := range source.Checklists {
+		checklist := sourceChecklist.Clone()
+		for i := range checklist.Items {
+			// The output of the command belongs to the source run.
+			checklist.Items[i].CommandOutputID = ""
+		}
+		checklists = append(checklists, checklist)
+	}
+
+	newParticipantIDs := []string{}
+	for _, participantID := range source.ParticipantIDs {
+		if !sliceContains(target.ParticipantIDs, participantID) {
+			newParticipantIDs = append(newParticipantIDs, participantID)
+		}
+	}
+
+	statusPostIDs := make([]string, 0, len(source.StatusPosts))
+	for _, statusPost := range source.StatusPosts {
+		statusPostIDs = append(statusPostIDs, statusPost.ID)
+	}
+
+	mergedAt := model.GetMillis()
+	merge := RunMerge{
+		TargetRunID:      target.ID,
+		SourceRunID:      source.ID,
+		SourceChecklists: checklists,
+		ParticipantIDs:   newParticipantIDs,
+		Tags:             source.Tags,
+		StatusPostIDs:    statusPostIDs,
+		MergedAt:         mergedAt,
+		TargetEvent: &TimelineEvent{
+			PlaybookRunID: target.ID,
+			CreateAt:      mergedAt,
+			EventAt:       mergedAt,
+			EventType:     RunMerged,
+			Summary:       fmt.Sprintf("Merged run %s into this run", source.Name),
+			Details:       source.ID,
+			SubjectUserID: userID,
+			CreatorUserID: userID,
+		},
+		SourceEvent: &TimelineEvent{
+			PlaybookRunID: source.ID,
+			CreateAt:      mergedAt,
+			EventAt:       mergedAt,
+			EventType:     RunMerged,
+			Summary:       fmt.Sprintf("Merged into run %s", target.Name),
+			Details:       target.ID,
+			SubjectUserID: userID,
+			CreatorUserID: userID,
+		},
+	}
+
+	if err = s.store.MergePlaybookRuns(merge); err != nil {
+		return nil, errors.Wrap(err, "failed to merge playbook runs")
+	}
+
+	s.RemoveReminder(source.ID)
+
+	// The reminders of the items stop with the source run, which has ended: they follow the
+	// items to the target.
+	for _, checklist := range checklists {
+		for _, item := range checklist.Items {
+			s.setChecklistItemDueReminder(target.ID, item)
+		}
+	}
+
+	if target.CreateChannelMemberOnNewParticipant {
+		for _, participantID := range newParticipantIDs {
+			participant, err := s.pluginAPI.User.Get(participantID)
+			if err != nil {
+				logger.WithError(err).WithField("user_id", participantID).Warn("failed to get merged participant")
+				continue
+			}
+			s.participateActions(target, nil, participant, user, false)
+		}
+	}
+
+	s.endMergedRunChannel(source, target, user, logger)
+
+	s.sendPlaybookRunUpdatedWS(source.ID)
+	s.sendPlaybookRunUpdatedWS(target.ID, WithAdditionalUserIDs(newParticipantIDs))
+
+	return s.store.GetPlaybookRun(target.ID)
+}
+
+// endMergedRunChannel points the channel of the merged run source to the target run, and archives
+// it unless it is still used by the target or by another active run. Failures are only logged,
+// since the merge itself is already stored.
+func (s *PlaybookRunServiceImpl) endMergedRunChannel(source, target *PlaybookRun, user *model.User, logger logrus.FieldLogger) {
+	logger = logger.WithField("channel_id", source.ChannelID)
+
+	siteURL := s.pluginAPI.Configuration.GetConfig().ServiceSettings.SiteURL
+	targetURL := getRunDetailsURL(*siteURL, target.ID)
+
+	keepChannel := source.ChannelID == target.ChannelID
+	if !keepChannel {
+		activeRunIDs, err := s.store.GetActivePlaybookRunIDsForChannel(source.ChannelID)
+		if err != nil {
+			logger.WithError(err).Warn("failed to get the active runs of the channel of the merged run")
+			return
+		}
+		keepChannel = len(activeRunIDs) > 0
+	}
+
+	message := fmt.Sprintf("@%s merged this run into [%s](%s). Its checklists, participants and status updates continue there.", user.Username, target.Name, targetURL)
+	if !keepChannel {
+		message += " This channel is now archived."
+	}
+	if _, err := s.poster.PostMessage(source.ChannelID, message); err != nil {
+		logger.WithError(err).Warn("failed to post the merge message to the channel of the merged run")
+	}
+
+	if keepChannel {
+		return
+	}
+
+	if err := s.pluginAPI.Channel.Delete(source.ChannelID); err != nil {
+		logger.WithError(err).Warn("failed to archive the channel of the merged run")
+		return
+	}
+
+	eventTime := model.GetMillis()
+	event := &TimelineEvent{
+		PlaybookRunID: source.ID,
+		CreateAt:      eventTime,
+		EventAt:       eventTime,
+		EventType:     ChannelArchived,
+		Summary:       "Channel archived after the run was merged",
+		SubjectUserID: user.Id,
+	}
+	if _, err := s.store.CreateTimelineEvent(event); err != nil {
+		logger.WithError(err).Warn("failed to create timeline event")
+	}
+}
+
 // OpenCreatePlaybookRunDialog opens a interactive dialog to start a new playbook run.
 func (s *PlaybookRunServiceImpl) OpenCreatePlaybookRunDialog(teamID, requesterID, triggerID, postID, clientID string, playbooks []Playbook, isMobileApp bool, promptPostID string) error {
 
@@ -676,6 +1089,7 @@ func (s *PlaybookRunServiceImpl) AddPostToTimeline(playbookRunID, userID, postID
 		PostID:        postID,
 		SubjectUserID: post.UserId,
 		CreatorUserID: userID,
+		UserCreated:   true,
 	}
 
 	if _, err = s.store.CreateTimelineEvent(event); err != nil {
@@ -716,6 +1130,70 @@ func (s *PlaybookRunServiceImpl) RemoveTimelineEvent(playbookRunID, userID, even
 	return nil
 }
 
+// AddKeyEvent adds a user-created event to the timeline of playbookRunID.
+func (s *PlaybookRunServiceImpl) AddKeyEvent(playbookRunID, userID string, options KeyEventOptions) (*TimelineEvent, error) {
+	now := model.GetMillis()
+	event := &TimelineEvent{
+		PlaybookRunID: playbookRunID,
+		CreateAt:      now,
+		EventAt:       now,
+		EventType:     KeyEvent,
+		Summary:       options.Summary,
+		Details:       options.Details,
+		SubjectUserID: userID,
+		CreatorUserID: userID,
+		UserCreated:   true,
+	}
+
+	if options.PostID != "" {
+		playbookRun, err := s.store.GetPlaybookRun(playbookRunID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to retrieve playbook run")
+		}
+
+		post, err := s.pluginAPI.Post.GetPost(options.PostID)
+		if errors.Is(err, pluginapi.ErrNotFound) {
+			return nil, errors.Wrapf(ErrNotFound, "post %s does not exist", options.PostID)
+		} else if err != nil {
+			return nil, errors.Wrap(err, "failed to find post")
+		}
+		if post.DeleteAt != 0 {
+			return nil, errors.Wrapf(ErrNotFound, "post %s was deleted", options.PostID)
+		}
+		if post.ChannelId != playbookRun.ChannelID {
+			return nil, errors.Wrapf(ErrInvalidKeyEvent, "post %s is not in the channel of the run", post.Id)
+		}
+
+		event.PostID = post.Id
+		event.EventAt = post.CreateAt
+		event.SubjectUserID = post.UserId
+	}
+
+	if _, err := s.store.CreateTimelineEvent(event); err != nil {
+		return nil, errors.Wrap(err, "failed to create timeline event")
+	}
+
+	s.sendPlaybookRunUpdatedWS(playbookRunID)
+
+	return event, nil
+}
+
+// RemoveKeyEvent removes a user-created event from the timeline of playbookRunID.
+func (s *PlaybookRunServiceImpl) RemoveKeyEvent(playbookRunID, userID, eventID string) error {
+	event, err := s.store.GetTimelineEvent(playbookRunID, eventID)
+	if err != nil {
+		return err
+	}
+	if event.DeleteAt != 0 {
+		return errors.Wrapf(ErrNotFound, "timeline event %s was already removed", eventID)
+	}
+	if !event.UserCreated {
+		return errors.Wrapf(ErrTimelineEventNotUserCreated, "timeline event %s", eventID)
+	}
+
+	return s.RemoveTimelineEvent(playbookRunID, userID, eventID)
+}
+
 func (s *PlaybookRunServiceImpl) buildStatusUpdatePost(statusUpdate, playbookRunID, authorID string) (*model.Post, error) {
 	playbookRun, err := s.store.GetPlaybookRun(playbookRunID)
 	if err != nil {
@@ -730,8 +1208,11 @@ func (s *PlaybookRunServiceImpl) buildStatusUpdatePost(statusUpdate, playbookRun
 	numTasks := 0
 	numTasksChecked := 0
 	for _, checklist := range playbookRun.Checklists {
-		numTasks += len(checklist.Items)
 		for _, task := range checklist.Items {
+			if task.Hidden {
+				continue
+			}
+			numTasks++
 			if task.State == ChecklistItemStateClosed {
 				numTasksChecked++
 			}
@@ -798,27 +1279,31 @@ func (s *PlaybookRunServiceImpl) sendWebhooksOnUpdateStatus(playbookRunID string
 		return
 	}
 
-	triggerWebhooks(s, playbookRun.WebhookOnStatusUpdateURLs, body)
+	s.webhookDispatcher.Dispatch(playbookRun.ID, playbookRun.WebhookOnStatusUpdateURLs, body)
 }
 
 // UpdateStatus updates a playbook run's status.
-func (s *PlaybookRunServiceImpl) UpdateStatus(playbookRunID, userID string, options StatusUpdateOptions) error {
+func (s *PlaybookRunServiceImpl) UpdateStatus(playbookRunID, userID string, options StatusUpdateOptions) ([]BroadcastResult, error) {
 	logger := logrus.WithField("playbook_run_id", playbookRunID)
 
 	playbookRunToModify, err := s.store.GetPlaybookRun(playbookRunID)
 	if err != nil {
-		return errors.Wrap(err, "failed to retrieve playbook run")
+		return nil, errors.Wrap(err, "failed to retrieve playbook run")
+	}
+
+	if err = checkNotViewer(playbookRunToModify, userID); err != nil {
+		return nil, err
 	}
 
 	originalPost, err := s.buildStatusUpdatePost(options.Message, playbookRunID, userID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	originalPost.ChannelId = playbookRunToModify.ChannelID
 
 	channelPost := originalPost.Clone()
 	if err = s.poster.Post(channelPost); err != nil {
-		return errors.Wrap(err, "failed to post update status message")
+		return nil, errors.Wrap(err, "failed to post update status message")
 	}
 
 	// Add the status manually for the broadcasts
@@ -833,22 +1318,23 @@ func (s *PlaybookRunServiceImpl) UpdateStatus(playbookRunID, userID string, opti
 		PlaybookRunID: playbookRunID,
 		PostID:        channelPost.Id,
 	}); err != nil {
-		return errors.Wrap(err, "failed to write status post to store. there is now inconsistent state")
+		return nil, errors.Wrap(err, "failed to write status post to store. there is now inconsistent state")
 	}
 
+	broadcastResults := []BroadcastResult{}
 	if playbookRunToModify.StatusUpdateBroadcastChannelsEnabled {
-		s.broadcastPlaybookRunMessageToChannels(playbookRunToModify.BroadcastChannelIDs, originalPost.Clone(), statusUpdateMessage, playbookRunToModify, logger)
+		broadcastResults = s.broadcastPlaybookRunMessageToChannels(playbookRunToModify.BroadcastChannelIDs, originalPost.Clone(), statusUpdateMessage, playbookRunToModify, logger)
 		s.telemetry.RunAction(playbookRunToModify, userID, TriggerTypeStatusUpdatePosted, ActionTypeBroadcastChannels, len(playbookRunToModify.BroadcastChannelIDs))
 	}
 
-	err = s.dmPostToRunFollowers(originalPost.Clone(), statusUpdateMessage, playbookRunID, userID)
+	err = s.dmPostToRunFollowers(untranslatedPost(originalPost), statusUpdateMessage, playbookRunID, userID)
 	if err != nil {
 		logger.WithError(err).Error("failed to dm post to run followers")
 	}
 
 	// Remove pending reminder (if any), even if current reminder was set to "none" (0 minutes)
 	if err = s.SetNewReminder(playbookRunID, options.Reminder); err != nil {
-		return errors.Wrapf(err, "failed to set new reminder")
+		return nil, errors.Wrapf(err, "failed to set new reminder")
 	}
 
 	event := &TimelineEvent{
@@ -861,10 +1347,11 @@ func (s *PlaybookRunServiceImpl) UpdateStatus(playbookRunID, userID string, opti
 	}
 
 	if _, err = s.store.CreateTimelineEvent(event); err != nil {
-		return errors.Wrap(err, "failed to create timeline event")
+		return nil, errors.Wrap(err, "failed to create timeline event")
 	}
 
 	s.telemetry.UpdateStatus(playbookRunToModify, userID)
+	s.metricsService.IncrementStatusUpdatesCount(1)
 	s.sendPlaybookRunUpdatedWS(playbookRunID)
 
 	if playbookRunToModify.StatusUpdateBroadcastWebhooksEnabled {
@@ -880,7 +1367,7 @@ func (s *PlaybookRunServiceImpl) UpdateStatus(playbookRunID, userID string, opti
 		s.telemetry.RunAction(playbookRunToModify, userID, TriggerTypeStatusUpdatePosted, ActionTypeBroadcastWebhooks, len(playbookRunToModify.WebhookOnStatusUpdateURLs))
 	}
 
-	return nil
+	return broadcastResults, nil
 }
 
 func (s *PlaybookRunServiceImpl) OpenFinishPlaybookRunDialog(playbookRunID, triggerID string) error {
@@ -955,25 +1442,51 @@ func (s *PlaybookRunServiceImpl) buildStatusUpdateMessage(playbookRun *PlaybookR
 
 // FinishPlaybookRun changes a run's state to Finished. If run is already in Finished state, the call is a noop.
 func (s *PlaybookRunServiceImpl) FinishPlaybookRun(playbookRunID, userID string) error {
+	_, err := s.finishPlaybookRun(playbookRunID, userID)
+	return err
+}
+
+// finishPlaybookRun finishes a run as FinishPlaybookRun does, returning whether it changed the
+// status of the run: false if the run had already finished. The status may have changed even if
+// an error is returned, when what follows the change fails.
+func (s *PlaybookRunServiceImpl) finishPlaybookRun(playbookRunID, userID string) (bool, error) {
 	logger := logrus.WithField("playbook_run_id", playbookRunID)
 
 	playbookRunToModify, err := s.store.GetPlaybookRun(playbookRunID)
 	if err != nil {
-		return errors.Wrap(err, "failed to retrieve playbook run")
+		return false, errors.Wrap(err, "failed to retrieve playbook run")
 	}
 
-	if playbookRunToModify.CurrentStatus == StatusFinished {
-		return nil
+	if err = checkNotViewer(playbookRunToModify, userID); err != nil {
+		return false, err
+	}
+
+	if playbookRunToModify.CurrentStatus == StatusFinished {
+		return false, nil
+	}
+
+	if playbookRunToModify.CurrentStatus == StatusMerged {
+		return false, errors.Wrap(ErrPlaybookRunNotActive, "cannot finish a merged run")
+	}
+
+	if playbookRunToModify.MissingRequiredRetrospective() {
+		return false, ErrRetrospectiveRequired
 	}
 
 	endAt := model.GetMillis()
-	if err = s.store.FinishPlaybookRun(playbookRunID, endAt); err != nil {
-		return err
+	finished, err := s.store.FinishPlaybookRun(playbookRunID, endAt)
+	if err != nil {
+		return false, err
+	}
+	if !finished {
+		// The run was finished, or merged, concurrently.
+		logger.Debug("run had already ended, not finishing it")
+		return false, nil
 	}
 
 	user, err := s.pluginAPI.User.Get(userID)
 	if err != nil {
-		return errors.Wrapf(err, "failed to to resolve user %s", userID)
+		return true, errors.Wrapf(err, "failed to to resolve user %s", userID)
 	}
 
 	message := fmt.Sprintf("@%s marked this run as finished.", user.Username)
@@ -991,7 +1504,7 @@ func (s *PlaybookRunServiceImpl) FinishPlaybookRun(playbookRunID, userID string)
 	}
 
 	runFinishedMessage := s.buildRunFinishedMessage(playbookRunToModify, user.Username)
-	err = s.dmPostToRunFollowers(&model.Post{Message: runFinishedMessage}, finishMessage, playbookRunToModify.ID, userID)
+	err = s.dmPostToRunFollowers(untranslatedPost(&model.Post{Message: runFinishedMessage}), finishMessage, playbookRunToModify.ID, userID)
 	if err != nil {
 		logger.WithError(err).Error("failed to dm post to run followers")
 	}
@@ -1009,12 +1522,12 @@ func (s *PlaybookRunServiceImpl) FinishPlaybookRun(playbookRunID, userID string)
 	if s.licenseChecker.RetrospectiveAllowed() {
 		if playbookRunToModify.RetrospectiveEnabled && playbookRunToModify.RetrospectivePublishedAt == 0 {
 			if err = s.postRetrospectiveReminder(playbookRunToModify, true); err != nil {
-				return errors.Wrap(err, "couldn't post retrospective reminder")
+				return true, errors.Wrap(err, "couldn't post retrospective reminder")
 			}
 			s.scheduler.Cancel(RetrospectivePrefix + playbookRunID)
 			if playbookRunToModify.RetrospectiveReminderIntervalSeconds != 0 {
 				if err = s.SetReminder(RetrospectivePrefix+playbookRunID, time.Duration(playbookRunToModify.RetrospectiveReminderIntervalSeconds)*time.Second); err != nil {
-					return errors.Wrap(err, "failed to set the retrospective reminder for playbook run")
+					return true, errors.Wrap(err, "failed to set the retrospective reminder for playbook run")
 				}
 			}
 		}
@@ -1030,11 +1543,12 @@ func (s *PlaybookRunServiceImpl) FinishPlaybookRun(playbookRunID, userID string)
 	}
 
 	if _, err = s.store.CreateTimelineEvent(event); err != nil {
-		return errors.Wrap(err, "failed to create timeline event")
+		return true, errors.Wrap(err, "failed to create timeline event")
 	}
 
 	s.telemetry.FinishPlaybookRun(playbookRunToModify, userID)
 	s.metricsService.IncrementRunsFinishedCount(1)
+	s.metricsService.ObserveRunDuration(time.Duration(playbookRunToModify.ActiveDuration(endAt)) * time.Millisecond)
 	s.sendPlaybookRunUpdatedWS(playbookRunID)
 
 	if playbookRunToModify.StatusUpdateBroadcastWebhooksEnabled {
@@ -1049,7 +1563,7 @@ func (s *PlaybookRunServiceImpl) FinishPlaybookRun(playbookRunID, userID string)
 		s.telemetry.RunAction(playbookRunToModify, userID, TriggerTypeStatusUpdatePosted, ActionTypeBroadcastWebhooks, len(playbookRunToModify.WebhookOnStatusUpdateURLs))
 	}
 
-	return nil
+	return true, nil
 }
 
 func (s *PlaybookRunServiceImpl) ToggleStatusUpdates(playbookRunID, userID string, enable bool) error {
@@ -1060,6 +1574,10 @@ func (s *PlaybookRunServiceImpl) ToggleStatusUpdates(playbookRunID, userID strin
 		return errors.Wrap(err, "failed to retrieve playbook run")
 	}
 
+	if err = checkNotViewer(playbookRunToModify, userID); err != nil {
+		return err
+	}
+
 	updateAt := model.GetMillis()
 	playbookRunToModify.StatusUpdateEnabled = enable
 
@@ -1103,7 +1621,7 @@ func (s *PlaybookRunServiceImpl) ToggleStatusUpdates(playbookRunID, userID strin
 	}
 
 	runStatusUpdateMessage := s.buildStatusUpdateMessage(playbookRunToModify, user.Username, statusUpdate)
-	if err := s.dmPostToRunFollowers(&model.Post{Message: runStatusUpdateMessage}, statusUpdateMessage, playbookRunToModify.ID, userID); err != nil {
+	if err := s.dmPostToRunFollowers(untranslatedPost(&model.Post{Message: runStatusUpdateMessage}), statusUpdateMessage, playbookRunToModify.ID, userID); err != nil {
 		logger.WithError(err).Error("failed to dm post toggle-run-status-updates to run followers")
 	}
 
@@ -1151,6 +1669,10 @@ func (s *PlaybookRunServiceImpl) RestorePlaybookRun(playbookRunID, userID string
 		return errors.Wrap(err, "failed to retrieve playbook run")
 	}
 
+	if err = checkNotViewer(playbookRunToRestore, userID); err != nil {
+		return err
+	}
+
 	if playbookRunToRestore.CurrentStatus != StatusFinished {
 		return nil
 	}
@@ -1210,6 +1732,201 @@ func (s *PlaybookRunServiceImpl) RestorePlaybookRun(playbookRunID, userID string
 	return nil
 }
 
+// RestartPlaybookRun reverts a run from the Finished state to continue it, instead of cloning it.
+// Unlike RestorePlaybookRun, the run's channel is unarchived if it was archived since the run
+// finished, and restarting a run that is not finished fails.
+func (s *PlaybookRunServiceImpl) RestartPlaybookRun(playbookRunID, userID string) error {
+	playbookRunToRestart, err := s.store.GetPlaybookRun(playbookRunID)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve playbook run")
+	}
+
+	if err = checkNotViewer(playbookRunToRestart, userID); err != nil {
+		return err
+	}
+
+	switch playbookRunToRestart.CurrentStatus {
+	case StatusFinished:
+	case StatusMerged:
+		return errors.Wrap(ErrPlaybookRunNotActive, "cannot restart a merged run")
+	default:
+		return errors.Wrapf(ErrPlaybookRunNotFinished, "cannot restart run %s, which is %s", playbookRunID, playbookRunToRestart.CurrentStatus)
+	}
+
+	// Unarchive the channel first, so that the status update can be posted in it.
+	if err = s.unarchiveRunChannel(playbookRunToRestart, userID); err != nil {
+		return err
+	}
+
+	return s.RestorePlaybookRun(playbookRunID, userID)
+}
+
+// unarchiveRunChannel unarchives the channel of playbookRun, if it was archived.
+func (s *PlaybookRunServiceImpl) unarchiveRunChannel(playbookRun *PlaybookRun, userID string) error {
+	channel, err := s.pluginAPI.Channel.Get(playbookRun.ChannelID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get the channel of the run")
+	}
+	if channel.DeleteAt == 0 {
+		return nil
+	}
+
+	// The plugin API can't restore a channel, but updating it with DeleteAt cleared does.
+	channel.DeleteAt = 0
+	if err = s.pluginAPI.Channel.Update(channel); err != nil {
+		return errors.Wrap(err, "failed to unarchive the channel of the run")
+	}
+
+	eventTime := model.GetMillis()
+	event := &TimelineEvent{
+		PlaybookRunID: playbookRun.ID,
+		CreateAt:      eventTime,
+		EventAt:       eventTime,
+		EventType:     ChannelUnarchived,
+		Summary:       "Channel unarchived after the run was restarted",
+		SubjectUserID: userID,
+	}
+	if _, err = s.store.CreateTimelineEvent(event); err != nil {
+		return errors.Wrap(err, "failed to create timeline event")
+	}
+
+	return nil
+}
+
+// PausePlaybookRun moves a run to the Paused state. Paused time is not counted towards the run's
+// duration and no status update reminders are sent while paused. If run is already paused, the call is a noop.
+func (s *PlaybookRunServiceImpl) PausePlaybookRun(playbookRunID, userID string) error {
+	logger := logrus.WithField("playbook_run_id", playbookRunID)
+
+	playbookRunToPause, err := s.store.GetPlaybookRun(playbookRunID)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve playbook run")
+	}
+
+	if err = checkNotViewer(playbookRunToPause, userID); err != nil {
+		return err
+	}
+
+	if playbookRunToPause.CurrentStatus == StatusPaused {
+		return nil
+	}
+
+	if playbookRunToPause.CurrentStatus == StatusFinished || playbookRunToPause.CurrentStatus == StatusMerged {
+		return errors.Wrap(ErrPlaybookRunNotActive, "cannot pause a finished run")
+	}
+
+	pausedAt := model.GetMillis()
+	paused, err := s.store.PausePlaybookRun(playbookRunID, pausedAt)
+	if err != nil {
+		return err
+	}
+	if !paused {
+		// The run was paused, or ended, concurrently.
+		logger.Debug("run was no longer in progress, not pausing it")
+		return nil
+	}
+
+	s.RemoveReminder(playbookRunID)
+
+	user, err := s.pluginAPI.User.Get(userID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to to resolve user %s", userID)
+	}
+
+	message := fmt.Sprintf("@%s paused this run.", user.Username)
+	postID := ""
+	post, err := s.poster.PostMessage(playbookRunToPause.ChannelID, message)
+	if err != nil {
+		logger.WithField("channel_id", playbookRunToPause.ChannelID).Error("failed to post the pause message to channel")
+	} else {
+		postID = post.Id
+	}
+
+	event := &TimelineEvent{
+		PlaybookRunID: playbookRunID,
+		CreateAt:      pausedAt,
+		EventAt:       pausedAt,
+		EventType:     RunPaused,
+		PostID:        postID,
+		SubjectUserID: userID,
+	}
+
+	if _, err = s.store.CreateTimelineEvent(event); err != nil {
+		return errors.Wrap(err, "failed to create timeline event")
+	}
+
+	s.sendPlaybookRunUpdatedWS(playbookRunID)
+
+	return nil
+}
+
+// ResumePlaybookRun moves a run from the Paused state back to In Progress, rescheduling its status
+// update reminder. If run was not paused, the call is a noop.
+func (s *PlaybookRunServiceImpl) ResumePlaybookRun(playbookRunID, userID string) error {
+	logger := logrus.WithField("playbook_run_id", playbookRunID)
+
+	playbookRunToResume, err := s.store.GetPlaybookRun(playbookRunID)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve playbook run")
+	}
+
+	if err = checkNotViewer(playbookRunToResume, userID); err != nil {
+		return err
+	}
+
+	if playbookRunToResume.CurrentStatus != StatusPaused {
+		return nil
+	}
+
+	resumedAt := model.GetMillis()
+	resumed, err := s.store.ResumePlaybookRun(playbookRunID, resumedAt)
+	if err != nil {
+		return err
+	}
+	if !resumed {
+		// The run was resumed, or ended, concurrently.
+		logger.Debug("run was no longer paused, not resuming it")
+		return nil
+	}
+
+	user, err := s.pluginAPI.User.Get(userID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to to resolve user %s", userID)
+	}
+
+	message := fmt.Sprintf("@%s resumed this run.", user.Username)
+	postID := ""
+	post, err := s.poster.PostMessage(playbookRunToResume.ChannelID, message)
+	if err != nil {
+		logger.WithField("channel_id", playbookRunToResume.ChannelID).Error("failed to post the resume message to channel")
+	} else {
+		postID = post.Id
+	}
+
+	if playbookRunToResume.StatusUpdateEnabled && playbookRunToResume.PreviousReminder > 0 {
+		if err = s.SetNewReminder(playbookRunID, playbookRunToResume.PreviousReminder); err != nil {
+			logger.WithError(err).Error("failed to reschedule the status update reminder")
+		}
+	}
+
+	event := &TimelineEvent{
+		PlaybookRunID: playbookRunID,
+		CreateAt:      resumedAt,
+		EventAt:       resumedAt,
+		EventType:     RunResumed,
+		PostID:        postID,
+		SubjectUserID: userID,
+	}
+
+	if _, err = s.store.CreateTimelineEvent(event); err != nil {
+		return errors.Wrap(err, "failed to create timeline event")
+	}
+
+	s.sendPlaybookRunUpdatedWS(playbookRunID)
+
+	return nil
+}
+
 // GraphqlUpdate updates fields based on a setmap
 func (s *PlaybookRunServiceImpl) GraphqlUpdate(id string, setmap map[string]interface{}) error {
 	if err := s.store.GraphqlUpdate(id, setmap); err != nil {
@@ -1221,13 +1938,14 @@ func (s *PlaybookRunServiceImpl) GraphqlUpdate(id string, setmap map[string]inte
 
 func (s *PlaybookRunServiceImpl) postRetrospectiveReminder(playbookRun *PlaybookRun, isInitial bool) error {
 	retrospectiveURL := getRunRetrospectiveURL("", playbookRun.ID)
+	T := s.serverTranslations()
 
 	attachments := []*model.SlackAttachment{
 		{
 			Actions: []*model.PostAction{
 				{
 					Type: "button",
-					Name: "No Retrospective",
+					Name: T("app.user.run.retrospective_reminder.no_retrospective_button"),
 					Integration: &model.PostActionIntegration{
 						URL: fmt.Sprintf("/plugins/%s/api/v0/runs/%s/no-retrospective-button",
 							s.configService.GetManifest().Id,
@@ -1243,7 +1961,8 @@ func (s *PlaybookRunServiceImpl) postRetrospectiveReminder(playbookRun *Playbook
 		customPostType = "custom_retro_rem_first"
 	}
 
-	if _, err := s.poster.PostCustomMessageWithAttachments(playbookRun.ChannelID, customPostType, attachments, "@channel Reminder to [fill out the retrospective](%s).", retrospectiveURL); err != nil {
+	message := T("app.user.run.retrospective_reminder", map[string]interface{}{"RetrospectiveURL": retrospectiveURL})
+	if _, err := s.poster.PostCustomMessageWithAttachments(playbookRun.ChannelID, customPostType, attachments, "%s", message); err != nil {
 		return errors.Wrap(err, "failed to post retro reminder to channel")
 	}
 
@@ -1255,6 +1974,54 @@ func (s *PlaybookRunServiceImpl) GetPlaybookRun(playbookRunID string) (*Playbook
 	return s.store.GetPlaybookRun(playbookRunID)
 }
 
+// GetPlaybookRunsBatch gets the runs of options.PlaybookRunIDs that userID can view, in the
+// order they were requested. Repeated IDs are returned once.
+func (s *PlaybookRunServiceImpl) GetPlaybookRunsBatch(userID string, options PlaybookRunsBatchOptions) (*PlaybookRunsBatchResult, error) {
+	playbookRuns, err := s.store.GetPlaybookRunsByIDs(options.PlaybookRunIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get playbook runs")
+	}
+
+	playbookRunsByID := make(map[string]*PlaybookRun, len(playbookRuns))
+	for i := range playbookRuns {
+		playbookRunsByID[playbookRuns[i].ID] = &playbookRuns[i]
+	}
+
+	result := &PlaybookRunsBatchResult{Items: []PlaybookRun{}, OmittedRunIDs: []string{}}
+	seen := make(map[string]bool, len(options.PlaybookRunIDs))
+	for _, playbookRunID := range options.PlaybookRunIDs {
+		if seen[playbookRunID] {
+			continue
+		}
+		seen[playbookRunID] = true
+
+		playbookRun, ok := playbookRunsByID[playbookRunID]
+		if !ok || s.permissions.RunViewWithRun(userID, playbookRun) != nil {
+			result.OmittedRunIDs = append(result.OmittedRunIDs, playbookRunID)
+			continue
+		}
+		result.Items = append(result.Items, *playbookRun)
+	}
+
+	return result, nil
+}
+
+// GetRunActivity gets a page of the activity feed of a playbook run, oldest first.
+func (s *PlaybookRunServiceImpl) GetRunActivity(playbookRunID string, options RunActivityOptions) (*RunActivityResults, error) {
+	after, err := parseRunActivityCursor(options.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	// One more entry than the page tells whether there are more pages.
+	playbookRun, err := s.store.GetPlaybookRunActivity(playbookRunID, after.At, after.ID, options.perPage()+1)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to retrieve activity of playbook run '%s'", playbookRunID)
+	}
+
+	return paginateRunActivity(getRunActivity(playbookRun), options)
+}
+
 // GetPlaybookRunMetadata gets ancillary metadata about a playbook run.
 func (s *PlaybookRunServiceImpl) GetPlaybookRunMetadata(playbookRunID string) (*Metadata, error) {
 	playbookRun, err := s.GetPlaybookRun(playbookRunID)
@@ -1302,6 +2069,22 @@ func (s *PlaybookRunServiceImpl) GetPlaybookRunIDForChannel(channelID string) (s
 	return playbookRunID, nil
 }
 
+// GetActivePlaybookRunIDsForChannel returns the IDs of the unfinished runs in channelID.
+func (s *PlaybookRunServiceImpl) GetActivePlaybookRunIDsForChannel(channelID string) ([]string, error) {
+	return s.store.GetActivePlaybookRunIDsForChannel(channelID)
+}
+
+// GetTaskLoad returns, for the users with work in the active runs of a team visible to the
+// requester, their incomplete assigned checklist items and owned runs. Options must be validated.
+func (s *PlaybookRunServiceImpl) GetTaskLoad(requesterInfo RequesterInfo, options TaskLoadOptions) ([]UserTaskLoad, error) {
+	taskLoad, err := s.store.GetTaskLoad(requesterInfo, options)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't get task load from the store")
+	}
+
+	return taskLoad, nil
+}
+
 // GetOwners returns all the owners of the playbook runs selected by options
 func (s *PlaybookRunServiceImpl) GetOwners(requesterInfo RequesterInfo, options PlaybookRunFilterOptions) ([]OwnerInfo, error) {
 	owners, err := s.store.GetOwners(requesterInfo, options)
@@ -1309,18 +2092,7 @@ func (s *PlaybookRunServiceImpl) GetOwners(requesterInfo RequesterInfo, options
 		return nil, errors.Wrap(err, "can't get owners from the store")
 	}
 
-	// ShowFullName is coming as nil when setting is set to false
-	// TODO: further investigation https://mattermost.atlassian.net/browse/MM-48464
-	var showFullName bool
-	if IsSystemAdmin(requesterInfo.UserID, s.pluginAPI) {
-		showFullName = true
-	} else {
-		cfg := s.pluginAPI.Configuration.GetConfig()
-		if cfg.PrivacySettings.ShowFullName != nil {
-			showFullName = *cfg.PrivacySettings.ShowFullName
-		}
-	}
-
+	showFullName := CanSeeFullNames(requesterInfo.UserID, s.pluginAPI)
 	for k, o := range owners {
 		if !showFullName {
 			o.FirstName = ""
@@ -1331,6 +2103,49 @@ func (s *PlaybookRunServiceImpl) GetOwners(requesterInfo RequesterInfo, options
 	return owners, nil
 }
 
+// AutocompleteRunUsers returns the users of the team of the run starting with prefix, members of
+// the channel of the run first.
+func (s *PlaybookRunServiceImpl) AutocompleteRunUsers(requesterUserID, playbookRunID, prefix string, limit int) ([]RunUserAutocompleteResult, error) {
+	if limit <= 0 {
+		limit = DefaultRunUserAutocompleteLimit
+	} else if limit > MaxRunUserAutocompleteLimit {
+		limit = MaxRunUserAutocompleteLimit
+	}
+
+	playbookRun, err := s.store.GetPlaybookRun(playbookRunID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get playbook run `%s`", playbookRunID)
+	}
+
+	// Users that can't see full names must not find other users by them either.
+	showFullName := CanSeeFullNames(requesterUserID, s.pluginAPI)
+	users, err := s.store.GetUsersForAutocomplete(playbookRun.TeamID, playbookRun.ChannelID, strings.TrimSpace(prefix), showFullName, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't get users from the store")
+	}
+
+	nameFormat := model.ShowUsername
+	if teammateNameDisplay := s.pluginAPI.Configuration.GetConfig().TeamSettings.TeammateNameDisplay; teammateNameDisplay != nil {
+		nameFormat = *teammateNameDisplay
+	}
+
+	for i, user := range users {
+		if !showFullName {
+			user.FirstName = ""
+			user.LastName = ""
+		}
+		user.DisplayName = (&model.User{
+			Username:  user.Username,
+			FirstName: user.FirstName,
+			LastName:  user.LastName,
+			Nickname:  user.Nickname,
+		}).GetDisplayName(nameFormat)
+		users[i] = user
+	}
+
+	return users, nil
+}
+
 // IsOwner returns true if the userID is the owner for playbookRunID.
 func (s *PlaybookRunServiceImpl) IsOwner(playbookRunID, userID string) bool {
 	playbookRun, err := s.store.GetPlaybookRun(playbookRunID)
@@ -1348,6 +2163,10 @@ func (s *PlaybookRunServiceImpl) ChangeOwner(playbookRunID, userID, ownerID stri
 		return err
 	}
 
+	if err = checkNotViewer(playbookRunToModify, userID); err != nil {
+		return err
+	}
+
 	if playbookRunToModify.OwnerUserID == ownerID {
 		return nil
 	}
@@ -1375,6 +2194,13 @@ func (s *PlaybookRunServiceImpl) ChangeOwner(playbookRunID, userID, ownerID stri
 		return errors.Wrap(err, "failed to make owner follow run")
 	}
 
+	// a co-owner promoted to owner is no longer a co-owner
+	if playbookRunToModify.IsOwnerOrCoOwner(ownerID) {
+		if err = s.store.RemoveCoOwners(playbookRunID, []string{ownerID}); err != nil {
+			return errors.Wrap(err, "failed to remove new owner from co-owners")
+		}
+	}
+
 	playbookRunToModify.OwnerUserID = ownerID
 
 	playbookRunToModify, err = s.store.UpdatePlaybookRun(playbookRunToModify)
@@ -1412,9 +2238,219 @@ func (s *PlaybookRunServiceImpl) ChangeOwner(playbookRunID, userID, ownerID stri
 	return nil
 }
 
+// AddCoOwner processes a request from userID to make coOwnerID a co-owner of playbookRunID.
+// Adding an existing co-owner is a no-op.
+func (s *PlaybookRunServiceImpl) AddCoOwner(playbookRunID, userID, coOwnerID string) error {
+	playbookRunToModify, err := s.store.GetPlaybookRun(playbookRunID)
+	if err != nil {
+		return err
+	}
+
+	if err = checkNotViewer(playbookRunToModify, userID); err != nil {
+		return err
+	}
+
+	if playbookRunToModify.OwnerUserID == coOwnerID {
+		return errors.Wrapf(ErrInvalidCoOwner, "user %s is already the owner of playbook run %s", coOwnerID, playbookRunID)
+	}
+
+	if playbookRunToModify.IsOwnerOrCoOwner(coOwnerID) {
+		return nil
+	}
+
+	coOwner, err := s.pluginAPI.User.Get(coOwnerID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to to resolve user %s", coOwnerID)
+	}
+	subjectUser, err := s.pluginAPI.User.Get(userID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to to resolve user %s", userID)
+	}
+
+	// add co-owner as user
+	err = s.AddParticipants(playbookRunID, []string{coOwnerID}, userID, false)
+	if err != nil {
+		return errors.Wrap(err, "failed to add co-owner as a participant")
+	}
+	err = s.Follow(playbookRunID, coOwnerID)
+	if err != nil {
+		return errors.Wrap(err, "failed to make co-owner follow run")
+	}
+
+	if err = s.store.AddCoOwner(playbookRunID, coOwnerID); err != nil {
+		return errors.Wrap(err, "failed to add co-owner")
+	}
+
+	if coOwnerID != userID {
+		msg := fmt.Sprintf("@%s added you as a co-owner of run: [%s](%s)",
+			subjectUser.Username, playbookRunToModify.Name, GetRunDetailsRelativeURL(playbookRunToModify.ID))
+		if err = s.poster.DM(coOwnerID, &model.Post{Message: msg}); err != nil {
+			return errors.Wrapf(err, "failed to send DM in AddCoOwner")
+		}
+	}
+
+	eventTime := model.GetMillis()
+	event := &TimelineEvent{
+		PlaybookRunID: playbookRunID,
+		CreateAt:      eventTime,
+		EventAt:       eventTime,
+		EventType:     CoOwnerAdded,
+		Summary:       fmt.Sprintf("@%s", coOwner.Username),
+		SubjectUserID: userID,
+	}
+
+	if _, err = s.store.CreateTimelineEvent(event); err != nil {
+		return errors.Wrap(err, "failed to create timeline event")
+	}
+
+	s.sendPlaybookRunUpdatedWS(playbookRunID)
+
+	return nil
+}
+
+// RemoveCoOwner processes a request from userID to remove coOwnerID from the co-owners of
+// playbookRunID. Removing a user that is not a co-owner is a no-op.
+func (s *PlaybookRunServiceImpl) RemoveCoOwner(playbookRunID, userID, coOwnerID string) error {
+	playbookRunToModify, err := s.store.GetPlaybookRun(playbookRunID)
+	if err != nil {
+		return err
+	}
+
+	if err = checkNotViewer(playbookRunToModify, userID); err != nil {
+		return err
+	}
+
+	if playbookRunToModify.OwnerUserID == coOwnerID || !playbookRunToModify.IsOwnerOrCoOwner(coOwnerID) {
+		return nil
+	}
+
+	coOwner, err := s.pluginAPI.User.Get(coOwnerID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to to resolve user %s", coOwnerID)
+	}
+
+	if err = s.store.RemoveCoOwners(playbookRunID, []string{coOwnerID}); err != nil {
+		return errors.Wrap(err, "failed to remove co-owner")
+	}
+
+	eventTime := model.GetMillis()
+	event := &TimelineEvent{
+		PlaybookRunID: playbookRunID,
+		CreateAt:      eventTime,
+		EventAt:       eventTime,
+		EventType:     CoOwnerRemoved,
+		Summary:       fmt.Sprintf("@%s", coOwner.Username),
+		SubjectUserID: userID,
+	}
+
+	if _, err = s.store.CreateTimelineEvent(event); err != nil {
+		return errors.Wrap(err, "failed to create timeline event")
+	}
+
+	s.sendPlaybookRunUpdatedWS(playbookRunID)
+
+	return nil
+}
+
+// runTagsAutocompleteLimit is the maximum number of tags suggested by GetTeamTags.
+const runTagsAutocompleteLimit = 20
+
+// AddTags normalizes tags and adds them to the tags of playbookRunID.
+func (s *PlaybookRunServiceImpl) AddTags(playbookRunID string, tags []string) error {
+	normalizedTags := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		normalized, err := NormalizeRunTag(tag)
+		if err != nil {
+			return err
+		}
+		normalizedTags = append(normalizedTags, normalized)
+	}
+
+	if len(normalizedTags) == 0 {
+		return nil
+	}
+
+	if err := s.store.AddRunTags(playbookRunID, normalizedTags); err != nil {
+		return errors.Wrap(err, "failed to add tags")
+	}
+
+	s.sendPlaybookRunUpdatedWS(playbookRunID)
+
+	return nil
+}
+
+// RemoveTag removes tag from the tags of playbookRunID.
+func (s *PlaybookRunServiceImpl) RemoveTag(playbookRunID string, tag string) error {
+	normalized, err := NormalizeRunTag(tag)
+	if err != nil {
+		return err
+	}
+
+	if err := s.store.RemoveRunTag(playbookRunID, normalized); err != nil {
+		return errors.Wrap(err, "failed to remove tag")
+	}
+
+	s.sendPlaybookRunUpdatedWS(playbookRunID)
+
+	return nil
+}
+
+// GetTeamTags suggests the tags already used by the runs of teamID that start with prefix.
+func (s *PlaybookRunServiceImpl) GetTeamTags(teamID, prefix string) ([]string, error) {
+	tags, err := s.store.GetTeamRunTags(teamID, strings.ToLower(strings.TrimSpace(prefix)), runTagsAutocompleteLimit)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get team tags")
+	}
+
+	return tags, nil
+}
+
+// SetPropertyValue validates value against the definition of the property, found in the run's
+// playbook, and sets it for the run.
+func (s *PlaybookRunServiceImpl) SetPropertyValue(playbookRunID, userID, propertyDefinitionID, value string) error {
+	playbookRun, err := s.store.GetPlaybookRun(playbookRunID)
+	if err != nil {
+		return err
+	}
+
+	if playbookRun.PlaybookID == "" {
+		return errors.Wrapf(ErrNotFound, "run %s has no playbook", playbookRunID)
+	}
+
+	playbook, err := s.playbookService.Get(playbookRun.PlaybookID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get playbook %s", playbookRun.PlaybookID)
+	}
+
+	var definition *PropertyDefinition
+	for i := range playbook.PropertyDefinitions {
+		if playbook.PropertyDefinitions[i].ID == propertyDefinitionID {
+			definition = &playbook.PropertyDefinitions[i]
+			break
+		}
+	}
+	if definition == nil {
+		return errors.Wrapf(ErrNotFound, "property definition %s not found in playbook %s", propertyDefinitionID, playbook.ID)
+	}
+
+	value = strings.TrimSpace(value)
+	if err = definition.ValidateValue(value); err != nil {
+		return err
+	}
+
+	if err = s.store.SetPropertyValue(playbookRunID, propertyDefinitionID, value); err != nil {
+		return errors.Wrapf(err, "failed to set property %s of run %s", propertyDefinitionID, playbookRunID)
+	}
+
+	s.sendPlaybookRunUpdatedWS(playbookRunID)
+
+	return nil
+}
+
 // ModifyCheckedState checks or unchecks the specified checklist item. Idempotent, will not perform
-// any action if the checklist item is already in the given checked state
-func (s *PlaybookRunServiceImpl) ModifyCheckedState(playbookRunID, userID, newState string, checklistNumber, itemNumber int) error {
+// any action if the checklist item is already in the given checked state. skipReason is only kept
+// for skipped items; changing the reason of a skipped item is recorded like a skip.
+func (s *PlaybookRunServiceImpl) ModifyCheckedState(playbookRunID, userID, newState, skipReason string, checklistNumber, itemNumber int) error {
 	playbookRunToModify, err := s.checklistItemParamsVerify(playbookRunID, userID, checklistNumber, itemNumber)
 	if err != nil {
 		return err
@@ -1425,10 +2461,28 @@ func (s *PlaybookRunServiceImpl) ModifyCheckedState(playbookRunID, userID, newSt
 	}
 
 	itemToCheck := playbookRunToModify.Checklists[checklistNumber].Items[itemNumber]
-	if newState == itemToCheck.State {
+	if newState != ChecklistItemStateSkipped {
+		skipReason = ""
+	}
+	if newState == itemToCheck.State && skipReason == itemToCheck.SkipReason {
 		return nil
 	}
 
+	if newState == ChecklistItemStateClosed && itemToCheck.Blocked {
+		dependency, _ := playbookRunToModify.ChecklistItemDependency(itemToCheck)
+		if playbookRunToModify.ChecklistItemDependenciesEnforced {
+			return errors.Wrapf(ErrChecklistItemBlocked, "checklist item %q depends on %q", itemToCheck.Title, dependency.Title)
+		}
+
+		T := userTranslations(s.pluginAPI, userID)
+		s.poster.EphemeralPost(userID, playbookRunToModify.ChannelID, &model.Post{
+			Message: T("app.user.run.checklist_item_dependency_warning", map[string]interface{}{
+				"ItemTitle":       stripmd.Strip(itemToCheck.Title),
+				"DependencyTitle": stripmd.Strip(dependency.Title),
+			}),
+		})
+	}
+
 	modifyMessage := fmt.Sprintf("checked off checklist item **%v**", stripmd.Strip(itemToCheck.Title))
 	if newState == ChecklistItemStateOpen {
 		modifyMessage = fmt.Sprintf("unchecked checklist item **%v**", stripmd.Strip(itemToCheck.Title))
@@ -1441,7 +2495,12 @@ func (s *PlaybookRunServiceImpl) ModifyCheckedState(playbookRunID, userID, newSt
 	}
 
 	itemToCheck.State = newState
+	itemToCheck.SkipReason = skipReason
 	itemToCheck.StateModified = model.GetMillis()
+	itemToCheck.StateModifiedBy = userID
+	if newState == ChecklistItemStateSkipped {
+		itemToCheck.LastSkipped = itemToCheck.StateModified
+	}
 	playbookRunToModify.Checklists[checklistNumber].Items[itemNumber] = itemToCheck
 
 	playbookRunToModify, err = s.store.UpdatePlaybookRun(playbookRunToModify)
@@ -1450,6 +2509,7 @@ func (s *PlaybookRunServiceImpl) ModifyCheckedState(playbookRunID, userID, newSt
 	}
 
 	s.telemetry.ModifyCheckedState(playbookRunID, userID, itemToCheck, playbookRunToModify.OwnerUserID == userID)
+	s.setChecklistItemDueReminder(playbookRunID, itemToCheck)
 
 	event := &TimelineEvent{
 		PlaybookRunID: playbookRunID,
@@ -1457,15 +2517,55 @@ func (s *PlaybookRunServiceImpl) ModifyCheckedState(playbookRunID, userID, newSt
 		EventAt:       itemToCheck.StateModified,
 		EventType:     TaskStateModified,
 		Summary:       modifyMessage,
+		Details:       skipReason,
 		SubjectUserID: userID,
 	}
 
-	if _, err = s.store.CreateTimelineEvent(event); err != nil {
-		return errors.Wrap(err, "failed to create timeline event")
+	if _, err = s.store.CreateTimelineEvent(event); err != nil {
+		return errors.Wrap(err, "failed to create timeline event")
+	}
+	s.sendPlaybookRunUpdatedWS(playbookRunID)
+
+	if newState != ChecklistItemStateOpen {
+		s.autoFinishIfChecklistsComplete(playbookRunToModify, userID)
+	}
+
+	return nil
+}
+
+// autoFinishIfChecklistsComplete finishes the run on behalf of userID, with a status update saying
+// why, if it finishes automatically and all its checklist items are now done or skipped. A run
+// missing its required retrospective is left in progress, with a message asking for it instead.
+// Errors are only logged, since the change to the checklists that completed them was saved.
+func (s *PlaybookRunServiceImpl) autoFinishIfChecklistsComplete(playbookRun *PlaybookRun, userID string) {
+	if !playbookRun.AutoFinishEnabled || playbookRun.CurrentStatus != StatusInProgress || !playbookRun.ChecklistsComplete() {
+		return
+	}
+
+	logger := logrus.WithField("playbook_run_id", playbookRun.ID)
+	T := s.serverTranslations()
+
+	if playbookRun.MissingRequiredRetrospective() {
+		if _, err := s.poster.PostMessage(playbookRun.ChannelID, T("app.user.run.auto_finish.retrospective_required")); err != nil {
+			logger.WithError(err).Error("failed to ask for the retrospective of a run with all its checklist items done")
+		}
+		return
+	}
+
+	// Items completed concurrently may both see the run complete: only the one that finishes it
+	// posts the notice.
+	finished, err := s.finishPlaybookRun(playbookRun.ID, userID)
+	if err != nil {
+		logger.WithError(err).Error("failed to finish a run with all its checklist items done")
+	}
+	if !finished {
+		return
 	}
-	s.sendPlaybookRunUpdatedWS(playbookRunID)
 
-	return nil
+	options := StatusUpdateOptions{Message: T("app.user.run.auto_finish.finished")}
+	if _, err := s.UpdateStatus(playbookRun.ID, userID, options); err != nil {
+		logger.WithError(err).Error("failed to post the status update finishing a run with all its checklist items done")
+	}
 }
 
 // ToggleCheckedState checks or unchecks the specified checklist item
@@ -1485,7 +2585,7 @@ func (s *PlaybookRunServiceImpl) ToggleCheckedState(playbookRunID, userID string
 		newState = ChecklistItemStateClosed
 	}
 
-	return s.ModifyCheckedState(playbookRunID, userID, newState, checklistNumber, itemNumber)
+	return s.ModifyCheckedState(playbookRunID, userID, newState, "", checklistNumber, itemNumber)
 }
 
 // SetAssignee sets the assignee for the specified checklist item
@@ -1582,6 +2682,7 @@ func (s *PlaybookRunServiceImpl) SetAssignee(playbookRunID, userID, assigneeID s
 	}
 
 	s.telemetry.SetAssignee(playbookRunID, userID, itemToCheck)
+	s.setChecklistItemDueReminder(playbookRunID, itemToCheck)
 
 	modifyMessage := fmt.Sprintf("changed assignee of checklist item **%s** from **%s** to **%s**",
 		stripmd.Strip(itemToCheck.Title), oldAssigneeUserAtMention, newAssigneeUserAtMention)
@@ -1639,12 +2740,47 @@ func (s *PlaybookRunServiceImpl) SetDueDate(playbookRunID, userID string, duedat
 
 	itemToCheck := playbookRunToModify.Checklists[checklistNumber].Items[itemNumber]
 	itemToCheck.DueDate = duedate
+	itemToCheck.DueOffset = 0
+	playbookRunToModify.Checklists[checklistNumber].Items[itemNumber] = itemToCheck
+
+	_, err = s.store.UpdatePlaybookRun(playbookRunToModify)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update playbook run; it is now in an inconsistent state")
+	}
+	s.setChecklistItemDueReminder(playbookRunID, itemToCheck)
+	s.sendPlaybookRunUpdatedWS(playbookRunID)
+
+	return nil
+}
+
+// SetDueOffset sets the due date of the specified checklist item relative to the start of the run
+func (s *PlaybookRunServiceImpl) SetDueOffset(playbookRunID, userID string, dueOffset int64, checklistNumber, itemNumber int) error {
+	if dueOffset < 0 {
+		return errors.New("due offset must not be negative")
+	}
+
+	playbookRunToModify, err := s.checklistItemParamsVerify(playbookRunID, userID, checklistNumber, itemNumber)
+	if err != nil {
+		return err
+	}
+
+	if !IsValidChecklistItemIndex(playbookRunToModify.Checklists, checklistNumber, itemNumber) {
+		return errors.New("invalid checklist item indices")
+	}
+
+	itemToCheck := playbookRunToModify.Checklists[checklistNumber].Items[itemNumber]
+	itemToCheck.DueOffset = dueOffset
+	itemToCheck.DueDate = 0
+	if dueOffset > 0 {
+		itemToCheck.DueDate = playbookRunToModify.CreateAt + dueOffset
+	}
 	playbookRunToModify.Checklists[checklistNumber].Items[itemNumber] = itemToCheck
 
 	_, err = s.store.UpdatePlaybookRun(playbookRunToModify)
 	if err != nil {
 		return errors.Wrapf(err, "failed to update playbook run; it is now in an inconsistent state")
 	}
+	s.setChecklistItemDueReminder(playbookRunID, itemToCheck)
 	s.sendPlaybookRunUpdatedWS(playbookRunID)
 
 	return nil
@@ -1691,14 +2827,22 @@ func (s *PlaybookRunServiceImpl) RunChecklistItemSlashCommand(playbookRunID, use
 	if err == pluginapi.ErrNotFound {
 		trigger := strings.Fields(command)[0]
 		s.poster.EphemeralPost(userID, playbookRun.ChannelID, &model.Post{Message: fmt.Sprintf("Failed to find slash command **%s**", trigger)})
+		s.recordFailedCommandOutput(playbookRun, checklistNumber, itemNumber, command, userID, fmt.Sprintf("Failed to find slash command %s", trigger))
 
 		return "", errors.Wrap(err, "failed to find slash command")
 	} else if err != nil {
 		s.poster.EphemeralPost(userID, playbookRun.ChannelID, &model.Post{Message: fmt.Sprintf("Failed to execute slash command **%s**", command)})
+		s.recordFailedCommandOutput(playbookRun, checklistNumber, itemNumber, command, userID, err.Error())
 
 		return "", errors.Wrap(err, "failed to run slash command")
 	}
 
+	output := newCommandOutput(playbookRunID, itemToRun.ID, command, userID, CommandOutputSucceeded, commandResponseText(cmdResponse))
+	if err = s.store.CreateCommandOutput(output); err != nil {
+		return "", errors.Wrap(err, "failed to store the output of the slash command")
+	}
+	playbookRun.Checklists[checklistNumber].Items[itemNumber].CommandOutputID = output.ID
+
 	// Record the last (successful) run time.
 	playbookRun.Checklists[checklistNumber].Items[itemNumber].CommandLastRun = model.GetMillis()
 
@@ -1726,6 +2870,31 @@ func (s *PlaybookRunServiceImpl) RunChecklistItemSlashCommand(playbookRunID, use
 	return cmdResponse.TriggerId, nil
 }
 
+// recordFailedCommandOutput stores the error of a slash command that could not be executed as the
+// output of the checklist item, so that the run shows the step didn't actually run. The failure is
+// only logged if it cannot be recorded, as the command already failed.
+func (s *PlaybookRunServiceImpl) recordFailedCommandOutput(playbookRun *PlaybookRun, checklistNumber, itemNumber int, command, userID, message string) {
+	logger := logrus.WithFields(logrus.Fields{"playbook_run_id": playbookRun.ID, "command": command})
+
+	output := newCommandOutput(playbookRun.ID, playbookRun.Checklists[checklistNumber].Items[itemNumber].ID, command, userID, CommandOutputFailed, message)
+	if err := s.store.CreateCommandOutput(output); err != nil {
+		logger.WithError(err).Warn("failed to store the output of the failed slash command")
+		return
+	}
+
+	playbookRun.Checklists[checklistNumber].Items[itemNumber].CommandOutputID = output.ID
+	if _, err := s.store.UpdatePlaybookRun(playbookRun); err != nil {
+		logger.WithError(err).Warn("failed to update playbook run recording the output of the failed slash command")
+		return
+	}
+	s.sendPlaybookRunUpdatedWS(playbookRun.ID)
+}
+
+// GetCommandOutput returns the command output outputID of the run playbookRunID.
+func (s *PlaybookRunServiceImpl) GetCommandOutput(playbookRunID, outputID string) (*CommandOutput, error) {
+	return s.store.GetCommandOutput(playbookRunID, outputID)
+}
+
 func (s *PlaybookRunServiceImpl) DuplicateChecklistItem(playbookRunID, userID string, checklistNumber, itemNumber int) error {
 	playbookRunToModify, err := s.checklistParamsVerify(playbookRunID, userID, checklistNumber)
 	if err != nil {
@@ -1762,6 +2931,10 @@ func (s *PlaybookRunServiceImpl) AddChecklist(playbookRunID, userID string, chec
 		return errors.Wrapf(err, "failed to retrieve playbook run")
 	}
 
+	if err = checkNotViewer(playbookRunToModify, userID); err != nil {
+		return err
+	}
+
 	playbookRunToModify.Checklists = append(playbookRunToModify.Checklists, checklist)
 
 	playbookRunToModify, err = s.store.UpdatePlaybookRun(playbookRunToModify)
@@ -1846,6 +3019,7 @@ func (s *PlaybookRunServiceImpl) AddChecklistItem(playbookRunID, userID string,
 	}
 
 	playbookRunToModify.Checklists[checklistNumber].Items = append(playbookRunToModify.Checklists[checklistNumber].Items, checklistItem)
+	playbookRunToModify.ApplyChecklistItemConditions()
 
 	playbookRunToModify, err = s.store.UpdatePlaybookRun(playbookRunToModify)
 	if err != nil {
@@ -1892,6 +3066,7 @@ func (s *PlaybookRunServiceImpl) SkipChecklist(playbookRunID, userID string, che
 	for itemNumber := 0; itemNumber < len(playbookRunToModify.Checklists[checklistNumber].Items); itemNumber++ {
 		playbookRunToModify.Checklists[checklistNumber].Items[itemNumber].LastSkipped = model.GetMillis()
 		playbookRunToModify.Checklists[checklistNumber].Items[itemNumber].State = ChecklistItemStateSkipped
+		playbookRunToModify.Checklists[checklistNumber].Items[itemNumber].SkipReason = ""
 	}
 
 	checklist := playbookRunToModify.Checklists[checklistNumber]
@@ -1903,6 +3078,7 @@ func (s *PlaybookRunServiceImpl) SkipChecklist(playbookRunID, userID string, che
 
 	s.sendPlaybookRunUpdatedWS(playbookRunID, WithPlaybookRun(playbookRunToModify))
 	s.telemetry.SkipChecklist(playbookRunID, userID, checklist)
+	s.autoFinishIfChecklistsComplete(playbookRunToModify, userID)
 
 	return nil
 }
@@ -1916,6 +3092,7 @@ func (s *PlaybookRunServiceImpl) RestoreChecklist(playbookRunID, userID string,
 
 	for itemNumber := 0; itemNumber < len(playbookRunToModify.Checklists[checklistNumber].Items); itemNumber++ {
 		playbookRunToModify.Checklists[checklistNumber].Items[itemNumber].State = ChecklistItemStateOpen
+		playbookRunToModify.Checklists[checklistNumber].Items[itemNumber].SkipReason = ""
 	}
 
 	checklist := playbookRunToModify.Checklists[checklistNumber]
@@ -1940,6 +3117,7 @@ func (s *PlaybookRunServiceImpl) SkipChecklistItem(playbookRunID, userID string,
 
 	playbookRunToModify.Checklists[checklistNumber].Items[itemNumber].LastSkipped = model.GetMillis()
 	playbookRunToModify.Checklists[checklistNumber].Items[itemNumber].State = ChecklistItemStateSkipped
+	playbookRunToModify.Checklists[checklistNumber].Items[itemNumber].SkipReason = ""
 
 	checklistItem := playbookRunToModify.Checklists[checklistNumber].Items[itemNumber]
 
@@ -1948,8 +3126,10 @@ func (s *PlaybookRunServiceImpl) SkipChecklistItem(playbookRunID, userID string,
 		return errors.Wrapf(err, "failed to update playbook run")
 	}
 
+	s.setChecklistItemDueReminder(playbookRunID, checklistItem)
 	s.sendPlaybookRunUpdatedWS(playbookRunID, WithPlaybookRun(playbookRunToModify))
 	s.telemetry.SkipTask(playbookRunID, userID, checklistItem)
+	s.autoFinishIfChecklistsComplete(playbookRunToModify, userID)
 
 	return nil
 }
@@ -1962,6 +3142,7 @@ func (s *PlaybookRunServiceImpl) RestoreChecklistItem(playbookRunID, userID stri
 	}
 
 	playbookRunToModify.Checklists[checklistNumber].Items[itemNumber].State = ChecklistItemStateOpen
+	playbookRunToModify.Checklists[checklistNumber].Items[itemNumber].SkipReason = ""
 
 	checklistItem := playbookRunToModify.Checklists[checklistNumber].Items[itemNumber]
 
@@ -1970,6 +3151,7 @@ func (s *PlaybookRunServiceImpl) RestoreChecklistItem(playbookRunID, userID stri
 		return errors.Wrapf(err, "failed to update playbook run")
 	}
 
+	s.setChecklistItemDueReminder(playbookRunID, checklistItem)
 	s.sendPlaybookRunUpdatedWS(playbookRunID, WithPlaybookRun(playbookRunToModify))
 	s.telemetry.RestoreTask(playbookRunID, userID, checklistItem)
 
@@ -2085,6 +3267,137 @@ func (s *PlaybookRunServiceImpl) MoveChecklistItem(playbookRunID, userID string,
 	return nil
 }
 
+// ReorderChecklistItems sets the order of all the items in a checklist at once, failing if
+// itemIDs does not contain exactly the IDs of the items currently in the checklist
+func (s *PlaybookRunServiceImpl) ReorderChecklistItems(playbookRunID, userID string, checklistNumber int, itemIDs []string) error {
+	if _, err := s.checklistParamsVerify(playbookRunID, userID, checklistNumber); err != nil {
+		return err
+	}
+
+	if err := s.store.ReorderChecklistItems(playbookRunID, checklistNumber, itemIDs); err != nil {
+		return errors.Wrapf(err, "failed to reorder checklist items")
+	}
+
+	s.sendPlaybookRunUpdatedWS(playbookRunID)
+
+	return nil
+}
+
+// ReassignTasks assigns the incomplete checklist items of options.FromUserID to options.ToUserID,
+// in every unfinished run matching the options. Runs userID is not allowed to manage are skipped.
+// All the runs are changed in a single transaction, and then each changed run gets a timeline
+// event and a websocket update.
+func (s *PlaybookRunServiceImpl) ReassignTasks(userID string, options ReassignTasksOptions) (*ReassignTasksResult, error) {
+	fromUser, err := s.pluginAPI.User.Get(options.FromUserID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to resolve user %s", options.FromUserID)
+	}
+	toUser, err := s.pluginAPI.User.Get(options.ToUserID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to resolve user %s", options.ToUserID)
+	}
+
+	candidateRunIDs, err := s.store.GetRunIDsWithIncompleteTasks(options.FromUserID, options.TeamID, options.PlaybookRunIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the runs with tasks to reassign")
+	}
+
+	result := &ReassignTasksResult{SkippedRunIDs: []string{}}
+	playbookRunIDs := []string{}
+	for _, playbookRunID := range candidateRunIDs {
+		if err = s.permissions.RunManageProperties(userID, playbookRunID); err != nil {
+			result.SkippedRunIDs = append(result.SkippedRunIDs, playbookRunID)
+			continue
+		}
+		playbookRunIDs = append(playbookRunIDs, playbookRunID)
+	}
+
+	if len(playbookRunIDs) == 0 {
+		return result, nil
+	}
+
+	modifiedAt := model.GetMillis()
+	reassigned, err := s.store.ReassignChecklistItems(playbookRunIDs, options.FromUserID, options.ToUserID, modifiedAt)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to reassign checklist items")
+	}
+
+	// The items are reassigned already, so from here on failures are only logged.
+	for _, playbookRunID := range playbookRunIDs {
+		count := reassigned[playbookRunID]
+		if count == 0 {
+			continue
+		}
+		result.ItemsReassigned += count
+		result.RunsChanged++
+
+		logger := logrus.WithField("playbook_run_id", playbookRunID)
+
+		playbookRun, err := s.store.GetPlaybookRun(playbookRunID)
+		if err != nil {
+			logger.WithError(err).Warn("failed to get run after reassigning its tasks")
+			continue
+		}
+
+		for _, checklist := range playbookRun.Checklists {
+			for _, item := range checklist.Items {
+				if item.AssigneeID == options.ToUserID {
+					s.setChecklistItemDueReminder(playbookRunID, item)
+				}
+			}
+		}
+
+		isParticipant := options.ToUserID == playbookRun.OwnerUserID
+		for _, participantID := range playbookRun.ParticipantIDs {
+			if participantID == options.ToUserID {
+				isParticipant = true
+				break
+			}
+		}
+		if !isParticipant {
+			if err = s.AddParticipants(playbookRunID, []string{options.ToUserID}, userID, false); err != nil {
+				logger.WithError(err).Warn("failed to add the new assignee to the run")
+			}
+		}
+
+		event := &TimelineEvent{
+			PlaybookRunID: playbookRunID,
+			CreateAt:      modifiedAt,
+			EventAt:       modifiedAt,
+			EventType:     AssigneeChanged,
+			Summary:       fmt.Sprintf("reassigned %d checklist item(s) from **@%s** to **@%s**", count, fromUser.Username, toUser.Username),
+			SubjectUserID: userID,
+		}
+		if _, err = s.store.CreateTimelineEvent(event); err != nil {
+			logger.WithError(err).Warn("failed to create timeline event")
+		}
+
+		s.sendPlaybookRunUpdatedWS(playbookRunID)
+	}
+
+	if options.ToUserID != userID && result.ItemsReassigned > 0 {
+		s.dmReassignedTasks(userID, fromUser, options.ToUserID, result)
+	}
+
+	return result, nil
+}
+
+func (s *PlaybookRunServiceImpl) dmReassignedTasks(userID string, fromUser *model.User, toUserID string, result *ReassignTasksResult) {
+	logger := logrus.WithField("user_id", toUserID)
+
+	subjectUser, err := s.pluginAPI.User.Get(userID)
+	if err != nil {
+		logger.WithError(err).Warn("failed to resolve the user reassigning tasks")
+		return
+	}
+
+	message := fmt.Sprintf("@%s assigned you %d task(s) previously assigned to @%s, in %d run(s).   #taskassigned",
+		subjectUser.Username, result.ItemsReassigned, fromUser.Username, result.RunsChanged)
+	if err = s.poster.DM(toUserID, &model.Post{Message: message}); err != nil {
+		logger.WithError(err).Warn("failed to DM the new assignee")
+	}
+}
+
 // GetChecklistAutocomplete returns the list of checklist items for playbookRunID to be used in autocomplete
 func (s *PlaybookRunServiceImpl) GetChecklistAutocomplete(playbookRunID string) ([]model.AutocompleteListItem, error) {
 	playbookRun, err := s.store.GetPlaybookRun(playbookRunID)
@@ -2246,12 +3559,22 @@ func (s *PlaybookRunServiceImpl) GetOverdueUpdateRuns(userID string) ([]RunLink,
 	return s.store.GetOverdueUpdateRuns(userID)
 }
 
+// GetOverdueChecklistItems returns the list of active runs that have open checklist items
+// past their due date, along with those items
+func (s *PlaybookRunServiceImpl) GetOverdueChecklistItems() ([]AssignedRun, error) {
+	return s.store.GetRunsWithOverdueTasks(model.GetMillis())
+}
+
 func (s *PlaybookRunServiceImpl) checklistParamsVerify(playbookRunID, userID string, checklistNumber int) (*PlaybookRun, error) {
 	playbookRunToModify, err := s.store.GetPlaybookRun(playbookRunID)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to retrieve playbook run")
 	}
 
+	if err = checkNotViewer(playbookRunToModify, userID); err != nil {
+		return nil, err
+	}
+
 	if checklistNumber < 0 || checklistNumber >= len(playbookRunToModify.Checklists) {
 		return nil, errors.New("invalid checklist number")
 	}
@@ -2291,6 +3614,10 @@ func (s *PlaybookRunServiceImpl) UpdateDescription(playbookRunID, description st
 	playbookRun.Summary = description
 	playbookRun.SummaryModifiedAt = model.GetMillis()
 
+	// The summary defines the run's variables, which may show or hide conditional items.
+	// The websocket update below lets open clients refresh them.
+	playbookRun.ApplyChecklistItemConditions()
+
 	playbookRun, err = s.store.UpdatePlaybookRun(playbookRun)
 	if err != nil {
 		return errors.Wrap(err, "failed to update playbook run")
@@ -2300,7 +3627,9 @@ func (s *PlaybookRunServiceImpl) UpdateDescription(playbookRunID, description st
 	return nil
 }
 
-func (s *PlaybookRunServiceImpl) createPlaybookRunChannel(playbookRun *PlaybookRun, header string, public bool) (*model.Channel, error) {
+// newPlaybookRunChannel returns the channel to create for playbookRun. Returns
+// ErrChannelDisplayNameInvalid if the name of the run cannot be used for a channel.
+func newPlaybookRunChannel(playbookRun *PlaybookRun, header string, public bool) (*model.Channel, error) {
 	channelType := model.ChannelTypePrivate
 	if public {
 		channelType = model.ChannelTypeOpen
@@ -2318,14 +3647,42 @@ func (s *PlaybookRunServiceImpl) createPlaybookRunChannel(playbookRun *PlaybookR
 		channel.Name = model.NewId()
 	}
 
+	// Validate a copy with the fields set by the server on creation, to catch the errors the
+	// creation would fail with.
+	validated := *channel
+	validated.Id = model.NewId()
+	validated.CreateAt = model.GetMillis()
+	validated.UpdateAt = validated.CreateAt
+	if appErr := validated.IsValid(); appErr != nil {
+		if isChannelDisplayNameError(appErr) {
+			return nil, ErrChannelDisplayNameInvalid
+		}
+		return nil, errors.Wrap(ErrMalformedPlaybookRun, appErr.Error())
+	}
+
+	return channel, nil
+}
+
+// isChannelDisplayNameError is true for the channel errors the user can correct by changing the
+// name of the run.
+func isChannelDisplayNameError(appErr *model.AppError) bool {
+	return appErr.Id == "model.channel.is_valid.display_name.app_error" ||
+		appErr.Id == "model.channel.is_valid.1_or_more.app_error"
+}
+
+func (s *PlaybookRunServiceImpl) createPlaybookRunChannel(playbookRun *PlaybookRun, header string, public bool) (*model.Channel, error) {
+	channel, err := newPlaybookRunChannel(playbookRun, header, public)
+	if err != nil {
+		return nil, err
+	}
+
 	// Prefer the channel name the user chose. But if it already exists, add some random bits
 	// and try exactly once more.
-	err := s.pluginAPI.Channel.Create(channel)
+	err = s.pluginAPI.Channel.Create(channel)
 	if err != nil {
 		if appErr, ok := err.(*model.AppError); ok {
 			// Let the user correct display name errors:
-			if appErr.Id == "model.channel.is_valid.display_name.app_error" ||
-				appErr.Id == "model.channel.is_valid.1_or_more.app_error" {
+			if isChannelDisplayNameError(appErr) {
 				return nil, ErrChannelDisplayNameInvalid
 			}
 
@@ -2682,6 +4039,8 @@ func (s *PlaybookRunServiceImpl) sendPlaybookRunUpdatedWS(playbookRunID string,
 	for userID := range uniqueUserIDs {
 		s.poster.PublishWebsocketEventToUser(playbookRunUpdatedWSEvent, playbookRun, userID)
 	}
+
+	s.runEvents.Publish(playbookRun.ID, playbookRunUpdatedWSEvent, playbookRun)
 }
 
 func (s *PlaybookRunServiceImpl) UpdateRetrospective(playbookRunID, updaterID string, newRetrospective RetrospectiveUpdate) error {
@@ -2742,7 +4101,7 @@ func (s *PlaybookRunServiceImpl) PublishRetrospective(playbookRunID, publisherID
 
 	telemetryString := fmt.Sprintf("?telem_action=follower_clicked_retrospective_dm&telem_run_id=%s", playbookRunToPublish.ID)
 	retrospectivePublishedMessage := fmt.Sprintf("@%s published the retrospective report for [%s](%s%s).\n%s", publisherUser.Username, playbookRunToPublish.Name, retrospectiveURL, telemetryString, retrospective.Text)
-	err = s.dmPostToRunFollowers(&model.Post{Message: retrospectivePublishedMessage}, retroMessage, playbookRunToPublish.ID, publisherID)
+	err = s.dmPostToRunFollowers(untranslatedPost(&model.Post{Message: retrospectivePublishedMessage}), retroMessage, playbookRunToPublish.ID, publisherID)
 	if err != nil {
 		logger.WithError(err).Error("failed to dm post to run followers")
 	}
@@ -2939,6 +4298,13 @@ func (s *PlaybookRunServiceImpl) RemoveParticipants(playbookRunID string, userID
 		return errors.Wrapf(err, "users `%+v` failed to remove participation in run `%s`", userIDs, playbookRunID)
 	}
 
+	// co-owners that leave the run stop leading it
+	if len(playbookRun.CoOwnerUserIDs) > 0 {
+		if err := s.store.RemoveCoOwners(playbookRunID, userIDs); err != nil {
+			return errors.Wrapf(err, "users `%+v` failed to be removed from the co-owners of run `%s`", userIDs, playbookRunID)
+		}
+	}
+
 	requesterUser, err := s.pluginAPI.User.Get(requesterUserID)
 	if err != nil {
 		return errors.Wrap(err, "failed to get requester user")
@@ -3036,6 +4402,85 @@ func (s *PlaybookRunServiceImpl) AddParticipants(playbookRunID string, userIDs [
 	return nil
 }
 
+// SetParticipantRole makes a participant of the run an active participant or a viewer, on
+// behalf of requesterUserID. The owner and co-owners cannot be made viewers. Setting the role a
+// participant already has is a no-op.
+func (s *PlaybookRunServiceImpl) SetParticipantRole(playbookRunID, userID string, role ParticipantRole, requesterUserID string) error {
+	if role != ParticipantRoleActive && role != ParticipantRoleViewer {
+		return errors.Wrapf(ErrInvalidParticipantRole, "unknown role %q", role)
+	}
+
+	playbookRun, err := s.store.GetPlaybookRun(playbookRunID)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve playbook run")
+	}
+
+	if err = checkNotViewer(playbookRun, requesterUserID); err != nil {
+		return err
+	}
+
+	isParticipant := false
+	for _, participantID := range playbookRun.ParticipantIDs {
+		if participantID == userID {
+			isParticipant = true
+			break
+		}
+	}
+	if !isParticipant {
+		return errors.Wrapf(ErrInvalidParticipantRole, "user %s is not a participant of run %s", userID, playbookRunID)
+	}
+
+	if role == ParticipantRoleViewer && playbookRun.IsOwnerOrCoOwner(userID) {
+		return errors.Wrapf(ErrInvalidParticipantRole, "user %s leads run %s and cannot be a viewer", userID, playbookRunID)
+	}
+
+	if playbookRun.ParticipantRole(userID) == role {
+		return nil
+	}
+
+	if err = s.store.SetParticipantViewer(playbookRunID, userID, role == ParticipantRoleViewer); err != nil {
+		return errors.Wrapf(err, "failed to set the role of user %s in run %s", userID, playbookRunID)
+	}
+
+	user, err := s.pluginAPI.User.Get(userID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to to resolve user %s", userID)
+	}
+
+	summary := fmt.Sprintf("@%s is now a viewer", user.Username)
+	if role == ParticipantRoleActive {
+		summary = fmt.Sprintf("@%s is now an active participant", user.Username)
+	}
+
+	eventTime := model.GetMillis()
+	event := &TimelineEvent{
+		PlaybookRunID: playbookRunID,
+		CreateAt:      eventTime,
+		EventAt:       eventTime,
+		EventType:     ParticipantRoleChanged,
+		Summary:       summary,
+		SubjectUserID: requesterUserID,
+		CreatorUserID: requesterUserID,
+	}
+	if _, err = s.store.CreateTimelineEvent(event); err != nil {
+		return errors.Wrap(err, "failed to create timeline event")
+	}
+
+	s.sendPlaybookRunUpdatedWS(playbookRunID, WithAdditionalUserIDs([]string{userID}))
+
+	return nil
+}
+
+// checkNotViewer returns ErrNoPermissions if userID is a viewer of playbookRun: viewers can
+// follow the run but not change it.
+func checkNotViewer(playbookRun *PlaybookRun, userID string) error {
+	if playbookRun.IsViewer(userID) {
+		return errors.Wrapf(ErrNoPermissions, "user %s is a viewer of run %s", userID, playbookRun.ID)
+	}
+
+	return nil
+}
+
 // changeParticipantsTimeline handles timeline event creation for run participation change triggers:
 // participate/leave events and add/remove participants (multiple allowed)
 func (s *PlaybookRunServiceImpl) changeParticipantsTimeline(playbookRunID string, requesterUser *model.User, users []*model.User, action string) error {
@@ -3120,7 +4565,12 @@ func (s *PlaybookRunServiceImpl) postMessageToThreadAndSaveRootID(playbookRunID,
 	if newRootID != channelIDsToRootIDs[channelID] {
 		channelIDsToRootIDs[channelID] = newRootID
 		if err = s.store.SetBroadcastChannelIDsToRootID(playbookRunID, channelIDsToRootIDs); err != nil {
-			return errors.Wrapf(err, "failed to SetBroadcastChannelIDsToRootID for playbookID '%s'", playbookRunID)
+			// The message was posted, so failing here would have it posted again on retries: the
+			// next broadcast starts a new thread instead.
+			logrus.WithError(err).WithFields(logrus.Fields{
+				"playbook_run_id":      playbookRunID,
+				"broadcast_channel_id": channelID,
+			}).Error("failed to save the root of the broadcast thread")
 		}
 	}
 
@@ -3233,38 +4683,6 @@ func min(a, b int) int {
 	return b
 }
 
-// Helper function to Trigger webhooks
-func triggerWebhooks(s *PlaybookRunServiceImpl, webhooks []string, body []byte) {
-	for i := range webhooks {
-		url := webhooks[i]
-
-		go func() {
-			req, err := http.NewRequest("POST", url, bytes.NewReader(body))
-
-			if err != nil {
-				logrus.WithError(err).WithField("webhook_url", url).Error("failed to create a POST request to webhook URL")
-				return
-			}
-
-			req.Header.Set("Content-Type", "application/json")
-
-			resp, err := s.httpClient.Do(req)
-			if err != nil {
-				logrus.WithError(err).WithField("webhook_url", url).Warn("failed to send a POST request to webhook URL")
-				return
-			}
-
-			defer resp.Body.Close()
-
-			if resp.StatusCode < 200 || resp.StatusCode > 299 {
-				err := errors.Errorf("response code is %d; expected a status code in the 2xx range", resp.StatusCode)
-				logrus.WithError(err).WithField("webhook_url", url).Warn("failed to finish a POST request to webhook URL")
-			}
-		}()
-	}
-
-}
-
 func buildAssignedTaskMessageSummary(runs []AssignedRun, locale string, timezone *time.Location, onlyDueUntilToday bool) string {
 	var msg strings.Builder
 
@@ -3410,19 +4828,30 @@ const (
 )
 
 // broadcasting to channels
-func (s *PlaybookRunServiceImpl) broadcastPlaybookRunMessageToChannels(channelIDs []string, post *model.Post, mType messageType, playbookRun *PlaybookRun, logger logrus.FieldLogger) {
+// broadcastPlaybookRunMessageToChannels posts a copy of post to each of channelIDs, threaded under
+// the previous broadcasts of the run. A failure in one channel doesn't prevent the others, and is
+// reported in the run channel and in its result.
+func (s *PlaybookRunServiceImpl) broadcastPlaybookRunMessageToChannels(channelIDs []string, post *model.Post, mType messageType, playbookRun *PlaybookRun, logger logrus.FieldLogger) []BroadcastResult {
 	logger = logger.WithField("message_type", mType)
 
+	results := make([]BroadcastResult, 0, len(channelIDs))
 	for _, broadcastChannelID := range channelIDs {
 		post.Id = "" // Reset the ID so we avoid cloning the whole object
+		result := BroadcastResult{ChannelID: broadcastChannelID}
 		if err := s.broadcastPlaybookRunMessage(broadcastChannelID, post, mType, playbookRun); err != nil {
-			logger.WithError(err).Error("failed to broadcast run to channel")
+			logger.WithError(err).WithField("broadcast_channel_id", broadcastChannelID).Error("failed to broadcast run to channel")
+			result.Error = err.Error()
 
 			if _, err = s.poster.PostMessage(playbookRun.ChannelID, fmt.Sprintf("Failed to broadcast run %s to the configured channel.", mType)); err != nil {
 				logger.WithError(err).WithField("channel_id", playbookRun.ChannelID).Error("failed to post failure message to the channel")
 			}
+		} else {
+			result.PostID = post.Id
 		}
+		results = append(results, result)
 	}
+
+	return results
 }
 
 func (s *PlaybookRunServiceImpl) broadcastPlaybookRunMessage(broadcastChannelID string, post *model.Post, mType messageType, playbookRun *PlaybookRun) error {
@@ -3431,6 +4860,10 @@ func (s *PlaybookRunServiceImpl) broadcastPlaybookRunMessage(broadcastChannelID
 		return errors.Wrap(err, "announcement channel is not active")
 	}
 
+	if err := s.ensureBotInBroadcastChannel(post.ChannelId); err != nil {
+		return err
+	}
+
 	if err := s.postMessageToThreadAndSaveRootID(playbookRun.ID, post.ChannelId, post); err != nil {
 		return errors.Wrapf(err, "error posting '%s' message, for playbook '%s', to channelID '%s'", mType, playbookRun.ID, post.ChannelId)
 	}
@@ -3438,18 +4871,112 @@ func (s *PlaybookRunServiceImpl) broadcastPlaybookRunMessage(broadcastChannelID
 	return nil
 }
 
+// ensureBotInBroadcastChannel adds the bot to channelID if it isn't a member yet, so that its
+// broadcasts are not posted by an outsider. Only those allowed to post in a channel can make it a
+// broadcast channel.
+func (s *PlaybookRunServiceImpl) ensureBotInBroadcastChannel(channelID string) error {
+	botUserID := s.configService.GetConfiguration().BotUserID
+	_, err := s.pluginAPI.Channel.GetMember(channelID, botUserID)
+	if err == nil {
+		return nil
+	} else if !errors.Is(err, pluginapi.ErrNotFound) {
+		return errors.Wrap(err, "failed to get the bot membership of the announcement channel")
+	}
+
+	if _, err := s.pluginAPI.Channel.AddMember(channelID, botUserID); err != nil {
+		return errors.Wrapf(ErrBotNotInChannel, "failed to add the bot to the announcement channel: %s", err.Error())
+	}
+
+	return nil
+}
+
+// RetryStatusUpdateBroadcast broadcasts the latest status update of a run again to channelIDs,
+// which must be broadcast channels of the run.
+func (s *PlaybookRunServiceImpl) RetryStatusUpdateBroadcast(playbookRunID, userID string, channelIDs []string) ([]BroadcastResult, error) {
+	logger := logrus.WithField("playbook_run_id", playbookRunID)
+
+	playbookRun, err := s.store.GetPlaybookRun(playbookRunID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve playbook run")
+	}
+
+	for _, channelID := range channelIDs {
+		if !sliceContains(playbookRun.BroadcastChannelIDs, channelID) {
+			return nil, errors.Wrapf(ErrInvalidBroadcastChannel, "channel `%s` is not a broadcast channel of the run", channelID)
+		}
+	}
+
+	var latest *StatusPost
+	for i := range playbookRun.StatusPosts {
+		statusPost := &playbookRun.StatusPosts[i]
+		if statusPost.DeleteAt == 0 && (latest == nil || statusPost.CreateAt > latest.CreateAt) {
+			latest = statusPost
+		}
+	}
+	if latest == nil {
+		return nil, ErrNoStatusUpdate
+	}
+
+	statusUpdatePost, err := s.pluginAPI.Post.GetPost(latest.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the latest status update")
+	}
+
+	post := &model.Post{
+		Message: statusUpdatePost.Message,
+		Type:    statusUpdatePost.Type,
+	}
+	for key, value := range statusUpdatePost.GetProps() {
+		post.AddProp(key, value)
+	}
+
+	results := s.broadcastPlaybookRunMessageToChannels(channelIDs, post, statusUpdateMessage, playbookRun, logger.WithField("user_id", userID))
+	s.telemetry.RunAction(playbookRun, userID, TriggerTypeStatusUpdatePosted, ActionTypeBroadcastChannels, len(channelIDs))
+
+	return results, nil
+}
+
 // dm to users who follow
 
-func (s *PlaybookRunServiceImpl) dmPostToRunFollowers(post *model.Post, mType messageType, playbookRunID, authorID string) error {
+// dmPostToRunFollowers DMs the followers of playbookRunID who can view it, except authorID, the
+// post built by buildPost in the locale of each follower.
+func (s *PlaybookRunServiceImpl) dmPostToRunFollowers(buildPost func(T i18n.TranslateFunc) *model.Post, mType messageType, playbookRunID, authorID string) error {
+	logger := logrus.WithFields(logrus.Fields{"playbook_run_id": playbookRunID, "message_type": mType})
+
 	followers, err := s.GetFollowers(playbookRunID)
 	if err != nil {
 		return errors.Wrap(err, "failed to get followers")
 	}
 
-	s.dmPostToUsersWithPermission(followers, post, playbookRunID, authorID)
+	for _, follower := range followers {
+		// Do not send update to the author
+		if follower == authorID {
+			continue
+		}
+
+		// Check for access permissions
+		if err := s.permissions.RunView(follower, playbookRunID); err != nil {
+			continue
+		}
+
+		post := buildPost(userTranslations(s.pluginAPI, follower))
+		post.Id = "" // Reset the ID so we avoid cloning the whole object
+		post.RootId = ""
+		if err := s.poster.DM(follower, post); err != nil {
+			logger.WithError(err).WithField("user_id", follower).Warn("failed to broadcast post to the user")
+		}
+	}
+
 	return nil
 }
 
+// untranslatedPost builds a copy of post for dmPostToRunFollowers, whatever the locale.
+func untranslatedPost(post *model.Post) func(T i18n.TranslateFunc) *model.Post {
+	return func(T i18n.TranslateFunc) *model.Post {
+		return post.Clone()
+	}
+}
+
 func (s *PlaybookRunServiceImpl) dmPostToAutoFollows(post *model.Post, playbookID, playbookRunID, authorID string) error {
 	autoFollows, err := s.playbookService.GetAutoFollows(playbookID)
 	if err != nil {
@@ -2,6 +2,7 @@ package app
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/mattermost/mattermost-server/v6/model"
@@ -204,3 +205,386 @@ func TestPlaybookRunFilterOptions_Validate(t *testing.T) {
 		require.Equal(t, options, validOptions)
 	})
 }
+
+func TestPlaybookRun_ActiveDuration(t *testing.T) {
+	testCases := []struct {
+		name     string
+		run      PlaybookRun
+		now      int64
+		expected int64
+	}{
+		{
+			name:     "never paused, in progress",
+			run:      PlaybookRun{CreateAt: 1000},
+			now:      5000,
+			expected: 4000,
+		},
+		{
+			name:     "never paused, finished",
+			run:      PlaybookRun{CreateAt: 1000, EndAt: 3000},
+			now:      5000,
+			expected: 2000,
+		},
+		{
+			name:     "single pause and resume",
+			run:      PlaybookRun{CreateAt: 1000, PausedDuration: 500},
+			now:      5000,
+			expected: 3500,
+		},
+		{
+			name:     "multiple pause and resume cycles",
+			run:      PlaybookRun{CreateAt: 1000, EndAt: 10000, PausedDuration: 300 + 1200 + 2500},
+			now:      20000,
+			expected: 5000,
+		},
+		{
+			name:     "currently paused",
+			run:      PlaybookRun{CreateAt: 1000, PausedAt: 4000, PausedDuration: 1000},
+			now:      6000,
+			expected: 2000,
+		},
+		{
+			name:     "paused for the whole run",
+			run:      PlaybookRun{CreateAt: 1000, PausedAt: 1000},
+			now:      6000,
+			expected: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.run.ActiveDuration(tc.now))
+		})
+	}
+}
+
+func TestPlaybookRun_CompletionPercentage(t *testing.T) {
+	items := func(states ...string) []ChecklistItem {
+		ret := []ChecklistItem{}
+		for _, state := range states {
+			ret = append(ret, ChecklistItem{State: state})
+		}
+		return ret
+	}
+
+	testCases := []struct {
+		name       string
+		checklists []Checklist
+		expected   float64
+	}{
+		{
+			name:     "no checklists",
+			expected: 0,
+		},
+		{
+			name:       "nothing done",
+			checklists: []Checklist{{Items: items(ChecklistItemStateOpen, ChecklistItemStateInProgress)}},
+			expected:   0,
+		},
+		{
+			name: "across checklists",
+			checklists: []Checklist{
+				{Items: items(ChecklistItemStateClosed, ChecklistItemStateOpen)},
+				{Items: items(ChecklistItemStateClosed, ChecklistItemStateInProgress)},
+			},
+			expected: 50,
+		},
+		{
+			name: "skipped and hidden items are not counted",
+			checklists: []Checklist{
+				{Items: append(items(ChecklistItemStateClosed, ChecklistItemStateSkipped), ChecklistItem{Hidden: true})},
+			},
+			expected: 100,
+		},
+		{
+			name:       "only skipped items",
+			checklists: []Checklist{{Items: items(ChecklistItemStateSkipped)}},
+			expected:   0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			run := PlaybookRun{Checklists: tc.checklists}
+			require.Equal(t, tc.expected, run.CompletionPercentage())
+		})
+	}
+}
+
+func TestPlaybookRun_ChecklistsComplete(t *testing.T) {
+	testCases := []struct {
+		name       string
+		checklists []Checklist
+		expected   bool
+	}{
+		{
+			name:     "no checklists",
+			expected: false,
+		},
+		{
+			name: "an item not done",
+			checklists: []Checklist{
+				{Items: []ChecklistItem{{State: ChecklistItemStateClosed}}},
+				{Items: []ChecklistItem{{State: ChecklistItemStateInProgress}}},
+			},
+			expected: false,
+		},
+		{
+			name: "done and skipped items",
+			checklists: []Checklist{
+				{Items: []ChecklistItem{{State: ChecklistItemStateClosed}}},
+				{Items: []ChecklistItem{{State: ChecklistItemStateSkipped}}},
+			},
+			expected: true,
+		},
+		{
+			name: "hidden items are not counted",
+			checklists: []Checklist{
+				{Items: []ChecklistItem{{State: ChecklistItemStateClosed}, {State: ChecklistItemStateOpen, Hidden: true}}},
+			},
+			expected: true,
+		},
+		{
+			name:       "only hidden items",
+			checklists: []Checklist{{Items: []ChecklistItem{{State: ChecklistItemStateClosed, Hidden: true}}}},
+			expected:   false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			run := PlaybookRun{Checklists: tc.checklists}
+			require.Equal(t, tc.expected, run.ChecklistsComplete())
+		})
+	}
+}
+
+func TestPlaybookRun_MissingRequiredRetrospective(t *testing.T) {
+	testCases := []struct {
+		name     string
+		run      PlaybookRun
+		expected bool
+	}{
+		{
+			name:     "not required",
+			run:      PlaybookRun{},
+			expected: false,
+		},
+		{
+			name:     "not published",
+			run:      PlaybookRun{RetrospectiveRequired: true, Retrospective: "Draft"},
+			expected: true,
+		},
+		{
+			name:     "published without text",
+			run:      PlaybookRun{RetrospectiveRequired: true, Retrospective: " \n", RetrospectivePublishedAt: 1000},
+			expected: true,
+		},
+		{
+			name:     "canceled",
+			run:      PlaybookRun{RetrospectiveRequired: true, Retrospective: "No retrospective for this run.", RetrospectivePublishedAt: 1000, RetrospectiveWasCanceled: true},
+			expected: true,
+		},
+		{
+			name:     "published",
+			run:      PlaybookRun{RetrospectiveRequired: true, Retrospective: "What went well", RetrospectivePublishedAt: 1000},
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.run.MissingRequiredRetrospective())
+		})
+	}
+}
+
+func TestPlaybookRun_SkippedItemsCount(t *testing.T) {
+	run := PlaybookRun{Checklists: []Checklist{
+		{Items: []ChecklistItem{
+			{State: ChecklistItemStateSkipped},
+			{State: ChecklistItemStateClosed},
+			{State: ChecklistItemStateSkipped, Hidden: true},
+		}},
+		{Items: []ChecklistItem{
+			{State: ChecklistItemStateSkipped, SkipReason: "Not needed"},
+			{State: ChecklistItemStateOpen},
+		}},
+	}}
+
+	require.Equal(t, 2, run.SkippedItemsCount())
+	require.Zero(t, (&PlaybookRun{}).SkippedItemsCount())
+}
+
+func TestPlaybookRun_ParticipantRole(t *testing.T) {
+	run := PlaybookRun{
+		OwnerUserID:    "owner",
+		CoOwnerUserIDs: []string{"coowner"},
+		ParticipantIDs: []string{"owner", "coowner", "active", "viewer"},
+		ViewerIDs:      []string{"viewer", "coowner"},
+	}
+
+	require.Equal(t, ParticipantRoleViewer, run.ParticipantRole("viewer"))
+	require.True(t, run.IsViewer("viewer"))
+
+	for _, userID := range []string{"owner", "coowner", "active", "other"} {
+		require.Equal(t, ParticipantRoleActive, run.ParticipantRole(userID), userID)
+		require.False(t, run.IsViewer(userID), userID)
+	}
+}
+
+func TestTaskLoadOptions_Validate(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		options, err := TaskLoadOptions{TeamID: model.NewId()}.Validate()
+		require.NoError(t, err)
+		require.Equal(t, TaskLoadSortByLoad, options.Sort)
+		require.Equal(t, DefaultTaskLoadLimit, options.Limit)
+	})
+
+	t.Run("wrong case sort and large limit", func(t *testing.T) {
+		options, err := TaskLoadOptions{TeamID: model.NewId(), Sort: "Owned_Runs", Limit: MaxTaskLoadLimit + 1}.Validate()
+		require.NoError(t, err)
+		require.Equal(t, TaskLoadSortByOwnedRuns, options.Sort)
+		require.Equal(t, MaxTaskLoadLimit, options.Limit)
+	})
+
+	for name, options := range map[string]TaskLoadOptions{
+		"missing team":   {},
+		"invalid sort":   {TeamID: model.NewId(), Sort: "name"},
+		"negative limit": {TeamID: model.NewId(), Limit: -1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := options.Validate()
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestKeyEventOptions_Validate(t *testing.T) {
+	options, err := KeyEventOptions{Summary: "  Root cause found ", Details: " DNS\n", PostID: model.NewId()}.Validate()
+	require.NoError(t, err)
+	require.Equal(t, "Root cause found", options.Summary)
+	require.Equal(t, "DNS", options.Details)
+
+	for name, options := range map[string]KeyEventOptions{
+		"missing summary": {Summary: " "},
+		"long summary":    {Summary: strings.Repeat("a", MaxKeyEventSummaryLength+1)},
+		"invalid post id": {Summary: "Root cause found", PostID: "post"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := options.Validate()
+			require.ErrorIs(t, err, ErrInvalidKeyEvent)
+		})
+	}
+}
+
+func TestPlaybookRun_SetChecklistFromPlaybook(t *testing.T) {
+	playbook := Playbook{Checklists: []Checklist{{Title: "Deploy", Items: []ChecklistItem{
+		{Title: "Deploy", DefaultAssigneeID: "oncall", DueDate: 1000},
+		{Title: "Announce"},
+	}}}}
+
+	run := PlaybookRun{}
+	run.SetChecklistFromPlaybook(playbook)
+
+	require.Len(t, run.Checklists, 1)
+	deploy, announce := run.Checklists[0].Items[0], run.Checklists[0].Items[1]
+	require.Equal(t, "oncall", deploy.AssigneeID)
+	require.NotZero(t, deploy.AssigneeModified)
+	require.Empty(t, deploy.DefaultAssigneeID)
+	require.Equal(t, int64(1000), deploy.DueOffset)
+	require.Greater(t, deploy.DueDate, int64(1000))
+	require.Empty(t, announce.AssigneeID)
+	require.Zero(t, announce.AssigneeModified)
+
+	// the run has a snapshot of the playbook's checklists
+	require.Equal(t, "oncall", playbook.Checklists[0].Items[0].DefaultAssigneeID)
+	require.Empty(t, playbook.Checklists[0].Items[0].AssigneeID)
+	require.Equal(t, int64(1000), playbook.Checklists[0].Items[0].DueDate)
+	playbook.Checklists[0].Items[0].DefaultAssigneeID = ""
+	playbook.Checklists[0].Items[1].Title = "Changed"
+	require.Equal(t, "oncall", run.Checklists[0].Items[0].AssigneeID)
+	require.Equal(t, "Announce", run.Checklists[0].Items[1].Title)
+}
+
+func TestNormalizeRunTag(t *testing.T) {
+	testCases := []struct {
+		tag      string
+		expected string
+	}{
+		{"security", "security"},
+		{"  Postmortem-Needed ", "postmortem-needed"},
+		{"two words", "two words"},
+		{strings.Repeat("ñ", MaxRunTagLength), strings.Repeat("ñ", MaxRunTagLength)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tag, func(t *testing.T) {
+			normalized, err := NormalizeRunTag(tc.tag)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, normalized)
+		})
+	}
+
+	for _, tag := range []string{"", "   ", "a,b", "a/b", strings.Repeat("a", MaxRunTagLength+1)} {
+		t.Run("invalid "+tag, func(t *testing.T) {
+			_, err := NormalizeRunTag(tag)
+			require.ErrorIs(t, err, ErrInvalidRunTag)
+		})
+	}
+}
+
+func TestTimelineEventType_IsRunActivity(t *testing.T) {
+	for _, eventType := range []timelineEventType{StatusUpdated, TaskStateModified, ParticipantsChanged, UserJoinedLeft} {
+		require.True(t, eventType.IsRunActivity(), eventType)
+	}
+	for _, eventType := range []timelineEventType{PlaybookRunCreated, StatusUpdateRequested, OwnerChanged, RunFinished} {
+		require.False(t, eventType.IsRunActivity(), eventType)
+	}
+}
+
+func TestNewPlaybookRunChannel(t *testing.T) {
+	t.Run("uses the name of the run", func(t *testing.T) {
+		channel, err := newPlaybookRunChannel(&PlaybookRun{TeamID: "team_id", Name: "Database Outage!"}, "header", true)
+		require.NoError(t, err)
+		require.Equal(t, "database-outage", channel.Name)
+		require.Equal(t, "Database Outage!", channel.DisplayName)
+		require.Equal(t, model.ChannelTypeOpen, channel.Type)
+	})
+
+	t.Run("a name without word characters gets a random channel name", func(t *testing.T) {
+		channel, err := newPlaybookRunChannel(&PlaybookRun{TeamID: "team_id", Name: "???"}, "header", false)
+		require.NoError(t, err)
+		require.True(t, model.IsValidId(channel.Name))
+		require.Equal(t, model.ChannelTypePrivate, channel.Type)
+	})
+
+	t.Run("too long name", func(t *testing.T) {
+		_, err := newPlaybookRunChannel(&PlaybookRun{TeamID: "team_id", Name: strings.Repeat("a", model.ChannelDisplayNameMaxRunes+1)}, "header", true)
+		require.ErrorIs(t, err, ErrChannelDisplayNameInvalid)
+	})
+}
+
+func TestSetNewPlaybookRunDefaults(t *testing.T) {
+	playbookRun := &PlaybookRun{}
+	setNewPlaybookRunDefaults(playbookRun, &Playbook{ChannelMode: PlaybookRunCreateNewChannel, ChannelNameTemplate: "Incident"}, 1000)
+
+	require.Equal(t, "Incident", playbookRun.Name)
+	require.Equal(t, int64(1000), playbookRun.CreateAt)
+	require.Equal(t, StatusInProgress, playbookRun.CurrentStatus)
+	require.Len(t, playbookRun.Checklists, 1)
+}
+
+func TestPlaybookRunsBatchOptions_Validate(t *testing.T) {
+	require.NoError(t, PlaybookRunsBatchOptions{PlaybookRunIDs: []string{model.NewId()}}.Validate())
+
+	ids := make([]string, MaxPlaybookRunsBatch)
+	for i := range ids {
+		ids[i] = model.NewId()
+	}
+	require.NoError(t, PlaybookRunsBatchOptions{PlaybookRunIDs: ids}.Validate())
+
+	require.Error(t, PlaybookRunsBatchOptions{}.Validate())
+	require.Error(t, PlaybookRunsBatchOptions{PlaybookRunIDs: append(ids, model.NewId())}.Validate())
+	require.Error(t, PlaybookRunsBatchOptions{PlaybookRunIDs: []string{"run"}}.Validate())
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/mattermost/mattermost-plugin-playbooks/server/bot"
+	"github.com/mattermost/mattermost-plugin-playbooks/server/config"
 	"github.com/mattermost/mattermost-plugin-playbooks/server/metrics"
 )
 
@@ -18,19 +19,23 @@ const (
 
 type playbookService struct {
 	store          PlaybookStore
+	versionStore   PlaybookVersionStore
 	poster         bot.Poster
 	telemetry      PlaybookTelemetry
 	api            *pluginapi.Client
+	configService  config.Service
 	metricsService *metrics.Metrics
 }
 
 // NewPlaybookService returns a new playbook service
-func NewPlaybookService(store PlaybookStore, poster bot.Poster, telemetry PlaybookTelemetry, api *pluginapi.Client, metricsService *metrics.Metrics) PlaybookService {
+func NewPlaybookService(store PlaybookStore, versionStore PlaybookVersionStore, poster bot.Poster, telemetry PlaybookTelemetry, api *pluginapi.Client, configService config.Service, metricsService *metrics.Metrics) PlaybookService {
 	return &playbookService{
 		store:          store,
+		versionStore:   versionStore,
 		poster:         poster,
 		telemetry:      telemetry,
 		api:            api,
+		configService:  configService,
 		metricsService: metricsService,
 	}
 }
@@ -84,15 +89,104 @@ func (s *playbookService) Update(playbook Playbook, userID string) error {
 
 	playbook.UpdateAt = model.GetMillis()
 
+	previous, err := s.store.Get(playbook.ID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get playbook `%s`", playbook.ID)
+	}
+
 	if err := s.store.Update(playbook); err != nil {
 		return err
 	}
 
+	s.recordVersion(previous, userID, playbook.UpdateAt)
 	s.telemetry.UpdatePlaybook(playbook, userID)
 
 	return nil
 }
 
+func (s *playbookService) GraphqlUpdate(id string, setmap map[string]interface{}, userID string) error {
+	previous, err := s.store.Get(id)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get playbook `%s`", id)
+	}
+
+	if err := s.store.GraphqlUpdate(id, setmap); err != nil {
+		return err
+	}
+
+	s.recordVersion(previous, userID, model.GetMillis())
+
+	return nil
+}
+
+// recordVersion keeps previous as a version of the playbook if the update of userID at updateAt
+// changed it. Failures are logged rather than returned, as the update is already done.
+func (s *playbookService) recordVersion(previous Playbook, userID string, updateAt int64) {
+	logger := logrus.WithFields(logrus.Fields{
+		"playbook_id": previous.ID,
+		"user_id":     userID,
+	})
+
+	current, err := s.store.Get(previous.ID)
+	if err != nil {
+		logger.WithError(err).Error("failed to get the updated playbook to record its version")
+		return
+	}
+
+	same, err := samePlaybookVersion(previous, current)
+	if err != nil {
+		logger.WithError(err).Error("failed to compare the playbook to its update")
+		return
+	}
+	if same {
+		return
+	}
+
+	version, err := newPlaybookVersion(previous, userID, updateAt)
+	if err != nil {
+		logger.WithError(err).Error("failed to record the previous version of the playbook")
+		return
+	}
+	if err := s.versionStore.CreatePlaybookVersion(version, s.maxVersions()); err != nil {
+		logger.WithError(err).Error("failed to record the previous version of the playbook")
+	}
+}
+
+func (s *playbookService) maxVersions() int {
+	maxVersions := s.configService.GetConfiguration().MaxPlaybookVersions
+	if maxVersions <= 0 {
+		return DefaultMaxPlaybookVersions
+	}
+
+	return maxVersions
+}
+
+func (s *playbookService) GetVersions(playbookID string) ([]PlaybookVersion, error) {
+	return s.versionStore.GetPlaybookVersions(playbookID)
+}
+
+func (s *playbookService) GetVersionDiff(playbookID string, version int64) (PlaybookVersionDiff, error) {
+	playbookVersion, err := s.versionStore.GetPlaybookVersion(playbookID, version)
+	if err != nil {
+		return PlaybookVersionDiff{}, err
+	}
+
+	current, err := s.store.Get(playbookID)
+	if err != nil {
+		return PlaybookVersionDiff{}, errors.Wrapf(err, "failed to get playbook `%s`", playbookID)
+	}
+
+	changes, err := diffPlaybooks(*playbookVersion.Playbook, current)
+	if err != nil {
+		return PlaybookVersionDiff{}, errors.Wrapf(err, "failed to diff version %d of playbook `%s`", version, playbookID)
+	}
+
+	return PlaybookVersionDiff{
+		Version: playbookVersion,
+		Changes: changes,
+	}, nil
+}
+
 func (s *playbookService) Archive(playbook Playbook, userID string) error {
 	if playbook.ID == "" {
 		return errors.New("can't archive a playbook without an ID")
@@ -186,6 +280,9 @@ func (s *playbookService) Duplicate(playbook Playbook, userID string) (string, e
 	for i := range newPlaybook.Metrics {
 		newPlaybook.Metrics[i].ID = ""
 	}
+	for i := range newPlaybook.PropertyDefinitions {
+		newPlaybook.PropertyDefinitions[i].ID = ""
+	}
 	newPlaybook.Title = "Copy of " + playbook.Title
 
 	// On duplicating, make the current user the administrator.
@@ -203,6 +300,40 @@ func (s *playbookService) Duplicate(playbook Playbook, userID string) (string, e
 	return playbookID, nil
 }
 
+// CreateFromTemplate creates a new playbook in targetTeamID as a copy of the template with templateID
+func (s *playbookService) CreateFromTemplate(templateID, targetTeamID, newName, userID string) (string, error) {
+	logger := logrus.WithFields(logrus.Fields{
+		"template_id":    templateID,
+		"target_team_id": targetTeamID,
+		"user_id":        userID,
+	})
+
+	template, err := s.store.Get(templateID)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get template `%s`", templateID)
+	}
+
+	if !template.IsTemplate {
+		return "", errors.Wrapf(ErrPlaybookNotTemplate, "playbook `%s`", templateID)
+	}
+
+	newPlaybook := template.CopyFromTemplate(targetTeamID, newName)
+
+	// The user creating the copy is its administrator.
+	newPlaybook.Members = []PlaybookMember{{
+		UserID: userID,
+		Roles:  []string{PlaybookRoleMember, PlaybookRoleAdmin},
+	}}
+
+	playbookID, err := s.Create(newPlaybook, userID)
+	if err != nil {
+		return "", err
+	}
+
+	logger.WithField("playbook_id", playbookID).Debug("Created playbook from template")
+	return playbookID, nil
+}
+
 // get top playbooks for teams
 func (s *playbookService) GetTopPlaybooksForTeam(teamID, userID string, opts *model.InsightsOpts) (*PlaybooksInsightsList, error) {
 	permissionFlag, err := licenseAndGuestCheck(s, userID)
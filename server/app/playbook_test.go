@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/mattermost/mattermost-server/v6/model"
 	"github.com/stretchr/testify/require"
 )
 
@@ -180,3 +181,163 @@ func TestPlaybookFilterOptions_Validate(t *testing.T) {
 		require.Equal(t, options, validOptions)
 	})
 }
+
+func TestChecklistItem_IsOverdue(t *testing.T) {
+	now := int64(10000)
+
+	tests := []struct {
+		name string
+		item ChecklistItem
+		want bool
+	}{
+		{"no due date", ChecklistItem{}, false},
+		{"due in the future", ChecklistItem{DueDate: now + 1}, false},
+		{"due right now", ChecklistItem{DueDate: now}, true},
+		{"past due", ChecklistItem{DueDate: now - 1}, true},
+		{"past due but closed", ChecklistItem{DueDate: now - 1, State: ChecklistItemStateClosed}, false},
+		{"past due but skipped", ChecklistItem{DueDate: now - 1, State: ChecklistItemStateSkipped}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.item.IsOverdue(now))
+		})
+	}
+}
+
+func TestChecklist_ReorderItems(t *testing.T) {
+	checklist := Checklist{Items: []ChecklistItem{{ID: "a"}, {ID: "b"}, {ID: "c"}}}
+
+	t.Run("valid order", func(t *testing.T) {
+		items, err := checklist.ReorderItems([]string{"c", "a", "b"})
+		require.NoError(t, err)
+		require.Equal(t, []ChecklistItem{{ID: "c"}, {ID: "a"}, {ID: "b"}}, items)
+	})
+
+	for name, itemIDs := range map[string][]string{
+		"missing id":  {"c", "a"},
+		"extra id":    {"c", "a", "b", "d"},
+		"unknown id":  {"c", "a", "d"},
+		"repeated id": {"c", "a", "a"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := checklist.ReorderItems(itemIDs)
+			require.ErrorIs(t, err, ErrInvalidChecklistItemOrder)
+		})
+	}
+}
+
+func TestChecklist_ReassignItems(t *testing.T) {
+	checklist := Checklist{Items: []ChecklistItem{
+		{ID: "open", AssigneeID: "from"},
+		{ID: "in_progress", AssigneeID: "from", State: ChecklistItemStateInProgress},
+		{ID: "closed", AssigneeID: "from", State: ChecklistItemStateClosed},
+		{ID: "skipped", AssigneeID: "from", State: ChecklistItemStateSkipped},
+		{ID: "other", AssigneeID: "other"},
+		{ID: "unassigned"},
+	}}
+
+	require.Equal(t, 2, checklist.ReassignItems("from", "to", 1000))
+
+	assignees := map[string]string{}
+	for _, item := range checklist.Items {
+		assignees[item.ID] = item.AssigneeID
+		if item.AssigneeID == "to" {
+			require.Equal(t, int64(1000), item.AssigneeModified)
+		} else {
+			require.Zero(t, item.AssigneeModified)
+		}
+	}
+	require.Equal(t, map[string]string{
+		"open":        "to",
+		"in_progress": "to",
+		"closed":      "from",
+		"skipped":     "from",
+		"other":       "other",
+		"unassigned":  "",
+	}, assignees)
+
+	require.Zero(t, checklist.ReassignItems("from", "to", 2000))
+}
+
+func TestPlaybook_CopyFromTemplate(t *testing.T) {
+	template := Playbook{
+		ID:                  "template_id",
+		Title:               "Golden playbook",
+		TeamID:              "team_id",
+		IsTemplate:          true,
+		CreateAt:            1000,
+		NumRuns:             3,
+		Members:             []PlaybookMember{{UserID: "user_id", Roles: []string{PlaybookRoleAdmin}}},
+		Checklists:          []Checklist{{Title: "Checklist", Items: []ChecklistItem{{Title: "Item", DefaultAssigneeID: "user_id"}}}},
+		Metrics:             []PlaybookMetricConfig{{ID: "metric_id", PlaybookID: "template_id", Title: "Metric"}},
+		BroadcastChannelIDs: []string{"channel_id"},
+		BroadcastEnabled:    true,
+		ChannelID:           "channel_id",
+		ChannelMode:         PlaybookRunLinkExistingChannel,
+		DefaultOwnerID:      "user_id",
+		DefaultOwnerEnabled: true,
+		InvitedUserIDs:      []string{"user_id"},
+		InvitedGroupIDs:     []string{"group_id"},
+		MessageOnJoin:       "welcome",
+	}
+
+	t.Run("same team", func(t *testing.T) {
+		copied := template.CopyFromTemplate("team_id", "")
+
+		require.Empty(t, copied.ID)
+		require.Equal(t, "Golden playbook", copied.Title)
+		require.False(t, copied.IsTemplate)
+		require.Equal(t, "template_id", copied.TemplateSourceID)
+		require.Empty(t, copied.Members)
+		require.Zero(t, copied.CreateAt)
+		require.Zero(t, copied.NumRuns)
+		require.Equal(t, []PlaybookMetricConfig{{Title: "Metric"}}, copied.Metrics)
+		require.Equal(t, template.Checklists, copied.Checklists)
+		require.Equal(t, "channel_id", copied.ChannelID)
+		require.Equal(t, []string{"channel_id"}, copied.BroadcastChannelIDs)
+		require.Equal(t, "welcome", copied.MessageOnJoin)
+
+		// the copy does not share memory with the template
+		copied.Checklists[0].Items[0].Title = "Changed"
+		copied.Metrics[0].Title = "Changed"
+		require.Equal(t, "Item", template.Checklists[0].Items[0].Title)
+		require.Equal(t, "Metric", template.Metrics[0].Title)
+		require.Equal(t, "metric_id", template.Metrics[0].ID)
+	})
+
+	t.Run("another team", func(t *testing.T) {
+		copied := template.CopyFromTemplate("other_team_id", "New title")
+
+		require.Equal(t, "New title", copied.Title)
+		require.Equal(t, "other_team_id", copied.TeamID)
+		require.Empty(t, copied.ChannelID)
+		require.Equal(t, PlaybookRunCreateNewChannel, copied.ChannelMode)
+		require.Empty(t, copied.BroadcastChannelIDs)
+		require.False(t, copied.BroadcastEnabled)
+		require.Empty(t, copied.DefaultOwnerID)
+		require.False(t, copied.DefaultOwnerEnabled)
+		require.Empty(t, copied.InvitedUserIDs)
+		require.Empty(t, copied.InvitedGroupIDs)
+		require.Empty(t, copied.Checklists[0].Items[0].DefaultAssigneeID)
+		require.Equal(t, "user_id", template.Checklists[0].Items[0].DefaultAssigneeID)
+		require.Equal(t, "welcome", copied.MessageOnJoin)
+	})
+}
+
+func TestValidateChecklistItemDefaultAssignees(t *testing.T) {
+	memberID := model.NewId()
+	isTeamMember := func(userID string) bool {
+		return userID == memberID
+	}
+
+	valid := []Checklist{{Items: []ChecklistItem{{Title: "Deploy", DefaultAssigneeID: memberID}, {Title: "Unassigned"}}}}
+	require.NoError(t, ValidateChecklistItemDefaultAssignees(valid, isTeamMember))
+	require.NoError(t, ValidateChecklistItemDefaultAssignees(nil, isTeamMember))
+
+	invalid := []Checklist{{Items: []ChecklistItem{{Title: "Deploy", DefaultAssigneeID: "on-call"}}}}
+	require.ErrorIs(t, ValidateChecklistItemDefaultAssignees(invalid, isTeamMember), ErrInvalidDefaultAssignee)
+
+	notMember := []Checklist{{Items: []ChecklistItem{{Title: "Deploy", DefaultAssigneeID: model.NewId()}}}}
+	require.ErrorIs(t, ValidateChecklistItemDefaultAssignees(notMember, isTeamMember), ErrInvalidDefaultAssignee)
+}
@@ -0,0 +1,152 @@
+package app
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultMaxPlaybookVersions is the number of versions kept per playbook when the limit is not
+// configured.
+const DefaultMaxPlaybookVersions = 50
+
+// PlaybookVersion is a snapshot of a playbook as it was before one of its updates.
+//
+// Version n is the playbook as it was before its n-th recorded change, made by UserID at
+// CreateAt. Updates that don't change the playbook are not recorded.
+type PlaybookVersion struct {
+	PlaybookID string `json:"playbook_id"`
+	Version    int64  `json:"version"`
+
+	// UserID is the user whose update replaced this version.
+	UserID string `json:"user_id"`
+
+	// CreateAt is when this version was replaced.
+	CreateAt int64 `json:"create_at"`
+
+	// Playbook is the snapshot. It is nil in the listings of versions.
+	Playbook *Playbook `json:"playbook,omitempty"`
+
+	// Hash identifies the content of the snapshot, so that unchanged snapshots are not stored
+	// twice.
+	Hash string `json:"-"`
+}
+
+// PlaybookFieldChange is a top-level field of a playbook whose value differs between two versions,
+// with both values as JSON.
+type PlaybookFieldChange struct {
+	Field   string          `json:"field"`
+	Version json.RawMessage `json:"version"`
+	Current json.RawMessage `json:"current"`
+}
+
+// PlaybookVersionDiff is a past version of a playbook and its changes up to the current playbook.
+type PlaybookVersionDiff struct {
+	Version PlaybookVersion       `json:"version"`
+	Changes []PlaybookFieldChange `json:"changes"`
+}
+
+// PlaybookVersionStore defines the methods the PlaybookService needs from the interface layer to
+// keep the versions of playbooks.
+type PlaybookVersionStore interface {
+	// CreatePlaybookVersion stores version as the next version of its playbook, unless its hash
+	// is that of the latest version. Only the maxVersions most recent versions are kept.
+	CreatePlaybookVersion(version PlaybookVersion, maxVersions int) error
+
+	// GetPlaybookVersions returns the versions of a playbook, most recent first, without their
+	// snapshot.
+	GetPlaybookVersions(playbookID string) ([]PlaybookVersion, error)
+
+	// GetPlaybookVersion returns a version of a playbook with its snapshot. Returns ErrNotFound if
+	// not found.
+	GetPlaybookVersion(playbookID string, version int64) (PlaybookVersion, error)
+}
+
+// playbookVersionSnapshot returns the parts of playbook that are part of its versions: the fields
+// computed from its runs, and the update time, are left out so that they don't show as changes.
+func playbookVersionSnapshot(playbook Playbook) Playbook {
+	playbook.UpdateAt = 0
+	playbook.NumRuns = 0
+	playbook.LastRunAt = 0
+	playbook.ActiveRuns = 0
+
+	return playbook
+}
+
+// newPlaybookVersion returns the version of playbook to be replaced by the update of userID at
+// updateAt. Its number is assigned when it is stored.
+func newPlaybookVersion(playbook Playbook, userID string, updateAt int64) (PlaybookVersion, error) {
+	snapshot := playbookVersionSnapshot(playbook)
+	snapshotJSON, err := json.Marshal(snapshot)
+	if err != nil {
+		return PlaybookVersion{}, errors.Wrap(err, "failed to marshal playbook version")
+	}
+	hash := sha256.Sum256(snapshotJSON)
+
+	return PlaybookVersion{
+		PlaybookID: playbook.ID,
+		UserID:     userID,
+		CreateAt:   updateAt,
+		Playbook:   &snapshot,
+		Hash:       hex.EncodeToString(hash[:]),
+	}, nil
+}
+
+// samePlaybookVersion is true if the two playbooks have the same versioned content.
+func samePlaybookVersion(a, b Playbook) (bool, error) {
+	aJSON, err := json.Marshal(playbookVersionSnapshot(a))
+	if err != nil {
+		return false, errors.Wrap(err, "failed to marshal playbook")
+	}
+	bJSON, err := json.Marshal(playbookVersionSnapshot(b))
+	if err != nil {
+		return false, errors.Wrap(err, "failed to marshal playbook")
+	}
+
+	return bytes.Equal(aJSON, bJSON), nil
+}
+
+// diffPlaybooks returns the top-level fields that differ between the version and current
+// playbooks, sorted by field.
+func diffPlaybooks(version, current Playbook) ([]PlaybookFieldChange, error) {
+	versionFields, err := playbookJSONFields(playbookVersionSnapshot(version))
+	if err != nil {
+		return nil, err
+	}
+	currentFields, err := playbookJSONFields(playbookVersionSnapshot(current))
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []PlaybookFieldChange{}
+	for field, currentValue := range currentFields {
+		versionValue, ok := versionFields[field]
+		if !ok {
+			versionValue = json.RawMessage("null")
+		}
+		if !bytes.Equal(versionValue, currentValue) {
+			changes = append(changes, PlaybookFieldChange{Field: field, Version: versionValue, Current: currentValue})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+
+	return changes, nil
+}
+
+func playbookJSONFields(playbook Playbook) (map[string]json.RawMessage, error) {
+	playbookJSON, err := json.Marshal(playbook)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal playbook")
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(playbookJSON, &fields); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal playbook fields")
+	}
+
+	return fields, nil
+}
@@ -0,0 +1,68 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlaybookVersionDiff(t *testing.T) {
+	playbook := Playbook{
+		ID:                  "playbook",
+		Title:               "Incident",
+		UpdateAt:            1000,
+		NumRuns:             3,
+		StatusUpdateEnabled: true,
+		Checklists:          []Checklist{{Title: "Triage"}},
+	}
+
+	t.Run("fields computed from runs and the update time are not versioned", func(t *testing.T) {
+		updated := playbook
+		updated.UpdateAt = 2000
+		updated.NumRuns = 4
+		updated.LastRunAt = 3000
+
+		same, err := samePlaybookVersion(playbook, updated)
+		require.NoError(t, err)
+		require.True(t, same)
+
+		changes, err := diffPlaybooks(playbook, updated)
+		require.NoError(t, err)
+		require.Empty(t, changes)
+	})
+
+	t.Run("changed fields", func(t *testing.T) {
+		updated := playbook
+		updated.Title = "Major incident"
+		updated.Checklists = []Checklist{{Title: "Triage"}, {Title: "Resolve"}}
+
+		same, err := samePlaybookVersion(playbook, updated)
+		require.NoError(t, err)
+		require.False(t, same)
+
+		changes, err := diffPlaybooks(playbook, updated)
+		require.NoError(t, err)
+		require.Len(t, changes, 2)
+		require.Equal(t, "checklists", changes[0].Field)
+		require.Equal(t, "title", changes[1].Field)
+		require.JSONEq(t, `"Incident"`, string(changes[1].Version))
+		require.JSONEq(t, `"Major incident"`, string(changes[1].Current))
+	})
+
+	t.Run("versions with the same content have the same hash", func(t *testing.T) {
+		first, err := newPlaybookVersion(playbook, "user", 2000)
+		require.NoError(t, err)
+
+		updated := playbook
+		updated.UpdateAt = 5000
+		second, err := newPlaybookVersion(updated, "other_user", 6000)
+		require.NoError(t, err)
+		require.Equal(t, first.Hash, second.Hash)
+		require.Zero(t, second.Playbook.UpdateAt)
+
+		updated.Title = "Major incident"
+		third, err := newPlaybookVersion(updated, "user", 7000)
+		require.NoError(t, err)
+		require.NotEqual(t, first.Hash, third.Hash)
+	})
+}
@@ -9,6 +9,7 @@ var (
 	ErrChannelNotFound          = errors.Errorf("channel not found")
 	ErrChannelDeleted           = errors.Errorf("channel deleted")
 	ErrChannelNotInExpectedTeam = errors.Errorf("channel in different team")
+	ErrBotNotInChannel          = errors.Errorf("bot is not a member of the channel and could not join it")
 )
 
 func IsChannelActiveInTeam(channelID string, expectedTeamID string, pluginAPI *pluginapi.Client) error {
@@ -0,0 +1,75 @@
+package app
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ValidatePropertyDefinitions checks that the property definitions of a playbook have a unique
+// name and a known type, and that the options of select properties are unique.
+func ValidatePropertyDefinitions(definitions []PropertyDefinition) error {
+	names := make(map[string]bool, len(definitions))
+	for _, definition := range definitions {
+		name := strings.TrimSpace(definition.Name)
+		if name == "" {
+			return errors.New("property name must not be empty")
+		}
+		if names[strings.ToLower(name)] {
+			return errors.Errorf("duplicate property %q", name)
+		}
+		names[strings.ToLower(name)] = true
+
+		switch definition.Type {
+		case PropertyTypeSelect:
+			if len(definition.Options) == 0 {
+				return errors.Errorf("select property %q must have options", name)
+			}
+			options := make(map[string]bool, len(definition.Options))
+			for _, option := range definition.Options {
+				if strings.TrimSpace(option) == "" {
+					return errors.Errorf("select property %q has an empty option", name)
+				}
+				if options[option] {
+					return errors.Errorf("select property %q has duplicate option %q", name, option)
+				}
+				options[option] = true
+			}
+		case PropertyTypeText, PropertyTypeNumber:
+			if len(definition.Options) != 0 {
+				return errors.Errorf("%s property %q must not have options", definition.Type, name)
+			}
+		default:
+			return errors.Errorf("property %q has unknown type %q", name, definition.Type)
+		}
+	}
+
+	return nil
+}
+
+// ValidateValue checks that value can be set for a property of this definition. The empty value
+// is always valid, and means that the property is unset.
+func (d PropertyDefinition) ValidateValue(value string) error {
+	if value == "" {
+		return nil
+	}
+
+	switch d.Type {
+	case PropertyTypeSelect:
+		for _, option := range d.Options {
+			if value == option {
+				return nil
+			}
+		}
+		return errors.Wrapf(ErrInvalidPropertyValue, "%q is not an option of property %q", value, d.Name)
+	case PropertyTypeNumber:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsInf(number, 0) || math.IsNaN(number) {
+			return errors.Wrapf(ErrInvalidPropertyValue, "%q is not a number for property %q", value, d.Name)
+		}
+	}
+
+	return nil
+}
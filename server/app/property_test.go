@@ -0,0 +1,70 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidatePropertyDefinitions(t *testing.T) {
+	valid := []PropertyDefinition{
+		{Name: "Customer", Type: PropertyTypeText},
+		{Name: "Severity", Type: PropertyTypeSelect, Options: []string{"low", "critical"}},
+		{Name: "Cost", Type: PropertyTypeNumber},
+	}
+	require.NoError(t, ValidatePropertyDefinitions(valid))
+	require.NoError(t, ValidatePropertyDefinitions(nil))
+
+	testCases := map[string][]PropertyDefinition{
+		"empty name":         {{Name: " ", Type: PropertyTypeText}},
+		"duplicate name":     {{Name: "Customer", Type: PropertyTypeText}, {Name: "customer", Type: PropertyTypeNumber}},
+		"unknown type":       {{Name: "Customer", Type: "date"}},
+		"select no options":  {{Name: "Severity", Type: PropertyTypeSelect}},
+		"empty option":       {{Name: "Severity", Type: PropertyTypeSelect, Options: []string{"low", ""}}},
+		"duplicate option":   {{Name: "Severity", Type: PropertyTypeSelect, Options: []string{"low", "low"}}},
+		"text with options":  {{Name: "Customer", Type: PropertyTypeText, Options: []string{"a"}}},
+		"number with option": {{Name: "Cost", Type: PropertyTypeNumber, Options: []string{"1"}}},
+	}
+	for name, definitions := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Error(t, ValidatePropertyDefinitions(definitions))
+		})
+	}
+}
+
+func TestPropertyDefinition_ValidateValue(t *testing.T) {
+	text := PropertyDefinition{Name: "Customer", Type: PropertyTypeText}
+	selectDefinition := PropertyDefinition{Name: "Severity", Type: PropertyTypeSelect, Options: []string{"low", "critical"}}
+	number := PropertyDefinition{Name: "Cost", Type: PropertyTypeNumber}
+
+	testCases := []struct {
+		definition PropertyDefinition
+		value      string
+		valid      bool
+	}{
+		{text, "Acme", true},
+		{text, "", true},
+		{selectDefinition, "critical", true},
+		{selectDefinition, "", true},
+		{selectDefinition, "Critical", false},
+		{selectDefinition, "high", false},
+		{number, "12.5", true},
+		{number, "-3", true},
+		{number, "", true},
+		{number, "twelve", false},
+		{number, "Inf", false},
+		{number, "NaN", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.definition.Type+" "+tc.value, func(t *testing.T) {
+			err := tc.definition.ValidateValue(tc.value)
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				require.True(t, errors.Is(err, ErrInvalidPropertyValue))
+			}
+		})
+	}
+}
@@ -9,16 +9,24 @@ import (
 	"time"
 
 	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/i18n"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	stripmd "github.com/writeas/go-strip-markdown"
 )
 
 const RetrospectivePrefix = "retro_"
 
+// ChecklistItemDuePrefix prefixes the keys of the reminders for overdue checklist items, which
+// are followed by the playbook run ID and the item ID, separated by an underscore.
+const ChecklistItemDuePrefix = "item_due_"
+
 // HandleReminder is the handler for all reminder events.
 func (s *PlaybookRunServiceImpl) HandleReminder(key string) {
 	if strings.HasPrefix(key, RetrospectivePrefix) {
 		s.handleReminderToFillRetro(strings.TrimPrefix(key, RetrospectivePrefix))
+	} else if strings.HasPrefix(key, ChecklistItemDuePrefix) {
+		s.handleChecklistItemDueReminder(strings.TrimPrefix(key, ChecklistItemDuePrefix))
 	} else {
 		s.handleStatusUpdateReminder(key)
 	}
@@ -58,6 +66,91 @@ func (s *PlaybookRunServiceImpl) handleReminderToFillRetro(playbookRunID string)
 	}()
 }
 
+func (s *PlaybookRunServiceImpl) handleChecklistItemDueReminder(key string) {
+	playbookRunID, itemID, found := strings.Cut(key, "_")
+	if !found {
+		logrus.WithField("key", key).Error("invalid checklist item due reminder key")
+		return
+	}
+	logger := logrus.WithFields(logrus.Fields{
+		"playbook_run_id":   playbookRunID,
+		"checklist_item_id": itemID,
+	})
+
+	playbookRun, err := s.GetPlaybookRun(playbookRunID)
+	if err != nil {
+		logger.WithError(err).Error("handleChecklistItemDueReminder failed to get playbook run")
+		return
+	}
+
+	if playbookRun.CurrentStatus != StatusInProgress {
+		return
+	}
+
+	var item *ChecklistItem
+	for i := range playbookRun.Checklists {
+		for j := range playbookRun.Checklists[i].Items {
+			if playbookRun.Checklists[i].Items[j].ID == itemID {
+				item = &playbookRun.Checklists[i].Items[j]
+			}
+		}
+	}
+
+	// The item may have been removed, completed, unassigned or given a later due date in the
+	// meantime, in which case there is nothing to remind about.
+	if item == nil || item.AssigneeID == "" || !item.IsOverdue(model.GetMillis()) {
+		return
+	}
+
+	assignee, err := s.pluginAPI.User.Get(item.AssigneeID)
+	if err != nil {
+		logger.WithError(err).WithField("user_id", item.AssigneeID).Error("handleChecklistItemDueReminder failed to get assignee")
+		return
+	}
+
+	if _, err = s.poster.PostMessage(playbookRun.ChannelID, "@%s, the checklist item **%s** is overdue.", assignee.Username, stripmd.Strip(item.Title)); err != nil {
+		logger.WithError(err).Error("handleChecklistItemDueReminder error posting reminder message")
+	}
+}
+
+// setChecklistItemDueReminder schedules the reminder for the given checklist item to fire when it
+// becomes overdue, replacing any previous one. Nothing is scheduled for items that are done,
+// unassigned or without a due date; items that are already overdue are reminded right away. The
+// DM reminding the assignee before the item is due is rescheduled along.
+func (s *PlaybookRunServiceImpl) setChecklistItemDueReminder(playbookRunID string, item ChecklistItem) {
+	if item.ID == "" {
+		return
+	}
+
+	if s.dueReminders != nil {
+		if err := s.dueReminders.Sync(playbookRunID, item); err != nil {
+			logrus.WithError(err).WithFields(logrus.Fields{
+				"playbook_run_id":   playbookRunID,
+				"checklist_item_id": item.ID,
+			}).Error("failed to schedule the due reminder of the checklist item")
+		}
+	}
+
+	key := ChecklistItemDuePrefix + playbookRunID + "_" + item.ID
+	s.scheduler.Cancel(key)
+
+	if item.State != ChecklistItemStateOpen || item.AssigneeID == "" || item.DueDate <= 0 {
+		return
+	}
+
+	runAt := time.Now()
+	if dueAt := time.UnixMilli(item.DueDate); dueAt.After(runAt) {
+		runAt = dueAt
+	}
+
+	if _, err := s.scheduler.ScheduleOnce(key, runAt); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"playbook_run_id":   playbookRunID,
+			"checklist_item_id": item.ID,
+		}).Error("failed to schedule checklist item due reminder")
+	}
+}
+
 func (s *PlaybookRunServiceImpl) handleStatusUpdateReminder(playbookRunID string) {
 	logger := logrus.WithField("playbook_run_id", playbookRunID)
 
@@ -67,18 +160,35 @@ func (s *PlaybookRunServiceImpl) handleStatusUpdateReminder(playbookRunID string
 		return
 	}
 
+	// Paused runs are not expected to post status updates
+	if playbookRunToModify.CurrentStatus == StatusPaused {
+		return
+	}
+
+	// The scheduler runs each reminder on a single server of the cluster, but a reminder may
+	// still go stale if a status update was posted, or the reminder was snoozed, after it was
+	// scheduled. Drop those, as well as any reminder that arrives while the previous reminder
+	// post is still waiting for an update, so that at most one reminder is posted per update.
+	if !playbookRunToModify.statusUpdateReminderDue(time.Now()) {
+		logger.Debug("dropping status update reminder that is no longer due")
+		return
+	}
+
 	owner, err := s.pluginAPI.User.Get(playbookRunToModify.OwnerUserID)
 	if err != nil {
 		logger.WithError(err).WithField("user_id", playbookRunToModify.OwnerUserID).Error("HandleReminder failed to get owner")
 		return
 	}
 
+	// The reminder is addressed to the owner, so it's in their locale.
+	T := i18n.GetUserTranslations(owner.Locale)
+
 	attachments := []*model.SlackAttachment{
 		{
 			Actions: []*model.PostAction{
 				{
 					Type: "button",
-					Name: "Update status",
+					Name: T("app.user.run.status_update_reminder.button"),
 					Integration: &model.PostActionIntegration{
 						URL: fmt.Sprintf("/plugins/%s/api/v0/runs/%s/reminder/button-update",
 							s.configService.GetManifest().Id,
@@ -90,7 +200,7 @@ func (s *PlaybookRunServiceImpl) handleStatusUpdateReminder(playbookRunID string
 	}
 
 	post := &model.Post{
-		Message:   fmt.Sprintf("@%s, please provide a status update.", owner.Username),
+		Message:   T("app.user.run.status_update_reminder", map[string]interface{}{"Username": owner.Username}),
 		ChannelId: playbookRunToModify.ChannelID,
 		Type:      "custom_update_status",
 		Props: map[string]interface{}{
@@ -104,12 +214,14 @@ func (s *PlaybookRunServiceImpl) handleStatusUpdateReminder(playbookRunID string
 		return
 	}
 
-	// broadcast to followers
-	message, err := s.buildOverdueStatusUpdateMessage(playbookRunToModify, owner.Username)
+	// broadcast to followers, each in their own locale
+	buildMessage, err := s.buildOverdueStatusUpdateMessage(playbookRunToModify, owner.Username)
 	if err != nil {
 		logger.WithError(err).Error("failed to build overdue status update message")
 	} else {
-		err = s.dmPostToRunFollowers(&model.Post{Message: message}, overdueStatusUpdateMessage, playbookRunToModify.ID, "")
+		err = s.dmPostToRunFollowers(func(T i18n.TranslateFunc) *model.Post {
+			return &model.Post{Message: buildMessage(T)}
+		}, overdueStatusUpdateMessage, playbookRunToModify.ID, "")
 		if err != nil {
 			logger.WithError(err).Error("failed to dm post to run followers")
 		}
@@ -121,26 +233,54 @@ func (s *PlaybookRunServiceImpl) handleStatusUpdateReminder(playbookRunID string
 	}
 }
 
-func (s *PlaybookRunServiceImpl) buildOverdueStatusUpdateMessage(playbookRun *PlaybookRun, ownerUserName string) (string, error) {
+// buildOverdueStatusUpdateMessage returns the function building, in the locale of the given
+// translations, the message telling the followers of playbookRun that its status update is late.
+func (s *PlaybookRunServiceImpl) buildOverdueStatusUpdateMessage(playbookRun *PlaybookRun, ownerUserName string) (func(T i18n.TranslateFunc) string, error) {
 	channel, err := s.pluginAPI.Channel.Get(playbookRun.ChannelID)
 	if err != nil {
-		return "", errors.Wrapf(err, "can't get channel - %s", playbookRun.ChannelID)
+		return nil, errors.Wrapf(err, "can't get channel - %s", playbookRun.ChannelID)
 	}
 
 	team, err := s.pluginAPI.Team.Get(channel.TeamId)
 	if err != nil {
-		return "", errors.Wrapf(err, "can't get team - %s", channel.TeamId)
+		return nil, errors.Wrapf(err, "can't get team - %s", channel.TeamId)
 	}
 
-	message := fmt.Sprintf("Status update is overdue for [%s](/%s/channels/%s?telem_action=todo_overduestatus_clicked&telem_run_id=%s&forceRHSOpen) (Owner: @%s)\n",
-		channel.DisplayName, team.Name, channel.Name, playbookRun.ID, ownerUserName)
+	data := map[string]interface{}{
+		"ChannelDisplayName": channel.DisplayName,
+		"ChannelURL":         fmt.Sprintf("/%s/channels/%s?telem_action=todo_overduestatus_clicked&telem_run_id=%s&forceRHSOpen", team.Name, channel.Name, playbookRun.ID),
+		"OwnerUsername":      ownerUserName,
+	}
 
-	return message, nil
+	return func(T i18n.TranslateFunc) string {
+		return T("app.user.run.status_update_overdue", data)
+	}, nil
 }
 
-// SetReminder sets a reminder. After timeInMinutes in the future, the owner will be
-// reminded to update the playbook run's status.
+// statusUpdateReminderDue returns whether the status update reminder of the run has to be posted
+// at now: the run is active with status updates enabled, no reminder has been posted since the
+// last update, and the reminder timer has elapsed.
+func (r *PlaybookRun) statusUpdateReminderDue(now time.Time) bool {
+	if r.CurrentStatus != StatusInProgress || !r.StatusUpdateEnabled || r.PreviousReminder <= 0 || r.ReminderPostID != "" {
+		return false
+	}
+
+	reminder := StatusUpdateReminder{
+		PlaybookRunID:      r.ID,
+		LastStatusUpdateAt: r.LastStatusUpdateAt,
+		PreviousReminder:   r.PreviousReminder,
+	}
+
+	return !reminder.DueAt().After(now)
+}
+
+// SetReminder sets a reminder, replacing any pending one, so that there is at most one pending
+// reminder for each key. After fromNow, the owner will be reminded to update the playbook run's
+// status.
 func (s *PlaybookRunServiceImpl) SetReminder(playbookRunID string, fromNow time.Duration) error {
+	// The scheduler refuses to schedule a key that already exists
+	s.scheduler.Cancel(playbookRunID)
+
 	if _, err := s.scheduler.ScheduleOnce(playbookRunID, time.Now().Add(fromNow)); err != nil {
 		return errors.Wrap(err, "unable to schedule reminder")
 	}
@@ -153,6 +293,46 @@ func (s *PlaybookRunServiceImpl) RemoveReminder(playbookRunID string) {
 	s.scheduler.Cancel(playbookRunID)
 }
 
+// RestoreReminders schedules the status update reminders that are missing from the scheduler,
+// reconstructing them from the persisted state of the active runs. Reminders that are already
+// overdue are scheduled right away. It is safe to call from every server of the cluster: a
+// reminder that is already scheduled is never scheduled again.
+func (s *PlaybookRunServiceImpl) RestoreReminders() error {
+	reminders, err := s.store.GetStatusUpdateReminders()
+	if err != nil {
+		return errors.Wrap(err, "failed to get status update reminders")
+	}
+
+	scheduledJobs, err := s.scheduler.ListScheduledJobs()
+	if err != nil {
+		return errors.Wrap(err, "failed to list scheduled reminders")
+	}
+
+	scheduled := make(map[string]bool, len(scheduledJobs))
+	for _, job := range scheduledJobs {
+		scheduled[job.Key] = true
+	}
+
+	now := time.Now()
+	for _, reminder := range reminders {
+		if scheduled[reminder.PlaybookRunID] {
+			continue
+		}
+
+		runAt := reminder.DueAt()
+		if runAt.Before(now) {
+			runAt = now
+		}
+
+		// This fails if another server restored the reminder in the meantime.
+		if _, err := s.scheduler.ScheduleOnce(reminder.PlaybookRunID, runAt); err != nil {
+			logrus.WithError(err).WithField("playbook_run_id", reminder.PlaybookRunID).Warn("failed to restore status update reminder")
+		}
+	}
+
+	return nil
+}
+
 // resetReminderTimer sets the previous reminder timer to 0.
 func (s *PlaybookRunServiceImpl) resetReminderTimer(playbookRunID string) error {
 	playbookRunToModify, err := s.store.GetPlaybookRun(playbookRunID)
@@ -168,6 +348,7 @@ func (s *PlaybookRunServiceImpl) resetReminderTimer(playbookRunID string) error
 	}
 
 	s.poster.PublishWebsocketEventToChannel(playbookRunUpdatedWSEvent, playbookRunToModify, playbookRunToModify.ChannelID)
+	s.runEvents.Publish(playbookRunToModify.ID, playbookRunUpdatedWSEvent, playbookRunToModify)
 
 	return nil
 }
@@ -230,6 +411,7 @@ func (s *PlaybookRunServiceImpl) SetNewReminder(playbookRunID string, newReminde
 	}
 
 	s.poster.PublishWebsocketEventToChannel(playbookRunUpdatedWSEvent, playbookRunToModify, playbookRunToModify.ChannelID)
+	s.runEvents.Publish(playbookRunToModify.ID, playbookRunUpdatedWSEvent, playbookRunToModify)
 
 	return nil
 }
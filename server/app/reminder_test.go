@@ -0,0 +1,164 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-api/cluster"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeJobOnceScheduler keeps the pending jobs in memory, and remembers every job ever scheduled.
+// Like the cluster scheduler, it refuses to schedule a key that is already pending.
+type fakeJobOnceScheduler struct {
+	pending   map[string]time.Time
+	scheduled []cluster.JobOnceMetadata
+}
+
+func newFakeJobOnceScheduler() *fakeJobOnceScheduler {
+	return &fakeJobOnceScheduler{pending: map[string]time.Time{}}
+}
+
+func (s *fakeJobOnceScheduler) Start() error { return nil }
+
+func (s *fakeJobOnceScheduler) SetCallback(func(string)) error { return nil }
+
+func (s *fakeJobOnceScheduler) ListScheduledJobs() ([]cluster.JobOnceMetadata, error) {
+	jobs := []cluster.JobOnceMetadata{}
+	for key, runAt := range s.pending {
+		jobs = append(jobs, cluster.JobOnceMetadata{Key: key, RunAt: runAt})
+	}
+	return jobs, nil
+}
+
+func (s *fakeJobOnceScheduler) ScheduleOnce(key string, runAt time.Time) (*cluster.JobOnce, error) {
+	if _, ok := s.pending[key]; ok {
+		return nil, errors.Errorf("job %s already scheduled", key)
+	}
+	s.pending[key] = runAt
+	s.scheduled = append(s.scheduled, cluster.JobOnceMetadata{Key: key, RunAt: runAt})
+	return nil, nil
+}
+
+func (s *fakeJobOnceScheduler) Cancel(key string) {
+	delete(s.pending, key)
+}
+
+type fakeReminderStore struct {
+	PlaybookRunStore
+	reminders []StatusUpdateReminder
+}
+
+func (s *fakeReminderStore) GetStatusUpdateReminders() ([]StatusUpdateReminder, error) {
+	return s.reminders, nil
+}
+
+func TestStatusUpdateReminderCoalescing(t *testing.T) {
+	t.Run("rapid status updates leave a single pending reminder", func(t *testing.T) {
+		scheduler := newFakeJobOnceScheduler()
+		s := &PlaybookRunServiceImpl{scheduler: scheduler}
+
+		for i := 0; i < 10; i++ {
+			require.NoError(t, s.SetReminder("run_id", time.Duration(i+1)*time.Minute))
+		}
+
+		jobs, err := scheduler.ListScheduledJobs()
+		require.NoError(t, err)
+		require.Len(t, jobs, 1)
+		require.Equal(t, scheduler.scheduled[len(scheduler.scheduled)-1], jobs[0])
+	})
+
+	t.Run("exactly one reminder fires after rapid status updates", func(t *testing.T) {
+		scheduler := newFakeJobOnceScheduler()
+		s := &PlaybookRunServiceImpl{scheduler: scheduler}
+		run := &PlaybookRun{
+			ID:                  "run_id",
+			CurrentStatus:       StatusInProgress,
+			StatusUpdateEnabled: true,
+			PreviousReminder:    15 * time.Minute,
+		}
+
+		// Each status update moves LastStatusUpdateAt forward and reschedules the reminder,
+		// as SetNewReminder does.
+		for i := 0; i < 20; i++ {
+			run.LastStatusUpdateAt = time.Now().UnixMilli()
+			require.NoError(t, s.SetReminder(run.ID, run.PreviousReminder))
+		}
+		require.Len(t, scheduler.pending, 1)
+
+		// Fire every job ever scheduled, as if the cancelled ones had been picked up by another
+		// server before being cancelled; the stale ones are dropped.
+		fired := 0
+		for _, job := range scheduler.scheduled {
+			if run.statusUpdateReminderDue(job.RunAt) {
+				fired++
+				run.ReminderPostID = "reminder_post_id"
+			}
+		}
+		require.Equal(t, 1, fired)
+
+		// Firing again before the next status update posts nothing.
+		require.False(t, run.statusUpdateReminderDue(time.Now().Add(time.Hour)))
+	})
+
+	t.Run("reminder due", func(t *testing.T) {
+		now := time.Now()
+		due := PlaybookRun{
+			CurrentStatus:       StatusInProgress,
+			StatusUpdateEnabled: true,
+			LastStatusUpdateAt:  now.Add(-time.Hour).UnixMilli(),
+			PreviousReminder:    time.Hour,
+		}
+
+		testCases := []struct {
+			name     string
+			modify   func(run *PlaybookRun)
+			expected bool
+		}{
+			{"due", func(run *PlaybookRun) {}, true},
+			{"updated since scheduled", func(run *PlaybookRun) { run.LastStatusUpdateAt = now.Add(-time.Minute).UnixMilli() }, false},
+			{"reminder already posted", func(run *PlaybookRun) { run.ReminderPostID = "reminder_post_id" }, false},
+			{"paused", func(run *PlaybookRun) { run.CurrentStatus = StatusPaused }, false},
+			{"finished", func(run *PlaybookRun) { run.CurrentStatus = StatusFinished }, false},
+			{"status updates disabled", func(run *PlaybookRun) { run.StatusUpdateEnabled = false }, false},
+			{"no reminder timer", func(run *PlaybookRun) { run.PreviousReminder = 0 }, false},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				run := due
+				tc.modify(&run)
+				require.Equal(t, tc.expected, run.statusUpdateReminderDue(now))
+			})
+		}
+	})
+}
+
+func TestRestoreReminders(t *testing.T) {
+	now := time.Now()
+	scheduler := newFakeJobOnceScheduler()
+	store := &fakeReminderStore{
+		reminders: []StatusUpdateReminder{
+			{PlaybookRunID: "pending", LastStatusUpdateAt: now.UnixMilli(), PreviousReminder: time.Hour},
+			{PlaybookRunID: "lost", LastStatusUpdateAt: now.UnixMilli(), PreviousReminder: time.Hour},
+			{PlaybookRunID: "overdue", LastStatusUpdateAt: now.Add(-2 * time.Hour).UnixMilli(), PreviousReminder: time.Hour},
+		},
+	}
+	s := &PlaybookRunServiceImpl{scheduler: scheduler, store: store}
+
+	pendingAt := now.Add(10 * time.Minute)
+	_, err := scheduler.ScheduleOnce("pending", pendingAt)
+	require.NoError(t, err)
+
+	require.NoError(t, s.RestoreReminders())
+
+	require.Len(t, scheduler.pending, 3)
+	require.Equal(t, pendingAt, scheduler.pending["pending"])
+	require.Equal(t, store.reminders[1].DueAt(), scheduler.pending["lost"])
+	require.WithinDuration(t, now, scheduler.pending["overdue"], time.Minute)
+
+	// Restoring again, e.g. from another server of the cluster, schedules nothing new.
+	require.NoError(t, s.RestoreReminders())
+	require.Len(t, scheduler.scheduled, 3)
+}
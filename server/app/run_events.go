@@ -0,0 +1,201 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// RunEventClusterEventID identifies the cluster events carrying the events of runs to the
+	// other servers of the cluster.
+	RunEventClusterEventID = "playbooks_run_event"
+
+	// RunEventsHeartbeatInterval is how often a comment is written to a stream of run events, so
+	// that proxies don't close it while the run is quiet.
+	RunEventsHeartbeatInterval = 30 * time.Second
+
+	// runEventSubscriberBuffer is the number of events kept for a subscriber that is not reading
+	// them fast enough. Further events are dropped until it catches up.
+	runEventSubscriberBuffer = 16
+)
+
+// RunEvent is an event about a playbook run, with the same name and payload as the websocket
+// event sent for it.
+type RunEvent struct {
+	PlaybookRunID string          `json:"playbook_run_id"`
+	Event         string          `json:"event"`
+	Payload       json.RawMessage `json:"payload"`
+}
+
+// RunEventClusterPublisher sends cluster events to the other servers of the cluster.
+type RunEventClusterPublisher interface {
+	PublishPluginClusterEvent(ev model.PluginClusterEvent, opts model.PluginClusterEventSendOptions) error
+}
+
+// RunEventBroker hands the events of playbook runs to the subscribers of each run, such as the
+// clients of the server-sent events API.
+//
+// Events are published on the server where the run changed, so they are also sent to the other
+// servers of the cluster, which hand them to their own subscribers in HandleClusterEvent.
+type RunEventBroker struct {
+	cluster RunEventClusterPublisher
+
+	mutex       sync.Mutex
+	subscribers map[string]map[chan RunEvent]struct{}
+}
+
+// NewRunEventBroker creates a new RunEventBroker. cluster may be nil when there are no other
+// servers to send the events to.
+func NewRunEventBroker(cluster RunEventClusterPublisher) *RunEventBroker {
+	return &RunEventBroker{
+		cluster:     cluster,
+		subscribers: make(map[string]map[chan RunEvent]struct{}),
+	}
+}
+
+// Subscribe returns the channel receiving the events of playbookRunID published from now on, and
+// the function to call to stop receiving them. The channel is closed when unsubscribing.
+func (b *RunEventBroker) Subscribe(playbookRunID string) (<-chan RunEvent, func()) {
+	events := make(chan RunEvent, runEventSubscriberBuffer)
+
+	b.mutex.Lock()
+	if b.subscribers[playbookRunID] == nil {
+		b.subscribers[playbookRunID] = make(map[chan RunEvent]struct{})
+	}
+	b.subscribers[playbookRunID][events] = struct{}{}
+	b.mutex.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mutex.Lock()
+			defer b.mutex.Unlock()
+
+			delete(b.subscribers[playbookRunID], events)
+			if len(b.subscribers[playbookRunID]) == 0 {
+				delete(b.subscribers, playbookRunID)
+			}
+			close(events)
+		})
+	}
+
+	return events, unsubscribe
+}
+
+// Publish hands the event to the subscribers of playbookRunID on every server of the cluster.
+// Safe to call on a nil broker.
+func (b *RunEventBroker) Publish(playbookRunID, event string, payload interface{}) {
+	if b == nil {
+		return
+	}
+
+	logger := logrus.WithFields(logrus.Fields{"playbook_run_id": playbookRunID, "event": event})
+
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		logger.WithError(err).Error("failed to marshal the payload of a run event")
+		return
+	}
+
+	runEvent := RunEvent{PlaybookRunID: playbookRunID, Event: event, Payload: payloadJSON}
+	b.deliver(runEvent)
+
+	if b.cluster == nil {
+		return
+	}
+
+	data, err := json.Marshal(runEvent)
+	if err != nil {
+		logger.WithError(err).Error("failed to marshal a run event")
+		return
+	}
+
+	err = b.cluster.PublishPluginClusterEvent(
+		model.PluginClusterEvent{Id: RunEventClusterEventID, Data: data},
+		model.PluginClusterEventSendOptions{SendType: model.PluginClusterEventSendTypeReliable},
+	)
+	if err != nil {
+		logger.WithError(err).Warn("failed to send a run event to the cluster")
+	}
+}
+
+// HandleClusterEvent hands a run event published by another server of the cluster to the
+// subscribers of this server.
+func (b *RunEventBroker) HandleClusterEvent(ev model.PluginClusterEvent) error {
+	var runEvent RunEvent
+	if err := json.Unmarshal(ev.Data, &runEvent); err != nil {
+		return errors.Wrap(err, "failed to unmarshal run event")
+	}
+
+	b.deliver(runEvent)
+
+	return nil
+}
+
+// deliver hands runEvent to the subscribers of its run on this server, without waiting for the
+// slow ones.
+func (b *RunEventBroker) deliver(runEvent RunEvent) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for events := range b.subscribers[runEvent.PlaybookRunID] {
+		select {
+		case events <- runEvent:
+		default:
+			logrus.WithField("playbook_run_id", runEvent.PlaybookRunID).Warn("dropped a run event for a slow subscriber")
+		}
+	}
+}
+
+// StreamRunEvents writes events to w as server-sent events, and a comment at every tick of
+// heartbeat, flushing w after each write if it is an http.Flusher. Before each heartbeat canView
+// is checked again, so that the stream ends when the subscriber loses access to the run.
+//
+// Returns nil when ctx is done or events is closed, or the error that ended the stream.
+func StreamRunEvents(ctx context.Context, w io.Writer, events <-chan RunEvent, heartbeat <-chan time.Time, canView func() error) error {
+	flush := func() {
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+	}
+
+	// Send the headers right away, so the client knows it's subscribed.
+	flush()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case runEvent, ok := <-events:
+			if !ok {
+				return nil
+			}
+
+			// The payload is compact JSON, so it fits in a single data line.
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", runEvent.Event, runEvent.Payload); err != nil {
+				return errors.Wrap(err, "failed to write run event")
+			}
+
+		case <-heartbeat:
+			if err := canView(); err != nil {
+				return err
+			}
+
+			if _, err := io.WriteString(w, ": heartbeat\n\n"); err != nil {
+				return errors.Wrap(err, "failed to write heartbeat")
+			}
+		}
+
+		flush()
+	}
+}
@@ -0,0 +1,135 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRunEventCluster struct {
+	events []model.PluginClusterEvent
+}
+
+func (c *fakeRunEventCluster) PublishPluginClusterEvent(ev model.PluginClusterEvent, opts model.PluginClusterEventSendOptions) error {
+	c.events = append(c.events, ev)
+	return nil
+}
+
+func TestRunEventBroker(t *testing.T) {
+	t.Run("subscribers only receive the events of their run", func(t *testing.T) {
+		broker := NewRunEventBroker(nil)
+		events, unsubscribe := broker.Subscribe("run1")
+		defer unsubscribe()
+		otherEvents, unsubscribeOther := broker.Subscribe("run2")
+		defer unsubscribeOther()
+
+		broker.Publish("run1", playbookRunUpdatedWSEvent, map[string]string{"name": "Outage"})
+
+		require.Equal(t, RunEvent{
+			PlaybookRunID: "run1",
+			Event:         playbookRunUpdatedWSEvent,
+			Payload:       json.RawMessage(`{"name":"Outage"}`),
+		}, <-events)
+		require.Empty(t, otherEvents)
+	})
+
+	t.Run("unsubscribing closes the channel", func(t *testing.T) {
+		broker := NewRunEventBroker(nil)
+		events, unsubscribe := broker.Subscribe("run1")
+
+		unsubscribe()
+		unsubscribe()
+
+		_, ok := <-events
+		require.False(t, ok)
+		require.Empty(t, broker.subscribers)
+
+		broker.Publish("run1", playbookRunUpdatedWSEvent, nil)
+	})
+
+	t.Run("slow subscribers miss events instead of blocking", func(t *testing.T) {
+		broker := NewRunEventBroker(nil)
+		events, unsubscribe := broker.Subscribe("run1")
+		defer unsubscribe()
+
+		for i := 0; i < runEventSubscriberBuffer+5; i++ {
+			broker.Publish("run1", playbookRunUpdatedWSEvent, i)
+		}
+
+		require.Len(t, events, runEventSubscriberBuffer)
+	})
+
+	t.Run("events are sent to the cluster", func(t *testing.T) {
+		cluster := &fakeRunEventCluster{}
+		broker := NewRunEventBroker(cluster)
+		broker.Publish("run1", playbookRunUpdatedWSEvent, "payload")
+		require.Len(t, cluster.events, 1)
+		require.Equal(t, RunEventClusterEventID, cluster.events[0].Id)
+
+		otherServer := NewRunEventBroker(nil)
+		events, unsubscribe := otherServer.Subscribe("run1")
+		defer unsubscribe()
+
+		require.NoError(t, otherServer.HandleClusterEvent(cluster.events[0]))
+		require.Equal(t, RunEvent{
+			PlaybookRunID: "run1",
+			Event:         playbookRunUpdatedWSEvent,
+			Payload:       json.RawMessage(`"payload"`),
+		}, <-events)
+
+		require.Error(t, otherServer.HandleClusterEvent(model.PluginClusterEvent{Id: RunEventClusterEventID, Data: []byte("{")}))
+	})
+
+	t.Run("nil broker", func(t *testing.T) {
+		var broker *RunEventBroker
+		broker.Publish("run1", playbookRunUpdatedWSEvent, nil)
+	})
+}
+
+func TestStreamRunEvents(t *testing.T) {
+	canView := func() error { return nil }
+
+	t.Run("events and heartbeats", func(t *testing.T) {
+		events := make(chan RunEvent, 2)
+		heartbeat := make(chan time.Time, 1)
+		events <- RunEvent{PlaybookRunID: "run1", Event: playbookRunUpdatedWSEvent, Payload: json.RawMessage(`{"id":"run1"}`)}
+		heartbeat <- time.Now()
+
+		w := &flushRecorder{}
+		done := make(chan error)
+		go func() {
+			done <- StreamRunEvents(context.Background(), w, events, heartbeat, canView)
+		}()
+
+		// The event and the heartbeat are handled in any order, so wait for both.
+		require.Eventually(t, func() bool { return len(events) == 0 && len(heartbeat) == 0 }, time.Second, time.Millisecond)
+		close(events)
+		require.NoError(t, <-done)
+
+		require.Contains(t, w.String(), "event: playbook_run_updated\ndata: {\"id\":\"run1\"}\n\n")
+		require.Contains(t, w.String(), ": heartbeat\n\n")
+		require.Equal(t, 3, w.flushes)
+	})
+
+	t.Run("the stream ends when the run can no longer be viewed", func(t *testing.T) {
+		heartbeat := make(chan time.Time, 1)
+		heartbeat <- time.Now()
+
+		w := &flushRecorder{}
+		err := StreamRunEvents(context.Background(), w, make(chan RunEvent), heartbeat, func() error { return ErrNoPermissions })
+		require.ErrorIs(t, err, ErrNoPermissions)
+		require.Empty(t, w.String())
+	})
+
+	t.Run("the stream ends when the client disconnects", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := StreamRunEvents(ctx, &flushRecorder{}, make(chan RunEvent), make(chan time.Time), canView)
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,172 @@
+package app
+
+import (
+	"time"
+	"unicode/utf8"
+
+	"github.com/mattermost/mattermost-plugin-api/cluster"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// RunIdempotencyKeyTTL is how long a repeated run creation request with the same key returns
+	// the run created by the first one.
+	RunIdempotencyKeyTTL = 24 * time.Hour
+
+	// MaxRunIdempotencyKeyLength is the maximum length, in characters, of an idempotency key.
+	MaxRunIdempotencyKeyLength = 128
+
+	// runIdempotencyReservationTimeout is how long a key stays reserved by a request that is still
+	// creating its run. Past that, the request is assumed to have died and the key can be reused.
+	runIdempotencyReservationTimeout = time.Minute
+
+	// runIdempotencyCleanupInterval is how often the expired keys are deleted.
+	runIdempotencyCleanupInterval = time.Hour
+
+	runIdempotencyCleanupJobKey = "IR_RunIdempotencyCleanup"
+)
+
+// RunIdempotencyKey records the run created by the request of a user with a given idempotency key.
+type RunIdempotencyKey struct {
+	UserID string
+
+	// Key is the idempotency key sent by the client. Keys are unique per user.
+	Key string
+
+	// PlaybookRunID is the run created for the key, or empty while it is being created.
+	PlaybookRunID string
+
+	CreateAt int64
+}
+
+// RunIdempotencyKeyStore defines the methods the RunIdempotencyService needs from the interface
+// layer.
+type RunIdempotencyKeyStore interface {
+	// CreateRunIdempotencyKey stores a new key. Returns ErrDuplicateEntry if the user already has
+	// the same key.
+	CreateRunIdempotencyKey(key RunIdempotencyKey) error
+
+	// GetRunIdempotencyKey returns the key of userID. Returns ErrNotFound if not found.
+	GetRunIdempotencyKey(userID, key string) (RunIdempotencyKey, error)
+
+	// SetRunIdempotencyKeyRun records the run created for the key of userID.
+	SetRunIdempotencyKeyRun(userID, key, playbookRunID string) error
+
+	// DeleteRunIdempotencyKey deletes the key of userID if it was created at createAt, leaving
+	// alone a key that was reused since.
+	DeleteRunIdempotencyKey(userID, key string, createAt int64) error
+
+	// DeleteRunIdempotencyKeysCreatedBefore deletes the keys created before the given time, in millis.
+	DeleteRunIdempotencyKeysCreatedBefore(before int64) error
+}
+
+// RunIdempotencyService makes the creation of runs idempotent: a client retrying a request with
+// the same idempotency key gets the run created by the first request instead of a new one.
+//
+// Keys are reserved before the run is created, relying on the uniqueness of the key in the store,
+// so only one of several concurrent requests with the same key creates a run. A cluster job
+// deletes the keys once they expire.
+type RunIdempotencyService struct {
+	store RunIdempotencyKeyStore
+	job   *cluster.Job
+}
+
+// NewRunIdempotencyService creates a new RunIdempotencyService. Call Start to begin deleting
+// expired keys.
+func NewRunIdempotencyService(store RunIdempotencyKeyStore) *RunIdempotencyService {
+	return &RunIdempotencyService{
+		store: store,
+	}
+}
+
+// Start schedules the job deleting expired keys.
+func (s *RunIdempotencyService) Start(api cluster.JobPluginAPI) error {
+	job, err := cluster.Schedule(api, runIdempotencyCleanupJobKey, cluster.MakeWaitForInterval(runIdempotencyCleanupInterval), s.cleanup)
+	if err != nil {
+		return errors.Wrap(err, "failed to schedule the run idempotency key cleanup job")
+	}
+	s.job = job
+
+	return nil
+}
+
+// Stop stops deleting expired keys.
+func (s *RunIdempotencyService) Stop() error {
+	if s.job == nil {
+		return nil
+	}
+
+	return s.job.Close()
+}
+
+func (s *RunIdempotencyService) cleanup() {
+	before := model.GetMillis() - RunIdempotencyKeyTTL.Milliseconds()
+	if err := s.store.DeleteRunIdempotencyKeysCreatedBefore(before); err != nil {
+		logrus.WithError(err).Error("failed to delete the expired run idempotency keys")
+	}
+}
+
+// Reserve claims key for a new run created by userID. If a run was already created with the
+// key, the returned reservation has its ID in PlaybookRunID and no run must be created. Returns
+// ErrIdempotencyKeyInUse if another request with the same key is still creating its run.
+//
+// A reservation without a run must be completed with Complete once the run is created, or
+// released with Release if the creation failed.
+func (s *RunIdempotencyService) Reserve(userID, key string) (RunIdempotencyKey, error) {
+	return s.reserve(userID, key, model.GetMillis())
+}
+
+func (s *RunIdempotencyService) reserve(userID, key string, now int64) (RunIdempotencyKey, error) {
+	if key == "" || utf8.RuneCountInString(key) > MaxRunIdempotencyKeyLength {
+		return RunIdempotencyKey{}, errors.Wrapf(ErrMalformedPlaybookRun, "idempotency key must have between 1 and %d characters", MaxRunIdempotencyKeyLength)
+	}
+
+	reservation := RunIdempotencyKey{UserID: userID, Key: key, CreateAt: now}
+
+	// A stale key is replaced once. If another request replaced it first, it is now in use.
+	for attempt := 0; attempt < 2; attempt++ {
+		err := s.store.CreateRunIdempotencyKey(reservation)
+		if err == nil {
+			return reservation, nil
+		}
+		if !errors.Is(err, ErrDuplicateEntry) {
+			return RunIdempotencyKey{}, errors.Wrap(err, "failed to reserve idempotency key")
+		}
+
+		existing, err := s.store.GetRunIdempotencyKey(userID, key)
+		if errors.Is(err, ErrNotFound) {
+			// Released or expired in the meantime.
+			continue
+		} else if err != nil {
+			return RunIdempotencyKey{}, errors.Wrap(err, "failed to get idempotency key")
+		}
+
+		expired := existing.CreateAt <= now-RunIdempotencyKeyTTL.Milliseconds()
+		abandoned := existing.PlaybookRunID == "" && existing.CreateAt <= now-runIdempotencyReservationTimeout.Milliseconds()
+		if !expired && !abandoned {
+			if existing.PlaybookRunID == "" {
+				return RunIdempotencyKey{}, errors.Wrapf(ErrIdempotencyKeyInUse, "idempotency key `%s`", key)
+			}
+			return existing, nil
+		}
+
+		if err := s.store.DeleteRunIdempotencyKey(userID, key, existing.CreateAt); err != nil {
+			return RunIdempotencyKey{}, errors.Wrap(err, "failed to delete stale idempotency key")
+		}
+	}
+
+	return RunIdempotencyKey{}, errors.Wrapf(ErrIdempotencyKeyInUse, "idempotency key `%s`", key)
+}
+
+// Complete records playbookRunID as the run created for reservation.
+func (s *RunIdempotencyService) Complete(reservation RunIdempotencyKey, playbookRunID string) error {
+	return s.store.SetRunIdempotencyKeyRun(reservation.UserID, reservation.Key, playbookRunID)
+}
+
+// Release frees the key of reservation after its run could not be created, so that the request
+// can be retried with the same key.
+func (s *RunIdempotencyService) Release(reservation RunIdempotencyKey) error {
+	return s.store.DeleteRunIdempotencyKey(reservation.UserID, reservation.Key, reservation.CreateAt)
+}
@@ -0,0 +1,131 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRunIdempotencyKeyStore keeps the keys in memory, enforcing their uniqueness like the sql store.
+type fakeRunIdempotencyKeyStore struct {
+	keys map[string]RunIdempotencyKey
+}
+
+func newFakeRunIdempotencyKeyStore() *fakeRunIdempotencyKeyStore {
+	return &fakeRunIdempotencyKeyStore{keys: map[string]RunIdempotencyKey{}}
+}
+
+func (s *fakeRunIdempotencyKeyStore) CreateRunIdempotencyKey(key RunIdempotencyKey) error {
+	if _, ok := s.keys[key.UserID+"/"+key.Key]; ok {
+		return ErrDuplicateEntry
+	}
+	s.keys[key.UserID+"/"+key.Key] = key
+	return nil
+}
+
+func (s *fakeRunIdempotencyKeyStore) GetRunIdempotencyKey(userID, key string) (RunIdempotencyKey, error) {
+	idempotencyKey, ok := s.keys[userID+"/"+key]
+	if !ok {
+		return RunIdempotencyKey{}, ErrNotFound
+	}
+	return idempotencyKey, nil
+}
+
+func (s *fakeRunIdempotencyKeyStore) SetRunIdempotencyKeyRun(userID, key, playbookRunID string) error {
+	idempotencyKey := s.keys[userID+"/"+key]
+	idempotencyKey.PlaybookRunID = playbookRunID
+	s.keys[userID+"/"+key] = idempotencyKey
+	return nil
+}
+
+func (s *fakeRunIdempotencyKeyStore) DeleteRunIdempotencyKey(userID, key string, createAt int64) error {
+	if s.keys[userID+"/"+key].CreateAt == createAt {
+		delete(s.keys, userID+"/"+key)
+	}
+	return nil
+}
+
+func (s *fakeRunIdempotencyKeyStore) DeleteRunIdempotencyKeysCreatedBefore(before int64) error {
+	for id, key := range s.keys {
+		if key.CreateAt < before {
+			delete(s.keys, id)
+		}
+	}
+	return nil
+}
+
+func TestRunIdempotencyService_Reserve(t *testing.T) {
+	const now = int64(1_000_000_000)
+
+	t.Run("a repeated key returns the created run", func(t *testing.T) {
+		s := NewRunIdempotencyService(newFakeRunIdempotencyKeyStore())
+
+		reservation, err := s.reserve("user", "key", now)
+		require.NoError(t, err)
+		require.Empty(t, reservation.PlaybookRunID)
+		require.NoError(t, s.Complete(reservation, "run"))
+
+		repeated, err := s.reserve("user", "key", now+1000)
+		require.NoError(t, err)
+		require.Equal(t, "run", repeated.PlaybookRunID)
+
+		other, err := s.reserve("other_user", "key", now+1000)
+		require.NoError(t, err)
+		require.Empty(t, other.PlaybookRunID)
+	})
+
+	t.Run("a key is in use while its run is being created", func(t *testing.T) {
+		s := NewRunIdempotencyService(newFakeRunIdempotencyKeyStore())
+
+		_, err := s.reserve("user", "key", now)
+		require.NoError(t, err)
+
+		_, err = s.reserve("user", "key", now+1000)
+		require.ErrorIs(t, err, ErrIdempotencyKeyInUse)
+	})
+
+	t.Run("an abandoned reservation can be taken over", func(t *testing.T) {
+		s := NewRunIdempotencyService(newFakeRunIdempotencyKeyStore())
+
+		_, err := s.reserve("user", "key", now)
+		require.NoError(t, err)
+
+		reservation, err := s.reserve("user", "key", now+runIdempotencyReservationTimeout.Milliseconds())
+		require.NoError(t, err)
+		require.Empty(t, reservation.PlaybookRunID)
+	})
+
+	t.Run("a released key can be reused", func(t *testing.T) {
+		s := NewRunIdempotencyService(newFakeRunIdempotencyKeyStore())
+
+		reservation, err := s.reserve("user", "key", now)
+		require.NoError(t, err)
+		require.NoError(t, s.Release(reservation))
+
+		_, err = s.reserve("user", "key", now+1000)
+		require.NoError(t, err)
+	})
+
+	t.Run("an expired key creates a new run", func(t *testing.T) {
+		s := NewRunIdempotencyService(newFakeRunIdempotencyKeyStore())
+
+		reservation, err := s.reserve("user", "key", now)
+		require.NoError(t, err)
+		require.NoError(t, s.Complete(reservation, "run"))
+
+		reservation, err = s.reserve("user", "key", now+RunIdempotencyKeyTTL.Milliseconds())
+		require.NoError(t, err)
+		require.Empty(t, reservation.PlaybookRunID)
+	})
+
+	t.Run("invalid keys", func(t *testing.T) {
+		s := NewRunIdempotencyService(newFakeRunIdempotencyKeyStore())
+
+		_, err := s.reserve("user", "", now)
+		require.ErrorIs(t, err, ErrMalformedPlaybookRun)
+
+		_, err = s.reserve("user", strings.Repeat("k", MaxRunIdempotencyKeyLength+1), now)
+		require.ErrorIs(t, err, ErrMalformedPlaybookRun)
+	})
+}
@@ -0,0 +1,228 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	pluginapi "github.com/mattermost/mattermost-plugin-api"
+	"github.com/mattermost/mattermost-plugin-api/cluster"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/mattermost/mattermost-plugin-playbooks/server/bot"
+)
+
+const (
+	// RunSchedulerPollInterval is how often the RunScheduler looks for due schedules.
+	RunSchedulerPollInterval = 1 * time.Minute
+
+	runSchedulerJobKey = "IR_RunScheduler"
+)
+
+// RunScheduler starts playbook runs on the recurring schedules attached to playbooks.
+//
+// Polling is done through a cluster job, so only one node in the cluster looks for due schedules
+// at a time. Each occurrence is additionally claimed in the database before the run is created,
+// so overlapping polls never start the same occurrence twice.
+type RunScheduler struct {
+	store              ScheduledRunStore
+	playbookService    PlaybookService
+	playbookRunService PlaybookRunService
+	permissions        *PermissionsService
+	poster             bot.Poster
+	pluginAPI          *pluginapi.Client
+	job                *cluster.Job
+}
+
+// NewRunScheduler creates a new RunScheduler. Call Start to begin polling.
+func NewRunScheduler(store ScheduledRunStore, playbookService PlaybookService, playbookRunService PlaybookRunService, permissions *PermissionsService, poster bot.Poster, pluginAPI *pluginapi.Client) *RunScheduler {
+	return &RunScheduler{
+		store:              store,
+		playbookService:    playbookService,
+		playbookRunService: playbookRunService,
+		permissions:        permissions,
+		poster:             poster,
+		pluginAPI:          pluginAPI,
+	}
+}
+
+// Start schedules the polling job. State lives in the database, so schedules that came due
+// while the plugin was stopped fire on the first poll after it starts again.
+func (s *RunScheduler) Start(api cluster.JobPluginAPI) error {
+	job, err := cluster.Schedule(api, runSchedulerJobKey, cluster.MakeWaitForInterval(RunSchedulerPollInterval), s.poll)
+	if err != nil {
+		return errors.Wrap(err, "failed to schedule the run scheduler job")
+	}
+	s.job = job
+
+	return nil
+}
+
+// Stop stops polling.
+func (s *RunScheduler) Stop() error {
+	if s.job == nil {
+		return nil
+	}
+
+	return s.job.Close()
+}
+
+// CreateScheduledRun validates and stores a new schedule for the given playbook.
+func (s *RunScheduler) CreateScheduledRun(scheduledRun ScheduledRun, userID string) (ScheduledRun, error) {
+	if err := scheduledRun.IsValid(); err != nil {
+		return ScheduledRun{}, errors.Wrap(ErrMalformedScheduledRun, err.Error())
+	}
+
+	next, err := scheduledRun.NextOccurrence(time.Now())
+	if err != nil {
+		return ScheduledRun{}, err
+	}
+
+	scheduledRun.CreatorUserID = userID
+	scheduledRun.LastRunAt = 0
+	scheduledRun.NextRunAt = model.GetMillisForTime(next)
+	scheduledRun.CreateAt = model.GetMillis()
+	scheduledRun.UpdateAt = scheduledRun.CreateAt
+
+	id, err := s.store.CreateScheduledRun(scheduledRun)
+	if err != nil {
+		return ScheduledRun{}, errors.Wrap(err, "failed to create scheduled run")
+	}
+	scheduledRun.ID = id
+
+	return scheduledRun, nil
+}
+
+// GetScheduledRun returns a schedule. Returns ErrNotFound if not found.
+func (s *RunScheduler) GetScheduledRun(id string) (ScheduledRun, error) {
+	return s.store.GetScheduledRun(id)
+}
+
+// GetScheduledRunsForPlaybook returns the schedules attached to a playbook.
+func (s *RunScheduler) GetScheduledRunsForPlaybook(playbookID string) ([]ScheduledRun, error) {
+	return s.store.GetScheduledRunsForPlaybook(playbookID)
+}
+
+// UpdateScheduledRun updates the frequency, start time, timezone and enabled flag of a schedule,
+// recomputing its next occurrence.
+func (s *RunScheduler) UpdateScheduledRun(scheduledRun ScheduledRun) (ScheduledRun, error) {
+	existing, err := s.store.GetScheduledRun(scheduledRun.ID)
+	if err != nil {
+		return ScheduledRun{}, err
+	}
+
+	existing.Frequency = scheduledRun.Frequency
+	existing.StartTime = scheduledRun.StartTime
+	existing.Timezone = scheduledRun.Timezone
+	existing.Enabled = scheduledRun.Enabled
+
+	if err = existing.IsValid(); err != nil {
+		return ScheduledRun{}, errors.Wrap(ErrMalformedScheduledRun, err.Error())
+	}
+
+	next, err := existing.NextOccurrence(time.Now())
+	if err != nil {
+		return ScheduledRun{}, err
+	}
+	existing.NextRunAt = model.GetMillisForTime(next)
+	existing.UpdateAt = model.GetMillis()
+
+	if err = s.store.UpdateScheduledRun(existing); err != nil {
+		return ScheduledRun{}, errors.Wrapf(err, "failed to update scheduled run %s", existing.ID)
+	}
+
+	return existing, nil
+}
+
+// DeleteScheduledRun removes a schedule.
+func (s *RunScheduler) DeleteScheduledRun(id string) error {
+	return s.store.DeleteScheduledRun(id)
+}
+
+func (s *RunScheduler) poll() {
+	now := time.Now()
+
+	due, err := s.store.GetDueScheduledRuns(model.GetMillisForTime(now))
+	if err != nil {
+		logrus.WithError(err).Error("failed to get due scheduled runs")
+		return
+	}
+
+	for _, scheduledRun := range due {
+		if err := s.fire(scheduledRun, now); err != nil {
+			logrus.WithError(err).WithFields(logrus.Fields{
+				"scheduled_run_id": scheduledRun.ID,
+				"playbook_id":      scheduledRun.PlaybookID,
+			}).Error("failed to start scheduled run")
+		}
+	}
+}
+
+// fire checks that the creator of the schedule can still start its runs, then claims the due
+// occurrence of the schedule and starts a run from it. Occurrences missed while the plugin was
+// down, or while the run couldn't be started, are collapsed into a single run.
+func (s *RunScheduler) fire(scheduledRun ScheduledRun, now time.Time) error {
+	next, err := scheduledRun.NextOccurrence(now)
+	if err != nil {
+		return err
+	}
+
+	playbook, err := s.playbookService.Get(scheduledRun.PlaybookID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get playbook")
+	}
+
+	if playbook.DeleteAt != 0 {
+		return errors.Wrap(ErrPlaybookArchived, "cannot create a new run using an archived playbook")
+	}
+
+	if err = s.permissions.RunCreate(scheduledRun.CreatorUserID, playbook); err != nil {
+		return err
+	}
+
+	permission := model.PermissionCreatePrivateChannel
+	if playbook.CreatePublicPlaybookRun {
+		permission = model.PermissionCreatePublicChannel
+	}
+	if playbook.ChannelMode == PlaybookRunCreateNewChannel && !s.pluginAPI.User.HasPermissionToTeam(scheduledRun.CreatorUserID, playbook.TeamID, permission) {
+		return errors.Wrap(ErrNoPermissions, "schedule creator is not able to create the run channel")
+	}
+
+	loc, err := time.LoadLocation(scheduledRun.Timezone)
+	if err != nil {
+		return errors.Wrapf(err, "invalid timezone %q", scheduledRun.Timezone)
+	}
+
+	// The occurrence is only claimed once the run can be started, so that an occurrence the
+	// creator can't start is retried on the next polls instead of being recorded as run.
+	claimed, err := s.store.ClaimScheduledRun(scheduledRun.ID, scheduledRun.NextRunAt, model.GetMillisForTime(next), model.GetMillisForTime(now))
+	if err != nil {
+		return errors.Wrap(err, "failed to claim scheduled run")
+	}
+	if !claimed {
+		// Another poll already handled this occurrence.
+		return nil
+	}
+
+	playbookRun := PlaybookRun{
+		Name:        fmt.Sprintf("%s - %s", playbook.Title, now.In(loc).Format("Jan 2, 2006")),
+		OwnerUserID: scheduledRun.CreatorUserID,
+		TeamID:      playbook.TeamID,
+		PlaybookID:  playbook.ID,
+	}
+	playbookRun.SetChecklistFromPlaybook(playbook)
+	playbookRun.SetConfigurationFromPlaybook(playbook)
+
+	createdRun, err := s.playbookRunService.CreatePlaybookRun(&playbookRun, &playbook, scheduledRun.CreatorUserID, playbook.CreatePublicPlaybookRun)
+	if err != nil {
+		return errors.Wrap(err, "failed to create playbook run")
+	}
+
+	s.poster.PublishWebsocketEventToUser(ScheduledRunFiredWSEvent, map[string]interface{}{
+		"scheduled_run_id": scheduledRun.ID,
+		"playbook_run":     createdRun,
+	}, scheduledRun.CreatorUserID)
+
+	return nil
+}
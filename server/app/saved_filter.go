@@ -0,0 +1,123 @@
+package app
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/pkg/errors"
+)
+
+// MaxSavedFilterNameLength is the maximum length, in characters, of the name of a saved filter.
+const MaxSavedFilterNameLength = 64
+
+// SavedFilter is a named preset of the filters of the run list, saved by a user for a team. It is
+// private to its creator unless it is shared with the team.
+type SavedFilter struct {
+	// ID is the unique identifier of the saved filter.
+	ID string `json:"id"`
+
+	// Name is the name shown in the run list.
+	Name string `json:"name"`
+
+	// TeamID is the team whose runs are filtered.
+	TeamID string `json:"team_id"`
+
+	// UserID is the user that created the filter. Only they can update or delete it.
+	UserID string `json:"user_id"`
+
+	// Shared determines whether the members of the team can see and apply the filter.
+	Shared bool `json:"shared"`
+
+	// Options are the filters applied to the run list.
+	Options SavedFilterOptions `json:"options"`
+
+	CreateAt int64 `json:"create_at"`
+	UpdateAt int64 `json:"update_at"`
+}
+
+// SavedFilterOptions are the filters of the run list kept by a saved filter. Empty options don't
+// filter.
+type SavedFilterOptions struct {
+	// OwnerID filters the runs owned by this user.
+	OwnerID string `json:"owner_user_id"`
+
+	// Statuses filters the runs with any of these statuses.
+	Statuses []string `json:"statuses"`
+
+	// PlaybookID filters the runs started from this playbook.
+	PlaybookID string `json:"playbook_id"`
+
+	// Tags filters the runs with any of these tags, or all of them if TagsMatchAll.
+	Tags         []string `json:"tags"`
+	TagsMatchAll bool     `json:"tags_match_all"`
+
+	// AssigneeID filters the runs with at least one open checklist item assigned to this user.
+	AssigneeID string `json:"assignee_id"`
+
+	// Sort and Direction order the runs, as in PlaybookRunFilterOptions.
+	Sort      SortField     `json:"sort"`
+	Direction SortDirection `json:"direction"`
+}
+
+// RunFilterOptions returns the options to get the runs of the team matching the saved filter.
+func (f SavedFilter) RunFilterOptions() PlaybookRunFilterOptions {
+	return PlaybookRunFilterOptions{
+		TeamID:       f.TeamID,
+		Sort:         f.Options.Sort,
+		Direction:    f.Options.Direction,
+		Statuses:     append([]string{}, f.Options.Statuses...),
+		OwnerID:      f.Options.OwnerID,
+		AssigneeID:   f.Options.AssigneeID,
+		PlaybookID:   f.Options.PlaybookID,
+		Tags:         append([]string{}, f.Options.Tags...),
+		TagsMatchAll: f.Options.TagsMatchAll,
+	}
+}
+
+// Normalize returns the saved filter with its name trimmed and its options validated, defaulting
+// the sort and normalizing the tags. Returns ErrMalformedSavedFilter if the filter is not valid.
+func (f SavedFilter) Normalize() (SavedFilter, error) {
+	f.Name = strings.TrimSpace(f.Name)
+	if f.Name == "" {
+		return SavedFilter{}, errors.Wrap(ErrMalformedSavedFilter, "name must not be empty")
+	}
+	if utf8.RuneCountInString(f.Name) > MaxSavedFilterNameLength {
+		return SavedFilter{}, errors.Wrapf(ErrMalformedSavedFilter, "name is longer than %d characters", MaxSavedFilterNameLength)
+	}
+
+	if !model.IsValidId(f.TeamID) {
+		return SavedFilter{}, errors.Wrap(ErrMalformedSavedFilter, "team_id must be 26 characters")
+	}
+
+	options, err := f.RunFilterOptions().Validate()
+	if err != nil {
+		return SavedFilter{}, errors.Wrap(ErrMalformedSavedFilter, err.Error())
+	}
+
+	f.Options.Statuses = options.Statuses
+	f.Options.Tags = options.Tags
+	f.Options.Sort = options.Sort
+	f.Options.Direction = options.Direction
+
+	return f, nil
+}
+
+// SavedFilterStore defines the methods the SavedFilterService needs from the interface layer.
+type SavedFilterStore interface {
+	// CreateSavedFilter stores a new saved filter and returns its ID.
+	CreateSavedFilter(filter SavedFilter) (string, error)
+
+	// GetSavedFilter retrieves a saved filter. Returns ErrNotFound if not found.
+	GetSavedFilter(id string) (SavedFilter, error)
+
+	// GetSavedFiltersForUser retrieves the saved filters of teamID created by userID, and those
+	// shared with the team by other users, ordered by name.
+	GetSavedFiltersForUser(userID, teamID string) ([]SavedFilter, error)
+
+	// UpdateSavedFilter updates the name, sharing and options of a saved filter.
+	UpdateSavedFilter(filter SavedFilter) error
+
+	// DeleteSavedFilter removes a saved filter.
+	DeleteSavedFilter(id string) error
+}
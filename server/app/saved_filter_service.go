@@ -0,0 +1,143 @@
+package app
+
+import (
+	pluginapi "github.com/mattermost/mattermost-plugin-api"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/pkg/errors"
+)
+
+// SavedFilterService manages the saved filters of the run list.
+type SavedFilterService struct {
+	store           SavedFilterStore
+	playbookService PlaybookService
+	pluginAPI       *pluginapi.Client
+}
+
+// NewSavedFilterService creates a new SavedFilterService.
+func NewSavedFilterService(store SavedFilterStore, playbookService PlaybookService, pluginAPI *pluginapi.Client) *SavedFilterService {
+	return &SavedFilterService{
+		store:           store,
+		playbookService: playbookService,
+		pluginAPI:       pluginAPI,
+	}
+}
+
+// CreateSavedFilter stores a new saved filter created by userID.
+func (s *SavedFilterService) CreateSavedFilter(filter SavedFilter, userID string) (SavedFilter, error) {
+	filter, err := filter.Normalize()
+	if err != nil {
+		return SavedFilter{}, err
+	}
+
+	filter.UserID = userID
+	filter.CreateAt = model.GetMillis()
+	filter.UpdateAt = filter.CreateAt
+
+	id, err := s.store.CreateSavedFilter(filter)
+	if err != nil {
+		return SavedFilter{}, errors.Wrap(err, "failed to create saved filter")
+	}
+	filter.ID = id
+
+	return filter, nil
+}
+
+// GetSavedFilter returns a saved filter. Returns ErrNotFound if not found.
+func (s *SavedFilterService) GetSavedFilter(id string) (SavedFilter, error) {
+	return s.store.GetSavedFilter(id)
+}
+
+// GetSavedFiltersForUser returns the saved filters of teamID that userID can see: their own and
+// those shared with the team.
+func (s *SavedFilterService) GetSavedFiltersForUser(userID, teamID string) ([]SavedFilter, error) {
+	return s.store.GetSavedFiltersForUser(userID, teamID)
+}
+
+// UpdateSavedFilter updates the name, sharing and options of a saved filter. Its team and creator
+// can't be changed.
+func (s *SavedFilterService) UpdateSavedFilter(filter SavedFilter) (SavedFilter, error) {
+	existing, err := s.store.GetSavedFilter(filter.ID)
+	if err != nil {
+		return SavedFilter{}, err
+	}
+
+	existing.Name = filter.Name
+	existing.Shared = filter.Shared
+	existing.Options = filter.Options
+
+	if existing, err = existing.Normalize(); err != nil {
+		return SavedFilter{}, err
+	}
+	existing.UpdateAt = model.GetMillis()
+
+	if err = s.store.UpdateSavedFilter(existing); err != nil {
+		return SavedFilter{}, errors.Wrapf(err, "failed to update saved filter %s", existing.ID)
+	}
+
+	return existing, nil
+}
+
+// DeleteSavedFilter removes a saved filter.
+func (s *SavedFilterService) DeleteSavedFilter(id string) error {
+	return s.store.DeleteSavedFilter(id)
+}
+
+// RunFilterOptions returns the options to get the runs matching a saved filter. The playbook and
+// users the filter refers to may have been deleted since it was saved: those filters are ignored
+// instead of matching no run at all.
+func (s *SavedFilterService) RunFilterOptions(filter SavedFilter) (PlaybookRunFilterOptions, error) {
+	return filter.runFilterOptionsWithoutStale(s.playbookExists, s.userExists)
+}
+
+func (s *SavedFilterService) playbookExists(id string) (bool, error) {
+	_, err := s.playbookService.Get(id)
+	if errors.Is(err, ErrNotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Wrapf(err, "failed to get playbook %s", id)
+	}
+
+	return true, nil
+}
+
+func (s *SavedFilterService) userExists(id string) (bool, error) {
+	_, err := s.pluginAPI.User.Get(id)
+	if errors.Is(err, pluginapi.ErrNotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Wrapf(err, "failed to get user %s", id)
+	}
+
+	return true, nil
+}
+
+// runFilterOptionsWithoutStale returns the run filter options of the saved filter, dropping the
+// playbook and users for which playbookExists and userExists return false.
+func (f SavedFilter) runFilterOptionsWithoutStale(playbookExists, userExists func(id string) (bool, error)) (PlaybookRunFilterOptions, error) {
+	options := f.RunFilterOptions()
+
+	if options.PlaybookID != "" {
+		exists, err := playbookExists(options.PlaybookID)
+		if err != nil {
+			return PlaybookRunFilterOptions{}, err
+		}
+		if !exists {
+			options.PlaybookID = ""
+		}
+	}
+
+	for _, userID := range []*string{&options.OwnerID, &options.AssigneeID} {
+		if *userID == "" {
+			continue
+		}
+		exists, err := userExists(*userID)
+		if err != nil {
+			return PlaybookRunFilterOptions{}, err
+		}
+		if !exists {
+			*userID = ""
+		}
+	}
+
+	return options.Validate()
+}
@@ -0,0 +1,91 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSavedFilter_Normalize(t *testing.T) {
+	teamID := model.NewId()
+
+	t.Run("valid", func(t *testing.T) {
+		filter, err := SavedFilter{
+			Name:   "  Incidents  ",
+			TeamID: teamID,
+			Options: SavedFilterOptions{
+				Statuses:  []string{StatusInProgress},
+				Tags:      []string{" Outage "},
+				Sort:      "Name",
+				Direction: "desc",
+			},
+		}.Normalize()
+		require.NoError(t, err)
+		require.Equal(t, "Incidents", filter.Name)
+		require.Equal(t, []string{"outage"}, filter.Options.Tags)
+		require.Equal(t, SortByName, filter.Options.Sort)
+		require.Equal(t, DirectionDesc, filter.Options.Direction)
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		filter, err := SavedFilter{Name: "All", TeamID: teamID}.Normalize()
+		require.NoError(t, err)
+		require.Equal(t, SortByCreateAt, filter.Options.Sort)
+		require.Equal(t, DirectionAsc, filter.Options.Direction)
+	})
+
+	for name, filter := range map[string]SavedFilter{
+		"empty name":       {Name: " ", TeamID: teamID},
+		"long name":        {Name: strings.Repeat("a", MaxSavedFilterNameLength+1), TeamID: teamID},
+		"invalid team":     {Name: "Runs", TeamID: "team"},
+		"invalid owner":    {Name: "Runs", TeamID: teamID, Options: SavedFilterOptions{OwnerID: "owner"}},
+		"invalid status":   {Name: "Runs", TeamID: teamID, Options: SavedFilterOptions{Statuses: []string{"Unknown"}}},
+		"invalid tag":      {Name: "Runs", TeamID: teamID, Options: SavedFilterOptions{Tags: []string{"a,b"}}},
+		"invalid sort":     {Name: "Runs", TeamID: teamID, Options: SavedFilterOptions{Sort: "color"}},
+		"invalid playbook": {Name: "Runs", TeamID: teamID, Options: SavedFilterOptions{PlaybookID: "playbook"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := filter.Normalize()
+			require.ErrorIs(t, err, ErrMalformedSavedFilter)
+		})
+	}
+}
+
+func TestSavedFilter_RunFilterOptionsWithoutStale(t *testing.T) {
+	existingID, staleID := model.NewId(), model.NewId()
+	exists := func(id string) (bool, error) {
+		return id == existingID, nil
+	}
+
+	filter := SavedFilter{
+		TeamID: model.NewId(),
+		Options: SavedFilterOptions{
+			OwnerID:    existingID,
+			AssigneeID: staleID,
+			PlaybookID: staleID,
+			Statuses:   []string{StatusFinished},
+			Tags:       []string{"outage"},
+		},
+	}
+
+	options, err := filter.runFilterOptionsWithoutStale(exists, exists)
+	require.NoError(t, err)
+	require.Equal(t, filter.TeamID, options.TeamID)
+	require.Equal(t, existingID, options.OwnerID)
+	require.Empty(t, options.AssigneeID)
+	require.Empty(t, options.PlaybookID)
+	require.Equal(t, []string{StatusFinished}, options.Statuses)
+	require.Equal(t, []string{"outage"}, options.Tags)
+	require.Equal(t, PerPageDefault, options.PerPage)
+
+	t.Run("lookup errors are returned", func(t *testing.T) {
+		failing := func(id string) (bool, error) {
+			return false, errors.New("unavailable")
+		}
+		_, err := filter.runFilterOptionsWithoutStale(exists, failing)
+		require.Error(t, err)
+	})
+}
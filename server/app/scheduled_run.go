@@ -0,0 +1,168 @@
+package app
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ScheduledRunFrequency describes how often a scheduled run is started.
+type ScheduledRunFrequency string
+
+const (
+	ScheduledRunHourly   ScheduledRunFrequency = "hourly"
+	ScheduledRunDaily    ScheduledRunFrequency = "daily"
+	ScheduledRunWeekdays ScheduledRunFrequency = "weekdays"
+	ScheduledRunWeekly   ScheduledRunFrequency = "weekly"
+	ScheduledRunMonthly  ScheduledRunFrequency = "monthly"
+)
+
+// ScheduledRunFiredWSEvent is published to the schedule's creator whenever a scheduled run is started.
+const ScheduledRunFiredWSEvent = "scheduled_run_fired"
+
+// ScheduledRun holds the configuration of a recurring run attached to a playbook.
+type ScheduledRun struct {
+	// ID is the unique identifier of the schedule.
+	ID string `json:"id"`
+
+	// PlaybookID is the playbook used to create each run.
+	PlaybookID string `json:"playbook_id"`
+
+	// CreatorUserID is the user that created the schedule. Runs are created on their behalf,
+	// and they become the owner of each run unless the playbook defines a default owner.
+	CreatorUserID string `json:"creator_user_id"`
+
+	// Frequency defines how often a run is started.
+	Frequency ScheduledRunFrequency `json:"frequency"`
+
+	// StartTime is the time, in milliseconds, of the first occurrence. Every later occurrence keeps
+	// the wall clock time (and, where relevant, weekday or day of month) of StartTime in Timezone.
+	StartTime int64 `json:"start_time"`
+
+	// Timezone is the IANA name of the timezone the schedule is evaluated in, e.g. "Europe/Madrid".
+	Timezone string `json:"timezone"`
+
+	// Enabled determines whether the schedule creates runs.
+	Enabled bool `json:"enabled"`
+
+	// LastRunAt is the time, in milliseconds, at which the schedule last fired, or 0 if it never did.
+	LastRunAt int64 `json:"last_run_at"`
+
+	// NextRunAt is the time, in milliseconds, at which the schedule fires next.
+	NextRunAt int64 `json:"next_run_at"`
+
+	CreateAt int64 `json:"create_at"`
+	UpdateAt int64 `json:"update_at"`
+}
+
+// IsValid checks that the frequency and timezone of the schedule are well-formed.
+func (sr ScheduledRun) IsValid() error {
+	switch sr.Frequency {
+	case ScheduledRunHourly, ScheduledRunDaily, ScheduledRunWeekdays, ScheduledRunWeekly, ScheduledRunMonthly:
+	default:
+		return errors.Errorf("invalid frequency %q", sr.Frequency)
+	}
+
+	if sr.StartTime <= 0 {
+		return errors.New("start time must be set")
+	}
+
+	if _, err := time.LoadLocation(sr.Timezone); err != nil {
+		return errors.Wrapf(err, "invalid timezone %q", sr.Timezone)
+	}
+
+	return nil
+}
+
+// NextOccurrence returns the first occurrence of the schedule strictly after the given time.
+//
+// Occurrences are computed on the wall clock of the schedule's timezone, so a run scheduled for
+// 09:00 keeps firing at 09:00 local time across DST transitions. A wall clock time that does not
+// exist on a given day (i.e., it falls in the gap of a spring-forward transition) is normalized by
+// the time package to the equivalent instant after the gap.
+func (sr ScheduledRun) NextOccurrence(after time.Time) (time.Time, error) {
+	loc, err := time.LoadLocation(sr.Timezone)
+	if err != nil {
+		return time.Time{}, errors.Wrapf(err, "invalid timezone %q", sr.Timezone)
+	}
+
+	start := time.UnixMilli(sr.StartTime).In(loc)
+	if start.After(after) {
+		return start, nil
+	}
+
+	if sr.Frequency == ScheduledRunHourly {
+		// Hours are absolute, so there's no need to go through the wall clock.
+		elapsed := after.Sub(start) / time.Hour
+		return start.Add((elapsed + 1) * time.Hour), nil
+	}
+
+	local := after.In(loc)
+	atDay := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, start.Hour(), start.Minute(), start.Second(), 0, loc)
+	}
+
+	switch sr.Frequency {
+	case ScheduledRunDaily, ScheduledRunWeekdays:
+		for i := 0; ; i++ {
+			candidate := atDay(local.Year(), local.Month(), local.Day()+i)
+			if !candidate.After(after) {
+				continue
+			}
+			if sr.Frequency == ScheduledRunWeekdays && (candidate.Weekday() == time.Saturday || candidate.Weekday() == time.Sunday) {
+				continue
+			}
+			return candidate, nil
+		}
+
+	case ScheduledRunWeekly:
+		offset := (int(start.Weekday()) - int(local.Weekday()) + 7) % 7
+		candidate := atDay(local.Year(), local.Month(), local.Day()+offset)
+		if !candidate.After(after) {
+			candidate = atDay(local.Year(), local.Month(), local.Day()+offset+7)
+		}
+		return candidate, nil
+
+	case ScheduledRunMonthly:
+		for i := 0; ; i++ {
+			year, month := local.Year(), local.Month()+time.Month(i)
+			// Clamp to the last day of months shorter than the start day, e.g. the 31st becomes the 30th in April.
+			day := start.Day()
+			if lastDay := time.Date(year, month+1, 0, 0, 0, 0, 0, loc).Day(); day > lastDay {
+				day = lastDay
+			}
+			candidate := atDay(year, month, day)
+			if candidate.After(after) {
+				return candidate, nil
+			}
+		}
+	}
+
+	return time.Time{}, errors.Errorf("invalid frequency %q", sr.Frequency)
+}
+
+// ScheduledRunStore defines the methods the RunScheduler needs from the store.
+type ScheduledRunStore interface {
+	// CreateScheduledRun stores a new schedule and returns its ID.
+	CreateScheduledRun(scheduledRun ScheduledRun) (string, error)
+
+	// GetScheduledRun retrieves a schedule. Returns ErrNotFound if not found.
+	GetScheduledRun(id string) (ScheduledRun, error)
+
+	// GetScheduledRunsForPlaybook retrieves all the schedules attached to the given playbook.
+	GetScheduledRunsForPlaybook(playbookID string) ([]ScheduledRun, error)
+
+	// GetDueScheduledRuns retrieves the enabled schedules whose next occurrence is at or before now.
+	GetDueScheduledRuns(now int64) ([]ScheduledRun, error)
+
+	// UpdateScheduledRun updates a schedule.
+	UpdateScheduledRun(scheduledRun ScheduledRun) error
+
+	// ClaimScheduledRun atomically moves the next occurrence of a schedule from expectedNextRunAt
+	// to nextRunAt, recording lastRunAt. It returns false if the schedule was already claimed,
+	// which guarantees that a single occurrence fires at most once.
+	ClaimScheduledRun(id string, expectedNextRunAt, nextRunAt, lastRunAt int64) (bool, error)
+
+	// DeleteScheduledRun removes a schedule.
+	DeleteScheduledRun(id string) error
+}
@@ -0,0 +1,110 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduledRunNextOccurrence(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	// Monday, March 6 2023, 09:00 in New York. DST starts on Sunday, March 12 2023.
+	start := time.Date(2023, time.March, 6, 9, 0, 0, 0, newYork)
+
+	tests := []struct {
+		name      string
+		frequency ScheduledRunFrequency
+		after     time.Time
+		want      time.Time
+	}{
+		{
+			name:      "before the start time, the start time is the next occurrence",
+			frequency: ScheduledRunDaily,
+			after:     start.Add(-time.Hour),
+			want:      start,
+		},
+		{
+			name:      "exactly at an occurrence, the next one is returned",
+			frequency: ScheduledRunDaily,
+			after:     start,
+			want:      time.Date(2023, time.March, 7, 9, 0, 0, 0, newYork),
+		},
+		{
+			name:      "daily keeps the wall clock time across the DST transition",
+			frequency: ScheduledRunDaily,
+			after:     time.Date(2023, time.March, 12, 0, 0, 0, 0, newYork),
+			want:      time.Date(2023, time.March, 12, 9, 0, 0, 0, newYork),
+		},
+		{
+			name:      "weekly keeps the weekday and wall clock time across the DST transition",
+			frequency: ScheduledRunWeekly,
+			after:     time.Date(2023, time.March, 7, 0, 0, 0, 0, newYork),
+			want:      time.Date(2023, time.March, 13, 9, 0, 0, 0, newYork),
+		},
+		{
+			name:      "weekly later on the same weekday moves to the next week",
+			frequency: ScheduledRunWeekly,
+			after:     time.Date(2023, time.March, 13, 10, 0, 0, 0, newYork),
+			want:      time.Date(2023, time.March, 20, 9, 0, 0, 0, newYork),
+		},
+		{
+			name:      "weekdays skip the weekend",
+			frequency: ScheduledRunWeekdays,
+			after:     time.Date(2023, time.March, 10, 10, 0, 0, 0, newYork),
+			want:      time.Date(2023, time.March, 13, 9, 0, 0, 0, newYork),
+		},
+		{
+			name:      "hourly counts absolute hours",
+			frequency: ScheduledRunHourly,
+			after:     start.Add(90 * time.Minute),
+			want:      start.Add(2 * time.Hour),
+		},
+		{
+			name:      "monthly keeps the day of month",
+			frequency: ScheduledRunMonthly,
+			after:     start,
+			want:      time.Date(2023, time.April, 6, 9, 0, 0, 0, newYork),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheduledRun := ScheduledRun{
+				Frequency: tt.frequency,
+				StartTime: start.UnixMilli(),
+				Timezone:  "America/New_York",
+			}
+
+			got, err := scheduledRun.NextOccurrence(tt.after)
+			require.NoError(t, err)
+			require.True(t, tt.want.Equal(got), "expected %s, got %s", tt.want, got)
+		})
+	}
+
+	t.Run("monthly clamps to the last day of shorter months", func(t *testing.T) {
+		scheduledRun := ScheduledRun{
+			Frequency: ScheduledRunMonthly,
+			StartTime: time.Date(2023, time.January, 31, 9, 0, 0, 0, newYork).UnixMilli(),
+			Timezone:  "America/New_York",
+		}
+
+		got, err := scheduledRun.NextOccurrence(time.Date(2023, time.February, 1, 0, 0, 0, 0, newYork))
+		require.NoError(t, err)
+		require.True(t, time.Date(2023, time.February, 28, 9, 0, 0, 0, newYork).Equal(got), "got %s", got)
+	})
+
+	t.Run("invalid timezone", func(t *testing.T) {
+		scheduledRun := ScheduledRun{
+			Frequency: ScheduledRunDaily,
+			StartTime: start.UnixMilli(),
+			Timezone:  "Not/A_Timezone",
+		}
+
+		_, err := scheduledRun.NextOccurrence(start)
+		require.Error(t, err)
+		require.Error(t, scheduledRun.IsValid())
+	})
+}
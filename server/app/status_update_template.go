@@ -0,0 +1,59 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/pkg/errors"
+)
+
+// StatusUpdateTemplate is a named template for the message of a status update.
+type StatusUpdateTemplate struct {
+	ID       string `json:"id" export:"-"`
+	Title    string `json:"title" export:"title"`
+	Template string `json:"template" export:"template"`
+}
+
+// PrepareStatusUpdateTemplates gives an ID to the templates without one, and checks that every
+// template has a unique ID, a unique title and a non-empty message.
+func PrepareStatusUpdateTemplates(templates []StatusUpdateTemplate) error {
+	ids := make(map[string]bool, len(templates))
+	titles := make(map[string]bool, len(templates))
+	for i := range templates {
+		template := &templates[i]
+		if template.ID == "" {
+			template.ID = model.NewId()
+		}
+		if ids[template.ID] {
+			return errors.Errorf("duplicate status update template id %q", template.ID)
+		}
+		ids[template.ID] = true
+
+		template.Title = strings.TrimSpace(template.Title)
+		if template.Title == "" {
+			return errors.New("status update template title must not be empty")
+		}
+		if titles[strings.ToLower(template.Title)] {
+			return errors.Errorf("duplicate status update template %q", template.Title)
+		}
+		titles[strings.ToLower(template.Title)] = true
+
+		if strings.TrimSpace(template.Template) == "" {
+			return errors.Errorf("status update template %q must not be empty", template.Title)
+		}
+	}
+
+	return nil
+}
+
+// GetStatusUpdateTemplate returns the status update template templateID of the run, as copied
+// from its playbook when the run started.
+func (r *PlaybookRun) GetStatusUpdateTemplate(templateID string) (StatusUpdateTemplate, error) {
+	for _, template := range r.StatusUpdateTemplates {
+		if template.ID == templateID {
+			return template, nil
+		}
+	}
+
+	return StatusUpdateTemplate{}, errors.Wrapf(ErrStatusUpdateTemplateNotFound, "template `%s` of run `%s`", templateID, r.ID)
+}
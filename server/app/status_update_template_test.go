@@ -0,0 +1,52 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrepareStatusUpdateTemplates(t *testing.T) {
+	t.Run("assigns missing ids and trims titles", func(t *testing.T) {
+		templates := []StatusUpdateTemplate{
+			{ID: "existing", Title: "Customer impact", Template: "Impact: "},
+			{Title: "  Mitigation  ", Template: "Mitigated by: "},
+		}
+		require.NoError(t, PrepareStatusUpdateTemplates(templates))
+		require.Equal(t, "existing", templates[0].ID)
+		require.NotEmpty(t, templates[1].ID)
+		require.Equal(t, "Mitigation", templates[1].Title)
+	})
+
+	t.Run("no templates", func(t *testing.T) {
+		require.NoError(t, PrepareStatusUpdateTemplates(nil))
+	})
+
+	testCases := map[string][]StatusUpdateTemplate{
+		"empty title":     {{Title: " ", Template: "text"}},
+		"empty template":  {{Title: "Impact", Template: "  "}},
+		"duplicate title": {{Title: "Impact", Template: "a"}, {Title: "impact", Template: "b"}},
+		"duplicate id":    {{ID: "id", Title: "Impact", Template: "a"}, {ID: "id", Title: "Other", Template: "b"}},
+	}
+	for name, templates := range testCases {
+		t.Run(name, func(t *testing.T) {
+			require.Error(t, PrepareStatusUpdateTemplates(templates))
+		})
+	}
+}
+
+func TestPlaybookRun_GetStatusUpdateTemplate(t *testing.T) {
+	run := &PlaybookRun{
+		ID: "run_id",
+		StatusUpdateTemplates: []StatusUpdateTemplate{
+			{ID: "impact", Title: "Customer impact", Template: "Impact: "},
+		},
+	}
+
+	template, err := run.GetStatusUpdateTemplate("impact")
+	require.NoError(t, err)
+	require.Equal(t, "Impact: ", template.Template)
+
+	_, err = run.GetStatusUpdateTemplate("unknown")
+	require.ErrorIs(t, err, ErrStatusUpdateTemplateNotFound)
+}
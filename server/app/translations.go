@@ -0,0 +1,39 @@
+package app
+
+import (
+	pluginapi "github.com/mattermost/mattermost-plugin-api"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/i18n"
+	"github.com/sirupsen/logrus"
+)
+
+// The messages of the bot are translated into the locale of the user they are addressed to. The
+// messages posted in a channel without a single recipient use the default locale of the server.
+
+// serverLocale returns the default locale of the server, or an empty string if it isn't set, in
+// which case the messages are in English.
+func serverLocale(cfg *model.Config) string {
+	if cfg == nil || cfg.LocalizationSettings.DefaultServerLocale == nil {
+		return ""
+	}
+
+	return *cfg.LocalizationSettings.DefaultServerLocale
+}
+
+// serverTranslations returns the function translating messages into the default locale of the
+// server, for the messages posted in channels.
+func (s *PlaybookRunServiceImpl) serverTranslations() i18n.TranslateFunc {
+	return i18n.GetUserTranslations(serverLocale(s.pluginAPI.Configuration.GetConfig()))
+}
+
+// userTranslations returns the function translating messages into the locale of userID, for the
+// messages addressed to them. The default locale of the server is used if the user can't be read.
+func userTranslations(pluginAPI *pluginapi.Client, userID string) i18n.TranslateFunc {
+	user, err := pluginAPI.User.Get(userID)
+	if err != nil {
+		logrus.WithError(err).WithField("user_id", userID).Warn("failed to get the locale of the user")
+		return i18n.GetUserTranslations(serverLocale(pluginAPI.Configuration.GetConfig()))
+	}
+
+	return i18n.GetUserTranslations(user.Locale)
+}
@@ -0,0 +1,69 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/shared/i18n"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerLocale(t *testing.T) {
+	require.Empty(t, serverLocale(nil))
+	require.Empty(t, serverLocale(&model.Config{}))
+
+	cfg := &model.Config{}
+	cfg.LocalizationSettings.DefaultServerLocale = model.NewString("de")
+	require.Equal(t, "de", serverLocale(cfg))
+}
+
+func TestBotMessageTranslations(t *testing.T) {
+	require.NoError(t, i18n.TranslationsPreInit("../../assets/i18n"))
+
+	en := i18n.GetUserTranslations("en")
+	de := i18n.GetUserTranslations("de")
+
+	t.Run("every message is translated", func(t *testing.T) {
+		for _, id := range []string{
+			"app.user.due_reminder",
+			"app.user.due_reminder.snooze_day",
+			"app.user.due_reminder.snooze_hour",
+			"app.user.run.auto_finish.finished",
+			"app.user.run.auto_finish.retrospective_required",
+			"app.user.run.channel_linked",
+			"app.user.run.checklist_item_dependency_warning",
+			"app.user.run.original_post",
+			"app.user.run.retrospective_reminder",
+			"app.user.run.retrospective_reminder.no_retrospective_button",
+			"app.user.run.status_update_overdue",
+			"app.user.run.status_update_reminder",
+			"app.user.run.status_update_reminder.button",
+		} {
+			require.NotEqual(t, id, en(id))
+			require.NotEqual(t, en(id), de(id), id)
+		}
+	})
+
+	t.Run("messages are rendered in the locale", func(t *testing.T) {
+		data := map[string]interface{}{"Username": "alice"}
+		require.Equal(t, "@alice, please provide a status update.", en("app.user.run.status_update_reminder", data))
+		require.Equal(t, "@alice, bitte gib eine Statusaktualisierung.", de("app.user.run.status_update_reminder", data))
+	})
+
+	t.Run("unknown locales fall back to English", func(t *testing.T) {
+		require.Equal(t, en("app.user.run.auto_finish.finished"), i18n.GetUserTranslations("")("app.user.run.auto_finish.finished"))
+		require.Equal(t, en("app.user.run.auto_finish.finished"), i18n.GetUserTranslations("xx")("app.user.run.auto_finish.finished"))
+	})
+
+	t.Run("overdue status update", func(t *testing.T) {
+		buildMessage := func(T i18n.TranslateFunc) string {
+			return T("app.user.run.status_update_overdue", map[string]interface{}{
+				"ChannelDisplayName": "Outage",
+				"ChannelURL":         "/team/channels/outage?forceRHSOpen",
+				"OwnerUsername":      "alice",
+			})
+		}
+		require.Equal(t, "Status update is overdue for [Outage](/team/channels/outage?forceRHSOpen) (Owner: @alice)\n", buildMessage(en))
+		require.Equal(t, "Die Statusaktualisierung für [Outage](/team/channels/outage?forceRHSOpen) ist überfällig (Besitzer: @alice)\n", buildMessage(de))
+	})
+}
@@ -0,0 +1,105 @@
+package app
+
+import (
+	"time"
+)
+
+// WebhookDeliveryStatus is the state of a webhook delivery that failed at least once.
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookDeliveryPending deliveries are waiting for their next attempt.
+	WebhookDeliveryPending WebhookDeliveryStatus = "pending"
+
+	// WebhookDeliveryDelivered deliveries succeeded after being retried.
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+
+	// WebhookDeliveryFailed deliveries exhausted all their attempts.
+	WebhookDeliveryFailed WebhookDeliveryStatus = "failed"
+)
+
+const (
+	// DefaultWebhookMaxDeliveryAttempts is used when the maximum number of attempts is not configured.
+	DefaultWebhookMaxDeliveryAttempts = 5
+
+	// webhookRetryBaseDelay is the delay before the first retry, doubled on every further attempt.
+	webhookRetryBaseDelay = 30 * time.Second
+
+	// webhookRetryMaxDelay caps the delay between two attempts.
+	webhookRetryMaxDelay = 1 * time.Hour
+)
+
+// WebhookDelivery is an outgoing webhook for a playbook run that could not be delivered on the
+// first attempt and is being retried.
+type WebhookDelivery struct {
+	// ID identifies the delivery. It is sent in every attempt so receivers can discard duplicates.
+	ID string `json:"id"`
+
+	// PlaybookRunID is the run that triggered the webhook.
+	PlaybookRunID string `json:"playbook_run_id"`
+
+	// URL is the webhook endpoint.
+	URL string `json:"url"`
+
+	// Payload is the JSON body sent to the endpoint.
+	Payload string `json:"-"`
+
+	// Status is the state of the delivery.
+	Status WebhookDeliveryStatus `json:"status"`
+
+	// Attempts is the number of times the delivery has been attempted so far.
+	Attempts int `json:"attempts"`
+
+	// LastError describes why the last attempt failed.
+	LastError string `json:"last_error"`
+
+	// NextAttemptAt is the time, in milliseconds since epoch, of the next attempt. Only meaningful
+	// for pending deliveries.
+	NextAttemptAt int64 `json:"next_attempt_at"`
+
+	CreateAt int64 `json:"create_at"`
+	UpdateAt int64 `json:"update_at"`
+}
+
+// WebhookDeliveryFilterOptions specifies the parameters when getting webhook deliveries.
+type WebhookDeliveryFilterOptions struct {
+	// Statuses filters by all statuses in the list (inclusive). Defaults to all statuses.
+	Statuses []WebhookDeliveryStatus
+
+	// Pagination options.
+	Page    int
+	PerPage int
+}
+
+// WebhookRetryBackoff returns how long to wait before the next attempt of a delivery that has
+// already been attempted the given number of times.
+func WebhookRetryBackoff(attempts int) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	delay := webhookRetryBaseDelay
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= webhookRetryMaxDelay {
+			return webhookRetryMaxDelay
+		}
+	}
+
+	return delay
+}
+
+// WebhookDeliveryStore defines the methods the WebhookDispatcher needs from the interface layer.
+type WebhookDeliveryStore interface {
+	// CreateWebhookDelivery stores a new delivery.
+	CreateWebhookDelivery(delivery WebhookDelivery) error
+
+	// UpdateWebhookDelivery updates the status, attempts, last error and next attempt of a delivery.
+	UpdateWebhookDelivery(delivery WebhookDelivery) error
+
+	// GetWebhookDeliveries returns the deliveries selected by options, newest first.
+	GetWebhookDeliveries(options WebhookDeliveryFilterOptions) ([]WebhookDelivery, error)
+
+	// GetDueWebhookDeliveries returns up to limit pending deliveries whose next attempt is at or before now.
+	GetDueWebhookDeliveries(now int64, limit int) ([]WebhookDelivery, error)
+}
@@ -0,0 +1,175 @@
+package app
+
+import (
+	"bytes"
+	"net/http"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-api/cluster"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/mattermost/mattermost-plugin-playbooks/server/config"
+	"github.com/mattermost/mattermost-plugin-playbooks/server/metrics"
+)
+
+const (
+	// WebhookDeliveryIDHeader carries the ID of the delivery, identical across all its attempts.
+	WebhookDeliveryIDHeader = "X-Playbooks-Delivery-Id"
+
+	// WebhookRetryPollInterval is how often the WebhookDispatcher looks for deliveries to retry.
+	WebhookRetryPollInterval = 30 * time.Second
+
+	webhookRetryJobKey    = "IR_WebhookRetry"
+	webhookRetryBatchSize = 100
+)
+
+// WebhookDispatcher sends the outgoing webhooks of playbook runs.
+//
+// Every webhook is first attempted right away. Failed deliveries are persisted and retried with
+// exponential backoff by a cluster job, so only one node in the cluster retries them at a time.
+type WebhookDispatcher struct {
+	store          WebhookDeliveryStore
+	configService  config.Service
+	httpClient     *http.Client
+	metricsService *metrics.Metrics
+	job            *cluster.Job
+}
+
+// NewWebhookDispatcher creates a new WebhookDispatcher. Call Start to begin retrying failed deliveries.
+func NewWebhookDispatcher(store WebhookDeliveryStore, configService config.Service, httpClient *http.Client, metricsService *metrics.Metrics) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		store:          store,
+		configService:  configService,
+		httpClient:     httpClient,
+		metricsService: metricsService,
+	}
+}
+
+// Start schedules the retry job.
+func (d *WebhookDispatcher) Start(api cluster.JobPluginAPI) error {
+	job, err := cluster.Schedule(api, webhookRetryJobKey, cluster.MakeWaitForInterval(WebhookRetryPollInterval), d.retryDue)
+	if err != nil {
+		return errors.Wrap(err, "failed to schedule the webhook retry job")
+	}
+	d.job = job
+
+	return nil
+}
+
+// Stop stops retrying failed deliveries.
+func (d *WebhookDispatcher) Stop() error {
+	if d.job == nil {
+		return nil
+	}
+
+	return d.job.Close()
+}
+
+// Dispatch sends body to every URL in the background, queueing the deliveries that fail for retry.
+func (d *WebhookDispatcher) Dispatch(playbookRunID string, urls []string, body []byte) {
+	for i := range urls {
+		delivery := WebhookDelivery{
+			ID:            model.NewId(),
+			PlaybookRunID: playbookRunID,
+			URL:           urls[i],
+			Payload:       string(body),
+		}
+
+		go d.attempt(delivery, true)
+	}
+}
+
+// GetWebhookDeliveries returns the deliveries that failed at least once, selected by options.
+func (d *WebhookDispatcher) GetWebhookDeliveries(options WebhookDeliveryFilterOptions) ([]WebhookDelivery, error) {
+	return d.store.GetWebhookDeliveries(options)
+}
+
+func (d *WebhookDispatcher) retryDue() {
+	due, err := d.store.GetDueWebhookDeliveries(model.GetMillis(), webhookRetryBatchSize)
+	if err != nil {
+		logrus.WithError(err).Error("failed to get webhook deliveries to retry")
+		return
+	}
+
+	for _, delivery := range due {
+		d.attempt(delivery, false)
+	}
+}
+
+// attempt sends the delivery once and records the outcome. First attempts are only persisted
+// when they fail.
+func (d *WebhookDispatcher) attempt(delivery WebhookDelivery, first bool) {
+	logger := logrus.WithFields(logrus.Fields{
+		"webhook_url":         delivery.URL,
+		"webhook_delivery_id": delivery.ID,
+		"playbook_run_id":     delivery.PlaybookRunID,
+	})
+
+	err := d.deliver(delivery)
+	d.metricsService.IncrementWebhookDeliveriesCount(err != nil)
+	if err == nil && first {
+		return
+	}
+
+	now := model.GetMillis()
+	delivery.Attempts++
+	delivery.UpdateAt = now
+
+	if err == nil {
+		delivery.Status = WebhookDeliveryDelivered
+		delivery.LastError = ""
+	} else {
+		logger.WithError(err).Warn("failed to deliver webhook")
+
+		delivery.LastError = err.Error()
+		delivery.Status = WebhookDeliveryPending
+		delivery.NextAttemptAt = now + WebhookRetryBackoff(delivery.Attempts).Milliseconds()
+		if delivery.Attempts >= d.maxAttempts() {
+			delivery.Status = WebhookDeliveryFailed
+			delivery.NextAttemptAt = 0
+		}
+	}
+
+	if first {
+		delivery.CreateAt = now
+		err = d.store.CreateWebhookDelivery(delivery)
+	} else {
+		err = d.store.UpdateWebhookDelivery(delivery)
+	}
+	if err != nil {
+		logger.WithError(err).Error("failed to save webhook delivery")
+	}
+}
+
+func (d *WebhookDispatcher) deliver(delivery WebhookDelivery) error {
+	req, err := http.NewRequest(http.MethodPost, delivery.URL, bytes.NewReader([]byte(delivery.Payload)))
+	if err != nil {
+		return errors.Wrap(err, "failed to create a POST request to webhook URL")
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookDeliveryIDHeader, delivery.ID)
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to send a POST request to webhook URL")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.Errorf("response code is %d; expected a status code in the 2xx range", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (d *WebhookDispatcher) maxAttempts() int {
+	maxAttempts := d.configService.GetConfiguration().WebhookMaxDeliveryAttempts
+	if maxAttempts <= 0 {
+		return DefaultWebhookMaxDeliveryAttempts
+	}
+
+	return maxAttempts
+}
@@ -0,0 +1,170 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-playbooks/server/config"
+)
+
+type fakeConfigService struct {
+	config.Service
+	configuration *config.Configuration
+}
+
+func (f fakeConfigService) GetConfiguration() *config.Configuration {
+	return f.configuration
+}
+
+type fakeWebhookDeliveryStore struct {
+	mutex      sync.Mutex
+	deliveries map[string]WebhookDelivery
+}
+
+func (f *fakeWebhookDeliveryStore) CreateWebhookDelivery(delivery WebhookDelivery) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.deliveries[delivery.ID] = delivery
+	return nil
+}
+
+func (f *fakeWebhookDeliveryStore) UpdateWebhookDelivery(delivery WebhookDelivery) error {
+	return f.CreateWebhookDelivery(delivery)
+}
+
+func (f *fakeWebhookDeliveryStore) GetWebhookDeliveries(options WebhookDeliveryFilterOptions) ([]WebhookDelivery, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	deliveries := []WebhookDelivery{}
+	for _, delivery := range f.deliveries {
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, nil
+}
+
+func (f *fakeWebhookDeliveryStore) GetDueWebhookDeliveries(now int64, limit int) ([]WebhookDelivery, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	deliveries := []WebhookDelivery{}
+	for _, delivery := range f.deliveries {
+		if delivery.Status == WebhookDeliveryPending && delivery.NextAttemptAt <= now {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	return deliveries, nil
+}
+
+func TestWebhookRetryBackoff(t *testing.T) {
+	require.Equal(t, 30*time.Second, WebhookRetryBackoff(0))
+	require.Equal(t, 30*time.Second, WebhookRetryBackoff(1))
+	require.Equal(t, 1*time.Minute, WebhookRetryBackoff(2))
+	require.Equal(t, 2*time.Minute, WebhookRetryBackoff(3))
+	require.Equal(t, 32*time.Minute, WebhookRetryBackoff(7))
+	require.Equal(t, 1*time.Hour, WebhookRetryBackoff(8))
+	require.Equal(t, 1*time.Hour, WebhookRetryBackoff(100))
+}
+
+func TestWebhookDispatcher(t *testing.T) {
+	var mutex sync.Mutex
+	statusCode := http.StatusServiceUnavailable
+	deliveryIDs := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		deliveryIDs = append(deliveryIDs, r.Header.Get(WebhookDeliveryIDHeader))
+		w.WriteHeader(statusCode)
+	}))
+	defer server.Close()
+
+	setStatusCode := func(code int) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		statusCode = code
+	}
+
+	newDispatcher := func(maxAttempts int) (*WebhookDispatcher, *fakeWebhookDeliveryStore) {
+		store := &fakeWebhookDeliveryStore{deliveries: map[string]WebhookDelivery{}}
+		configService := fakeConfigService{configuration: &config.Configuration{WebhookMaxDeliveryAttempts: maxAttempts}}
+		return NewWebhookDispatcher(store, configService, server.Client(), nil), store
+	}
+
+	// makeDue moves the next attempt of every pending delivery to the past.
+	makeDue := func(store *fakeWebhookDeliveryStore) {
+		store.mutex.Lock()
+		defer store.mutex.Unlock()
+		for id, delivery := range store.deliveries {
+			delivery.NextAttemptAt = 0
+			store.deliveries[id] = delivery
+		}
+	}
+
+	getDelivery := func(t *testing.T, store *fakeWebhookDeliveryStore) WebhookDelivery {
+		deliveries, err := store.GetWebhookDeliveries(WebhookDeliveryFilterOptions{})
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		return deliveries[0]
+	}
+
+	t.Run("successful first attempts are not persisted", func(t *testing.T) {
+		setStatusCode(http.StatusOK)
+		dispatcher, store := newDispatcher(3)
+
+		dispatcher.attempt(WebhookDelivery{ID: "delivery1", URL: server.URL, Payload: "{}"}, true)
+
+		deliveries, err := store.GetWebhookDeliveries(WebhookDeliveryFilterOptions{})
+		require.NoError(t, err)
+		require.Empty(t, deliveries)
+	})
+
+	t.Run("failed deliveries are retried with the same delivery id until they succeed", func(t *testing.T) {
+		setStatusCode(http.StatusServiceUnavailable)
+		dispatcher, store := newDispatcher(3)
+
+		dispatcher.attempt(WebhookDelivery{ID: "delivery2", URL: server.URL, Payload: "{}"}, true)
+
+		delivery := getDelivery(t, store)
+		require.Equal(t, WebhookDeliveryPending, delivery.Status)
+		require.Equal(t, 1, delivery.Attempts)
+		require.Contains(t, delivery.LastError, "503")
+		require.Greater(t, delivery.NextAttemptAt, delivery.CreateAt)
+
+		// not due yet
+		dispatcher.retryDue()
+		require.Equal(t, 1, getDelivery(t, store).Attempts)
+
+		setStatusCode(http.StatusOK)
+		makeDue(store)
+		dispatcher.retryDue()
+
+		delivery = getDelivery(t, store)
+		require.Equal(t, WebhookDeliveryDelivered, delivery.Status)
+		require.Equal(t, 2, delivery.Attempts)
+		require.Empty(t, delivery.LastError)
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		require.Equal(t, []string{"delivery2", "delivery2"}, deliveryIDs[len(deliveryIDs)-2:])
+	})
+
+	t.Run("deliveries fail after the maximum number of attempts", func(t *testing.T) {
+		setStatusCode(http.StatusServiceUnavailable)
+		dispatcher, store := newDispatcher(2)
+
+		dispatcher.attempt(WebhookDelivery{ID: "delivery3", URL: server.URL, Payload: "{}"}, true)
+		makeDue(store)
+		dispatcher.retryDue()
+
+		delivery := getDelivery(t, store)
+		require.Equal(t, WebhookDeliveryFailed, delivery.Status)
+		require.Equal(t, 2, delivery.Attempts)
+
+		// failed deliveries are not retried anymore
+		dispatcher.retryDue()
+		require.Equal(t, 2, getDelivery(t, store).Attempts)
+	})
+}
@@ -26,6 +26,7 @@ const helpText = "###### Mattermost Playbooks Plugin - Slash Command Help\n" +
 	"* `/playbook check [checklist #] [item #]` - check/uncheck the checklist item. \n" +
 	"* `/playbook checkadd [checklist #] [item text]` - add a checklist item. \n" +
 	"* `/playbook checkremove [checklist #] [item #]` - remove a checklist item. \n" +
+	"* `/playbook checkfromposts [checklist #] [number of posts]` - add the most recent messages of this channel as checklist items. \n" +
 	"* `/playbook owner [@username]` - Show or change the current owner. \n" +
 	"* `/playbook list` - List all your playbook runs. \n" +
 	"* `/playbook info` - Show a summary of the current playbook run. \n" +
@@ -62,7 +63,7 @@ func getCommand(addTestCommands bool) *model.Command {
 
 func getAutocompleteData(addTestCommands bool) *model.AutocompleteData {
 	command := model.NewAutocompleteData("playbook", "[command]",
-		"Available commands: run, finish, update, check, checkadd, checkremove, list, owner, info, timeline, todo, settings")
+		"Available commands: run, finish, update, check, checkadd, checkremove, checkfromposts, list, owner, info, timeline, todo, settings")
 
 	run := model.NewAutocompleteData("run", "", "Starts a new playbook run")
 	command.AddCommand(run)
@@ -94,8 +95,16 @@ func getAutocompleteData(addTestCommands bool) *model.AutocompleteData {
 		"List of checklist items is loading",
 		"api/v0/runs/checklist-autocomplete-item", true)
 
+	itemsFromPosts := model.NewAutocompleteData("checkfromposts", "[checklist] [number of posts]",
+		"Add the most recent messages of this channel as checklist items")
+	itemsFromPosts.AddDynamicListArgument(
+		"List of checklist items is loading",
+		"api/v0/runs/checklist-autocomplete", true)
+	itemsFromPosts.AddTextArgument(fmt.Sprintf("The number of recent messages to add, at most %d.", app.MaxChecklistItemsFromPosts), "[number of posts]", "")
+
 	command.AddCommand(itemAdd)
 	command.AddCommand(itemRemove)
+	command.AddCommand(itemsFromPosts)
 
 	list := model.NewAutocompleteData("list", "", "Lists all your playbook runs")
 	command.AddCommand(list)
@@ -356,7 +365,9 @@ func (r *Runner) actionCheck(args []string) {
 	}
 
 	err = r.playbookRunService.ToggleCheckedState(playbookRunID, r.args.UserId, checklist, item)
-	if err != nil {
+	if errors.Is(err, app.ErrChecklistItemBlocked) {
+		r.postCommandResponse("This item depends on another item. Check off or skip that item first.")
+	} else if err != nil {
 		r.warnUserAndLogErrorf("Error checking/unchecking item: %v", err)
 	}
 }
@@ -402,6 +413,58 @@ func (r *Runner) actionAddChecklistItem(args []string) {
 
 }
 
+func (r *Runner) actionAddChecklistItemsFromPosts(args []string) {
+	if len(args) != 2 {
+		r.postCommandResponse("Command expects two arguments: the checklist number and the number of messages.")
+		return
+	}
+
+	checklist, err := strconv.Atoi(args[0])
+	if err != nil {
+		r.postCommandResponse("Error parsing the first argument. Must be a number.")
+		return
+	}
+
+	numPosts, err := strconv.Atoi(args[1])
+	if err != nil || numPosts <= 0 || numPosts > app.MaxChecklistItemsFromPosts {
+		r.postCommandResponse(fmt.Sprintf("Error parsing the second argument. Must be a number between 1 and %d.", app.MaxChecklistItemsFromPosts))
+		return
+	}
+
+	playbookRunID, err := r.playbookRunService.GetPlaybookRunIDForChannel(r.args.ChannelId)
+	if err != nil {
+		if errors.Is(err, app.ErrNotFound) {
+			r.postCommandResponse("This command only works when run from a playbook run channel.")
+			return
+		}
+		r.warnUserAndLogErrorf("Error retrieving playbook run: %v", err)
+		return
+	}
+
+	if err = r.permissions.RunManageProperties(r.args.UserId, playbookRunID); err != nil {
+		if errors.Is(err, app.ErrNoPermissions) {
+			r.postCommandResponse(fmt.Sprintf("userID `%s` is not an admin or channel member", r.args.UserId))
+			return
+		}
+		r.warnUserAndLogErrorf("Error retrieving playbook run: %v", err)
+		return
+	}
+
+	result, err := r.playbookRunService.AddChecklistItemsFromPosts(playbookRunID, r.args.UserId, checklist, app.AddChecklistItemsFromPostsOptions{
+		RecentPosts: numPosts,
+	})
+	if err != nil {
+		r.warnUserAndLogErrorf("Error adding items from messages: %v", err)
+		return
+	}
+
+	response := fmt.Sprintf("Added %d checklist items.", result.ItemsAdded)
+	if result.PostsSkipped > 0 {
+		response += fmt.Sprintf(" Skipped %d messages that were system messages, had no text or could not be read.", result.PostsSkipped)
+	}
+	r.postCommandResponse(response)
+}
+
 func (r *Runner) actionRemoveChecklistItem(args []string) {
 	if len(args) != 2 {
 		r.postCommandResponse("Command expects two arguments: the checklist number and the item number.")
@@ -635,6 +698,9 @@ func (r *Runner) actionInfo() {
 	tasks := ""
 	for _, checklist := range playbookRun.Checklists {
 		for _, item := range checklist.Items {
+			if item.Hidden {
+				continue
+			}
 			icon := ":white_large_square: "
 			timestamp := ""
 			if item.State == app.ChecklistItemStateClosed {
@@ -682,6 +748,16 @@ func (r *Runner) actionFinish() {
 		return
 	}
 
+	playbookRun, err := r.playbookRunService.GetPlaybookRun(playbookRunID)
+	if err != nil {
+		r.warnUserAndLogErrorf("Error retrieving playbook run: %v", err)
+		return
+	}
+	if playbookRun.MissingRequiredRetrospective() {
+		r.postCommandResponse("This run requires a retrospective. Publish it before finishing the run.")
+		return
+	}
+
 	err = r.playbookRunService.OpenFinishPlaybookRunDialog(playbookRunID, r.args.TriggerId)
 	if err != nil {
 		r.warnUserAndLogErrorf("Error finishing the playbook run: %v", err)
@@ -1120,12 +1196,12 @@ And... yes, of course, we have emojis
 		return
 	}
 
-	if err := r.playbookRunService.ModifyCheckedState(playbookRun.ID, r.args.UserId, app.ChecklistItemStateClosed, 0, 0); err != nil {
+	if err := r.playbookRunService.ModifyCheckedState(playbookRun.ID, r.args.UserId, app.ChecklistItemStateClosed, "", 0, 0); err != nil {
 		r.postCommandResponse("Unable to modify checked state: " + err.Error())
 		return
 	}
 
-	if err := r.playbookRunService.ModifyCheckedState(playbookRun.ID, r.args.UserId, app.ChecklistItemStateOpen, 0, 2); err != nil {
+	if err := r.playbookRunService.ModifyCheckedState(playbookRun.ID, r.args.UserId, app.ChecklistItemStateOpen, "", 0, 2); err != nil {
 		r.postCommandResponse("Unable to modify checked state: " + err.Error())
 		return
 	}
@@ -1911,6 +1987,8 @@ func (r *Runner) Execute() error {
 		r.actionAddChecklistItem(parameters)
 	case "checkremove":
 		r.actionRemoveChecklistItem(parameters)
+	case "checkfromposts":
+		r.actionAddChecklistItemsFromPosts(parameters)
 	case "owner":
 		r.actionOwner(parameters)
 	case "list":
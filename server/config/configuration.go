@@ -21,6 +21,18 @@ type Configuration struct {
 	// LinkRunToExistingChannelEnabled determines if run link to existing channels is enabled.
 	LinkRunToExistingChannelEnabled bool
 
+	// WebhookMaxDeliveryAttempts is the number of times an outgoing webhook is attempted before
+	// giving up on it. Defaults to 5 when not set.
+	WebhookMaxDeliveryAttempts int
+
+	// MaxPlaybookVersions is the number of past versions kept for each playbook. Defaults to 50
+	// when not set.
+	MaxPlaybookVersions int
+
+	// DueReminderMinutes is how long, in minutes, before a checklist item is due its assignee is
+	// reminded by DM. Defaults to 60 when not set.
+	DueReminderMinutes int
+
 	// ** The following are NOT stored on the server
 	// AdminUserIDs contains a list of user IDs that are allowed
 	// to administer plugin functions, even if not Mattermost sysadmins.
@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"os"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
@@ -14,6 +15,12 @@ const (
 	MetricsSubsystemSystem    = "system"
 
 	MetricsCloudInstallationLabel = "installationId"
+
+	// MetricsWebhookResultLabel tells whether a webhook delivery attempt succeeded or failed.
+	MetricsWebhookResultLabel = "result"
+
+	webhookResultSuccess = "success"
+	webhookResultFailure = "failure"
 )
 
 type InstanceInfo struct {
@@ -32,8 +39,12 @@ type Metrics struct {
 	playbooksRestoredCount prometheus.Counter
 	runsCreatedCount       prometheus.Counter
 	runsFinishedCount      prometheus.Counter
+	statusUpdatesCount     prometheus.Counter
+	webhookDeliveriesCount *prometheus.CounterVec
 	errorsCount            prometheus.Counter
 
+	runDurationSeconds prometheus.Histogram
+
 	playbooksActiveTotal      prometheus.Gauge
 	runsActiveTotal           prometheus.Gauge
 	remindersOutstandingTotal prometheus.Gauge
@@ -113,6 +124,38 @@ func NewMetrics(info InstanceInfo) *Metrics {
 	})
 	m.registry.MustRegister(m.runsFinishedCount)
 
+	m.statusUpdatesCount = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace:   MetricsNamespace,
+		Subsystem:   MetricsSubsystemRuns,
+		Name:        "status_updates_posted_count",
+		Help:        "Number of status updates posted since the last launch.",
+		ConstLabels: additionalLabels,
+	})
+	m.registry.MustRegister(m.statusUpdatesCount)
+
+	m.webhookDeliveriesCount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace:   MetricsNamespace,
+		Subsystem:   MetricsSubsystemRuns,
+		Name:        "webhook_deliveries_count",
+		Help:        "Number of webhook delivery attempts since the last launch, by result.",
+		ConstLabels: additionalLabels,
+	}, []string{MetricsWebhookResultLabel})
+	m.registry.MustRegister(m.webhookDeliveriesCount)
+	// Both results are exported from the start, so that rates don't miss the first attempts.
+	m.webhookDeliveriesCount.WithLabelValues(webhookResultSuccess)
+	m.webhookDeliveriesCount.WithLabelValues(webhookResultFailure)
+
+	m.runDurationSeconds = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace:   MetricsNamespace,
+		Subsystem:   MetricsSubsystemRuns,
+		Name:        "run_duration_seconds",
+		Help:        "Duration of the runs finished since the last launch, excluding the time they were paused.",
+		ConstLabels: additionalLabels,
+		// From a minute to about 11 days.
+		Buckets: prometheus.ExponentialBuckets(60, 4, 8),
+	})
+	m.registry.MustRegister(m.runDurationSeconds)
+
 	m.errorsCount = prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace:   MetricsNamespace,
 		Subsystem:   MetricsSubsystemSystem,
@@ -208,6 +251,30 @@ func (m *Metrics) IncrementRunsFinishedCount(num int) {
 	}
 }
 
+func (m *Metrics) IncrementStatusUpdatesCount(num int) {
+	if m != nil {
+		m.statusUpdatesCount.Add(float64(num))
+	}
+}
+
+// IncrementWebhookDeliveriesCount counts an attempt to deliver a webhook, which succeeded unless
+// failed is true.
+func (m *Metrics) IncrementWebhookDeliveriesCount(failed bool) {
+	if m != nil {
+		result := webhookResultSuccess
+		if failed {
+			result = webhookResultFailure
+		}
+		m.webhookDeliveriesCount.WithLabelValues(result).Inc()
+	}
+}
+
+func (m *Metrics) ObserveRunDuration(duration time.Duration) {
+	if m != nil {
+		m.runDurationSeconds.Observe(duration.Seconds())
+	}
+}
+
 func (m *Metrics) IncrementErrorsCount(num int) {
 	if m != nil {
 		m.errorsCount.Add(float64(num))
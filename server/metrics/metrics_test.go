@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+)
+
+func gatherMetric(t *testing.T, m *Metrics, name string) []*dto.Metric {
+	t.Helper()
+
+	families, err := m.registry.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() == name {
+			return family.GetMetric()
+		}
+	}
+
+	require.Failf(t, "metric not found", "no metric named %s", name)
+	return nil
+}
+
+func TestMetrics_RunLifecycle(t *testing.T) {
+	m := NewMetrics(InstanceInfo{Version: "test"})
+
+	// Scrapes happen while the counters are incremented. Their errors are checked once they are
+	// done, since the test can only fail from its own goroutine.
+	var wg sync.WaitGroup
+	gatherErrs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			m.IncrementStatusUpdatesCount(1)
+			m.IncrementWebhookDeliveriesCount(false)
+			m.IncrementWebhookDeliveriesCount(true)
+			m.ObserveRunDuration(90 * time.Minute)
+		}()
+		go func() {
+			defer wg.Done()
+			_, err := m.registry.Gather()
+			gatherErrs <- err
+		}()
+	}
+	wg.Wait()
+	close(gatherErrs)
+	for err := range gatherErrs {
+		require.NoError(t, err)
+	}
+
+	statusUpdates := gatherMetric(t, m, "playbooks_plugin_runs_status_updates_posted_count")
+	require.Equal(t, float64(10), statusUpdates[0].GetCounter().GetValue())
+
+	deliveries := gatherMetric(t, m, "playbooks_plugin_runs_webhook_deliveries_count")
+	require.Len(t, deliveries, 2)
+	for _, delivery := range deliveries {
+		require.Len(t, delivery.GetLabel(), 1)
+		require.Equal(t, MetricsWebhookResultLabel, delivery.GetLabel()[0].GetName())
+		require.Equal(t, float64(10), delivery.GetCounter().GetValue())
+	}
+
+	durations := gatherMetric(t, m, "playbooks_plugin_runs_run_duration_seconds")
+	require.Equal(t, uint64(10), durations[0].GetHistogram().GetSampleCount())
+	require.Equal(t, float64(10*90*60), durations[0].GetHistogram().GetSampleSum())
+}
+
+func TestMetrics_Nil(t *testing.T) {
+	var m *Metrics
+
+	require.NotPanics(t, func() {
+		m.IncrementStatusUpdatesCount(1)
+		m.IncrementWebhookDeliveriesCount(true)
+		m.ObserveRunDuration(time.Minute)
+	})
+}
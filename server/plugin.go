@@ -12,6 +12,7 @@ import (
 	"github.com/mattermost/mattermost-plugin-playbooks/server/command"
 	"github.com/mattermost/mattermost-plugin-playbooks/server/config"
 	"github.com/mattermost/mattermost-plugin-playbooks/server/enterprise"
+	"github.com/mattermost/mattermost-plugin-playbooks/server/httptools"
 	"github.com/mattermost/mattermost-plugin-playbooks/server/metrics"
 	"github.com/mattermost/mattermost-plugin-playbooks/server/scheduler"
 	"github.com/mattermost/mattermost-plugin-playbooks/server/sqlstore"
@@ -80,6 +81,14 @@ type Plugin struct {
 	telemetryClient      TelemetryClient
 	licenseChecker       app.LicenseChecker
 	metricsService       *metrics.Metrics
+	runScheduler         *app.RunScheduler
+	followerDigest       *app.FollowerDigest
+	runIdempotency       *app.RunIdempotencyService
+	webhookDispatcher    *app.WebhookDispatcher
+	channelAutoArchiver  *app.ChannelAutoArchiver
+	dueReminders         *app.DueReminderScheduler
+	runEvents            *app.RunEventBroker
+	savedFilters         *app.SavedFilterService
 }
 
 type StatusRecorder struct {
@@ -92,6 +101,13 @@ func (r *StatusRecorder) WriteHeader(status int) {
 	r.ResponseWriter.WriteHeader(status)
 }
 
+// Flush sends the buffered data to the client, for the handlers streaming their response.
+func (r *StatusRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
 // ServeHTTP routes incoming HTTP requests to the plugin's REST API.
 func (p *Plugin) ServeHTTP(c *plugin.Context, w http.ResponseWriter, r *http.Request) {
 	p.handler.ServeHTTP(w, r)
@@ -183,10 +199,16 @@ func (p *Plugin) OnActivate() error {
 	p.userInfoStore = sqlstore.NewUserInfoStore(sqlStore)
 	channelActionStore := sqlstore.NewChannelActionStore(apiClient, sqlStore)
 	categoryStore := sqlstore.NewCategoryStore(apiClient, sqlStore)
+	scheduledRunStore := sqlstore.NewScheduledRunStore(sqlStore)
+	webhookDeliveryStore := sqlstore.NewWebhookDeliveryStore(sqlStore)
+	runIdempotencyKeyStore := sqlstore.NewRunIdempotencyKeyStore(sqlStore)
+	playbookVersionStore := sqlstore.NewPlaybookVersionStore(sqlStore)
+	dueReminderStore := sqlstore.NewDueReminderStore(sqlStore)
+	savedFilterStore := sqlstore.NewSavedFilterStore(sqlStore)
 
 	p.handler = api.NewHandler(pluginAPIClient, p.config)
 
-	p.playbookService = app.NewPlaybookService(playbookStore, p.bot, p.telemetryClient, pluginAPIClient, p.metricsService)
+	p.playbookService = app.NewPlaybookService(playbookStore, playbookVersionStore, p.bot, p.telemetryClient, pluginAPIClient, p.config, p.metricsService)
 
 	keywordsThreadIgnorer := app.NewKeywordsThreadIgnorer()
 	p.channelActionService = app.NewChannelActionsService(pluginAPIClient, p.bot, p.config, channelActionStore, p.playbookService, keywordsThreadIgnorer, p.telemetryClient)
@@ -194,6 +216,11 @@ func (p *Plugin) OnActivate() error {
 
 	p.licenseChecker = enterprise.NewLicenseChecker(pluginAPIClient)
 
+	p.webhookDispatcher = app.NewWebhookDispatcher(webhookDeliveryStore, p.config, httptools.MakeClient(pluginAPIClient), p.metricsService)
+
+	p.dueReminders = app.NewDueReminderScheduler(dueReminderStore, playbookRunStore, p.config, p.bot, pluginAPIClient)
+	p.runEvents = app.NewRunEventBroker(p.API)
+
 	p.playbookRunService = app.NewPlaybookRunService(
 		pluginAPIClient,
 		playbookRunStore,
@@ -207,6 +234,9 @@ func (p *Plugin) OnActivate() error {
 		p.channelActionService,
 		p.licenseChecker,
 		p.metricsService,
+		p.webhookDispatcher,
+		p.dueReminders,
+		p.runEvents,
 	)
 
 	if err = scheduler.SetCallback(p.playbookRunService.HandleReminder); err != nil {
@@ -228,8 +258,42 @@ func (p *Plugin) OnActivate() error {
 	}
 	mutex.Unlock()
 
+	if err = p.playbookRunService.RestoreReminders(); err != nil {
+		logrus.WithError(err).Error("failed to restore the status update reminders")
+	}
+
 	p.permissions = app.NewPermissionsService(p.playbookService, p.playbookRunService, pluginAPIClient, p.config, p.licenseChecker)
 
+	p.runScheduler = app.NewRunScheduler(scheduledRunStore, p.playbookService, p.playbookRunService, p.permissions, p.bot, pluginAPIClient)
+	if err = p.runScheduler.Start(p.API); err != nil {
+		logrus.WithError(err).Error("RunScheduler could not start")
+	}
+
+	p.followerDigest = app.NewFollowerDigest(playbookRunStore, p.userInfoStore, p.permissions, p.bot, pluginAPIClient)
+	if err = p.followerDigest.Start(p.API); err != nil {
+		logrus.WithError(err).Error("FollowerDigest could not start")
+	}
+
+	if err = p.webhookDispatcher.Start(p.API); err != nil {
+		logrus.WithError(err).Error("WebhookDispatcher could not start")
+	}
+
+	p.runIdempotency = app.NewRunIdempotencyService(runIdempotencyKeyStore)
+	if err = p.runIdempotency.Start(p.API); err != nil {
+		logrus.WithError(err).Error("RunIdempotencyService could not start")
+	}
+
+	p.channelAutoArchiver = app.NewChannelAutoArchiver(playbookRunStore, p.config, pluginAPIClient)
+	if err = p.channelAutoArchiver.Start(p.API); err != nil {
+		logrus.WithError(err).Error("ChannelAutoArchiver could not start")
+	}
+
+	if err = p.dueReminders.Start(p.API); err != nil {
+		logrus.WithError(err).Error("DueReminderScheduler could not start")
+	}
+
+	p.savedFilters = app.NewSavedFilterService(savedFilterStore, p.playbookService, pluginAPIClient)
+
 	// register collections and topics.
 	// TODO bump the minimum server version
 	if err := p.API.RegisterCollectionAndTopic(CollectionTypeRun, TopicTypeStatus); err != nil {
@@ -256,6 +320,7 @@ func (p *Plugin) OnActivate() error {
 		pluginAPIClient,
 		p.config,
 		p.permissions,
+		p.runScheduler,
 	)
 	api.NewPlaybookRunHandler(
 		p.handler.APIRouter,
@@ -263,9 +328,12 @@ func (p *Plugin) OnActivate() error {
 		p.playbookService,
 		p.permissions,
 		p.licenseChecker,
+		p.runIdempotency,
 		pluginAPIClient,
 		p.bot,
 		p.config,
+		p.runEvents,
+		p.savedFilters,
 	)
 	api.NewStatsHandler(p.handler.APIRouter, pluginAPIClient, statsStore, p.playbookService, p.permissions, p.licenseChecker)
 	api.NewBotHandler(p.handler.APIRouter, pluginAPIClient, p.bot, p.config, p.playbookRunService, p.userInfoStore)
@@ -274,6 +342,8 @@ func (p *Plugin) OnActivate() error {
 	api.NewSettingsHandler(p.handler.APIRouter, pluginAPIClient, p.config)
 	api.NewActionsHandler(p.handler.APIRouter, p.channelActionService, p.pluginAPI, p.permissions)
 	api.NewCategoryHandler(p.handler.APIRouter, pluginAPIClient, p.categoryService, p.playbookService, p.playbookRunService)
+	api.NewWebhookDeliveryHandler(p.handler.APIRouter, pluginAPIClient, p.webhookDispatcher)
+	api.NewDueReminderHandler(p.handler.APIRouter, pluginAPIClient, p.dueReminders)
 
 	isTestingEnabled := false
 	flag := p.API.GetConfig().ServiceSettings.EnableTesting
@@ -304,6 +374,47 @@ func (p *Plugin) OnActivate() error {
 	return nil
 }
 
+// OnDeactivate Called when this plugin is deactivated.
+func (p *Plugin) OnDeactivate() error {
+	if p.runScheduler != nil {
+		if err := p.runScheduler.Stop(); err != nil {
+			logrus.WithError(err).Warn("RunScheduler could not be stopped")
+		}
+	}
+
+	if p.followerDigest != nil {
+		if err := p.followerDigest.Stop(); err != nil {
+			logrus.WithError(err).Warn("FollowerDigest could not be stopped")
+		}
+	}
+
+	if p.webhookDispatcher != nil {
+		if err := p.webhookDispatcher.Stop(); err != nil {
+			logrus.WithError(err).Warn("WebhookDispatcher could not be stopped")
+		}
+	}
+
+	if p.runIdempotency != nil {
+		if err := p.runIdempotency.Stop(); err != nil {
+			logrus.WithError(err).Warn("RunIdempotencyService could not be stopped")
+		}
+	}
+
+	if p.channelAutoArchiver != nil {
+		if err := p.channelAutoArchiver.Stop(); err != nil {
+			logrus.WithError(err).Warn("ChannelAutoArchiver could not be stopped")
+		}
+	}
+
+	if p.dueReminders != nil {
+		if err := p.dueReminders.Stop(); err != nil {
+			logrus.WithError(err).Warn("DueReminderScheduler could not be stopped")
+		}
+	}
+
+	return nil
+}
+
 // OnConfigurationChange handles any change in the configuration.
 func (p *Plugin) OnConfigurationChange() error {
 	if p.config == nil {
@@ -337,6 +448,15 @@ func (p *Plugin) MessageHasBeenPosted(c *plugin.Context, post *model.Post) {
 	p.channelActionService.MessageHasBeenPosted(c.SessionId, post)
 }
 
+// OnPluginClusterEvent handles the events sent by the other servers of the cluster.
+func (p *Plugin) OnPluginClusterEvent(c *plugin.Context, ev model.PluginClusterEvent) {
+	if ev.Id == app.RunEventClusterEventID && p.runEvents != nil {
+		if err := p.runEvents.HandleClusterEvent(ev); err != nil {
+			logrus.WithError(err).Warn("failed to handle a run event from the cluster")
+		}
+	}
+}
+
 func (p *Plugin) newMetricsInstance() *metrics.Metrics {
 	// Init metrics
 	instanceInfo := metrics.InstanceInfo{
@@ -0,0 +1,143 @@
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/mattermost-plugin-playbooks/server/app"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/pkg/errors"
+)
+
+// dueReminderStore is a sql store for the reminders of checklist items due soon. Use
+// NewDueReminderStore to create it.
+type dueReminderStore struct {
+	store             *SQLStore
+	dueReminderSelect sq.SelectBuilder
+}
+
+// Ensure dueReminderStore implements the app.DueReminderStore interface.
+var _ app.DueReminderStore = (*dueReminderStore)(nil)
+
+// NewDueReminderStore creates a new store for the reminders of checklist items due soon.
+func NewDueReminderStore(sqlStore *SQLStore) app.DueReminderStore {
+	dueReminderSelect := sqlStore.builder.
+		Select(
+			"r.PlaybookRunID",
+			"r.ChecklistItemID",
+			"r.UserID",
+			"r.DueDate",
+			"r.RemindAt",
+			"r.SentAt",
+			"r.CreateAt",
+			"r.UpdateAt",
+		).
+		From("IR_DueReminder r")
+
+	return &dueReminderStore{
+		store:             sqlStore,
+		dueReminderSelect: dueReminderSelect,
+	}
+}
+
+// GetDueReminder returns the reminder of a checklist item. Returns ErrNotFound if not found.
+func (s *dueReminderStore) GetDueReminder(playbookRunID, checklistItemID string) (app.DueReminder, error) {
+	var reminder app.DueReminder
+	err := s.store.getBuilder(s.store.db, &reminder, s.dueReminderSelect.
+		Where(sq.Eq{"r.PlaybookRunID": playbookRunID, "r.ChecklistItemID": checklistItemID}))
+	if err == sql.ErrNoRows {
+		return app.DueReminder{}, errors.Wrapf(app.ErrNotFound, "checklist item `%s` of run `%s` has no due reminder", checklistItemID, playbookRunID)
+	} else if err != nil {
+		return app.DueReminder{}, errors.Wrapf(err, "failed to get due reminder of checklist item `%s`", checklistItemID)
+	}
+
+	return reminder, nil
+}
+
+// UpsertDueReminder stores the reminder, replacing the one of the same item if any.
+func (s *dueReminderStore) UpsertDueReminder(reminder app.DueReminder) error {
+	if reminder.PlaybookRunID == "" || reminder.ChecklistItemID == "" {
+		return errors.New("PlaybookRunID and ChecklistItemID should not be empty")
+	}
+
+	query := sq.
+		Insert("IR_DueReminder").
+		SetMap(map[string]interface{}{
+			"PlaybookRunID":   reminder.PlaybookRunID,
+			"ChecklistItemID": reminder.ChecklistItemID,
+			"UserID":          reminder.UserID,
+			"DueDate":         reminder.DueDate,
+			"RemindAt":        reminder.RemindAt,
+			"SentAt":          reminder.SentAt,
+			"CreateAt":        reminder.CreateAt,
+			"UpdateAt":        reminder.UpdateAt,
+		})
+
+	var err error
+	if s.store.db.DriverName() == model.DatabaseDriverMysql {
+		_, err = s.store.execBuilder(s.store.db, query.
+			Suffix("ON DUPLICATE KEY UPDATE UserID = ?, DueDate = ?, RemindAt = ?, SentAt = ?, UpdateAt = ?",
+				reminder.UserID, reminder.DueDate, reminder.RemindAt, reminder.SentAt, reminder.UpdateAt))
+	} else {
+		_, err = s.store.execBuilder(s.store.db, query.
+			Suffix("ON CONFLICT (PlaybookRunID,ChecklistItemID) DO UPDATE SET UserID = ?, DueDate = ?, RemindAt = ?, SentAt = ?, UpdateAt = ?",
+				reminder.UserID, reminder.DueDate, reminder.RemindAt, reminder.SentAt, reminder.UpdateAt))
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to store due reminder of checklist item `%s`", reminder.ChecklistItemID)
+	}
+
+	return nil
+}
+
+// DeleteDueReminder removes the reminder of a checklist item, if any.
+func (s *dueReminderStore) DeleteDueReminder(playbookRunID, checklistItemID string) error {
+	if _, err := s.store.execBuilder(s.store.db, sq.
+		Delete("IR_DueReminder").
+		Where(sq.Eq{"PlaybookRunID": playbookRunID, "ChecklistItemID": checklistItemID})); err != nil {
+		return errors.Wrapf(err, "failed to delete due reminder of checklist item `%s`", checklistItemID)
+	}
+
+	return nil
+}
+
+// GetPendingDueReminders returns up to limit reminders not sent yet whose time is at or before
+// now, in millis, the earliest first.
+func (s *dueReminderStore) GetPendingDueReminders(now int64, limit int) ([]app.DueReminder, error) {
+	var reminders []app.DueReminder
+	err := s.store.selectBuilder(s.store.db, &reminders, s.dueReminderSelect.
+		Where(sq.Eq{"r.SentAt": 0}).
+		Where(sq.LtOrEq{"r.RemindAt": now}).
+		OrderBy("r.RemindAt ASC").
+		Limit(uint64(limit)))
+	if err != nil && err != sql.ErrNoRows {
+		return nil, errors.Wrap(err, "failed to get pending due reminders")
+	}
+
+	return reminders, nil
+}
+
+// ClaimDueReminder marks the reminder as sent at sentAt, unless it was sent, snoozed or moved
+// since it was read. Returns whether this call claimed it.
+func (s *dueReminderStore) ClaimDueReminder(reminder app.DueReminder, sentAt int64) (bool, error) {
+	result, err := s.store.execBuilder(s.store.db, sq.
+		Update("IR_DueReminder").
+		Set("SentAt", sentAt).
+		Where(sq.Eq{
+			"PlaybookRunID":   reminder.PlaybookRunID,
+			"ChecklistItemID": reminder.ChecklistItemID,
+			"UserID":          reminder.UserID,
+			"RemindAt":        reminder.RemindAt,
+			"SentAt":          0,
+		}))
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to claim due reminder of checklist item `%s`", reminder.ChecklistItemID)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get rows affected")
+	}
+
+	return rows == 1, nil
+}
@@ -0,0 +1,97 @@
+package sqlstore
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-plugin-playbooks/server/app"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDueReminders(t *testing.T) {
+	for _, driverName := range driverNames {
+		db := setupTestDB(t, driverName)
+		sqlStore := setupSQLStore(t, db)
+		dueReminderStore := NewDueReminderStore(sqlStore)
+
+		newReminder := func(remindAt int64) app.DueReminder {
+			return app.DueReminder{
+				PlaybookRunID:   model.NewId(),
+				ChecklistItemID: model.NewId(),
+				UserID:          model.NewId(),
+				DueDate:         remindAt + 3600000,
+				RemindAt:        remindAt,
+				CreateAt:        100,
+				UpdateAt:        100,
+			}
+		}
+
+		earlier := newReminder(1000)
+		later := newReminder(2000)
+		notYet := newReminder(5000)
+		for _, reminder := range []app.DueReminder{later, earlier, notYet} {
+			require.NoError(t, dueReminderStore.UpsertDueReminder(reminder))
+		}
+
+		t.Run("get reminder", func(t *testing.T) {
+			reminder, err := dueReminderStore.GetDueReminder(earlier.PlaybookRunID, earlier.ChecklistItemID)
+			require.NoError(t, err)
+			require.Equal(t, earlier, reminder)
+
+			_, err = dueReminderStore.GetDueReminder(earlier.PlaybookRunID, model.NewId())
+			require.ErrorIs(t, err, app.ErrNotFound)
+		})
+
+		t.Run("get pending reminders, earliest first", func(t *testing.T) {
+			reminders, err := dueReminderStore.GetPendingDueReminders(3000, 10)
+			require.NoError(t, err)
+			require.Equal(t, []app.DueReminder{earlier, later}, reminders)
+		})
+
+		t.Run("upsert replaces the reminder of the item", func(t *testing.T) {
+			later.UserID = model.NewId()
+			later.RemindAt = 6000
+			later.UpdateAt = 200
+			require.NoError(t, dueReminderStore.UpsertDueReminder(later))
+
+			reminder, err := dueReminderStore.GetDueReminder(later.PlaybookRunID, later.ChecklistItemID)
+			require.NoError(t, err)
+			require.Equal(t, later, reminder)
+
+			reminders, err := dueReminderStore.GetPendingDueReminders(3000, 10)
+			require.NoError(t, err)
+			require.Equal(t, []app.DueReminder{earlier}, reminders)
+		})
+
+		t.Run("claim once", func(t *testing.T) {
+			claimed, err := dueReminderStore.ClaimDueReminder(earlier, 3000)
+			require.NoError(t, err)
+			require.True(t, claimed)
+
+			claimed, err = dueReminderStore.ClaimDueReminder(earlier, 3001)
+			require.NoError(t, err)
+			require.False(t, claimed)
+
+			reminders, err := dueReminderStore.GetPendingDueReminders(3000, 10)
+			require.NoError(t, err)
+			require.Empty(t, reminders)
+		})
+
+		t.Run("claim fails if the reminder was snoozed", func(t *testing.T) {
+			snoozed := notYet
+			snoozed.RemindAt = 9000
+			require.NoError(t, dueReminderStore.UpsertDueReminder(snoozed))
+
+			claimed, err := dueReminderStore.ClaimDueReminder(notYet, 9000)
+			require.NoError(t, err)
+			require.False(t, claimed)
+		})
+
+		t.Run("delete", func(t *testing.T) {
+			require.NoError(t, dueReminderStore.DeleteDueReminder(later.PlaybookRunID, later.ChecklistItemID))
+
+			_, err := dueReminderStore.GetDueReminder(later.PlaybookRunID, later.ChecklistItemID)
+			require.ErrorIs(t, err, app.ErrNotFound)
+		})
+	}
+}
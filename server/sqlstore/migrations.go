@@ -2455,4 +2455,785 @@ var migrations = []Migration{
 			return nil
 		},
 	},
+	{
+		fromVersion: semver.MustParse("0.62.0"),
+		toVersion:   semver.MustParse("0.63.0"),
+		migrationFunc: func(e sqlx.Ext, sqlStore *SQLStore) error {
+			if e.DriverName() == model.DatabaseDriverMysql {
+				if _, err := e.Exec(`
+					CREATE TABLE IF NOT EXISTS IR_ScheduledRun (
+						ID VARCHAR(26) PRIMARY KEY,
+						PlaybookID VARCHAR(26) NOT NULL REFERENCES IR_Playbook(ID),
+						CreatorUserID VARCHAR(26) NOT NULL,
+						Frequency VARCHAR(32) NOT NULL,
+						StartTime BIGINT NOT NULL,
+						Timezone VARCHAR(64) NOT NULL,
+						Enabled BOOLEAN DEFAULT TRUE,
+						LastRunAt BIGINT NOT NULL DEFAULT 0,
+						NextRunAt BIGINT NOT NULL DEFAULT 0,
+						CreateAt BIGINT NOT NULL,
+						UpdateAt BIGINT NOT NULL DEFAULT 0,
+						INDEX IR_ScheduledRun_PlaybookID (PlaybookID),
+						INDEX IR_ScheduledRun_Enabled_NextRunAt (Enabled, NextRunAt)
+					)
+				` + MySQLCharset); err != nil {
+					return errors.Wrapf(err, "failed creating table IR_ScheduledRun")
+				}
+			} else {
+				if _, err := e.Exec(`
+					CREATE TABLE IF NOT EXISTS IR_ScheduledRun (
+						ID VARCHAR(26) PRIMARY KEY,
+						PlaybookID VARCHAR(26) NOT NULL REFERENCES IR_Playbook(ID),
+						CreatorUserID VARCHAR(26) NOT NULL,
+						Frequency VARCHAR(32) NOT NULL,
+						StartTime BIGINT NOT NULL,
+						Timezone VARCHAR(64) NOT NULL,
+						Enabled BOOLEAN DEFAULT TRUE,
+						LastRunAt BIGINT NOT NULL DEFAULT 0,
+						NextRunAt BIGINT NOT NULL DEFAULT 0,
+						CreateAt BIGINT NOT NULL,
+						UpdateAt BIGINT NOT NULL DEFAULT 0
+					)
+				`); err != nil {
+					return errors.Wrapf(err, "failed creating table IR_ScheduledRun")
+				}
+
+				if _, err := e.Exec(createPGIndex("IR_ScheduledRun_PlaybookID", "IR_ScheduledRun", "PlaybookID")); err != nil {
+					return errors.Wrapf(err, "failed creating index IR_ScheduledRun_PlaybookID")
+				}
+
+				if _, err := e.Exec(createPGIndex("IR_ScheduledRun_Enabled_NextRunAt", "IR_ScheduledRun", "Enabled, NextRunAt")); err != nil {
+					return errors.Wrapf(err, "failed creating index IR_ScheduledRun_Enabled_NextRunAt")
+				}
+			}
+
+			return nil
+		},
+	},
+	{
+		fromVersion: semver.MustParse("0.63.0"),
+		toVersion:   semver.MustParse("0.64.0"),
+		migrationFunc: func(e sqlx.Ext, sqlStore *SQLStore) error {
+			if e.DriverName() == model.DatabaseDriverMysql {
+				if _, err := e.Exec(`
+					CREATE TABLE IF NOT EXISTS IR_WebhookDelivery (
+						ID VARCHAR(26) PRIMARY KEY,
+						PlaybookRunID VARCHAR(26) NOT NULL,
+						URL TEXT NOT NULL,
+						Payload TEXT NOT NULL,
+						Status VARCHAR(32) NOT NULL,
+						Attempts INT NOT NULL DEFAULT 0,
+						LastError TEXT NOT NULL,
+						NextAttemptAt BIGINT NOT NULL DEFAULT 0,
+						CreateAt BIGINT NOT NULL,
+						UpdateAt BIGINT NOT NULL DEFAULT 0,
+						INDEX IR_WebhookDelivery_Status_NextAttemptAt (Status, NextAttemptAt),
+						INDEX IR_WebhookDelivery_PlaybookRunID (PlaybookRunID)
+					)
+				` + MySQLCharset); err != nil {
+					return errors.Wrapf(err, "failed creating table IR_WebhookDelivery")
+				}
+			} else {
+				if _, err := e.Exec(`
+					CREATE TABLE IF NOT EXISTS IR_WebhookDelivery (
+						ID VARCHAR(26) PRIMARY KEY,
+						PlaybookRunID VARCHAR(26) NOT NULL,
+						URL TEXT NOT NULL,
+						Payload TEXT NOT NULL,
+						Status VARCHAR(32) NOT NULL,
+						Attempts INT NOT NULL DEFAULT 0,
+						LastError TEXT NOT NULL,
+						NextAttemptAt BIGINT NOT NULL DEFAULT 0,
+						CreateAt BIGINT NOT NULL,
+						UpdateAt BIGINT NOT NULL DEFAULT 0
+					)
+				`); err != nil {
+					return errors.Wrapf(err, "failed creating table IR_WebhookDelivery")
+				}
+
+				if _, err := e.Exec(createPGIndex("IR_WebhookDelivery_Status_NextAttemptAt", "IR_WebhookDelivery", "Status, NextAttemptAt")); err != nil {
+					return errors.Wrapf(err, "failed creating index IR_WebhookDelivery_Status_NextAttemptAt")
+				}
+
+				if _, err := e.Exec(createPGIndex("IR_WebhookDelivery_PlaybookRunID", "IR_WebhookDelivery", "PlaybookRunID")); err != nil {
+					return errors.Wrapf(err, "failed creating index IR_WebhookDelivery_PlaybookRunID")
+				}
+			}
+
+			return nil
+		},
+	},
+	{
+		fromVersion: semver.MustParse("0.64.0"),
+		toVersion:   semver.MustParse("0.65.0"),
+		migrationFunc: func(e sqlx.Ext, sqlStore *SQLStore) error {
+			if e.DriverName() == model.DatabaseDriverMysql {
+				if err := addColumnToMySQLTable(e, "IR_Playbook", "IsTemplate", "BOOLEAN DEFAULT FALSE"); err != nil {
+					return errors.Wrapf(err, "failed adding column IsTemplate to table IR_Playbook")
+				}
+				if err := addColumnToMySQLTable(e, "IR_Playbook", "TemplateSourceID", "VARCHAR(26) DEFAULT ''"); err != nil {
+					return errors.Wrapf(err, "failed adding column TemplateSourceID to table IR_Playbook")
+				}
+			} else {
+				if err := addColumnToPGTable(e, "IR_Playbook", "IsTemplate", "BOOLEAN DEFAULT FALSE"); err != nil {
+					return errors.Wrapf(err, "failed adding column IsTemplate to table IR_Playbook")
+				}
+				if err := addColumnToPGTable(e, "IR_Playbook", "TemplateSourceID", "VARCHAR(26) DEFAULT ''"); err != nil {
+					return errors.Wrapf(err, "failed adding column TemplateSourceID to table IR_Playbook")
+				}
+			}
+
+			return nil
+		},
+	},
+	{
+		fromVersion: semver.MustParse("0.65.0"),
+		toVersion:   semver.MustParse("0.66.0"),
+		migrationFunc: func(e sqlx.Ext, sqlStore *SQLStore) error {
+			if e.DriverName() == model.DatabaseDriverMysql {
+				if err := addColumnToMySQLTable(e, "IR_Incident", "PausedAt", "BIGINT NOT NULL DEFAULT 0"); err != nil {
+					return errors.Wrapf(err, "failed adding column PausedAt to table IR_Incident")
+				}
+				if err := addColumnToMySQLTable(e, "IR_Incident", "PausedDuration", "BIGINT NOT NULL DEFAULT 0"); err != nil {
+					return errors.Wrapf(err, "failed adding column PausedDuration to table IR_Incident")
+				}
+			} else {
+				if err := addColumnToPGTable(e, "IR_Incident", "PausedAt", "BIGINT NOT NULL DEFAULT 0"); err != nil {
+					return errors.Wrapf(err, "failed adding column PausedAt to table IR_Incident")
+				}
+				if err := addColumnToPGTable(e, "IR_Incident", "PausedDuration", "BIGINT NOT NULL DEFAULT 0"); err != nil {
+					return errors.Wrapf(err, "failed adding column PausedDuration to table IR_Incident")
+				}
+			}
+
+			return nil
+		},
+	},
+	{
+		fromVersion: semver.MustParse("0.66.0"),
+		toVersion:   semver.MustParse("0.67.0"),
+		migrationFunc: func(e sqlx.Ext, sqlStore *SQLStore) error {
+			if e.DriverName() == model.DatabaseDriverMysql {
+				if _, err := e.Exec(`
+					CREATE TABLE IF NOT EXISTS IR_RunCoOwner (
+						IncidentID VARCHAR(26) NOT NULL REFERENCES IR_Incident(ID),
+						UserID VARCHAR(26) NOT NULL,
+						CreateAt BIGINT NOT NULL DEFAULT 0,
+						PRIMARY KEY (IncidentID, UserID),
+						INDEX IR_RunCoOwner_UserID (UserID)
+					)
+				` + MySQLCharset); err != nil {
+					return errors.Wrapf(err, "failed creating table IR_RunCoOwner")
+				}
+			} else {
+				if _, err := e.Exec(`
+					CREATE TABLE IF NOT EXISTS IR_RunCoOwner (
+						IncidentID VARCHAR(26) NOT NULL REFERENCES IR_Incident(ID),
+						UserID VARCHAR(26) NOT NULL,
+						CreateAt BIGINT NOT NULL DEFAULT 0,
+						PRIMARY KEY (IncidentID, UserID)
+					)
+				`); err != nil {
+					return errors.Wrapf(err, "failed creating table IR_RunCoOwner")
+				}
+
+				if _, err := e.Exec(createPGIndex("IR_RunCoOwner_UserID", "IR_RunCoOwner", "UserID")); err != nil {
+					return errors.Wrapf(err, "failed creating index IR_RunCoOwner_UserID")
+				}
+			}
+
+			return nil
+		},
+	},
+	{
+		fromVersion: semver.MustParse("0.67.0"),
+		toVersion:   semver.MustParse("0.68.0"),
+		migrationFunc: func(e sqlx.Ext, sqlStore *SQLStore) error {
+			if e.DriverName() == model.DatabaseDriverMysql {
+				if _, err := e.Exec(`
+					CREATE TABLE IF NOT EXISTS IR_PropertyDefinition (
+						ID VARCHAR(26) PRIMARY KEY,
+						PlaybookID VARCHAR(26) NOT NULL REFERENCES IR_Playbook(ID),
+						Name VARCHAR(512) NOT NULL,
+						Type VARCHAR(32) NOT NULL,
+						OptionsJSON TEXT NOT NULL,
+						Ordering SMALLINT NOT NULL DEFAULT 0,
+						DeleteAt BIGINT NOT NULL DEFAULT 0,
+						INDEX IR_PropertyDefinition_PlaybookID (PlaybookID)
+					)
+				` + MySQLCharset); err != nil {
+					return errors.Wrapf(err, "failed creating table IR_PropertyDefinition")
+				}
+
+				if _, err := e.Exec(`
+					CREATE TABLE IF NOT EXISTS IR_PropertyValue (
+						IncidentID VARCHAR(26) NOT NULL REFERENCES IR_Incident(ID),
+						PropertyDefinitionID VARCHAR(26) NOT NULL REFERENCES IR_PropertyDefinition(ID),
+						Value TEXT NOT NULL,
+						PRIMARY KEY (IncidentID, PropertyDefinitionID),
+						INDEX IR_PropertyValue_PropertyDefinitionID (PropertyDefinitionID)
+					)
+				` + MySQLCharset); err != nil {
+					return errors.Wrapf(err, "failed creating table IR_PropertyValue")
+				}
+			} else {
+				if _, err := e.Exec(`
+					CREATE TABLE IF NOT EXISTS IR_PropertyDefinition (
+						ID TEXT PRIMARY KEY,
+						PlaybookID TEXT NOT NULL REFERENCES IR_Playbook(ID),
+						Name TEXT NOT NULL,
+						Type TEXT NOT NULL,
+						OptionsJSON TEXT NOT NULL,
+						Ordering SMALLINT NOT NULL DEFAULT 0,
+						DeleteAt BIGINT NOT NULL DEFAULT 0
+					)
+				`); err != nil {
+					return errors.Wrapf(err, "failed creating table IR_PropertyDefinition")
+				}
+
+				if _, err := e.Exec(createPGIndex("IR_PropertyDefinition_PlaybookID", "IR_PropertyDefinition", "PlaybookID")); err != nil {
+					return errors.Wrapf(err, "failed creating index IR_PropertyDefinition_PlaybookID")
+				}
+
+				if _, err := e.Exec(`
+					CREATE TABLE IF NOT EXISTS IR_PropertyValue (
+						IncidentID TEXT NOT NULL REFERENCES IR_Incident(ID),
+						PropertyDefinitionID TEXT NOT NULL REFERENCES IR_PropertyDefinition(ID),
+						Value TEXT NOT NULL,
+						PRIMARY KEY (IncidentID, PropertyDefinitionID)
+					)
+				`); err != nil {
+					return errors.Wrapf(err, "failed creating table IR_PropertyValue")
+				}
+
+				if _, err := e.Exec(createPGIndex("IR_PropertyValue_PropertyDefinitionID", "IR_PropertyValue", "PropertyDefinitionID")); err != nil {
+					return errors.Wrapf(err, "failed creating index IR_PropertyValue_PropertyDefinitionID")
+				}
+			}
+
+			return nil
+		},
+	},
+	{
+		fromVersion: semver.MustParse("0.68.0"),
+		toVersion:   semver.MustParse("0.69.0"),
+		migrationFunc: func(e sqlx.Ext, sqlStore *SQLStore) error {
+			if e.DriverName() == model.DatabaseDriverMysql {
+				if err := addColumnToMySQLTable(e, "IR_MetricConfig", "Direction", "VARCHAR(32) NOT NULL DEFAULT ''"); err != nil {
+					return errors.Wrapf(err, "failed adding column Direction to table IR_MetricConfig")
+				}
+			} else {
+				if err := addColumnToPGTable(e, "IR_MetricConfig", "Direction", "TEXT NOT NULL DEFAULT ''"); err != nil {
+					return errors.Wrapf(err, "failed adding column Direction to table IR_MetricConfig")
+				}
+			}
+
+			return nil
+		},
+	},
+	{
+		fromVersion: semver.MustParse("0.69.0"),
+		toVersion:   semver.MustParse("0.70.0"),
+		migrationFunc: func(e sqlx.Ext, sqlStore *SQLStore) error {
+			if e.DriverName() == model.DatabaseDriverMysql {
+				if _, err := e.Exec(`
+					CREATE TABLE IF NOT EXISTS IR_RunTag (
+						IncidentID VARCHAR(26) NOT NULL REFERENCES IR_Incident(ID),
+						Tag VARCHAR(64) NOT NULL,
+						CreateAt BIGINT NOT NULL DEFAULT 0,
+						PRIMARY KEY (IncidentID, Tag),
+						INDEX IR_RunTag_Tag (Tag)
+					)
+				` + MySQLCharset); err != nil {
+					return errors.Wrapf(err, "failed creating table IR_RunTag")
+				}
+			} else {
+				if _, err := e.Exec(`
+					CREATE TABLE IF NOT EXISTS IR_RunTag (
+						IncidentID VARCHAR(26) NOT NULL REFERENCES IR_Incident(ID),
+						Tag TEXT NOT NULL,
+						CreateAt BIGINT NOT NULL DEFAULT 0,
+						PRIMARY KEY (IncidentID, Tag)
+					)
+				`); err != nil {
+					return errors.Wrapf(err, "failed creating table IR_RunTag")
+				}
+
+				if _, err := e.Exec(createPGIndex("IR_RunTag_Tag", "IR_RunTag", "Tag")); err != nil {
+					return errors.Wrapf(err, "failed creating index IR_RunTag_Tag")
+				}
+			}
+
+			return nil
+		},
+	},
+	{
+		fromVersion: semver.MustParse("0.70.0"),
+		toVersion:   semver.MustParse("0.71.0"),
+		migrationFunc: func(e sqlx.Ext, sqlStore *SQLStore) error {
+			if e.DriverName() == model.DatabaseDriverMysql {
+				if err := addColumnToMySQLTable(e, "IR_Playbook", "StatusUpdateTemplatesJSON", "JSON"); err != nil {
+					return errors.Wrapf(err, "failed adding column StatusUpdateTemplatesJSON to table IR_Playbook")
+				}
+				if err := addColumnToMySQLTable(e, "IR_Incident", "StatusUpdateTemplatesJSON", "JSON"); err != nil {
+					return errors.Wrapf(err, "failed adding column StatusUpdateTemplatesJSON to table IR_Incident")
+				}
+			} else {
+				if err := addColumnToPGTable(e, "IR_Playbook", "StatusUpdateTemplatesJSON", "JSON"); err != nil {
+					return errors.Wrapf(err, "failed adding column StatusUpdateTemplatesJSON to table IR_Playbook")
+				}
+				if err := addColumnToPGTable(e, "IR_Incident", "StatusUpdateTemplatesJSON", "JSON"); err != nil {
+					return errors.Wrapf(err, "failed adding column StatusUpdateTemplatesJSON to table IR_Incident")
+				}
+			}
+
+			return nil
+		},
+	},
+	{
+		fromVersion: semver.MustParse("0.71.0"),
+		toVersion:   semver.MustParse("0.72.0"),
+		migrationFunc: func(e sqlx.Ext, sqlStore *SQLStore) error {
+			if e.DriverName() == model.DatabaseDriverMysql {
+				if _, err := e.Exec(`
+					CREATE TABLE IF NOT EXISTS IR_RunIdempotencyKey (
+						UserID VARCHAR(26) NOT NULL,
+						IdempotencyKey VARCHAR(128) NOT NULL,
+						PlaybookRunID VARCHAR(26) NOT NULL DEFAULT '',
+						CreateAt BIGINT NOT NULL,
+						PRIMARY KEY (UserID, IdempotencyKey),
+						INDEX IR_RunIdempotencyKey_CreateAt (CreateAt)
+					)
+				` + MySQLCharset); err != nil {
+					return errors.Wrapf(err, "failed creating table IR_RunIdempotencyKey")
+				}
+			} else {
+				if _, err := e.Exec(`
+					CREATE TABLE IF NOT EXISTS IR_RunIdempotencyKey (
+						UserID TEXT NOT NULL,
+						IdempotencyKey VARCHAR(128) NOT NULL,
+						PlaybookRunID TEXT NOT NULL DEFAULT '',
+						CreateAt BIGINT NOT NULL,
+						PRIMARY KEY (UserID, IdempotencyKey)
+					)
+				`); err != nil {
+					return errors.Wrapf(err, "failed creating table IR_RunIdempotencyKey")
+				}
+
+				if _, err := e.Exec(createPGIndex("IR_RunIdempotencyKey_CreateAt", "IR_RunIdempotencyKey", "CreateAt")); err != nil {
+					return errors.Wrapf(err, "failed creating index IR_RunIdempotencyKey_CreateAt")
+				}
+			}
+
+			return nil
+		},
+	},
+	{
+		fromVersion: semver.MustParse("0.72.0"),
+		toVersion:   semver.MustParse("0.73.0"),
+		migrationFunc: func(e sqlx.Ext, sqlStore *SQLStore) error {
+			if e.DriverName() == model.DatabaseDriverMysql {
+				if err := addColumnToMySQLTable(e, "IR_Incident", "LastActivityAt", "BIGINT NOT NULL DEFAULT 0"); err != nil {
+					return errors.Wrapf(err, "failed adding column LastActivityAt to table IR_Incident")
+				}
+			} else {
+				if err := addColumnToPGTable(e, "IR_Incident", "LastActivityAt", "BIGINT NOT NULL DEFAULT 0"); err != nil {
+					return errors.Wrapf(err, "failed adding column LastActivityAt to table IR_Incident")
+				}
+			}
+
+			// Fill in the LastActivityAt column as the most recent activity event of the run, or
+			// its last status update or creation if more recent.
+			activityEventTypes := make([]interface{}, 0, len(app.RunActivityEventTypes))
+			for _, eventType := range app.RunActivityEventTypes {
+				activityEventTypes = append(activityEventTypes, string(eventType))
+			}
+			lastActivityUpdate := sqlStore.builder.
+				Update("IR_Incident").
+				Set("LastActivityAt", sq.Expr(`GREATEST(CreateAt, COALESCE(LastStatusUpdateAt, 0), COALESCE((
+					SELECT MAX(te.EventAt) FROM IR_TimelineEvent AS te
+					WHERE te.IncidentID = IR_Incident.ID AND te.DeleteAt = 0 AND te.EventType IN (`+sq.Placeholders(len(activityEventTypes))+`)
+				), 0))`, activityEventTypes...))
+			if _, err := sqlStore.execBuilder(e, lastActivityUpdate); err != nil {
+				return errors.Wrapf(err, "failed setting the LastActivityAt of the runs")
+			}
+
+			if e.DriverName() == model.DatabaseDriverMysql {
+				if _, err := e.Exec(`ALTER TABLE IR_Incident ADD INDEX IR_Incident_CurrentStatus_LastActivityAt (CurrentStatus, LastActivityAt)`); err != nil {
+					me, ok := err.(*mysql.MySQLError)
+					if !ok || me.Number != 1061 { // not a Duplicate key name error
+						return errors.Wrapf(err, "failed creating index IR_Incident_CurrentStatus_LastActivityAt")
+					}
+				}
+			} else {
+				if _, err := e.Exec(createPGIndex("IR_Incident_CurrentStatus_LastActivityAt", "IR_Incident", "CurrentStatus, LastActivityAt")); err != nil {
+					return errors.Wrapf(err, "failed creating index IR_Incident_CurrentStatus_LastActivityAt")
+				}
+			}
+
+			return nil
+		},
+	},
+	{
+		fromVersion: semver.MustParse("0.73.0"),
+		toVersion:   semver.MustParse("0.74.0"),
+		migrationFunc: func(e sqlx.Ext, sqlStore *SQLStore) error {
+			if e.DriverName() == model.DatabaseDriverMysql {
+				if _, err := e.Exec(`
+					CREATE TABLE IF NOT EXISTS IR_CommandOutput (
+						ID VARCHAR(26) PRIMARY KEY,
+						IncidentID VARCHAR(26) NOT NULL REFERENCES IR_Incident(ID),
+						ChecklistItemID VARCHAR(26) NOT NULL,
+						Command TEXT NOT NULL,
+						UserID VARCHAR(26) NOT NULL,
+						Status VARCHAR(32) NOT NULL,
+						Output TEXT NOT NULL,
+						Truncated BOOLEAN NOT NULL DEFAULT FALSE,
+						CreateAt BIGINT NOT NULL,
+						INDEX IR_CommandOutput_IncidentID (IncidentID)
+					)
+				` + MySQLCharset); err != nil {
+					return errors.Wrapf(err, "failed creating table IR_CommandOutput")
+				}
+			} else {
+				if _, err := e.Exec(`
+					CREATE TABLE IF NOT EXISTS IR_CommandOutput (
+						ID TEXT PRIMARY KEY,
+						IncidentID TEXT NOT NULL REFERENCES IR_Incident(ID),
+						ChecklistItemID TEXT NOT NULL,
+						Command TEXT NOT NULL,
+						UserID TEXT NOT NULL,
+						Status TEXT NOT NULL,
+						Output TEXT NOT NULL,
+						Truncated BOOLEAN NOT NULL DEFAULT FALSE,
+						CreateAt BIGINT NOT NULL
+					)
+				`); err != nil {
+					return errors.Wrapf(err, "failed creating table IR_CommandOutput")
+				}
+
+				if _, err := e.Exec(createPGIndex("IR_CommandOutput_IncidentID", "IR_CommandOutput", "IncidentID")); err != nil {
+					return errors.Wrapf(err, "failed creating index IR_CommandOutput_IncidentID")
+				}
+			}
+
+			return nil
+		},
+	},
+	{
+		fromVersion: semver.MustParse("0.74.0"),
+		toVersion:   semver.MustParse("0.75.0"),
+		migrationFunc: func(e sqlx.Ext, sqlStore *SQLStore) error {
+			if e.DriverName() == model.DatabaseDriverMysql {
+				if err := addColumnToMySQLTable(e, "IR_Playbook", "ArchiveChannelOnFinishEnabled", "BOOLEAN DEFAULT FALSE"); err != nil {
+					return errors.Wrapf(err, "failed adding column ArchiveChannelOnFinishEnabled to table IR_Playbook")
+				}
+				if err := addColumnToMySQLTable(e, "IR_Playbook", "ArchiveChannelOnFinishDelayMinutes", "BIGINT NOT NULL DEFAULT 0"); err != nil {
+					return errors.Wrapf(err, "failed adding column ArchiveChannelOnFinishDelayMinutes to table IR_Playbook")
+				}
+				if err := addColumnToMySQLTable(e, "IR_Playbook", "ArchiveChannelSkipIfPosted", "BOOLEAN DEFAULT FALSE"); err != nil {
+					return errors.Wrapf(err, "failed adding column ArchiveChannelSkipIfPosted to table IR_Playbook")
+				}
+				if err := addColumnToMySQLTable(e, "IR_Incident", "ArchiveChannelOnFinishEnabled", "BOOLEAN DEFAULT FALSE"); err != nil {
+					return errors.Wrapf(err, "failed adding column ArchiveChannelOnFinishEnabled to table IR_Incident")
+				}
+				if err := addColumnToMySQLTable(e, "IR_Incident", "ArchiveChannelOnFinishDelayMinutes", "BIGINT NOT NULL DEFAULT 0"); err != nil {
+					return errors.Wrapf(err, "failed adding column ArchiveChannelOnFinishDelayMinutes to table IR_Incident")
+				}
+				if err := addColumnToMySQLTable(e, "IR_Incident", "ArchiveChannelSkipIfPosted", "BOOLEAN DEFAULT FALSE"); err != nil {
+					return errors.Wrapf(err, "failed adding column ArchiveChannelSkipIfPosted to table IR_Incident")
+				}
+				if err := addColumnToMySQLTable(e, "IR_Incident", "ChannelAutoArchiveAt", "BIGINT NOT NULL DEFAULT 0"); err != nil {
+					return errors.Wrapf(err, "failed adding column ChannelAutoArchiveAt to table IR_Incident")
+				}
+
+				if _, err := e.Exec(`ALTER TABLE IR_Incident ADD INDEX IR_Incident_ChannelAutoArchiveAt (ChannelAutoArchiveAt)`); err != nil {
+					me, ok := err.(*mysql.MySQLError)
+					if !ok || me.Number != 1061 { // not a Duplicate key name error
+						return errors.Wrapf(err, "failed creating index IR_Incident_ChannelAutoArchiveAt")
+					}
+				}
+			} else {
+				if err := addColumnToPGTable(e, "IR_Playbook", "ArchiveChannelOnFinishEnabled", "BOOLEAN DEFAULT FALSE"); err != nil {
+					return errors.Wrapf(err, "failed adding column ArchiveChannelOnFinishEnabled to table IR_Playbook")
+				}
+				if err := addColumnToPGTable(e, "IR_Playbook", "ArchiveChannelOnFinishDelayMinutes", "BIGINT NOT NULL DEFAULT 0"); err != nil {
+					return errors.Wrapf(err, "failed adding column ArchiveChannelOnFinishDelayMinutes to table IR_Playbook")
+				}
+				if err := addColumnToPGTable(e, "IR_Playbook", "ArchiveChannelSkipIfPosted", "BOOLEAN DEFAULT FALSE"); err != nil {
+					return errors.Wrapf(err, "failed adding column ArchiveChannelSkipIfPosted to table IR_Playbook")
+				}
+				if err := addColumnToPGTable(e, "IR_Incident", "ArchiveChannelOnFinishEnabled", "BOOLEAN DEFAULT FALSE"); err != nil {
+					return errors.Wrapf(err, "failed adding column ArchiveChannelOnFinishEnabled to table IR_Incident")
+				}
+				if err := addColumnToPGTable(e, "IR_Incident", "ArchiveChannelOnFinishDelayMinutes", "BIGINT NOT NULL DEFAULT 0"); err != nil {
+					return errors.Wrapf(err, "failed adding column ArchiveChannelOnFinishDelayMinutes to table IR_Incident")
+				}
+				if err := addColumnToPGTable(e, "IR_Incident", "ArchiveChannelSkipIfPosted", "BOOLEAN DEFAULT FALSE"); err != nil {
+					return errors.Wrapf(err, "failed adding column ArchiveChannelSkipIfPosted to table IR_Incident")
+				}
+				if err := addColumnToPGTable(e, "IR_Incident", "ChannelAutoArchiveAt", "BIGINT NOT NULL DEFAULT 0"); err != nil {
+					return errors.Wrapf(err, "failed adding column ChannelAutoArchiveAt to table IR_Incident")
+				}
+
+				if _, err := e.Exec(createPGIndex("IR_Incident_ChannelAutoArchiveAt", "IR_Incident", "ChannelAutoArchiveAt")); err != nil {
+					return errors.Wrapf(err, "failed creating index IR_Incident_ChannelAutoArchiveAt")
+				}
+			}
+
+			return nil
+		},
+	},
+	{
+		fromVersion: semver.MustParse("0.75.0"),
+		toVersion:   semver.MustParse("0.76.0"),
+		migrationFunc: func(e sqlx.Ext, sqlStore *SQLStore) error {
+			if e.DriverName() == model.DatabaseDriverMysql {
+				if _, err := e.Exec(`
+					CREATE TABLE IF NOT EXISTS IR_PlaybookVersion (
+						PlaybookID VARCHAR(26) NOT NULL REFERENCES IR_Playbook(ID),
+						Version BIGINT NOT NULL,
+						UserID VARCHAR(26) NOT NULL,
+						CreateAt BIGINT NOT NULL,
+						Hash VARCHAR(64) NOT NULL,
+						PlaybookJSON JSON NOT NULL,
+						PRIMARY KEY (PlaybookID, Version)
+					)
+				` + MySQLCharset); err != nil {
+					return errors.Wrapf(err, "failed creating table IR_PlaybookVersion")
+				}
+			} else {
+				if _, err := e.Exec(`
+					CREATE TABLE IF NOT EXISTS IR_PlaybookVersion (
+						PlaybookID TEXT NOT NULL REFERENCES IR_Playbook(ID),
+						Version BIGINT NOT NULL,
+						UserID TEXT NOT NULL,
+						CreateAt BIGINT NOT NULL,
+						Hash TEXT NOT NULL,
+						PlaybookJSON JSON NOT NULL,
+						PRIMARY KEY (PlaybookID, Version)
+					)
+				`); err != nil {
+					return errors.Wrapf(err, "failed creating table IR_PlaybookVersion")
+				}
+			}
+
+			return nil
+		},
+	},
+	{
+		fromVersion: semver.MustParse("0.76.0"),
+		toVersion:   semver.MustParse("0.77.0"),
+		migrationFunc: func(e sqlx.Ext, sqlStore *SQLStore) error {
+			if e.DriverName() == model.DatabaseDriverMysql {
+				if err := addColumnToMySQLTable(e, "IR_Playbook", "RetrospectiveRequired", "BOOLEAN DEFAULT FALSE"); err != nil {
+					return errors.Wrapf(err, "failed adding column RetrospectiveRequired to table IR_Playbook")
+				}
+				if err := addColumnToMySQLTable(e, "IR_Incident", "RetrospectiveRequired", "BOOLEAN DEFAULT FALSE"); err != nil {
+					return errors.Wrapf(err, "failed adding column RetrospectiveRequired to table IR_Incident")
+				}
+			} else {
+				if err := addColumnToPGTable(e, "IR_Playbook", "RetrospectiveRequired", "BOOLEAN DEFAULT FALSE"); err != nil {
+					return errors.Wrapf(err, "failed adding column RetrospectiveRequired to table IR_Playbook")
+				}
+				if err := addColumnToPGTable(e, "IR_Incident", "RetrospectiveRequired", "BOOLEAN DEFAULT FALSE"); err != nil {
+					return errors.Wrapf(err, "failed adding column RetrospectiveRequired to table IR_Incident")
+				}
+			}
+
+			return nil
+		},
+	},
+	{
+		fromVersion: semver.MustParse("0.77.0"),
+		toVersion:   semver.MustParse("0.78.0"),
+		migrationFunc: func(e sqlx.Ext, sqlStore *SQLStore) error {
+			if e.DriverName() == model.DatabaseDriverMysql {
+				if err := addColumnToMySQLTable(e, "IR_Incident", "MergedIntoRunID", "VARCHAR(26) DEFAULT ''"); err != nil {
+					return errors.Wrapf(err, "failed adding column MergedIntoRunID to table IR_Incident")
+				}
+			} else {
+				if err := addColumnToPGTable(e, "IR_Incident", "MergedIntoRunID", "TEXT DEFAULT ''"); err != nil {
+					return errors.Wrapf(err, "failed adding column MergedIntoRunID to table IR_Incident")
+				}
+			}
+
+			return nil
+		},
+	},
+	{
+		fromVersion: semver.MustParse("0.78.0"),
+		toVersion:   semver.MustParse("0.79.0"),
+		migrationFunc: func(e sqlx.Ext, sqlStore *SQLStore) error {
+			if e.DriverName() == model.DatabaseDriverMysql {
+				if _, err := e.Exec(`
+					CREATE TABLE IF NOT EXISTS IR_DueReminder (
+						PlaybookRunID VARCHAR(26) NOT NULL,
+						ChecklistItemID VARCHAR(26) NOT NULL,
+						UserID VARCHAR(26) NOT NULL,
+						DueDate BIGINT NOT NULL,
+						RemindAt BIGINT NOT NULL,
+						SentAt BIGINT NOT NULL DEFAULT 0,
+						CreateAt BIGINT NOT NULL,
+						UpdateAt BIGINT NOT NULL DEFAULT 0,
+						PRIMARY KEY (PlaybookRunID, ChecklistItemID),
+						INDEX IR_DueReminder_SentAt_RemindAt (SentAt, RemindAt)
+					)
+				` + MySQLCharset); err != nil {
+					return errors.Wrapf(err, "failed creating table IR_DueReminder")
+				}
+			} else {
+				if _, err := e.Exec(`
+					CREATE TABLE IF NOT EXISTS IR_DueReminder (
+						PlaybookRunID TEXT NOT NULL,
+						ChecklistItemID TEXT NOT NULL,
+						UserID TEXT NOT NULL,
+						DueDate BIGINT NOT NULL,
+						RemindAt BIGINT NOT NULL,
+						SentAt BIGINT NOT NULL DEFAULT 0,
+						CreateAt BIGINT NOT NULL,
+						UpdateAt BIGINT NOT NULL DEFAULT 0,
+						PRIMARY KEY (PlaybookRunID, ChecklistItemID)
+					)
+				`); err != nil {
+					return errors.Wrapf(err, "failed creating table IR_DueReminder")
+				}
+
+				if _, err := e.Exec(createPGIndex("IR_DueReminder_SentAt_RemindAt", "IR_DueReminder", "SentAt, RemindAt")); err != nil {
+					return errors.Wrapf(err, "failed creating index IR_DueReminder_SentAt_RemindAt")
+				}
+			}
+
+			return nil
+		},
+	},
+	{
+		fromVersion: semver.MustParse("0.79.0"),
+		toVersion:   semver.MustParse("0.80.0"),
+		migrationFunc: func(e sqlx.Ext, sqlStore *SQLStore) error {
+			if e.DriverName() == model.DatabaseDriverMysql {
+				if err := addColumnToMySQLTable(e, "IR_Run_Participants", "IsViewer", "BOOLEAN DEFAULT FALSE"); err != nil {
+					return errors.Wrapf(err, "failed adding column IsViewer to table IR_Run_Participants")
+				}
+			} else {
+				if err := addColumnToPGTable(e, "IR_Run_Participants", "IsViewer", "BOOLEAN DEFAULT FALSE"); err != nil {
+					return errors.Wrapf(err, "failed adding column IsViewer to table IR_Run_Participants")
+				}
+			}
+
+			return nil
+		},
+	},
+	{
+		fromVersion: semver.MustParse("0.80.0"),
+		toVersion:   semver.MustParse("0.81.0"),
+		migrationFunc: func(e sqlx.Ext, sqlStore *SQLStore) error {
+			if e.DriverName() == model.DatabaseDriverMysql {
+				if err := addColumnToMySQLTable(e, "IR_Playbook", "ChecklistItemDependenciesEnforced", "BOOLEAN DEFAULT FALSE"); err != nil {
+					return errors.Wrapf(err, "failed adding column ChecklistItemDependenciesEnforced to table IR_Playbook")
+				}
+				if err := addColumnToMySQLTable(e, "IR_Incident", "ChecklistItemDependenciesEnforced", "BOOLEAN DEFAULT FALSE"); err != nil {
+					return errors.Wrapf(err, "failed adding column ChecklistItemDependenciesEnforced to table IR_Incident")
+				}
+			} else {
+				if err := addColumnToPGTable(e, "IR_Playbook", "ChecklistItemDependenciesEnforced", "BOOLEAN DEFAULT FALSE"); err != nil {
+					return errors.Wrapf(err, "failed adding column ChecklistItemDependenciesEnforced to table IR_Playbook")
+				}
+				if err := addColumnToPGTable(e, "IR_Incident", "ChecklistItemDependenciesEnforced", "BOOLEAN DEFAULT FALSE"); err != nil {
+					return errors.Wrapf(err, "failed adding column ChecklistItemDependenciesEnforced to table IR_Incident")
+				}
+			}
+
+			return nil
+		},
+	},
+	{
+		fromVersion: semver.MustParse("0.81.0"),
+		toVersion:   semver.MustParse("0.82.0"),
+		migrationFunc: func(e sqlx.Ext, sqlStore *SQLStore) error {
+			if e.DriverName() == model.DatabaseDriverMysql {
+				if err := addColumnToMySQLTable(e, "IR_TimelineEvent", "UserCreated", "BOOLEAN DEFAULT FALSE"); err != nil {
+					return errors.Wrapf(err, "failed adding column UserCreated to table IR_TimelineEvent")
+				}
+			} else {
+				if err := addColumnToPGTable(e, "IR_TimelineEvent", "UserCreated", "BOOLEAN DEFAULT FALSE"); err != nil {
+					return errors.Wrapf(err, "failed adding column UserCreated to table IR_TimelineEvent")
+				}
+			}
+
+			// Events from posts were always added by users.
+			if _, err := e.Exec("UPDATE IR_TimelineEvent SET UserCreated = TRUE WHERE EventType = 'event_from_post'"); err != nil {
+				return errors.Wrapf(err, "failed to flag the events from posts as created by users")
+			}
+
+			return nil
+		},
+	},
+	{
+		fromVersion: semver.MustParse("0.82.0"),
+		toVersion:   semver.MustParse("0.83.0"),
+		migrationFunc: func(e sqlx.Ext, sqlStore *SQLStore) error {
+			if e.DriverName() == model.DatabaseDriverMysql {
+				if err := addColumnToMySQLTable(e, "IR_Playbook", "AutoFinishEnabled", "BOOLEAN DEFAULT FALSE"); err != nil {
+					return errors.Wrapf(err, "failed adding column AutoFinishEnabled to table IR_Playbook")
+				}
+				if err := addColumnToMySQLTable(e, "IR_Incident", "AutoFinishEnabled", "BOOLEAN DEFAULT FALSE"); err != nil {
+					return errors.Wrapf(err, "failed adding column AutoFinishEnabled to table IR_Incident")
+				}
+			} else {
+				if err := addColumnToPGTable(e, "IR_Playbook", "AutoFinishEnabled", "BOOLEAN DEFAULT FALSE"); err != nil {
+					return errors.Wrapf(err, "failed adding column AutoFinishEnabled to table IR_Playbook")
+				}
+				if err := addColumnToPGTable(e, "IR_Incident", "AutoFinishEnabled", "BOOLEAN DEFAULT FALSE"); err != nil {
+					return errors.Wrapf(err, "failed adding column AutoFinishEnabled to table IR_Incident")
+				}
+			}
+
+			return nil
+		},
+	},
+	{
+		fromVersion: semver.MustParse("0.83.0"),
+		toVersion:   semver.MustParse("0.84.0"),
+		migrationFunc: func(e sqlx.Ext, sqlStore *SQLStore) error {
+			if e.DriverName() == model.DatabaseDriverMysql {
+				if _, err := e.Exec(`
+					CREATE TABLE IF NOT EXISTS IR_SavedFilter (
+						ID VARCHAR(26) PRIMARY KEY,
+						Name VARCHAR(256) NOT NULL,
+						TeamID VARCHAR(26) NOT NULL,
+						UserID VARCHAR(26) NOT NULL,
+						Shared BOOLEAN NOT NULL DEFAULT FALSE,
+						OptionsJSON JSON,
+						CreateAt BIGINT NOT NULL,
+						UpdateAt BIGINT NOT NULL,
+						INDEX IR_SavedFilter_TeamID_UserID (TeamID, UserID)
+					)
+				` + MySQLCharset); err != nil {
+					return errors.Wrapf(err, "failed creating table IR_SavedFilter")
+				}
+			} else {
+				if _, err := e.Exec(`
+					CREATE TABLE IF NOT EXISTS IR_SavedFilter (
+						ID TEXT PRIMARY KEY,
+						Name TEXT NOT NULL,
+						TeamID TEXT NOT NULL,
+						UserID TEXT NOT NULL,
+						Shared BOOLEAN NOT NULL DEFAULT FALSE,
+						OptionsJSON JSON,
+						CreateAt BIGINT NOT NULL,
+						UpdateAt BIGINT NOT NULL
+					)
+				`); err != nil {
+					return errors.Wrapf(err, "failed creating table IR_SavedFilter")
+				}
+
+				if _, err := e.Exec(createPGIndex("IR_SavedFilter_TeamID_UserID", "IR_SavedFilter", "TeamID, UserID")); err != nil {
+					return errors.Wrapf(err, "failed creating index IR_SavedFilter_TeamID_UserID")
+				}
+			}
+
+			return nil
+		},
+	},
 }
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS IR_ScheduledRun (
+    ID VARCHAR(26) PRIMARY KEY,
+    PlaybookID VARCHAR(26) NOT NULL REFERENCES IR_Playbook(ID),
+    CreatorUserID VARCHAR(26) NOT NULL,
+    Frequency VARCHAR(32) NOT NULL,
+    StartTime BIGINT NOT NULL,
+    Timezone VARCHAR(64) NOT NULL,
+    Enabled BOOLEAN DEFAULT TRUE,
+    LastRunAt BIGINT NOT NULL DEFAULT 0,
+    NextRunAt BIGINT NOT NULL DEFAULT 0,
+    CreateAt BIGINT NOT NULL,
+    UpdateAt BIGINT NOT NULL DEFAULT 0,
+    INDEX IR_ScheduledRun_PlaybookID (PlaybookID),
+    INDEX IR_ScheduledRun_Enabled_NextRunAt (Enabled, NextRunAt)
+) DEFAULT CHARACTER SET utf8mb4;
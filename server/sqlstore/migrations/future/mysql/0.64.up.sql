@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS IR_WebhookDelivery (
+    ID VARCHAR(26) PRIMARY KEY,
+    PlaybookRunID VARCHAR(26) NOT NULL,
+    URL TEXT NOT NULL,
+    Payload TEXT NOT NULL,
+    Status VARCHAR(32) NOT NULL,
+    Attempts INT NOT NULL DEFAULT 0,
+    LastError TEXT NOT NULL,
+    NextAttemptAt BIGINT NOT NULL DEFAULT 0,
+    CreateAt BIGINT NOT NULL,
+    UpdateAt BIGINT NOT NULL DEFAULT 0,
+    INDEX IR_WebhookDelivery_Status_NextAttemptAt (Status, NextAttemptAt),
+    INDEX IR_WebhookDelivery_PlaybookRunID (PlaybookRunID)
+) DEFAULT CHARACTER SET utf8mb4;
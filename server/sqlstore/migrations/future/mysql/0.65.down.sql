@@ -0,0 +1,29 @@
+SET @preparedStatement = (SELECT IF(
+    EXISTS(
+        SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'IR_Playbook'
+        AND table_schema = DATABASE()
+        AND column_name = 'IsTemplate'
+    ),
+    'ALTER TABLE IR_Playbook DROP COLUMN IsTemplate;',
+    'SELECT 1;'
+));
+
+PREPARE removeColumnIfExists FROM @preparedStatement;
+EXECUTE removeColumnIfExists;
+DEALLOCATE PREPARE removeColumnIfExists;
+
+SET @preparedStatement = (SELECT IF(
+    EXISTS(
+        SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'IR_Playbook'
+        AND table_schema = DATABASE()
+        AND column_name = 'TemplateSourceID'
+    ),
+    'ALTER TABLE IR_Playbook DROP COLUMN TemplateSourceID;',
+    'SELECT 1;'
+));
+
+PREPARE removeColumnIfExists FROM @preparedStatement;
+EXECUTE removeColumnIfExists;
+DEALLOCATE PREPARE removeColumnIfExists;
@@ -0,0 +1,29 @@
+SET @preparedStatement = (SELECT IF(
+    NOT EXISTS(
+        SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'IR_Playbook'
+        AND table_schema = DATABASE()
+        AND column_name = 'IsTemplate'
+    ),
+    'ALTER TABLE IR_Playbook ADD COLUMN IsTemplate BOOLEAN DEFAULT FALSE;',
+    'SELECT 1;'
+));
+
+PREPARE addColumnIfNotExists FROM @preparedStatement;
+EXECUTE addColumnIfNotExists;
+DEALLOCATE PREPARE addColumnIfNotExists;
+
+SET @preparedStatement = (SELECT IF(
+    NOT EXISTS(
+        SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'IR_Playbook'
+        AND table_schema = DATABASE()
+        AND column_name = 'TemplateSourceID'
+    ),
+    'ALTER TABLE IR_Playbook ADD COLUMN TemplateSourceID VARCHAR(26) DEFAULT "";',
+    'SELECT 1;'
+));
+
+PREPARE addColumnIfNotExists FROM @preparedStatement;
+EXECUTE addColumnIfNotExists;
+DEALLOCATE PREPARE addColumnIfNotExists;
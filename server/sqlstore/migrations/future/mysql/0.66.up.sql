@@ -0,0 +1,29 @@
+SET @preparedStatement = (SELECT IF(
+    NOT EXISTS(
+        SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'IR_Incident'
+        AND table_schema = DATABASE()
+        AND column_name = 'PausedAt'
+    ),
+    'ALTER TABLE IR_Incident ADD COLUMN PausedAt BIGINT NOT NULL DEFAULT 0;',
+    'SELECT 1;'
+));
+
+PREPARE addColumnIfNotExists FROM @preparedStatement;
+EXECUTE addColumnIfNotExists;
+DEALLOCATE PREPARE addColumnIfNotExists;
+
+SET @preparedStatement = (SELECT IF(
+    NOT EXISTS(
+        SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'IR_Incident'
+        AND table_schema = DATABASE()
+        AND column_name = 'PausedDuration'
+    ),
+    'ALTER TABLE IR_Incident ADD COLUMN PausedDuration BIGINT NOT NULL DEFAULT 0;',
+    'SELECT 1;'
+));
+
+PREPARE addColumnIfNotExists FROM @preparedStatement;
+EXECUTE addColumnIfNotExists;
+DEALLOCATE PREPARE addColumnIfNotExists;
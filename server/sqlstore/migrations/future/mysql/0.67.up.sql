@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS IR_RunCoOwner (
+    IncidentID VARCHAR(26) NOT NULL REFERENCES IR_Incident(ID),
+    UserID VARCHAR(26) NOT NULL,
+    CreateAt BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (IncidentID, UserID),
+    INDEX IR_RunCoOwner_UserID (UserID)
+) DEFAULT CHARACTER SET utf8mb4;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS IR_PropertyValue;
+DROP TABLE IF EXISTS IR_PropertyDefinition;
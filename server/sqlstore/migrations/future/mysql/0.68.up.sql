@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS IR_PropertyDefinition (
+    ID VARCHAR(26) PRIMARY KEY,
+    PlaybookID VARCHAR(26) NOT NULL REFERENCES IR_Playbook(ID),
+    Name VARCHAR(512) NOT NULL,
+    Type VARCHAR(32) NOT NULL,
+    OptionsJSON TEXT NOT NULL,
+    Ordering SMALLINT NOT NULL DEFAULT 0,
+    DeleteAt BIGINT NOT NULL DEFAULT 0,
+    INDEX IR_PropertyDefinition_PlaybookID (PlaybookID)
+) DEFAULT CHARACTER SET utf8mb4;
+
+CREATE TABLE IF NOT EXISTS IR_PropertyValue (
+    IncidentID VARCHAR(26) NOT NULL REFERENCES IR_Incident(ID),
+    PropertyDefinitionID VARCHAR(26) NOT NULL REFERENCES IR_PropertyDefinition(ID),
+    Value TEXT NOT NULL,
+    PRIMARY KEY (IncidentID, PropertyDefinitionID),
+    INDEX IR_PropertyValue_PropertyDefinitionID (PropertyDefinitionID)
+) DEFAULT CHARACTER SET utf8mb4;
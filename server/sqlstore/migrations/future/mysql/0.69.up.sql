@@ -0,0 +1,14 @@
+SET @preparedStatement = (SELECT IF(
+    NOT EXISTS(
+        SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'IR_MetricConfig'
+        AND table_schema = DATABASE()
+        AND column_name = 'Direction'
+    ),
+    'ALTER TABLE IR_MetricConfig ADD COLUMN Direction VARCHAR(32) NOT NULL DEFAULT '''';',
+    'SELECT 1;'
+));
+
+PREPARE addColumnIfNotExists FROM @preparedStatement;
+EXECUTE addColumnIfNotExists;
+DEALLOCATE PREPARE addColumnIfNotExists;
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS IR_RunTag (
+    IncidentID VARCHAR(26) NOT NULL REFERENCES IR_Incident(ID),
+    Tag VARCHAR(64) NOT NULL,
+    CreateAt BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (IncidentID, Tag),
+    INDEX IR_RunTag_Tag (Tag)
+) DEFAULT CHARACTER SET utf8mb4;
@@ -0,0 +1,29 @@
+SET @preparedStatement = (SELECT IF(
+    EXISTS(
+        SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'IR_Playbook'
+        AND table_schema = DATABASE()
+        AND column_name = 'StatusUpdateTemplatesJSON'
+    ),
+    'ALTER TABLE IR_Playbook DROP COLUMN StatusUpdateTemplatesJSON;',
+    'SELECT 1;'
+));
+
+PREPARE removeColumnIfExists FROM @preparedStatement;
+EXECUTE removeColumnIfExists;
+DEALLOCATE PREPARE removeColumnIfExists;
+
+SET @preparedStatement = (SELECT IF(
+    EXISTS(
+        SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'IR_Incident'
+        AND table_schema = DATABASE()
+        AND column_name = 'StatusUpdateTemplatesJSON'
+    ),
+    'ALTER TABLE IR_Incident DROP COLUMN StatusUpdateTemplatesJSON;',
+    'SELECT 1;'
+));
+
+PREPARE removeColumnIfExists FROM @preparedStatement;
+EXECUTE removeColumnIfExists;
+DEALLOCATE PREPARE removeColumnIfExists;
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS IR_RunIdempotencyKey (
+    UserID VARCHAR(26) NOT NULL,
+    IdempotencyKey VARCHAR(128) NOT NULL,
+    PlaybookRunID VARCHAR(26) NOT NULL DEFAULT '',
+    CreateAt BIGINT NOT NULL,
+    PRIMARY KEY (UserID, IdempotencyKey),
+    INDEX IR_RunIdempotencyKey_CreateAt (CreateAt)
+) DEFAULT CHARACTER SET utf8mb4;
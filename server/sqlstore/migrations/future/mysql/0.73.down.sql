@@ -0,0 +1,29 @@
+SET @preparedStatement = (SELECT IF(
+    EXISTS(
+        SELECT 1 FROM INFORMATION_SCHEMA.STATISTICS
+        WHERE table_name = 'IR_Incident'
+        AND index_schema = DATABASE()
+        AND index_name = 'IR_Incident_CurrentStatus_LastActivityAt'
+    ),
+    'DROP INDEX IR_Incident_CurrentStatus_LastActivityAt ON IR_Incident;',
+    'SELECT 1;'
+));
+
+PREPARE dropIndexIfExists FROM @preparedStatement;
+EXECUTE dropIndexIfExists;
+DEALLOCATE PREPARE dropIndexIfExists;
+
+SET @preparedStatement = (SELECT IF(
+    EXISTS(
+        SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'IR_Incident'
+        AND table_schema = DATABASE()
+        AND column_name = 'LastActivityAt'
+    ),
+    'ALTER TABLE IR_Incident DROP COLUMN LastActivityAt;',
+    'SELECT 1;'
+));
+
+PREPARE removeColumnIfExists FROM @preparedStatement;
+EXECUTE removeColumnIfExists;
+DEALLOCATE PREPARE removeColumnIfExists;
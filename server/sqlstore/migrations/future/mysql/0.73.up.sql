@@ -0,0 +1,37 @@
+SET @preparedStatement = (SELECT IF(
+    NOT EXISTS(
+        SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'IR_Incident'
+        AND table_schema = DATABASE()
+        AND column_name = 'LastActivityAt'
+    ),
+    'ALTER TABLE IR_Incident ADD COLUMN LastActivityAt BIGINT NOT NULL DEFAULT 0;',
+    'SELECT 1;'
+));
+
+PREPARE addColumnIfNotExists FROM @preparedStatement;
+EXECUTE addColumnIfNotExists;
+DEALLOCATE PREPARE addColumnIfNotExists;
+
+-- fill in the last activity from the timeline, the last status update and the creation
+UPDATE IR_Incident
+SET LastActivityAt = GREATEST(CreateAt, COALESCE(LastStatusUpdateAt, 0), COALESCE((
+    SELECT MAX(te.EventAt) FROM IR_TimelineEvent AS te
+    WHERE te.IncidentID = IR_Incident.ID AND te.DeleteAt = 0
+    AND te.EventType IN ('status_updated', 'task_state_modified', 'participants_changed', 'user_joined_left')
+), 0));
+
+SET @preparedStatement = (SELECT IF(
+    NOT EXISTS(
+        SELECT 1 FROM INFORMATION_SCHEMA.STATISTICS
+        WHERE table_name = 'IR_Incident'
+        AND index_schema = DATABASE()
+        AND index_name = 'IR_Incident_CurrentStatus_LastActivityAt'
+    ),
+    'CREATE INDEX IR_Incident_CurrentStatus_LastActivityAt ON IR_Incident(CurrentStatus, LastActivityAt);',
+    'SELECT 1;'
+));
+
+PREPARE createIndexIfNotExists FROM @preparedStatement;
+EXECUTE createIndexIfNotExists;
+DEALLOCATE PREPARE createIndexIfNotExists;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS IR_CommandOutput (
+    ID VARCHAR(26) PRIMARY KEY,
+    IncidentID VARCHAR(26) NOT NULL REFERENCES IR_Incident(ID),
+    ChecklistItemID VARCHAR(26) NOT NULL,
+    Command TEXT NOT NULL,
+    UserID VARCHAR(26) NOT NULL,
+    Status VARCHAR(32) NOT NULL,
+    Output TEXT NOT NULL,
+    Truncated BOOLEAN NOT NULL DEFAULT FALSE,
+    CreateAt BIGINT NOT NULL,
+    INDEX IR_CommandOutput_IncidentID (IncidentID)
+) DEFAULT CHARACTER SET utf8mb4;
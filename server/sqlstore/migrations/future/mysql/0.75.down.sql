@@ -0,0 +1,119 @@
+SET @preparedStatement = (SELECT IF(
+    EXISTS(
+        SELECT 1 FROM INFORMATION_SCHEMA.STATISTICS
+        WHERE table_name = 'IR_Incident'
+        AND index_schema = DATABASE()
+        AND index_name = 'IR_Incident_ChannelAutoArchiveAt'
+    ),
+    'DROP INDEX IR_Incident_ChannelAutoArchiveAt ON IR_Incident;',
+    'SELECT 1;'
+));
+
+PREPARE dropIndexIfExists FROM @preparedStatement;
+EXECUTE dropIndexIfExists;
+DEALLOCATE PREPARE dropIndexIfExists;
+
+SET @preparedStatement = (SELECT IF(
+    EXISTS(
+        SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'IR_Incident'
+        AND table_schema = DATABASE()
+        AND column_name = 'ChannelAutoArchiveAt'
+    ),
+    'ALTER TABLE IR_Incident DROP COLUMN ChannelAutoArchiveAt;',
+    'SELECT 1;'
+));
+
+PREPARE removeColumnIfExists FROM @preparedStatement;
+EXECUTE removeColumnIfExists;
+DEALLOCATE PREPARE removeColumnIfExists;
+
+SET @preparedStatement = (SELECT IF(
+    EXISTS(
+        SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'IR_Incident'
+        AND table_schema = DATABASE()
+        AND column_name = 'ArchiveChannelSkipIfPosted'
+    ),
+    'ALTER TABLE IR_Incident DROP COLUMN ArchiveChannelSkipIfPosted;',
+    'SELECT 1;'
+));
+
+PREPARE removeColumnIfExists FROM @preparedStatement;
+EXECUTE removeColumnIfExists;
+DEALLOCATE PREPARE removeColumnIfExists;
+
+SET @preparedStatement = (SELECT IF(
+    EXISTS(
+        SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'IR_Incident'
+        AND table_schema = DATABASE()
+        AND column_name = 'ArchiveChannelOnFinishDelayMinutes'
+    ),
+    'ALTER TABLE IR_Incident DROP COLUMN ArchiveChannelOnFinishDelayMinutes;',
+    'SELECT 1;'
+));
+
+PREPARE removeColumnIfExists FROM @preparedStatement;
+EXECUTE removeColumnIfExists;
+DEALLOCATE PREPARE removeColumnIfExists;
+
+SET @preparedStatement = (SELECT IF(
+    EXISTS(
+        SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'IR_Incident'
+        AND table_schema = DATABASE()
+        AND column_name = 'ArchiveChannelOnFinishEnabled'
+    ),
+    'ALTER TABLE IR_Incident DROP COLUMN ArchiveChannelOnFinishEnabled;',
+    'SELECT 1;'
+));
+
+PREPARE removeColumnIfExists FROM @preparedStatement;
+EXECUTE removeColumnIfExists;
+DEALLOCATE PREPARE removeColumnIfExists;
+
+SET @preparedStatement = (SELECT IF(
+    EXISTS(
+        SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'IR_Playbook'
+        AND table_schema = DATABASE()
+        AND column_name = 'ArchiveChannelSkipIfPosted'
+    ),
+    'ALTER TABLE IR_Playbook DROP COLUMN ArchiveChannelSkipIfPosted;',
+    'SELECT 1;'
+));
+
+PREPARE removeColumnIfExists FROM @preparedStatement;
+EXECUTE removeColumnIfExists;
+DEALLOCATE PREPARE removeColumnIfExists;
+
+SET @preparedStatement = (SELECT IF(
+    EXISTS(
+        SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'IR_Playbook'
+        AND table_schema = DATABASE()
+        AND column_name = 'ArchiveChannelOnFinishDelayMinutes'
+    ),
+    'ALTER TABLE IR_Playbook DROP COLUMN ArchiveChannelOnFinishDelayMinutes;',
+    'SELECT 1;'
+));
+
+PREPARE removeColumnIfExists FROM @preparedStatement;
+EXECUTE removeColumnIfExists;
+DEALLOCATE PREPARE removeColumnIfExists;
+
+SET @preparedStatement = (SELECT IF(
+    EXISTS(
+        SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'IR_Playbook'
+        AND table_schema = DATABASE()
+        AND column_name = 'ArchiveChannelOnFinishEnabled'
+    ),
+    'ALTER TABLE IR_Playbook DROP COLUMN ArchiveChannelOnFinishEnabled;',
+    'SELECT 1;'
+));
+
+PREPARE removeColumnIfExists FROM @preparedStatement;
+EXECUTE removeColumnIfExists;
+DEALLOCATE PREPARE removeColumnIfExists;
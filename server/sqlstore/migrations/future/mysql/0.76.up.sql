@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS IR_PlaybookVersion (
+    PlaybookID VARCHAR(26) NOT NULL REFERENCES IR_Playbook(ID),
+    Version BIGINT NOT NULL,
+    UserID VARCHAR(26) NOT NULL,
+    CreateAt BIGINT NOT NULL,
+    Hash VARCHAR(64) NOT NULL,
+    PlaybookJSON JSON NOT NULL,
+    PRIMARY KEY (PlaybookID, Version)
+) DEFAULT CHARACTER SET utf8mb4;
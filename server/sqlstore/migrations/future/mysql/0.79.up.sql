@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS IR_DueReminder (
+    PlaybookRunID VARCHAR(26) NOT NULL,
+    ChecklistItemID VARCHAR(26) NOT NULL,
+    UserID VARCHAR(26) NOT NULL,
+    DueDate BIGINT NOT NULL,
+    RemindAt BIGINT NOT NULL,
+    SentAt BIGINT NOT NULL DEFAULT 0,
+    CreateAt BIGINT NOT NULL,
+    UpdateAt BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (PlaybookRunID, ChecklistItemID),
+    INDEX IR_DueReminder_SentAt_RemindAt (SentAt, RemindAt)
+) DEFAULT CHARACTER SET utf8mb4;
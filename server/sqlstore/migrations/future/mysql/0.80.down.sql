@@ -0,0 +1,14 @@
+SET @preparedStatement = (SELECT IF(
+    EXISTS(
+        SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'IR_Run_Participants'
+        AND table_schema = DATABASE()
+        AND column_name = 'IsViewer'
+    ),
+    'ALTER TABLE IR_Run_Participants DROP COLUMN IsViewer;',
+    'SELECT 1;'
+));
+
+PREPARE removeColumnIfExists FROM @preparedStatement;
+EXECUTE removeColumnIfExists;
+DEALLOCATE PREPARE removeColumnIfExists;
@@ -0,0 +1,14 @@
+SET @preparedStatement = (SELECT IF(
+    NOT EXISTS(
+        SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'IR_Run_Participants'
+        AND table_schema = DATABASE()
+        AND column_name = 'IsViewer'
+    ),
+    'ALTER TABLE IR_Run_Participants ADD COLUMN IsViewer BOOLEAN DEFAULT FALSE;',
+    'SELECT 1;'
+));
+
+PREPARE addColumnIfNotExists FROM @preparedStatement;
+EXECUTE addColumnIfNotExists;
+DEALLOCATE PREPARE addColumnIfNotExists;
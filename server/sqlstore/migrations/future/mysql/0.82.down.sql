@@ -0,0 +1,14 @@
+SET @preparedStatement = (SELECT IF(
+    EXISTS(
+        SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'IR_TimelineEvent'
+        AND table_schema = DATABASE()
+        AND column_name = 'UserCreated'
+    ),
+    'ALTER TABLE IR_TimelineEvent DROP COLUMN UserCreated;',
+    'SELECT 1;'
+));
+
+PREPARE removeColumnIfExists FROM @preparedStatement;
+EXECUTE removeColumnIfExists;
+DEALLOCATE PREPARE removeColumnIfExists;
@@ -0,0 +1,16 @@
+SET @preparedStatement = (SELECT IF(
+    NOT EXISTS(
+        SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'IR_TimelineEvent'
+        AND table_schema = DATABASE()
+        AND column_name = 'UserCreated'
+    ),
+    'ALTER TABLE IR_TimelineEvent ADD COLUMN UserCreated BOOLEAN DEFAULT FALSE;',
+    'SELECT 1;'
+));
+
+PREPARE addColumnIfNotExists FROM @preparedStatement;
+EXECUTE addColumnIfNotExists;
+DEALLOCATE PREPARE addColumnIfNotExists;
+
+UPDATE IR_TimelineEvent SET UserCreated = TRUE WHERE EventType = 'event_from_post';
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS IR_SavedFilter (
+    ID VARCHAR(26) PRIMARY KEY,
+    Name VARCHAR(256) NOT NULL,
+    TeamID VARCHAR(26) NOT NULL,
+    UserID VARCHAR(26) NOT NULL,
+    Shared BOOLEAN NOT NULL DEFAULT FALSE,
+    OptionsJSON JSON,
+    CreateAt BIGINT NOT NULL,
+    UpdateAt BIGINT NOT NULL,
+    INDEX IR_SavedFilter_TeamID_UserID (TeamID, UserID)
+) DEFAULT CHARACTER SET utf8mb4;
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS IR_WebhookDelivery (
+    ID VARCHAR(26) PRIMARY KEY,
+    PlaybookRunID VARCHAR(26) NOT NULL,
+    URL TEXT NOT NULL,
+    Payload TEXT NOT NULL,
+    Status VARCHAR(32) NOT NULL,
+    Attempts INT NOT NULL DEFAULT 0,
+    LastError TEXT NOT NULL,
+    NextAttemptAt BIGINT NOT NULL DEFAULT 0,
+    CreateAt BIGINT NOT NULL,
+    UpdateAt BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS IR_WebhookDelivery_Status_NextAttemptAt ON IR_WebhookDelivery (Status, NextAttemptAt);
+CREATE INDEX IF NOT EXISTS IR_WebhookDelivery_PlaybookRunID ON IR_WebhookDelivery (PlaybookRunID);
@@ -0,0 +1,2 @@
+ALTER TABLE IR_Playbook DROP COLUMN IF EXISTS IsTemplate;
+ALTER TABLE IR_Playbook DROP COLUMN IF EXISTS TemplateSourceID;
@@ -0,0 +1,2 @@
+ALTER TABLE IR_Playbook ADD COLUMN IF NOT EXISTS IsTemplate BOOLEAN DEFAULT FALSE;
+ALTER TABLE IR_Playbook ADD COLUMN IF NOT EXISTS TemplateSourceID VARCHAR(26) DEFAULT '';
@@ -0,0 +1,2 @@
+ALTER TABLE IR_Incident DROP COLUMN IF EXISTS PausedAt;
+ALTER TABLE IR_Incident DROP COLUMN IF EXISTS PausedDuration;
@@ -0,0 +1,2 @@
+ALTER TABLE IR_Incident ADD COLUMN IF NOT EXISTS PausedAt BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE IR_Incident ADD COLUMN IF NOT EXISTS PausedDuration BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS IR_RunCoOwner (
+    IncidentID VARCHAR(26) NOT NULL REFERENCES IR_Incident(ID),
+    UserID VARCHAR(26) NOT NULL,
+    CreateAt BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (IncidentID, UserID)
+);
+
+CREATE INDEX IF NOT EXISTS IR_RunCoOwner_UserID ON IR_RunCoOwner (UserID);
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS IR_PropertyDefinition (
+    ID TEXT PRIMARY KEY,
+    PlaybookID TEXT NOT NULL REFERENCES IR_Playbook(ID),
+    Name TEXT NOT NULL,
+    Type TEXT NOT NULL,
+    OptionsJSON TEXT NOT NULL,
+    Ordering SMALLINT NOT NULL DEFAULT 0,
+    DeleteAt BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS IR_PropertyDefinition_PlaybookID ON IR_PropertyDefinition (PlaybookID);
+
+CREATE TABLE IF NOT EXISTS IR_PropertyValue (
+    IncidentID TEXT NOT NULL REFERENCES IR_Incident(ID),
+    PropertyDefinitionID TEXT NOT NULL REFERENCES IR_PropertyDefinition(ID),
+    Value TEXT NOT NULL,
+    PRIMARY KEY (IncidentID, PropertyDefinitionID)
+);
+
+CREATE INDEX IF NOT EXISTS IR_PropertyValue_PropertyDefinitionID ON IR_PropertyValue (PropertyDefinitionID);
@@ -0,0 +1 @@
+ALTER TABLE IR_MetricConfig DROP COLUMN IF EXISTS Direction;
@@ -0,0 +1 @@
+ALTER TABLE IR_MetricConfig ADD COLUMN IF NOT EXISTS Direction TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS IR_RunTag (
+    IncidentID VARCHAR(26) NOT NULL REFERENCES IR_Incident(ID),
+    Tag TEXT NOT NULL,
+    CreateAt BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (IncidentID, Tag)
+);
+
+CREATE INDEX IF NOT EXISTS IR_RunTag_Tag ON IR_RunTag (Tag);
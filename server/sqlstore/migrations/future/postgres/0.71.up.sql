@@ -0,0 +1,2 @@
+ALTER TABLE IR_Playbook ADD COLUMN IF NOT EXISTS StatusUpdateTemplatesJSON JSON;
+ALTER TABLE IR_Incident ADD COLUMN IF NOT EXISTS StatusUpdateTemplatesJSON JSON;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS IR_RunIdempotencyKey (
+    UserID TEXT NOT NULL,
+    IdempotencyKey VARCHAR(128) NOT NULL,
+    PlaybookRunID TEXT NOT NULL DEFAULT '',
+    CreateAt BIGINT NOT NULL,
+    PRIMARY KEY (UserID, IdempotencyKey)
+);
+
+CREATE INDEX IF NOT EXISTS IR_RunIdempotencyKey_CreateAt ON IR_RunIdempotencyKey (CreateAt);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS IR_Incident_CurrentStatus_LastActivityAt;
+ALTER TABLE IR_Incident DROP COLUMN IF EXISTS LastActivityAt;
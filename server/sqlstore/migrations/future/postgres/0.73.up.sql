@@ -0,0 +1,11 @@
+ALTER TABLE IR_Incident ADD COLUMN IF NOT EXISTS LastActivityAt BIGINT NOT NULL DEFAULT 0;
+
+-- fill in the last activity from the timeline, the last status update and the creation
+UPDATE IR_Incident
+SET LastActivityAt = GREATEST(CreateAt, COALESCE(LastStatusUpdateAt, 0), COALESCE((
+    SELECT MAX(te.EventAt) FROM IR_TimelineEvent AS te
+    WHERE te.IncidentID = IR_Incident.ID AND te.DeleteAt = 0
+    AND te.EventType IN ('status_updated', 'task_state_modified', 'participants_changed', 'user_joined_left')
+), 0));
+
+CREATE INDEX IF NOT EXISTS IR_Incident_CurrentStatus_LastActivityAt ON IR_Incident (CurrentStatus, LastActivityAt);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS IR_CommandOutput (
+    ID TEXT PRIMARY KEY,
+    IncidentID TEXT NOT NULL REFERENCES IR_Incident(ID),
+    ChecklistItemID TEXT NOT NULL,
+    Command TEXT NOT NULL,
+    UserID TEXT NOT NULL,
+    Status TEXT NOT NULL,
+    Output TEXT NOT NULL,
+    Truncated BOOLEAN NOT NULL DEFAULT FALSE,
+    CreateAt BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS IR_CommandOutput_IncidentID ON IR_CommandOutput (IncidentID);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS IR_Incident_ChannelAutoArchiveAt;
+ALTER TABLE IR_Incident DROP COLUMN IF EXISTS ChannelAutoArchiveAt;
+ALTER TABLE IR_Incident DROP COLUMN IF EXISTS ArchiveChannelSkipIfPosted;
+ALTER TABLE IR_Incident DROP COLUMN IF EXISTS ArchiveChannelOnFinishDelayMinutes;
+ALTER TABLE IR_Incident DROP COLUMN IF EXISTS ArchiveChannelOnFinishEnabled;
+ALTER TABLE IR_Playbook DROP COLUMN IF EXISTS ArchiveChannelSkipIfPosted;
+ALTER TABLE IR_Playbook DROP COLUMN IF EXISTS ArchiveChannelOnFinishDelayMinutes;
+ALTER TABLE IR_Playbook DROP COLUMN IF EXISTS ArchiveChannelOnFinishEnabled;
@@ -0,0 +1,9 @@
+ALTER TABLE IR_Playbook ADD COLUMN IF NOT EXISTS ArchiveChannelOnFinishEnabled BOOLEAN DEFAULT FALSE;
+ALTER TABLE IR_Playbook ADD COLUMN IF NOT EXISTS ArchiveChannelOnFinishDelayMinutes BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE IR_Playbook ADD COLUMN IF NOT EXISTS ArchiveChannelSkipIfPosted BOOLEAN DEFAULT FALSE;
+ALTER TABLE IR_Incident ADD COLUMN IF NOT EXISTS ArchiveChannelOnFinishEnabled BOOLEAN DEFAULT FALSE;
+ALTER TABLE IR_Incident ADD COLUMN IF NOT EXISTS ArchiveChannelOnFinishDelayMinutes BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE IR_Incident ADD COLUMN IF NOT EXISTS ArchiveChannelSkipIfPosted BOOLEAN DEFAULT FALSE;
+ALTER TABLE IR_Incident ADD COLUMN IF NOT EXISTS ChannelAutoArchiveAt BIGINT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS IR_Incident_ChannelAutoArchiveAt ON IR_Incident (ChannelAutoArchiveAt);
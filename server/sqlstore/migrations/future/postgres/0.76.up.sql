@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS IR_PlaybookVersion (
+    PlaybookID TEXT NOT NULL REFERENCES IR_Playbook(ID),
+    Version BIGINT NOT NULL,
+    UserID TEXT NOT NULL,
+    CreateAt BIGINT NOT NULL,
+    Hash TEXT NOT NULL,
+    PlaybookJSON JSON NOT NULL,
+    PRIMARY KEY (PlaybookID, Version)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE IR_Incident DROP COLUMN IF EXISTS RetrospectiveRequired;
+ALTER TABLE IR_Playbook DROP COLUMN IF EXISTS RetrospectiveRequired;
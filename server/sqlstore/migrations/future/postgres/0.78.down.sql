@@ -0,0 +1 @@
+ALTER TABLE IR_Incident DROP COLUMN IF EXISTS MergedIntoRunID;
@@ -0,0 +1 @@
+ALTER TABLE IR_Incident ADD COLUMN IF NOT EXISTS MergedIntoRunID TEXT DEFAULT '';
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS IR_DueReminder (
+    PlaybookRunID TEXT NOT NULL,
+    ChecklistItemID TEXT NOT NULL,
+    UserID TEXT NOT NULL,
+    DueDate BIGINT NOT NULL,
+    RemindAt BIGINT NOT NULL,
+    SentAt BIGINT NOT NULL DEFAULT 0,
+    CreateAt BIGINT NOT NULL,
+    UpdateAt BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (PlaybookRunID, ChecklistItemID)
+);
+
+CREATE INDEX IF NOT EXISTS IR_DueReminder_SentAt_RemindAt ON IR_DueReminder (SentAt, RemindAt);
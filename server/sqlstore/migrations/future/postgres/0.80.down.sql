@@ -0,0 +1 @@
+ALTER TABLE IR_Run_Participants DROP COLUMN IF EXISTS IsViewer;
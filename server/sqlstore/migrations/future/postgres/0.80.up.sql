@@ -0,0 +1 @@
+ALTER TABLE IR_Run_Participants ADD COLUMN IF NOT EXISTS IsViewer BOOLEAN DEFAULT FALSE;
@@ -0,0 +1 @@
+ALTER TABLE IR_TimelineEvent DROP COLUMN IF EXISTS UserCreated;
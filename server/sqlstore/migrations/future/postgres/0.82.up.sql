@@ -0,0 +1,3 @@
+ALTER TABLE IR_TimelineEvent ADD COLUMN IF NOT EXISTS UserCreated BOOLEAN DEFAULT FALSE;
+
+UPDATE IR_TimelineEvent SET UserCreated = TRUE WHERE EventType = 'event_from_post';
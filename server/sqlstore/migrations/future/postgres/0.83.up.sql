@@ -0,0 +1,2 @@
+ALTER TABLE IR_Playbook ADD COLUMN IF NOT EXISTS AutoFinishEnabled BOOLEAN DEFAULT FALSE;
+ALTER TABLE IR_Incident ADD COLUMN IF NOT EXISTS AutoFinishEnabled BOOLEAN DEFAULT FALSE;
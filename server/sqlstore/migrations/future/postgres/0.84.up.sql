@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS IR_SavedFilter (
+    ID TEXT PRIMARY KEY,
+    Name TEXT NOT NULL,
+    TeamID TEXT NOT NULL,
+    UserID TEXT NOT NULL,
+    Shared BOOLEAN NOT NULL DEFAULT FALSE,
+    OptionsJSON JSON,
+    CreateAt BIGINT NOT NULL,
+    UpdateAt BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS IR_SavedFilter_TeamID_UserID ON IR_SavedFilter (TeamID, UserID);
@@ -16,6 +16,7 @@ import (
 type sqlPlaybook struct {
 	app.Playbook
 	ChecklistsJSON                        json.RawMessage
+	StatusUpdateTemplatesJSON             json.RawMessage
 	ConcatenatedInvitedUserIDs            string
 	ConcatenatedInvitedGroupIDs           string
 	ConcatenatedSignalAnyKeywords         string
@@ -32,11 +33,18 @@ type playbookStore struct {
 	playbookSelect sq.SelectBuilder
 	membersSelect  sq.SelectBuilder
 	metricsSelect  sq.SelectBuilder
+
+	propertyDefinitionsSelect sq.SelectBuilder
 }
 
 // Ensure playbookStore implements the playbook.Store interface.
 var _ app.PlaybookStore = (*playbookStore)(nil)
 
+type sqlPropertyDefinition struct {
+	app.PropertyDefinition
+	OptionsJSON string
+}
+
 type playbookMember struct {
 	PlaybookID string
 	MemberID   string
@@ -130,7 +138,8 @@ func NewPlaybookStore(pluginAPI PluginAPIClient, sqlStore *SQLStore) app.Playboo
 				CASE WHEN p.SignalAnyKeywordsEnabled THEN 1 ELSE 0 END +
 				CASE WHEN p.CategorizeChannelEnabled THEN 1 ELSE 0 END +
 				CASE WHEN p.CreateChannelMemberOnNewParticipant THEN 1 ELSE 0 END +
-				CASE WHEN p.RemoveChannelMemberOnRemovedParticipant THEN 1 ELSE 0 END
+				CASE WHEN p.RemoveChannelMemberOnRemovedParticipant THEN 1 ELSE 0 END +
+				CASE WHEN p.ArchiveChannelOnFinishEnabled THEN 1 ELSE 0 END
 			) AS NumActions`,
 			"COALESCE(p.ReminderMessageTemplate, '') ReminderMessageTemplate",
 			"p.ReminderTimerDefaultSeconds",
@@ -156,9 +165,18 @@ func NewPlaybookStore(pluginAPI PluginAPIClient, sqlStore *SQLStore) app.Playboo
 			"p.CategorizeChannelEnabled",
 			"p.CreateChannelMemberOnNewParticipant",
 			"p.RemoveChannelMemberOnRemovedParticipant",
+			"p.ArchiveChannelOnFinishEnabled",
+			"p.ArchiveChannelOnFinishDelayMinutes",
+			"p.ArchiveChannelSkipIfPosted",
+			"p.RetrospectiveRequired",
+			"p.ChecklistItemDependenciesEnforced",
+			"p.AutoFinishEnabled",
 			"p.ChannelID",
 			"p.ChannelMode",
+			"p.IsTemplate",
+			"COALESCE(p.TemplateSourceID, '') TemplateSourceID",
 			"p.ChecklistsJSON",
+			"p.StatusUpdateTemplatesJSON",
 			"COALESCE(p.CategoryName, '') CategoryName",
 			"p.RunSummaryTemplateEnabled",
 			"COALESCE(p.RunSummaryTemplate, '') RunSummaryTemplate",
@@ -189,11 +207,24 @@ func NewPlaybookStore(pluginAPI PluginAPIClient, sqlStore *SQLStore) app.Playboo
 			"Description",
 			"Type",
 			"Target",
+			"Direction",
 		).
 		From("IR_MetricConfig").
 		Where(sq.Eq{"DeleteAt": 0}).
 		OrderBy("Ordering ASC")
 
+	propertyDefinitionsSelect := sqlStore.builder.
+		Select(
+			"ID",
+			"PlaybookID",
+			"Name",
+			"Type",
+			"OptionsJSON",
+		).
+		From("IR_PropertyDefinition").
+		Where(sq.Eq{"DeleteAt": 0}).
+		OrderBy("Ordering ASC")
+
 	newStore := &playbookStore{
 		pluginAPI:      pluginAPI,
 		store:          sqlStore,
@@ -201,6 +232,8 @@ func NewPlaybookStore(pluginAPI PluginAPIClient, sqlStore *SQLStore) app.Playboo
 		playbookSelect: playbookSelect,
 		membersSelect:  membersSelect,
 		metricsSelect:  metricsSelect,
+
+		propertyDefinitionsSelect: propertyDefinitionsSelect,
 	}
 	return newStore
 }
@@ -236,6 +269,7 @@ func (p *playbookStore) Create(playbook app.Playbook) (id string, err error) {
 			"UpdateAt":                                rawPlaybook.UpdateAt,
 			"DeleteAt":                                rawPlaybook.DeleteAt,
 			"ChecklistsJSON":                          rawPlaybook.ChecklistsJSON,
+			"StatusUpdateTemplatesJSON":               rawPlaybook.StatusUpdateTemplatesJSON,
 			"NumStages":                               len(rawPlaybook.Checklists),
 			"NumSteps":                                getSteps(rawPlaybook.Playbook),
 			"ReminderMessageTemplate":                 rawPlaybook.ReminderMessageTemplate,
@@ -266,8 +300,16 @@ func (p *playbookStore) Create(playbook app.Playbook) (id string, err error) {
 			"ChannelNameTemplate":                     rawPlaybook.ChannelNameTemplate,
 			"CreateChannelMemberOnNewParticipant":     rawPlaybook.CreateChannelMemberOnNewParticipant,
 			"RemoveChannelMemberOnRemovedParticipant": rawPlaybook.RemoveChannelMemberOnRemovedParticipant,
+			"ArchiveChannelOnFinishEnabled":           rawPlaybook.ArchiveChannelOnFinishEnabled,
+			"ArchiveChannelOnFinishDelayMinutes":      rawPlaybook.ArchiveChannelOnFinishDelayMinutes,
+			"ArchiveChannelSkipIfPosted":              rawPlaybook.ArchiveChannelSkipIfPosted,
+			"RetrospectiveRequired":                   rawPlaybook.RetrospectiveRequired,
+			"ChecklistItemDependenciesEnforced":       rawPlaybook.ChecklistItemDependenciesEnforced,
+			"AutoFinishEnabled":                       rawPlaybook.AutoFinishEnabled,
 			"ChannelID":                               rawPlaybook.ChannelID,
 			"ChannelMode":                             rawPlaybook.ChannelMode,
+			"IsTemplate":                              rawPlaybook.IsTemplate,
+			"TemplateSourceID":                        rawPlaybook.TemplateSourceID,
 		}))
 	if err != nil {
 		return "", errors.Wrap(err, "failed to store new playbook")
@@ -281,6 +323,10 @@ func (p *playbookStore) Create(playbook app.Playbook) (id string, err error) {
 		return "", errors.Wrap(err, "failed to replace playbook metrics configs")
 	}
 
+	if err = p.replacePlaybookPropertyDefinitions(tx, rawPlaybook.Playbook); err != nil {
+		return "", errors.Wrap(err, "failed to replace playbook property definitions")
+	}
+
 	if err = tx.Commit(); err != nil {
 		return "", errors.Wrap(err, "could not commit transaction")
 	}
@@ -325,12 +371,23 @@ func (p *playbookStore) Get(id string) (app.Playbook, error) {
 		return app.Playbook{}, errors.Wrapf(err, "failed to get metrics configs for playbook with id '%s'", id)
 	}
 
+	var rawPropertyDefinitions []sqlPropertyDefinition
+	err = p.store.selectBuilder(tx, &rawPropertyDefinitions, p.propertyDefinitionsSelect.Where(sq.Eq{"PlaybookID": id}))
+	if err != nil && err != sql.ErrNoRows {
+		return app.Playbook{}, errors.Wrapf(err, "failed to get property definitions for playbook with id '%s'", id)
+	}
+	propertyDefinitions, err := toPropertyDefinitions(rawPropertyDefinitions)
+	if err != nil {
+		return app.Playbook{}, err
+	}
+
 	if err = tx.Commit(); err != nil {
 		return app.Playbook{}, errors.Wrap(err, "could not commit transaction")
 	}
 
 	addMembersToPlaybook(members, &playbook)
 	playbook.Metrics = metrics
+	playbook.PropertyDefinitions = propertyDefinitions
 	return playbook, nil
 }
 
@@ -370,7 +427,8 @@ func (p *playbookStore) GetPlaybooks() ([]app.Playbook, error) {
 				CASE WHEN p.SignalAnyKeywordsEnabled THEN 1 ELSE 0 END +
 				CASE WHEN p.CategorizeChannelEnabled THEN 1 ELSE 0 END +
 				CASE WHEN p.CreateChannelMemberOnNewParticipant THEN 1 ELSE 0 END +
-				CASE WHEN p.RemoveChannelMemberOnRemovedParticipant THEN 1 ELSE 0 END
+				CASE WHEN p.RemoveChannelMemberOnRemovedParticipant THEN 1 ELSE 0 END +
+				CASE WHEN p.ArchiveChannelOnFinishEnabled THEN 1 ELSE 0 END
 			) AS NumActions`,
 			"COALESCE(ChannelNameTemplate, '') ChannelNameTemplate",
 			"COALESCE(s.DefaultPlaybookAdminRole, 'playbook_admin') DefaultPlaybookAdminRole",
@@ -409,6 +467,19 @@ func (p *playbookStore) GetPlaybooksForTeam(requesterInfo app.RequesterInfo, tea
 	}
 	teamLimitExpr := buildTeamLimitExpr(requesterInfo, teamID, "p")
 
+	if opts.TemplatesOnly {
+		if requesterInfo.IsGuest {
+			permissionsAndFilter = sq.And{sq.Expr(`p.IsTemplate = true`), permissionsAndFilter}
+		} else {
+			// Templates are visible from every team, except to guests.
+			permissionsAndFilter = sq.Expr(`p.IsTemplate = true`)
+			teamLimitExpr = nil
+			if teamID != "" {
+				teamLimitExpr = sq.Eq{"p.TeamID": teamID}
+			}
+		}
+	}
+
 	queryForResults := p.store.builder.
 		Select(
 			"p.ID",
@@ -423,6 +494,8 @@ func (p *playbookStore) GetPlaybooksForTeam(requesterInfo app.RequesterInfo, tea
 			"p.NumSteps",
 			"p.DefaultCommanderEnabled AS DefaultOwnerEnabled",
 			"p.DefaultCommanderID AS DefaultOwnerID",
+			"p.IsTemplate",
+			"COALESCE(p.TemplateSourceID, '') TemplateSourceID",
 			"COUNT(i.ID) AS NumRuns",
 			"COUNT(CASE WHEN i.CurrentStatus='InProgress' THEN 1 END) AS ActiveRuns",
 			"COALESCE(MAX(i.CreateAt), 0) AS LastRunAt",
@@ -437,7 +510,8 @@ func (p *playbookStore) GetPlaybooksForTeam(requesterInfo app.RequesterInfo, tea
 				CASE WHEN p.SignalAnyKeywordsEnabled THEN 1 ELSE 0 END +
 				CASE WHEN p.CategorizeChannelEnabled THEN 1 ELSE 0 END +
 				CASE WHEN p.CreateChannelMemberOnNewParticipant THEN 1 ELSE 0 END +
-				CASE WHEN p.RemoveChannelMemberOnRemovedParticipant THEN 1 ELSE 0 END
+				CASE WHEN p.RemoveChannelMemberOnRemovedParticipant THEN 1 ELSE 0 END +
+				CASE WHEN p.ArchiveChannelOnFinishEnabled THEN 1 ELSE 0 END
 			) AS NumActions`,
 			"COALESCE(ChannelNameTemplate, '') ChannelNameTemplate",
 			"COALESCE(s.DefaultPlaybookAdminRole, 'playbook_admin') DefaultPlaybookAdminRole",
@@ -512,9 +586,19 @@ func (p *playbookStore) GetPlaybooksForTeam(requesterInfo app.RequesterInfo, tea
 	if err != nil {
 		return app.GetPlaybooksResults{}, errors.Wrap(err, "failed to get playbooks metrics")
 	}
+	var rawPropertyDefinitions []sqlPropertyDefinition
+	err = p.store.selectBuilder(p.store.db, &rawPropertyDefinitions, p.propertyDefinitionsSelect.Where(sq.Eq{"PlaybookID": ids}))
+	if err != nil {
+		return app.GetPlaybooksResults{}, errors.Wrap(err, "failed to get playbooks property definitions")
+	}
+	propertyDefinitions, err := toPropertyDefinitions(rawPropertyDefinitions)
+	if err != nil {
+		return app.GetPlaybooksResults{}, err
+	}
 
 	addMembersToPlaybooks(members, playbooks)
 	addMetricsToPlaybooks(metrics, playbooks)
+	addPropertyDefinitionsToPlaybooks(propertyDefinitions, playbooks)
 
 	pageCount := 0
 	if opts.PerPage > 0 {
@@ -662,6 +746,7 @@ func (p *playbookStore) Update(playbook app.Playbook) (err error) {
 			"UpdateAt":                                rawPlaybook.UpdateAt,
 			"DeleteAt":                                rawPlaybook.DeleteAt,
 			"ChecklistsJSON":                          rawPlaybook.ChecklistsJSON,
+			"StatusUpdateTemplatesJSON":               rawPlaybook.StatusUpdateTemplatesJSON,
 			"NumStages":                               len(rawPlaybook.Checklists),
 			"NumSteps":                                getSteps(rawPlaybook.Playbook),
 			"ReminderMessageTemplate":                 rawPlaybook.ReminderMessageTemplate,
@@ -692,8 +777,15 @@ func (p *playbookStore) Update(playbook app.Playbook) (err error) {
 			"ChannelNameTemplate":                     rawPlaybook.ChannelNameTemplate,
 			"CreateChannelMemberOnNewParticipant":     rawPlaybook.CreateChannelMemberOnNewParticipant,
 			"RemoveChannelMemberOnRemovedParticipant": rawPlaybook.RemoveChannelMemberOnRemovedParticipant,
+			"ArchiveChannelOnFinishEnabled":           rawPlaybook.ArchiveChannelOnFinishEnabled,
+			"ArchiveChannelOnFinishDelayMinutes":      rawPlaybook.ArchiveChannelOnFinishDelayMinutes,
+			"ArchiveChannelSkipIfPosted":              rawPlaybook.ArchiveChannelSkipIfPosted,
+			"RetrospectiveRequired":                   rawPlaybook.RetrospectiveRequired,
+			"ChecklistItemDependenciesEnforced":       rawPlaybook.ChecklistItemDependenciesEnforced,
+			"AutoFinishEnabled":                       rawPlaybook.AutoFinishEnabled,
 			"ChannelID":                               rawPlaybook.ChannelID,
 			"ChannelMode":                             rawPlaybook.ChannelMode,
+			"IsTemplate":                              rawPlaybook.IsTemplate,
 		}).
 		Where(sq.Eq{"ID": rawPlaybook.ID}))
 
@@ -709,6 +801,10 @@ func (p *playbookStore) Update(playbook app.Playbook) (err error) {
 		return errors.Wrapf(err, "failed to replace playbook metrics configs for playbook with id '%s'", rawPlaybook.ID)
 	}
 
+	if err = p.replacePlaybookPropertyDefinitions(tx, rawPlaybook.Playbook); err != nil {
+		return errors.Wrapf(err, "failed to replace playbook property definitions for playbook with id '%s'", rawPlaybook.ID)
+	}
+
 	if err = tx.Commit(); err != nil {
 		return errors.Wrap(err, "could not commit transaction")
 	}
@@ -864,8 +960,8 @@ func (p *playbookStore) replacePlaybookMetrics(q queryExecer, playbook app.Playb
 		if m.ID == "" {
 			_, err = p.store.execBuilder(q, sq.
 				Insert("IR_MetricConfig").
-				Columns("ID", "PlaybookID", "Title", "Description", "Type", "Target", "Ordering").
-				Values(model.NewId(), playbook.ID, m.Title, m.Description, m.Type, m.Target, i))
+				Columns("ID", "PlaybookID", "Title", "Description", "Type", "Target", "Direction", "Ordering").
+				Values(model.NewId(), playbook.ID, m.Title, m.Description, m.Type, m.Target, m.Direction, i))
 		} else {
 			_, err = p.store.execBuilder(q, sq.
 				Update("IR_MetricConfig").
@@ -873,6 +969,7 @@ func (p *playbookStore) replacePlaybookMetrics(q queryExecer, playbook app.Playb
 					"Title":       m.Title,
 					"Description": m.Description,
 					"Target":      m.Target,
+					"Direction":   m.Direction,
 					"Ordering":    i,
 					"DeleteAt":    0,
 				}).
@@ -887,6 +984,55 @@ func (p *playbookStore) replacePlaybookMetrics(q queryExecer, playbook app.Playb
 	return nil
 }
 
+// replacePlaybookPropertyDefinitions replaces the property definitions of a playbook, the same
+// way as replacePlaybookMetrics does for metric configs.
+func (p *playbookStore) replacePlaybookPropertyDefinitions(q queryExecer, playbook app.Playbook) error {
+	updateBuilder := sq.Update("IR_PropertyDefinition").
+		Set("DeleteAt", model.GetMillis()).
+		Where(sq.Eq{"PlaybookID": playbook.ID}).
+		Where(sq.Eq{"DeleteAt": 0})
+
+	if _, err := p.store.execBuilder(q, updateBuilder); err != nil {
+		return err
+	}
+
+	for i, d := range playbook.PropertyDefinitions {
+		options := d.Options
+		if options == nil {
+			options = []string{}
+		}
+		optionsJSON, err := json.Marshal(options)
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal options of property %q", d.Name)
+		}
+
+		if d.ID == "" {
+			_, err = p.store.execBuilder(q, sq.
+				Insert("IR_PropertyDefinition").
+				Columns("ID", "PlaybookID", "Name", "Type", "OptionsJSON", "Ordering").
+				Values(model.NewId(), playbook.ID, d.Name, d.Type, string(optionsJSON), i))
+		} else {
+			_, err = p.store.execBuilder(q, sq.
+				Update("IR_PropertyDefinition").
+				SetMap(map[string]interface{}{
+					"Name":        d.Name,
+					"Type":        d.Type,
+					"OptionsJSON": string(optionsJSON),
+					"Ordering":    i,
+					"DeleteAt":    0,
+				}).
+				Where(sq.Eq{"ID": d.ID}).
+				Where(sq.Eq{"PlaybookID": playbook.ID}),
+			)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (p *playbookStore) AutoFollow(playbookID, userID string) error {
 	var err error
 	if p.store.db.DriverName() == model.DatabaseDriverMysql {
@@ -940,6 +1086,7 @@ func (p *playbookStore) GetMetric(id string) (*app.PlaybookMetricConfig, error)
 			"c.Description",
 			"c.Type",
 			"c.Target",
+			"c.Direction",
 		).
 		From("IR_MetricConfig c").
 		Where(sq.Eq{"c.ID": id})
@@ -965,8 +1112,8 @@ func (p *playbookStore) AddMetric(playbookID string, config app.PlaybookMetricCo
 
 	_, err = p.store.execBuilder(p.store.db, sq.
 		Insert("IR_MetricConfig").
-		Columns("ID", "PlaybookID", "Title", "Description", "Type", "Target", "Ordering").
-		Values(model.NewId(), playbookID, config.Title, config.Description, config.Type, config.Target, numExistingMetrics))
+		Columns("ID", "PlaybookID", "Title", "Description", "Type", "Target", "Direction", "Ordering").
+		Values(model.NewId(), playbookID, config.Title, config.Description, config.Type, config.Target, config.Direction, numExistingMetrics))
 
 	if err != nil {
 		return errors.Wrapf(err, "failed to add metric")
@@ -1062,6 +1209,33 @@ func addMetricsToPlaybooks(metrics []app.PlaybookMetricConfig, playbooks []app.P
 	}
 }
 
+func addPropertyDefinitionsToPlaybooks(definitions []app.PropertyDefinition, playbooks []app.Playbook) {
+	playbookToDefinitions := make(map[string][]app.PropertyDefinition)
+	for _, definition := range definitions {
+		playbookToDefinitions[definition.PlaybookID] = append(playbookToDefinitions[definition.PlaybookID], definition)
+	}
+
+	for i, playbook := range playbooks {
+		playbooks[i].PropertyDefinitions = playbookToDefinitions[playbook.ID]
+	}
+}
+
+func toPropertyDefinitions(rawDefinitions []sqlPropertyDefinition) ([]app.PropertyDefinition, error) {
+	var definitions []app.PropertyDefinition
+	for _, rawDefinition := range rawDefinitions {
+		definition := rawDefinition.PropertyDefinition
+		if err := json.Unmarshal([]byte(rawDefinition.OptionsJSON), &definition.Options); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal options of property definition %s", definition.ID)
+		}
+		if len(definition.Options) == 0 {
+			definition.Options = nil
+		}
+		definitions = append(definitions, definition)
+	}
+
+	return definitions, nil
+}
+
 func getSteps(playbook app.Playbook) int {
 	steps := 0
 	for _, p := range playbook.Checklists {
@@ -1081,9 +1255,15 @@ func toSQLPlaybook(playbook app.Playbook) (*sqlPlaybook, error) {
 		return nil, errors.Wrapf(err, "checklist json for playbook id '%s' is too long (max %d)", playbook.ID, maxJSONLength)
 	}
 
+	statusUpdateTemplatesJSON, err := json.Marshal(playbook.StatusUpdateTemplates)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal status update templates json for playbook id: '%s'", playbook.ID)
+	}
+
 	return &sqlPlaybook{
 		Playbook:                              playbook,
 		ChecklistsJSON:                        checklistsJSON,
+		StatusUpdateTemplatesJSON:             statusUpdateTemplatesJSON,
 		ConcatenatedInvitedUserIDs:            strings.Join(playbook.InvitedUserIDs, ","),
 		ConcatenatedInvitedGroupIDs:           strings.Join(playbook.InvitedGroupIDs, ","),
 		ConcatenatedSignalAnyKeywords:         strings.Join(playbook.SignalAnyKeywords, ","),
@@ -1101,6 +1281,13 @@ func toPlaybook(rawPlaybook sqlPlaybook) (app.Playbook, error) {
 		}
 	}
 
+	p.StatusUpdateTemplates = []app.StatusUpdateTemplate(nil)
+	if len(rawPlaybook.StatusUpdateTemplatesJSON) > 0 {
+		if err := json.Unmarshal(rawPlaybook.StatusUpdateTemplatesJSON, &p.StatusUpdateTemplates); err != nil {
+			return app.Playbook{}, errors.Wrapf(err, "failed to unmarshal status update templates json for playbook id: '%s'", p.ID)
+		}
+	}
+
 	p.InvitedUserIDs = []string(nil)
 	if rawPlaybook.ConcatenatedInvitedUserIDs != "" {
 		p.InvitedUserIDs = strings.Split(rawPlaybook.ConcatenatedInvitedUserIDs, ",")
@@ -5,8 +5,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"gopkg.in/guregu/null.v4"
 
@@ -16,6 +18,7 @@ import (
 	"github.com/mattermost/mattermost-plugin-playbooks/server/app"
 	"github.com/mattermost/mattermost-server/v6/model"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 )
 
 const (
@@ -25,9 +28,13 @@ const (
 type sqlPlaybookRun struct {
 	app.PlaybookRun
 	ChecklistsJSON                        json.RawMessage
+	StatusUpdateTemplatesJSON             json.RawMessage
 	ConcatenatedInvitedUserIDs            string
 	ConcatenatedInvitedGroupIDs           string
 	ConcatenatedParticipantIDs            string
+	ConcatenatedViewerIDs                 string
+	ConcatenatedCoOwnerUserIDs            string
+	ConcatenatedTags                      string
 	ConcatenatedBroadcastChannelIDs       string
 	ConcatenatedWebhookOnCreationURLs     string
 	ConcatenatedWebhookOnStatusUpdateURLs string
@@ -38,6 +45,12 @@ type sqlRunMetricData struct {
 	IncidentID     string
 	MetricConfigID string
 	Value          null.Int
+	Breached       bool
+}
+
+type sqlPropertyValue struct {
+	IncidentID string
+	app.PropertyValue
 }
 
 // playbookRunStore holds the information needed to fulfill the methods in the store interface.
@@ -50,6 +63,7 @@ type playbookRunStore struct {
 	timelineEventsSelect             sq.SelectBuilder
 	metricsDataSelectSingleRun       sq.SelectBuilder
 	sqlMetricsDataSelectMultipleRuns sq.SelectBuilder
+	propertyValuesSelect             sq.SelectBuilder
 }
 
 // Ensure playbookRunStore implements the app.PlaybookRunStore interface.
@@ -177,6 +191,61 @@ func NewPlaybookRunStore(pluginAPI PluginAPIClient, sqlStore *SQLStore) app.Play
         ) AS ConcatenatedParticipantIDs`
 	}
 
+	viewersCol := `
+        COALESCE(
+			(SELECT string_agg(rp.UserId, ',')
+				FROM IR_Run_Participants as rp
+				WHERE rp.IncidentID = i.ID
+				AND rp.IsParticipant = true
+				AND rp.IsViewer = true
+			), ''
+        ) AS ConcatenatedViewerIDs`
+	if sqlStore.db.DriverName() == model.DatabaseDriverMysql {
+		viewersCol = `
+        COALESCE(
+			(SELECT group_concat(rp.UserId separator ',')
+				FROM IR_Run_Participants as rp
+				WHERE rp.IncidentID = i.ID
+				AND rp.IsParticipant = true
+				AND rp.IsViewer = true
+			), ''
+        ) AS ConcatenatedViewerIDs`
+	}
+
+	coOwnersCol := `
+        COALESCE(
+			(SELECT string_agg(co.UserID, ',' ORDER BY co.CreateAt)
+				FROM IR_RunCoOwner as co
+				WHERE co.IncidentID = i.ID
+			), ''
+        ) AS ConcatenatedCoOwnerUserIDs`
+	if sqlStore.db.DriverName() == model.DatabaseDriverMysql {
+		coOwnersCol = `
+        COALESCE(
+			(SELECT group_concat(co.UserID ORDER BY co.CreateAt separator ',')
+				FROM IR_RunCoOwner as co
+				WHERE co.IncidentID = i.ID
+			), ''
+        ) AS ConcatenatedCoOwnerUserIDs`
+	}
+
+	tagsCol := `
+        COALESCE(
+			(SELECT string_agg(t.Tag, ',' ORDER BY t.Tag)
+				FROM IR_RunTag as t
+				WHERE t.IncidentID = i.ID
+			), ''
+        ) AS ConcatenatedTags`
+	if sqlStore.db.DriverName() == model.DatabaseDriverMysql {
+		tagsCol = `
+        COALESCE(
+			(SELECT group_concat(t.Tag ORDER BY t.Tag separator ',')
+				FROM IR_RunTag as t
+				WHERE t.IncidentID = i.ID
+			), ''
+        ) AS ConcatenatedTags`
+	}
+
 	// When adding a PlaybookRun column #1: add to this select
 	playbookRunSelect := sqlStore.builder.
 		Select("i.ID", "i.Name AS Name", "i.Description AS Summary", "i.CommanderUserID AS OwnerUserID", "i.TeamID", "i.ChannelID",
@@ -187,8 +256,14 @@ func NewPlaybookRunStore(pluginAPI PluginAPIClient, sqlStore *SQLStore) app.Play
 			"ConcatenatedBroadcastChannelIDs", "ConcatenatedWebhookOnCreationURLs", "Retrospective", "RetrospectiveEnabled", "MessageOnJoin", "RetrospectivePublishedAt", "RetrospectiveReminderIntervalSeconds",
 			"RetrospectiveWasCanceled", "ConcatenatedWebhookOnStatusUpdateURLs", "StatusUpdateBroadcastChannelsEnabled", "StatusUpdateBroadcastWebhooksEnabled",
 			"CreateChannelMemberOnNewParticipant", "RemoveChannelMemberOnRemovedParticipant",
-			"COALESCE(CategoryName, '') CategoryName", "SummaryModifiedAt").
+			"i.ArchiveChannelOnFinishEnabled", "i.ArchiveChannelOnFinishDelayMinutes", "i.ArchiveChannelSkipIfPosted", "i.ChannelAutoArchiveAt",
+			"i.RetrospectiveRequired", "i.ChecklistItemDependenciesEnforced", "i.AutoFinishEnabled", "COALESCE(i.MergedIntoRunID, '') MergedIntoRunID",
+			"COALESCE(CategoryName, '') CategoryName", "SummaryModifiedAt", "i.PausedAt", "i.PausedDuration",
+			"i.StatusUpdateTemplatesJSON").
 		Column(participantsCol).
+		Column(viewersCol).
+		Column(coOwnersCol).
+		Column(tagsCol).
 		From("IR_Incident AS i")
 
 	statusPostsSelect := sqlStore.builder.
@@ -220,22 +295,30 @@ func NewPlaybookRunStore(pluginAPI PluginAPIClient, sqlStore *SQLStore) app.Play
 			"te.PostID",
 			"te.SubjectUserID",
 			"te.CreatorUserID",
+			"te.UserCreated",
 		).
 		From("IR_TimelineEvent as te")
 
 	metricsDataSelectSingleRun := sqlStore.builder.
-		Select("MetricConfigID", "Value").
+		Select("MetricConfigID", "Value", metricBreachedColumn).
 		From("IR_Metric AS m").
 		Join("IR_MetricConfig AS mc ON (mc.ID = m.MetricConfigID)").
 		Where("mc.DeleteAt = 0")
 
 	sqlMetricsDataSelectMultipleRuns := sqlStore.builder.
-		Select("IncidentID", "MetricConfigID", "Value").
+		Select("IncidentID", "MetricConfigID", "Value", metricBreachedColumn).
 		From("IR_Metric AS m").
 		Join("IR_MetricConfig AS mc ON (mc.ID = m.MetricConfigID)").
 		Where("mc.DeleteAt = 0").
 		OrderBy("mc.Ordering ASC")
 
+	propertyValuesSelect := sqlStore.builder.
+		Select("pv.IncidentID", "pv.PropertyDefinitionID", "pd.Name", "pv.Value").
+		From("IR_PropertyValue AS pv").
+		Join("IR_PropertyDefinition AS pd ON (pd.ID = pv.PropertyDefinitionID)").
+		Where("pd.DeleteAt = 0").
+		OrderBy("pd.Ordering ASC")
+
 	return &playbookRunStore{
 		pluginAPI:                        pluginAPI,
 		store:                            sqlStore,
@@ -245,6 +328,7 @@ func NewPlaybookRunStore(pluginAPI PluginAPIClient, sqlStore *SQLStore) app.Play
 		timelineEventsSelect:             timelineEventsSelect,
 		metricsDataSelectSingleRun:       metricsDataSelectSingleRun,
 		sqlMetricsDataSelectMultipleRuns: sqlMetricsDataSelectMultipleRuns,
+		propertyValuesSelect:             propertyValuesSelect,
 	}
 }
 
@@ -316,6 +400,33 @@ func (s *playbookRunStore) GetPlaybookRuns(requesterInfo app.RequesterInfo, opti
 		queryForTotal = queryForTotal.Where(myRunsClause)
 	}
 
+	if options.AssigneeID != "" {
+		assigneeClause, err := s.buildAssigneeClause(options.AssigneeID, app.ChecklistItemStateOpen)
+		if err != nil {
+			return nil, err
+		}
+
+		queryForResults = queryForResults.Where(assigneeClause)
+		queryForTotal = queryForTotal.Where(assigneeClause)
+	}
+
+	for _, propertyValue := range options.PropertyValues {
+		propertyClause := sq.Expr(`EXISTS(SELECT 1
+			FROM IR_PropertyValue AS pv
+			WHERE pv.IncidentID = i.ID
+			AND pv.PropertyDefinitionID = ?
+			AND pv.Value = ?)`, propertyValue.PropertyDefinitionID, propertyValue.Value)
+
+		queryForResults = queryForResults.Where(propertyClause)
+		queryForTotal = queryForTotal.Where(propertyClause)
+	}
+
+	if len(options.Tags) > 0 {
+		tagsClause := buildTagsClause(options.Tags, options.TagsMatchAll)
+		queryForResults = queryForResults.Where(tagsClause)
+		queryForTotal = queryForTotal.Where(tagsClause)
+	}
+
 	if options.PlaybookID != "" {
 		queryForResults = queryForResults.Where(sq.Eq{"i.PlaybookID": options.PlaybookID})
 		queryForTotal = queryForTotal.Where(sq.Eq{"i.PlaybookID": options.PlaybookID})
@@ -374,11 +485,60 @@ func (s *playbookRunStore) GetPlaybookRuns(requesterInfo app.RequesterInfo, opti
 	}
 	hasMore := options.Page+1 < pageCount
 
+	playbookRuns, err := s.toPlaybookRunsWithDetails(tx, rawPlaybookRuns)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "could not commit transaction")
+	}
+
+	return &app.GetPlaybookRunsResults{
+		TotalCount: total,
+		PageCount:  pageCount,
+		HasMore:    hasMore,
+		Items:      playbookRuns,
+	}, nil
+}
+
+// GetPlaybookRunsByIDs gets the playbook runs with the given IDs, in no particular order. IDs
+// without a run are ignored.
+func (s *playbookRunStore) GetPlaybookRunsByIDs(playbookRunIDs []string) ([]app.PlaybookRun, error) {
+	if len(playbookRunIDs) == 0 {
+		return []app.PlaybookRun{}, nil
+	}
+
+	tx, err := s.store.db.Beginx()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not begin transaction")
+	}
+	defer s.store.finalizeTransaction(tx)
+
+	var rawPlaybookRuns []sqlPlaybookRun
+	if err = s.store.selectBuilder(tx, &rawPlaybookRuns, s.playbookRunSelect.Where(sq.Eq{"i.ID": playbookRunIDs})); err != nil && err != sql.ErrNoRows {
+		return nil, errors.Wrap(err, "failed to query for playbook runs by ids")
+	}
+
+	playbookRuns, err := s.toPlaybookRunsWithDetails(tx, rawPlaybookRuns)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "could not commit transaction")
+	}
+
+	return playbookRuns, nil
+}
+
+// toPlaybookRunsWithDetails converts the raw runs, adding their status posts, timeline events,
+// metrics and property values. Each of them is fetched with a single query for all the runs.
+func (s *playbookRunStore) toPlaybookRunsWithDetails(q sqlx.Queryer, rawPlaybookRuns []sqlPlaybookRun) ([]app.PlaybookRun, error) {
 	playbookRuns := make([]app.PlaybookRun, 0, len(rawPlaybookRuns))
 	playbookRunIDs := make([]string, 0, len(rawPlaybookRuns))
 	for _, rawPlaybookRun := range rawPlaybookRuns {
-		var playbookRun *app.PlaybookRun
-		playbookRun, err = s.toPlaybookRun(rawPlaybookRun)
+		playbookRun, err := s.toPlaybookRun(rawPlaybookRun)
 		if err != nil {
 			return nil, err
 		}
@@ -392,37 +552,188 @@ func (s *playbookRunStore) GetPlaybookRuns(requesterInfo app.RequesterInfo, opti
 		OrderBy("p.CreateAt").
 		Where(sq.Eq{"sp.IncidentID": playbookRunIDs})
 
-	err = s.store.selectBuilder(tx, &statusPosts, postInfoSelect)
+	err := s.store.selectBuilder(q, &statusPosts, postInfoSelect)
 	if err != nil && err != sql.ErrNoRows {
 		return nil, errors.Wrap(err, "failed to get playbook run status posts")
 	}
 
-	timelineEvents, err := s.getTimelineEventsForPlaybookRun(tx, playbookRunIDs)
+	timelineEvents, err := s.getTimelineEventsForPlaybookRun(q, playbookRunIDs)
 	if err != nil {
 		return nil, err
 	}
 
-	metricsData, err := s.getMetricsForPlaybookRun(tx, playbookRunIDs)
+	metricsData, err := s.getMetricsForPlaybookRun(q, playbookRunIDs)
 	if err != nil {
 		return nil, err
 	}
 
-	if err = tx.Commit(); err != nil {
-		return nil, errors.Wrap(err, "could not commit transaction")
+	propertyValues, err := s.getPropertyValuesForPlaybookRuns(q, playbookRunIDs)
+	if err != nil {
+		return nil, err
 	}
 
 	addStatusPostsToPlaybookRuns(statusPosts, playbookRuns)
 	addTimelineEventsToPlaybookRuns(timelineEvents, playbookRuns)
 	addMetricsToPlaybookRuns(metricsData, playbookRuns)
+	addPropertyValuesToPlaybookRuns(propertyValues, playbookRuns)
 
-	return &app.GetPlaybookRunsResults{
+	return playbookRuns, nil
+}
+
+// SearchStatusUpdates returns the status updates of the runs in teamID matching term, newest
+// first. The search relies on the full-text index of the Posts table, falling back to a LIKE query
+// when the database is unable to run the full-text search, e.g. if the index is missing.
+func (s *playbookRunStore) SearchStatusUpdates(requesterInfo app.RequesterInfo, teamID, term string, options app.StatusUpdateSearchOptions) (*app.SearchStatusUpdatesResults, error) {
+	if strings.TrimSpace(term) == "" {
+		return nil, errors.New("search term should not be empty")
+	}
+
+	results, err := s.searchStatusUpdates(requesterInfo, teamID, term, options, s.buildStatusUpdateFullTextExpr(term))
+	if err != nil {
+		logrus.WithError(err).Warn("full-text search of status updates failed, falling back to LIKE")
+		results, err = s.searchStatusUpdates(requesterInfo, teamID, term, options, s.buildStatusUpdateLikeExpr(term))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (s *playbookRunStore) searchStatusUpdates(requesterInfo app.RequesterInfo, teamID, term string, options app.StatusUpdateSearchOptions, matchExpr sq.Sqlizer) (*app.SearchStatusUpdatesResults, error) {
+	statusUpdatesSelect := s.store.builder.
+		Select().
+		From("IR_StatusPosts AS sp").
+		Join("IR_Incident AS i ON i.ID = sp.IncidentID").
+		Join("Posts AS p ON p.Id = sp.PostID").
+		Where(sq.Eq{"p.DeleteAt": 0}).
+		Where(s.buildPermissionsExpr(requesterInfo)).
+		Where(buildTeamLimitExpr(requesterInfo, teamID, "i")).
+		Where(matchExpr)
+
+	queryForResults := statusUpdatesSelect.
+		Columns("i.ID AS PlaybookRunID", "i.Name AS PlaybookRunName", "p.Id AS PostID", "p.Message", "p.CreateAt").
+		OrderBy("p.CreateAt DESC", "p.Id")
+	queryForTotal := statusUpdatesSelect.Columns("COUNT(*)")
+
+	if options.PerPage > 0 {
+		page := options.Page
+		if page < 0 {
+			page = 0
+		}
+		queryForResults = queryForResults.
+			Offset(uint64(page * options.PerPage)).
+			Limit(uint64(options.PerPage))
+	}
+
+	var rawResults []struct {
+		PlaybookRunID   string
+		PlaybookRunName string
+		PostID          string
+		Message         string
+		CreateAt        int64
+	}
+	if err := s.store.selectBuilder(s.store.db, &rawResults, queryForResults); err != nil && err != sql.ErrNoRows {
+		return nil, errors.Wrap(err, "failed to search status updates")
+	}
+
+	var total int
+	if err := s.store.getBuilder(s.store.db, &total, queryForTotal); err != nil {
+		return nil, errors.Wrap(err, "failed to get total count of matching status updates")
+	}
+
+	pageCount := 0
+	if options.PerPage > 0 {
+		pageCount = int(math.Ceil(float64(total) / float64(options.PerPage)))
+	}
+
+	items := make([]app.StatusUpdateSearchResult, 0, len(rawResults))
+	for _, rawResult := range rawResults {
+		items = append(items, app.StatusUpdateSearchResult{
+			PlaybookRunID:   rawResult.PlaybookRunID,
+			PlaybookRunName: rawResult.PlaybookRunName,
+			PostID:          rawResult.PostID,
+			Snippet:         statusUpdateSnippet(rawResult.Message, term),
+			CreateAt:        rawResult.CreateAt,
+		})
+	}
+
+	return &app.SearchStatusUpdatesResults{
 		TotalCount: total,
 		PageCount:  pageCount,
-		HasMore:    hasMore,
-		Items:      playbookRuns,
+		HasMore:    options.Page+1 < pageCount,
+		Items:      items,
 	}, nil
 }
 
+// buildStatusUpdateFullTextExpr matches the posts whose message matches term, using the same
+// expressions as the full-text indexes created by the server on the Posts table.
+func (s *playbookRunStore) buildStatusUpdateFullTextExpr(term string) sq.Sqlizer {
+	if s.store.db.DriverName() == model.DatabaseDriverMysql {
+		return sq.Expr("MATCH (p.Message) AGAINST (? IN NATURAL LANGUAGE MODE)", term)
+	}
+
+	return sq.Expr("to_tsvector('english', p.Message) @@ plainto_tsquery('english', ?)", term)
+}
+
+// buildStatusUpdateLikeExpr matches the posts whose message contains term.
+func (s *playbookRunStore) buildStatusUpdateLikeExpr(term string) sq.Sqlizer {
+	pattern := "%" + likeEscaper.Replace(term) + "%"
+
+	// Postgres performs a case-sensitive search, so we need to lowercase
+	// both the column contents and the search string
+	if s.store.db.DriverName() == model.DatabaseDriverPostgres {
+		return sq.Like{"LOWER(p.Message)": strings.ToLower(pattern)}
+	}
+
+	return sq.Like{"p.Message": pattern}
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// statusUpdateSnippetRadius is the number of characters kept on each side of the match in a snippet.
+const statusUpdateSnippetRadius = 80
+
+// statusUpdateSnippet returns the part of message around the first occurrence of any of the words
+// of term. If none is found, e.g. because the full-text search matched another form of the word,
+// the beginning of the message is returned.
+func statusUpdateSnippet(message, term string) string {
+	lowerMessage := strings.ToLower(message)
+	matchStart, matchLen := -1, 0
+	for _, word := range strings.Fields(strings.ToLower(term)) {
+		index := strings.Index(lowerMessage, word)
+		if index < 0 {
+			continue
+		}
+		if start := utf8.RuneCountInString(lowerMessage[:index]); matchStart < 0 || start < matchStart {
+			matchStart, matchLen = start, utf8.RuneCountInString(word)
+		}
+	}
+
+	runes := []rune(message)
+	from, to := 0, 2*statusUpdateSnippetRadius
+	if matchStart >= 0 {
+		from = matchStart - statusUpdateSnippetRadius
+		to = matchStart + matchLen + statusUpdateSnippetRadius
+	}
+	if from < 0 {
+		from = 0
+	}
+	if to > len(runes) {
+		to = len(runes)
+	}
+
+	snippet := strings.TrimSpace(string(runes[from:to]))
+	if from > 0 {
+		snippet = "…" + snippet
+	}
+	if to < len(runes) {
+		snippet += "…"
+	}
+
+	return snippet
+}
+
 // CreatePlaybookRun creates a new playbook run. If playbook run has an ID, that ID will be used.
 func (s *playbookRunStore) CreatePlaybookRun(playbookRun *app.PlaybookRun) (*app.PlaybookRun, error) {
 	if playbookRun == nil {
@@ -462,10 +773,12 @@ func (s *playbookRunStore) CreatePlaybookRun(playbookRun *app.PlaybookRun) (*app
 			"ReminderPostID":                          rawPlaybookRun.ReminderPostID,
 			"PreviousReminder":                        rawPlaybookRun.PreviousReminder,
 			"ReminderMessageTemplate":                 rawPlaybookRun.ReminderMessageTemplate,
+			"StatusUpdateTemplatesJSON":               rawPlaybookRun.StatusUpdateTemplatesJSON,
 			"StatusUpdateEnabled":                     rawPlaybookRun.StatusUpdateEnabled,
 			"ReminderTimerDefaultSeconds":             rawPlaybookRun.ReminderTimerDefaultSeconds,
 			"CurrentStatus":                           rawPlaybookRun.CurrentStatus,
 			"LastStatusUpdateAt":                      rawPlaybookRun.LastStatusUpdateAt,
+			"LastActivityAt":                          rawPlaybookRun.CreateAt,
 			"ConcatenatedInvitedUserIDs":              rawPlaybookRun.ConcatenatedInvitedUserIDs,
 			"ConcatenatedInvitedGroupIDs":             rawPlaybookRun.ConcatenatedInvitedGroupIDs,
 			"DefaultCommanderID":                      rawPlaybookRun.DefaultOwnerID,
@@ -483,6 +796,14 @@ func (s *playbookRunStore) CreatePlaybookRun(playbookRun *app.PlaybookRun) (*app
 			"StatusUpdateBroadcastWebhooksEnabled":    rawPlaybookRun.StatusUpdateBroadcastWebhooksEnabled,
 			"CreateChannelMemberOnNewParticipant":     rawPlaybookRun.CreateChannelMemberOnNewParticipant,
 			"RemoveChannelMemberOnRemovedParticipant": rawPlaybookRun.RemoveChannelMemberOnRemovedParticipant,
+			"ArchiveChannelOnFinishEnabled":           rawPlaybookRun.ArchiveChannelOnFinishEnabled,
+			"ArchiveChannelOnFinishDelayMinutes":      rawPlaybookRun.ArchiveChannelOnFinishDelayMinutes,
+			"ArchiveChannelSkipIfPosted":              rawPlaybookRun.ArchiveChannelSkipIfPosted,
+			"RetrospectiveRequired":                   rawPlaybookRun.RetrospectiveRequired,
+			"ChecklistItemDependenciesEnforced":       rawPlaybookRun.ChecklistItemDependenciesEnforced,
+			"AutoFinishEnabled":                       rawPlaybookRun.AutoFinishEnabled,
+			"PausedAt":                                rawPlaybookRun.PausedAt,
+			"PausedDuration":                          rawPlaybookRun.PausedDuration,
 			// Preserved for backwards compatibility with v1.2
 			"ActiveStage":      0,
 			"ActiveStageTitle": "",
@@ -547,6 +868,12 @@ func (s *playbookRunStore) UpdatePlaybookRun(playbookRun *app.PlaybookRun) (*app
 			"StatusUpdateEnabled":                     rawPlaybookRun.StatusUpdateEnabled,
 			"CreateChannelMemberOnNewParticipant":     rawPlaybookRun.CreateChannelMemberOnNewParticipant,
 			"RemoveChannelMemberOnRemovedParticipant": rawPlaybookRun.RemoveChannelMemberOnRemovedParticipant,
+			"ArchiveChannelOnFinishEnabled":           rawPlaybookRun.ArchiveChannelOnFinishEnabled,
+			"ArchiveChannelOnFinishDelayMinutes":      rawPlaybookRun.ArchiveChannelOnFinishDelayMinutes,
+			"ArchiveChannelSkipIfPosted":              rawPlaybookRun.ArchiveChannelSkipIfPosted,
+			"RetrospectiveRequired":                   rawPlaybookRun.RetrospectiveRequired,
+			"ChecklistItemDependenciesEnforced":       rawPlaybookRun.ChecklistItemDependenciesEnforced,
+			"AutoFinishEnabled":                       rawPlaybookRun.AutoFinishEnabled,
 		}).
 		Where(sq.Eq{"ID": rawPlaybookRun.ID}))
 
@@ -565,6 +892,161 @@ func (s *playbookRunStore) UpdatePlaybookRun(playbookRun *app.PlaybookRun) (*app
 	return playbookRun, nil
 }
 
+// ReorderChecklistItems sets the order of the items of a run's checklist in a single transaction.
+func (s *playbookRunStore) ReorderChecklistItems(playbookRunID string, checklistNumber int, itemIDs []string) error {
+	tx, err := s.store.db.Beginx()
+	if err != nil {
+		return errors.Wrap(err, "could not begin transaction")
+	}
+	defer s.store.finalizeTransaction(tx)
+
+	var rawChecklists json.RawMessage
+	err = s.store.getBuilder(tx, &rawChecklists, sq.
+		Select("ChecklistsJSON").
+		From("IR_Incident").
+		Where(sq.Eq{"ID": playbookRunID}).
+		Suffix("FOR UPDATE"))
+	if err == sql.ErrNoRows {
+		return errors.Wrapf(app.ErrNotFound, "playbook run with id '%s' does not exist", playbookRunID)
+	} else if err != nil {
+		return errors.Wrapf(err, "failed to get checklists for playbook run with id '%s'", playbookRunID)
+	}
+
+	var checklists []app.Checklist
+	if err = json.Unmarshal(rawChecklists, &checklists); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal checklists json for playbook run id '%s'", playbookRunID)
+	}
+
+	if checklistNumber < 0 || checklistNumber >= len(checklists) {
+		return errors.Wrapf(app.ErrNotFound, "checklist %d does not exist in playbook run with id '%s'", checklistNumber, playbookRunID)
+	}
+
+	items, err := checklists[checklistNumber].ReorderItems(itemIDs)
+	if err != nil {
+		return err
+	}
+	checklists[checklistNumber].Items = items
+
+	checklistsJSON, err := checklistsToJSON(checklists)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal checklist json for playbook run id '%s'", playbookRunID)
+	}
+
+	_, err = s.store.execBuilder(tx, sq.
+		Update("IR_Incident").
+		Set("ChecklistsJSON", checklistsJSON).
+		Where(sq.Eq{"ID": playbookRunID}))
+	if err != nil {
+		return errors.Wrapf(err, "failed to update checklists for playbook run with id '%s'", playbookRunID)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(err, "could not commit transaction")
+	}
+
+	return nil
+}
+
+// GetRunIDsWithIncompleteTasks returns the unfinished runs with checklist items assigned to
+// assigneeID that are not done or skipped yet, optionally limited to teamID and playbookRunIDs.
+func (s *playbookRunStore) GetRunIDsWithIncompleteTasks(assigneeID, teamID string, playbookRunIDs []string) ([]string, error) {
+	openClause, err := s.buildAssigneeClause(assigneeID, app.ChecklistItemStateOpen)
+	if err != nil {
+		return nil, err
+	}
+	inProgressClause, err := s.buildAssigneeClause(assigneeID, app.ChecklistItemStateInProgress)
+	if err != nil {
+		return nil, err
+	}
+
+	query := sq.
+		Select("i.ID").
+		From("IR_Incident AS i").
+		Where(sq.NotEq{"i.CurrentStatus": []string{app.StatusFinished, app.StatusMerged}}).
+		Where(sq.Or{openClause, inProgressClause}).
+		OrderBy("i.ID")
+
+	if teamID != "" {
+		query = query.Where(sq.Eq{"i.TeamID": teamID})
+	}
+	if len(playbookRunIDs) > 0 {
+		query = query.Where(sq.Eq{"i.ID": playbookRunIDs})
+	}
+
+	var ids []string
+	if err := s.store.selectBuilder(s.store.db, &ids, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get runs with tasks assigned to user '%s'", assigneeID)
+	}
+
+	return ids, nil
+}
+
+// ReassignChecklistItems assigns to toUserID the checklist items of fromUserID that are not done
+// or skipped yet in every given run, in a single transaction. Returns the number of reassigned
+// items by run; runs without items to reassign are left untouched.
+func (s *playbookRunStore) ReassignChecklistItems(playbookRunIDs []string, fromUserID, toUserID string, modifiedAt int64) (map[string]int, error) {
+	reassigned := make(map[string]int)
+	if len(playbookRunIDs) == 0 {
+		return reassigned, nil
+	}
+
+	tx, err := s.store.db.Beginx()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not begin transaction")
+	}
+	defer s.store.finalizeTransaction(tx)
+
+	var rows []struct {
+		ID             string
+		ChecklistsJSON json.RawMessage
+	}
+	err = s.store.selectBuilder(tx, &rows, sq.
+		Select("ID", "ChecklistsJSON").
+		From("IR_Incident").
+		Where(sq.Eq{"ID": playbookRunIDs}).
+		OrderBy("ID").
+		Suffix("FOR UPDATE"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get checklists of playbook runs")
+	}
+
+	for _, row := range rows {
+		var checklists []app.Checklist
+		if err = json.Unmarshal(row.ChecklistsJSON, &checklists); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal checklists json for playbook run id '%s'", row.ID)
+		}
+
+		count := 0
+		for i := range checklists {
+			count += checklists[i].ReassignItems(fromUserID, toUserID, modifiedAt)
+		}
+		if count == 0 {
+			continue
+		}
+
+		checklistsJSON, err := checklistsToJSON(checklists)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to marshal checklist json for playbook run id '%s'", row.ID)
+		}
+
+		_, err = s.store.execBuilder(tx, sq.
+			Update("IR_Incident").
+			Set("ChecklistsJSON", checklistsJSON).
+			Where(sq.Eq{"ID": row.ID}))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to update checklists for playbook run with id '%s'", row.ID)
+		}
+
+		reassigned[row.ID] = count
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "could not commit transaction")
+	}
+
+	return reassigned, nil
+}
+
 func (s *playbookRunStore) UpdateStatus(statusPost *app.SQLStatusPost) error {
 	if statusPost == nil {
 		return errors.New("status post is nil")
@@ -588,16 +1070,137 @@ func (s *playbookRunStore) UpdateStatus(statusPost *app.SQLStatusPost) error {
 	return nil
 }
 
-func (s *playbookRunStore) FinishPlaybookRun(playbookRunID string, endAt int64) error {
-	if _, err := s.store.execBuilder(s.store.db, sq.
+// FinishPlaybookRun finishes a run at endAt (in millis), scheduling the archive of its channel if
+// enabled. Returns false if the run had already ended, in which case it is left unchanged.
+func (s *playbookRunStore) FinishPlaybookRun(playbookRunID string, endAt int64) (bool, error) {
+	// A paused run is resumed when finished.
+	result, err := s.store.execBuilder(s.store.db, endPause(sq.
 		Update("IR_Incident").
-		SetMap(map[string]interface{}{
-			"CurrentStatus": app.StatusFinished,
-			"EndAt":         endAt,
-		}).
-		Where(sq.Eq{"ID": playbookRunID}),
-	); err != nil {
-		return errors.Wrapf(err, "failed to finish run for id '%s'", playbookRunID)
+		Set("CurrentStatus", app.StatusFinished).
+		Set("EndAt", endAt), endAt).
+		Set("ChannelAutoArchiveAt", sq.Expr("CASE WHEN ArchiveChannelOnFinishEnabled THEN ? + ArchiveChannelOnFinishDelayMinutes * 60000 ELSE 0 END", endAt)).
+		Where(sq.Eq{"ID": playbookRunID}).
+		Where(sq.NotEq{"CurrentStatus": []string{app.StatusFinished, app.StatusMerged}}),
+	)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to finish run for id '%s'", playbookRunID)
+	}
+
+	return statusChanged(result, playbookRunID)
+}
+
+// MergePlaybookRuns stores a merge of runs in a single transaction, ending the source run as
+// merged into the target run. Returns ErrPlaybookRunNotActive if either run has ended.
+func (s *playbookRunStore) MergePlaybookRuns(merge app.RunMerge) error {
+	tx, err := s.store.db.Beginx()
+	if err != nil {
+		return errors.Wrap(err, "could not begin transaction")
+	}
+	defer s.store.finalizeTransaction(tx)
+
+	// Both runs are locked before checking that they are active, so that they can't end while
+	// being merged, and the checklists of the target can't change before the source's are
+	// appended. They are locked in the order of their IDs to avoid deadlocking with a merge of
+	// the same runs in the opposite direction.
+	var lockedRuns []struct {
+		ID             string
+		CurrentStatus  string
+		ChecklistsJSON json.RawMessage
+	}
+	if err = s.store.selectBuilder(tx, &lockedRuns, sq.
+		Select("ID", "CurrentStatus", "ChecklistsJSON").
+		From("IR_Incident").
+		Where(sq.Eq{"ID": []string{merge.TargetRunID, merge.SourceRunID}}).
+		OrderBy("ID").
+		Suffix("FOR UPDATE")); err != nil {
+		return errors.Wrapf(err, "failed to lock playbook runs '%s' and '%s'", merge.TargetRunID, merge.SourceRunID)
+	}
+
+	statuses := make(map[string]string, len(lockedRuns))
+	var targetChecklists []app.Checklist
+	for _, run := range lockedRuns {
+		statuses[run.ID] = run.CurrentStatus
+		if run.ID != merge.TargetRunID {
+			continue
+		}
+		if err = json.Unmarshal(run.ChecklistsJSON, &targetChecklists); err != nil {
+			return errors.Wrapf(err, "failed to unmarshal checklists json for playbook run id '%s'", merge.TargetRunID)
+		}
+	}
+	for _, playbookRunID := range []string{merge.TargetRunID, merge.SourceRunID} {
+		status, ok := statuses[playbookRunID]
+		if !ok {
+			return errors.Wrapf(app.ErrNotFound, "playbook run with id '%s' does not exist", playbookRunID)
+		}
+		if status != app.StatusInProgress && status != app.StatusPaused {
+			return errors.Wrapf(app.ErrPlaybookRunNotActive, "run '%s' has ended", playbookRunID)
+		}
+	}
+
+	checklistsJSON, err := checklistsToJSON(append(targetChecklists, merge.SourceChecklists...))
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal checklist json for playbook run id '%s'", merge.TargetRunID)
+	}
+
+	// The rows affected by the updates are not checked: MySQL doesn't count a row whose values
+	// don't change, such as the checklists of the target when the source has none.
+	if _, err = s.store.execBuilder(tx, sq.
+		Update("IR_Incident").
+		Set("ChecklistsJSON", checklistsJSON).
+		Where(sq.Eq{"ID": merge.TargetRunID})); err != nil {
+		return errors.Wrapf(err, "failed to update checklists for playbook run with id '%s'", merge.TargetRunID)
+	}
+
+	// A paused run is resumed when merged, like when finished.
+	if _, err = s.store.execBuilder(tx, endPause(sq.
+		Update("IR_Incident").
+		Set("CurrentStatus", app.StatusMerged).
+		Set("MergedIntoRunID", merge.TargetRunID).
+		Set("EndAt", merge.MergedAt), merge.MergedAt).
+		Set("ChannelAutoArchiveAt", 0).
+		Where(sq.Eq{"ID": merge.SourceRunID})); err != nil {
+		return errors.Wrapf(err, "failed to mark run for id '%s' as merged", merge.SourceRunID)
+	}
+
+	if len(merge.ParticipantIDs) > 0 {
+		if err = s.updateParticipating(tx, merge.TargetRunID, merge.ParticipantIDs, true); err != nil {
+			return err
+		}
+	}
+
+	if err = s.addRunTags(tx, merge.TargetRunID, merge.Tags); err != nil {
+		return err
+	}
+
+	if len(merge.StatusPostIDs) > 0 {
+		query := sq.
+			Insert("IR_StatusPosts").
+			Columns("IncidentID", "PostID")
+		for _, postID := range merge.StatusPostIDs {
+			query = query.Values(merge.TargetRunID, postID)
+		}
+
+		if s.store.db.DriverName() == model.DatabaseDriverMysql {
+			_, err = s.store.execBuilder(tx, query.Suffix("ON DUPLICATE KEY UPDATE PostID = PostID"))
+		} else {
+			_, err = s.store.execBuilder(tx, query.Suffix("ON CONFLICT (IncidentID,PostID) DO NOTHING"))
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to add status posts to run '%s'", merge.TargetRunID)
+		}
+	}
+
+	for _, event := range []*app.TimelineEvent{merge.TargetEvent, merge.SourceEvent} {
+		if event == nil {
+			continue
+		}
+		if err = s.createTimelineEvent(tx, event); err != nil {
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(err, "could not commit transaction")
 	}
 
 	return nil
@@ -607,9 +1210,10 @@ func (s *playbookRunStore) RestorePlaybookRun(playbookRunID string, restoredAt i
 	if _, err := s.store.execBuilder(s.store.db, sq.
 		Update("IR_Incident").
 		SetMap(map[string]interface{}{
-			"CurrentStatus":      app.StatusInProgress,
-			"EndAt":              0,
-			"LastStatusUpdateAt": restoredAt,
+			"CurrentStatus":        app.StatusInProgress,
+			"EndAt":                0,
+			"LastStatusUpdateAt":   restoredAt,
+			"ChannelAutoArchiveAt": 0,
 		}).
 		Where(sq.Eq{"ID": playbookRunID})); err != nil {
 		return errors.Wrapf(err, "failed to restore run for id '%s'", playbookRunID)
@@ -618,6 +1222,59 @@ func (s *playbookRunStore) RestorePlaybookRun(playbookRunID string, restoredAt i
 	return nil
 }
 
+// PausePlaybookRun pauses a run at pausedAt (in millis). Returns false if the run was not in
+// progress, in which case it is left unchanged.
+func (s *playbookRunStore) PausePlaybookRun(playbookRunID string, pausedAt int64) (bool, error) {
+	result, err := s.store.execBuilder(s.store.db, sq.
+		Update("IR_Incident").
+		SetMap(map[string]interface{}{
+			"CurrentStatus": app.StatusPaused,
+			"PausedAt":      pausedAt,
+		}).
+		Where(sq.Eq{"ID": playbookRunID, "CurrentStatus": app.StatusInProgress}))
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to pause run for id '%s'", playbookRunID)
+	}
+
+	return statusChanged(result, playbookRunID)
+}
+
+// ResumePlaybookRun resumes a run at resumedAt (in millis), adding the time since it was paused
+// to its paused duration. Returns false if the run was not paused, in which case it is left
+// unchanged.
+func (s *playbookRunStore) ResumePlaybookRun(playbookRunID string, resumedAt int64) (bool, error) {
+	result, err := s.store.execBuilder(s.store.db, endPause(sq.
+		Update("IR_Incident").
+		Set("CurrentStatus", app.StatusInProgress), resumedAt).
+		Where(sq.Eq{"ID": playbookRunID, "CurrentStatus": app.StatusPaused}))
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to resume run for id '%s'", playbookRunID)
+	}
+
+	return statusChanged(result, playbookRunID)
+}
+
+// endPause adds to the update the assignments ending the pause of a run at endAt, if it is
+// paused, accumulating its paused time. PausedDuration must be set before PausedAt, since MySQL
+// evaluates the assignments from left to right.
+func endPause(update sq.UpdateBuilder, endAt int64) sq.UpdateBuilder {
+	return update.
+		Set("PausedDuration", sq.Expr("PausedDuration + CASE WHEN PausedAt > 0 THEN ? - PausedAt ELSE 0 END", endAt)).
+		Set("PausedAt", 0)
+}
+
+// statusChanged is true if the status update of a run, which is conditioned on its current
+// status, changed a row. The status always differs when the update happens, so MySQL, which only
+// counts the rows it changed, reports it too.
+func statusChanged(result sql.Result, playbookRunID string) (bool, error) {
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to get the rows affected by the update of run '%s'", playbookRunID)
+	}
+
+	return rows > 0, nil
+}
+
 // CreateTimelineEvent creates the timeline event
 func (s *playbookRunStore) CreateTimelineEvent(event *app.TimelineEvent) (*app.TimelineEvent, error) {
 	if event.PlaybookRunID == "" {
@@ -626,6 +1283,27 @@ func (s *playbookRunStore) CreateTimelineEvent(event *app.TimelineEvent) (*app.T
 	if event.EventType == "" {
 		return nil, errors.New("needs event type")
 	}
+
+	tx, err := s.store.db.Beginx()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not begin transaction")
+	}
+	defer s.store.finalizeTransaction(tx)
+
+	if err = s.createTimelineEvent(tx, event); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "could not commit transaction")
+	}
+
+	return event, nil
+}
+
+// createTimelineEvent inserts event within tx, updating the last activity of its run if the event
+// counts as activity.
+func (s *playbookRunStore) createTimelineEvent(tx *sqlx.Tx, event *app.TimelineEvent) error {
 	if event.CreateAt == 0 {
 		event.CreateAt = model.GetMillis()
 	}
@@ -636,7 +1314,7 @@ func (s *playbookRunStore) CreateTimelineEvent(event *app.TimelineEvent) (*app.T
 		eventType = legacyEventTypeCommanderChanged
 	}
 
-	_, err := s.store.execBuilder(s.store.db, sq.
+	_, err := s.store.execBuilder(tx, sq.
 		Insert("IR_TimelineEvent").
 		SetMap(map[string]interface{}{
 			"ID":            event.ID,
@@ -650,13 +1328,32 @@ func (s *playbookRunStore) CreateTimelineEvent(event *app.TimelineEvent) (*app.T
 			"PostID":        event.PostID,
 			"SubjectUserID": event.SubjectUserID,
 			"CreatorUserID": event.CreatorUserID,
+			"UserCreated":   event.UserCreated,
 		}))
 
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to insert timeline event")
+		return errors.Wrap(err, "failed to insert timeline event")
 	}
 
-	return event, nil
+	// The last activity is kept on the run so that stale runs are found without reading the
+	// timeline of every run. It only moves forward, in case events are not created in order.
+	if event.EventType.IsRunActivity() {
+		activityAt := event.EventAt
+		if activityAt == 0 {
+			activityAt = event.CreateAt
+		}
+
+		_, err = s.store.execBuilder(tx, sq.
+			Update("IR_Incident").
+			Set("LastActivityAt", activityAt).
+			Where(sq.Eq{"ID": event.PlaybookRunID}).
+			Where(sq.Lt{"LastActivityAt": activityAt}))
+		if err != nil {
+			return errors.Wrap(err, "failed to update the last activity of the playbook run")
+		}
+	}
+
+	return nil
 }
 
 // UpdateTimelineEvent updates (or inserts) the timeline event
@@ -689,6 +1386,7 @@ func (s *playbookRunStore) UpdateTimelineEvent(event *app.TimelineEvent) error {
 			"PostID":        event.PostID,
 			"SubjectUserID": event.SubjectUserID,
 			"CreatorUserID": event.CreatorUserID,
+			"UserCreated":   event.UserCreated,
 		}).
 		Where(sq.Eq{"ID": event.ID}))
 
@@ -750,6 +1448,11 @@ func (s *playbookRunStore) GetPlaybookRun(playbookRunID string) (*app.PlaybookRu
 		return nil, errors.Wrapf(err, "failed to get metrics data for run with id `%s`", playbookRunID)
 	}
 
+	propertyValues, err := s.getPropertyValuesForPlaybookRuns(tx, []string{playbookRunID})
+	if err != nil {
+		return nil, err
+	}
+
 	if err = tx.Commit(); err != nil {
 		return nil, errors.Wrap(err, "could not commit transaction")
 	}
@@ -760,6 +1463,105 @@ func (s *playbookRunStore) GetPlaybookRun(playbookRunID string) (*app.PlaybookRu
 
 	playbookRun.TimelineEvents = append(playbookRun.TimelineEvents, timelineEvents...)
 	playbookRun.MetricsData = metricsData
+	for _, v := range propertyValues {
+		playbookRun.PropertyValues = append(playbookRun.PropertyValues, v.PropertyValue)
+	}
+
+	return playbookRun, nil
+}
+
+// GetPlaybookRunActivity gets a playbook run by ID with what its activity feed after the position
+// (afterAt, afterID) is built from: its checklists, the first limit of its status posts and
+// timeline events after the position, ordered by time and then by ID, and the status update
+// events of those posts. Deleted status posts and timeline events are left out. Timeline events
+// recording the completion of a checklist item don't count toward the limit, since the feed shows
+// the item instead.
+func (s *playbookRunStore) GetPlaybookRunActivity(playbookRunID string, afterAt int64, afterID string, limit int) (*app.PlaybookRun, error) {
+	if playbookRunID == "" {
+		return nil, errors.New("ID cannot be empty")
+	}
+
+	tx, err := s.store.db.Beginx()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not begin transaction")
+	}
+	defer s.store.finalizeTransaction(tx)
+
+	var rawPlaybookRun sqlPlaybookRun
+	err = s.store.getBuilder(tx, &rawPlaybookRun, s.playbookRunSelect.Where(sq.Eq{"i.ID": playbookRunID}))
+	if err == sql.ErrNoRows {
+		return nil, errors.Wrapf(app.ErrNotFound, "playbook run with id '%s' does not exist", playbookRunID)
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "failed to get playbook run by id '%s'", playbookRunID)
+	}
+
+	playbookRun, err := s.toPlaybookRun(rawPlaybookRun)
+	if err != nil {
+		return nil, err
+	}
+
+	var statusPosts playbookRunStatusPosts
+	err = s.store.selectBuilder(tx, &statusPosts, s.statusPostsSelect.
+		Where(sq.Eq{"sp.IncidentID": playbookRunID}).
+		Where(sq.Eq{"p.DeleteAt": 0}).
+		Where(sq.Or{
+			sq.Gt{"p.CreateAt": afterAt},
+			sq.And{sq.Eq{"p.CreateAt": afterAt}, sq.Gt{"p.Id": afterID}},
+		}).
+		OrderBy("p.CreateAt ASC", "p.Id ASC").
+		Limit(uint64(limit)))
+	if err != nil && err != sql.ErrNoRows {
+		return nil, errors.Wrapf(err, "failed to get playbook run status posts for playbook run with id '%s'", playbookRunID)
+	}
+
+	postIDs := make([]string, 0, len(statusPosts))
+	for _, p := range statusPosts {
+		playbookRun.StatusPosts = append(playbookRun.StatusPosts, p.StatusPost)
+		postIDs = append(postIDs, p.ID)
+	}
+
+	if len(postIDs) > 0 {
+		var statusUpdateEvents []app.TimelineEvent
+		err = s.store.selectBuilder(tx, &statusUpdateEvents, s.timelineEventsSelect.
+			Where(sq.Eq{"te.IncidentID": playbookRunID}).
+			Where(sq.Eq{"te.DeleteAt": 0}).
+			Where(sq.Eq{"te.EventType": app.StatusUpdated}).
+			Where(sq.Eq{"te.PostID": postIDs}))
+		if err != nil && err != sql.ErrNoRows {
+			return nil, errors.Wrapf(err, "failed to get status update events for playbook run with id '%s'", playbookRunID)
+		}
+		playbookRun.TimelineEvents = append(playbookRun.TimelineEvents, statusUpdateEvents...)
+	}
+
+	// Up to one event per completed item is merged into the item.
+	for _, checklist := range playbookRun.Checklists {
+		for _, item := range checklist.Items {
+			if item.State == app.ChecklistItemStateClosed && item.StateModified != 0 {
+				limit++
+			}
+		}
+	}
+
+	var timelineEvents []app.TimelineEvent
+	err = s.store.selectBuilder(tx, &timelineEvents, s.timelineEventsSelect.
+		Where(sq.Eq{"te.IncidentID": playbookRunID}).
+		Where(sq.Eq{"te.DeleteAt": 0}).
+		Where(sq.NotEq{"te.EventType": app.StatusUpdated}).
+		Where(sq.Or{
+			sq.Gt{"te.EventAt": afterAt},
+			sq.And{sq.Eq{"te.EventAt": afterAt}, sq.Gt{"te.ID": afterID}},
+		}).
+		OrderBy("te.EventAt ASC", "te.ID ASC").
+		Limit(uint64(limit)))
+	if err != nil && err != sql.ErrNoRows {
+		return nil, errors.Wrapf(err, "failed to get timeline events for playbook run with id '%s'", playbookRunID)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, errors.Wrap(err, "could not commit transaction")
+	}
+
+	playbookRun.TimelineEvents = append(playbookRun.TimelineEvents, timelineEvents...)
 
 	return playbookRun, nil
 }
@@ -793,6 +1595,18 @@ func (s *playbookRunStore) getMetricsForPlaybookRun(q sqlx.Queryer, playbookRunI
 	return metricsData, nil
 }
 
+func (s *playbookRunStore) getPropertyValuesForPlaybookRuns(q sqlx.Queryer, playbookRunIDs []string) ([]sqlPropertyValue, error) {
+	var propertyValues []sqlPropertyValue
+
+	err := s.store.selectBuilder(q, &propertyValues, s.propertyValuesSelect.
+		Where(sq.Eq{"pv.IncidentID": playbookRunIDs}))
+	if err != nil && err != sql.ErrNoRows {
+		return nil, errors.Wrap(err, "failed to get property values")
+	}
+
+	return propertyValues, nil
+}
+
 // GetTimelineEvent returns the timeline event by id for the given playbook run.
 func (s *playbookRunStore) GetTimelineEvent(playbookRunID, eventID string) (*app.TimelineEvent, error) {
 	var event app.TimelineEvent
@@ -828,6 +1642,60 @@ func (s *playbookRunStore) GetPlaybookRunIDForChannel(channelID string) (string,
 	return id, nil
 }
 
+// GetActivePlaybookRunIDsForChannel gets the IDs of the unfinished playbook runs associated with
+// the given channel ID.
+func (s *playbookRunStore) GetActivePlaybookRunIDsForChannel(channelID string) ([]string, error) {
+	query := s.queryBuilder.
+		Select("i.ID").
+		From("IR_Incident i").
+		Where(sq.Eq{"i.ChannelID": channelID}).
+		Where(sq.NotEq{"i.CurrentStatus": []string{app.StatusFinished, app.StatusMerged}})
+
+	var ids []string
+	if err := s.store.selectBuilder(s.store.db, &ids, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get active playbook runs by channelID '%s'", channelID)
+	}
+
+	return ids, nil
+}
+
+// GetPlaybookRunsDueForChannelArchive returns the finished runs whose channel was due to be
+// archived at or before now, in millis.
+func (s *playbookRunStore) GetPlaybookRunsDueForChannelArchive(now int64) ([]app.PlaybookRun, error) {
+	var rawPlaybookRuns []sqlPlaybookRun
+	err := s.store.selectBuilder(s.store.db, &rawPlaybookRuns, s.playbookRunSelect.
+		Where(sq.Eq{"i.CurrentStatus": app.StatusFinished}).
+		Where(sq.Gt{"i.ChannelAutoArchiveAt": 0}).
+		Where(sq.LtOrEq{"i.ChannelAutoArchiveAt": now}).
+		OrderBy("i.ChannelAutoArchiveAt"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the runs with a channel due to be archived")
+	}
+
+	playbookRuns := make([]app.PlaybookRun, 0, len(rawPlaybookRuns))
+	for _, rawPlaybookRun := range rawPlaybookRuns {
+		playbookRun, err := s.toPlaybookRun(rawPlaybookRun)
+		if err != nil {
+			return nil, err
+		}
+		playbookRuns = append(playbookRuns, *playbookRun)
+	}
+
+	return playbookRuns, nil
+}
+
+// ClearChannelAutoArchive marks the automatic archive of the channel of the run as handled.
+func (s *playbookRunStore) ClearChannelAutoArchive(playbookRunID string) error {
+	if _, err := s.store.execBuilder(s.store.db, sq.
+		Update("IR_Incident").
+		Set("ChannelAutoArchiveAt", 0).
+		Where(sq.Eq{"ID": playbookRunID})); err != nil {
+		return errors.Wrapf(err, "failed to clear the channel auto-archive of run '%s'", playbookRunID)
+	}
+
+	return nil
+}
+
 // GetHistoricalPlaybookRunParticipantsCount returns the count of all members of a playbook run's channel
 // since the beginning of the playbook run, excluding bots.
 func (s *playbookRunStore) GetHistoricalPlaybookRunParticipantsCount(channelID string) (int64, error) {
@@ -859,13 +1727,120 @@ func (s *playbookRunStore) GetOwners(requesterInfo app.RequesterInfo, options ap
 		Where(teamLimitExpr).
 		Where(permissionsExpr)
 
-	var owners []app.OwnerInfo
-	err := s.store.selectBuilder(s.store.db, &owners, query)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to query database")
+	var owners []app.OwnerInfo
+	err := s.store.selectBuilder(s.store.db, &owners, query)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query database")
+	}
+
+	return owners, nil
+}
+
+// GetTaskLoad returns the incomplete assigned checklist items and owned runs of the users with
+// work in the unfinished runs of options.TeamID visible to the requester, sorted and limited by
+// options. Options must be validated.
+//
+// The checklists are stored as JSON, so their items are expanded by the database to count them
+// along with the owned runs in a single aggregated query.
+func (s *playbookRunStore) GetTaskLoad(requesterInfo app.RequesterInfo, options app.TaskLoadOptions) ([]app.UserTaskLoad, error) {
+	activeRuns := func(columns ...string) sq.SelectBuilder {
+		return sq.
+			Select(columns...).
+			From("IR_Incident AS i").
+			Where(buildTeamLimitExpr(requesterInfo, options.TeamID, "i")).
+			Where(s.buildPermissionsExpr(requesterInfo)).
+			Where(sq.NotEq{"i.CurrentStatus": []string{app.StatusFinished, app.StatusMerged}})
+	}
+
+	var assignedItems sq.SelectBuilder
+	if s.store.db.DriverName() == model.DatabaseDriverMysql {
+		assignedItems = activeRuns("item.AssigneeID AS UserID", "1 AS IncompleteItems", "0 AS OwnedRuns").
+			JoinClause(`CROSS JOIN JSON_TABLE(i.ChecklistsJSON, '$[*].items[*]' COLUMNS (
+				AssigneeID VARCHAR(26) PATH '$.assignee_id',
+				State VARCHAR(32) PATH '$.state',
+				Hidden VARCHAR(5) PATH '$.hidden'
+			)) AS item`).
+			Where("COALESCE(item.AssigneeID, '') <> ''").
+			Where("COALESCE(item.Hidden, 'false') <> 'true'").
+			Where(sq.Expr("COALESCE(item.State, '') IN (?, ?)", app.ChecklistItemStateOpen, app.ChecklistItemStateInProgress))
+	} else {
+		// Checklists without items have null items, which jsonb_array_elements can't expand.
+		assignedItems = activeRuns("item->>'assignee_id' AS UserID", "1 AS IncompleteItems", "0 AS OwnedRuns").
+			JoinClause("CROSS JOIN LATERAL jsonb_array_elements(i.ChecklistsJSON::jsonb) AS checklist").
+			JoinClause(`CROSS JOIN LATERAL jsonb_array_elements(
+				CASE jsonb_typeof(checklist->'items') WHEN 'array' THEN checklist->'items' ELSE '[]'::jsonb END
+			) AS item`).
+			Where("COALESCE(item->>'assignee_id', '') <> ''").
+			Where("COALESCE(item->>'hidden', 'false') <> 'true'").
+			Where(sq.Expr("COALESCE(item->>'state', '') IN (?, ?)", app.ChecklistItemStateOpen, app.ChecklistItemStateInProgress))
+	}
+
+	ownedRuns := activeRuns("i.CommanderUserID AS UserID", "0 AS IncompleteItems", "1 AS OwnedRuns").
+		Where(sq.NotEq{"i.CommanderUserID": ""})
+
+	// Ties are broken by user ID, so that the result is stable.
+	orderBy := []string{"IncompleteItems DESC", "OwnedRuns DESC", "loads.UserID ASC"}
+	if options.Sort == app.TaskLoadSortByOwnedRuns {
+		orderBy[0], orderBy[1] = orderBy[1], orderBy[0]
+	}
+
+	query := s.store.builder.
+		Select("loads.UserID", "SUM(loads.IncompleteItems) AS IncompleteItems", "SUM(loads.OwnedRuns) AS OwnedRuns").
+		FromSelect(ownedRuns.Suffix("UNION ALL").SuffixExpr(assignedItems), "loads").
+		GroupBy("loads.UserID").
+		OrderBy(orderBy...)
+	if options.Limit > 0 {
+		query = query.Limit(uint64(options.Limit))
+	}
+
+	load := []app.UserTaskLoad{}
+	if err := s.store.selectBuilder(s.store.db, &load, query); err != nil && err != sql.ErrNoRows {
+		return nil, errors.Wrapf(err, "failed to get task load of team '%s'", options.TeamID)
+	}
+
+	return load, nil
+}
+
+// GetUsersForAutocomplete returns up to limit active members of teamID whose username or
+// nickname, or first or last name if showFullName, starts with prefix, ignoring case. Members of
+// channelID come first, then users are sorted by username. DisplayName is left empty.
+func (s *playbookRunStore) GetUsersForAutocomplete(teamID, channelID, prefix string, showFullName bool, limit int) ([]app.RunUserAutocompleteResult, error) {
+	pattern := strings.ToLower(likeEscaper.Replace(prefix)) + "%"
+
+	matchesPrefix := sq.Or{
+		sq.Like{"LOWER(u.Username)": pattern},
+		sq.Like{"LOWER(u.Nickname)": pattern},
+	}
+	if showFullName {
+		matchesPrefix = append(matchesPrefix,
+			sq.Like{"LOWER(u.FirstName)": pattern},
+			sq.Like{"LOWER(u.LastName)": pattern},
+		)
+	}
+
+	query := s.queryBuilder.
+		Select(
+			"u.Id AS UserID",
+			"u.Username",
+			"COALESCE(u.FirstName, '') AS FirstName",
+			"COALESCE(u.LastName, '') AS LastName",
+			"COALESCE(u.Nickname, '') AS Nickname",
+			"(cm.UserId IS NOT NULL) AS InChannel",
+		).
+		From("Users AS u").
+		Join("TeamMembers AS tm ON tm.UserId = u.Id").
+		LeftJoin("ChannelMembers AS cm ON cm.UserId = u.Id AND cm.ChannelId = ?", channelID).
+		Where(sq.Eq{"tm.TeamId": teamID, "tm.DeleteAt": 0, "u.DeleteAt": 0}).
+		Where(matchesPrefix).
+		OrderBy("InChannel DESC", "u.Username").
+		Limit(uint64(limit))
+
+	var users []app.RunUserAutocompleteResult
+	if err := s.store.selectBuilder(s.store.db, &users, query); err != nil && err != sql.ErrNoRows {
+		return nil, errors.Wrap(err, "failed to get users for autocomplete")
 	}
 
-	return owners, nil
+	return users, nil
 }
 
 // NukeDB removes all playbook run related data.
@@ -876,7 +1851,7 @@ func (s *playbookRunStore) NukeDB() (err error) {
 	}
 	defer s.store.finalizeTransaction(tx)
 
-	if _, err := tx.Exec("DROP TABLE IF EXISTS IR_Metric, IR_MetricConfig, IR_PlaybookMember, IR_Run_Participants, IR_PlaybookAutoFollow, IR_StatusPosts, IR_TimelineEvent, IR_Incident, IR_Playbook, IR_System"); err != nil {
+	if _, err := tx.Exec("DROP TABLE IF EXISTS IR_SavedFilter, IR_DueReminder, IR_PlaybookVersion, IR_CommandOutput, IR_RunIdempotencyKey, IR_RunTag, IR_PropertyValue, IR_PropertyDefinition, IR_Metric, IR_MetricConfig, IR_PlaybookMember, IR_Run_Participants, IR_RunCoOwner, IR_PlaybookAutoFollow, IR_StatusPosts, IR_TimelineEvent, IR_Incident, IR_ScheduledRun, IR_WebhookDelivery, IR_Playbook, IR_System"); err != nil {
 		return errors.Wrap(err, "could not delete all IR tables")
 	}
 
@@ -992,6 +1967,50 @@ func (s *playbookRunStore) buildPermissionsExpr(info app.RequesterInfo) sq.Sqliz
 		))`, info.UserID, info.UserID)
 }
 
+// buildAssigneeClause matches the runs that have at least one checklist item in the given state
+// assigned to assigneeID, relying on the JSON containment operator of each database: a checklist
+// array contains the candidate when any of its checklists has an item with the same assignee and state.
+func (s *playbookRunStore) buildAssigneeClause(assigneeID, state string) (sq.Sqlizer, error) {
+	candidate, err := json.Marshal([]map[string]interface{}{{
+		"items": []map[string]string{{
+			"assignee_id": assigneeID,
+			"state":       state,
+		}},
+	}})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal assignee filter")
+	}
+
+	if s.store.db.DriverName() == model.DatabaseDriverMysql {
+		return sq.Expr("JSON_CONTAINS(i.ChecklistsJSON, ?)", string(candidate)), nil
+	}
+
+	return sq.Expr("i.ChecklistsJSON::jsonb @> ?::jsonb", string(candidate)), nil
+}
+
+// buildTagsClause matches the runs that have any of the given tags or, if matchAll, every one of them.
+func buildTagsClause(tags []string, matchAll bool) sq.Sqlizer {
+	if matchAll {
+		clause := sq.And{}
+		for _, tag := range tags {
+			clause = append(clause, sq.Expr(`EXISTS(SELECT 1
+				FROM IR_RunTag AS t
+				WHERE t.IncidentID = i.ID
+				AND t.Tag = ?)`, tag))
+		}
+		return clause
+	}
+
+	args := make([]interface{}, 0, len(tags))
+	for _, tag := range tags {
+		args = append(args, tag)
+	}
+	return sq.Expr(`EXISTS(SELECT 1
+		FROM IR_RunTag AS t
+		WHERE t.IncidentID = i.ID
+		AND t.Tag IN (`+sq.Placeholders(len(tags))+`))`, args...)
+}
+
 func buildTeamLimitExpr(info app.RequesterInfo, teamID, tableName string) sq.Sqlizer {
 	filterToSelectedTeam := sq.Eq{fmt.Sprintf("%s.TeamID", tableName): teamID}
 	onlyTeamsUserIsAMember := sq.Expr(fmt.Sprintf(`
@@ -1025,6 +2044,14 @@ func (s *playbookRunStore) toPlaybookRun(rawPlaybookRun sqlPlaybookRun) (*app.Pl
 	if err := json.Unmarshal(rawPlaybookRun.ChecklistsJSON, &playbookRun.Checklists); err != nil {
 		return nil, errors.Wrapf(err, "failed to unmarshal checklists json for playbook run id: %s", rawPlaybookRun.ID)
 	}
+	playbookRun.ApplyChecklistItemDependencies()
+
+	playbookRun.StatusUpdateTemplates = []app.StatusUpdateTemplate(nil)
+	if len(rawPlaybookRun.StatusUpdateTemplatesJSON) > 0 {
+		if err := json.Unmarshal(rawPlaybookRun.StatusUpdateTemplatesJSON, &playbookRun.StatusUpdateTemplates); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal status update templates json for playbook run id: %s", rawPlaybookRun.ID)
+		}
+	}
 
 	playbookRun.InvitedUserIDs = []string(nil)
 	if rawPlaybookRun.ConcatenatedInvitedUserIDs != "" {
@@ -1041,6 +2068,21 @@ func (s *playbookRunStore) toPlaybookRun(rawPlaybookRun sqlPlaybookRun) (*app.Pl
 		playbookRun.ParticipantIDs = strings.Split(rawPlaybookRun.ConcatenatedParticipantIDs, ",")
 	}
 
+	playbookRun.ViewerIDs = []string(nil)
+	if rawPlaybookRun.ConcatenatedViewerIDs != "" {
+		playbookRun.ViewerIDs = strings.Split(rawPlaybookRun.ConcatenatedViewerIDs, ",")
+	}
+
+	playbookRun.CoOwnerUserIDs = []string(nil)
+	if rawPlaybookRun.ConcatenatedCoOwnerUserIDs != "" {
+		playbookRun.CoOwnerUserIDs = strings.Split(rawPlaybookRun.ConcatenatedCoOwnerUserIDs, ",")
+	}
+
+	playbookRun.Tags = []string(nil)
+	if rawPlaybookRun.ConcatenatedTags != "" {
+		playbookRun.Tags = strings.Split(rawPlaybookRun.ConcatenatedTags, ",")
+	}
+
 	playbookRun.BroadcastChannelIDs = []string(nil)
 	if rawPlaybookRun.ConcatenatedBroadcastChannelIDs != "" {
 		playbookRun.BroadcastChannelIDs = strings.Split(rawPlaybookRun.ConcatenatedBroadcastChannelIDs, ",")
@@ -1125,6 +2167,58 @@ func (s *playbookRunStore) GetRunsWithAssignedTasks(userID string) ([]app.Assign
 	return ret, nil
 }
 
+// GetRunsWithOverdueTasks returns the list of active runs that have open tasks whose due date
+// is at or before now
+func (s *playbookRunStore) GetRunsWithOverdueTasks(now int64) ([]app.AssignedRun, error) {
+	var raw []struct {
+		app.AssignedRun
+		ChecklistsJSON json.RawMessage
+	}
+
+	query := s.store.builder.Select("i.ID AS PlaybookRunID", "t.Name AS TeamName",
+		"c.Name AS ChannelName", "c.DisplayName AS ChannelDisplayName",
+		"i.ChecklistsJSON AS ChecklistsJSON").
+		From("IR_Incident AS i").
+		Join("Teams AS t ON (i.TeamID = t.Id)").
+		Join("Channels AS c ON (i.ChannelID = c.Id)").
+		Where(sq.Eq{"i.CurrentStatus": app.StatusInProgress}).
+		OrderBy("ChannelDisplayName")
+
+	if err := s.store.selectBuilder(s.store.db, &raw, query); err != nil {
+		return nil, errors.Wrap(err, "failed to query for overdue tasks")
+	}
+
+	var ret []app.AssignedRun
+	for _, rawItem := range raw {
+		run := rawItem.AssignedRun
+
+		var checklists []app.Checklist
+		err := json.Unmarshal(rawItem.ChecklistsJSON, &checklists)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal checklists json for playbook run id: %s", rawItem.PlaybookRunID)
+		}
+
+		for _, checklist := range checklists {
+			for _, item := range checklist.Items {
+				if item.IsOverdue(now) {
+					task := app.AssignedTask{
+						ChecklistID:    checklist.ID,
+						ChecklistTitle: checklist.Title,
+						ChecklistItem:  item,
+					}
+					run.Tasks = append(run.Tasks, task)
+				}
+			}
+		}
+
+		if len(run.Tasks) > 0 {
+			ret = append(ret, run)
+		}
+	}
+
+	return ret, nil
+}
+
 // GetParticipatingRuns returns the list of active runs with userID as a participant
 func (s *playbookRunStore) GetParticipatingRuns(userID string) ([]app.RunLink, error) {
 	membershipClause := s.queryBuilder.
@@ -1154,6 +2248,25 @@ func (s *playbookRunStore) GetParticipatingRuns(userID string) ([]app.RunLink, e
 	return ret, nil
 }
 
+// GetStatusUpdateReminders returns the status update reminders of the active runs that have
+// status updates enabled, a reminder timer set, and no reminder posted yet.
+func (s *playbookRunStore) GetStatusUpdateReminders() ([]app.StatusUpdateReminder, error) {
+	query := s.store.builder.
+		Select("ID AS PlaybookRunID", "LastStatusUpdateAt", "PreviousReminder").
+		From("IR_Incident").
+		Where(sq.Eq{"CurrentStatus": app.StatusInProgress}).
+		Where(sq.Eq{"StatusUpdateEnabled": true}).
+		Where(sq.NotEq{"PreviousReminder": 0}).
+		Where(sq.Eq{"COALESCE(ReminderPostID, '')": ""})
+
+	var ret []app.StatusUpdateReminder
+	if err := s.store.selectBuilder(s.store.db, &ret, query); err != nil {
+		return nil, errors.Wrap(err, "failed to query for status update reminders")
+	}
+
+	return ret, nil
+}
+
 // GetOverdueUpdateRuns returns runs owned by userID and that have overdue status updates.
 func (s *playbookRunStore) GetOverdueUpdateRuns(userID string) ([]app.RunLink, error) {
 	// only notify if the user is still a participant
@@ -1242,6 +2355,54 @@ func (s *playbookRunStore) GetFollowers(playbookRunID string) ([]string, error)
 	return followers, nil
 }
 
+func (s *playbookRunStore) GetRunFollowersSince(since int64) ([]app.RunFollower, error) {
+	query := s.queryBuilder.
+		Select("rp.IncidentID AS PlaybookRunID", "rp.UserID").
+		From("IR_Run_Participants AS rp").
+		Join("IR_Incident AS i ON (i.ID = rp.IncidentID)").
+		Where(sq.Eq{"rp.IsFollower": true}).
+		Where(sq.Or{sq.Eq{"i.EndAt": 0}, sq.GtOrEq{"i.EndAt": since}}).
+		OrderBy("rp.UserID", "rp.IncidentID")
+
+	var followers []app.RunFollower
+	err := s.store.selectBuilder(s.store.db, &followers, query)
+	if err == sql.ErrNoRows {
+		return []app.RunFollower{}, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to get run followers")
+	}
+
+	return followers, nil
+}
+
+// systemFollowerDigestSentAtKey is the IR_System key of the time the last follower digest was
+// sent at.
+const systemFollowerDigestSentAtKey = "FollowerDigestSentAt"
+
+// GetFollowerDigestSentAt returns the time, in millis, the last follower digest was sent at, or 0
+// if none was sent yet.
+func (s *playbookRunStore) GetFollowerDigestSentAt() (int64, error) {
+	value, err := s.store.getSystemValue(s.store.db, systemFollowerDigestSentAtKey)
+	if err != nil {
+		return 0, err
+	}
+	if value == "" {
+		return 0, nil
+	}
+
+	sentAt, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to parse the time the last follower digest was sent at, %q", value)
+	}
+
+	return sentAt, nil
+}
+
+// SetFollowerDigestSentAt stores the time, in millis, the last follower digest was sent at.
+func (s *playbookRunStore) SetFollowerDigestSentAt(sentAt int64) error {
+	return s.store.setSystemValue(s.store.db, systemFollowerDigestSentAtKey, strconv.FormatInt(sentAt, 10))
+}
+
 // Get number of active runs.
 func (s *playbookRunStore) GetRunsActiveTotal() (int64, error) {
 	var count int64
@@ -1421,14 +2582,14 @@ func (s *playbookRunStore) updateRunMetrics(q queryExecer, playbookRun app.Playb
 }
 
 func (s *playbookRunStore) AddParticipants(playbookRunID string, userIDs []string) error {
-	return s.updateParticipating(playbookRunID, userIDs, true)
+	return s.updateParticipating(s.store.db, playbookRunID, userIDs, true)
 }
 
 func (s *playbookRunStore) RemoveParticipants(playbookRunID string, userIDs []string) error {
-	return s.updateParticipating(playbookRunID, userIDs, false)
+	return s.updateParticipating(s.store.db, playbookRunID, userIDs, false)
 }
 
-func (s *playbookRunStore) updateParticipating(playbookRunID string, userIDs []string, isParticipating bool) error {
+func (s *playbookRunStore) updateParticipating(e execer, playbookRunID string, userIDs []string, isParticipating bool) error {
 	query := sq.
 		Insert("IR_Run_Participants").
 		Columns("IncidentID", "UserID", "IsParticipant")
@@ -1437,16 +2598,22 @@ func (s *playbookRunStore) updateParticipating(playbookRunID string, userIDs []s
 		query = query.Values(playbookRunID, userID, isParticipating)
 	}
 
+	update := "IsParticipant = ?"
+	if !isParticipating {
+		// Participants that leave lose their role, so that they join again as active participants
+		update += ", IsViewer = false"
+	}
+
 	var err error
 	if s.store.db.DriverName() == model.DatabaseDriverMysql {
 		_, err = s.store.execBuilder(
-			s.store.db,
-			query.Suffix("ON DUPLICATE KEY UPDATE IsParticipant = ?", isParticipating),
+			e,
+			query.Suffix("ON DUPLICATE KEY UPDATE "+update, isParticipating),
 		)
 	} else {
 		_, err = s.store.execBuilder(
-			s.store.db,
-			query.Suffix("ON CONFLICT (IncidentID,UserID) DO UPDATE SET IsParticipant = ?", isParticipating),
+			e,
+			query.Suffix("ON CONFLICT (IncidentID,UserID) DO UPDATE SET "+update, isParticipating),
 		)
 	}
 
@@ -1457,6 +2624,272 @@ func (s *playbookRunStore) updateParticipating(playbookRunID string, userIDs []s
 	return nil
 }
 
+// SetParticipantViewer makes a participant of the run a viewer, or an active participant.
+func (s *playbookRunStore) SetParticipantViewer(playbookRunID, userID string, isViewer bool) error {
+	if _, err := s.store.execBuilder(s.store.db, sq.
+		Update("IR_Run_Participants").
+		Set("IsViewer", isViewer).
+		Where(sq.Eq{"IncidentID": playbookRunID, "UserID": userID, "IsParticipant": true})); err != nil {
+		return errors.Wrapf(err, "failed to set the role of participant '%s' for run '%s'", userID, playbookRunID)
+	}
+
+	return nil
+}
+
+func (s *playbookRunStore) AddCoOwner(playbookRunID string, userID string) error {
+	query := sq.
+		Insert("IR_RunCoOwner").
+		Columns("IncidentID", "UserID", "CreateAt").
+		Values(playbookRunID, userID, model.GetMillis())
+
+	var err error
+	if s.store.db.DriverName() == model.DatabaseDriverMysql {
+		_, err = s.store.execBuilder(s.store.db, query.Suffix("ON DUPLICATE KEY UPDATE UserID = UserID"))
+	} else {
+		_, err = s.store.execBuilder(s.store.db, query.Suffix("ON CONFLICT (IncidentID,UserID) DO NOTHING"))
+	}
+
+	if err != nil {
+		return errors.Wrapf(err, "failed to add co-owner '%s' for run '%s'", userID, playbookRunID)
+	}
+
+	return nil
+}
+
+func (s *playbookRunStore) SetPropertyValue(playbookRunID, propertyDefinitionID, value string) error {
+	if value == "" {
+		_, err := s.store.execBuilder(s.store.db, sq.
+			Delete("IR_PropertyValue").
+			Where(sq.Eq{"IncidentID": playbookRunID, "PropertyDefinitionID": propertyDefinitionID}))
+		if err != nil {
+			return errors.Wrapf(err, "failed to remove property '%s' for run '%s'", propertyDefinitionID, playbookRunID)
+		}
+
+		return nil
+	}
+
+	query := sq.
+		Insert("IR_PropertyValue").
+		Columns("IncidentID", "PropertyDefinitionID", "Value").
+		Values(playbookRunID, propertyDefinitionID, value)
+
+	var err error
+	if s.store.db.DriverName() == model.DatabaseDriverMysql {
+		_, err = s.store.execBuilder(s.store.db, query.Suffix("ON DUPLICATE KEY UPDATE Value = VALUES(Value)"))
+	} else {
+		_, err = s.store.execBuilder(s.store.db, query.Suffix("ON CONFLICT (IncidentID,PropertyDefinitionID) DO UPDATE SET Value = EXCLUDED.Value"))
+	}
+
+	if err != nil {
+		return errors.Wrapf(err, "failed to set property '%s' for run '%s'", propertyDefinitionID, playbookRunID)
+	}
+
+	return nil
+}
+
+// CreateCommandOutput stores the output of the slash command of a checklist item.
+func (s *playbookRunStore) CreateCommandOutput(output app.CommandOutput) error {
+	_, err := s.store.execBuilder(s.store.db, sq.
+		Insert("IR_CommandOutput").
+		SetMap(map[string]interface{}{
+			"ID":              output.ID,
+			"IncidentID":      output.PlaybookRunID,
+			"ChecklistItemID": output.ChecklistItemID,
+			"Command":         output.Command,
+			"UserID":          output.UserID,
+			"Status":          output.Status,
+			"Output":          output.Output,
+			"Truncated":       output.Truncated,
+			"CreateAt":        output.CreateAt,
+		}))
+	if err != nil {
+		return errors.Wrapf(err, "failed to store command output for run '%s'", output.PlaybookRunID)
+	}
+
+	return nil
+}
+
+// GetCommandOutput returns the command output outputID of the run playbookRunID. Returns
+// ErrNotFound if not found.
+func (s *playbookRunStore) GetCommandOutput(playbookRunID, outputID string) (*app.CommandOutput, error) {
+	var output app.CommandOutput
+
+	err := s.store.getBuilder(s.store.db, &output, s.queryBuilder.
+		Select(
+			"co.ID",
+			"co.IncidentID AS PlaybookRunID",
+			"co.ChecklistItemID",
+			"co.Command",
+			"co.UserID",
+			"co.Status",
+			"co.Output",
+			"co.Truncated",
+			"co.CreateAt",
+		).
+		From("IR_CommandOutput co").
+		Where(sq.Eq{"co.IncidentID": playbookRunID, "co.ID": outputID}))
+	if err == sql.ErrNoRows {
+		return nil, errors.Wrapf(app.ErrNotFound, "command output with id (%s) does not exist for playbook run with id (%s)", outputID, playbookRunID)
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "failed to get command output with id (%s) for playbook run with id (%s)", outputID, playbookRunID)
+	}
+
+	return &output, nil
+}
+
+func (s *playbookRunStore) RemoveCoOwners(playbookRunID string, userIDs []string) error {
+	_, err := s.store.execBuilder(s.store.db, sq.
+		Delete("IR_RunCoOwner").
+		Where(sq.Eq{"IncidentID": playbookRunID, "UserID": userIDs}))
+	if err != nil {
+		return errors.Wrapf(err, "failed to remove co-owners '%+v' for run '%s'", userIDs, playbookRunID)
+	}
+
+	return nil
+}
+
+func (s *playbookRunStore) AddRunTags(playbookRunID string, tags []string) error {
+	return s.addRunTags(s.store.db, playbookRunID, tags)
+}
+
+func (s *playbookRunStore) addRunTags(e execer, playbookRunID string, tags []string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	query := sq.
+		Insert("IR_RunTag").
+		Columns("IncidentID", "Tag", "CreateAt")
+	createAt := model.GetMillis()
+	for _, tag := range tags {
+		query = query.Values(playbookRunID, tag, createAt)
+	}
+
+	var err error
+	if s.store.db.DriverName() == model.DatabaseDriverMysql {
+		_, err = s.store.execBuilder(e, query.Suffix("ON DUPLICATE KEY UPDATE Tag = Tag"))
+	} else {
+		_, err = s.store.execBuilder(e, query.Suffix("ON CONFLICT (IncidentID,Tag) DO NOTHING"))
+	}
+
+	if err != nil {
+		return errors.Wrapf(err, "failed to add tags '%+v' for run '%s'", tags, playbookRunID)
+	}
+
+	return nil
+}
+
+func (s *playbookRunStore) RemoveRunTag(playbookRunID string, tag string) error {
+	_, err := s.store.execBuilder(s.store.db, sq.
+		Delete("IR_RunTag").
+		Where(sq.Eq{"IncidentID": playbookRunID, "Tag": tag}))
+	if err != nil {
+		return errors.Wrapf(err, "failed to remove tag '%s' for run '%s'", tag, playbookRunID)
+	}
+
+	return nil
+}
+
+// GetTeamRunTags returns up to limit distinct tags starting with prefix of the runs of teamID,
+// the most used first.
+func (s *playbookRunStore) GetTeamRunTags(teamID, prefix string, limit int) ([]string, error) {
+	query := s.store.builder.
+		Select("t.Tag").
+		From("IR_RunTag AS t").
+		Join("IR_Incident AS i ON i.ID = t.IncidentID").
+		Where(sq.Eq{"i.TeamID": teamID}).
+		GroupBy("t.Tag").
+		OrderBy("COUNT(*) DESC", "t.Tag").
+		Limit(uint64(limit))
+
+	if prefix != "" {
+		query = query.Where(sq.Like{"t.Tag": prefix + "%"})
+	}
+
+	var tags []string
+	if err := s.store.selectBuilder(s.store.db, &tags, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get tags of team '%s'", teamID)
+	}
+
+	return tags, nil
+}
+
+// GetPlaybookRunsForExport returns up to limit runs of teamID visible to the requester that were
+// created after the cursor, ordered by creation time and then by ID. The runs are paged by keyset
+// rather than by offset, so that exporting every page reads each run only once.
+func (s *playbookRunStore) GetPlaybookRunsForExport(requesterInfo app.RequesterInfo, teamID string, after app.RunExportCursor, limit int) ([]app.RunExportRow, error) {
+	query := s.store.builder.
+		Select("i.ID", "i.Name", "i.PlaybookID", "i.CommanderUserID AS OwnerUserID", "i.ChannelID",
+			"i.CurrentStatus", "i.CreateAt", "i.EndAt", "i.LastStatusUpdateAt").
+		From("IR_Incident AS i").
+		Where(s.buildPermissionsExpr(requesterInfo)).
+		Where(buildTeamLimitExpr(requesterInfo, teamID, "i")).
+		Where(sq.Or{
+			sq.Gt{"i.CreateAt": after.CreateAt},
+			sq.And{sq.Eq{"i.CreateAt": after.CreateAt}, sq.Gt{"i.ID": after.ID}},
+		}).
+		OrderBy("i.CreateAt ASC", "i.ID ASC").
+		Limit(uint64(limit))
+
+	rows := []app.RunExportRow{}
+	if err := s.store.selectBuilder(s.store.db, &rows, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get runs for export of team '%s'", teamID)
+	}
+
+	return rows, nil
+}
+
+// GetStalePlaybookRuns returns the in-progress runs visible to the requester whose last activity
+// was before lastActivityBefore (in millis), stalest first. All the teams of the requester are
+// searched if teamID is empty.
+func (s *playbookRunStore) GetStalePlaybookRuns(requesterInfo app.RequesterInfo, teamID string, lastActivityBefore int64, options app.StaleRunsOptions) (*app.GetStaleRunsResults, error) {
+	staleRunsSelect := s.store.builder.
+		Select().
+		From("IR_Incident AS i").
+		Where(sq.Eq{"i.CurrentStatus": app.StatusInProgress}).
+		Where(sq.Lt{"i.LastActivityAt": lastActivityBefore}).
+		Where(s.buildPermissionsExpr(requesterInfo)).
+		Where(buildTeamLimitExpr(requesterInfo, teamID, "i"))
+
+	queryForResults := staleRunsSelect.
+		Columns("i.ID", "i.Name", "i.TeamID", "i.ChannelID", "i.CommanderUserID AS OwnerUserID", "i.PlaybookID",
+			"i.CreateAt", "COALESCE(i.LastStatusUpdateAt, 0) AS LastStatusUpdateAt", "i.LastActivityAt").
+		OrderBy("i.LastActivityAt ASC", "i.ID ASC")
+	queryForTotal := staleRunsSelect.Columns("COUNT(*)")
+
+	if options.PerPage > 0 {
+		page := options.Page
+		if page < 0 {
+			page = 0
+		}
+		queryForResults = queryForResults.
+			Offset(uint64(page * options.PerPage)).
+			Limit(uint64(options.PerPage))
+	}
+
+	items := []app.StaleRun{}
+	if err := s.store.selectBuilder(s.store.db, &items, queryForResults); err != nil && err != sql.ErrNoRows {
+		return nil, errors.Wrap(err, "failed to get stale playbook runs")
+	}
+
+	var total int
+	if err := s.store.getBuilder(s.store.db, &total, queryForTotal); err != nil {
+		return nil, errors.Wrap(err, "failed to get total count of stale playbook runs")
+	}
+
+	pageCount := 0
+	if options.PerPage > 0 {
+		pageCount = int(math.Ceil(float64(total) / float64(options.PerPage)))
+	}
+
+	return &app.GetStaleRunsResults{
+		TotalCount: total,
+		PageCount:  pageCount,
+		HasMore:    options.Page+1 < pageCount,
+		Items:      items,
+	}, nil
+}
+
 // GetPlaybookRunIDsForUser returns run ids where user is a participant or is following
 func (s *playbookRunStore) GetPlaybookRunIDsForUser(userID string) ([]string, error) {
 	requesterInfo := app.RequesterInfo{UserID: userID}
@@ -1607,9 +3040,15 @@ func toSQLPlaybookRun(playbookRun app.PlaybookRun) (*sqlPlaybookRun, error) {
 		return nil, errors.Wrapf(err, "checklist json for playbook run id '%s' is too long (max %d)", playbookRun.ID, maxJSONLength)
 	}
 
+	statusUpdateTemplatesJSON, err := json.Marshal(playbookRun.StatusUpdateTemplates)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal status update templates json for playbook run id '%s'", playbookRun.ID)
+	}
+
 	return &sqlPlaybookRun{
 		PlaybookRun:                           playbookRun,
 		ChecklistsJSON:                        checklistsJSON,
+		StatusUpdateTemplatesJSON:             statusUpdateTemplatesJSON,
 		ConcatenatedInvitedUserIDs:            strings.Join(playbookRun.InvitedUserIDs, ","),
 		ConcatenatedInvitedGroupIDs:           strings.Join(playbookRun.InvitedGroupIDs, ","),
 		ConcatenatedBroadcastChannelIDs:       strings.Join(playbookRun.BroadcastChannelIDs, ","),
@@ -1677,6 +3116,7 @@ func addMetricsToPlaybookRuns(metrics []sqlRunMetricData, playbookRuns []app.Pla
 			app.RunMetricData{
 				MetricConfigID: metric.MetricConfigID,
 				Value:          metric.Value,
+				Breached:       metric.Breached,
 			})
 	}
 
@@ -1685,6 +3125,17 @@ func addMetricsToPlaybookRuns(metrics []sqlRunMetricData, playbookRuns []app.Pla
 	}
 }
 
+func addPropertyValuesToPlaybookRuns(propertyValues []sqlPropertyValue, playbookRuns []app.PlaybookRun) {
+	playbookRunToValues := make(map[string][]app.PropertyValue)
+	for _, v := range propertyValues {
+		playbookRunToValues[v.IncidentID] = append(playbookRunToValues[v.IncidentID], v.PropertyValue)
+	}
+
+	for i, run := range playbookRuns {
+		playbookRuns[i].PropertyValues = playbookRunToValues[run.ID]
+	}
+}
+
 // queryActiveBetweenTimes will modify the query only if one (or both) of start and end are non-zero.
 // If both are non-zero, return the playbook runs active between those two times.
 // If start is zero, return the playbook run active before the end (not active after the end).
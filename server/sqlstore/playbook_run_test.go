@@ -386,6 +386,409 @@ func TestRestorePlaybookRun(t *testing.T) {
 	}
 }
 
+func TestMergePlaybookRuns(t *testing.T) {
+	for _, driverName := range driverNames {
+		db := setupTestDB(t, driverName)
+		playbookRunStore := setupPlaybookRunStore(t, db)
+		setupChannelsTable(t, db)
+
+		t.Run("merge a source without checklists", func(t *testing.T) {
+			// The checklists of the target don't change, so MySQL reports no affected row for
+			// their update.
+			target, err := playbookRunStore.CreatePlaybookRun(NewBuilder(t).WithChecklists([]int{2}).ToPlaybookRun())
+			require.NoError(t, err)
+			source, err := playbookRunStore.CreatePlaybookRun(NewBuilder(t).ToPlaybookRun())
+			require.NoError(t, err)
+
+			err = playbookRunStore.MergePlaybookRuns(app.RunMerge{
+				TargetRunID: target.ID,
+				SourceRunID: source.ID,
+				MergedAt:    model.GetMillis(),
+			})
+			require.NoError(t, err)
+
+			mergedTarget, err := playbookRunStore.GetPlaybookRun(target.ID)
+			require.NoError(t, err)
+			require.Equal(t, app.StatusInProgress, mergedTarget.CurrentStatus)
+			require.Equal(t, target.Checklists, mergedTarget.Checklists)
+
+			mergedSource, err := playbookRunStore.GetPlaybookRun(source.ID)
+			require.NoError(t, err)
+			require.Equal(t, app.StatusMerged, mergedSource.CurrentStatus)
+			require.Equal(t, target.ID, mergedSource.MergedIntoRunID)
+		})
+
+		t.Run("changes to the target made before the merge are kept", func(t *testing.T) {
+			target, err := playbookRunStore.CreatePlaybookRun(NewBuilder(t).WithChecklists([]int{1}).ToPlaybookRun())
+			require.NoError(t, err)
+			source, err := playbookRunStore.CreatePlaybookRun(NewBuilder(t).WithChecklists([]int{1}).ToPlaybookRun())
+			require.NoError(t, err)
+
+			// The item of the target is checked after the runs were read to prepare the merge.
+			target.Checklists[0].Items[0].State = app.ChecklistItemStateClosed
+			_, err = playbookRunStore.UpdatePlaybookRun(target)
+			require.NoError(t, err)
+
+			err = playbookRunStore.MergePlaybookRuns(app.RunMerge{
+				TargetRunID:      target.ID,
+				SourceRunID:      source.ID,
+				SourceChecklists: source.Checklists,
+				MergedAt:         model.GetMillis(),
+			})
+			require.NoError(t, err)
+
+			mergedTarget, err := playbookRunStore.GetPlaybookRun(target.ID)
+			require.NoError(t, err)
+			require.Len(t, mergedTarget.Checklists, 2)
+			require.Equal(t, app.ChecklistItemStateClosed, mergedTarget.Checklists[0].Items[0].State)
+			require.Equal(t, source.Checklists[0].Items[0].ID, mergedTarget.Checklists[1].Items[0].ID)
+		})
+
+		t.Run("runs that ended can't be merged", func(t *testing.T) {
+			target, err := playbookRunStore.CreatePlaybookRun(NewBuilder(t).ToPlaybookRun())
+			require.NoError(t, err)
+			finished, err := playbookRunStore.CreatePlaybookRun(NewBuilder(t).WithCurrentStatus(app.StatusFinished).ToPlaybookRun())
+			require.NoError(t, err)
+
+			err = playbookRunStore.MergePlaybookRuns(app.RunMerge{TargetRunID: target.ID, SourceRunID: finished.ID})
+			require.ErrorIs(t, err, app.ErrPlaybookRunNotActive)
+
+			err = playbookRunStore.MergePlaybookRuns(app.RunMerge{TargetRunID: finished.ID, SourceRunID: target.ID})
+			require.ErrorIs(t, err, app.ErrPlaybookRunNotActive)
+
+			err = playbookRunStore.MergePlaybookRuns(app.RunMerge{TargetRunID: target.ID, SourceRunID: model.NewId()})
+			require.ErrorIs(t, err, app.ErrNotFound)
+
+			unchanged, err := playbookRunStore.GetPlaybookRun(target.ID)
+			require.NoError(t, err)
+			require.Equal(t, app.StatusInProgress, unchanged.CurrentStatus)
+		})
+	}
+}
+
+func TestPauseAndResumePlaybookRun(t *testing.T) {
+	for _, driverName := range driverNames {
+		db := setupTestDB(t, driverName)
+		playbookRunStore := setupPlaybookRunStore(t, db)
+		store := setupSQLStore(t, db)
+
+		createRun := func(t *testing.T) *app.PlaybookRun {
+			returned, err := playbookRunStore.CreatePlaybookRun(NewBuilder(t).
+				WithCreateAt(1000).
+				WithCurrentStatus(app.StatusInProgress).
+				ToPlaybookRun())
+			require.NoError(t, err)
+			createPlaybookRunChannel(t, store, returned)
+			return returned
+		}
+
+		pause := func(t *testing.T, runID string, pausedAt int64) {
+			paused, err := playbookRunStore.PausePlaybookRun(runID, pausedAt)
+			require.NoError(t, err)
+			require.True(t, paused)
+		}
+
+		resume := func(t *testing.T, runID string, resumedAt int64) {
+			resumed, err := playbookRunStore.ResumePlaybookRun(runID, resumedAt)
+			require.NoError(t, err)
+			require.True(t, resumed)
+		}
+
+		t.Run("paused time accumulates over multiple cycles", func(t *testing.T) {
+			run := createRun(t)
+
+			pause(t, run.ID, 2000)
+			actual, err := playbookRunStore.GetPlaybookRun(run.ID)
+			require.NoError(t, err)
+			require.Equal(t, app.StatusPaused, actual.CurrentStatus)
+			require.EqualValues(t, 2000, actual.PausedAt)
+
+			resume(t, run.ID, 2500)
+			pause(t, run.ID, 4000)
+			resume(t, run.ID, 7000)
+
+			actual, err = playbookRunStore.GetPlaybookRun(run.ID)
+			require.NoError(t, err)
+			require.Equal(t, app.StatusInProgress, actual.CurrentStatus)
+			require.EqualValues(t, 0, actual.PausedAt)
+			require.EqualValues(t, 3500, actual.PausedDuration)
+
+			finishRun(t, playbookRunStore, run.ID, 10000)
+			actual, err = playbookRunStore.GetPlaybookRun(run.ID)
+			require.NoError(t, err)
+			require.EqualValues(t, 3500, actual.PausedDuration)
+			require.EqualValues(t, 5500, actual.ActiveDuration(20000))
+		})
+
+		t.Run("finishing a paused run closes the paused interval", func(t *testing.T) {
+			run := createRun(t)
+
+			pause(t, run.ID, 3000)
+			finishRun(t, playbookRunStore, run.ID, 5000)
+
+			actual, err := playbookRunStore.GetPlaybookRun(run.ID)
+			require.NoError(t, err)
+			require.Equal(t, app.StatusFinished, actual.CurrentStatus)
+			require.EqualValues(t, 0, actual.PausedAt)
+			require.EqualValues(t, 2000, actual.PausedDuration)
+			require.EqualValues(t, 2000, actual.ActiveDuration(20000))
+		})
+
+		t.Run("finishing twice changes nothing", func(t *testing.T) {
+			run := createRun(t)
+			finishRun(t, playbookRunStore, run.ID, 5000)
+
+			finished, err := playbookRunStore.FinishPlaybookRun(run.ID, 6000)
+			require.NoError(t, err)
+			require.False(t, finished)
+
+			actual, err := playbookRunStore.GetPlaybookRun(run.ID)
+			require.NoError(t, err)
+			require.EqualValues(t, 5000, actual.EndAt)
+		})
+
+		t.Run("only in progress runs can be paused", func(t *testing.T) {
+			run := createRun(t)
+			finishRun(t, playbookRunStore, run.ID, 5000)
+
+			paused, err := playbookRunStore.PausePlaybookRun(run.ID, 6000)
+			require.NoError(t, err)
+			require.False(t, paused)
+
+			actual, err := playbookRunStore.GetPlaybookRun(run.ID)
+			require.NoError(t, err)
+			require.Equal(t, app.StatusFinished, actual.CurrentStatus)
+			require.EqualValues(t, 0, actual.PausedAt)
+		})
+
+		t.Run("pausing or resuming twice changes nothing", func(t *testing.T) {
+			run := createRun(t)
+
+			pause(t, run.ID, 2000)
+			paused, err := playbookRunStore.PausePlaybookRun(run.ID, 3000)
+			require.NoError(t, err)
+			require.False(t, paused)
+
+			resume(t, run.ID, 4000)
+			resumed, err := playbookRunStore.ResumePlaybookRun(run.ID, 5000)
+			require.NoError(t, err)
+			require.False(t, resumed)
+
+			actual, err := playbookRunStore.GetPlaybookRun(run.ID)
+			require.NoError(t, err)
+			require.Equal(t, app.StatusInProgress, actual.CurrentStatus)
+			require.EqualValues(t, 2000, actual.PausedDuration)
+		})
+	}
+}
+
+func TestChannelAutoArchive(t *testing.T) {
+	for _, driverName := range driverNames {
+		db := setupTestDB(t, driverName)
+		playbookRunStore := setupPlaybookRunStore(t, db)
+		store := setupSQLStore(t, db)
+
+		createRun := func(t *testing.T, archiveEnabled bool, delayMinutes int64) *app.PlaybookRun {
+			playbookRun := NewBuilder(t).
+				WithCreateAt(1000).
+				WithCurrentStatus(app.StatusInProgress).
+				ToPlaybookRun()
+			playbookRun.ArchiveChannelOnFinishEnabled = archiveEnabled
+			playbookRun.ArchiveChannelOnFinishDelayMinutes = delayMinutes
+
+			returned, err := playbookRunStore.CreatePlaybookRun(playbookRun)
+			require.NoError(t, err)
+			createPlaybookRunChannel(t, store, returned)
+			return returned
+		}
+
+		dueRunIDs := func(t *testing.T, now int64) []string {
+			playbookRuns, err := playbookRunStore.GetPlaybookRunsDueForChannelArchive(now)
+			require.NoError(t, err)
+
+			ids := []string{}
+			for _, playbookRun := range playbookRuns {
+				ids = append(ids, playbookRun.ID)
+			}
+			return ids
+		}
+
+		t.Run("finishing schedules the archive after the delay", func(t *testing.T) {
+			run := createRun(t, true, 10)
+			finishRun(t, playbookRunStore, run.ID, 5000)
+
+			actual, err := playbookRunStore.GetPlaybookRun(run.ID)
+			require.NoError(t, err)
+			require.EqualValues(t, 5000+10*60*1000, actual.ChannelAutoArchiveAt)
+
+			require.NotContains(t, dueRunIDs(t, actual.ChannelAutoArchiveAt-1), run.ID)
+			require.Contains(t, dueRunIDs(t, actual.ChannelAutoArchiveAt), run.ID)
+
+			require.NoError(t, playbookRunStore.ClearChannelAutoArchive(run.ID))
+			require.NotContains(t, dueRunIDs(t, actual.ChannelAutoArchiveAt), run.ID)
+		})
+
+		t.Run("restoring cancels the archive", func(t *testing.T) {
+			run := createRun(t, true, 0)
+			finishRun(t, playbookRunStore, run.ID, 5000)
+			require.Contains(t, dueRunIDs(t, 5000), run.ID)
+
+			require.NoError(t, playbookRunStore.RestorePlaybookRun(run.ID, 6000))
+			require.NotContains(t, dueRunIDs(t, model.GetMillis()), run.ID)
+
+			actual, err := playbookRunStore.GetPlaybookRun(run.ID)
+			require.NoError(t, err)
+			require.Zero(t, actual.ChannelAutoArchiveAt)
+		})
+
+		t.Run("runs without archiving are never due", func(t *testing.T) {
+			run := createRun(t, false, 0)
+			finishRun(t, playbookRunStore, run.ID, 5000)
+
+			require.NotContains(t, dueRunIDs(t, model.GetMillis()), run.ID)
+		})
+	}
+}
+
+func TestRunCoOwners(t *testing.T) {
+	for _, driverName := range driverNames {
+		db := setupTestDB(t, driverName)
+		playbookRunStore := setupPlaybookRunStore(t, db)
+		store := setupSQLStore(t, db)
+
+		run, err := playbookRunStore.CreatePlaybookRun(NewBuilder(t).ToPlaybookRun())
+		require.NoError(t, err)
+		createPlaybookRunChannel(t, store, run)
+
+		t.Run("no co-owners", func(t *testing.T) {
+			actual, err := playbookRunStore.GetPlaybookRun(run.ID)
+			require.NoError(t, err)
+			require.Empty(t, actual.CoOwnerUserIDs)
+		})
+
+		t.Run("add co-owners", func(t *testing.T) {
+			require.NoError(t, playbookRunStore.AddCoOwner(run.ID, "user_1"))
+			require.NoError(t, playbookRunStore.AddCoOwner(run.ID, "user_2"))
+			// adding an existing co-owner is a no-op
+			require.NoError(t, playbookRunStore.AddCoOwner(run.ID, "user_1"))
+
+			actual, err := playbookRunStore.GetPlaybookRun(run.ID)
+			require.NoError(t, err)
+			require.ElementsMatch(t, []string{"user_1", "user_2"}, actual.CoOwnerUserIDs)
+		})
+
+		t.Run("remove co-owners", func(t *testing.T) {
+			require.NoError(t, playbookRunStore.RemoveCoOwners(run.ID, []string{"user_1", "user_3"}))
+
+			actual, err := playbookRunStore.GetPlaybookRun(run.ID)
+			require.NoError(t, err)
+			require.Equal(t, []string{"user_2"}, actual.CoOwnerUserIDs)
+		})
+	}
+}
+
+func TestRunPropertyValues(t *testing.T) {
+	for _, driverName := range driverNames {
+		db := setupTestDB(t, driverName)
+		playbookRunStore := setupPlaybookRunStore(t, db)
+		playbookStore := setupPlaybookStore(t, db)
+		store := setupSQLStore(t, db)
+
+		teamID := model.NewId()
+		playbook := NewPBBuilder().WithTitle("playbook").WithTeamID(teamID).ToPlaybook()
+		playbook.PropertyDefinitions = []app.PropertyDefinition{
+			{Name: "Severity", Type: app.PropertyTypeSelect, Options: []string{"low", "critical"}},
+			{Name: "Customer", Type: app.PropertyTypeText},
+		}
+		playbookID, err := playbookStore.Create(playbook)
+		require.NoError(t, err)
+		playbook, err = playbookStore.Get(playbookID)
+		require.NoError(t, err)
+		require.Len(t, playbook.PropertyDefinitions, 2)
+		require.Equal(t, []string{"low", "critical"}, playbook.PropertyDefinitions[0].Options)
+		require.Nil(t, playbook.PropertyDefinitions[1].Options)
+		severity, customer := playbook.PropertyDefinitions[0], playbook.PropertyDefinitions[1]
+
+		now := model.GetMillis()
+		createRun := func(t *testing.T, i int) *app.PlaybookRun {
+			run, err := playbookRunStore.CreatePlaybookRun(NewBuilder(t).
+				WithTeamID(teamID).
+				WithPlaybookID(playbookID).
+				WithCreateAt(now + int64(i*1000)).
+				ToPlaybookRun())
+			require.NoError(t, err)
+			createPlaybookRunChannel(t, store, run)
+			return run
+		}
+		run0 := createRun(t, 0)
+		run1 := createRun(t, 1)
+
+		t.Run("set, replace and unset values", func(t *testing.T) {
+			require.NoError(t, playbookRunStore.SetPropertyValue(run0.ID, customer.ID, "Acme"))
+			require.NoError(t, playbookRunStore.SetPropertyValue(run0.ID, severity.ID, "low"))
+			require.NoError(t, playbookRunStore.SetPropertyValue(run0.ID, severity.ID, "critical"))
+			require.NoError(t, playbookRunStore.SetPropertyValue(run1.ID, severity.ID, "low"))
+			require.NoError(t, playbookRunStore.SetPropertyValue(run1.ID, customer.ID, "Acme"))
+			require.NoError(t, playbookRunStore.SetPropertyValue(run1.ID, customer.ID, ""))
+
+			actual, err := playbookRunStore.GetPlaybookRun(run0.ID)
+			require.NoError(t, err)
+			require.Equal(t, []app.PropertyValue{
+				{PropertyDefinitionID: severity.ID, Name: "Severity", Value: "critical"},
+				{PropertyDefinitionID: customer.ID, Name: "Customer", Value: "Acme"},
+			}, actual.PropertyValues)
+
+			actual, err = playbookRunStore.GetPlaybookRun(run1.ID)
+			require.NoError(t, err)
+			require.Equal(t, []app.PropertyValue{
+				{PropertyDefinitionID: severity.ID, Name: "Severity", Value: "low"},
+			}, actual.PropertyValues)
+		})
+
+		t.Run("filter runs by property value", func(t *testing.T) {
+			getRunIDs := func(t *testing.T, propertyValues ...app.PropertyValue) []string {
+				results, err := playbookRunStore.GetPlaybookRuns(app.RequesterInfo{
+					UserID:  "testID",
+					IsAdmin: true,
+				}, app.PlaybookRunFilterOptions{
+					TeamID:         teamID,
+					PerPage:        10,
+					Sort:           app.SortByCreateAt,
+					Direction:      app.DirectionAsc,
+					PropertyValues: propertyValues,
+				})
+				require.NoError(t, err)
+
+				ids := []string{}
+				for _, run := range results.Items {
+					ids = append(ids, run.ID)
+				}
+				require.Len(t, ids, results.TotalCount)
+				return ids
+			}
+
+			require.Equal(t, []string{run0.ID}, getRunIDs(t, app.PropertyValue{PropertyDefinitionID: severity.ID, Value: "critical"}))
+			require.Equal(t, []string{run0.ID, run1.ID}, getRunIDs(t))
+			require.Equal(t, []string{run1.ID}, getRunIDs(t, app.PropertyValue{PropertyDefinitionID: severity.ID, Value: "low"}))
+			require.Empty(t, getRunIDs(t,
+				app.PropertyValue{PropertyDefinitionID: severity.ID, Value: "low"},
+				app.PropertyValue{PropertyDefinitionID: customer.ID, Value: "Acme"},
+			))
+		})
+
+		t.Run("values of deleted definitions are omitted", func(t *testing.T) {
+			playbook.PropertyDefinitions = playbook.PropertyDefinitions[1:]
+			require.NoError(t, playbookStore.Update(playbook))
+
+			actual, err := playbookRunStore.GetPlaybookRun(run0.ID)
+			require.NoError(t, err)
+			require.Equal(t, []app.PropertyValue{
+				{PropertyDefinitionID: customer.ID, Name: "Customer", Value: "Acme"},
+			}, actual.PropertyValues)
+		})
+	}
+}
+
 // intended to catch problems with the code assembling StatusPosts
 func TestStressTestGetPlaybookRuns(t *testing.T) {
 	rand.Seed(time.Now().UTC().UnixNano())
@@ -561,6 +964,281 @@ func TestGetPlaybookRunIDForChannel(t *testing.T) {
 	}
 }
 
+func TestGetActivePlaybookRunIDsForChannel(t *testing.T) {
+	for _, driverName := range driverNames {
+		db := setupTestDB(t, driverName)
+		playbookRunStore := setupPlaybookRunStore(t, db)
+		setupChannelsTable(t, db)
+
+		channelID := model.NewId()
+		createRun := func(status string) string {
+			run := NewBuilder(t).WithCurrentStatus(status).ToPlaybookRun()
+			run.ChannelID = channelID
+			run, err := playbookRunStore.CreatePlaybookRun(run)
+			require.NoError(t, err)
+			return run.ID
+		}
+
+		inProgress := createRun(app.StatusInProgress)
+		paused := createRun(app.StatusPaused)
+		createRun(app.StatusFinished)
+		_, err := playbookRunStore.CreatePlaybookRun(NewBuilder(t).ToPlaybookRun())
+		require.NoError(t, err)
+
+		ids, err := playbookRunStore.GetActivePlaybookRunIDsForChannel(channelID)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{inProgress, paused}, ids)
+
+		ids, err = playbookRunStore.GetActivePlaybookRunIDsForChannel(model.NewId())
+		require.NoError(t, err)
+		require.Empty(t, ids)
+	}
+}
+
+func TestGetPlaybookRunsByIDs(t *testing.T) {
+	for _, driverName := range driverNames {
+		db := setupTestDB(t, driverName)
+		playbookRunStore := setupPlaybookRunStore(t, db)
+		setupChannelsTable(t, db)
+
+		run1, err := playbookRunStore.CreatePlaybookRun(NewBuilder(t).WithName("run 1").ToPlaybookRun())
+		require.NoError(t, err)
+		run2, err := playbookRunStore.CreatePlaybookRun(NewBuilder(t).WithName("run 2").ToPlaybookRun())
+		require.NoError(t, err)
+		_, err = playbookRunStore.CreatePlaybookRun(NewBuilder(t).WithName("run 3").ToPlaybookRun())
+		require.NoError(t, err)
+
+		runs, err := playbookRunStore.GetPlaybookRunsByIDs([]string{run2.ID, model.NewId(), run1.ID})
+		require.NoError(t, err)
+		require.Len(t, runs, 2)
+
+		names := []string{}
+		for _, run := range runs {
+			expected, err := playbookRunStore.GetPlaybookRun(run.ID)
+			require.NoError(t, err)
+			require.Equal(t, expected.ChannelID, run.ChannelID)
+			require.Equal(t, expected.Checklists, run.Checklists)
+			require.Equal(t, expected.TimelineEvents, run.TimelineEvents)
+			names = append(names, run.Name)
+		}
+		require.ElementsMatch(t, []string{"run 1", "run 2"}, names)
+
+		runs, err = playbookRunStore.GetPlaybookRunsByIDs(nil)
+		require.NoError(t, err)
+		require.Empty(t, runs)
+	}
+}
+
+func TestRunTags(t *testing.T) {
+	teamID := model.NewId()
+
+	for _, driverName := range driverNames {
+		db := setupTestDB(t, driverName)
+		playbookRunStore := setupPlaybookRunStore(t, db)
+		setupChannelsTable(t, db)
+
+		createRun := func(teamID string, tags ...string) string {
+			run, err := playbookRunStore.CreatePlaybookRun(NewBuilder(t).WithTeamID(teamID).ToPlaybookRun())
+			require.NoError(t, err)
+			require.NoError(t, playbookRunStore.AddRunTags(run.ID, tags))
+			return run.ID
+		}
+
+		security := createRun(teamID, "security", "postmortem-needed")
+		postmortem := createRun(teamID, "postmortem-needed")
+		untagged := createRun(teamID)
+		createRun(model.NewId(), "security", "sev-1")
+
+		getRunIDs := func(tags []string, matchAll bool) []string {
+			results, err := playbookRunStore.GetPlaybookRuns(app.RequesterInfo{
+				UserID:  "testID",
+				IsAdmin: true,
+			}, app.PlaybookRunFilterOptions{
+				TeamID:       teamID,
+				Tags:         tags,
+				TagsMatchAll: matchAll,
+				PerPage:      10,
+			})
+			require.NoError(t, err)
+
+			ids := []string{}
+			for _, run := range results.Items {
+				ids = append(ids, run.ID)
+			}
+			return ids
+		}
+
+		t.Run(driverName+" - get run with tags", func(t *testing.T) {
+			run, err := playbookRunStore.GetPlaybookRun(security)
+			require.NoError(t, err)
+			require.Equal(t, []string{"postmortem-needed", "security"}, run.Tags)
+
+			run, err = playbookRunStore.GetPlaybookRun(untagged)
+			require.NoError(t, err)
+			require.Empty(t, run.Tags)
+		})
+
+		t.Run(driverName+" - adding an existing tag is a no-op", func(t *testing.T) {
+			require.NoError(t, playbookRunStore.AddRunTags(postmortem, []string{"postmortem-needed"}))
+
+			run, err := playbookRunStore.GetPlaybookRun(postmortem)
+			require.NoError(t, err)
+			require.Equal(t, []string{"postmortem-needed"}, run.Tags)
+		})
+
+		t.Run(driverName+" - filter by tags", func(t *testing.T) {
+			require.ElementsMatch(t, []string{security, postmortem}, getRunIDs([]string{"security", "postmortem-needed"}, false))
+			require.ElementsMatch(t, []string{security}, getRunIDs([]string{"security", "postmortem-needed"}, true))
+			require.ElementsMatch(t, []string{security}, getRunIDs([]string{"security"}, false))
+			require.Empty(t, getRunIDs([]string{"sev-1"}, false))
+			require.ElementsMatch(t, []string{security, postmortem, untagged}, getRunIDs(nil, false))
+		})
+
+		t.Run(driverName+" - team tags", func(t *testing.T) {
+			tags, err := playbookRunStore.GetTeamRunTags(teamID, "", 10)
+			require.NoError(t, err)
+			require.Equal(t, []string{"postmortem-needed", "security"}, tags)
+
+			tags, err = playbookRunStore.GetTeamRunTags(teamID, "sec", 10)
+			require.NoError(t, err)
+			require.Equal(t, []string{"security"}, tags)
+
+			tags, err = playbookRunStore.GetTeamRunTags(teamID, "", 1)
+			require.NoError(t, err)
+			require.Equal(t, []string{"postmortem-needed"}, tags)
+		})
+
+		t.Run(driverName+" - remove tag", func(t *testing.T) {
+			require.NoError(t, playbookRunStore.RemoveRunTag(security, "security"))
+			require.NoError(t, playbookRunStore.RemoveRunTag(security, "unknown"))
+
+			run, err := playbookRunStore.GetPlaybookRun(security)
+			require.NoError(t, err)
+			require.Equal(t, []string{"postmortem-needed"}, run.Tags)
+
+			require.Empty(t, getRunIDs([]string{"security"}, false))
+		})
+	}
+}
+
+func TestGetPlaybookRunsForExport(t *testing.T) {
+	teamID := model.NewId()
+
+	for _, driverName := range driverNames {
+		db := setupTestDB(t, driverName)
+		playbookRunStore := setupPlaybookRunStore(t, db)
+		setupChannelsTable(t, db)
+
+		var runs []app.PlaybookRun
+		for i := 0; i < 5; i++ {
+			run, err := playbookRunStore.CreatePlaybookRun(NewBuilder(t).WithTeamID(teamID).WithCreateAt(int64(1000 + i/2)).ToPlaybookRun())
+			require.NoError(t, err)
+			runs = append(runs, *run)
+		}
+		// Runs created at the same time are ordered by ID.
+		sort.Slice(runs, func(i, j int) bool {
+			if runs[i].CreateAt != runs[j].CreateAt {
+				return runs[i].CreateAt < runs[j].CreateAt
+			}
+			return runs[i].ID < runs[j].ID
+		})
+		expected := []string{}
+		for _, run := range runs {
+			expected = append(expected, run.ID)
+		}
+		_, err := playbookRunStore.CreatePlaybookRun(NewBuilder(t).WithTeamID(model.NewId()).ToPlaybookRun())
+		require.NoError(t, err)
+
+		t.Run(driverName+" - page through every run", func(t *testing.T) {
+			var ids []string
+			var cursor app.RunExportCursor
+			for {
+				rows, err := playbookRunStore.GetPlaybookRunsForExport(app.RequesterInfo{UserID: "testID", IsAdmin: true}, teamID, cursor, 2)
+				require.NoError(t, err)
+				require.LessOrEqual(t, len(rows), 2)
+
+				for _, row := range rows {
+					ids = append(ids, row.ID)
+				}
+				if len(rows) < 2 {
+					break
+				}
+				cursor = app.RunExportCursor{CreateAt: rows[len(rows)-1].CreateAt, ID: rows[len(rows)-1].ID}
+			}
+
+			require.Equal(t, expected, ids)
+		})
+	}
+}
+
+func TestGetStalePlaybookRuns(t *testing.T) {
+	teamID := model.NewId()
+	requesterInfo := app.RequesterInfo{UserID: "testID", IsAdmin: true}
+
+	for _, driverName := range driverNames {
+		db := setupTestDB(t, driverName)
+		playbookRunStore := setupPlaybookRunStore(t, db)
+		setupChannelsTable(t, db)
+
+		createRun := func(createAt int64, status string) *app.PlaybookRun {
+			run, err := playbookRunStore.CreatePlaybookRun(NewBuilder(t).WithTeamID(teamID).WithCreateAt(createAt).WithCurrentStatus(status).ToPlaybookRun())
+			require.NoError(t, err)
+			return run
+		}
+		createEvent := func(run *app.PlaybookRun, event app.TimelineEvent) {
+			event.PlaybookRunID = run.ID
+			_, err := playbookRunStore.CreateTimelineEvent(&event)
+			require.NoError(t, err)
+		}
+		getStaleIDs := func(lastActivityBefore int64) []string {
+			results, err := playbookRunStore.GetStalePlaybookRuns(requesterInfo, teamID, lastActivityBefore, app.StaleRunsOptions{})
+			require.NoError(t, err)
+
+			ids := []string{}
+			for _, run := range results.Items {
+				ids = append(ids, run.ID)
+			}
+			return ids
+		}
+
+		quiet := createRun(1000, app.StatusInProgress)
+		statusUpdated := createRun(1000, app.StatusInProgress)
+		taskChecked := createRun(1500, app.StatusInProgress)
+		participantJoined := createRun(1000, app.StatusInProgress)
+		finished := createRun(1000, app.StatusFinished)
+
+		createEvent(statusUpdated, app.TimelineEvent{EventType: app.StatusUpdated, EventAt: 4000})
+		createEvent(taskChecked, app.TimelineEvent{EventType: app.TaskStateModified, EventAt: 3000})
+		createEvent(participantJoined, app.TimelineEvent{EventType: app.ParticipantsChanged, EventAt: 5000})
+		// Events that are not activity, and activity older than the last one, don't move it.
+		createEvent(quiet, app.TimelineEvent{EventType: app.StatusUpdateRequested, EventAt: 9000})
+		createEvent(statusUpdated, app.TimelineEvent{EventType: app.StatusUpdated, EventAt: 2000})
+
+		t.Run(driverName+" - stalest first", func(t *testing.T) {
+			require.Equal(t, []string{quiet.ID, taskChecked.ID, statusUpdated.ID, participantJoined.ID}, getStaleIDs(6000))
+		})
+
+		t.Run(driverName+" - only runs without activity since the threshold", func(t *testing.T) {
+			require.Equal(t, []string{quiet.ID, taskChecked.ID}, getStaleIDs(4000))
+			require.Empty(t, getStaleIDs(1000))
+		})
+
+		t.Run(driverName+" - paginate and report the last activity", func(t *testing.T) {
+			results, err := playbookRunStore.GetStalePlaybookRuns(requesterInfo, teamID, 6000, app.StaleRunsOptions{Page: 1, PerPage: 1})
+			require.NoError(t, err)
+			require.Equal(t, 4, results.TotalCount)
+			require.True(t, results.HasMore)
+			require.Len(t, results.Items, 1)
+			require.Equal(t, taskChecked.ID, results.Items[0].ID)
+			require.Equal(t, int64(3000), results.Items[0].LastActivityAt)
+		})
+
+		t.Run(driverName+" - finished runs are never stale", func(t *testing.T) {
+			require.NotContains(t, getStaleIDs(6000), finished.ID)
+		})
+	}
+}
+
 func TestNukeDB(t *testing.T) {
 	team1id := model.NewId()
 
@@ -752,6 +1430,15 @@ func TestTasksAndRunsDigest(t *testing.T) {
 			ToPlaybookRun()
 		inc03.Checklists[2].Items[2].AssigneeID = "someotheruserid"
 
+		// due dates: only the open, past due item of an active run is overdue
+		now := model.GetMillis()
+		inc01.Checklists[0].Items[0].DueDate = now - 1000
+		inc02.Checklists[0].Items[0].DueDate = now - 1000
+		inc02.Checklists[0].Items[0].State = app.ChecklistItemStateClosed
+		inc03.Checklists[0].Items[0].DueDate = now + time.Hour.Milliseconds()
+		inc04.Checklists[0].Items[0].DueDate = now - 1000
+		overdueTaskTitle := inc01.Checklists[0].Items[0].Title
+
 		playbookRuns := []app.PlaybookRun{inc01, inc02, inc03, inc04, inc05, inc06}
 
 		for i := range playbookRuns {
@@ -788,97 +1475,409 @@ func TestTasksAndRunsDigest(t *testing.T) {
 			}
 		})
 
-		t.Run("gets participating runs only", func(t *testing.T) {
-			runs, err := playbookRunStore.GetParticipatingRuns(userID)
-			require.NoError(t, err)
+		t.Run("gets overdue tasks only", func(t *testing.T) {
+			runs, err := playbookRunStore.GetRunsWithOverdueTasks(now)
+			require.NoError(t, err)
+
+			require.Len(t, runs, 1)
+			require.Equal(t, channel01.Name, runs[0].ChannelName)
+			require.Len(t, runs[0].Tasks, 1)
+			require.Equal(t, overdueTaskTitle, runs[0].Tasks[0].Title)
+		})
+
+		t.Run("gets participating runs only", func(t *testing.T) {
+			runs, err := playbookRunStore.GetParticipatingRuns(userID)
+			require.NoError(t, err)
+
+			total := len(runs)
+
+			require.Equal(t, 4, total)
+
+			// don't make assumptions about ordering until we figure that out PM-side
+			expected := map[string]int{
+				channel01.Name: 1,
+				channel02.Name: 1,
+				channel03.Name: 1,
+				channel06.Name: 1,
+			}
+
+			actual := make(map[string]int)
+
+			for _, run := range runs {
+				actual[run.ChannelName]++
+			}
+
+			require.Equal(t, expected, actual)
+		})
+
+		t.Run("gets overdue updates", func(t *testing.T) {
+			runs, err := playbookRunStore.GetOverdueUpdateRuns(userID)
+			require.NoError(t, err)
+
+			total := len(runs)
+
+			require.Equal(t, 2, total)
+
+			// don't make assumptions about ordering until we figure that out PM-side
+			expected := map[string]int{
+				channel01.Name: 1,
+				channel02.Name: 1,
+			}
+
+			actual := make(map[string]int)
+
+			for _, run := range runs {
+				actual[run.ChannelName]++
+			}
+
+			require.Equal(t, expected, actual)
+		})
+	}
+}
+
+func TestGetRunsActiveTotal(t *testing.T) {
+	createRuns := func(store *SQLStore, playbookRunStore app.PlaybookRunStore, num int, status string) {
+		now := model.GetMillis()
+		for i := 0; i < num; i++ {
+			run := NewBuilder(t).
+				WithCreateAt(now - int64(i*1000)).
+				WithCurrentStatus(status).
+				ToPlaybookRun()
+
+			returned, err := playbookRunStore.CreatePlaybookRun(run)
+			require.NoError(t, err)
+			createPlaybookRunChannel(t, store, returned)
+		}
+	}
+
+	for _, driverName := range driverNames {
+		db := setupTestDB(t, driverName)
+		playbookRunStore := setupPlaybookRunStore(t, db)
+		store := setupSQLStore(t, db)
+
+		t.Run("zero runs", func(t *testing.T) {
+			actual, err := playbookRunStore.GetRunsActiveTotal()
+			require.NoError(t, err)
+			require.Equal(t, int64(0), actual)
+		})
+
+		// add finished runs
+		createRuns(store, playbookRunStore, 10, app.StatusFinished)
+
+		t.Run("zero active runs, few finished runs", func(t *testing.T) {
+			actual, err := playbookRunStore.GetRunsActiveTotal()
+			require.NoError(t, err)
+			require.Equal(t, int64(0), actual)
+		})
+
+		// add active runs
+		createRuns(store, playbookRunStore, 15, app.StatusInProgress)
+		t.Run("few active runs, few finished runs", func(t *testing.T) {
+			actual, err := playbookRunStore.GetRunsActiveTotal()
+			require.NoError(t, err)
+			require.Equal(t, int64(15), actual)
+		})
+	}
+}
+
+func TestGetPlaybookRunsFilterByAssignee(t *testing.T) {
+	assigneeID := model.NewId()
+	otherUserID := model.NewId()
+
+	for _, driverName := range driverNames {
+		db := setupTestDB(t, driverName)
+		playbookRunStore := setupPlaybookRunStore(t, db)
+		store := setupSQLStore(t, db)
+
+		teamID := model.NewId()
+		now := model.GetMillis()
+		newRun := func(i int) *app.PlaybookRun {
+			return NewBuilder(t).
+				WithName(fmt.Sprint("run ", i)).
+				WithTeamID(teamID).
+				WithCreateAt(now + int64(i*1000)).
+				WithChecklists([]int{2, 2}).
+				ToPlaybookRun()
+		}
+
+		// open item assigned to the user
+		run0 := newRun(0)
+		run0.Checklists[0].Items[1].AssigneeID = assigneeID
+
+		// item assigned to the user, but already done
+		run1 := newRun(1)
+		run1.Checklists[0].Items[0].AssigneeID = assigneeID
+		run1.Checklists[0].Items[0].State = app.ChecklistItemStateClosed
+
+		// open item assigned to someone else
+		run2 := newRun(2)
+		run2.Checklists[1].Items[0].AssigneeID = otherUserID
+
+		// done and open items assigned to the user in different checklists
+		run3 := newRun(3)
+		run3.Checklists[0].Items[0].AssigneeID = assigneeID
+		run3.Checklists[0].Items[0].State = app.ChecklistItemStateClosed
+		run3.Checklists[1].Items[1].AssigneeID = assigneeID
+
+		// item assigned to the user, but skipped
+		run4 := newRun(4)
+		run4.Checklists[1].Items[1].AssigneeID = assigneeID
+		run4.Checklists[1].Items[1].State = app.ChecklistItemStateSkipped
+
+		// open item assigned to the user, next to one assigned to someone else
+		run5 := newRun(5)
+		run5.Checklists[1].Items[0].AssigneeID = otherUserID
+		run5.Checklists[1].Items[1].AssigneeID = assigneeID
+
+		runIDs := []string{}
+		for _, run := range []*app.PlaybookRun{run0, run1, run2, run3, run4, run5} {
+			created, err := playbookRunStore.CreatePlaybookRun(run)
+			require.NoError(t, err)
+			createPlaybookRunChannel(t, store, created)
+			runIDs = append(runIDs, created.ID)
+		}
+
+		getRuns := func(t *testing.T, options app.PlaybookRunFilterOptions) *app.GetPlaybookRunsResults {
+			t.Helper()
+
+			options.TeamID = teamID
+			options.AssigneeID = assigneeID
+			if options.PerPage == 0 {
+				options.PerPage = 10
+			}
+			results, err := playbookRunStore.GetPlaybookRuns(app.RequesterInfo{
+				UserID:  "testID",
+				IsAdmin: true,
+			}, options)
+			require.NoError(t, err)
+
+			return results
+		}
+
+		resultIDs := func(results *app.GetPlaybookRunsResults) []string {
+			ids := []string{}
+			for _, run := range results.Items {
+				ids = append(ids, run.ID)
+			}
+			return ids
+		}
+
+		t.Run("only runs with open items assigned to the user", func(t *testing.T) {
+			results := getRuns(t, app.PlaybookRunFilterOptions{
+				Sort:      app.SortByCreateAt,
+				Direction: app.DirectionAsc,
+			})
+
+			require.Equal(t, 3, results.TotalCount)
+			require.Equal(t, []string{runIDs[0], runIDs[3], runIDs[5]}, resultIDs(results))
+		})
+
+		t.Run("sorting", func(t *testing.T) {
+			results := getRuns(t, app.PlaybookRunFilterOptions{
+				Sort:      app.SortByCreateAt,
+				Direction: app.DirectionDesc,
+			})
+
+			require.Equal(t, []string{runIDs[5], runIDs[3], runIDs[0]}, resultIDs(results))
+		})
 
-			total := len(runs)
+		t.Run("pagination", func(t *testing.T) {
+			results := getRuns(t, app.PlaybookRunFilterOptions{
+				Sort:      app.SortByCreateAt,
+				Direction: app.DirectionAsc,
+				Page:      0,
+				PerPage:   2,
+			})
 
-			require.Equal(t, 4, total)
+			require.Equal(t, 3, results.TotalCount)
+			require.Equal(t, 2, results.PageCount)
+			require.True(t, results.HasMore)
+			require.Equal(t, []string{runIDs[0], runIDs[3]}, resultIDs(results))
 
-			// don't make assumptions about ordering until we figure that out PM-side
-			expected := map[string]int{
-				channel01.Name: 1,
-				channel02.Name: 1,
-				channel03.Name: 1,
-				channel06.Name: 1,
-			}
+			results = getRuns(t, app.PlaybookRunFilterOptions{
+				Sort:      app.SortByCreateAt,
+				Direction: app.DirectionAsc,
+				Page:      1,
+				PerPage:   2,
+			})
 
-			actual := make(map[string]int)
+			require.Equal(t, 3, results.TotalCount)
+			require.False(t, results.HasMore)
+			require.Equal(t, []string{runIDs[5]}, resultIDs(results))
+		})
 
-			for _, run := range runs {
-				actual[run.ChannelName]++
-			}
+		t.Run("combined with another filter", func(t *testing.T) {
+			results := getRuns(t, app.PlaybookRunFilterOptions{
+				Sort:       app.SortByCreateAt,
+				Direction:  app.DirectionAsc,
+				SearchTerm: "run 3",
+			})
 
-			require.Equal(t, expected, actual)
+			require.Equal(t, []string{runIDs[3]}, resultIDs(results))
 		})
+	}
+}
 
-		t.Run("gets overdue updates", func(t *testing.T) {
-			runs, err := playbookRunStore.GetOverdueUpdateRuns(userID)
-			require.NoError(t, err)
+func TestSearchStatusUpdates(t *testing.T) {
+	for _, driverName := range driverNames {
+		db := setupTestDB(t, driverName)
+		store := setupSQLStore(t, db)
+		runStore := setupPlaybookRunStore(t, db).(*playbookRunStore)
+		setupPostsTable(t, db)
 
-			total := len(runs)
+		teamID := model.NewId()
+		otherTeamID := model.NewId()
 
-			require.Equal(t, 2, total)
+		run1, err := runStore.CreatePlaybookRun(NewBuilder(t).WithTeamID(teamID).WithName("Outage").ToPlaybookRun())
+		require.NoError(t, err)
+		run2, err := runStore.CreatePlaybookRun(NewBuilder(t).WithTeamID(teamID).WithName("Slow queries").ToPlaybookRun())
+		require.NoError(t, err)
+		run3, err := runStore.CreatePlaybookRun(NewBuilder(t).WithTeamID(otherTeamID).WithName("Other team").ToPlaybookRun())
+		require.NoError(t, err)
 
-			// don't make assumptions about ordering until we figure that out PM-side
-			expected := map[string]int{
-				channel01.Name: 1,
-				channel02.Name: 1,
+		newStatusPost := func(run *app.PlaybookRun, createAt int64, message string, deleted bool) *model.Post {
+			post := &model.Post{Id: model.NewId(), CreateAt: createAt, Message: message}
+			if deleted {
+				post.DeleteAt = createAt + 1
 			}
+			savePosts(t, store, []*model.Post{post})
+			require.NoError(t, runStore.UpdateStatus(&app.SQLStatusPost{PlaybookRunID: run.ID, PostID: post.Id}))
+			return post
+		}
 
-			actual := make(map[string]int)
+		post1 := newStatusPost(run1, 1000, "Started the database failover to the secondary region", false)
+		newStatusPost(run1, 2000, "Waiting for the replicas to catch up", false)
+		post3 := newStatusPost(run2, 3000, "The database failover is complete", false)
+		newStatusPost(run2, 4000, "Database failover was rolled back", true)
+		newStatusPost(run3, 5000, "Database failover in the other team", false)
 
-			for _, run := range runs {
-				actual[run.ChannelName]++
-			}
+		admin := app.RequesterInfo{UserID: model.NewId(), IsAdmin: true}
 
-			require.Equal(t, expected, actual)
+		t.Run("matches the status updates of the team, newest first", func(t *testing.T) {
+			results, err := runStore.SearchStatusUpdates(admin, teamID, "database failover", app.StatusUpdateSearchOptions{})
+			require.NoError(t, err)
+			require.Equal(t, 2, results.TotalCount)
+			require.Equal(t, []app.StatusUpdateSearchResult{
+				{
+					PlaybookRunID:   run2.ID,
+					PlaybookRunName: run2.Name,
+					PostID:          post3.Id,
+					Snippet:         "The database failover is complete",
+					CreateAt:        3000,
+				},
+				{
+					PlaybookRunID:   run1.ID,
+					PlaybookRunName: run1.Name,
+					PostID:          post1.Id,
+					Snippet:         "Started the database failover to the secondary region",
+					CreateAt:        1000,
+				},
+			}, results.Items)
 		})
-	}
-}
 
-func TestGetRunsActiveTotal(t *testing.T) {
-	createRuns := func(store *SQLStore, playbookRunStore app.PlaybookRunStore, num int, status string) {
-		now := model.GetMillis()
-		for i := 0; i < num; i++ {
-			run := NewBuilder(t).
-				WithCreateAt(now - int64(i*1000)).
-				WithCurrentStatus(status).
-				ToPlaybookRun()
+		t.Run("paginates", func(t *testing.T) {
+			results, err := runStore.SearchStatusUpdates(admin, teamID, "database failover", app.StatusUpdateSearchOptions{Page: 1, PerPage: 1})
+			require.NoError(t, err)
+			require.Equal(t, 2, results.TotalCount)
+			require.Equal(t, 2, results.PageCount)
+			require.False(t, results.HasMore)
+			require.Len(t, results.Items, 1)
+			require.Equal(t, post1.Id, results.Items[0].PostID)
+		})
 
-			returned, err := playbookRunStore.CreatePlaybookRun(run)
+		t.Run("all teams", func(t *testing.T) {
+			results, err := runStore.SearchStatusUpdates(admin, "", "failover", app.StatusUpdateSearchOptions{})
 			require.NoError(t, err)
-			createPlaybookRunChannel(t, store, returned)
-		}
+			require.Equal(t, 3, results.TotalCount)
+		})
+
+		t.Run("like fallback", func(t *testing.T) {
+			results, err := runStore.searchStatusUpdates(admin, teamID, "replicas", app.StatusUpdateSearchOptions{},
+				runStore.buildStatusUpdateLikeExpr("REPLICAS"))
+			require.NoError(t, err)
+			require.Equal(t, 1, results.TotalCount)
+		})
+
+		t.Run("empty term", func(t *testing.T) {
+			_, err := runStore.SearchStatusUpdates(admin, teamID, "  ", app.StatusUpdateSearchOptions{})
+			require.Error(t, err)
+		})
 	}
+}
+
+func TestStatusUpdateSnippet(t *testing.T) {
+	long := strings.Repeat("a", 100) + " Database failover " + strings.Repeat("b", 100)
+
+	require.Equal(t, "Short update", statusUpdateSnippet("Short update", "failover"))
+	require.Equal(t, "…"+strings.Repeat("a", 79)+" Database failover "+strings.Repeat("b", 70)+"…", statusUpdateSnippet(long, "failover database"))
+	require.Equal(t, strings.Repeat("a", 100)+" Database failover "+strings.Repeat("b", 41)+"…", statusUpdateSnippet(long, "failing"))
+}
+
+func TestGetTaskLoad(t *testing.T) {
+	teamID := model.NewId()
+	aliceID, bobID, carolID := model.NewId(), model.NewId(), model.NewId()
+	requesterInfo := app.RequesterInfo{UserID: "testID", IsAdmin: true}
 
 	for _, driverName := range driverNames {
 		db := setupTestDB(t, driverName)
 		playbookRunStore := setupPlaybookRunStore(t, db)
-		store := setupSQLStore(t, db)
+		setupChannelsTable(t, db)
 
-		t.Run("zero runs", func(t *testing.T) {
-			actual, err := playbookRunStore.GetRunsActiveTotal()
+		t.Run("no runs", func(t *testing.T) {
+			load, err := playbookRunStore.GetTaskLoad(requesterInfo, app.TaskLoadOptions{TeamID: teamID, Sort: app.TaskLoadSortByLoad, Limit: 10})
 			require.NoError(t, err)
-			require.Equal(t, int64(0), actual)
+			require.Empty(t, load)
+			require.NotNil(t, load)
 		})
 
-		// add finished runs
-		createRuns(store, playbookRunStore, 10, app.StatusFinished)
+		createRun := func(builder *PlaybookRunBuilder, checklists ...app.Checklist) {
+			run := builder.WithTeamID(teamID).ToPlaybookRun()
+			run.Checklists = checklists
+			_, err := playbookRunStore.CreatePlaybookRun(run)
+			require.NoError(t, err)
+		}
 
-		t.Run("zero active runs, few finished runs", func(t *testing.T) {
-			actual, err := playbookRunStore.GetRunsActiveTotal()
+		createRun(NewBuilder(t).WithOwnerUserID(aliceID), app.Checklist{Title: "A", Items: []app.ChecklistItem{
+			{AssigneeID: bobID},
+			{AssigneeID: bobID, State: app.ChecklistItemStateInProgress},
+			{AssigneeID: bobID, State: app.ChecklistItemStateClosed},
+			{AssigneeID: bobID, State: app.ChecklistItemStateSkipped},
+			{AssigneeID: bobID, Hidden: true},
+			{Title: "unassigned"},
+		}}, app.Checklist{Title: "Empty"})
+		createRun(NewBuilder(t).WithOwnerUserID(aliceID), app.Checklist{Title: "A", Items: []app.ChecklistItem{{AssigneeID: carolID}}})
+		createRun(NewBuilder(t).WithOwnerUserID(carolID), app.Checklist{Title: "A", Items: []app.ChecklistItem{
+			{AssigneeID: aliceID, State: app.ChecklistItemStateClosed},
+		}})
+		createRun(NewBuilder(t).WithOwnerUserID(carolID).WithCurrentStatus(app.StatusFinished), app.Checklist{Title: "A", Items: []app.ChecklistItem{
+			{AssigneeID: bobID},
+		}})
+		createRun(NewBuilder(t).WithOwnerUserID(bobID).WithTeamID(model.NewId()))
+
+		t.Run("by load", func(t *testing.T) {
+			load, err := playbookRunStore.GetTaskLoad(requesterInfo, app.TaskLoadOptions{TeamID: teamID, Sort: app.TaskLoadSortByLoad, Limit: 10})
 			require.NoError(t, err)
-			require.Equal(t, int64(0), actual)
+			require.Equal(t, []app.UserTaskLoad{
+				{UserID: bobID, IncompleteItems: 2},
+				{UserID: carolID, IncompleteItems: 1, OwnedRuns: 1},
+				{UserID: aliceID, OwnedRuns: 2},
+			}, load)
 		})
 
-		// add active runs
-		createRuns(store, playbookRunStore, 15, app.StatusInProgress)
-		t.Run("few active runs, few finished runs", func(t *testing.T) {
-			actual, err := playbookRunStore.GetRunsActiveTotal()
+		t.Run("by owned runs", func(t *testing.T) {
+			load, err := playbookRunStore.GetTaskLoad(requesterInfo, app.TaskLoadOptions{TeamID: teamID, Sort: app.TaskLoadSortByOwnedRuns, Limit: 10})
 			require.NoError(t, err)
-			require.Equal(t, int64(15), actual)
+			require.Equal(t, []app.UserTaskLoad{
+				{UserID: aliceID, OwnedRuns: 2},
+				{UserID: carolID, IncompleteItems: 1, OwnedRuns: 1},
+				{UserID: bobID, IncompleteItems: 2},
+			}, load)
+		})
+
+		t.Run("limit", func(t *testing.T) {
+			load, err := playbookRunStore.GetTaskLoad(requesterInfo, app.TaskLoadOptions{TeamID: teamID, Sort: app.TaskLoadSortByLoad, Limit: 1})
+			require.NoError(t, err)
+			require.Equal(t, []app.UserTaskLoad{{UserID: bobID, IncompleteItems: 2}}, load)
 		})
 	}
 }
@@ -943,6 +1942,38 @@ func TestGetOverdueUpdateRunsTotal(t *testing.T) {
 	}
 }
 
+func TestGetStatusUpdateReminders(t *testing.T) {
+	for _, driverName := range driverNames {
+		db := setupTestDB(t, driverName)
+		playbookRunStore := setupPlaybookRunStore(t, db)
+		store := setupSQLStore(t, db)
+
+		createRun := func(builder *PlaybookRunBuilder) *app.PlaybookRun {
+			run, err := playbookRunStore.CreatePlaybookRun(builder.ToPlaybookRun())
+			require.NoError(t, err)
+			createPlaybookRunChannel(t, store, run)
+			return run
+		}
+
+		withReminder := createRun(NewBuilder(t).WithStatusUpdateEnabled(true).WithUpdateOverdueBy(time.Hour))
+		createRun(NewBuilder(t).WithStatusUpdateEnabled(true))
+		createRun(NewBuilder(t).WithStatusUpdateEnabled(false).WithUpdateOverdueBy(time.Hour))
+		createRun(NewBuilder(t).WithStatusUpdateEnabled(true).WithUpdateOverdueBy(time.Hour).WithCurrentStatus(app.StatusFinished))
+		reminded := createRun(NewBuilder(t).WithStatusUpdateEnabled(true).WithUpdateOverdueBy(time.Hour))
+		reminded.ReminderPostID = model.NewId()
+		_, err := playbookRunStore.UpdatePlaybookRun(reminded)
+		require.NoError(t, err)
+
+		reminders, err := playbookRunStore.GetStatusUpdateReminders()
+		require.NoError(t, err)
+		require.Equal(t, []app.StatusUpdateReminder{{
+			PlaybookRunID:      withReminder.ID,
+			LastStatusUpdateAt: withReminder.LastStatusUpdateAt,
+			PreviousReminder:   withReminder.PreviousReminder,
+		}}, reminders)
+	}
+}
+
 func TestGetOverdueRetroRunsTotal(t *testing.T) {
 	createRuns := func(
 		store *SQLStore,
@@ -1078,6 +2109,218 @@ func TestGetFollowersActiveTotal(t *testing.T) {
 	}
 }
 
+func TestGetRunFollowersSince(t *testing.T) {
+	alice := model.NewId()
+	bob := model.NewId()
+
+	for _, driverName := range driverNames {
+		db := setupTestDB(t, driverName)
+		playbookRunStore := setupPlaybookRunStore(t, db)
+		setupChannelsTable(t, db)
+
+		createRun := func(followers []string, endAt int64) string {
+			run, err := playbookRunStore.CreatePlaybookRun(NewBuilder(t).ToPlaybookRun())
+			require.NoError(t, err)
+			for _, follower := range followers {
+				require.NoError(t, playbookRunStore.Follow(run.ID, follower))
+			}
+			if endAt > 0 {
+				finishRun(t, playbookRunStore, run.ID, endAt)
+			}
+			return run.ID
+		}
+
+		active := createRun([]string{bob, alice}, 0)
+		recentlyFinished := createRun([]string{alice}, 2000)
+		createRun([]string{alice, bob}, 500)
+		unfollowed := createRun([]string{bob}, 0)
+		require.NoError(t, playbookRunStore.Unfollow(unfollowed, bob))
+		createRun(nil, 0)
+
+		actual, err := playbookRunStore.GetRunFollowersSince(1000)
+		require.NoError(t, err)
+
+		expected := []app.RunFollower{
+			{PlaybookRunID: active, UserID: alice},
+			{PlaybookRunID: recentlyFinished, UserID: alice},
+			{PlaybookRunID: active, UserID: bob},
+		}
+		sort.Slice(expected, func(i, j int) bool {
+			if expected[i].UserID != expected[j].UserID {
+				return expected[i].UserID < expected[j].UserID
+			}
+			return expected[i].PlaybookRunID < expected[j].PlaybookRunID
+		})
+		require.Equal(t, expected, actual)
+	}
+}
+
+func TestFollowerDigestSentAt(t *testing.T) {
+	for _, driverName := range driverNames {
+		db := setupTestDB(t, driverName)
+		playbookRunStore := setupPlaybookRunStore(t, db)
+
+		sentAt, err := playbookRunStore.GetFollowerDigestSentAt()
+		require.NoError(t, err)
+		require.Zero(t, sentAt)
+
+		require.NoError(t, playbookRunStore.SetFollowerDigestSentAt(1000))
+		require.NoError(t, playbookRunStore.SetFollowerDigestSentAt(2000))
+
+		sentAt, err = playbookRunStore.GetFollowerDigestSentAt()
+		require.NoError(t, err)
+		require.EqualValues(t, 2000, sentAt)
+	}
+}
+
+func TestGetPlaybookRunActivity(t *testing.T) {
+	for _, driverName := range driverNames {
+		db := setupTestDB(t, driverName)
+		store := setupSQLStore(t, db)
+		runStore := setupPlaybookRunStore(t, db)
+		setupPostsTable(t, db)
+
+		// The completed item makes room for the event recording its completion.
+		run := NewBuilder(t).WithTeamID(model.NewId()).WithChecklists([]int{1}).ToPlaybookRun()
+		run.Checklists[0].Items[0].State = app.ChecklistItemStateClosed
+		run.Checklists[0].Items[0].StateModified = 3200
+		run, err := runStore.CreatePlaybookRun(run)
+		require.NoError(t, err)
+
+		authorID := model.NewId()
+		newStatusPost := func(createAt int64, deleted bool) *model.Post {
+			post := &model.Post{Id: model.NewId(), CreateAt: createAt}
+			if deleted {
+				post.DeleteAt = createAt + 1
+			}
+			savePosts(t, store, []*model.Post{post})
+			require.NoError(t, runStore.UpdateStatus(&app.SQLStatusPost{PlaybookRunID: run.ID, PostID: post.Id}))
+			_, err := runStore.CreateTimelineEvent(&app.TimelineEvent{
+				PlaybookRunID: run.ID,
+				EventAt:       createAt,
+				EventType:     app.StatusUpdated,
+				PostID:        post.Id,
+				SubjectUserID: authorID,
+			})
+			require.NoError(t, err)
+			return post
+		}
+		newEvent := func(eventAt int64) string {
+			event, err := runStore.CreateTimelineEvent(&app.TimelineEvent{
+				PlaybookRunID: run.ID,
+				EventAt:       eventAt,
+				EventType:     app.UserJoinedLeft,
+			})
+			require.NoError(t, err)
+			return event.ID
+		}
+
+		newStatusPost(1000, false)
+		newStatusPost(2000, true)
+		post3 := newStatusPost(3000, false)
+		post4 := newStatusPost(4000, false)
+		newEvent(1500)
+		event2 := newEvent(3500)
+		event3 := newEvent(4500)
+		newEvent(5500)
+
+		activity, err := runStore.GetPlaybookRunActivity(run.ID, 2000, "", 1)
+		require.NoError(t, err)
+		require.Len(t, activity.Checklists, 1)
+		require.Equal(t, run.Checklists[0].Items[0].ID, activity.Checklists[0].Items[0].ID)
+
+		require.Len(t, activity.StatusPosts, 1)
+		require.Equal(t, post3.Id, activity.StatusPosts[0].ID)
+
+		eventIDs := []string{}
+		for _, event := range activity.TimelineEvents {
+			eventIDs = append(eventIDs, event.ID)
+			if event.EventType == app.StatusUpdated {
+				require.Equal(t, post3.Id, event.PostID)
+				require.Equal(t, authorID, event.SubjectUserID)
+			}
+		}
+		require.Len(t, eventIDs, 3)
+		require.Contains(t, eventIDs, event2)
+		require.Contains(t, eventIDs, event3)
+
+		t.Run("the entry at the position is left out", func(t *testing.T) {
+			activity, err := runStore.GetPlaybookRunActivity(run.ID, 3000, post3.Id, 1)
+			require.NoError(t, err)
+			require.Len(t, activity.StatusPosts, 1)
+			require.Equal(t, post4.Id, activity.StatusPosts[0].ID)
+		})
+
+		t.Run("unknown run", func(t *testing.T) {
+			_, err := runStore.GetPlaybookRunActivity(model.NewId(), 0, "", 1)
+			require.ErrorIs(t, err, app.ErrNotFound)
+		})
+	}
+}
+
+func TestReassignChecklistItems(t *testing.T) {
+	alice := model.NewId()
+	bob := model.NewId()
+	teamID := model.NewId()
+
+	for _, driverName := range driverNames {
+		db := setupTestDB(t, driverName)
+		playbookRunStore := setupPlaybookRunStore(t, db)
+		setupChannelsTable(t, db)
+
+		createRun := func(teamID, status string, items ...app.ChecklistItem) string {
+			run := NewBuilder(t).WithTeamID(teamID).WithCurrentStatus(status).ToPlaybookRun()
+			run.Checklists = []app.Checklist{{ID: model.NewId(), Title: "Checklist", Items: items}}
+			run, err := playbookRunStore.CreatePlaybookRun(run)
+			require.NoError(t, err)
+			return run.ID
+		}
+
+		open := createRun(teamID, app.StatusInProgress,
+			app.ChecklistItem{ID: model.NewId(), AssigneeID: alice},
+			app.ChecklistItem{ID: model.NewId(), AssigneeID: alice, State: app.ChecklistItemStateClosed},
+			app.ChecklistItem{ID: model.NewId(), AssigneeID: bob},
+		)
+		inProgress := createRun(model.NewId(), app.StatusInProgress,
+			app.ChecklistItem{ID: model.NewId(), AssigneeID: alice, State: app.ChecklistItemStateInProgress},
+		)
+		createRun(teamID, app.StatusInProgress, app.ChecklistItem{ID: model.NewId(), AssigneeID: alice, State: app.ChecklistItemStateSkipped})
+		createRun(teamID, app.StatusFinished, app.ChecklistItem{ID: model.NewId(), AssigneeID: alice})
+
+		t.Run(driverName+" - get runs with incomplete tasks", func(t *testing.T) {
+			ids, err := playbookRunStore.GetRunIDsWithIncompleteTasks(alice, "", nil)
+			require.NoError(t, err)
+			require.ElementsMatch(t, []string{open, inProgress}, ids)
+
+			ids, err = playbookRunStore.GetRunIDsWithIncompleteTasks(alice, teamID, nil)
+			require.NoError(t, err)
+			require.Equal(t, []string{open}, ids)
+
+			ids, err = playbookRunStore.GetRunIDsWithIncompleteTasks(alice, "", []string{inProgress})
+			require.NoError(t, err)
+			require.Equal(t, []string{inProgress}, ids)
+		})
+
+		t.Run(driverName+" - reassign", func(t *testing.T) {
+			reassigned, err := playbookRunStore.ReassignChecklistItems([]string{open, inProgress}, alice, bob, 1000)
+			require.NoError(t, err)
+			require.Equal(t, map[string]int{open: 1, inProgress: 1}, reassigned)
+
+			run, err := playbookRunStore.GetPlaybookRun(open)
+			require.NoError(t, err)
+			items := run.Checklists[0].Items
+			require.Equal(t, bob, items[0].AssigneeID)
+			require.Equal(t, int64(1000), items[0].AssigneeModified)
+			require.Equal(t, alice, items[1].AssigneeID)
+			require.Equal(t, bob, items[2].AssigneeID)
+
+			ids, err := playbookRunStore.GetRunIDsWithIncompleteTasks(alice, "", nil)
+			require.NoError(t, err)
+			require.Empty(t, ids)
+		})
+	}
+}
+
 func TestGetParticipantsActiveTotal(t *testing.T) {
 	createRuns := func(
 		store *SQLStore,
@@ -1179,6 +2422,14 @@ func TestGetParticipantsActiveTotal(t *testing.T) {
 	}
 }
 
+func finishRun(t *testing.T, playbookRunStore app.PlaybookRunStore, playbookRunID string, endAt int64) {
+	t.Helper()
+
+	finished, err := playbookRunStore.FinishPlaybookRun(playbookRunID, endAt)
+	require.NoError(t, err)
+	require.True(t, finished)
+}
+
 func setupPlaybookRunStore(t *testing.T, db *sqlx.DB) app.PlaybookRunStore {
 	mockCtrl := gomock.NewController(t)
 
@@ -1194,6 +2445,99 @@ func setupPlaybookRunStore(t *testing.T, db *sqlx.DB) app.PlaybookRunStore {
 	return NewPlaybookRunStore(pluginAPIClient, sqlStore)
 }
 
+func TestGetUsersForAutocomplete(t *testing.T) {
+	for _, driverName := range driverNames {
+		db := setupTestDB(t, driverName)
+		playbookRunStore := setupPlaybookRunStore(t, db)
+		store := setupSQLStore(t, db)
+
+		teamID := model.NewId()
+		channelID := model.NewId()
+
+		addUser := func(username, firstName, lastName string, deleteAt int64) userInfo {
+			user := userInfo{ID: model.NewId(), Name: username}
+			_, err := store.execBuilder(store.db, sq.
+				Insert("Users").
+				SetMap(map[string]interface{}{
+					"ID":        user.ID,
+					"Username":  username,
+					"FirstName": firstName,
+					"LastName":  lastName,
+					"Nickname":  "",
+					"DeleteAt":  deleteAt,
+				}))
+			require.NoError(t, err)
+			return user
+		}
+
+		annie := addUser("annie", "Anne", "Smith", 0)
+		andy := addUser("andy", "Andrew", "Jones", 0)
+		bob := addUser("bob", "Robert", "Anderson", 0)
+		alex := addUser("alex", "Alex", "Brown", 0)
+		deleted := addUser("anna", "Anna", "Deleted", 1000)
+		addUser("arthur", "Arthur", "Other Team", 0)
+
+		addUsersToTeam(t, store, []userInfo{annie, andy, bob, alex, deleted}, teamID)
+		addUsersToChannels(t, store, []userInfo{bob, andy}, []string{channelID})
+
+		usernames := func(t *testing.T, prefix string, limit int) []string {
+			users, err := playbookRunStore.GetUsersForAutocomplete(teamID, channelID, prefix, true, limit)
+			require.NoError(t, err)
+
+			names := []string{}
+			for _, user := range users {
+				names = append(names, user.Username)
+			}
+			return names
+		}
+
+		t.Run("channel members first", func(t *testing.T) {
+			require.Equal(t, []string{"andy", "bob", "alex", "annie"}, usernames(t, "a", 10))
+		})
+
+		t.Run("the result count is bounded", func(t *testing.T) {
+			require.Equal(t, []string{"andy", "bob"}, usernames(t, "a", 2))
+		})
+
+		t.Run("prefix of the names, ignoring case", func(t *testing.T) {
+			require.Equal(t, []string{"bob"}, usernames(t, "ROB", 10))
+			require.Equal(t, []string{"annie"}, usernames(t, "smi", 10))
+			require.Empty(t, usernames(t, "nie", 10))
+		})
+
+		t.Run("full names are not matched if hidden", func(t *testing.T) {
+			users, err := playbookRunStore.GetUsersForAutocomplete(teamID, channelID, "a", false, 10)
+			require.NoError(t, err)
+			require.Len(t, users, 3)
+			require.Equal(t, "andy", users[0].Username)
+			require.Equal(t, "alex", users[1].Username)
+			require.Equal(t, "annie", users[2].Username)
+
+			users, err = playbookRunStore.GetUsersForAutocomplete(teamID, channelID, "rob", false, 10)
+			require.NoError(t, err)
+			require.Empty(t, users)
+		})
+
+		t.Run("like wildcards are matched literally", func(t *testing.T) {
+			require.Empty(t, usernames(t, "%", 10))
+			require.Empty(t, usernames(t, "_", 10))
+		})
+
+		t.Run("channel membership is reported", func(t *testing.T) {
+			users, err := playbookRunStore.GetUsersForAutocomplete(teamID, channelID, "an", true, 10)
+			require.NoError(t, err)
+			require.Len(t, users, 3)
+			require.Equal(t, "andy", users[0].Username)
+			require.True(t, users[0].InChannel)
+			require.Equal(t, "bob", users[1].Username)
+			require.True(t, users[1].InChannel)
+			require.Equal(t, "annie", users[2].Username)
+			require.False(t, users[2].InChannel)
+			require.Equal(t, "Anne", users[2].FirstName)
+		})
+	}
+}
+
 func TestGetSchemeRolesForChannel(t *testing.T) {
 	for _, driverName := range driverNames {
 		db := setupTestDB(t, driverName)
@@ -1701,6 +3045,8 @@ func generateMetricData(playbook app.Playbook) []app.RunMetricData {
 			app.RunMetricData{
 				MetricConfigID: mc.ID,
 				Value:          null.IntFrom(int64(i + 10)),
+				// Values are above the targets, and lower is better
+				Breached: mc.Target.Valid,
 			},
 		)
 	}
@@ -1716,3 +3062,39 @@ func min(a, b int) int {
 	}
 	return b
 }
+
+func TestCommandOutputs(t *testing.T) {
+	for _, driverName := range driverNames {
+		db := setupTestDB(t, driverName)
+		playbookRunStore := setupPlaybookRunStore(t, db)
+
+		run, err := playbookRunStore.CreatePlaybookRun(NewBuilder(t).ToPlaybookRun())
+		require.NoError(t, err)
+		otherRun, err := playbookRunStore.CreatePlaybookRun(NewBuilder(t).ToPlaybookRun())
+		require.NoError(t, err)
+
+		output := app.CommandOutput{
+			ID:              model.NewId(),
+			PlaybookRunID:   run.ID,
+			ChecklistItemID: model.NewId(),
+			Command:         "/diagnose db",
+			UserID:          model.NewId(),
+			Status:          app.CommandOutputFailed,
+			Output:          "Failed to find slash command /diagnose",
+			Truncated:       true,
+			CreateAt:        model.GetMillis(),
+		}
+		require.NoError(t, playbookRunStore.CreateCommandOutput(output))
+
+		t.Run(driverName+" - get output", func(t *testing.T) {
+			actual, err := playbookRunStore.GetCommandOutput(run.ID, output.ID)
+			require.NoError(t, err)
+			require.Equal(t, output, *actual)
+		})
+
+		t.Run(driverName+" - output of another run", func(t *testing.T) {
+			_, err := playbookRunStore.GetCommandOutput(otherRun.ID, output.ID)
+			require.ErrorIs(t, err, app.ErrNotFound)
+		})
+	}
+}
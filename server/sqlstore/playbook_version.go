@@ -0,0 +1,174 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"github.com/mattermost/mattermost-plugin-playbooks/server/app"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/pkg/errors"
+)
+
+// playbookVersionStore is a sql store for the past versions of playbooks. Use
+// NewPlaybookVersionStore to create it.
+type playbookVersionStore struct {
+	store                 *SQLStore
+	playbookVersionSelect sq.SelectBuilder
+}
+
+type sqlPlaybookVersion struct {
+	PlaybookID   string
+	Version      int64
+	UserID       string
+	CreateAt     int64
+	Hash         string
+	PlaybookJSON json.RawMessage
+}
+
+// Ensure playbookVersionStore implements the app.PlaybookVersionStore interface.
+var _ app.PlaybookVersionStore = (*playbookVersionStore)(nil)
+
+// NewPlaybookVersionStore creates a new store for the past versions of playbooks.
+func NewPlaybookVersionStore(sqlStore *SQLStore) app.PlaybookVersionStore {
+	playbookVersionSelect := sqlStore.builder.
+		Select(
+			"v.PlaybookID",
+			"v.Version",
+			"v.UserID",
+			"v.CreateAt",
+			"v.Hash",
+		).
+		From("IR_PlaybookVersion v")
+
+	return &playbookVersionStore{
+		store:                 sqlStore,
+		playbookVersionSelect: playbookVersionSelect,
+	}
+}
+
+// CreatePlaybookVersion stores version as the next version of its playbook, unless its hash is
+// that of the latest version. Only the maxVersions most recent versions are kept.
+func (s *playbookVersionStore) CreatePlaybookVersion(version app.PlaybookVersion, maxVersions int) error {
+	if version.Playbook == nil {
+		return errors.New("playbook version must have a snapshot")
+	}
+
+	playbookJSON, err := json.Marshal(version.Playbook)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal version of playbook `%s`", version.PlaybookID)
+	}
+
+	tx, err := s.store.db.Beginx()
+	if err != nil {
+		return errors.Wrap(err, "could not begin transaction")
+	}
+	defer s.store.finalizeTransaction(tx)
+
+	var latest sqlPlaybookVersion
+	err = s.store.getBuilder(tx, &latest, s.playbookVersionSelect.
+		Where(sq.Eq{"v.PlaybookID": version.PlaybookID}).
+		OrderBy("v.Version DESC").
+		Limit(1))
+	if err != nil && err != sql.ErrNoRows {
+		return errors.Wrapf(err, "failed to get latest version of playbook `%s`", version.PlaybookID)
+	}
+	if err == nil && latest.Hash == version.Hash {
+		return nil
+	}
+
+	newVersion := latest.Version + 1
+	_, err = s.store.execBuilder(tx, sq.
+		Insert("IR_PlaybookVersion").
+		SetMap(map[string]interface{}{
+			"PlaybookID":   version.PlaybookID,
+			"Version":      newVersion,
+			"UserID":       version.UserID,
+			"CreateAt":     version.CreateAt,
+			"Hash":         version.Hash,
+			"PlaybookJSON": playbookJSON,
+		}))
+	if err != nil {
+		if s.store.db.DriverName() == model.DatabaseDriverMysql {
+			me, ok := err.(*mysql.MySQLError)
+			if ok && me.Number == 1062 {
+				return errors.Wrap(app.ErrDuplicateEntry, err.Error())
+			}
+		} else {
+			pe, ok := err.(*pq.Error)
+			if ok && pe.Code == "23505" {
+				return errors.Wrap(app.ErrDuplicateEntry, err.Error())
+			}
+		}
+
+		return errors.Wrapf(err, "failed to store new version of playbook `%s`", version.PlaybookID)
+	}
+
+	if _, err := s.store.execBuilder(tx, sq.
+		Delete("IR_PlaybookVersion").
+		Where(sq.Eq{"PlaybookID": version.PlaybookID}).
+		Where(sq.LtOrEq{"Version": newVersion - int64(maxVersions)})); err != nil {
+		return errors.Wrapf(err, "failed to delete old versions of playbook `%s`", version.PlaybookID)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "could not commit transaction")
+	}
+
+	return nil
+}
+
+// GetPlaybookVersions returns the versions of a playbook, most recent first, without their
+// snapshot.
+func (s *playbookVersionStore) GetPlaybookVersions(playbookID string) ([]app.PlaybookVersion, error) {
+	var rawVersions []sqlPlaybookVersion
+	err := s.store.selectBuilder(s.store.db, &rawVersions, s.playbookVersionSelect.
+		Where(sq.Eq{"v.PlaybookID": playbookID}).
+		OrderBy("v.Version DESC"))
+	if err != nil && err != sql.ErrNoRows {
+		return nil, errors.Wrapf(err, "failed to get versions of playbook `%s`", playbookID)
+	}
+
+	versions := make([]app.PlaybookVersion, 0, len(rawVersions))
+	for _, rawVersion := range rawVersions {
+		versions = append(versions, toPlaybookVersion(rawVersion))
+	}
+
+	return versions, nil
+}
+
+// GetPlaybookVersion returns a version of a playbook with its snapshot. Returns ErrNotFound if
+// not found.
+func (s *playbookVersionStore) GetPlaybookVersion(playbookID string, version int64) (app.PlaybookVersion, error) {
+	var rawVersion sqlPlaybookVersion
+	err := s.store.getBuilder(s.store.db, &rawVersion, s.playbookVersionSelect.
+		Column("v.PlaybookJSON").
+		Where(sq.Eq{"v.PlaybookID": playbookID, "v.Version": version}))
+	if err == sql.ErrNoRows {
+		return app.PlaybookVersion{}, errors.Wrapf(app.ErrNotFound, "version %d of playbook `%s` does not exist", version, playbookID)
+	} else if err != nil {
+		return app.PlaybookVersion{}, errors.Wrapf(err, "failed to get version %d of playbook `%s`", version, playbookID)
+	}
+
+	var playbook app.Playbook
+	if err := json.Unmarshal(rawVersion.PlaybookJSON, &playbook); err != nil {
+		return app.PlaybookVersion{}, errors.Wrapf(err, "failed to unmarshal version %d of playbook `%s`", version, playbookID)
+	}
+
+	playbookVersion := toPlaybookVersion(rawVersion)
+	playbookVersion.Playbook = &playbook
+
+	return playbookVersion, nil
+}
+
+func toPlaybookVersion(rawVersion sqlPlaybookVersion) app.PlaybookVersion {
+	return app.PlaybookVersion{
+		PlaybookID: rawVersion.PlaybookID,
+		Version:    rawVersion.Version,
+		UserID:     rawVersion.UserID,
+		CreateAt:   rawVersion.CreateAt,
+		Hash:       rawVersion.Hash,
+	}
+}
@@ -0,0 +1,76 @@
+package sqlstore
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-plugin-playbooks/server/app"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlaybookVersions(t *testing.T) {
+	for _, driverName := range driverNames {
+		db := setupTestDB(t, driverName)
+		sqlStore := setupSQLStore(t, db)
+		playbookStore := setupPlaybookStore(t, db)
+		playbookVersionStore := NewPlaybookVersionStore(sqlStore)
+
+		playbookID, err := playbookStore.Create(NewPBBuilder().WithTitle("playbook").ToPlaybook())
+		require.NoError(t, err)
+
+		userID := model.NewId()
+		newVersion := func(title, hash string, createAt int64) app.PlaybookVersion {
+			return app.PlaybookVersion{
+				PlaybookID: playbookID,
+				UserID:     userID,
+				CreateAt:   createAt,
+				Playbook:   &app.Playbook{ID: playbookID, Title: title},
+				Hash:       hash,
+			}
+		}
+
+		versionNumbers := func(t *testing.T) []int64 {
+			versions, err := playbookVersionStore.GetPlaybookVersions(playbookID)
+			require.NoError(t, err)
+
+			numbers := []int64{}
+			for _, version := range versions {
+				require.Nil(t, version.Playbook)
+				numbers = append(numbers, version.Version)
+			}
+			return numbers
+		}
+
+		t.Run("no versions", func(t *testing.T) {
+			require.Empty(t, versionNumbers(t))
+
+			_, err := playbookVersionStore.GetPlaybookVersion(playbookID, 1)
+			require.ErrorIs(t, err, app.ErrNotFound)
+		})
+
+		t.Run("create and get", func(t *testing.T) {
+			require.NoError(t, playbookVersionStore.CreatePlaybookVersion(newVersion("first", "hash1", 1000), 10))
+			require.NoError(t, playbookVersionStore.CreatePlaybookVersion(newVersion("second", "hash2", 2000), 10))
+			require.Equal(t, []int64{2, 1}, versionNumbers(t))
+
+			version, err := playbookVersionStore.GetPlaybookVersion(playbookID, 1)
+			require.NoError(t, err)
+			require.Equal(t, userID, version.UserID)
+			require.EqualValues(t, 1000, version.CreateAt)
+			require.Equal(t, "first", version.Playbook.Title)
+		})
+
+		t.Run("the same content as the latest version is not stored again", func(t *testing.T) {
+			require.NoError(t, playbookVersionStore.CreatePlaybookVersion(newVersion("second", "hash2", 3000), 10))
+			require.Equal(t, []int64{2, 1}, versionNumbers(t))
+		})
+
+		t.Run("only the most recent versions are kept", func(t *testing.T) {
+			require.NoError(t, playbookVersionStore.CreatePlaybookVersion(newVersion("third", "hash3", 4000), 2))
+			require.Equal(t, []int64{3, 2}, versionNumbers(t))
+
+			_, err := playbookVersionStore.GetPlaybookVersion(playbookID, 1)
+			require.ErrorIs(t, err, app.ErrNotFound)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"github.com/mattermost/mattermost-plugin-playbooks/server/app"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/pkg/errors"
+)
+
+// runIdempotencyKeyStore is a sql store for the idempotency keys of run creation requests. Use
+// NewRunIdempotencyKeyStore to create it.
+type runIdempotencyKeyStore struct {
+	store                   *SQLStore
+	runIdempotencyKeySelect sq.SelectBuilder
+}
+
+// sqlRunIdempotencyKey names the key column IdempotencyKey as KEY is reserved in MySQL.
+type sqlRunIdempotencyKey struct {
+	UserID         string
+	IdempotencyKey string
+	PlaybookRunID  string
+	CreateAt       int64
+}
+
+// Ensure runIdempotencyKeyStore implements the app.RunIdempotencyKeyStore interface.
+var _ app.RunIdempotencyKeyStore = (*runIdempotencyKeyStore)(nil)
+
+// NewRunIdempotencyKeyStore creates a new store for the idempotency keys of run creation requests.
+func NewRunIdempotencyKeyStore(sqlStore *SQLStore) app.RunIdempotencyKeyStore {
+	runIdempotencyKeySelect := sqlStore.builder.
+		Select(
+			"k.UserID",
+			"k.IdempotencyKey",
+			"k.PlaybookRunID",
+			"k.CreateAt",
+		).
+		From("IR_RunIdempotencyKey k")
+
+	return &runIdempotencyKeyStore{
+		store:                   sqlStore,
+		runIdempotencyKeySelect: runIdempotencyKeySelect,
+	}
+}
+
+// CreateRunIdempotencyKey stores a new key. Returns ErrDuplicateEntry if the user already has
+// the same key.
+func (s *runIdempotencyKeyStore) CreateRunIdempotencyKey(key app.RunIdempotencyKey) error {
+	_, err := s.store.execBuilder(s.store.db, sq.
+		Insert("IR_RunIdempotencyKey").
+		SetMap(map[string]interface{}{
+			"UserID":         key.UserID,
+			"IdempotencyKey": key.Key,
+			"PlaybookRunID":  key.PlaybookRunID,
+			"CreateAt":       key.CreateAt,
+		}))
+
+	if err != nil {
+		if s.store.db.DriverName() == model.DatabaseDriverMysql {
+			me, ok := err.(*mysql.MySQLError)
+			if ok && me.Number == 1062 {
+				return errors.Wrap(app.ErrDuplicateEntry, err.Error())
+			}
+		} else {
+			pe, ok := err.(*pq.Error)
+			if ok && pe.Code == "23505" {
+				return errors.Wrap(app.ErrDuplicateEntry, err.Error())
+			}
+		}
+
+		return errors.Wrap(err, "failed to store new run idempotency key")
+	}
+
+	return nil
+}
+
+// GetRunIdempotencyKey returns the key of userID. Returns ErrNotFound if not found.
+func (s *runIdempotencyKeyStore) GetRunIdempotencyKey(userID, key string) (app.RunIdempotencyKey, error) {
+	var idempotencyKey sqlRunIdempotencyKey
+	err := s.store.getBuilder(s.store.db, &idempotencyKey, s.runIdempotencyKeySelect.
+		Where(sq.Eq{"k.UserID": userID, "k.IdempotencyKey": key}))
+	if err == sql.ErrNoRows {
+		return app.RunIdempotencyKey{}, errors.Wrapf(app.ErrNotFound, "run idempotency key does not exist for user %q", userID)
+	} else if err != nil {
+		return app.RunIdempotencyKey{}, errors.Wrapf(err, "failed to get run idempotency key for user %q", userID)
+	}
+
+	return app.RunIdempotencyKey{
+		UserID:        idempotencyKey.UserID,
+		Key:           idempotencyKey.IdempotencyKey,
+		PlaybookRunID: idempotencyKey.PlaybookRunID,
+		CreateAt:      idempotencyKey.CreateAt,
+	}, nil
+}
+
+// SetRunIdempotencyKeyRun records the run created for the key of userID.
+func (s *runIdempotencyKeyStore) SetRunIdempotencyKeyRun(userID, key, playbookRunID string) error {
+	if _, err := s.store.execBuilder(s.store.db, sq.
+		Update("IR_RunIdempotencyKey").
+		Set("PlaybookRunID", playbookRunID).
+		Where(sq.Eq{"UserID": userID, "IdempotencyKey": key})); err != nil {
+		return errors.Wrapf(err, "failed to set the run of the idempotency key of user %q", userID)
+	}
+
+	return nil
+}
+
+// DeleteRunIdempotencyKey deletes the key of userID if it was created at createAt, leaving alone
+// a key that was reused since.
+func (s *runIdempotencyKeyStore) DeleteRunIdempotencyKey(userID, key string, createAt int64) error {
+	if _, err := s.store.execBuilder(s.store.db, sq.
+		Delete("IR_RunIdempotencyKey").
+		Where(sq.Eq{"UserID": userID, "IdempotencyKey": key, "CreateAt": createAt})); err != nil {
+		return errors.Wrapf(err, "failed to delete run idempotency key of user %q", userID)
+	}
+
+	return nil
+}
+
+// DeleteRunIdempotencyKeysCreatedBefore deletes the keys created before the given time, in millis.
+func (s *runIdempotencyKeyStore) DeleteRunIdempotencyKeysCreatedBefore(before int64) error {
+	if _, err := s.store.execBuilder(s.store.db, sq.
+		Delete("IR_RunIdempotencyKey").
+		Where(sq.Lt{"CreateAt": before})); err != nil {
+		return errors.Wrap(err, "failed to delete expired run idempotency keys")
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package sqlstore
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-plugin-playbooks/server/app"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunIdempotencyKeys(t *testing.T) {
+	for _, driverName := range driverNames {
+		db := setupTestDB(t, driverName)
+		sqlStore := setupSQLStore(t, db)
+		runIdempotencyKeyStore := NewRunIdempotencyKeyStore(sqlStore)
+
+		userID := model.NewId()
+		key := app.RunIdempotencyKey{UserID: userID, Key: "retry-1", CreateAt: 1000}
+
+		t.Run("create and get", func(t *testing.T) {
+			require.NoError(t, runIdempotencyKeyStore.CreateRunIdempotencyKey(key))
+
+			got, err := runIdempotencyKeyStore.GetRunIdempotencyKey(userID, key.Key)
+			require.NoError(t, err)
+			require.Equal(t, key, got)
+		})
+
+		t.Run("the same key is a duplicate for the same user only", func(t *testing.T) {
+			err := runIdempotencyKeyStore.CreateRunIdempotencyKey(app.RunIdempotencyKey{UserID: userID, Key: key.Key, CreateAt: 2000})
+			require.ErrorIs(t, err, app.ErrDuplicateEntry)
+
+			other := app.RunIdempotencyKey{UserID: model.NewId(), Key: key.Key, CreateAt: 2000}
+			require.NoError(t, runIdempotencyKeyStore.CreateRunIdempotencyKey(other))
+		})
+
+		t.Run("set run", func(t *testing.T) {
+			playbookRunID := model.NewId()
+			require.NoError(t, runIdempotencyKeyStore.SetRunIdempotencyKeyRun(userID, key.Key, playbookRunID))
+
+			got, err := runIdempotencyKeyStore.GetRunIdempotencyKey(userID, key.Key)
+			require.NoError(t, err)
+			require.Equal(t, playbookRunID, got.PlaybookRunID)
+		})
+
+		t.Run("delete only if created at the given time", func(t *testing.T) {
+			require.NoError(t, runIdempotencyKeyStore.DeleteRunIdempotencyKey(userID, key.Key, 999))
+			_, err := runIdempotencyKeyStore.GetRunIdempotencyKey(userID, key.Key)
+			require.NoError(t, err)
+
+			require.NoError(t, runIdempotencyKeyStore.DeleteRunIdempotencyKey(userID, key.Key, key.CreateAt))
+			_, err = runIdempotencyKeyStore.GetRunIdempotencyKey(userID, key.Key)
+			require.ErrorIs(t, err, app.ErrNotFound)
+		})
+
+		t.Run("delete expired keys", func(t *testing.T) {
+			old := app.RunIdempotencyKey{UserID: userID, Key: "old", CreateAt: 100}
+			recent := app.RunIdempotencyKey{UserID: userID, Key: "recent", CreateAt: 5000}
+			require.NoError(t, runIdempotencyKeyStore.CreateRunIdempotencyKey(old))
+			require.NoError(t, runIdempotencyKeyStore.CreateRunIdempotencyKey(recent))
+
+			require.NoError(t, runIdempotencyKeyStore.DeleteRunIdempotencyKeysCreatedBefore(3000))
+
+			_, err := runIdempotencyKeyStore.GetRunIdempotencyKey(userID, old.Key)
+			require.ErrorIs(t, err, app.ErrNotFound)
+			_, err = runIdempotencyKeyStore.GetRunIdempotencyKey(userID, recent.Key)
+			require.NoError(t, err)
+		})
+	}
+}
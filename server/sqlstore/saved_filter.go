@@ -0,0 +1,170 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/mattermost-plugin-playbooks/server/app"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/pkg/errors"
+)
+
+// sqlSavedFilter is the row of a saved filter, whose options are stored as JSON.
+type sqlSavedFilter struct {
+	app.SavedFilter
+	OptionsJSON json.RawMessage
+}
+
+// savedFilterStore is a sql store for the saved filters of the run list. Use NewSavedFilterStore
+// to create it.
+type savedFilterStore struct {
+	store             *SQLStore
+	savedFilterSelect sq.SelectBuilder
+}
+
+// Ensure savedFilterStore implements the app.SavedFilterStore interface.
+var _ app.SavedFilterStore = (*savedFilterStore)(nil)
+
+// NewSavedFilterStore creates a new store for saved filters.
+func NewSavedFilterStore(sqlStore *SQLStore) app.SavedFilterStore {
+	savedFilterSelect := sqlStore.builder.
+		Select(
+			"f.ID",
+			"f.Name",
+			"f.TeamID",
+			"f.UserID",
+			"f.Shared",
+			"f.OptionsJSON",
+			"f.CreateAt",
+			"f.UpdateAt",
+		).
+		From("IR_SavedFilter f")
+
+	return &savedFilterStore{
+		store:             sqlStore,
+		savedFilterSelect: savedFilterSelect,
+	}
+}
+
+// CreateSavedFilter stores a new saved filter and returns its ID.
+func (s *savedFilterStore) CreateSavedFilter(filter app.SavedFilter) (string, error) {
+	if filter.ID != "" {
+		return "", errors.New("ID should be empty")
+	}
+	filter.ID = model.NewId()
+
+	optionsJSON, err := json.Marshal(filter.Options)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to marshal options of saved filter %q", filter.Name)
+	}
+
+	if _, err := s.store.execBuilder(s.store.db, sq.
+		Insert("IR_SavedFilter").
+		SetMap(map[string]interface{}{
+			"ID":          filter.ID,
+			"Name":        filter.Name,
+			"TeamID":      filter.TeamID,
+			"UserID":      filter.UserID,
+			"Shared":      filter.Shared,
+			"OptionsJSON": optionsJSON,
+			"CreateAt":    filter.CreateAt,
+			"UpdateAt":    filter.UpdateAt,
+		})); err != nil {
+		return "", errors.Wrap(err, "failed to store new saved filter")
+	}
+
+	return filter.ID, nil
+}
+
+// GetSavedFilter retrieves a saved filter. Returns ErrNotFound if not found.
+func (s *savedFilterStore) GetSavedFilter(id string) (app.SavedFilter, error) {
+	if !model.IsValidId(id) {
+		return app.SavedFilter{}, errors.New("ID is not valid")
+	}
+
+	var rawFilter sqlSavedFilter
+	err := s.store.getBuilder(s.store.db, &rawFilter, s.savedFilterSelect.Where(sq.Eq{"f.ID": id}))
+	if err == sql.ErrNoRows {
+		return app.SavedFilter{}, errors.Wrapf(app.ErrNotFound, "saved filter does not exist for id %q", id)
+	} else if err != nil {
+		return app.SavedFilter{}, errors.Wrapf(err, "failed to get saved filter by id %q", id)
+	}
+
+	return toSavedFilter(rawFilter)
+}
+
+// GetSavedFiltersForUser retrieves the saved filters of teamID created by userID, and those
+// shared with the team by other users, ordered by name.
+func (s *savedFilterStore) GetSavedFiltersForUser(userID, teamID string) ([]app.SavedFilter, error) {
+	var rawFilters []sqlSavedFilter
+	err := s.store.selectBuilder(s.store.db, &rawFilters, s.savedFilterSelect.
+		Where(sq.Eq{"f.TeamID": teamID}).
+		Where(sq.Or{
+			sq.Eq{"f.UserID": userID},
+			sq.Eq{"f.Shared": true},
+		}).
+		OrderBy("f.Name ASC", "f.CreateAt ASC"))
+	if err != nil && err != sql.ErrNoRows {
+		return nil, errors.Wrapf(err, "failed to get saved filters of team %q for user %q", teamID, userID)
+	}
+
+	filters := make([]app.SavedFilter, 0, len(rawFilters))
+	for _, rawFilter := range rawFilters {
+		filter, err := toSavedFilter(rawFilter)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+
+	return filters, nil
+}
+
+// UpdateSavedFilter updates the name, sharing and options of a saved filter.
+func (s *savedFilterStore) UpdateSavedFilter(filter app.SavedFilter) error {
+	if filter.ID == "" {
+		return errors.New("ID should not be empty")
+	}
+
+	optionsJSON, err := json.Marshal(filter.Options)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal options of saved filter with id '%s'", filter.ID)
+	}
+
+	if _, err := s.store.execBuilder(s.store.db, sq.
+		Update("IR_SavedFilter").
+		SetMap(map[string]interface{}{
+			"Name":        filter.Name,
+			"Shared":      filter.Shared,
+			"OptionsJSON": optionsJSON,
+			"UpdateAt":    filter.UpdateAt,
+		}).
+		Where(sq.Eq{"ID": filter.ID})); err != nil {
+		return errors.Wrapf(err, "failed to update saved filter with id '%s'", filter.ID)
+	}
+
+	return nil
+}
+
+// DeleteSavedFilter removes a saved filter.
+func (s *savedFilterStore) DeleteSavedFilter(id string) error {
+	if _, err := s.store.execBuilder(s.store.db, sq.
+		Delete("IR_SavedFilter").
+		Where(sq.Eq{"ID": id})); err != nil {
+		return errors.Wrapf(err, "failed to delete saved filter with id '%s'", id)
+	}
+
+	return nil
+}
+
+func toSavedFilter(rawFilter sqlSavedFilter) (app.SavedFilter, error) {
+	filter := rawFilter.SavedFilter
+	if len(rawFilter.OptionsJSON) > 0 {
+		if err := json.Unmarshal(rawFilter.OptionsJSON, &filter.Options); err != nil {
+			return app.SavedFilter{}, errors.Wrapf(err, "failed to unmarshal options of saved filter with id '%s'", filter.ID)
+		}
+	}
+
+	return filter, nil
+}
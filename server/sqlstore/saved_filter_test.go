@@ -0,0 +1,105 @@
+package sqlstore
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-plugin-playbooks/server/app"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSavedFilters(t *testing.T) {
+	for _, driverName := range driverNames {
+		db := setupTestDB(t, driverName)
+		sqlStore := setupSQLStore(t, db)
+		savedFilterStore := NewSavedFilterStore(sqlStore)
+
+		teamID := model.NewId()
+		userID := model.NewId()
+		otherUserID := model.NewId()
+
+		newSavedFilter := func(name, userID string, shared bool) app.SavedFilter {
+			return app.SavedFilter{
+				Name:   name,
+				TeamID: teamID,
+				UserID: userID,
+				Shared: shared,
+				Options: app.SavedFilterOptions{
+					OwnerID:   model.NewId(),
+					Statuses:  []string{app.StatusInProgress, app.StatusPaused},
+					Tags:      []string{"outage"},
+					Sort:      app.SortByName,
+					Direction: app.DirectionDesc,
+				},
+				CreateAt: 1000,
+				UpdateAt: 1000,
+			}
+		}
+
+		t.Run("create and get", func(t *testing.T) {
+			expected := newSavedFilter("Outages", userID, false)
+			id, err := savedFilterStore.CreateSavedFilter(expected)
+			require.NoError(t, err)
+			expected.ID = id
+
+			actual, err := savedFilterStore.GetSavedFilter(id)
+			require.NoError(t, err)
+			require.Equal(t, expected, actual)
+
+			_, err = savedFilterStore.GetSavedFilter(model.NewId())
+			require.ErrorIs(t, err, app.ErrNotFound)
+		})
+
+		t.Run("users get their own filters and the shared ones", func(t *testing.T) {
+			_, err := savedFilterStore.CreateSavedFilter(newSavedFilter("Shared", otherUserID, true))
+			require.NoError(t, err)
+			_, err = savedFilterStore.CreateSavedFilter(newSavedFilter("Private", otherUserID, false))
+			require.NoError(t, err)
+			otherTeamFilter := newSavedFilter("Other team", userID, false)
+			otherTeamFilter.TeamID = model.NewId()
+			_, err = savedFilterStore.CreateSavedFilter(otherTeamFilter)
+			require.NoError(t, err)
+
+			names := func(filters []app.SavedFilter) []string {
+				result := []string{}
+				for _, filter := range filters {
+					result = append(result, filter.Name)
+				}
+				return result
+			}
+
+			filters, err := savedFilterStore.GetSavedFiltersForUser(userID, teamID)
+			require.NoError(t, err)
+			require.Equal(t, []string{"Outages", "Shared"}, names(filters))
+
+			filters, err = savedFilterStore.GetSavedFiltersForUser(otherUserID, teamID)
+			require.NoError(t, err)
+			require.Equal(t, []string{"Private", "Shared"}, names(filters))
+
+			filters, err = savedFilterStore.GetSavedFiltersForUser(model.NewId(), model.NewId())
+			require.NoError(t, err)
+			require.Empty(t, filters)
+		})
+
+		t.Run("update and delete", func(t *testing.T) {
+			filter := newSavedFilter("Before", userID, false)
+			id, err := savedFilterStore.CreateSavedFilter(filter)
+			require.NoError(t, err)
+			filter.ID = id
+
+			filter.Name = "After"
+			filter.Shared = true
+			filter.Options = app.SavedFilterOptions{PlaybookID: model.NewId(), Statuses: []string{}, Tags: []string{}}
+			filter.UpdateAt = 2000
+			require.NoError(t, savedFilterStore.UpdateSavedFilter(filter))
+
+			actual, err := savedFilterStore.GetSavedFilter(id)
+			require.NoError(t, err)
+			require.Equal(t, filter, actual)
+
+			require.NoError(t, savedFilterStore.DeleteSavedFilter(id))
+			_, err = savedFilterStore.GetSavedFilter(id)
+			require.ErrorIs(t, err, app.ErrNotFound)
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/mattermost-plugin-playbooks/server/app"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/pkg/errors"
+)
+
+// scheduledRunStore is a sql store for scheduled runs. Use NewScheduledRunStore to create it.
+type scheduledRunStore struct {
+	store              *SQLStore
+	scheduledRunSelect sq.SelectBuilder
+}
+
+// Ensure scheduledRunStore implements the app.ScheduledRunStore interface.
+var _ app.ScheduledRunStore = (*scheduledRunStore)(nil)
+
+// NewScheduledRunStore creates a new store for scheduled runs.
+func NewScheduledRunStore(sqlStore *SQLStore) app.ScheduledRunStore {
+	scheduledRunSelect := sqlStore.builder.
+		Select(
+			"s.ID",
+			"s.PlaybookID",
+			"s.CreatorUserID",
+			"s.Frequency",
+			"s.StartTime",
+			"s.Timezone",
+			"s.Enabled",
+			"s.LastRunAt",
+			"s.NextRunAt",
+			"s.CreateAt",
+			"s.UpdateAt",
+		).
+		From("IR_ScheduledRun s")
+
+	return &scheduledRunStore{
+		store:              sqlStore,
+		scheduledRunSelect: scheduledRunSelect,
+	}
+}
+
+// CreateScheduledRun stores a new schedule and returns its ID.
+func (s *scheduledRunStore) CreateScheduledRun(scheduledRun app.ScheduledRun) (string, error) {
+	if scheduledRun.ID != "" {
+		return "", errors.New("ID should be empty")
+	}
+	scheduledRun.ID = model.NewId()
+
+	if _, err := s.store.execBuilder(s.store.db, sq.
+		Insert("IR_ScheduledRun").
+		SetMap(map[string]interface{}{
+			"ID":            scheduledRun.ID,
+			"PlaybookID":    scheduledRun.PlaybookID,
+			"CreatorUserID": scheduledRun.CreatorUserID,
+			"Frequency":     scheduledRun.Frequency,
+			"StartTime":     scheduledRun.StartTime,
+			"Timezone":      scheduledRun.Timezone,
+			"Enabled":       scheduledRun.Enabled,
+			"LastRunAt":     scheduledRun.LastRunAt,
+			"NextRunAt":     scheduledRun.NextRunAt,
+			"CreateAt":      scheduledRun.CreateAt,
+			"UpdateAt":      scheduledRun.UpdateAt,
+		})); err != nil {
+		return "", errors.Wrap(err, "failed to store new scheduled run")
+	}
+
+	return scheduledRun.ID, nil
+}
+
+// GetScheduledRun retrieves a schedule. Returns ErrNotFound if not found.
+func (s *scheduledRunStore) GetScheduledRun(id string) (app.ScheduledRun, error) {
+	if !model.IsValidId(id) {
+		return app.ScheduledRun{}, errors.New("ID is not valid")
+	}
+
+	var scheduledRun app.ScheduledRun
+	err := s.store.getBuilder(s.store.db, &scheduledRun, s.scheduledRunSelect.Where(sq.Eq{"s.ID": id}))
+	if err == sql.ErrNoRows {
+		return app.ScheduledRun{}, errors.Wrapf(app.ErrNotFound, "scheduled run does not exist for id %q", id)
+	} else if err != nil {
+		return app.ScheduledRun{}, errors.Wrapf(err, "failed to get scheduled run by id %q", id)
+	}
+
+	return scheduledRun, nil
+}
+
+// GetScheduledRunsForPlaybook retrieves all the schedules attached to the given playbook.
+func (s *scheduledRunStore) GetScheduledRunsForPlaybook(playbookID string) ([]app.ScheduledRun, error) {
+	scheduledRuns := []app.ScheduledRun{}
+	err := s.store.selectBuilder(s.store.db, &scheduledRuns, s.scheduledRunSelect.
+		Where(sq.Eq{"s.PlaybookID": playbookID}).
+		OrderBy("s.CreateAt ASC"))
+	if err != nil && err != sql.ErrNoRows {
+		return nil, errors.Wrapf(err, "failed to get scheduled runs for playbook %q", playbookID)
+	}
+
+	return scheduledRuns, nil
+}
+
+// GetDueScheduledRuns retrieves the enabled schedules whose next occurrence is at or before now.
+func (s *scheduledRunStore) GetDueScheduledRuns(now int64) ([]app.ScheduledRun, error) {
+	scheduledRuns := []app.ScheduledRun{}
+	err := s.store.selectBuilder(s.store.db, &scheduledRuns, s.scheduledRunSelect.
+		Where(sq.Eq{"s.Enabled": true}).
+		Where(sq.LtOrEq{"s.NextRunAt": now}).
+		OrderBy("s.NextRunAt ASC"))
+	if err != nil && err != sql.ErrNoRows {
+		return nil, errors.Wrap(err, "failed to get due scheduled runs")
+	}
+
+	return scheduledRuns, nil
+}
+
+// UpdateScheduledRun updates a schedule.
+func (s *scheduledRunStore) UpdateScheduledRun(scheduledRun app.ScheduledRun) error {
+	if scheduledRun.ID == "" {
+		return errors.New("ID should not be empty")
+	}
+
+	if _, err := s.store.execBuilder(s.store.db, sq.
+		Update("IR_ScheduledRun").
+		SetMap(map[string]interface{}{
+			"Frequency": scheduledRun.Frequency,
+			"StartTime": scheduledRun.StartTime,
+			"Timezone":  scheduledRun.Timezone,
+			"Enabled":   scheduledRun.Enabled,
+			"LastRunAt": scheduledRun.LastRunAt,
+			"NextRunAt": scheduledRun.NextRunAt,
+			"UpdateAt":  scheduledRun.UpdateAt,
+		}).
+		Where(sq.Eq{"ID": scheduledRun.ID})); err != nil {
+		return errors.Wrapf(err, "failed to update scheduled run with id '%s'", scheduledRun.ID)
+	}
+
+	return nil
+}
+
+// ClaimScheduledRun moves the next occurrence of a schedule from expectedNextRunAt to nextRunAt
+// only if no one else did it before, returning whether this call claimed the occurrence.
+func (s *scheduledRunStore) ClaimScheduledRun(id string, expectedNextRunAt, nextRunAt, lastRunAt int64) (bool, error) {
+	result, err := s.store.execBuilder(s.store.db, sq.
+		Update("IR_ScheduledRun").
+		SetMap(map[string]interface{}{
+			"LastRunAt": lastRunAt,
+			"NextRunAt": nextRunAt,
+		}).
+		Where(sq.Eq{
+			"ID":        id,
+			"NextRunAt": expectedNextRunAt,
+		}))
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to claim scheduled run with id '%s'", id)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to claim scheduled run with id '%s'", id)
+	}
+
+	return rowsAffected == 1, nil
+}
+
+// DeleteScheduledRun removes a schedule.
+func (s *scheduledRunStore) DeleteScheduledRun(id string) error {
+	if _, err := s.store.execBuilder(s.store.db, sq.
+		Delete("IR_ScheduledRun").
+		Where(sq.Eq{"ID": id})); err != nil {
+		return errors.Wrapf(err, "failed to delete scheduled run with id '%s'", id)
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package sqlstore
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-plugin-playbooks/server/app"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduledRuns(t *testing.T) {
+	for _, driverName := range driverNames {
+		db := setupTestDB(t, driverName)
+		sqlStore := setupSQLStore(t, db)
+		playbookStore := setupPlaybookStore(t, db)
+		scheduledRunStore := NewScheduledRunStore(sqlStore)
+
+		playbookID, err := playbookStore.Create(NewPBBuilder().WithTitle("pb1").WithTeamID(model.NewId()).ToPlaybook())
+		require.NoError(t, err)
+
+		newScheduledRun := func(nextRunAt int64, enabled bool) app.ScheduledRun {
+			return app.ScheduledRun{
+				PlaybookID:    playbookID,
+				CreatorUserID: model.NewId(),
+				Frequency:     app.ScheduledRunWeekly,
+				StartTime:     100,
+				Timezone:      "Europe/Madrid",
+				Enabled:       enabled,
+				NextRunAt:     nextRunAt,
+				CreateAt:      nextRunAt,
+				UpdateAt:      nextRunAt,
+			}
+		}
+
+		t.Run("create and get", func(t *testing.T) {
+			expected := newScheduledRun(1000, true)
+			id, err := scheduledRunStore.CreateScheduledRun(expected)
+			require.NoError(t, err)
+			expected.ID = id
+
+			actual, err := scheduledRunStore.GetScheduledRun(id)
+			require.NoError(t, err)
+			require.Equal(t, expected, actual)
+
+			_, err = scheduledRunStore.GetScheduledRun(model.NewId())
+			require.ErrorIs(t, err, app.ErrNotFound)
+		})
+
+		t.Run("get due scheduled runs ignores disabled and future ones", func(t *testing.T) {
+			dueID, err := scheduledRunStore.CreateScheduledRun(newScheduledRun(2000, true))
+			require.NoError(t, err)
+			_, err = scheduledRunStore.CreateScheduledRun(newScheduledRun(2000, false))
+			require.NoError(t, err)
+			_, err = scheduledRunStore.CreateScheduledRun(newScheduledRun(5000, true))
+			require.NoError(t, err)
+
+			due, err := scheduledRunStore.GetDueScheduledRuns(2500)
+			require.NoError(t, err)
+
+			ids := []string{}
+			for _, scheduledRun := range due {
+				ids = append(ids, scheduledRun.ID)
+			}
+			require.Contains(t, ids, dueID)
+			for _, scheduledRun := range due {
+				require.True(t, scheduledRun.Enabled)
+				require.LessOrEqual(t, scheduledRun.NextRunAt, int64(2500))
+			}
+
+			all, err := scheduledRunStore.GetScheduledRunsForPlaybook(playbookID)
+			require.NoError(t, err)
+			require.Len(t, all, 4)
+		})
+
+		t.Run("an occurrence can only be claimed once", func(t *testing.T) {
+			id, err := scheduledRunStore.CreateScheduledRun(newScheduledRun(3000, true))
+			require.NoError(t, err)
+
+			claimed, err := scheduledRunStore.ClaimScheduledRun(id, 3000, 4000, 3001)
+			require.NoError(t, err)
+			require.True(t, claimed)
+
+			claimed, err = scheduledRunStore.ClaimScheduledRun(id, 3000, 4000, 3002)
+			require.NoError(t, err)
+			require.False(t, claimed)
+
+			actual, err := scheduledRunStore.GetScheduledRun(id)
+			require.NoError(t, err)
+			require.Equal(t, int64(4000), actual.NextRunAt)
+			require.Equal(t, int64(3001), actual.LastRunAt)
+		})
+
+		t.Run("update and delete", func(t *testing.T) {
+			id, err := scheduledRunStore.CreateScheduledRun(newScheduledRun(3000, true))
+			require.NoError(t, err)
+
+			scheduledRun, err := scheduledRunStore.GetScheduledRun(id)
+			require.NoError(t, err)
+			scheduledRun.Enabled = false
+			scheduledRun.Frequency = app.ScheduledRunDaily
+			require.NoError(t, scheduledRunStore.UpdateScheduledRun(scheduledRun))
+
+			actual, err := scheduledRunStore.GetScheduledRun(id)
+			require.NoError(t, err)
+			require.Equal(t, scheduledRun, actual)
+
+			require.NoError(t, scheduledRunStore.DeleteScheduledRun(id))
+			_, err = scheduledRunStore.GetScheduledRun(id)
+			require.ErrorIs(t, err, app.ErrNotFound)
+		})
+	}
+}
@@ -1,9 +1,12 @@
 package sqlstore
 
 import (
+	"database/sql"
+	"encoding/json"
 	"fmt"
 	"math"
 	"reflect"
+	"sort"
 	"strconv"
 	"time"
 
@@ -12,6 +15,7 @@ import (
 	"gopkg.in/guregu/null.v4"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/mattermost-plugin-playbooks/server/app"
 	"github.com/mattermost/mattermost-server/v6/model"
 )
 
@@ -62,6 +66,223 @@ func (s *StatsStore) TotalInProgressPlaybookRuns(filters *StatsFilters) int {
 	return total
 }
 
+// AverageRunDuration returns the average duration, in milliseconds, of the finished runs that
+// match the filters. Time spent paused is not counted. Null if there are no finished runs.
+func (s *StatsStore) AverageRunDuration(filters *StatsFilters) null.Int {
+	query := s.store.builder.
+		Select("FLOOR(AVG(i.EndAt - i.CreateAt - i.PausedDuration))").
+		From("IR_Incident as i").
+		Where(sq.Gt{"i.EndAt": 0})
+
+	query = applyFilters(query, filters)
+
+	var average null.Int
+	if err := s.store.getBuilder(s.store.db, &average, query); err != nil {
+		logrus.WithError(err).Error("failed to query average run duration")
+		return null.Int{}
+	}
+
+	return average
+}
+
+// RunFrequency describes how often runs were started during a time window.
+type RunFrequency struct {
+	// RunsStarted is the number of runs started during the window.
+	RunsStarted int
+
+	// MeanTimeBetweenRuns is the mean interval, in milliseconds, between the creation of
+	// consecutive runs started during the window. Null if fewer than two runs were started.
+	MeanTimeBetweenRuns null.Int
+}
+
+// RunFrequencyBetween returns how often runs matching the filters were started between
+// startMillis (inclusive) and endMillis (exclusive). The window is given in epoch milliseconds,
+// so the result does not depend on the timezone of the server.
+func (s *StatsStore) RunFrequencyBetween(filters *StatsFilters, startMillis, endMillis int64) RunFrequency {
+	query := s.store.builder.
+		Select(
+			"COUNT(i.ID) AS RunsStarted",
+			"COALESCE(MIN(i.CreateAt), 0) AS FirstCreateAt",
+			"COALESCE(MAX(i.CreateAt), 0) AS LastCreateAt",
+		).
+		From("IR_Incident as i").
+		Where(sq.GtOrEq{"i.CreateAt": startMillis}).
+		Where(sq.Lt{"i.CreateAt": endMillis})
+	query = applyFilters(query, filters)
+
+	var result struct {
+		RunsStarted   int
+		FirstCreateAt int64
+		LastCreateAt  int64
+	}
+	if err := s.store.getBuilder(s.store.db, &result, query); err != nil {
+		logrus.WithError(err).Error("failed to query run frequency")
+		return RunFrequency{}
+	}
+
+	return RunFrequency{
+		RunsStarted:         result.RunsStarted,
+		MeanTimeBetweenRuns: meanTimeBetweenRuns(result.RunsStarted, result.FirstCreateAt, result.LastCreateAt),
+	}
+}
+
+// meanTimeBetweenRuns returns the mean interval between count consecutive runs, the first one
+// created at first and the last one at last. The intervals between consecutive runs add up to
+// the time between the first and last run, so there is no need to know the ones in between.
+func meanTimeBetweenRuns(count int, first, last int64) null.Int {
+	if count < 2 {
+		return null.Int{}
+	}
+
+	return null.IntFrom((last - first) / int64(count-1))
+}
+
+// MaxCompletionVelocityPoints is the maximum number of points in a completion velocity series.
+const MaxCompletionVelocityPoints = 1000
+
+const (
+	// MinCompletionVelocityInterval is the shortest interval a completion velocity series can be
+	// requested with.
+	MinCompletionVelocityInterval = time.Hour
+
+	// MaxCompletionVelocityInterval is the longest interval a completion velocity series can be
+	// requested with.
+	MaxCompletionVelocityInterval = 365 * 24 * time.Hour
+)
+
+// completionVelocityIntervals are the intervals picked from, shortest first, when a completion
+// velocity series is requested without one.
+var completionVelocityIntervals = []time.Duration{
+	time.Minute,
+	5 * time.Minute,
+	15 * time.Minute,
+	time.Hour,
+	6 * time.Hour,
+	24 * time.Hour,
+	7 * 24 * time.Hour,
+	30 * 24 * time.Hour,
+}
+
+// completionVelocityDefaultPoints is the number of points the picked intervals aim not to exceed.
+const completionVelocityDefaultPoints = 100
+
+// CompletionVelocityPoint is a point of the completion velocity series of a run.
+type CompletionVelocityPoint struct {
+	// At is the end of the interval, in milliseconds since epoch.
+	At int64 `json:"at"`
+
+	// Completed is the number of checklist items completed from the start of the run up to At.
+	Completed int `json:"completed"`
+}
+
+// RunCompletionVelocity returns the cumulative number of checklist items of a run completed by
+// the end of each interval from the start of the run, the first point being the start itself.
+// The series ends when the run finished, or at now, in millis, for runs still in progress. If
+// interval is 0, the shortest of completionVelocityIntervals keeping the series to about a hundred
+// points is used.
+//
+// Items count as completed when they were last checked, so items reopened and checked again
+// count once, and items reopened since don't count. Skipped items don't count either. Returns
+// ErrNotFound if the run does not exist, and ErrInvalidStatsInterval if the interval is negative
+// or the series would have more than MaxCompletionVelocityPoints points.
+func (s *StatsStore) RunCompletionVelocity(playbookRunID string, interval time.Duration, now int64) ([]CompletionVelocityPoint, error) {
+	if interval < 0 {
+		return nil, errors.Wrap(app.ErrInvalidStatsInterval, "interval must be positive")
+	}
+
+	var run struct {
+		CreateAt       int64
+		EndAt          int64
+		ChecklistsJSON json.RawMessage
+	}
+	err := s.store.getBuilder(s.store.db, &run, s.store.builder.
+		Select("i.CreateAt", "i.EndAt", "i.ChecklistsJSON").
+		From("IR_Incident as i").
+		Where(sq.Eq{"i.ID": playbookRunID}))
+	if err == sql.ErrNoRows {
+		return nil, errors.Wrapf(app.ErrNotFound, "playbook run with id '%s' does not exist", playbookRunID)
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "failed to get playbook run with id '%s'", playbookRunID)
+	}
+
+	var checklists []app.Checklist
+	if err = json.Unmarshal(run.ChecklistsJSON, &checklists); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal checklists json for playbook run id: '%s'", playbookRunID)
+	}
+
+	completedAt := []int64{}
+	for _, checklist := range checklists {
+		for _, item := range checklist.Items {
+			if item.State == app.ChecklistItemStateClosed {
+				completedAt = append(completedAt, item.StateModified)
+			}
+		}
+	}
+
+	end := run.EndAt
+	if end == 0 {
+		end = now
+	}
+	if interval == 0 {
+		interval = defaultCompletionVelocityInterval(end - run.CreateAt)
+	}
+
+	return completionVelocity(run.CreateAt, end, interval.Milliseconds(), completedAt)
+}
+
+// defaultCompletionVelocityInterval returns the shortest of completionVelocityIntervals that
+// splits the duration, in millis, in at most completionVelocityDefaultPoints intervals, or the
+// longest one for longer durations.
+func defaultCompletionVelocityInterval(duration int64) time.Duration {
+	for _, interval := range completionVelocityIntervals {
+		if duration <= interval.Milliseconds()*completionVelocityDefaultPoints {
+			return interval
+		}
+	}
+
+	return completionVelocityIntervals[len(completionVelocityIntervals)-1]
+}
+
+// completionVelocity buckets the completion times, in millis, by interval from start up to end,
+// returning the cumulative count at the end of each interval. The last interval is cut short at
+// end. Completions before start count from the start, and completions after end at the end.
+func completionVelocity(start, end, interval int64, completedAt []int64) ([]CompletionVelocityPoint, error) {
+	if interval <= 0 {
+		return nil, errors.Wrapf(app.ErrInvalidStatsInterval, "interval must be positive, got %d ms", interval)
+	}
+	if end < start {
+		end = start
+	}
+
+	numPoints := (end-start+interval-1)/interval + 1
+	if numPoints > MaxCompletionVelocityPoints {
+		return nil, errors.Wrapf(app.ErrInvalidStatsInterval, "interval is too short: the series would have %d points, more than the maximum of %d", numPoints, MaxCompletionVelocityPoints)
+	}
+
+	sorted := make([]int64, len(completedAt))
+	copy(sorted, completedAt)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	points := make([]CompletionVelocityPoint, 0, numPoints)
+	completed := 0
+	for at := start; ; at += interval {
+		if at > end {
+			at = end
+		}
+		for completed < len(sorted) && sorted[completed] <= at {
+			completed++
+		}
+		if at == end {
+			completed = len(sorted)
+		}
+		points = append(points, CompletionVelocityPoint{At: at, Completed: completed})
+
+		if at == end {
+			return points, nil
+		}
+	}
+}
+
 // TotalPlaybooks returns the number of playbooks in the server
 func (s *StatsStore) TotalPlaybooks() (int, error) {
 	query := s.store.builder.
@@ -332,6 +553,71 @@ func (s *StatsStore) ActiveParticipantsPerDayLastXDays(x int, filters *StatsFilt
 	return counts, daysAsTimes
 }
 
+// metricBreachedCondition holds for the values of IR_Metric AS m that miss the target of their
+// IR_MetricConfig AS mc. Metrics without a target are never breached.
+var metricBreachedCondition = fmt.Sprintf(
+	"mc.Target IS NOT NULL AND m.Value IS NOT NULL AND ((mc.Direction = '%s' AND m.Value < mc.Target) OR (mc.Direction <> '%s' AND m.Value > mc.Target))",
+	app.MetricDirectionHigherIsBetter, app.MetricDirectionHigherIsBetter,
+)
+
+// metricBreachedColumn selects metricBreachedCondition as a boolean Breached column.
+var metricBreachedColumn = "CASE WHEN " + metricBreachedCondition + " THEN TRUE ELSE FALSE END AS Breached"
+
+// MetricBreachingRuns returns, for each metric of a specific playbook, the number of runs whose
+// published value breaches the metric's target.
+// Returns empty list when Playbook doesn't have configured metrics
+// If some metrics have no target, the corresponding element will be nil in the resulting slice
+func (s *StatsStore) MetricBreachingRuns(filters StatsFilters) []null.Int {
+	query := s.store.builder.
+		Select("mc.ID AS ID", "mc.Target AS Target", "COUNT(m.IncidentID) AS Count").
+		From("IR_MetricConfig AS mc").
+		LeftJoin("IR_Metric AS m ON (m.MetricConfigID = mc.ID AND m.Published = ? AND "+metricBreachedCondition+")", true).
+		Where(sq.Eq{"mc.PlaybookID": filters.PlaybookID}).
+		Where(sq.Eq{"mc.DeleteAt": 0}).
+		GroupBy("mc.ID", "mc.Target", "mc.Ordering").
+		OrderBy("mc.Ordering ASC")
+
+	var counts []struct {
+		ID     string
+		Target null.Int
+		Count  int64
+	}
+	if err := s.store.selectBuilder(s.store.db, &counts, query); err != nil {
+		logrus.WithError(err).WithField("playbook_id", filters.PlaybookID).Error("failed to query metric breaching runs")
+		return []null.Int{}
+	}
+
+	breachingRuns := make([]null.Int, len(counts))
+	for i, c := range counts {
+		if c.Target.Valid {
+			breachingRuns[i] = null.IntFrom(c.Count)
+		}
+	}
+
+	return breachingRuns
+}
+
+// RunsBreachingMetricTargets returns the number of runs of a specific playbook with a published
+// value breaching the target of at least one of the playbook's metrics.
+func (s *StatsStore) RunsBreachingMetricTargets(filters StatsFilters) int {
+	query := s.store.builder.
+		Select("COUNT(DISTINCT m.IncidentID)").
+		From("IR_Metric AS m").
+		InnerJoin("IR_MetricConfig AS mc ON m.MetricConfigID = mc.ID").
+		Where(sq.Eq{"mc.PlaybookID": filters.PlaybookID}).
+		Where(sq.Eq{"mc.DeleteAt": 0}).
+		Where(sq.Eq{"m.Published": true}).
+		Where(metricBreachedCondition)
+
+	var count int
+	if err := s.store.getBuilder(s.store.db, &count, query); err != nil {
+		logrus.WithError(err).WithField("playbook_id", filters.PlaybookID).Error("failed to count runs breaching metric targets")
+		return 0
+	}
+
+	return count
+}
+
 // MetricOverallAverage for a specific playbook returns a list that contains an average value for each metric.
 // Only published metrics values are included.
 // Returns empty list when Playbook doesn't have configured metrics
@@ -3,6 +3,7 @@ package sqlstore
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/jmoiron/sqlx"
@@ -679,6 +680,69 @@ func TestMetricsStats(t *testing.T) {
 			require.Equal(t, [][]int64{{103, 200}, {9, 3, 3, 7}}, actualRollingValues)
 			require.Equal(t, [][]int64{{103, 200}, {2, 11}}, actualRange)
 		})
+
+		t.Run("metric targets", func(t *testing.T) {
+			playbook := NewPBBuilder().
+				WithTitle("pb1").
+				WithTeamID(teamID).
+				WithCreateAt(500).
+				ToPlaybook()
+			playbook.Metrics = []app.PlaybookMetricConfig{
+				{Title: "lower is better", Type: app.MetricTypeDuration, Target: null.IntFrom(5)},
+				{Title: "higher is better", Type: app.MetricTypeInteger, Target: null.IntFrom(5), Direction: app.MetricDirectionHigherIsBetter},
+				{Title: "no target", Type: app.MetricTypeCurrency},
+			}
+
+			playbookID, err := playbookStore.Create(playbook)
+			require.NoError(t, err)
+			playbook, err = playbookStore.Get(playbookID)
+			require.NoError(t, err)
+
+			metricsData := createMetricsData(playbook.Metrics, [][]int64{{2, 3, 0}, {9, 8, 1}, {11, 1, 2}, {5, 5, 3}})
+			createRunsWithMetrics(t, playbookRunStore, store, playbookID, metricsData, true, &publishTime)
+			createRunsWithMetrics(t, playbookRunStore, store, playbookID, metricsData[1:2], false, &publishTime)
+
+			filters := StatsFilters{
+				PlaybookID: playbookID,
+			}
+
+			require.Equal(t, []null.Int{null.IntFrom(2), null.IntFrom(2), null.NewInt(0, false)}, statsStore.MetricBreachingRuns(filters))
+			require.Equal(t, 3, statsStore.RunsBreachingMetricTargets(filters))
+
+			runs, err := playbookRunStore.GetPlaybookRuns(app.RequesterInfo{UserID: "testID", IsAdmin: true}, app.PlaybookRunFilterOptions{
+				PlaybookID: playbookID,
+				Page:       0,
+				PerPage:    10,
+			})
+			require.NoError(t, err)
+			require.Len(t, runs.Items, 5)
+			breached := [][]bool{}
+			for _, run := range runs.Items {
+				runBreached := []bool{}
+				for _, md := range run.MetricsData {
+					runBreached = append(runBreached, md.Breached)
+				}
+				breached = append(breached, runBreached)
+			}
+			require.ElementsMatch(t, [][]bool{
+				{false, true, false},
+				{true, false, false},
+				{true, true, false},
+				{false, false, false},
+				{true, false, false},
+			}, breached)
+		})
+
+		t.Run("breaching runs without metrics configured", func(t *testing.T) {
+			playbookID, err := playbookStore.Create(NewPBBuilder().WithTitle("pb1").WithTeamID(teamID).ToPlaybook())
+			require.NoError(t, err)
+
+			filters := StatsFilters{
+				PlaybookID: playbookID,
+			}
+			require.Equal(t, []null.Int{}, statsStore.MetricBreachingRuns(filters))
+			require.Equal(t, 0, statsStore.RunsBreachingMetricTargets(filters))
+		})
 	}
 }
 
@@ -728,3 +792,165 @@ func createMetricsData(metricsConfigs []app.PlaybookMetricConfig, data [][]int64
 	}
 	return metricsData
 }
+
+func TestRunFrequencyBetween(t *testing.T) {
+	for _, driverName := range driverNames {
+		db := setupTestDB(t, driverName)
+		playbookRunStore := setupPlaybookRunStore(t, db)
+		statsStore := setupStatsStore(t, db)
+		store := setupSQLStore(t, db)
+
+		for _, createAt := range []int64{1000, 4000, 6000, 10000} {
+			run, err := playbookRunStore.CreatePlaybookRun(NewBuilder(t).
+				WithCreateAt(createAt).
+				WithPlaybookID("playbook1").
+				ToPlaybookRun())
+			require.NoError(t, err)
+			createPlaybookRunChannel(t, store, run)
+		}
+
+		run, err := playbookRunStore.CreatePlaybookRun(NewBuilder(t).
+			WithCreateAt(5000).
+			WithPlaybookID("playbook2").
+			ToPlaybookRun())
+		require.NoError(t, err)
+		createPlaybookRunChannel(t, store, run)
+
+		filters := &StatsFilters{PlaybookID: "playbook1"}
+
+		testCases := []struct {
+			name     string
+			start    int64
+			end      int64
+			expected RunFrequency
+		}{
+			{"no runs", 20000, 30000, RunFrequency{RunsStarted: 0, MeanTimeBetweenRuns: null.Int{}}},
+			{"one run", 0, 2000, RunFrequency{RunsStarted: 1, MeanTimeBetweenRuns: null.Int{}}},
+			{"all runs", 0, 20000, RunFrequency{RunsStarted: 4, MeanTimeBetweenRuns: null.IntFrom(3000)}},
+			{"end is exclusive", 1000, 10000, RunFrequency{RunsStarted: 3, MeanTimeBetweenRuns: null.IntFrom(2500)}},
+		}
+
+		for _, tc := range testCases {
+			t.Run(driverName+" - "+tc.name, func(t *testing.T) {
+				require.Equal(t, tc.expected, statsStore.RunFrequencyBetween(filters, tc.start, tc.end))
+			})
+		}
+	}
+}
+
+func TestMeanTimeBetweenRuns(t *testing.T) {
+	assert.Equal(t, null.Int{}, meanTimeBetweenRuns(0, 0, 0))
+	assert.Equal(t, null.Int{}, meanTimeBetweenRuns(1, 1000, 1000))
+	assert.Equal(t, null.IntFrom(0), meanTimeBetweenRuns(2, 1000, 1000))
+	assert.Equal(t, null.IntFrom(1500), meanTimeBetweenRuns(3, 1000, 4000))
+}
+
+func TestRunCompletionVelocity(t *testing.T) {
+	for _, driverName := range driverNames {
+		db := setupTestDB(t, driverName)
+		playbookRunStore := setupPlaybookRunStore(t, db)
+		statsStore := setupStatsStore(t, db)
+		store := setupSQLStore(t, db)
+
+		playbookRun := NewBuilder(t).WithCreateAt(1000).ToPlaybookRun()
+		playbookRun.Checklists = []app.Checklist{
+			{
+				Title: "checklist",
+				Items: []app.ChecklistItem{
+					{ID: "late", Title: "late", State: app.ChecklistItemStateClosed, StateModified: 3500},
+					{ID: "early", Title: "early", State: app.ChecklistItemStateClosed, StateModified: 1200},
+					{ID: "reopened", Title: "reopened", State: app.ChecklistItemStateOpen, StateModified: 2500},
+					{ID: "skipped", Title: "skipped", State: app.ChecklistItemStateSkipped, StateModified: 1500},
+				},
+			},
+			{
+				Title: "other checklist",
+				Items: []app.ChecklistItem{
+					{ID: "middle", Title: "middle", State: app.ChecklistItemStateClosed, StateModified: 2000},
+				},
+			},
+		}
+		playbookRun, err := playbookRunStore.CreatePlaybookRun(playbookRun)
+		require.NoError(t, err)
+		createPlaybookRunChannel(t, store, playbookRun)
+
+		t.Run(driverName+" - in progress run ends at now", func(t *testing.T) {
+			points, err := statsStore.RunCompletionVelocity(playbookRun.ID, time.Second, 4200)
+			require.NoError(t, err)
+			require.Equal(t, []CompletionVelocityPoint{
+				{At: 1000, Completed: 0},
+				{At: 2000, Completed: 2},
+				{At: 3000, Completed: 2},
+				{At: 4000, Completed: 3},
+				{At: 4200, Completed: 3},
+			}, points)
+		})
+
+		t.Run(driverName+" - interval too short", func(t *testing.T) {
+			_, err := statsStore.RunCompletionVelocity(playbookRun.ID, time.Millisecond, 1000+MaxCompletionVelocityPoints)
+			require.ErrorIs(t, err, app.ErrInvalidStatsInterval)
+		})
+
+		t.Run(driverName+" - unknown run", func(t *testing.T) {
+			_, err := statsStore.RunCompletionVelocity(model.NewId(), time.Second, 4200)
+			require.ErrorIs(t, err, app.ErrNotFound)
+		})
+	}
+}
+
+func TestCompletionVelocity(t *testing.T) {
+	t.Run("out of order completions", func(t *testing.T) {
+		points, err := completionVelocity(0, 300, 100, []int64{250, 50, 150, 100, 60})
+		require.NoError(t, err)
+		require.Equal(t, []CompletionVelocityPoint{
+			{At: 0, Completed: 0},
+			{At: 100, Completed: 3},
+			{At: 200, Completed: 4},
+			{At: 300, Completed: 5},
+		}, points)
+	})
+
+	t.Run("last interval is cut short at the end", func(t *testing.T) {
+		points, err := completionVelocity(0, 250, 100, []int64{220})
+		require.NoError(t, err)
+		require.Equal(t, []CompletionVelocityPoint{
+			{At: 0, Completed: 0},
+			{At: 100, Completed: 0},
+			{At: 200, Completed: 0},
+			{At: 250, Completed: 1},
+		}, points)
+	})
+
+	t.Run("completions outside of the run", func(t *testing.T) {
+		points, err := completionVelocity(1000, 1100, 100, []int64{500, 2000})
+		require.NoError(t, err)
+		require.Equal(t, []CompletionVelocityPoint{
+			{At: 1000, Completed: 1},
+			{At: 1100, Completed: 2},
+		}, points)
+	})
+
+	t.Run("run without duration", func(t *testing.T) {
+		points, err := completionVelocity(1000, 1000, 100, nil)
+		require.NoError(t, err)
+		require.Equal(t, []CompletionVelocityPoint{{At: 1000, Completed: 0}}, points)
+	})
+
+	t.Run("too many points", func(t *testing.T) {
+		_, err := completionVelocity(0, MaxCompletionVelocityPoints, 1, nil)
+		require.ErrorIs(t, err, app.ErrInvalidStatsInterval)
+	})
+
+	t.Run("zero interval", func(t *testing.T) {
+		_, err := completionVelocity(0, 100, 0, nil)
+		require.ErrorIs(t, err, app.ErrInvalidStatsInterval)
+	})
+}
+
+func TestDefaultCompletionVelocityInterval(t *testing.T) {
+	assert.Equal(t, time.Minute, defaultCompletionVelocityInterval(0))
+	assert.Equal(t, time.Minute, defaultCompletionVelocityInterval(100*time.Minute.Milliseconds()))
+	assert.Equal(t, 5*time.Minute, defaultCompletionVelocityInterval(101*time.Minute.Milliseconds()))
+	assert.Equal(t, time.Hour, defaultCompletionVelocityInterval(3*24*time.Hour.Milliseconds()))
+	assert.Equal(t, 30*24*time.Hour, defaultCompletionVelocityInterval(100*365*24*time.Hour.Milliseconds()))
+}
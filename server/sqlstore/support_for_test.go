@@ -775,10 +775,10 @@ func makeAdmin(t *testing.T, store *SQLStore, user userInfo) {
 func savePosts(t testing.TB, store *SQLStore, posts []*model.Post) {
 	t.Helper()
 
-	insertBuilder := store.builder.Insert("Posts").Columns("Id", "CreateAt", "DeleteAt")
+	insertBuilder := store.builder.Insert("Posts").Columns("Id", "CreateAt", "DeleteAt", "Message")
 
 	for _, p := range posts {
-		insertBuilder = insertBuilder.Values(p.Id, p.CreateAt, p.DeleteAt)
+		insertBuilder = insertBuilder.Values(p.Id, p.CreateAt, p.DeleteAt, p.Message)
 	}
 
 	_, err := store.execBuilder(store.db, insertBuilder)
@@ -0,0 +1,131 @@
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/mattermost/mattermost-plugin-playbooks/server/app"
+	"github.com/pkg/errors"
+)
+
+// webhookDeliveryStore is a sql store for webhook deliveries. Use NewWebhookDeliveryStore to create it.
+type webhookDeliveryStore struct {
+	store                 *SQLStore
+	webhookDeliverySelect sq.SelectBuilder
+}
+
+// Ensure webhookDeliveryStore implements the app.WebhookDeliveryStore interface.
+var _ app.WebhookDeliveryStore = (*webhookDeliveryStore)(nil)
+
+// NewWebhookDeliveryStore creates a new store for webhook deliveries.
+func NewWebhookDeliveryStore(sqlStore *SQLStore) app.WebhookDeliveryStore {
+	webhookDeliverySelect := sqlStore.builder.
+		Select(
+			"d.ID",
+			"d.PlaybookRunID",
+			"d.URL",
+			"d.Payload",
+			"d.Status",
+			"d.Attempts",
+			"d.LastError",
+			"d.NextAttemptAt",
+			"d.CreateAt",
+			"d.UpdateAt",
+		).
+		From("IR_WebhookDelivery d")
+
+	return &webhookDeliveryStore{
+		store:                 sqlStore,
+		webhookDeliverySelect: webhookDeliverySelect,
+	}
+}
+
+// CreateWebhookDelivery stores a new delivery.
+func (s *webhookDeliveryStore) CreateWebhookDelivery(delivery app.WebhookDelivery) error {
+	if delivery.ID == "" {
+		return errors.New("ID should not be empty")
+	}
+
+	if _, err := s.store.execBuilder(s.store.db, sq.
+		Insert("IR_WebhookDelivery").
+		SetMap(map[string]interface{}{
+			"ID":            delivery.ID,
+			"PlaybookRunID": delivery.PlaybookRunID,
+			"URL":           delivery.URL,
+			"Payload":       delivery.Payload,
+			"Status":        delivery.Status,
+			"Attempts":      delivery.Attempts,
+			"LastError":     delivery.LastError,
+			"NextAttemptAt": delivery.NextAttemptAt,
+			"CreateAt":      delivery.CreateAt,
+			"UpdateAt":      delivery.UpdateAt,
+		})); err != nil {
+		return errors.Wrap(err, "failed to store new webhook delivery")
+	}
+
+	return nil
+}
+
+// UpdateWebhookDelivery updates the status, attempts, last error and next attempt of a delivery.
+func (s *webhookDeliveryStore) UpdateWebhookDelivery(delivery app.WebhookDelivery) error {
+	if delivery.ID == "" {
+		return errors.New("ID should not be empty")
+	}
+
+	if _, err := s.store.execBuilder(s.store.db, sq.
+		Update("IR_WebhookDelivery").
+		SetMap(map[string]interface{}{
+			"Status":        delivery.Status,
+			"Attempts":      delivery.Attempts,
+			"LastError":     delivery.LastError,
+			"NextAttemptAt": delivery.NextAttemptAt,
+			"UpdateAt":      delivery.UpdateAt,
+		}).
+		Where(sq.Eq{"ID": delivery.ID})); err != nil {
+		return errors.Wrapf(err, "failed to update webhook delivery with id '%s'", delivery.ID)
+	}
+
+	return nil
+}
+
+// GetWebhookDeliveries returns the deliveries selected by options, newest first.
+func (s *webhookDeliveryStore) GetWebhookDeliveries(options app.WebhookDeliveryFilterOptions) ([]app.WebhookDelivery, error) {
+	query := s.webhookDeliverySelect.OrderBy("d.CreateAt DESC")
+
+	if len(options.Statuses) != 0 {
+		query = query.Where(sq.Eq{"d.Status": options.Statuses})
+	}
+
+	if options.PerPage > 0 {
+		page := options.Page
+		if page < 0 {
+			page = 0
+		}
+		query = query.
+			Offset(uint64(page * options.PerPage)).
+			Limit(uint64(options.PerPage))
+	}
+
+	deliveries := []app.WebhookDelivery{}
+	err := s.store.selectBuilder(s.store.db, &deliveries, query)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, errors.Wrap(err, "failed to get webhook deliveries")
+	}
+
+	return deliveries, nil
+}
+
+// GetDueWebhookDeliveries returns up to limit pending deliveries whose next attempt is at or before now.
+func (s *webhookDeliveryStore) GetDueWebhookDeliveries(now int64, limit int) ([]app.WebhookDelivery, error) {
+	deliveries := []app.WebhookDelivery{}
+	err := s.store.selectBuilder(s.store.db, &deliveries, s.webhookDeliverySelect.
+		Where(sq.Eq{"d.Status": app.WebhookDeliveryPending}).
+		Where(sq.LtOrEq{"d.NextAttemptAt": now}).
+		OrderBy("d.NextAttemptAt ASC").
+		Limit(uint64(limit)))
+	if err != nil && err != sql.ErrNoRows {
+		return nil, errors.Wrap(err, "failed to get due webhook deliveries")
+	}
+
+	return deliveries, nil
+}
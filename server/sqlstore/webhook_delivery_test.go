@@ -0,0 +1,79 @@
+package sqlstore
+
+import (
+	"testing"
+
+	"github.com/mattermost/mattermost-plugin-playbooks/server/app"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookDeliveries(t *testing.T) {
+	for _, driverName := range driverNames {
+		db := setupTestDB(t, driverName)
+		sqlStore := setupSQLStore(t, db)
+		webhookDeliveryStore := NewWebhookDeliveryStore(sqlStore)
+
+		newDelivery := func(status app.WebhookDeliveryStatus, nextAttemptAt, createAt int64) app.WebhookDelivery {
+			return app.WebhookDelivery{
+				ID:            model.NewId(),
+				PlaybookRunID: model.NewId(),
+				URL:           "https://example.com/hook",
+				Payload:       `{"name":"run"}`,
+				Status:        status,
+				Attempts:      1,
+				LastError:     "response code is 503",
+				NextAttemptAt: nextAttemptAt,
+				CreateAt:      createAt,
+				UpdateAt:      createAt,
+			}
+		}
+
+		pendingDue := newDelivery(app.WebhookDeliveryPending, 1000, 100)
+		pendingLater := newDelivery(app.WebhookDeliveryPending, 5000, 200)
+		failed := newDelivery(app.WebhookDeliveryFailed, 0, 300)
+		for _, delivery := range []app.WebhookDelivery{pendingDue, pendingLater, failed} {
+			require.NoError(t, webhookDeliveryStore.CreateWebhookDelivery(delivery))
+		}
+
+		t.Run("get deliveries, newest first", func(t *testing.T) {
+			deliveries, err := webhookDeliveryStore.GetWebhookDeliveries(app.WebhookDeliveryFilterOptions{})
+			require.NoError(t, err)
+			require.Equal(t, []app.WebhookDelivery{failed, pendingLater, pendingDue}, deliveries)
+		})
+
+		t.Run("filter by status and paginate", func(t *testing.T) {
+			deliveries, err := webhookDeliveryStore.GetWebhookDeliveries(app.WebhookDeliveryFilterOptions{
+				Statuses: []app.WebhookDeliveryStatus{app.WebhookDeliveryPending},
+				Page:     1,
+				PerPage:  1,
+			})
+			require.NoError(t, err)
+			require.Equal(t, []app.WebhookDelivery{pendingDue}, deliveries)
+		})
+
+		t.Run("get due deliveries only returns pending ones", func(t *testing.T) {
+			deliveries, err := webhookDeliveryStore.GetDueWebhookDeliveries(2000, 10)
+			require.NoError(t, err)
+			require.Equal(t, []app.WebhookDelivery{pendingDue}, deliveries)
+		})
+
+		t.Run("update", func(t *testing.T) {
+			pendingDue.Status = app.WebhookDeliveryDelivered
+			pendingDue.Attempts = 2
+			pendingDue.LastError = ""
+			pendingDue.UpdateAt = 2000
+			require.NoError(t, webhookDeliveryStore.UpdateWebhookDelivery(pendingDue))
+
+			deliveries, err := webhookDeliveryStore.GetWebhookDeliveries(app.WebhookDeliveryFilterOptions{
+				Statuses: []app.WebhookDeliveryStatus{app.WebhookDeliveryDelivered},
+			})
+			require.NoError(t, err)
+			require.Equal(t, []app.WebhookDelivery{pendingDue}, deliveries)
+
+			deliveries, err = webhookDeliveryStore.GetDueWebhookDeliveries(10000, 10)
+			require.NoError(t, err)
+			require.Equal(t, []app.WebhookDelivery{pendingLater}, deliveries)
+		})
+	}
+}